@@ -597,6 +597,8 @@ func TestMRFieldsRoundTrip(t *testing.T) {
 		Rig:         "gastown",
 		MergeCommit: "abc123def789",
 		CloseReason: "merged",
+		CachedGates: "lint,test",
+		Traceparent: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
 	}
 
 	// Format to string
@@ -914,6 +916,7 @@ func TestAttachmentFieldsRoundTrip(t *testing.T) {
 	original := &AttachmentFields{
 		AttachedMolecule: "mol-roundtrip",
 		AttachedAt:       "2025-12-21T15:30:00Z",
+		Traceparent:      "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
 	}
 
 	// Format to string
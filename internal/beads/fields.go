@@ -27,6 +27,7 @@ type AttachmentFields struct {
 	ConvoyID         string // Convoy bead ID tracking this issue (e.g., "hq-cv-abc")
 	MergeStrategy    string // Convoy merge strategy: "direct", "mr", "local", or "" (default = mr)
 	ConvoyOwned      bool   // If true, convoy has gt:owned label (caller-managed lifecycle)
+	Traceparent      string // W3C traceparent of the sling that dispatched this work
 }
 
 // ParseAttachmentFields extracts attachment fields from an issue's description.
@@ -86,6 +87,9 @@ func ParseAttachmentFields(issue *Issue) *AttachmentFields {
 		case "convoy_owned", "convoy-owned", "convoyowned":
 			fields.ConvoyOwned = strings.ToLower(value) == "true"
 			hasFields = true
+		case "traceparent":
+			fields.Traceparent = value
+			hasFields = true
 		}
 	}
 
@@ -131,6 +135,9 @@ func FormatAttachmentFields(fields *AttachmentFields) string {
 	if fields.ConvoyOwned {
 		lines = append(lines, "convoy_owned: true")
 	}
+	if fields.Traceparent != "" {
+		lines = append(lines, "traceparent: "+fields.Traceparent)
+	}
 
 	return strings.Join(lines, "\n")
 }
@@ -167,6 +174,7 @@ func SetAttachmentFields(issue *Issue, fields *AttachmentFields) string {
 		"convoy_owned":      true,
 		"convoy-owned":      true,
 		"convoyowned":       true,
+		"traceparent":       true,
 	}
 
 	// Collect non-attachment lines from existing description
@@ -237,6 +245,12 @@ type MRFields struct {
 	// Convoy tracking (for priority scoring - convoy starvation prevention)
 	ConvoyID        string // Parent convoy ID if part of a convoy
 	ConvoyCreatedAt string // Convoy creation time (ISO 8601) for starvation prevention
+
+	// Gate cache (refinery reused passing gate results instead of rerunning)
+	CachedGates string // Comma-separated gate names whose results were reused
+
+	// Tracing (links the merge back to the sling that started the work)
+	Traceparent string // W3C traceparent of the gt done that submitted this MR
 }
 
 // ParseMRFields extracts structured merge-request fields from an issue's description.
@@ -311,6 +325,12 @@ func ParseMRFields(issue *Issue) *MRFields {
 		case "convoy_created_at", "convoy-created-at", "convoycreatedat":
 			fields.ConvoyCreatedAt = value
 			hasFields = true
+		case "cached_gates", "cached-gates", "cachedgates":
+			fields.CachedGates = value
+			hasFields = true
+		case "traceparent":
+			fields.Traceparent = value
+			hasFields = true
 		}
 	}
 
@@ -375,6 +395,12 @@ func FormatMRFields(fields *MRFields) string {
 	if fields.ConvoyCreatedAt != "" {
 		lines = append(lines, "convoy_created_at: "+fields.ConvoyCreatedAt)
 	}
+	if fields.CachedGates != "" {
+		lines = append(lines, "cached_gates: "+fields.CachedGates)
+	}
+	if fields.Traceparent != "" {
+		lines = append(lines, "traceparent: "+fields.Traceparent)
+	}
 
 	return strings.Join(lines, "\n")
 }
@@ -421,6 +447,10 @@ func SetMRFields(issue *Issue, fields *MRFields) string {
 		"convoy_created_at":  true,
 		"convoy-created-at":  true,
 		"convoycreatedat":    true,
+		"cached_gates":       true,
+		"cached-gates":       true,
+		"cachedgates":        true,
+		"traceparent":        true,
 	}
 
 	// Collect non-MR lines from existing description
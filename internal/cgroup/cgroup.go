@@ -0,0 +1,35 @@
+// Package cgroup places agent sessions in per-session cgroup v2 groups with
+// optional CPU, memory, and process limits, and reads back their usage.
+//
+// Session cgroups live under a "gastown" group inside a subtree the current
+// user may write to: $GT_CGROUP_PARENT if set, else the systemd user manager's
+// delegated subtree (user@<uid>.service), else the cgroup root when running as
+// root. Without cgroup v2 or delegation, Apply returns ErrUnsupported and
+// sessions run unconstrained.
+package cgroup
+
+import "errors"
+
+// ErrUnsupported is returned when cgroup v2 is unavailable or not delegated.
+var ErrUnsupported = errors.New("cgroup v2 not available")
+
+// Limits are the resource limits applied to a session cgroup.
+// Zero fields leave the kernel default (unlimited).
+type Limits struct {
+	CPUWeight int   // cpu.weight, 1-10000
+	MemoryMax int64 // memory.max in bytes
+	PidsMax   int   // pids.max
+}
+
+// Usage is a snapshot of a session cgroup's resource consumption.
+type Usage struct {
+	Path          string `json:"path"`
+	MemoryCurrent int64  `json:"memory_current"`
+	MemoryPeak    int64  `json:"memory_peak,omitempty"`
+	MemoryMax     int64  `json:"memory_max,omitempty"` // 0 = unlimited
+	CPUUsageUsec  int64  `json:"cpu_usage_usec"`
+	CPUWeight     int    `json:"cpu_weight,omitempty"`
+	PidsCurrent   int    `json:"pids_current"`
+	PidsMax       int    `json:"pids_max,omitempty"` // 0 = unlimited
+	OOMKills      int    `json:"oom_kills,omitempty"`
+}
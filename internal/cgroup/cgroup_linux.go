@@ -0,0 +1,219 @@
+//go:build linux
+
+package cgroup
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// fsRoot and procRoot are overridden in tests.
+var (
+	fsRoot   = "/sys/fs/cgroup"
+	procRoot = "/proc"
+)
+
+// groupName is the parent group holding all session cgroups.
+const groupName = "gastown"
+
+// controllers are enabled for session cgroups, best-effort.
+var controllers = []string{"cpu", "memory", "pids"}
+
+// parentDir finds a cgroup directory the current user may create children in.
+func parentDir() (string, error) {
+	if p := os.Getenv("GT_CGROUP_PARENT"); p != "" {
+		return p, nil
+	}
+	if _, err := os.Stat(filepath.Join(fsRoot, "cgroup.controllers")); err != nil {
+		return "", ErrUnsupported
+	}
+
+	data, err := os.ReadFile(filepath.Join(procRoot, "self", "cgroup"))
+	if err != nil {
+		return "", ErrUnsupported
+	}
+	var self string
+	for _, line := range strings.Split(string(data), "\n") {
+		if rest, ok := strings.CutPrefix(line, "0::"); ok {
+			self = rest
+			break
+		}
+	}
+
+	// systemd delegates user@<uid>.service to the user.
+	marker := fmt.Sprintf("/user@%d.service", os.Getuid())
+	if i := strings.Index(self, marker); i >= 0 {
+		return filepath.Join(fsRoot, self[:i+len(marker)]), nil
+	}
+	if os.Geteuid() == 0 {
+		return fsRoot, nil
+	}
+	return "", fmt.Errorf("%w: no delegated cgroup subtree (set GT_CGROUP_PARENT)", ErrUnsupported)
+}
+
+// Path returns the cgroup directory for a session, whether or not it exists.
+func Path(name string) (string, error) {
+	if name == "" || strings.ContainsAny(name, "/\x00") || name == "." || name == ".." {
+		return "", fmt.Errorf("invalid cgroup name %q", name)
+	}
+	parent, err := parentDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(parent, groupName, name), nil
+}
+
+// Apply creates the session's cgroup, writes its limits, and moves pid and
+// all of its current descendants into it. Processes forked later inherit the
+// cgroup. Returns the cgroup directory.
+func Apply(name string, pid int, limits Limits) (string, error) {
+	dir, err := Path(name)
+	if err != nil {
+		return "", err
+	}
+	group := filepath.Dir(dir)
+	if err := os.MkdirAll(group, 0755); err != nil {
+		return "", fmt.Errorf("creating %s: %w", group, err)
+	}
+	enableControllers(filepath.Dir(group))
+	enableControllers(group)
+
+	// A leftover group from an earlier session with the same name still
+	// carries its OOM counters. Recreate it so each session starts clean.
+	if procs, err := os.ReadFile(filepath.Join(dir, "cgroup.procs")); err == nil && len(bytes.TrimSpace(procs)) == 0 {
+		_ = os.Remove(dir)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("creating %s: %w", dir, err)
+	}
+
+	if limits.CPUWeight > 0 {
+		if err := writeControl(dir, "cpu.weight", strconv.Itoa(limits.CPUWeight)); err != nil {
+			return dir, err
+		}
+	}
+	if limits.MemoryMax > 0 {
+		if err := writeControl(dir, "memory.max", strconv.FormatInt(limits.MemoryMax, 10)); err != nil {
+			return dir, err
+		}
+	}
+	if limits.PidsMax > 0 {
+		if err := writeControl(dir, "pids.max", strconv.Itoa(limits.PidsMax)); err != nil {
+			return dir, err
+		}
+	}
+
+	for i, p := range processTree(pid) {
+		err := writeControl(dir, "cgroup.procs", strconv.Itoa(p))
+		if err != nil && i == 0 {
+			return dir, err
+		}
+		// Descendants may exit between listing and moving; ignore them.
+	}
+	return dir, nil
+}
+
+// ReadUsage reads current usage and limits from a session's cgroup.
+// The cgroup outlives its processes, so usage (notably OOM kills) can be
+// read after the session has died, until Remove is called.
+func ReadUsage(name string) (*Usage, error) {
+	dir, err := Path(name)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(dir); err != nil {
+		return nil, err
+	}
+
+	u := &Usage{Path: dir}
+	u.MemoryCurrent = readInt(dir, "memory.current")
+	u.MemoryPeak = readInt(dir, "memory.peak")
+	u.MemoryMax = readInt(dir, "memory.max")
+	u.CPUWeight = int(readInt(dir, "cpu.weight"))
+	u.PidsCurrent = int(readInt(dir, "pids.current"))
+	u.PidsMax = int(readInt(dir, "pids.max"))
+	u.CPUUsageUsec = readKey(dir, "cpu.stat", "usage_usec")
+	u.OOMKills = int(readKey(dir, "memory.events", "oom_kill"))
+	return u, nil
+}
+
+// Remove deletes a session's cgroup. It fails if processes remain in it.
+func Remove(name string) error {
+	dir, err := Path(name)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(dir); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// enableControllers turns on controllers for dir's children, one at a time
+// so an unavailable controller doesn't block the others.
+func enableControllers(dir string) {
+	for _, c := range controllers {
+		_ = os.WriteFile(filepath.Join(dir, "cgroup.subtree_control"), []byte("+"+c), 0644) //nolint:gosec // G306: cgroupfs control file
+	}
+}
+
+func writeControl(dir, file, value string) error {
+	if err := os.WriteFile(filepath.Join(dir, file), []byte(value), 0644); err != nil { //nolint:gosec // G306: cgroupfs control file
+		return fmt.Errorf("writing %s: %w", file, err)
+	}
+	return nil
+}
+
+// readInt reads a single-value control file. "max" and missing files read as 0.
+func readInt(dir, file string) int64 {
+	data, err := os.ReadFile(filepath.Join(dir, file)) //nolint:gosec // G304: path is constructed internally
+	if err != nil {
+		return 0
+	}
+	n, _ := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+	return n
+}
+
+// readKey reads one "key value" line from a flat-keyed file like cpu.stat.
+func readKey(dir, file, key string) int64 {
+	f, err := os.Open(filepath.Join(dir, file)) //nolint:gosec // G304: path is constructed internally
+	if err != nil {
+		return 0
+	}
+	defer func() { _ = f.Close() }()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && fields[0] == key {
+			n, _ := strconv.ParseInt(fields[1], 10, 64)
+			return n
+		}
+	}
+	return 0
+}
+
+// processTree returns pid followed by its descendants, parents first.
+func processTree(pid int) []int {
+	tree := []int{pid}
+	for i := 0; i < len(tree); i++ {
+		tasks, _ := filepath.Glob(filepath.Join(procRoot, strconv.Itoa(tree[i]), "task", "*", "children"))
+		for _, t := range tasks {
+			data, err := os.ReadFile(t) //nolint:gosec // G304: procfs path
+			if err != nil {
+				continue
+			}
+			for _, f := range strings.Fields(string(data)) {
+				if child, err := strconv.Atoi(f); err == nil {
+					tree = append(tree, child)
+				}
+			}
+		}
+	}
+	return tree
+}
@@ -0,0 +1,116 @@
+//go:build linux
+
+package cgroup
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func writeFake(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// fakeProc builds a /proc with 100 -> {101, 102}, 101 -> {103}.
+func fakeProc(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	writeFake(t, filepath.Join(root, "100", "task", "100", "children"), "101 102 ")
+	writeFake(t, filepath.Join(root, "101", "task", "101", "children"), "103")
+	writeFake(t, filepath.Join(root, "102", "task", "102", "children"), "")
+	return root
+}
+
+func TestProcessTree(t *testing.T) {
+	old := procRoot
+	procRoot = fakeProc(t)
+	defer func() { procRoot = old }()
+
+	if got, want := processTree(100), []int{100, 101, 102, 103}; !reflect.DeepEqual(got, want) {
+		t.Errorf("processTree(100) = %v, want %v", got, want)
+	}
+}
+
+func TestApplyAndReadUsage(t *testing.T) {
+	parent := t.TempDir()
+	t.Setenv("GT_CGROUP_PARENT", parent)
+	old := procRoot
+	procRoot = fakeProc(t)
+	defer func() { procRoot = old }()
+
+	dir, err := Apply("gt-gastown-Toast", 100, Limits{CPUWeight: 50, MemoryMax: 4 << 30, PidsMax: 256})
+	if err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	if want := filepath.Join(parent, "gastown", "gt-gastown-Toast"); dir != want {
+		t.Errorf("dir = %s, want %s", dir, want)
+	}
+	for file, want := range map[string]string{
+		"cpu.weight":   "50",
+		"memory.max":   fmt.Sprint(4 << 30),
+		"pids.max":     "256",
+		"cgroup.procs": "103", // fake file keeps the last write
+	} {
+		if data, _ := os.ReadFile(filepath.Join(dir, file)); string(data) != want {
+			t.Errorf("%s = %q, want %q", file, data, want)
+		}
+	}
+	if data, _ := os.ReadFile(filepath.Join(parent, "gastown", "cgroup.subtree_control")); !strings.HasPrefix(string(data), "+") {
+		t.Errorf("controllers not enabled on gastown group: %q", data)
+	}
+
+	writeFake(t, filepath.Join(dir, "memory.current"), "1048576\n")
+	writeFake(t, filepath.Join(dir, "pids.current"), "7\n")
+	writeFake(t, filepath.Join(dir, "cpu.stat"), "usage_usec 2500000\nuser_usec 2000000\n")
+	writeFake(t, filepath.Join(dir, "memory.events"), "low 0\nhigh 0\nmax 3\noom 1\noom_kill 1\n")
+
+	u, err := ReadUsage("gt-gastown-Toast")
+	if err != nil {
+		t.Fatalf("ReadUsage: %v", err)
+	}
+	want := Usage{Path: dir, MemoryCurrent: 1 << 20, MemoryMax: 4 << 30, CPUUsageUsec: 2500000,
+		CPUWeight: 50, PidsCurrent: 7, PidsMax: 256, OOMKills: 1}
+	if *u != want {
+		t.Errorf("ReadUsage = %+v, want %+v", *u, want)
+	}
+
+	if _, err := ReadUsage("gt-gastown-Nux"); !os.IsNotExist(err) {
+		t.Errorf("ReadUsage(missing) err = %v, want not-exist", err)
+	}
+	if _, err := Path("../escape"); err == nil {
+		t.Error("Path accepted a name with a slash")
+	}
+}
+
+func TestParentDirDelegatedUserService(t *testing.T) {
+	t.Setenv("GT_CGROUP_PARENT", "")
+	oldFS, oldProc := fsRoot, procRoot
+	fsRoot, procRoot = t.TempDir(), t.TempDir()
+	defer func() { fsRoot, procRoot = oldFS, oldProc }()
+
+	if _, err := parentDir(); err == nil {
+		t.Error("parentDir without cgroup2 mount should fail")
+	}
+
+	writeFake(t, filepath.Join(fsRoot, "cgroup.controllers"), "cpu memory pids\n")
+	slice := fmt.Sprintf("/user.slice/user-%d.slice/user@%d.service", os.Getuid(), os.Getuid())
+	writeFake(t, filepath.Join(procRoot, "self", "cgroup"), "0::"+slice+"/app.slice/tmux-spawn.scope\n")
+
+	got, err := parentDir()
+	if err != nil {
+		t.Fatalf("parentDir: %v", err)
+	}
+	if want := filepath.Join(fsRoot, slice); got != want {
+		t.Errorf("parentDir = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,18 @@
+//go:build !linux
+
+package cgroup
+
+// Apply is a no-op outside Linux.
+func Apply(name string, pid int, limits Limits) (string, error) {
+	return "", ErrUnsupported
+}
+
+// ReadUsage is unavailable outside Linux.
+func ReadUsage(name string) (*Usage, error) {
+	return nil, ErrUnsupported
+}
+
+// Remove is a no-op outside Linux.
+func Remove(name string) error {
+	return nil
+}
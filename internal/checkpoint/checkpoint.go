@@ -38,6 +38,13 @@ type Checkpoint struct {
 	// Branch is the current git branch.
 	Branch string `json:"branch,omitempty"`
 
+	// WIPRef is the hidden ref holding a snapshot of uncommitted work
+	// (see SnapshotWIP). Empty when the worktree was clean.
+	WIPRef string `json:"wip_ref,omitempty"`
+
+	// WIPCommit is the snapshot commit WIPRef pointed to at capture time.
+	WIPCommit string `json:"wip_commit,omitempty"`
+
 	// HookedBead is the bead ID on the agent's hook.
 	HookedBead string `json:"hooked_bead,omitempty"`
 
@@ -115,6 +122,8 @@ func Remove(polecatDir string) error {
 }
 
 // Capture creates a checkpoint by capturing current git and work state.
+// Uncommitted changes are also snapshotted to a hidden WIP ref so they can
+// be restored if the worktree is lost.
 func Capture(polecatDir string) (*Checkpoint, error) {
 	cp := &Checkpoint{
 		Timestamp: time.Now(),
@@ -153,6 +162,14 @@ func Capture(polecatDir string) (*Checkpoint, error) {
 		cp.Branch = strings.TrimSpace(string(output))
 	}
 
+	// Snapshot uncommitted work (best-effort, like the queries above)
+	if len(cp.ModifiedFiles) > 0 {
+		if snap, err := SnapshotWIP(polecatDir, WIPName(polecatDir), cp.Timestamp); err == nil && snap != nil {
+			cp.WIPRef = snap.Ref
+			cp.WIPCommit = snap.Commit
+		}
+	}
+
 	return cp, nil
 }
 
@@ -210,6 +227,10 @@ func (cp *Checkpoint) Summary() string {
 		parts = append(parts, fmt.Sprintf("branch: %s", cp.Branch))
 	}
 
+	if cp.WIPRef != "" {
+		parts = append(parts, "wip snapshot saved")
+	}
+
 	if len(parts) == 0 {
 		return "no significant state"
 	}
@@ -0,0 +1,258 @@
+package checkpoint
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// WIPRefPrefix is the namespace for work-in-progress snapshot refs.
+// Snapshots live at refs/gastown/wip/<polecat>/<timestamp>. Refs outside
+// refs/heads are shared by all worktrees of a repository, so a snapshot
+// survives its worktree being removed.
+const WIPRefPrefix = "refs/gastown/wip/"
+
+// wipTimeFormat is the timestamp component of a snapshot ref (UTC).
+const wipTimeFormat = "20060102T150405Z"
+
+// Default WIP snapshot retention, applied by the daemon.
+const (
+	DefaultWIPMaxAge      = 7 * 24 * time.Hour
+	DefaultWIPKeepPerName = 20
+)
+
+// emptyTreeSHA is git's well-known empty tree, used as the base when a
+// snapshot is taken on an unborn branch.
+const emptyTreeSHA = "4b825dc642cb6eb9a060e54bf8d69288fbee4904"
+
+// WIPSnapshot describes a work-in-progress snapshot ref.
+type WIPSnapshot struct {
+	Ref     string    `json:"ref"`
+	Commit  string    `json:"commit"`
+	Polecat string    `json:"polecat"`
+	Time    time.Time `json:"time"`
+}
+
+// WIPName returns the name used in snapshot refs for the worktree at dir:
+// GT_POLECAT when set, otherwise the polecat name from the
+// <rig>/polecats/<name>/<clone> layout, otherwise the directory name.
+func WIPName(dir string) string {
+	if name := os.Getenv("GT_POLECAT"); name != "" {
+		return name
+	}
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		abs = dir
+	}
+	parent := filepath.Dir(abs)
+	if filepath.Base(filepath.Dir(parent)) == "polecats" {
+		return filepath.Base(parent)
+	}
+	return filepath.Base(abs)
+}
+
+// runGit runs git in dir with extra environment, returning trimmed stdout.
+func runGit(dir string, env []string, stdin io.Reader, args ...string) (string, error) {
+	out, err := runGitRaw(dir, env, stdin, args...)
+	return strings.TrimSpace(out), err
+}
+
+// runGitRaw is runGit without trimming, for output such as patches where
+// trailing whitespace is significant.
+func runGitRaw(dir string, env []string, stdin io.Reader, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stdin = stdin
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("git %s: %v: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.String(), nil
+}
+
+// snapshotIdentity ensures commit-tree works in worktrees without a
+// configured user; an explicitly configured identity still wins.
+func snapshotIdentity(dir string) []string {
+	if _, err := runGit(dir, nil, nil, "config", "user.email"); err == nil {
+		return nil
+	}
+	return []string{
+		"GIT_AUTHOR_NAME=Gas Town", "GIT_AUTHOR_EMAIL=gastown@localhost",
+		"GIT_COMMITTER_NAME=Gas Town", "GIT_COMMITTER_EMAIL=gastown@localhost",
+	}
+}
+
+// SnapshotWIP records the worktree at dir, including untracked files that
+// are not ignored, under refs/gastown/wip/<name>/<timestamp>. The branch,
+// index and working tree are left untouched.
+//
+// The snapshot commit has HEAD as its first parent and a commit of the
+// index as its second, mirroring git stash. When nothing differs from
+// HEAD no snapshot is taken and SnapshotWIP returns nil, nil.
+func SnapshotWIP(dir, name string, now time.Time) (*WIPSnapshot, error) {
+	head, _ := runGit(dir, nil, nil, "rev-parse", "--verify", "-q", "HEAD")
+	headTree := emptyTreeSHA
+	if head != "" {
+		headTree, _ = runGit(dir, nil, nil, "rev-parse", head+"^{tree}")
+	}
+
+	// Index tree. write-tree fails with unresolved conflicts; fall back to
+	// HEAD's tree so the working tree is still captured.
+	indexTree, err := runGit(dir, nil, nil, "write-tree")
+	if err != nil {
+		indexTree = headTree
+	}
+
+	// Working tree: stage everything into a scratch copy of the index.
+	indexPath, err := runGit(dir, nil, nil, "rev-parse", "--path-format=absolute", "--git-path", "index")
+	if err != nil {
+		return nil, err
+	}
+	tmp, err := os.CreateTemp("", "gt-wip-index-*")
+	if err != nil {
+		return nil, fmt.Errorf("creating scratch index: %w", err)
+	}
+	tmpIndex := tmp.Name()
+	_ = tmp.Close()
+	defer func() { _ = os.Remove(tmpIndex) }()
+	if data, err := os.ReadFile(indexPath); err == nil { //nolint:gosec // G304: path from git
+		if err := os.WriteFile(tmpIndex, data, 0600); err != nil {
+			return nil, fmt.Errorf("copying index: %w", err)
+		}
+	} else {
+		_ = os.Remove(tmpIndex) // git creates a fresh index
+	}
+	scratch := []string{"GIT_INDEX_FILE=" + tmpIndex}
+	if _, err := runGit(dir, scratch, nil, "add", "-A", "--", "."); err != nil {
+		return nil, err
+	}
+	worktreeTree, err := runGit(dir, scratch, nil, "write-tree")
+	if err != nil {
+		return nil, err
+	}
+
+	if indexTree == headTree && worktreeTree == headTree {
+		return nil, nil
+	}
+
+	ident := snapshotIdentity(dir)
+	var parents []string
+	if head != "" {
+		parents = []string{"-p", head}
+	}
+	indexCommit, err := runGit(dir, ident, nil,
+		append(append([]string{"commit-tree", indexTree}, parents...), "-m", "index on "+name)...)
+	if err != nil {
+		return nil, err
+	}
+	msg := fmt.Sprintf("WIP snapshot of %s at %s", name, now.UTC().Format(time.RFC3339))
+	args := append(append([]string{"commit-tree", worktreeTree}, parents...), "-p", indexCommit, "-m", msg)
+	commit, err := runGit(dir, ident, nil, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	ref := WIPRefPrefix + name + "/" + now.UTC().Format(wipTimeFormat)
+	if _, err := runGit(dir, nil, nil, "update-ref", "-m", msg, ref, commit); err != nil {
+		return nil, err
+	}
+	return &WIPSnapshot{Ref: ref, Commit: commit, Polecat: name, Time: now.UTC().Truncate(time.Second)}, nil
+}
+
+// ListWIP returns the WIP snapshots in the repository containing dir,
+// newest first. An empty name lists snapshots for every polecat.
+func ListWIP(dir, name string) ([]WIPSnapshot, error) {
+	prefix := WIPRefPrefix
+	if name != "" {
+		prefix += name + "/"
+	}
+	out, err := runGit(dir, nil, nil, "for-each-ref", "--format=%(refname) %(objectname)", prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	var snaps []WIPSnapshot
+	for _, line := range strings.Split(out, "\n") {
+		ref, commit, ok := strings.Cut(strings.TrimSpace(line), " ")
+		if !ok {
+			continue
+		}
+		rest := strings.TrimPrefix(ref, WIPRefPrefix)
+		i := strings.LastIndex(rest, "/")
+		if i < 0 {
+			continue
+		}
+		ts, err := time.Parse(wipTimeFormat, rest[i+1:])
+		if err != nil {
+			continue
+		}
+		snaps = append(snaps, WIPSnapshot{Ref: ref, Commit: commit, Polecat: rest[:i], Time: ts})
+	}
+	sort.Slice(snaps, func(i, j int) bool { return snaps[i].Time.After(snaps[j].Time) })
+	return snaps, nil
+}
+
+// RestoreWIP applies a snapshot's changes to the worktree at dir. The
+// changes are computed against the snapshot's base commit and applied with
+// a three-way merge, so the target may be a fresh worktree on a different
+// commit. Files the snapshot had untracked are restored as new files.
+func RestoreWIP(dir, ref string) error {
+	commit, err := runGit(dir, nil, nil, "rev-parse", "--verify", ref+"^{commit}")
+	if err != nil {
+		return fmt.Errorf("snapshot %s not found: %w", ref, err)
+	}
+	// Snapshots of a born branch have parents (HEAD, index); on an unborn
+	// branch only the index commit, so the base is the empty tree.
+	base := emptyTreeSHA
+	parents, err := runGit(dir, nil, nil, "rev-list", "--parents", "-n", "1", commit)
+	if err != nil {
+		return err
+	}
+	if fields := strings.Fields(parents); len(fields) == 3 {
+		base = fields[1]
+	}
+
+	diff, err := runGitRaw(dir, nil, nil, "diff", "--binary", "--full-index", base, commit)
+	if err != nil {
+		return err
+	}
+	if diff == "" {
+		return nil
+	}
+	if _, err := runGit(dir, nil, strings.NewReader(diff), "apply", "--3way", "--whitespace=nowarn"); err != nil {
+		return fmt.Errorf("applying snapshot %s: %w", ref, err)
+	}
+	return nil
+}
+
+// PruneWIP deletes snapshots older than maxAge, and all but the newest keep
+// snapshots per polecat. It returns the refs deleted.
+func PruneWIP(dir string, maxAge time.Duration, keep int, now time.Time) ([]string, error) {
+	snaps, err := ListWIP(dir, "")
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]int)
+	var pruned []string
+	for _, s := range snaps { // newest first
+		seen[s.Polecat]++
+		if seen[s.Polecat] <= keep && now.Sub(s.Time) <= maxAge {
+			continue
+		}
+		if _, err := runGit(dir, nil, nil, "update-ref", "-d", s.Ref); err != nil {
+			return pruned, err
+		}
+		pruned = append(pruned, s.Ref)
+	}
+	return pruned, nil
+}
@@ -0,0 +1,189 @@
+package checkpoint
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func gitT(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func initWIPRepo(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	gitT(t, dir, "init", "-q", "-b", "main")
+	gitT(t, dir, "config", "user.email", "test@example.com")
+	gitT(t, dir, "config", "user.name", "Test")
+	if err := os.WriteFile(filepath.Join(dir, "a.txt"), []byte("one\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ".gitignore"), []byte("*.log\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	gitT(t, dir, "add", ".")
+	gitT(t, dir, "commit", "-q", "-m", "initial")
+	return dir
+}
+
+func TestSnapshotAndRestoreWIP(t *testing.T) {
+	repo := initWIPRepo(t)
+	head := gitT(t, repo, "rev-parse", "HEAD")
+
+	// Clean worktree: no snapshot.
+	snap, err := SnapshotWIP(repo, "Toast", time.Now())
+	if err != nil || snap != nil {
+		t.Fatalf("clean SnapshotWIP = %v, %v; want nil, nil", snap, err)
+	}
+
+	// Staged edit, unstaged edit, untracked file, ignored file.
+	if err := os.WriteFile(filepath.Join(repo, "a.txt"), []byte("one\ntwo\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	gitT(t, repo, "add", "a.txt")
+	if err := os.WriteFile(filepath.Join(repo, "a.txt"), []byte("one\ntwo\nthree\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(repo, "new.txt"), []byte("fresh\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(repo, "debug.log"), []byte("noise\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	statusBefore := gitT(t, repo, "status", "--porcelain")
+
+	at := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	snap, err = SnapshotWIP(repo, "Toast", at)
+	if err != nil {
+		t.Fatalf("SnapshotWIP: %v", err)
+	}
+	if snap == nil || snap.Ref != "refs/gastown/wip/Toast/20261018T120000Z" {
+		t.Fatalf("snapshot = %+v", snap)
+	}
+
+	// Branch, index, and working tree are untouched.
+	if got := gitT(t, repo, "rev-parse", "HEAD"); got != head {
+		t.Errorf("HEAD moved to %s", got)
+	}
+	if got := gitT(t, repo, "status", "--porcelain"); got != statusBefore {
+		t.Errorf("status changed:\n%s\nwant:\n%s", got, statusBefore)
+	}
+
+	// Snapshot contents: working tree including untracked, excluding ignored.
+	files := gitT(t, repo, "ls-tree", "--name-only", snap.Commit)
+	if !strings.Contains(files, "new.txt") || strings.Contains(files, "debug.log") {
+		t.Errorf("snapshot files = %q", files)
+	}
+	if got := gitT(t, repo, "show", snap.Commit+":a.txt"); got != "one\ntwo\nthree" {
+		t.Errorf("snapshot a.txt = %q", got)
+	}
+	if got := gitT(t, repo, "show", snap.Commit+"^2:a.txt"); got != "one\ntwo" {
+		t.Errorf("snapshot index a.txt = %q", got)
+	}
+
+	snaps, err := ListWIP(repo, "Toast")
+	if err != nil || len(snaps) != 1 || snaps[0].Commit != snap.Commit || !snaps[0].Time.Equal(at) {
+		t.Fatalf("ListWIP = %+v, %v", snaps, err)
+	}
+
+	// Restore into a fresh worktree whose branch has moved on.
+	fresh := filepath.Join(t.TempDir(), "fresh")
+	gitT(t, repo, "worktree", "add", "-q", "-b", "polecat/Toast-2", fresh, "main")
+	if err := os.WriteFile(filepath.Join(fresh, "other.txt"), []byte("x\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	gitT(t, fresh, "add", "other.txt")
+	gitT(t, fresh, "commit", "-q", "-m", "unrelated")
+
+	if err := RestoreWIP(fresh, snap.Ref); err != nil {
+		t.Fatalf("RestoreWIP: %v", err)
+	}
+	for name, want := range map[string]string{"a.txt": "one\ntwo\nthree\n", "new.txt": "fresh\n", "other.txt": "x\n"} {
+		data, err := os.ReadFile(filepath.Join(fresh, name))
+		if err != nil || string(data) != want {
+			t.Errorf("restored %s = %q, %v; want %q", name, data, err, want)
+		}
+	}
+}
+
+func TestSnapshotWIPUnbornBranch(t *testing.T) {
+	repo := t.TempDir()
+	gitT(t, repo, "init", "-q", "-b", "main")
+	if err := os.WriteFile(filepath.Join(repo, "a.txt"), []byte("hello\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	snap, err := SnapshotWIP(repo, "Nux", time.Now())
+	if err != nil || snap == nil {
+		t.Fatalf("SnapshotWIP on unborn branch = %v, %v", snap, err)
+	}
+
+	fresh := t.TempDir()
+	gitT(t, fresh, "init", "-q", "-b", "main")
+	gitT(t, fresh, "fetch", "-q", repo, snap.Ref+":"+snap.Ref)
+	if err := RestoreWIP(fresh, snap.Ref); err != nil {
+		t.Fatalf("RestoreWIP: %v", err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(fresh, "a.txt")); string(data) != "hello\n" {
+		t.Errorf("restored a.txt = %q", data)
+	}
+}
+
+func TestPruneWIP(t *testing.T) {
+	repo := initWIPRepo(t)
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	for i, age := range []time.Duration{0, time.Hour, 2 * time.Hour, 10 * 24 * time.Hour} {
+		if err := os.WriteFile(filepath.Join(repo, "a.txt"), []byte(strings.Repeat("x", i+1)), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := SnapshotWIP(repo, "Toast", now.Add(-age)); err != nil {
+			t.Fatalf("SnapshotWIP: %v", err)
+		}
+	}
+	if _, err := SnapshotWIP(repo, "Nux", now.Add(-time.Hour)); err != nil {
+		t.Fatalf("SnapshotWIP: %v", err)
+	}
+
+	// Keep 2 per polecat, drop anything older than a week.
+	pruned, err := PruneWIP(repo, 7*24*time.Hour, 2, now)
+	if err != nil {
+		t.Fatalf("PruneWIP: %v", err)
+	}
+	if len(pruned) != 2 {
+		t.Errorf("pruned %v, want the 2h-old and 10d-old Toast snapshots", pruned)
+	}
+
+	remaining, _ := ListWIP(repo, "")
+	if len(remaining) != 3 {
+		t.Fatalf("remaining = %+v, want 3", remaining)
+	}
+	if remaining[0].Polecat != "Toast" || !remaining[0].Time.Equal(now) {
+		t.Errorf("newest Toast snapshot should survive, got %+v", remaining[0])
+	}
+}
+
+func TestWIPName(t *testing.T) {
+	t.Setenv("GT_POLECAT", "")
+	if got := WIPName("/town/gastown/polecats/Toast/gastown"); got != "Toast" {
+		t.Errorf("WIPName(polecat clone) = %q, want Toast", got)
+	}
+	if got := WIPName("/town/gastown/crew/joe"); got != "joe" {
+		t.Errorf("WIPName(crew) = %q, want joe", got)
+	}
+	t.Setenv("GT_POLECAT", "Nux")
+	if got := WIPName("/anywhere"); got != "Nux" {
+		t.Errorf("WIPName with GT_POLECAT = %q, want Nux", got)
+	}
+}
@@ -404,35 +404,14 @@ func collectFeedEvents(townRoot, actor string, since time.Time) ([]AuditEntry, e
 	var entries []AuditEntry
 
 	eventsPath := filepath.Join(townRoot, events.EventsFile)
-	file, err := os.Open(eventsPath)
-	if err != nil {
-		if os.IsNotExist(err) {
-			return nil, nil // No events file yet
-		}
-		return nil, err
-	}
-	defer file.Close()
-
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		var e events.Event
-		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
-			continue // Skip malformed lines
-		}
-
+	err := events.Read(eventsPath, events.Query{Since: since}, func(e events.Event) bool {
 		// Apply actor filter
 		if actor != "" && !matchesActor(e.Actor, actor) {
-			continue
+			return true
 		}
 
-		// Parse timestamp
 		ts, _ := time.Parse(time.RFC3339, e.Timestamp)
 
-		// Apply since filter
-		if !since.IsZero() && ts.Before(since) {
-			continue
-		}
-
 		entries = append(entries, AuditEntry{
 			Timestamp: ts,
 			Source:    "events",
@@ -440,9 +419,10 @@ func collectFeedEvents(townRoot, actor string, since time.Time) ([]AuditEntry, e
 			Actor:     e.Actor,
 			Summary:   formatFeedSummary(e),
 		})
-	}
+		return true
+	})
 
-	return entries, nil
+	return entries, err
 }
 
 // formatFeedSummary creates a readable summary from a feed event.
@@ -536,6 +516,8 @@ func formatSource(source string) string {
 		return style.Dim.Render("[log]")
 	case "events":
 		return style.Warning.Render("[events]")
+	case "mail":
+		return style.Info.Render("[mail]")
 	default:
 		return fmt.Sprintf("[%s]", source)
 	}
@@ -555,8 +537,8 @@ func formatType(t string) string {
 		return style.Success.Render("done")
 	case "handoff":
 		return style.Bold.Render("handoff")
-	case "crash":
-		return style.Error.Render("crash")
+	case "crash", "session_death":
+		return style.Error.Render(t)
 	case "kill":
 		return style.Warning.Render("kill")
 	case "merged":
@@ -432,6 +432,7 @@ var expectedBeadsNewCounts = map[string]int{
 	"mq_status.go":                 1,
 	"mq_submit.go":                 1,
 	"notify.go":                    1,
+	"notify_watch.go":              1,
 	"nudge.go":                     1,
 	"polecat.go":                   2,
 	"polecat_helpers.go":           2,
@@ -439,7 +440,7 @@ var expectedBeadsNewCounts = map[string]int{
 	"polecat_spawn.go":             2,
 	"prime.go":                     3,
 	"prime_molecule.go":            1,
-	"prime_output.go":              2,
+	"prime_output.go":              3,
 	"prime_session.go":             3,
 	"ready.go":                     2,
 	"refinery.go":                  1,
@@ -451,6 +452,7 @@ var expectedBeadsNewCounts = map[string]int{
 	"sling_helpers.go":             3,
 	"status.go":                    4,
 	"statusline.go":                2,
+	"timeline.go":                  3,
 	"unsling.go":                   3,
 	"up.go":                        1,
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/steveyegge/gastown/internal/boot"
 	"github.com/steveyegge/gastown/internal/daemon"
 	"github.com/steveyegge/gastown/internal/deacon"
+	"github.com/steveyegge/gastown/internal/events"
 	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/tmux"
@@ -325,6 +326,9 @@ func runDegradedTriage(b *boot.Boot) (action, target string, err error) {
 	// Deacon exists - check heartbeat to detect stuck sessions
 	// A session can exist but be stuck (not making progress)
 	if townRoot != "" {
+		// In patrol shadow mode, triage records what it would do to the
+		// Deacon and leaves the session alone.
+		shadow := deacon.InShadow(townRoot)
 		hb := deacon.ReadHeartbeat(townRoot)
 		if hb.IsVeryStale() {
 			// Heartbeat is stale (>15 min) - Deacon is stuck
@@ -333,8 +337,16 @@ func runDegradedTriage(b *boot.Boot) (action, target string, err error) {
 			if age > 30*time.Minute {
 				// Very stuck - restart the session.
 				// Use KillSessionWithProcesses to ensure all descendant processes are killed.
+				reason := fmt.Sprintf("heartbeat %s old", age.Round(time.Minute))
+				if shadow {
+					logTriageReceipt(townRoot, deaconSession, "heartbeat-very-stale", "killed", reason, true, nil)
+					fmt.Printf("Shadow mode: would restart Deacon session (%s)\n", reason)
+					return "nothing", "shadow:deacon-stuck", nil
+				}
 				fmt.Printf("Deacon heartbeat is %s old - restarting session\n", age.Round(time.Minute))
-				if err := tm.KillSessionWithProcesses(deaconSession); err == nil {
+				err := tm.KillSessionWithProcesses(deaconSession)
+				logTriageReceipt(townRoot, deaconSession, "heartbeat-very-stale", "killed", reason, false, err)
+				if err == nil {
 					return "restart", "deacon-stuck", nil
 				}
 				// Kill failed - report it (daemon will retry next tick)
@@ -342,6 +354,12 @@ func runDegradedTriage(b *boot.Boot) (action, target string, err error) {
 				return "restart-failed", "deacon-stuck", nil
 			} else {
 				// Stuck but not critically - try nudging first
+				reason := fmt.Sprintf("heartbeat %s old", age.Round(time.Minute))
+				logTriageReceipt(townRoot, deaconSession, "heartbeat-stale", "nudged", reason, shadow, nil)
+				if shadow {
+					fmt.Printf("Shadow mode: would nudge Deacon session (%s)\n", reason)
+					return "nothing", "shadow:deacon-stale", nil
+				}
 				fmt.Printf("Deacon heartbeat is %s old - nudging session\n", age.Round(time.Minute))
 				_ = tm.NudgeSession(deaconSession, "HEALTH_CHECK: heartbeat is stale, respond to confirm responsiveness")
 				return "nudge", "deacon-stale", nil
@@ -361,6 +379,11 @@ func runDegradedTriage(b *boot.Boot) (action, target string, err error) {
 
 			hookBead := getDeaconHookBead()
 			if hookBead == "" {
+				logTriageReceipt(townRoot, deaconSession, "idle", "nudged", "no work on hook", shadow, nil)
+				if shadow {
+					fmt.Println("Shadow mode: would nudge Deacon to restart patrol (no work on hook)")
+					return "nothing", "shadow:deacon-idle", nil
+				}
 				fmt.Println("Deacon heartbeat fresh but no work on hook - nudging to restart patrol")
 				_ = tm.NudgeSession(deaconSession, "IDLE_CHECK: No active work on hook. If idle, start patrol: gt deacon patrol")
 				return "nudge", "deacon-idle", nil
@@ -370,6 +393,12 @@ func runDegradedTriage(b *boot.Boot) (action, target string, err error) {
 			// by looking at when the last molecule step was closed.
 			lastActivity, err := getMoleculeLastActivity(hookBead)
 			if err == nil && !lastActivity.IsZero() && time.Since(lastActivity) > 15*time.Minute {
+				reason := fmt.Sprintf("no progress on %s in %s", hookBead, time.Since(lastActivity).Round(time.Minute))
+				logTriageReceipt(townRoot, deaconSession, "stale-work", "nudged", reason, shadow, nil)
+				if shadow {
+					fmt.Printf("Shadow mode: would nudge Deacon (%s)\n", reason)
+					return "nothing", "shadow:deacon-stale-work", nil
+				}
 				fmt.Printf("Deacon has hooked work but no progress in %s - nudging\n", time.Since(lastActivity).Round(time.Minute))
 				_ = tm.NudgeSession(deaconSession, "IDLE_CHECK: Hooked work not progressing. Continue work or restart patrol: gt deacon patrol")
 				return "nudge", "deacon-stale-work", nil
@@ -380,6 +409,28 @@ func runDegradedTriage(b *boot.Boot) (action, target string, err error) {
 	return "nothing", "", nil
 }
 
+// logTriageReceipt records a degraded-triage decision about the Deacon.
+func logTriageReceipt(townRoot, sessionName, decision, action, reason string, shadow bool, actErr error) {
+	r := events.Receipt{
+		Agent:   "deacon",
+		Session: sessionName,
+		Rule:    "boot_triage",
+		Thresholds: map[string]interface{}{
+			"nudge_after":   "15m",
+			"restart_after": "30m",
+		},
+		Decision: decision,
+		Action:   action,
+		Reason:   reason,
+		Shadow:   shadow,
+	}
+	if actErr != nil {
+		r.Action = "kill-failed"
+		r.Error = actErr.Error()
+	}
+	_ = events.LogReceipt(townRoot, "boot", r)
+}
+
 // isDeaconInBackoff checks if the Deacon is in await-signal backoff mode.
 // When in backoff mode, the deacon bead has an "idle:N" label where N >= 0.
 // This indicates the deacon is legitimately waiting for beads activity signals
@@ -9,6 +9,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/steveyegge/gastown/internal/deacon"
 	"github.com/steveyegge/gastown/internal/tmux"
 )
 
@@ -309,6 +310,51 @@ func TestExecuteWarrants_SkipsAlreadyExecuted(t *testing.T) {
 	}
 }
 
+// TestExecuteWarrants_ShadowLeavesPending verifies that in patrol shadow mode
+// warrants stay pending and the would-be execution is recorded only once.
+func TestExecuteWarrants_ShadowLeavesPending(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("skipping warrant execution test on Windows (no tmux)")
+	}
+	setupWarrantTestRegistry(t)
+
+	townRoot := t.TempDir()
+	warrantDir := filepath.Join(townRoot, "warrants")
+	if err := os.MkdirAll(warrantDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := deacon.EnableShadow(townRoot, "test", "test"); err != nil {
+		t.Fatal(err)
+	}
+
+	pending := Warrant{
+		ID:      "warrant-test-shadow",
+		Target:  "gastown/polecats/test-shadow-x7q",
+		Reason:  "Zombie: no session, idle >10m",
+		FiledBy: "test",
+		FiledAt: time.Now().Add(-5 * time.Minute),
+	}
+	writeTestWarrant(t, warrantDir, pending)
+
+	tm := tmux.NewTmux()
+	executeWarrants(warrantDir, tm)
+
+	result := readTestWarrant(t, warrantDir, pending.Target)
+	if result.Executed {
+		t.Error("Executed = true, want warrant left pending in shadow mode")
+	}
+	if result.ShadowedAt == nil {
+		t.Fatal("ShadowedAt = nil, want shadow pass recorded")
+	}
+	shadowedAt := *result.ShadowedAt
+
+	executeWarrants(warrantDir, tm)
+	result = readTestWarrant(t, warrantDir, pending.Target)
+	if result.Executed || result.ShadowedAt == nil || !result.ShadowedAt.Equal(shadowedAt) {
+		t.Errorf("second shadow pass changed warrant: %+v", result)
+	}
+}
+
 // TestExecuteWarrants_MissingDir verifies that executeWarrants handles a
 // missing warrants directory gracefully (no panic, no error).
 func TestExecuteWarrants_MissingDir(t *testing.T) {
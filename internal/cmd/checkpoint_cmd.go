@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
@@ -27,8 +28,16 @@ Checkpoint data includes:
 - Modified files list
 - Git branch and last commit
 - Timestamp
+- A WIP snapshot of uncommitted changes (when there are any)
 
-Checkpoints are stored in .polecat-checkpoint.json in the polecat directory.`,
+Checkpoints are stored in .polecat-checkpoint.json in the polecat directory.
+
+WIP snapshots are commits stored under refs/gastown/wip/<polecat>/<time>
+in the rig's shared repository. They capture the index and working tree
+(including untracked files) without touching the branch, so they survive
+the worktree being nuked. Use 'gt checkpoint restore' to apply one to a
+fresh worktree. The daemon prunes snapshots older than 7 days, keeping at
+most 20 per polecat.`,
 }
 
 var checkpointWriteCmd = &cobra.Command{
@@ -59,6 +68,38 @@ var checkpointClearCmd = &cobra.Command{
 	RunE:  runCheckpointClear,
 }
 
+var checkpointWIPCmd = &cobra.Command{
+	Use:   "wip",
+	Short: "List WIP snapshots",
+	Long: `List work-in-progress snapshots for this polecat, newest first.
+
+Use --all to list snapshots for every polecat in the repository.`,
+	Args: cobra.NoArgs,
+	RunE: runCheckpointWIP,
+}
+
+var checkpointRestoreCmd = &cobra.Command{
+	Use:   "restore [ref]",
+	Short: "Restore a WIP snapshot into the current worktree",
+	Long: `Apply a WIP snapshot's uncommitted changes to the current worktree.
+
+Without a ref, restores the snapshot recorded in the checkpoint, or else
+the newest snapshot for this polecat. The changes are applied with a
+three-way merge, so the worktree may be on a newer commit than the one the
+snapshot was taken on. The branch is not changed.
+
+Examples:
+  gt checkpoint restore
+  gt checkpoint restore refs/gastown/wip/Toast/20261018T120000Z`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runCheckpointRestore,
+}
+
+var (
+	checkpointWIPAll  bool
+	checkpointWIPJSON bool
+)
+
 var (
 	checkpointNotes    string
 	checkpointMolecule string
@@ -69,6 +110,13 @@ func init() {
 	checkpointCmd.AddCommand(checkpointWriteCmd)
 	checkpointCmd.AddCommand(checkpointReadCmd)
 	checkpointCmd.AddCommand(checkpointClearCmd)
+	checkpointCmd.AddCommand(checkpointWIPCmd)
+	checkpointCmd.AddCommand(checkpointRestoreCmd)
+
+	checkpointWIPCmd.Flags().BoolVar(&checkpointWIPAll, "all", false,
+		"List snapshots for all polecats")
+	checkpointWIPCmd.Flags().BoolVar(&checkpointWIPJSON, "json", false,
+		"Output as JSON")
 
 	checkpointWriteCmd.Flags().StringVar(&checkpointNotes, "notes", "",
 		"Add notes to the checkpoint")
@@ -194,6 +242,9 @@ func runCheckpointRead(cmd *cobra.Command, args []string) error {
 			fmt.Printf("  - %s\n", f)
 		}
 	}
+	if cp.WIPRef != "" {
+		fmt.Printf("WIP Snapshot: %s\n", cp.WIPRef)
+	}
 	if cp.Notes != "" {
 		fmt.Printf("Notes: %s\n", cp.Notes)
 	}
@@ -218,6 +269,72 @@ func runCheckpointClear(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runCheckpointWIP(cmd *cobra.Command, args []string) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("getting current directory: %w", err)
+	}
+
+	name := checkpoint.WIPName(cwd)
+	if checkpointWIPAll {
+		name = ""
+	}
+	snaps, err := checkpoint.ListWIP(cwd, name)
+	if err != nil {
+		return fmt.Errorf("listing WIP snapshots: %w", err)
+	}
+
+	if checkpointWIPJSON {
+		if snaps == nil {
+			snaps = []checkpoint.WIPSnapshot{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(snaps)
+	}
+
+	if len(snaps) == 0 {
+		fmt.Printf("%s No WIP snapshots\n", style.Dim.Render("○"))
+		return nil
+	}
+	for _, s := range snaps {
+		fmt.Printf("%s  %s  %s\n", s.Time.Local().Format("2006-01-02 15:04:05"),
+			s.Commit[:min(12, len(s.Commit))], s.Ref)
+	}
+	return nil
+}
+
+func runCheckpointRestore(cmd *cobra.Command, args []string) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("getting current directory: %w", err)
+	}
+
+	var ref string
+	if len(args) > 0 {
+		ref = args[0]
+	} else {
+		if cp, err := checkpoint.Read(cwd); err == nil && cp != nil && cp.WIPRef != "" {
+			ref = cp.WIPRef
+		} else {
+			snaps, err := checkpoint.ListWIP(cwd, checkpoint.WIPName(cwd))
+			if err != nil {
+				return fmt.Errorf("listing WIP snapshots: %w", err)
+			}
+			if len(snaps) == 0 {
+				return fmt.Errorf("no WIP snapshots for %s", checkpoint.WIPName(cwd))
+			}
+			ref = snaps[0].Ref
+		}
+	}
+
+	if err := checkpoint.RestoreWIP(cwd, ref); err != nil {
+		return err
+	}
+	fmt.Printf("%s Restored %s\n", style.Bold.Render("✓"), ref)
+	return nil
+}
+
 // detectMoleculeContext tries to detect the current molecule and step from beads.
 func detectMoleculeContext(workDir string, ctx RoleInfo) (moleculeID, stepID, stepTitle string) {
 	b := beads.New(workDir)
@@ -6,6 +6,7 @@ import (
 	"encoding/base32"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -18,10 +19,13 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/doltserver"
+	"github.com/steveyegge/gastown/internal/events"
 	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/tui/convoy"
 	"github.com/steveyegge/gastown/internal/workspace"
+	"golang.org/x/term"
 )
 
 // generateShortID generates a short random ID (5 lowercase chars).
@@ -63,6 +67,10 @@ var (
 	convoyOwned        bool
 	convoyMerge        string
 	convoyStatusJSON   bool
+	convoyStatusWatch  bool
+	convoyStatusEvery  int
+	convoyStatusNotify bool
+	convoyStatusAsOf   string
 	convoyListJSON     bool
 	convoyListStatus   string
 	convoyListAll      bool
@@ -200,7 +208,22 @@ var convoyStatusCmd = &cobra.Command{
 	Long: `Show detailed status for a convoy.
 
 Displays convoy metadata, tracked issues, and completion progress.
-Without an ID, shows status of all active convoys.`,
+Without an ID, shows status of all active convoys.
+
+Use --watch to keep the view open; it is redrawn only when something
+changes. Add --notify to get a terminal/desktop notification when a convoy
+lands, an escalation is raised, or a merge fails (see 'gt notify watch').
+
+Use --as-of to read the convoy and its tracked issues from Dolt history:
+a duration ago (6h, 1d), "yesterday", a local date or time
+(2006-01-02 15:04), or a Dolt commit. Use 'gt history <id>' to see how a
+single issue got from there to now.
+
+Examples:
+  gt convoy status hq-cv-abc
+  gt convoy status 1 --watch
+  gt convoy status --watch --notify
+  gt convoy status hq-cv-abc --as-of yesterday`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runConvoyStatus,
 }
@@ -329,6 +352,10 @@ func init() {
 
 	// Status flags
 	convoyStatusCmd.Flags().BoolVar(&convoyStatusJSON, "json", false, "Output as JSON")
+	convoyStatusCmd.Flags().BoolVarP(&convoyStatusWatch, "watch", "w", false, "Watch mode: redraw whenever the convoy changes")
+	convoyStatusCmd.Flags().IntVarP(&convoyStatusEvery, "interval", "n", 5, "Refresh interval in seconds (with --watch)")
+	convoyStatusCmd.Flags().BoolVar(&convoyStatusNotify, "notify", false, "With --watch: send overseer notifications for subscribed events")
+	convoyStatusCmd.Flags().StringVar(&convoyStatusAsOf, "as-of", "", "Show the convoy as it was at a past time or Dolt commit")
 
 	// List flags
 	convoyListCmd.Flags().BoolVar(&convoyListJSON, "json", false, "Output as JSON")
@@ -1425,6 +1452,9 @@ func checkAndCloseCompletedConvoys(townBeads string, dryRun bool) ([]struct{ ID,
 
 // notifyConvoyCompletion sends notifications to owner and any notify addresses.
 func notifyConvoyCompletion(townBeads, convoyID, title string) {
+	// Overseer notifiers (gt notify watch, --watch --notify) follow this event.
+	_ = events.LogFeedIn(filepath.Dir(townBeads), events.TypeConvoyLanded, "gt", events.ConvoyPayload(convoyID, title))
+
 	// Get convoy description to find owner and notify addresses
 	showArgs := []string{"show", convoyID, "--json"}
 	showCmd := exec.Command("bd", showArgs...)
@@ -1493,6 +1523,13 @@ func notifyMayorSession(townBeads, convoyID, title string) {
 }
 
 func runConvoyStatus(cmd *cobra.Command, args []string) error {
+	if convoyStatusWatch {
+		return runConvoyStatusWatch(args)
+	}
+	if convoyStatusAsOf != "" {
+		return runConvoyStatusAsOf(args)
+	}
+
 	townBeads, err := getTownBeadsDir()
 	if err != nil {
 		return err
@@ -1654,6 +1691,180 @@ func runConvoyStatus(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// runConvoyStatusWatch redraws convoy status until interrupted. Each frame
+// runs a one-shot "gt convoy status" in a subprocess so the snapshot code
+// keeps printing straight to stdout.
+func runConvoyStatusWatch(args []string) error {
+	if convoyStatusJSON {
+		return fmt.Errorf("--json and --watch cannot be used together")
+	}
+	if convoyStatusAsOf != "" {
+		return fmt.Errorf("--as-of and --watch cannot be used together")
+	}
+	if convoyStatusEvery <= 0 {
+		return fmt.Errorf("interval must be positive, got %d", convoyStatusEvery)
+	}
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	self, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("locating gt binary: %w", err)
+	}
+
+	snapshotArgs := append([]string{"convoy", "status"}, args...)
+	env := os.Environ()
+	if term.IsTerminal(int(os.Stdout.Fd())) {
+		env = append(env, "CLICOLOR_FORCE=1")
+	}
+	render := func(w io.Writer) error {
+		snap := exec.Command(self, snapshotArgs...) //nolint:gosec // G204: re-invokes this binary
+		snap.Env = env
+		var out bytes.Buffer
+		snap.Stdout = &out
+		snap.Stderr = &out
+		err := snap.Run()
+		_, _ = w.Write(out.Bytes())
+		if err != nil && out.Len() == 0 {
+			return err
+		}
+		return nil
+	}
+
+	title := "gt convoy status --watch"
+	if len(args) > 0 {
+		title = "gt convoy status " + args[0] + " --watch"
+	}
+	loop := &watchLoop{
+		title:    title,
+		interval: time.Duration(convoyStatusEvery) * time.Second,
+		render:   render,
+	}
+	if convoyStatusNotify {
+		loop.attachNotifier(townRoot)
+	}
+	return loop.run()
+}
+
+// runConvoyStatusAsOf shows convoy status as recorded in Dolt history.
+// Worker details are omitted: they describe live sessions, not the past.
+func runConvoyStatusAsOf(args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	at, err := doltserver.ParseAsOf(convoyStatusAsOf, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if len(args) == 0 {
+		convoys, err := doltserver.OpenIssuesAsOf(townRoot, doltserver.DatabaseForRig(townRoot, "hq"), "convoy", at)
+		if err != nil {
+			return err
+		}
+		if convoyStatusJSON {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			return enc.Encode(convoys)
+		}
+		if len(convoys) == 0 {
+			fmt.Printf("No active convoys as of %s.\n", at)
+			return nil
+		}
+		fmt.Printf("%s\n\n", style.Bold.Render("Active Convoys as of "+at.String()))
+		for _, c := range convoys {
+			fmt.Printf("  🚚 %s: %s\n", c.ID, c.Title)
+		}
+		return nil
+	}
+
+	convoyID := args[0]
+	if n, err := strconv.Atoi(convoyID); err == nil && n > 0 {
+		resolved, err := resolveConvoyNumber(filepath.Join(townRoot, ".beads"), n)
+		if err != nil {
+			return err
+		}
+		convoyID = resolved
+	}
+
+	found, err := doltserver.BeadsAsOf(townRoot, []string{convoyID}, at)
+	if err != nil {
+		return err
+	}
+	convoy, ok := found[convoyID]
+	if !ok {
+		return fmt.Errorf("convoy '%s' did not exist as of %s", convoyID, at)
+	}
+	trackedIDs, err := doltserver.TrackedAsOf(townRoot, convoyID, at)
+	if err != nil {
+		return err
+	}
+	details, err := doltserver.BeadsAsOf(townRoot, trackedIDs, at)
+	if err != nil {
+		return err
+	}
+
+	tracked := make([]trackedIssueInfo, 0, len(trackedIDs))
+	completed := 0
+	for _, id := range trackedIDs {
+		info := trackedIssueInfo{ID: id, Type: "tracks", Status: "missing"}
+		if d, ok := details[id]; ok {
+			info.Title, info.Status, info.IssueType, info.Assignee = d.Title, d.Status, d.Type, d.Assignee
+		}
+		if info.Status == "closed" {
+			completed++
+		}
+		tracked = append(tracked, info)
+	}
+
+	if convoyStatusJSON {
+		out := struct {
+			ID        string             `json:"id"`
+			Title     string             `json:"title"`
+			Status    string             `json:"status"`
+			AsOf      string             `json:"as_of"`
+			Tracked   []trackedIssueInfo `json:"tracked"`
+			Completed int                `json:"completed"`
+			Total     int                `json:"total"`
+		}{convoy.ID, convoy.Title, convoy.Status, at.String(), tracked, completed, len(tracked)}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(out)
+	}
+
+	fmt.Printf("🚚 %s %s\n", style.Bold.Render(convoy.ID+":"), convoy.Title)
+	fmt.Printf("  %s\n\n", style.Dim.Render("as of "+at.String()))
+	fmt.Printf("  Status:    %s\n", formatConvoyStatus(convoy.Status))
+	fmt.Printf("  Progress:  %d/%d completed\n", completed, len(tracked))
+	fmt.Printf("  Created:   %s\n", convoy.CreatedAt)
+	if convoy.ClosedAt != "" {
+		fmt.Printf("  Closed:    %s\n", convoy.ClosedAt)
+	}
+	if len(tracked) > 0 {
+		fmt.Printf("\n  %s\n", style.Bold.Render("Tracked Issues:"))
+		for _, t := range tracked {
+			status := "○"
+			switch t.Status {
+			case "closed":
+				status = "✓"
+			case "in_progress", "hooked":
+				status = "▶"
+			}
+			bracketContent := t.IssueType
+			if t.Assignee != "" {
+				parts := strings.Split(t.Assignee, "/")
+				bracketContent = parts[len(parts)-1]
+			} else if bracketContent == "" {
+				bracketContent = t.Status
+			}
+			fmt.Printf("    %s %s: %s [%s]\n", status, t.ID, t.Title, bracketContent)
+		}
+	}
+	return nil
+}
+
 func showAllConvoyStatus(townBeads string) error {
 	// List all convoy-type issues
 	listArgs := []string{"list", "--type=convoy", "--status=open", "--json"}
@@ -16,6 +16,7 @@ import (
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/constants"
 	"github.com/steveyegge/gastown/internal/deacon"
+	"github.com/steveyegge/gastown/internal/events"
 	"github.com/steveyegge/gastown/internal/polecat"
 	"github.com/steveyegge/gastown/internal/runtime"
 	"github.com/steveyegge/gastown/internal/session"
@@ -911,6 +912,7 @@ func runDeaconTriggerPending(cmd *cobra.Command, args []string) error {
 
 // runDeaconHealthCheck implements the health-check command.
 // It sends a HEALTH_CHECK nudge to an agent, waits for response, and tracks state.
+// In patrol shadow mode it doesn't nudge: see shadowHealthCheck.
 func runDeaconHealthCheck(cmd *cobra.Command, args []string) error {
 	agent := args[0]
 
@@ -918,9 +920,10 @@ func runDeaconHealthCheck(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("not in a Gas Town workspace: %w", err)
 	}
+	shadow := deacon.InShadow(townRoot)
 
 	// Load health check state
-	state, err := deacon.LoadHealthCheckState(townRoot)
+	state, err := loadHealthCheckState(townRoot, shadow)
 	if err != nil {
 		return fmt.Errorf("loading health check state: %w", err)
 	}
@@ -952,6 +955,10 @@ func runDeaconHealthCheck(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	if shadow {
+		return shadowHealthCheck(townRoot, agent, sessionName, t, state, agentState)
+	}
+
 	// Record ping
 	agentState.RecordPing()
 
@@ -1011,6 +1018,7 @@ Done:
 	// Record result
 	if responded {
 		agentState.RecordResponse()
+		_ = events.LogReceipt(townRoot, "deacon", healthCheckReceipt(agent, sessionName, true, agentState))
 		if err := deacon.SaveHealthCheckState(townRoot, state); err != nil {
 			style.PrintWarning("failed to save health check state: %v", err)
 		}
@@ -1024,6 +1032,10 @@ Done:
 	if err := deacon.SaveHealthCheckState(townRoot, state); err != nil {
 		style.PrintWarning("failed to save health check state: %v", err)
 	}
+	receipt := healthCheckReceipt(agent, sessionName, false, agentState)
+	receipt.Reason = fmt.Sprintf("no bead update within %s (%d/%d consecutive failures)",
+		healthCheckTimeout, agentState.ConsecutiveFailures, healthCheckFailures)
+	_ = events.LogReceipt(townRoot, "deacon", receipt)
 
 	fmt.Printf("%s Agent %s did not respond (consecutive failures: %d/%d)\n",
 		style.Dim.Render("⚠"), agent, agentState.ConsecutiveFailures, healthCheckFailures)
@@ -1037,8 +1049,90 @@ Done:
 	return nil
 }
 
+// healthCheckReceipt explains a health check outcome already recorded in st.
+func healthCheckReceipt(agent, sessionName string, responded bool, st *deacon.AgentHealthState) events.Receipt {
+	r := events.Receipt{
+		Agent:   agent,
+		Session: sessionName,
+		Rule:    "health_check",
+		Inputs: map[string]interface{}{
+			"responded":            responded,
+			"consecutive_failures": st.ConsecutiveFailures,
+		},
+		Thresholds: map[string]interface{}{
+			"timeout":  healthCheckTimeout.String(),
+			"failures": healthCheckFailures,
+		},
+		Decision: "responsive",
+		Action:   "nudged",
+	}
+	if !responded {
+		r.Decision = "unresponsive"
+		if st.ShouldForceKill(healthCheckFailures) {
+			r.Decision = "force-kill-due"
+		}
+	}
+	return r
+}
+
+// shadowHealthCheck judges an agent's health in patrol shadow mode, where
+// no HEALTH_CHECK nudge may be sent. An agent counts as responsive if its
+// session produced output since the previous check. Idle agents that would
+// have answered a nudge count as failures, so shadow force-kill decisions
+// are an upper bound on what the live check would do.
+func shadowHealthCheck(townRoot, agent, sessionName string, t *tmux.Tmux, state *deacon.HealthCheckState, agentState *deacon.AgentHealthState) error {
+	since := agentState.LastPingTime
+	if since.IsZero() {
+		since = time.Now().Add(-healthCheckTimeout)
+	}
+	lastActivity, err := t.GetSessionActivity(sessionName)
+	responded := err == nil && lastActivity.After(since)
+
+	agentState.RecordPing()
+	if responded {
+		agentState.RecordResponse()
+	} else {
+		agentState.RecordFailure()
+	}
+	if err := deacon.SaveShadowHealthCheckState(townRoot, state); err != nil {
+		style.PrintWarning("failed to save shadow health check state: %v", err)
+	}
+
+	receipt := healthCheckReceipt(agent, sessionName, responded, agentState)
+	receipt.Shadow = true
+	receipt.Inputs["probe"] = "session-activity"
+	if !responded {
+		receipt.Reason = fmt.Sprintf("no session output since %s (%d/%d consecutive failures)",
+			since.Format(time.RFC3339), agentState.ConsecutiveFailures, healthCheckFailures)
+	}
+	_ = events.LogReceipt(townRoot, "deacon", receipt)
+
+	if responded {
+		fmt.Printf("%s Shadow mode: agent %s active since last check (would nudge)\n",
+			style.Dim.Render("○"), agent)
+		return nil
+	}
+	fmt.Printf("%s Shadow mode: agent %s idle since last check (shadow failures: %d/%d, would nudge)\n",
+		style.Dim.Render("⚠"), agent, agentState.ConsecutiveFailures, healthCheckFailures)
+	if agentState.ShouldForceKill(healthCheckFailures) {
+		fmt.Printf("%s Agent %s would be force-killed\n", style.Bold.Render("✗"), agent)
+		return NewSilentExit(2) // same signal as live, so the shadow force-kill runs
+	}
+	return nil
+}
+
+// loadHealthCheckState loads live health check state, or the separate
+// state shadow mode keeps so its counters never leak into live decisions.
+func loadHealthCheckState(townRoot string, shadow bool) (*deacon.HealthCheckState, error) {
+	if shadow {
+		return deacon.LoadShadowHealthCheckState(townRoot)
+	}
+	return deacon.LoadHealthCheckState(townRoot)
+}
+
 // runDeaconForceKill implements the force-kill command.
-// It kills a stuck agent session and updates its bead state.
+// It kills a stuck agent session and updates its bead state. In patrol
+// shadow mode it only records that it would have.
 func runDeaconForceKill(cmd *cobra.Command, args []string) error {
 	agent := args[0]
 
@@ -1046,9 +1140,10 @@ func runDeaconForceKill(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("not in a Gas Town workspace: %w", err)
 	}
+	shadow := deacon.InShadow(townRoot)
 
 	// Load health check state
-	state, err := deacon.LoadHealthCheckState(townRoot)
+	state, err := loadHealthCheckState(townRoot, shadow)
 	if err != nil {
 		return fmt.Errorf("loading health check state: %w", err)
 	}
@@ -1086,6 +1181,36 @@ func runDeaconForceKill(cmd *cobra.Command, args []string) error {
 			agentState.ConsecutiveFailures)
 	}
 
+	receipt := events.Receipt{
+		Agent:   agent,
+		Session: sessionName,
+		Rule:    "force_kill",
+		Inputs: map[string]interface{}{
+			"consecutive_failures": agentState.ConsecutiveFailures,
+			"previous_kills":       agentState.ForceKillCount,
+		},
+		Thresholds: map[string]interface{}{
+			"cooldown": healthCheckCooldown.String(),
+		},
+		Decision: "unresponsive",
+		Action:   "killed",
+		Reason:   reason,
+	}
+	if forceKillReason != "" {
+		receipt.Decision = "requested"
+	}
+
+	if shadow {
+		receipt.Shadow = true
+		_ = events.LogReceipt(townRoot, "deacon", receipt)
+		agentState.RecordForceKill()
+		if err := deacon.SaveShadowHealthCheckState(townRoot, state); err != nil {
+			style.PrintWarning("failed to save shadow health check state: %v", err)
+		}
+		fmt.Printf("%s Shadow mode: would force-kill agent %s (%s)\n", style.Bold.Render("?"), agent, reason)
+		return nil
+	}
+
 	// Step 1: Log the intervention (send mail to agent)
 	fmt.Printf("%s Sending force-kill notification to %s...\n", style.Dim.Render("1."), agent)
 	mailBody := fmt.Sprintf("Deacon detected %s as unresponsive.\nReason: %s\nAction: force-killing session", agent, reason)
@@ -1095,8 +1220,12 @@ func runDeaconForceKill(cmd *cobra.Command, args []string) error {
 	// Use KillSessionWithProcesses to ensure all descendant processes are killed.
 	fmt.Printf("%s Killing tmux session %s...\n", style.Dim.Render("2."), sessionName)
 	if err := t.KillSessionWithProcesses(sessionName); err != nil {
+		receipt.Action = "kill-failed"
+		receipt.Error = err.Error()
+		_ = events.LogReceipt(townRoot, "deacon", receipt)
 		return fmt.Errorf("killing session: %w", err)
 	}
+	_ = events.LogReceipt(townRoot, "deacon", receipt)
 
 	// Step 3: Update agent bead state (optional - best effort)
 	fmt.Printf("%s Updating agent bead state to 'killed'...\n", style.Dim.Render("3."))
@@ -1290,7 +1419,7 @@ func runDeaconStaleHooks(cmd *cobra.Command, args []string) error {
 		action := "skipped (agent alive)"
 
 		if !r.AgentAlive {
-			if staleHooksDryRun {
+			if staleHooksDryRun || result.Shadow {
 				status = style.Bold.Render("?")
 				action = "would unhook (agent dead)"
 			} else if r.Unhooked {
@@ -1335,6 +1464,9 @@ func runDeaconStaleHooks(cmd *cobra.Command, args []string) error {
 	if staleHooksDryRun {
 		fmt.Printf("\n%s Dry run - no changes made. Run without --dry-run to unhook.\n",
 			style.Dim.Render("ℹ"))
+	} else if result.Shadow {
+		fmt.Printf("\n%s Patrol shadow mode - no changes made, decisions recorded. See 'gt patrol shadow report'.\n",
+			style.Dim.Render("ℹ"))
 	} else if result.Unhooked > 0 {
 		fmt.Printf("\n%s Unhooked %d stale bead(s)\n",
 			style.Bold.Render("✓"), result.Unhooked)
@@ -17,7 +17,9 @@ var dndCmd = &cobra.Command{
 	Long: `Control notification level for the current agent.
 
 Do Not Disturb (DND) mode mutes non-critical notifications,
-allowing you to focus on work without interruption.
+allowing you to focus on work without interruption. The overseer
+notifier (gt notify watch, gt status --watch --notify) honors DND too:
+only critical escalations and mass session deaths get through.
 
 Subcommands:
   on      Enable DND mode (mute notifications)
@@ -2,11 +2,14 @@ package cmd
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/doctor"
+	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/workspace"
 )
 
@@ -16,6 +19,12 @@ var (
 	doctorRig             string
 	doctorRestartSessions bool
 	doctorSlow            string
+	doctorJobs            int
+	doctorChecks          []string
+	doctorRecord          bool
+	doctorEscalate        bool
+	doctorFormat          string
+	doctorPlan            bool
 )
 
 var doctorCmd = &cobra.Command{
@@ -98,10 +107,41 @@ Patrol checks:
   - patrol-hooks-wired       Verify daemon triggers patrols
   - patrol-not-stuck         Detect stale wisps (>1h)
   - patrol-plugins-accessible Verify plugin directories
+  - patrol-shadow-state      Report shadow mode; error if its file is unreadable
+
+Plugin checks:
+  Executables in <town>/doctor.d/ and <rig>/doctor.d/ run as extra checks,
+  named after the file (rig plugins as <rig>/<name>). Each prints a JSON
+  result: {"status": "ok|warning|error", "message": ..., "details": [...],
+  "fix_hint": ...}. Header lines such as "# gt-doctor-timeout: 2m",
+  "# gt-doctor-category: Rig" and "# gt-doctor-fixable: true" set the
+  timeout (default 30s), category and whether --fix runs "<plugin> --fix".
+  GT_TOWN_ROOT and GT_RIG are set in the plugin's environment.
 
 Use --fix to attempt automatic fixes for issues that support it.
 Use --rig to check a specific rig instead of the entire workspace.
-Use --slow to highlight slow checks (default threshold: 1s, e.g. --slow=500ms).`,
+Use --slow to highlight slow checks (default threshold: 1s, e.g. --slow=500ms).
+
+Independent checks run concurrently (--jobs, default 8; --jobs=1 runs them
+one at a time). Results are always listed in the order above. Checks that
+need a failing prerequisite (e.g. bead queries when bd or Dolt is broken)
+are reported as skipped instead of failing noisily. With --fix, fixes start
+once every check has run, one at a time with prerequisites first, and a
+check whose prerequisite was fixed is re-run before its own fix.
+
+Use --checks to run only the named checks. Use --record to append the
+results to the doctor history (see 'gt doctor history'); with --escalate,
+checks that were OK when last recorded and now fail raise an escalation.
+The daemon's doctor patrol runs 'gt doctor --record --escalate' on a
+schedule when enabled in mayor/daemon.json.
+
+Use --format json or --format sarif for machine-readable output: the full
+report (status, message, details, fix hint, category and timing of every
+check) is written to stdout once all checks finish, and progress output
+goes to stderr. Use --fix --plan to list what each fixable check would
+change (files, beads, sessions, git worktrees) without changing anything.
+Checks that can't itemize their fix list what they found instead, and
+--plan then exits non-zero naming them.`,
 	RunE: runDoctor,
 }
 
@@ -113,10 +153,28 @@ func init() {
 	doctorCmd.Flags().StringVar(&doctorSlow, "slow", "", "Highlight slow checks (optional threshold, default 1s)")
 	// Allow --slow without a value (uses default 1s)
 	doctorCmd.Flags().Lookup("slow").NoOptDefVal = "1s"
+	doctorCmd.Flags().IntVarP(&doctorJobs, "jobs", "j", 0, "Run up to N checks at once (1 = sequential)")
+	doctorCmd.Flags().StringSliceVar(&doctorChecks, "checks", nil, "Run only these checks (comma-separated names)")
+	doctorCmd.Flags().BoolVar(&doctorRecord, "record", false, "Append results to the doctor history")
+	doctorCmd.Flags().BoolVar(&doctorEscalate, "escalate", false, "Escalate checks that regressed from OK to error (use with --record)")
+	doctorCmd.Flags().StringVar(&doctorFormat, "format", doctor.FormatText, "Output format: text, json or sarif")
+	doctorCmd.Flags().BoolVar(&doctorPlan, "plan", false, "With --fix, show what each fix would change without applying it")
 	rootCmd.AddCommand(doctorCmd)
 }
 
 func runDoctor(cmd *cobra.Command, args []string) error {
+	if doctorEscalate && !doctorRecord {
+		return fmt.Errorf("--escalate requires --record")
+	}
+	if doctorPlan && !doctorFix {
+		return fmt.Errorf("--plan requires --fix")
+	}
+	switch doctorFormat {
+	case doctor.FormatText, doctor.FormatJSON, doctor.FormatSARIF:
+	default:
+		return fmt.Errorf("invalid --format %q (want text, json or sarif)", doctorFormat)
+	}
+
 	// Find town root
 	townRoot, err := workspace.FindFromCwdOrError()
 	if err != nil {
@@ -133,6 +191,7 @@ func runDoctor(cmd *cobra.Command, args []string) error {
 
 	// Create doctor and register checks
 	d := doctor.NewDoctor()
+	d.SetWorkers(doctorJobs)
 
 	// Register workspace-level checks first (fundamental)
 	d.RegisterAll(doctor.WorkspaceChecks()...)
@@ -175,12 +234,14 @@ func runDoctor(cmd *cobra.Command, args []string) error {
 	d.Register(doctor.NewThemeCheck())
 	d.Register(doctor.NewCrashReportCheck())
 	d.Register(doctor.NewEnvVarsCheck())
+	d.Register(doctor.NewRedactionCheck())
 
 	// Patrol system checks
 	d.Register(doctor.NewPatrolMoleculesExistCheck())
 	d.Register(doctor.NewPatrolHooksWiredCheck())
 	d.Register(doctor.NewPatrolNotStuckCheck())
 	d.Register(doctor.NewPatrolPluginsAccessibleCheck())
+	d.Register(doctor.NewPatrolShadowStateCheck())
 	d.Register(doctor.NewAgentBeadsCheck())
 	d.Register(doctor.NewStaleAgentBeadsCheck())
 	d.Register(doctor.NewRigBeadsCheck())
@@ -235,6 +296,15 @@ func runDoctor(cmd *cobra.Command, args []string) error {
 		d.RegisterAll(doctor.RigChecks()...)
 	}
 
+	// External checks from doctor.d (town, and each rig or just --rig)
+	d.RegisterAll(doctor.DiscoverPluginChecks(townRoot, doctorRig)...)
+
+	if len(doctorChecks) > 0 {
+		if unknown := d.Only(doctorChecks); len(unknown) > 0 {
+			return fmt.Errorf("unknown check(s): %s", strings.Join(unknown, ", "))
+		}
+	}
+
 	// Parse slow threshold (0 = disabled)
 	var slowThreshold time.Duration
 	if doctorSlow != "" {
@@ -245,17 +315,55 @@ func runDoctor(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Machine-readable output owns stdout; progress printed by fixes and
+	// escalations goes to stderr instead.
+	var stream, progress io.Writer = os.Stdout, os.Stdout
+	if doctorFormat != doctor.FormatText {
+		stream, progress = nil, os.Stderr
+	} else {
+		fmt.Println() // Initial blank line
+	}
+	ctx.Out = progress
+
 	// Run checks with streaming output
-	fmt.Println() // Initial blank line
 	var report *doctor.Report
-	if doctorFix {
-		report = d.FixStreaming(ctx, os.Stdout, slowThreshold)
-	} else {
-		report = d.RunStreaming(ctx, os.Stdout, slowThreshold)
+	var plans []*doctor.FixPlan
+	switch {
+	case doctorPlan:
+		report, plans = d.PlanFixes(ctx, stream, slowThreshold)
+	case doctorFix:
+		report = d.FixStreaming(ctx, stream, slowThreshold)
+	default:
+		report = d.RunStreaming(ctx, stream, slowThreshold)
 	}
 
-	// Print summary (checks were already printed during streaming)
-	report.PrintSummaryOnly(os.Stdout, doctorVerbose, slowThreshold)
+	switch doctorFormat {
+	case doctor.FormatJSON:
+		if err := doctor.WriteJSON(os.Stdout, report, plans); err != nil {
+			return fmt.Errorf("writing report: %w", err)
+		}
+	case doctor.FormatSARIF:
+		if err := doctor.WriteSARIF(os.Stdout, report, d.Checks(), plans, Version); err != nil {
+			return fmt.Errorf("writing report: %w", err)
+		}
+	default:
+		// Print summary (checks were already printed during streaming)
+		report.PrintSummaryOnly(os.Stdout, doctorVerbose, slowThreshold)
+		if doctorPlan {
+			doctor.PrintFixPlans(os.Stdout, plans)
+		}
+	}
+
+	if doctorRecord {
+		if err := recordDoctorRun(progress, townRoot, report, doctorEscalate); err != nil {
+			fmt.Fprintf(progress, "%s could not record doctor history: %v\n", style.Warning.Render("⚠"), err)
+		}
+	}
+
+	// A plan that leaves out changes the fix would make isn't a preview.
+	if unplanned := doctor.Unpreviewable(plans); len(unplanned) > 0 {
+		return fmt.Errorf("fix plan incomplete: %d fix(es) can't be previewed: %s", len(unplanned), strings.Join(unplanned, ", "))
+	}
 
 	// Exit with error code if there are errors
 	if report.HasErrors() {
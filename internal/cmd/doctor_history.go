@@ -0,0 +1,206 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/doctor"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/ui"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var (
+	doctorHistorySince    string
+	doctorHistoryChecks   []string
+	doctorHistoryFlapping bool
+	doctorHistoryJSON     bool
+)
+
+var doctorHistoryCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Show doctor check trends and flapping checks",
+	Long: `Show how doctor checks have fared over recorded runs.
+
+Runs are recorded by 'gt doctor --record', which the daemon's doctor patrol
+runs on a schedule. For each check this shows its current status, how many
+recorded runs passed, warned or failed, and its recent results (oldest
+first). A check that changed status 3 or more times in the window is
+flagged as flapping.
+
+Examples:
+  gt doctor history                      # Last 7 days
+  gt doctor history --since 24h
+  gt doctor history --check stale-agent-beads,orphan-sessions
+  gt doctor history --flapping --json`,
+	Args: cobra.NoArgs,
+	RunE: runDoctorHistory,
+}
+
+func init() {
+	doctorHistoryCmd.Flags().StringVar(&doctorHistorySince, "since", "7d", "Window to summarize (e.g. 24h, 30d)")
+	doctorHistoryCmd.Flags().StringSliceVar(&doctorHistoryChecks, "check", nil, "Only show these checks")
+	doctorHistoryCmd.Flags().BoolVar(&doctorHistoryFlapping, "flapping", false, "Only show flapping checks")
+	doctorHistoryCmd.Flags().BoolVar(&doctorHistoryJSON, "json", false, "Output as JSON")
+	doctorCmd.AddCommand(doctorHistoryCmd)
+}
+
+func runDoctorHistory(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	window, err := parseDuration(doctorHistorySince)
+	if err != nil {
+		return fmt.Errorf("invalid --since duration: %w", err)
+	}
+	entries, err := doctor.LoadHistory(townRoot, time.Now().Add(-window))
+	if err != nil {
+		return err
+	}
+	trends := filterTrends(doctor.Trends(entries), doctorHistoryChecks, doctorHistoryFlapping)
+
+	if doctorHistoryJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(trends)
+	}
+
+	if len(entries) == 0 {
+		fmt.Printf("No doctor runs recorded in the last %s.\n", doctorHistorySince)
+		fmt.Println(ui.RenderMuted("  Record one with: gt doctor --record"))
+		return nil
+	}
+	fmt.Printf("%s %d run(s) since %s\n\n", style.Bold.Render("Doctor history:"),
+		len(entries), entries[0].Time.Local().Format("2006-01-02 15:04"))
+	if len(trends) == 0 {
+		fmt.Println(ui.RenderMuted("  No matching checks."))
+		return nil
+	}
+
+	nameWidth := 0
+	for _, t := range trends {
+		if len(t.Name) > nameWidth {
+			nameWidth = len(t.Name)
+		}
+	}
+	for _, t := range trends {
+		fmt.Printf("  %s  %-*s  %s  %s", trendIcon(t.Current), nameWidth, t.Name,
+			renderTrend(t.Recent), ui.RenderMuted(fmt.Sprintf("%d ok / %d warn / %d err", t.OK, t.Warnings, t.Errors)))
+		if t.Flapping() {
+			fmt.Printf("  %s", style.Warning.Render(fmt.Sprintf("flapping (%d changes)", t.Changes)))
+		}
+		fmt.Println()
+		if t.Current != doctor.StatusOK && t.Message != "" {
+			fmt.Printf("     %s%s\n", ui.MutedStyle.Render(ui.TreeLast), ui.RenderMuted(t.Message))
+		}
+		if t.Changes > 0 && !t.LastChange.IsZero() {
+			fmt.Printf("     %s%s\n", ui.MutedStyle.Render(ui.TreeLast),
+				ui.RenderMuted("last changed "+relativeTime(t.LastChange)))
+		}
+	}
+	return nil
+}
+
+// filterTrends applies --check and --flapping.
+func filterTrends(trends []*doctor.CheckTrend, names []string, flappingOnly bool) []*doctor.CheckTrend {
+	want := make(map[string]bool, len(names))
+	for _, n := range names {
+		want[n] = true
+	}
+	out := make([]*doctor.CheckTrend, 0, len(trends))
+	for _, t := range trends {
+		if len(want) > 0 && !want[t.Name] {
+			continue
+		}
+		if flappingOnly && !t.Flapping() {
+			continue
+		}
+		out = append(out, t)
+	}
+	return out
+}
+
+func trendIcon(s doctor.CheckStatus) string {
+	switch s {
+	case doctor.StatusOK:
+		return ui.RenderPassIcon()
+	case doctor.StatusWarning:
+		return ui.RenderWarnIcon()
+	case doctor.StatusError:
+		return ui.RenderFailIcon()
+	default:
+		return ui.RenderSkipIcon()
+	}
+}
+
+// renderTrend renders recent results as one glyph per run.
+func renderTrend(recent []doctor.CheckStatus) string {
+	var sb strings.Builder
+	for _, s := range recent {
+		switch s {
+		case doctor.StatusOK:
+			sb.WriteString(ui.PassStyle.Render("▁"))
+		case doctor.StatusWarning:
+			sb.WriteString(ui.WarnStyle.Render("▄"))
+		default:
+			sb.WriteString(ui.FailStyle.Render("█"))
+		}
+	}
+	// Pad so the counts line up across checks with fewer runs
+	if pad := doctor.TrendLength - len(recent); pad > 0 {
+		sb.WriteString(strings.Repeat(" ", pad))
+	}
+	return sb.String()
+}
+
+// recordDoctorRun appends report to the doctor history. With escalate, each
+// check that was OK when last recorded and is now an error raises an
+// escalation; a check that stays broken is escalated only once.
+func recordDoctorRun(w io.Writer, townRoot string, report *doctor.Report, escalate bool) error {
+	prior, err := doctor.LoadHistory(townRoot, time.Time{})
+	if err != nil {
+		return err
+	}
+	regressed := doctor.Regressions(doctor.LastStatuses(prior), report)
+	if err := doctor.AppendHistory(townRoot, doctor.NewHistoryEntry(report)); err != nil {
+		return err
+	}
+	if !escalate || len(regressed) == 0 {
+		return nil
+	}
+
+	cfg, err := config.LoadOrCreateEscalationConfig(config.EscalationConfigPath(townRoot))
+	if err != nil {
+		return fmt.Errorf("loading escalation config: %w", err)
+	}
+	for _, c := range regressed {
+		reason := c.Message
+		if len(c.Details) > 0 {
+			reason += "\n\n" + strings.Join(c.Details, "\n")
+		}
+		if c.FixHint != "" {
+			reason += "\n\nFix: " + c.FixHint
+		}
+		id, _, _, err := raiseEscalation(townRoot, cfg, escalation{
+			Description: fmt.Sprintf("doctor: %s regressed from OK to error", c.Name),
+			Severity:    config.SeverityHigh,
+			Reason:      reason,
+			Source:      "doctor:" + c.Name,
+			From:        "doctor",
+		})
+		if err != nil {
+			fmt.Fprintf(w, "%s could not escalate %s regression: %v\n", style.Warning.Render("⚠"), c.Name, err)
+			continue
+		}
+		fmt.Fprintf(w, "%s Escalated %s regression: %s\n", style.Warning.Render("⚠"), c.Name, id)
+	}
+	return nil
+}
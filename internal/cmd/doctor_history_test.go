@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"io"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/doctor"
+)
+
+func TestFilterTrends(t *testing.T) {
+	trends := []*doctor.CheckTrend{
+		{Name: "flappy", Changes: doctor.FlapThreshold},
+		{Name: "steady"},
+		{Name: "broken", Current: doctor.StatusError},
+	}
+	if got := filterTrends(trends, nil, false); len(got) != 3 {
+		t.Errorf("no filter kept %d, want 3", len(got))
+	}
+	if got := filterTrends(trends, nil, true); len(got) != 1 || got[0].Name != "flappy" {
+		t.Errorf("--flapping = %v", got)
+	}
+	if got := filterTrends(trends, []string{"steady", "broken"}, false); len(got) != 2 {
+		t.Errorf("--check kept %d, want 2", len(got))
+	}
+}
+
+func TestRecordDoctorRun(t *testing.T) {
+	town := t.TempDir()
+	report := doctor.NewReport()
+	report.Add(&doctor.CheckResult{Name: "a", Status: doctor.StatusOK})
+	if err := recordDoctorRun(io.Discard, town, report, false); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := doctor.LoadHistory(town, report.Timestamp.Add(-1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || len(entries[0].Checks) != 1 || entries[0].Checks[0].Name != "a" {
+		t.Errorf("recorded = %+v", entries)
+	}
+}
@@ -26,7 +26,9 @@ import (
 	"github.com/steveyegge/gastown/internal/telemetry"
 	"github.com/steveyegge/gastown/internal/tmux"
 	"github.com/steveyegge/gastown/internal/townlog"
+	"github.com/steveyegge/gastown/internal/transcript"
 	"github.com/steveyegge/gastown/internal/workspace"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 var doneCmd = &cobra.Command{
@@ -80,7 +82,16 @@ func init() {
 }
 
 func runDone(cmd *cobra.Command, args []string) (retErr error) {
-	defer func() { telemetry.RecordDone(context.Background(), strings.ToUpper(doneStatus), retErr) }()
+	// gt done continues the sling's trace (TRACEPARENT from the session env)
+	// and hands it on to the MR bead and the mail it sends.
+	doneCtx, doneSpan := telemetry.StartSpan(context.Background(), "gt.done",
+		attribute.String("gt.status", strings.ToUpper(doneStatus)))
+	restoreTraceparent := telemetry.SetProcessTraceparent(doneCtx)
+	defer func() {
+		restoreTraceparent()
+		telemetry.RecordDone(doneCtx, strings.ToUpper(doneStatus), retErr)
+		telemetry.EndSpan(doneSpan, retErr)
+	}()
 	// Guard: Only polecats should call gt done
 	// Crew, deacons, witnesses etc. don't use gt done - they persist across tasks.
 	// Polecat sessions end with gt done — the session is cleaned up, but the
@@ -114,10 +125,11 @@ func runDone(cmd *cobra.Command, args []string) (retErr error) {
 	var sessionKilled bool
 	var deferredTownRoot string
 	var deferredRoleInfo RoleInfo
+	var deferredIssueID string
 	defer func() {
 		if sessionCleanupNeeded && !sessionKilled {
 			fmt.Printf("%s Deferred session cleanup (backstop)\n", style.Bold.Render("→"))
-			if err := selfKillSession(deferredTownRoot, deferredRoleInfo); err != nil {
+			if err := selfKillSession(deferredTownRoot, deferredRoleInfo, deferredIssueID); err != nil {
 				style.PrintWarning("deferred session kill failed: %v", err)
 			}
 			retErr = NewSilentExit(0)
@@ -135,7 +147,7 @@ func runDone(cmd *cobra.Command, args []string) (retErr error) {
 		<-sigCh
 		fmt.Fprintf(os.Stderr, "\n%s Received SIGTERM — running deferred cleanup\n", style.Bold.Render("→"))
 		if sessionCleanupNeeded && !sessionKilled {
-			if err := selfKillSession(deferredTownRoot, deferredRoleInfo); err != nil {
+			if err := selfKillSession(deferredTownRoot, deferredRoleInfo, deferredIssueID); err != nil {
 				fmt.Fprintf(os.Stderr, "Warning: SIGTERM cleanup failed: %v\n", err)
 			}
 		}
@@ -333,6 +345,7 @@ func runDone(cmd *cobra.Command, args []string) (retErr error) {
 			issueID = hookIssue
 		}
 	}
+	deferredIssueID = issueID
 
 	// Write done-intent label EARLY, before push/MR operations.
 	// If gt done crashes after this point, the Witness can detect the intent
@@ -627,6 +640,9 @@ func runDone(cmd *cobra.Command, args []string) (retErr error) {
 		sourceIssueForNoMerge, err := bd.Show(issueID)
 		if err == nil {
 			attachmentFields := beads.ParseAttachmentFields(sourceIssueForNoMerge)
+			if attachmentFields != nil {
+				telemetry.LinkTraceparent(doneCtx, attachmentFields.Traceparent)
+			}
 			if attachmentFields != nil && attachmentFields.NoMerge {
 				fmt.Printf("%s No-merge mode: skipping merge queue\n", style.Bold.Render("→"))
 				fmt.Printf("  Branch: %s\n", branch)
@@ -760,6 +776,11 @@ func runDone(cmd *cobra.Command, args []string) (retErr error) {
 			description += "\nlast_conflict_sha: null"
 			description += "\nconflict_task_id: null"
 
+			// Carry the trace to the refinery so the merge joins it.
+			if tp := telemetry.Traceparent(doneCtx); tp != "" {
+				description += "\ntraceparent: " + tp
+			}
+
 			// Create MR bead (ephemeral wisp - will be cleaned up after merge)
 			mrIssue, err := bd.Create(beads.CreateOptions{
 				Title:       title,
@@ -968,7 +989,7 @@ afterDoltMerge:
 		// This is the last thing we do - the process will be killed when tmux session dies
 		// All exit types kill the session - "done means gone"
 		fmt.Printf("%s Terminating session (done means gone)\n", style.Bold.Render("→"))
-		if err := selfKillSession(townRoot, roleInfo); err != nil {
+		if err := selfKillSession(townRoot, roleInfo, issueID); err != nil {
 			// If session kill fails, fall through to normal exit
 			style.PrintWarning("session kill failed: %v", err)
 		} else {
@@ -1385,7 +1406,10 @@ func isPolecatActor(actor string) bool {
 // - GT_RIG: the rig name
 // - GT_POLECAT: the polecat name
 // Session name format: gt-<rig>-<polecat>
-func selfKillSession(townRoot string, roleInfo RoleInfo) error {
+//
+// Before the kill, the session's runtime transcript is archived into the
+// town (indexed under issueID) and session_end is recorded.
+func selfKillSession(townRoot string, roleInfo RoleInfo, issueID string) error {
 	// Get session info from environment (set at session startup)
 	rigName := os.Getenv("GT_RIG")
 	polecatName := os.Getenv("GT_POLECAT")
@@ -1409,11 +1433,17 @@ func selfKillSession(townRoot string, roleInfo RoleInfo) error {
 	if townRoot != "" {
 		logger := townlog.NewLogger(townRoot)
 		_ = logger.Log(townlog.EventKill, agentID, "self-clean: done means gone")
+		transcript.EndSession(townRoot, agentID, issueID)
 	}
 
-	// Log to events (JSON audit log with structured payload)
-	_ = events.LogFeed(events.TypeSessionDeath, agentID,
-		events.SessionDeathPayload(sessionName, agentID, "self-clean: done means gone", "gt done"))
+	// Log to events (JSON audit log with structured payload). The worktree
+	// may already be nuked, so name the town rather than resolving it from cwd.
+	deathPayload := events.SessionDeathPayload(sessionName, agentID, "self-clean: done means gone", "gt done")
+	if townRoot != "" {
+		_ = events.LogFeedIn(townRoot, events.TypeSessionDeath, agentID, deathPayload)
+	} else {
+		_ = events.LogFeed(events.TypeSessionDeath, agentID, deathPayload)
+	}
 
 	// Kill our own tmux session with proper process cleanup
 	// This will terminate Claude and all child processes, completing the self-cleaning cycle.
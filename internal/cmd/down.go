@@ -22,6 +22,7 @@ import (
 	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/transcript"
 	"github.com/steveyegge/gastown/internal/workspace"
 )
 
@@ -164,6 +165,7 @@ func runDown(cmd *cobra.Command, args []string) error {
 			printDownStatus(fmt.Sprintf("Refinery (%s)", rigName), false, err.Error())
 			allOK = false
 		} else if wasRunning {
+			transcript.EndSession(townRoot, rigName+"/refinery", "")
 			printDownStatus(fmt.Sprintf("Refinery (%s)", rigName), true, "stopped")
 		} else {
 			printDownStatus(fmt.Sprintf("Refinery (%s)", rigName), true, "not running")
@@ -184,6 +186,7 @@ func runDown(cmd *cobra.Command, args []string) error {
 			printDownStatus(fmt.Sprintf("Witness (%s)", rigName), false, err.Error())
 			allOK = false
 		} else if wasRunning {
+			transcript.EndSession(townRoot, rigName+"/witness", "")
 			printDownStatus(fmt.Sprintf("Witness (%s)", rigName), true, "stopped")
 		} else {
 			printDownStatus(fmt.Sprintf("Witness (%s)", rigName), true, "not running")
@@ -203,6 +206,7 @@ func runDown(cmd *cobra.Command, args []string) error {
 			printDownStatus(ts.Name, false, err.Error())
 			allOK = false
 		} else if stopped {
+			transcript.EndSession(townRoot, strings.ToLower(ts.Name), "")
 			printDownStatus(ts.Name, true, "stopped")
 		} else {
 			printDownStatus(ts.Name, true, "not running")
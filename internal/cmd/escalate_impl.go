@@ -79,71 +79,22 @@ func runEscalate(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	// Create escalation bead
-	bd := beads.New(beads.ResolveBeadsDir(townRoot))
-	fields := &beads.EscalationFields{
+	issueID, actions, targets, err := raiseEscalation(townRoot, escalationConfig, escalation{
+		Description: description,
 		Severity:    severity,
 		Reason:      escalateReason,
 		Source:      escalateSource,
-		EscalatedBy: agentID,
-		EscalatedAt: time.Now().Format(time.RFC3339),
 		RelatedBead: escalateRelatedBead,
-	}
-
-	issue, err := bd.CreateEscalationBead(description, fields)
+		From:        agentID,
+	})
 	if err != nil {
-		return fmt.Errorf("creating escalation bead: %w", err)
+		return err
 	}
 
-	// Get routing actions for this severity
-	actions := escalationConfig.GetRouteForSeverity(severity)
-	targets := extractMailTargetsFromActions(actions)
-
-	// Send mail to each target (actions with "mail:" prefix)
-	router := mail.NewRouter(townRoot)
-	defer router.WaitPendingNotifications()
-	for _, target := range targets {
-		msg := &mail.Message{
-			From:    agentID,
-			To:      target,
-			Subject: fmt.Sprintf("[%s] %s", strings.ToUpper(severity), description),
-			Body:    formatEscalationMailBody(issue.ID, severity, escalateReason, agentID, escalateRelatedBead),
-			Type:    mail.TypeTask,
-		}
-
-		// Set priority based on severity
-		switch severity {
-		case config.SeverityCritical:
-			msg.Priority = mail.PriorityUrgent
-		case config.SeverityHigh:
-			msg.Priority = mail.PriorityHigh
-		case config.SeverityMedium:
-			msg.Priority = mail.PriorityNormal
-		default:
-			msg.Priority = mail.PriorityLow
-		}
-
-		if err := router.Send(msg); err != nil {
-			style.PrintWarning("failed to send to %s: %v", target, err)
-		}
-	}
-
-	// Process external notification actions (email:, sms:, slack)
-	executeExternalActions(actions, escalationConfig, issue.ID, severity, description)
-
-	// Log to activity feed
-	payload := events.EscalationPayload(issue.ID, agentID, strings.Join(targets, ","), description)
-	payload["severity"] = severity
-	payload["actions"] = strings.Join(actions, ",")
-	if escalateSource != "" {
-		payload["source"] = escalateSource
-	}
-	_ = events.LogFeed(events.TypeEscalationSent, agentID, payload)
-
 	// Output
 	if escalateJSON {
 		result := map[string]interface{}{
-			"id":       issue.ID,
+			"id":       issueID,
 			"severity": severity,
 			"actions":  actions,
 			"targets":  targets,
@@ -155,7 +106,7 @@ func runEscalate(cmd *cobra.Command, args []string) error {
 		fmt.Println(string(out))
 	} else {
 		emoji := severityEmoji(severity)
-		fmt.Printf("%s Escalation created: %s\n", emoji, issue.ID)
+		fmt.Printf("%s Escalation created: %s\n", emoji, issueID)
 		fmt.Printf("  Severity: %s\n", severity)
 		if escalateSource != "" {
 			fmt.Printf("  Source: %s\n", escalateSource)
@@ -445,6 +396,84 @@ func runEscalateStale(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// escalation is a request to raise a new escalation.
+type escalation struct {
+	Description string
+	Severity    string // critical, high, medium or low
+	Reason      string
+	Source      string
+	RelatedBead string
+	From        string // escalating agent address
+}
+
+// raiseEscalation creates the escalation bead, mails the targets routed for
+// its severity, runs external notification actions and logs it to the feed.
+func raiseEscalation(townRoot string, cfg *config.EscalationConfig, e escalation) (issueID string, actions, targets []string, err error) {
+	// Create escalation bead
+	bd := beads.New(beads.ResolveBeadsDir(townRoot))
+	fields := &beads.EscalationFields{
+		Severity:    e.Severity,
+		Reason:      e.Reason,
+		Source:      e.Source,
+		EscalatedBy: e.From,
+		EscalatedAt: time.Now().Format(time.RFC3339),
+		RelatedBead: e.RelatedBead,
+	}
+
+	issue, err := bd.CreateEscalationBead(e.Description, fields)
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("creating escalation bead: %w", err)
+	}
+
+	// Get routing actions for this severity
+	actions = cfg.GetRouteForSeverity(e.Severity)
+	targets = extractMailTargetsFromActions(actions)
+
+	// Send mail to each target (actions with "mail:" prefix)
+	router := mail.NewRouter(townRoot)
+	defer router.WaitPendingNotifications()
+	for _, target := range targets {
+		msg := &mail.Message{
+			From:    e.From,
+			To:      target,
+			Subject: fmt.Sprintf("[%s] %s", strings.ToUpper(e.Severity), e.Description),
+			Body:    formatEscalationMailBody(issue.ID, e.Severity, e.Reason, e.From, e.RelatedBead),
+			Type:    mail.TypeTask,
+		}
+
+		// Set priority based on severity
+		switch e.Severity {
+		case config.SeverityCritical:
+			msg.Priority = mail.PriorityUrgent
+		case config.SeverityHigh:
+			msg.Priority = mail.PriorityHigh
+		case config.SeverityMedium:
+			msg.Priority = mail.PriorityNormal
+		default:
+			msg.Priority = mail.PriorityLow
+		}
+
+		if err := router.Send(msg); err != nil {
+			style.PrintWarning("failed to send to %s: %v", target, err)
+		}
+	}
+
+	// Process external notification actions (email:, sms:, slack)
+	executeExternalActions(actions, cfg, issue.ID, e.Severity, e.Description)
+
+	// Log to activity feed
+	payload := events.EscalationPayload(issue.ID, e.From, strings.Join(targets, ","), e.Description)
+	payload["escalation_id"] = issue.ID
+	payload["severity"] = e.Severity
+	payload["actions"] = strings.Join(actions, ",")
+	if e.Source != "" {
+		payload["source"] = e.Source
+	}
+	_ = events.LogFeed(events.TypeEscalationSent, e.From, payload)
+
+	return issue.ID, actions, targets, nil
+}
+
 func getNextSeverity(severity string) string {
 	switch severity {
 	case "low":
@@ -13,6 +13,7 @@ import (
 	"github.com/steveyegge/gastown/internal/constants"
 	"github.com/steveyegge/gastown/internal/events"
 	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/handoff"
 	"github.com/steveyegge/gastown/internal/mail"
 	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/style"
@@ -48,6 +49,16 @@ The --collect (-c) flag gathers current state (hooked work, inbox, ready beads,
 in-progress items) and includes it in the handoff mail. This provides context
 for the next session without manual summarization.
 
+Handoff mail is a structured document with sections for the goal, what is
+done, what is in progress, next steps, open questions, relevant files and
+commands to rerun. Fill them with --goal, --done, --in-progress, --next,
+--question, --file and --rerun (repeatable), or write the sections as markdown
+in the message body (see --template). Missing sections are reported as a
+warning on send; the successor's gt prime renders the document.
+
+  gt handoff --goal "Fix flaky merge test" --done "Found race in setup" \
+    --next "Add lock around fixture" --rerun "go test ./internal/refinery/..."
+
 The --cycle flag triggers automatic session cycling (used by PreCompact hooks).
 Unlike --auto (state only) or normal handoff (polecat→gt-done redirect), --cycle
 always does a full respawn regardless of role. This enables crew workers and
@@ -69,6 +80,16 @@ var (
 	handoffCycle      bool
 	handoffReason     string
 	handoffNoGitCheck bool
+	handoffTemplate   bool
+
+	// Structured handoff document sections (see internal/handoff)
+	handoffGoal       string
+	handoffDone       []string
+	handoffInProgress []string
+	handoffNext       []string
+	handoffQuestions  []string
+	handoffFiles      []string
+	handoffRerun      []string
 )
 
 func init() {
@@ -82,6 +103,14 @@ func init() {
 	handoffCmd.Flags().BoolVar(&handoffCycle, "cycle", false, "Auto-cycle session (for PreCompact hooks that want full session replacement)")
 	handoffCmd.Flags().StringVar(&handoffReason, "reason", "", "Reason for handoff (e.g., 'compaction', 'idle')")
 	handoffCmd.Flags().BoolVar(&handoffNoGitCheck, "no-git-check", false, "Skip git workspace cleanliness check")
+	handoffCmd.Flags().BoolVar(&handoffTemplate, "template", false, "Print an empty handoff document and exit")
+	handoffCmd.Flags().StringVar(&handoffGoal, "goal", "", "Handoff doc: what this work is trying to achieve")
+	handoffCmd.Flags().StringArrayVar(&handoffDone, "done", nil, "Handoff doc: completed item (repeatable)")
+	handoffCmd.Flags().StringArrayVar(&handoffInProgress, "in-progress", nil, "Handoff doc: partially done item (repeatable)")
+	handoffCmd.Flags().StringArrayVar(&handoffNext, "next", nil, "Handoff doc: next step for the successor (repeatable)")
+	handoffCmd.Flags().StringArrayVar(&handoffQuestions, "question", nil, "Handoff doc: open question (repeatable)")
+	handoffCmd.Flags().StringArrayVar(&handoffFiles, "file", nil, "Handoff doc: relevant file (repeatable)")
+	handoffCmd.Flags().StringArrayVar(&handoffRerun, "rerun", nil, "Handoff doc: command to rerun (repeatable)")
 	rootCmd.AddCommand(handoffCmd)
 }
 
@@ -98,6 +127,12 @@ func runHandoff(cmd *cobra.Command, args []string) error {
 		handoffMessage = strings.TrimRight(string(data), "\n")
 	}
 
+	if handoffTemplate {
+		fmt.Print(handoff.Template())
+		return nil
+	}
+	applyHandoffDocFlags()
+
 	// --auto mode: save state only, no session cycling.
 	// Used by PreCompact hook to preserve state before compaction.
 	// Note: auto-mode exits here, before the git-status warning check below.
@@ -229,6 +264,7 @@ func runHandoff(cmd *cobra.Command, args []string) error {
 
 	// Handing off ourselves - print feedback then respawn
 	fmt.Printf("%s Handing off %s...\n", style.Bold.Render("🤝"), currentSession)
+	warnHandoffDoc(handoffMessage)
 
 	// Log handoff event (both townlog and events feed)
 	if townRoot, err := workspace.FindFromCwd(); err == nil && townRoot != "" {
@@ -238,7 +274,7 @@ func runHandoff(cmd *cobra.Command, args []string) error {
 		}
 		_ = LogHandoff(townRoot, agent, handoffSubject)
 		// Also log to activity feed
-		_ = events.LogFeed(events.TypeHandoff, agent, events.HandoffPayload(handoffSubject, true))
+		_ = events.LogFeed(events.TypeHandoff, agent, handoffEventPayload(handoffSubject, true, handoffMessage))
 	}
 
 	// Dry run mode - show what would happen (BEFORE any side effects)
@@ -376,7 +412,7 @@ func runHandoffAuto() error {
 		if agent == "" || agent == "overseer" {
 			agent = "unknown"
 		}
-		_ = events.LogFeed(events.TypeHandoff, agent, events.HandoffPayload(subject, false))
+		_ = events.LogFeed(events.TypeHandoff, agent, handoffEventPayload(subject, false, message))
 	}
 
 	return nil
@@ -474,7 +510,7 @@ func runHandoffCycle() error {
 			agent = currentSession
 		}
 		_ = LogHandoff(townRoot, agent, subject)
-		_ = events.LogFeed(events.TypeHandoff, agent, events.HandoffPayload(subject, true))
+		_ = events.LogFeed(events.TypeHandoff, agent, handoffEventPayload(subject, true, message))
 	}
 
 	// Build restart command for fresh session
@@ -1256,7 +1292,7 @@ func hookBeadForHandoff(beadID string) error {
 }
 
 // collectHandoffState gathers current state for handoff context.
-// Collects: inbox summary, ready beads, hooked work.
+// Collects: inbox summary, ready beads, hooked work, changed files.
 func collectHandoffState() string {
 	var parts []string
 
@@ -1310,6 +1346,11 @@ func collectHandoffState() string {
 		}
 	}
 
+	// Files with uncommitted changes are the ones the successor will need
+	if files := handoffChangedFiles(); len(files) > 0 {
+		parts = append(parts, "## Relevant Files\n- "+strings.Join(files, "\n- "))
+	}
+
 	if len(parts) == 0 {
 		return "No active state to report."
 	}
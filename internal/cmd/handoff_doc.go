@@ -0,0 +1,182 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/handoff"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var (
+	handoffScoreRig   string
+	handoffScoreSince string
+	handoffScoreWeak  bool
+	handoffScoreJSON  bool
+)
+
+var handoffDocCmd = &cobra.Command{
+	Use:   "doc",
+	Short: "Work with handoff documents",
+	Long: `Work with the structured handoff documents agents leave their successors.`,
+}
+
+var handoffScoreCmd = &cobra.Command{
+	Use:   "score",
+	Short: "Grade recent handoff documents",
+	Long: `Show the quality score of recent handoffs.
+
+Every handoff records a score (0-100) for how complete its handoff document
+was, weighted by section: goal and next steps count most, open questions
+least. Scores below ` + fmt.Sprint(handoff.PassingScore) + ` are weak. The witness runs this each patrol to
+find agents whose successors are starting without context.
+
+Examples:
+  gt handoff doc score                      # All handoffs in the last 24h
+  gt handoff doc score --rig gastown --weak # Weak handoffs in one rig
+  gt handoff doc score --since 7d --json`,
+	Args: cobra.NoArgs,
+	RunE: runHandoffScore,
+}
+
+func init() {
+	handoffScoreCmd.Flags().StringVar(&handoffScoreRig, "rig", "", "Only handoffs by agents in this rig")
+	handoffScoreCmd.Flags().StringVar(&handoffScoreSince, "since", "24h", "How far back to look (e.g. 1h, 7d)")
+	handoffScoreCmd.Flags().BoolVar(&handoffScoreWeak, "weak", false, "Only show weak handoffs")
+	handoffScoreCmd.Flags().BoolVar(&handoffScoreJSON, "json", false, "Output as JSON")
+	handoffDocCmd.AddCommand(handoffScoreCmd)
+	handoffCmd.AddCommand(handoffDocCmd)
+}
+
+// applyHandoffDocFlags merges the structured section flags into the
+// handoff message. Without section flags the message is left as written.
+func applyHandoffDocFlags() {
+	if handoffGoal == "" && len(handoffDone)+len(handoffInProgress)+len(handoffNext)+
+		len(handoffQuestions)+len(handoffFiles)+len(handoffRerun) == 0 {
+		return
+	}
+	doc := handoff.New()
+	doc.Add(handoff.SectionGoal, handoffGoal)
+	doc.Add(handoff.SectionDone, handoffDone...)
+	doc.Add(handoff.SectionInProgress, handoffInProgress...)
+	doc.Add(handoff.SectionNextSteps, handoffNext...)
+	doc.Add(handoff.SectionOpenQuestions, handoffQuestions...)
+	doc.Add(handoff.SectionFiles, handoffFiles...)
+	doc.Add(handoff.SectionCommands, handoffRerun...)
+	doc.Merge(handoff.Parse(handoffMessage))
+	handoffMessage = strings.TrimRight(doc.Render(), "\n")
+}
+
+// warnHandoffDoc validates the handoff document about to be sent and
+// warns about missing sections. Non-blocking, like the git check.
+func warnHandoffDoc(message string) {
+	doc := handoff.Parse(message)
+	missing := doc.Missing()
+	if len(missing) == 0 {
+		return
+	}
+	style.PrintWarning("handoff document is missing: %s (score %d/100)",
+		strings.Join(missing, ", "), doc.Score())
+	fmt.Println("  (fill sections with --goal/--done/--in-progress/--next/--question/--file/--rerun,")
+	fmt.Println("   or see 'gt handoff --template')")
+}
+
+// handoffEventPayload builds the handoff event payload, including the
+// document's quality grade.
+func handoffEventPayload(subject string, toSession bool, message string) map[string]interface{} {
+	return handoff.AddQuality(events.HandoffPayload(subject, toSession), handoff.Parse(message))
+}
+
+// handoffChangedFiles lists files with uncommitted changes in the current
+// workspace, excluding beads data. Returns nil outside a git repo.
+func handoffChangedFiles() []string {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil
+	}
+	g := git.NewGit(cwd)
+	if !g.IsRepo() {
+		return nil
+	}
+	status, err := g.CheckUncommittedWork()
+	if err != nil {
+		return nil
+	}
+	var files []string
+	for _, f := range append(status.ModifiedFiles, status.UntrackedFiles...) {
+		if strings.HasPrefix(f, ".beads/") || strings.HasPrefix(f, ".runtime/") {
+			continue
+		}
+		files = append(files, f)
+	}
+	const maxFiles = 20
+	if len(files) > maxFiles {
+		files = append(files[:maxFiles], fmt.Sprintf("... (%d more)", len(files)-maxFiles))
+	}
+	return files
+}
+
+func runHandoffScore(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	d, err := parseDuration(handoffScoreSince)
+	if err != nil {
+		return fmt.Errorf("invalid --since duration: %w", err)
+	}
+	prefix := ""
+	if handoffScoreRig != "" {
+		prefix = handoffScoreRig + "/"
+	}
+
+	grades, err := handoff.Grades(filepath.Join(townRoot, events.EventsFile), prefix, time.Now().Add(-d))
+	if err != nil {
+		return fmt.Errorf("reading events: %w", err)
+	}
+	if handoffScoreWeak {
+		weak := grades[:0]
+		for _, g := range grades {
+			if g.Weak() {
+				weak = append(weak, g)
+			}
+		}
+		grades = weak
+	}
+
+	if handoffScoreJSON {
+		if grades == nil {
+			grades = []handoff.Grade{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(grades)
+	}
+
+	if len(grades) == 0 {
+		fmt.Println("No graded handoffs in window.")
+		return nil
+	}
+	weak := 0
+	for _, g := range grades {
+		score := style.Success.Render(fmt.Sprintf("%3d", g.Score))
+		if g.Weak() {
+			score = style.Warning.Render(fmt.Sprintf("%3d", g.Score))
+			weak++
+		}
+		fmt.Printf("%s  %s  %-28s %s\n", g.Time.Local().Format("2006-01-02 15:04"), score, g.Agent, g.Subject)
+		if len(g.Missing) > 0 {
+			fmt.Printf("    %s\n", style.Dim.Render("missing: "+strings.Join(g.Missing, ", ")))
+		}
+	}
+	fmt.Printf("\n%d handoff(s), %d weak (score < %d)\n", len(grades), weak, handoff.PassingScore)
+	return nil
+}
@@ -496,3 +496,43 @@ func TestHandoffProcessNames(t *testing.T) {
 		}
 	})
 }
+
+func TestApplyHandoffDocFlags(t *testing.T) {
+	origMessage, origGoal, origNext, origRerun := handoffMessage, handoffGoal, handoffNext, handoffRerun
+	defer func() {
+		handoffMessage, handoffGoal, handoffNext, handoffRerun = origMessage, origGoal, origNext, origRerun
+	}()
+
+	t.Run("free-text message untouched without section flags", func(t *testing.T) {
+		handoffMessage = "just some notes"
+		handoffGoal, handoffNext, handoffRerun = "", nil, nil
+		applyHandoffDocFlags()
+		if handoffMessage != "just some notes" {
+			t.Errorf("message changed: %q", handoffMessage)
+		}
+	})
+
+	t.Run("section flags render a structured document", func(t *testing.T) {
+		handoffMessage = "## Done\n- found the race"
+		handoffGoal = "Fix flaky merge test"
+		handoffNext = []string{"add lock", "rerun CI"}
+		handoffRerun = []string{"go test ./internal/refinery/..."}
+		applyHandoffDocFlags()
+
+		for _, want := range []string{"## Goal\nFix flaky merge test", "## Done\n- found the race",
+			"## Next Steps\n- add lock\n- rerun CI", "## Commands to Rerun\n- `go test ./internal/refinery/...`"} {
+			if !strings.Contains(handoffMessage, want) {
+				t.Errorf("message missing %q:\n%s", want, handoffMessage)
+			}
+		}
+
+		payload := handoffEventPayload("subj", true, handoffMessage)
+		if payload["quality"] != 70 {
+			t.Errorf("quality = %v, want 70", payload["quality"])
+		}
+		missing, _ := payload["missing"].([]string)
+		if strings.Join(missing, ",") != "In Progress,Open Questions,Relevant Files" {
+			t.Errorf("missing = %v", payload["missing"])
+		}
+	})
+}
@@ -0,0 +1,188 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/doltserver"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var (
+	historyJSON   bool
+	historyFull   bool
+	historyFields []string
+	historySince  string
+)
+
+var historyCmd = &cobra.Command{
+	Use:     "history <bead-id>",
+	GroupID: GroupDiag,
+	Short:   "Show how a bead changed over time, commit by commit",
+	Long: `Show field-level changes to a bead across its Dolt history.
+
+Every bd write is a Dolt commit, so the full life of a bead is still on
+record: who moved it to in_progress, when the assignee changed, which labels
+and dependencies came and went. Each entry is one commit, with the
+committer and commit message. Uncommitted changes show as WORKING.
+
+Long text fields (description, design, notes, ...) are summarized unless
+--full is given.
+
+To see the whole board at a past point instead, use --as-of on gt ready
+or gt convoy status.
+
+Examples:
+  gt history gt-abc12
+  gt history gt-abc12 --field status,assignee
+  gt history hq-cv-xyz --since 2d --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runHistory,
+}
+
+func init() {
+	historyCmd.Flags().BoolVar(&historyJSON, "json", false, "Output as JSON")
+	historyCmd.Flags().BoolVar(&historyFull, "full", false, "Show long text fields in full")
+	historyCmd.Flags().StringSliceVar(&historyFields, "field", nil, "Only show changes to these fields (e.g. status,assignee,label)")
+	historyCmd.Flags().StringVar(&historySince, "since", "", "Only show commits newer than this (e.g. 6h, 2d)")
+	rootCmd.AddCommand(historyCmd)
+}
+
+// historyLongFields are summarized unless --full.
+var historyLongFields = map[string]bool{
+	"description":         true,
+	"design":              true,
+	"acceptance_criteria": true,
+	"notes":               true,
+}
+
+func runHistory(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	beadID := args[0]
+
+	var since time.Time
+	if historySince != "" {
+		d, err := parseDuration(historySince)
+		if err != nil {
+			return fmt.Errorf("invalid --since duration: %w", err)
+		}
+		since = time.Now().Add(-d)
+	}
+
+	entries, err := doltserver.BeadHistory(townRoot, beadID)
+	if err != nil {
+		return err
+	}
+	entries = filterHistory(entries, historyFields, since)
+
+	if historyJSON {
+		if entries == nil {
+			entries = []doltserver.HistoryEntry{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(entries)
+	}
+
+	if len(entries) == 0 {
+		fmt.Printf("No history for %s in %s.\n", beadID, doltserver.DatabaseForBead(townRoot, beadID))
+		return nil
+	}
+	fmt.Printf("📜 %s %s\n\n", style.Bold.Render(beadID), style.Dim.Render("("+doltserver.DatabaseForBead(townRoot, beadID)+")"))
+	for _, e := range entries {
+		printHistoryEntry(e)
+	}
+	return nil
+}
+
+// filterHistory drops entries older than since and, when fields are
+// given, changes to other fields (and entries left empty by that).
+func filterHistory(entries []doltserver.HistoryEntry, fields []string, since time.Time) []doltserver.HistoryEntry {
+	want := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		want[strings.TrimSpace(f)] = true
+	}
+	var out []doltserver.HistoryEntry
+	for _, e := range entries {
+		if !since.IsZero() && !e.Date.IsZero() && e.Date.Before(since) {
+			continue
+		}
+		if len(want) > 0 {
+			var kept []doltserver.FieldChange
+			for _, c := range e.Changes {
+				if want[c.Field] {
+					kept = append(kept, c)
+				}
+			}
+			if len(kept) == 0 {
+				continue
+			}
+			e.Changes = kept
+		}
+		out = append(out, e)
+	}
+	return out
+}
+
+func printHistoryEntry(e doltserver.HistoryEntry) {
+	when := "uncommitted"
+	if !e.Date.IsZero() {
+		when = e.Date.Local().Format("2006-01-02 15:04:05")
+	}
+	commit := e.Commit
+	if len(commit) > 8 {
+		commit = commit[:8]
+	}
+	fmt.Printf("%s  %s  %s\n", style.Bold.Render(when), style.Dim.Render(commit), e.Committer)
+	if e.Message != "" {
+		fmt.Printf("  %s\n", style.Dim.Render(truncateWithEllipsis(strings.SplitN(e.Message, "\n", 2)[0], 72)))
+	}
+	switch {
+	case e.Created:
+		fmt.Printf("  %s\n", style.Success.Render("created"))
+	case e.Deleted:
+		fmt.Printf("  %s\n", style.Error.Render("deleted"))
+	}
+	for _, c := range e.Changes {
+		if e.Created && c.From == "" && historyLongFields[c.Field] && !historyFull {
+			continue // creation already implies the initial text
+		}
+		fmt.Printf("  %s\n", formatHistoryChange(c, historyFull))
+	}
+	fmt.Println()
+}
+
+// formatHistoryChange renders one change as "field: from → to", or as an
+// add/remove line for labels and dependencies.
+func formatHistoryChange(c doltserver.FieldChange, full bool) string {
+	if c.Field == "label" || c.Field == "dependency" {
+		switch {
+		case c.From == "":
+			return style.Success.Render("+ "+c.Field+" ") + c.To
+		case c.To == "":
+			return style.Error.Render("- "+c.Field+" ") + c.From
+		}
+	}
+	if historyLongFields[c.Field] && !full {
+		return fmt.Sprintf("%-10s %s", c.Field+":", style.Dim.Render(
+			fmt.Sprintf("changed (%d → %d chars)", len(c.From), len(c.To))))
+	}
+	value := func(s string) string {
+		if s == "" {
+			return style.Dim.Render("(none)")
+		}
+		if !full {
+			s = truncateWithEllipsis(strings.ReplaceAll(s, "\n", " "), 60)
+		}
+		return s
+	}
+	return fmt.Sprintf("%-10s %s → %s", c.Field+":", value(c.From), value(c.To))
+}
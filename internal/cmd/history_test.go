@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/doltserver"
+)
+
+func TestFilterHistory(t *testing.T) {
+	now := time.Now()
+	entries := []doltserver.HistoryEntry{
+		{Commit: "old", Date: now.Add(-48 * time.Hour), Changes: []doltserver.FieldChange{{Field: "status", To: "open"}}},
+		{Commit: "new", Date: now.Add(-time.Hour), Changes: []doltserver.FieldChange{
+			{Field: "status", From: "open", To: "in_progress"},
+			{Field: "title", From: "a", To: "b"},
+		}},
+		{Commit: "WORKING", Changes: []doltserver.FieldChange{{Field: "title", From: "b", To: "c"}}},
+	}
+
+	got := filterHistory(entries, []string{"status"}, now.Add(-24*time.Hour))
+	if len(got) != 1 || got[0].Commit != "new" || len(got[0].Changes) != 1 || got[0].Changes[0].Field != "status" {
+		t.Errorf("filtered = %+v", got)
+	}
+	if len(entries[1].Changes) != 2 {
+		t.Error("filterHistory modified its input")
+	}
+	if got := filterHistory(entries, nil, time.Time{}); len(got) != 3 {
+		t.Errorf("no filter kept %d entries, want 3", len(got))
+	}
+}
+
+func TestFormatHistoryChange(t *testing.T) {
+	if got := formatHistoryChange(doltserver.FieldChange{Field: "label", To: "urgent"}, false); !strings.Contains(got, "+ label") || !strings.Contains(got, "urgent") {
+		t.Errorf("label add = %q", got)
+	}
+	long := strings.Repeat("x", 200)
+	if got := formatHistoryChange(doltserver.FieldChange{Field: "description", From: "short", To: long}, false); !strings.Contains(got, "5 → 200 chars") {
+		t.Errorf("description summary = %q", got)
+	}
+	if got := formatHistoryChange(doltserver.FieldChange{Field: "title", From: "", To: long}, false); !strings.Contains(got, "(none)") || strings.Contains(got, long) {
+		t.Errorf("title change = %q", got)
+	}
+}
@@ -31,7 +31,8 @@ Examples:
   gt krc prune              # Remove expired events
   gt krc prune --dry-run    # Preview what would be pruned
   gt krc config             # Show TTL configuration
-  gt krc config set patrol_* 12h   # Set TTL for patrol events`,
+  gt krc config set patrol_* 12h   # Set TTL for patrol events
+  gt krc rollups            # Summaries of pruned records`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return cmd.Help()
 	},
@@ -54,8 +55,22 @@ var krcPruneCmd = &cobra.Command{
 	Short: "Remove expired events",
 	Long: `Prune events that have exceeded their TTL.
 
-Events are removed from both .events.jsonl and .feed.jsonl.
-The operation is atomic (uses temp files and rename).
+Events are removed from both .events.jsonl and .feed.jsonl, and archived
+transcripts past their transcript_<role> TTL are deleted. The operation is
+atomic (uses temp files and rename).
+
+If event logs and transcripts together still exceed the disk budget, the
+records with the lowest forensic score (see 'gt krc decay') are evicted
+early, oldest first, until they fit.
+
+Some records are never pruned:
+  - events of escalations that are still open, and the escalating agent's
+    events in the context window before it escalated
+  - the lead-up to session deaths within the death protection period: the
+    agent's events in the context window before it died, and its transcript
+
+Pruned event log records and transcripts are summarized into daily rollups
+per type ('gt krc rollups').
 
 Use --dry-run to preview what would be pruned without making changes.`,
 	RunE: runKrcPrune,
@@ -70,12 +85,14 @@ Without arguments, shows the current configuration.
 
 Subcommands:
   set <pattern> <ttl>   Set TTL for event type pattern
+  set <setting> <value> Set a retention setting
   reset                 Reset to default configuration
 
 Examples:
   gt krc config                     # Show current config
   gt krc config set patrol_* 12h    # Set patrol TTL to 12 hours
   gt krc config set default 3d      # Set default TTL to 3 days
+  gt krc config set disk_budget 500MB   # Cap event logs and transcripts
   gt krc config reset               # Reset to defaults`,
 	RunE: runKrcConfig,
 }
@@ -88,7 +105,13 @@ var krcConfigSetCmd = &cobra.Command{
 Patterns support glob-style matching with * (e.g., "patrol_*" matches all patrol events).
 Use "default" as the pattern to set the default TTL.
 
-TTL format: 1h, 12h, 1d, 7d, 30d, etc.`,
+TTL format: 1h, 12h, 1d, 7d, 30d, etc.
+
+Retention settings use the same command:
+  disk_budget        Size cap for event logs plus transcripts (e.g. 500MB, 2GB; 0 disables)
+  death_protection   How long a session death protects its lead-up (e.g. 7d)
+  context_window     How far before an escalation or death events are protected (e.g. 1h)
+  rollup_ttl         How long rollups of pruned records are kept (e.g. 365d)`,
 	Args: cobra.ExactArgs(2),
 	RunE: runKrcConfigSet,
 }
@@ -118,6 +141,17 @@ Events with low forensic scores are candidates for aggressive pruning.`,
 	RunE: runKrcDecay,
 }
 
+var krcRollupsCmd = &cobra.Command{
+	Use:   "rollups",
+	Short: "Show summaries of pruned records",
+	Long: `Display the daily rollups of records removed by pruning.
+
+Each rollup counts the records of one type pruned from one day, with their
+size, the most frequent actors, and whether they expired (ttl) or were
+evicted to stay within the disk budget (budget).`,
+	RunE: runKrcRollups,
+}
+
 var krcAutoPruneStatusCmd = &cobra.Command{
 	Use:   "auto-prune-status",
 	Short: "Show auto-prune scheduling state",
@@ -133,6 +167,7 @@ var (
 	krcPruneAuto   bool
 	krcStatsJSON   bool
 	krcDecayJSON   bool
+	krcRollupsJSON bool
 )
 
 func init() {
@@ -142,6 +177,7 @@ func init() {
 	krcCmd.AddCommand(krcConfigCmd)
 	krcCmd.AddCommand(krcDecayCmd)
 	krcCmd.AddCommand(krcAutoPruneStatusCmd)
+	krcCmd.AddCommand(krcRollupsCmd)
 	krcConfigCmd.AddCommand(krcConfigSetCmd)
 	krcConfigCmd.AddCommand(krcConfigResetCmd)
 
@@ -149,6 +185,7 @@ func init() {
 	krcPruneCmd.Flags().BoolVar(&krcPruneAuto, "auto", false, "Daemon mode: only prune if PruneInterval has elapsed")
 	krcStatsCmd.Flags().BoolVar(&krcStatsJSON, "json", false, "Output in JSON format")
 	krcDecayCmd.Flags().BoolVar(&krcDecayJSON, "json", false, "Output in JSON format")
+	krcRollupsCmd.Flags().BoolVar(&krcRollupsJSON, "json", false, "Output in JSON format")
 }
 
 func runKrcStats(cmd *cobra.Command, args []string) error {
@@ -184,6 +221,10 @@ func runKrcStats(cmd *cobra.Command, args []string) error {
 	fmt.Println(style.Bold.Render("Files:"))
 	fmt.Printf("  Events: %s (%d events)\n", formatBytes(stats.EventsFile.Size), stats.EventsFile.EventCount)
 	fmt.Printf("  Feed:   %s (%d events)\n", formatBytes(stats.FeedFile.Size), stats.FeedFile.EventCount)
+	if stats.Segments.Count > 0 {
+		fmt.Printf("  Closed: %d segments, %s on disk (%s raw, %d events)\n", stats.Segments.Count,
+			formatBytes(stats.Segments.Size), formatBytes(stats.Segments.Bytes), stats.Segments.EventCount)
+	}
 	fmt.Println()
 
 	// Age distribution
@@ -249,65 +290,53 @@ func runKrcPrune(cmd *cobra.Command, args []string) error {
 		return runKrcAutoPrune(townRoot, config)
 	}
 
+	pruner := krc.NewPruner(townRoot, config)
+	var result *krc.PruneResult
 	if krcPruneDryRun {
-		// Show what would be pruned
-		stats, err := krc.GetStats(townRoot, config)
-		if err != nil {
-			return fmt.Errorf("getting stats: %w", err)
-		}
-
-		totalExpired := 0
-		for _, info := range stats.TTLBreakdown {
-			totalExpired += info.Expired
-		}
-
-		if totalExpired == 0 {
-			fmt.Println("No expired events to prune.")
-			return nil
-		}
-
-		fmt.Println(style.Bold.Render("Dry run - would prune:"))
-		fmt.Println()
-
-		// Sort by expired count
-		var types []string
-		for t, info := range stats.TTLBreakdown {
-			if info.Expired > 0 {
-				types = append(types, t)
-			}
-		}
-		sort.Slice(types, func(i, j int) bool {
-			return stats.TTLBreakdown[types[i]].Expired > stats.TTLBreakdown[types[j]].Expired
-		})
-
-		for _, t := range types {
-			info := stats.TTLBreakdown[t]
-			fmt.Printf("  %-20s %d events (TTL: %s)\n", t, info.Expired, krcFormatDuration(info.TTL))
-		}
-		fmt.Println()
-		fmt.Printf("Total: %d events would be pruned\n", totalExpired)
-		fmt.Println()
-		fmt.Println("Run without --dry-run to prune.")
-		return nil
+		result, err = pruner.DryRun()
+	} else {
+		result, err = pruner.Prune()
 	}
-
-	// Actually prune
-	pruner := krc.NewPruner(townRoot, config)
-	result, err := pruner.Prune()
 	if err != nil {
 		return fmt.Errorf("pruning: %w", err)
 	}
 
-	if result.EventsPruned == 0 {
+	if result.EventsPruned == 0 && result.TranscriptsPruned == 0 {
 		fmt.Println("No expired events to prune.")
+		if result.EventsProtected > 0 {
+			fmt.Printf("%d expired events kept for open escalations or recent deaths.\n", result.EventsProtected)
+		}
 		return nil
 	}
 
-	fmt.Println(style.Bold.Render("Prune complete:"))
+	if krcPruneDryRun {
+		fmt.Println(style.Bold.Render("Dry run - would prune:"))
+	} else {
+		fmt.Println(style.Bold.Render("Prune complete:"))
+	}
 	fmt.Printf("  Events processed: %d\n", result.EventsProcessed)
 	fmt.Printf("  Events pruned:    %d\n", result.EventsPruned)
+	if result.EventsEvicted > 0 {
+		fmt.Printf("    over budget:    %d\n", result.EventsEvicted)
+	}
 	fmt.Printf("  Events retained:  %d\n", result.EventsRetained)
-	fmt.Printf("  Space saved:      %s\n", formatBytes(result.BytesBefore-result.BytesAfter))
+	if result.EventsProtected > 0 {
+		fmt.Printf("    protected:      %d expired (open escalations, recent deaths)\n", result.EventsProtected)
+	}
+	if result.TranscriptsPruned > 0 {
+		fmt.Printf("  Transcripts:      %d pruned (%s)", result.TranscriptsPruned, formatBytes(result.TranscriptBytesFreed))
+		if result.TranscriptsEvicted > 0 {
+			fmt.Printf(", %d over budget", result.TranscriptsEvicted)
+		}
+		fmt.Println()
+	}
+	fmt.Printf("  Space saved:      %s\n", formatBytes(result.BytesBefore-result.BytesAfter+result.TranscriptBytesFreed))
+	if result.DiskBudget > 0 {
+		fmt.Printf("  Disk usage:       %s of %s budget\n", formatBytes(result.BytesRetained), formatBytes(result.DiskBudget))
+	}
+	if result.Rollups > 0 {
+		fmt.Printf("  Rollups:          %d\n", result.Rollups)
+	}
 	fmt.Printf("  Duration:         %s\n", result.Duration.Round(time.Millisecond))
 
 	if len(result.PrunedByType) > 0 {
@@ -328,6 +357,10 @@ func runKrcPrune(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if krcPruneDryRun {
+		fmt.Println()
+		fmt.Println("Run without --dry-run to prune.")
+	}
 	return nil
 }
 
@@ -349,6 +382,14 @@ func runKrcConfig(cmd *cobra.Command, args []string) error {
 	fmt.Printf("Default TTL:     %s\n", krcFormatDuration(config.DefaultTTL))
 	fmt.Printf("Prune interval:  %s\n", krcFormatDuration(config.PruneInterval))
 	fmt.Printf("Min retain:      %d events\n", config.MinRetainCount)
+	if config.DiskBudget > 0 {
+		fmt.Printf("Disk budget:     %s\n", formatBytes(config.DiskBudget))
+	} else {
+		fmt.Printf("Disk budget:     %s\n", style.Dim.Render("none"))
+	}
+	fmt.Printf("Death protection: %s\n", krcFormatDuration(config.DeathProtection))
+	fmt.Printf("Context window:  %s\n", krcFormatDuration(config.ContextWindow))
+	fmt.Printf("Rollup TTL:      %s\n", krcFormatDuration(config.RollupTTL))
 	fmt.Println()
 	fmt.Println(style.Bold.Render("TTLs by pattern:"))
 
@@ -370,11 +411,6 @@ func runKrcConfigSet(cmd *cobra.Command, args []string) error {
 	pattern := args[0]
 	ttlStr := args[1]
 
-	ttl, err := krcParseDuration(ttlStr)
-	if err != nil {
-		return fmt.Errorf("invalid TTL %q: %w", ttlStr, err)
-	}
-
 	townRoot, err := workspace.FindFromCwd()
 	if err != nil {
 		return fmt.Errorf("not in a Gas Town workspace: %w", err)
@@ -385,10 +421,42 @@ func runKrcConfigSet(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("loading config: %w", err)
 	}
 
-	if pattern == "default" {
+	if pattern == "disk_budget" {
+		budget, err := krcParseBytes(ttlStr)
+		if err != nil {
+			return fmt.Errorf("invalid disk budget %q: %w", ttlStr, err)
+		}
+		config.DiskBudget = budget
+		if budget == 0 {
+			fmt.Println("Disabled disk budget")
+		} else {
+			fmt.Printf("Set disk budget to %s\n", formatBytes(budget))
+		}
+		if err := krc.SaveConfig(townRoot, config); err != nil {
+			return fmt.Errorf("saving config: %w", err)
+		}
+		return nil
+	}
+
+	ttl, err := krcParseDuration(ttlStr)
+	if err != nil {
+		return fmt.Errorf("invalid TTL %q: %w", ttlStr, err)
+	}
+
+	switch pattern {
+	case "death_protection":
+		config.DeathProtection = ttl
+		fmt.Printf("Set death protection to %s\n", krcFormatDuration(ttl))
+	case "context_window":
+		config.ContextWindow = ttl
+		fmt.Printf("Set context window to %s\n", krcFormatDuration(ttl))
+	case "rollup_ttl":
+		config.RollupTTL = ttl
+		fmt.Printf("Set rollup TTL to %s\n", krcFormatDuration(ttl))
+	case "default":
 		config.DefaultTTL = ttl
 		fmt.Printf("Set default TTL to %s\n", krcFormatDuration(ttl))
-	} else {
+	default:
 		if config.TTLs == nil {
 			config.TTLs = make(map[string]time.Duration)
 		}
@@ -432,6 +500,31 @@ func krcParseDuration(s string) (time.Duration, error) {
 	return time.ParseDuration(s)
 }
 
+// krcParseBytes parses a size such as "500MB", "2GB" or "1048576".
+// Units are binary (1KB = 1024 bytes).
+func krcParseBytes(s string) (int64, error) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	units := []struct {
+		suffix string
+		mult   int64
+	}{
+		{"TB", 1 << 40}, {"GB", 1 << 30}, {"MB", 1 << 20}, {"KB", 1 << 10}, {"B", 1},
+	}
+	mult := int64(1)
+	for _, u := range units {
+		if strings.HasSuffix(s, u.suffix) {
+			s = strings.TrimSpace(strings.TrimSuffix(s, u.suffix))
+			mult = u.mult
+			break
+		}
+	}
+	var n float64
+	if _, err := fmt.Sscanf(s, "%g", &n); err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid size: %s", s)
+	}
+	return int64(n * float64(mult)), nil
+}
+
 // krcFormatDuration formats a duration in human-readable form.
 func krcFormatDuration(d time.Duration) string {
 	if d >= 24*time.Hour {
@@ -632,3 +725,68 @@ func runKrcAutoPruneStatus(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+func runKrcRollups(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwd()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	rollups, err := krc.LoadRollups(townRoot)
+	if err != nil {
+		return err
+	}
+
+	if krcRollupsJSON {
+		if rollups == nil {
+			rollups = []krc.Rollup{}
+		}
+		data, err := json.MarshalIndent(rollups, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if len(rollups) == 0 {
+		fmt.Printf("%s No rollups yet (nothing has been pruned)\n", style.Dim.Render("○"))
+		return nil
+	}
+
+	fmt.Println(style.Bold.Render("Pruned Record Rollups"))
+	fmt.Println()
+	fmt.Printf("  %-10s %-24s %-7s %-9s %-14s %s\n", "DAY", "TYPE", "COUNT", "SIZE", "REASON", "TOP ACTORS")
+	for _, r := range rollups {
+		var reasons []string
+		for _, reason := range []string{"ttl", "budget"} {
+			if n := r.Reasons[reason]; n > 0 {
+				reasons = append(reasons, fmt.Sprintf("%s:%d", reason, n))
+			}
+		}
+		fmt.Printf("  %-10s %-24s %-7d %-9s %-14s %s\n",
+			r.Day, r.Type, r.Count, formatBytes(r.Bytes), strings.Join(reasons, " "), krcTopActors(r.Actors, 3))
+	}
+	return nil
+}
+
+// krcTopActors lists the n most frequent actors, most frequent first.
+func krcTopActors(actors map[string]int, n int) string {
+	names := make([]string, 0, len(actors))
+	for a := range actors {
+		names = append(names, a)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if actors[names[i]] != actors[names[j]] {
+			return actors[names[i]] > actors[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	if len(names) > n {
+		names = names[:n]
+	}
+	for i, a := range names {
+		names[i] = fmt.Sprintf("%s(%d)", a, actors[a])
+	}
+	return strings.Join(names, " ")
+}
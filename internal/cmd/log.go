@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/townlog"
 	"github.com/steveyegge/gastown/internal/workspace"
@@ -63,6 +64,8 @@ It's not typically run manually.
 The exit code determines if this was a crash or expected exit:
   - Exit code 0: Expected exit (logged as 'done' if no other done was recorded)
   - Exit code non-zero: Crash (logged as 'crash')
+  - Killed by the kernel OOM killer in the session's cgroup: logged as
+    'session_death' with the memory limit that was exceeded
 
 Examples:
   gt log crash --agent greenplace/Toast --session gt-greenplace-Toast --exit-code 1`,
@@ -316,6 +319,8 @@ func runLogCrash(cmd *cobra.Command, args []string) error {
 		if townRoot == "" {
 			return fmt.Errorf("cannot find town root (tried cwd and ~/gt)")
 		}
+		// The events feed locates the town from cwd.
+		_ = os.Chdir(townRoot)
 	}
 
 	// Determine event type based on exit code
@@ -342,6 +347,15 @@ func runLogCrash(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// A kernel OOM kill in the session's cgroup is a death with a known cause,
+	// not a generic crash (the agent usually exits 137 from SIGKILL).
+	if crashSession != "" && crashExitCode != 0 {
+		if reason, oom := session.ReportOOMDeath(crashSession, crashAgent, "pane-died hook"); oom {
+			eventType = townlog.EventSessionDeath
+			context = fmt.Sprintf("%s, exit code %d (session: %s)", reason, crashExitCode, crashSession)
+		}
+	}
+
 	// Log the event
 	logger := townlog.NewLogger(townRoot)
 	if err := logger.Log(eventType, crashAgent, context); err != nil {
@@ -37,6 +37,7 @@ var (
 	mailSearchSubject bool
 	mailSearchBody    bool
 	mailSearchArchive bool
+	mailSearchReindex bool
 	mailSearchJSON    bool
 
 	// Announces flags
@@ -123,6 +124,14 @@ Priority levels:
 
 Use --urgent as shortcut for --priority 0.
 
+Scheduled delivery:
+  --at <time>     Deliver at a time (YYYY-MM-DDTHH:MM, RFC 3339, or HH:MM)
+  --in <delay>    Deliver after a delay (30m, 2h, 1d)
+  --cron <spec>   Deliver repeatedly (5-field cron, local time, or @daily etc.)
+
+Scheduled messages are sent by the daemon; manage them with
+gt mail scheduled list/cancel.
+
 Examples:
   gt mail send greenplace/Toast -s "Status check" -m "How's that bug fix going?"
   gt mail send mayor/ -s "Work complete" -m "Finished gt-abc"
@@ -133,6 +142,9 @@ Examples:
   gt mail send --self -s "Handoff" -m "Context for next session"
   gt mail send greenplace/Toast -s "Update" -m "Progress report" --cc overseer
   gt mail send list:oncall -s "Alert" -m "System down"
+  gt mail send gastown/witness -s "Check X" -m "Remember X" --at 09:00
+  gt mail send mayor/ -s "Follow up" -m "Did gt-abc land?" --in 2h
+  gt mail send mayor/ -s "Weekly report" -m "..." --cron "0 9 * * 1"
 
   # Read body from stdin (avoids shell quoting issues):
   gt mail send mayor/ -s "Update" --stdin <<'BODY'
@@ -392,29 +404,52 @@ Examples:
 var mailSearchCmd = &cobra.Command{
 	Use:   "search <query>",
 	Short: "Search messages by content",
-	Long: `Search inbox for messages matching a pattern.
+	Long: `Search inbox and archived messages using the mail search index.
 
 SYNTAX:
   gt mail search <query> [flags]
 
-The query is a regular expression pattern. Search is case-insensitive by default.
+The query is a list of clauses; a message must match all of them.
+Matching is case-insensitive and word-based.
+
+QUERY SYNTAX:
+  word              Message contains the word
+  word*             Message contains a word starting with "word"
+  "exact phrase"    Message contains the words in order
+  from:<sender>     Sender address contains <sender>
+  to:<recipient>    To or CC address contains <recipient>
+  thread:<id>       Message belongs to the thread
+  after:<date>      Sent on or after <date>
+  before:<date>     Sent before <date>
+
+Dates are YYYY-MM-DD, RFC 3339, or an age such as 7d or 12h.
+Results are ranked by relevance (subject matches weigh more), newest first
+among equal matches.
+
+The index lives in .runtime/mail-index/ and is updated as mail is sent,
+read, and archived. It holds word counts rather than message bodies, so
+results show headers only; use 'gt mail read' for the full text. It is
+rebuilt from beads automatically once a day, or on demand with --reindex.
 
 FLAGS:
   --from <sender>   Filter by sender address (substring match)
   --subject         Only search subject lines
   --body            Only search message body
   --archive         Include archived (closed) messages
+  --reindex         Rebuild the search index before searching
   --json            Output as JSON
 
 By default, searches both subject and body text.
 
 Examples:
-  gt mail search "urgent"                    # Find messages with "urgent"
-  gt mail search "status.*check" --subject   # Regex in subjects only
-  gt mail search "error" --from witness      # From witness, containing "error"
-  gt mail search "handoff" --archive         # Include archived messages
-  gt mail search "" --from mayor/            # All messages from mayor`,
-	Args: cobra.ExactArgs(1),
+  gt mail search urgent                           # Find messages with "urgent"
+  gt mail search '"merge conflict"' --subject     # Phrase in subjects only
+  gt mail search error from:witness after:7d      # From witness this week
+  gt mail search 'thread:thread-abc123'           # Whole thread
+  gt mail search "handoff" --archive              # Include archived messages
+  gt mail search "" --from mayor/                 # All messages from mayor
+  gt mail search --reindex                        # Rebuild the index`,
+	Args: cobra.ArbitraryArgs,
 	RunE: runMailSearch,
 }
 
@@ -499,6 +534,7 @@ func init() {
 	mailSearchCmd.Flags().BoolVar(&mailSearchSubject, "subject", false, "Only search subject lines")
 	mailSearchCmd.Flags().BoolVar(&mailSearchBody, "body", false, "Only search message body")
 	mailSearchCmd.Flags().BoolVar(&mailSearchArchive, "archive", false, "Include archived messages")
+	mailSearchCmd.Flags().BoolVar(&mailSearchReindex, "reindex", false, "Rebuild the search index before searching")
 	mailSearchCmd.Flags().BoolVar(&mailSearchJSON, "json", false, "Output as JSON")
 
 	// Announces flags
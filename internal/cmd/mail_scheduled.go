@@ -0,0 +1,261 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// Scheduled send flags (registered on gt mail send)
+var (
+	mailSendAt   string
+	mailSendIn   string
+	mailSendCron string
+)
+
+var mailScheduledJSON bool
+
+var mailScheduledCmd = &cobra.Command{
+	Use:   "scheduled",
+	Short: "Manage scheduled and recurring mail",
+	RunE:  requireSubcommand,
+	Long: `Manage messages scheduled with gt mail send --at, --in, or --cron.
+
+Scheduled messages are stored in .runtime/mail-scheduled.json and sent by
+the daemon when due (checked every minute). Recipients are resolved at
+delivery time, so lists and groups reflect their membership then.
+
+Examples:
+  gt mail scheduled list
+  gt mail scheduled cancel sched-1a2b3c4d`,
+}
+
+var mailScheduledListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List pending scheduled messages",
+	Args:  cobra.NoArgs,
+	RunE:  runMailScheduledList,
+}
+
+var mailScheduledCancelCmd = &cobra.Command{
+	Use:   "cancel <id>...",
+	Short: "Cancel scheduled messages",
+	Args:  cobra.MinimumNArgs(1),
+	RunE:  runMailScheduledCancel,
+}
+
+var mailScheduledDispatchCmd = &cobra.Command{
+	Use:    "dispatch",
+	Short:  "Send scheduled messages that are due (run by the daemon)",
+	Hidden: true,
+	Args:   cobra.NoArgs,
+	RunE:   runMailScheduledDispatch,
+}
+
+func init() {
+	mailSendCmd.Flags().StringVar(&mailSendAt, "at", "", "Deliver at a time (e.g., 2026-10-20T09:00, \"2026-10-20 09:00\", 09:00)")
+	mailSendCmd.Flags().StringVar(&mailSendIn, "in", "", "Deliver after a delay (e.g., 30m, 2h, 1d)")
+	mailSendCmd.Flags().StringVar(&mailSendCron, "cron", "", "Deliver repeatedly on a cron schedule (e.g., \"0 9 * * 1-5\")")
+	mailSendCmd.MarkFlagsMutuallyExclusive("at", "in", "cron")
+
+	mailScheduledListCmd.Flags().BoolVar(&mailScheduledJSON, "json", false, "Output as JSON")
+
+	mailScheduledCmd.AddCommand(mailScheduledListCmd)
+	mailScheduledCmd.AddCommand(mailScheduledCancelCmd)
+	mailScheduledCmd.AddCommand(mailScheduledDispatchCmd)
+	mailCmd.AddCommand(mailScheduledCmd)
+}
+
+// parseDeliveryTime interprets --at and --in relative to now. --at accepts
+// RFC 3339, "YYYY-MM-DDTHH:MM", "YYYY-MM-DD HH:MM" (local time), or a bare
+// "HH:MM" meaning the next occurrence of that time. --in accepts Go
+// durations plus a "d" suffix for days.
+func parseDeliveryTime(at, in string, now time.Time) (time.Time, error) {
+	if in != "" {
+		if days, ok := strings.CutSuffix(in, "d"); ok {
+			if n, err := strconv.Atoi(days); err == nil && n > 0 {
+				return now.AddDate(0, 0, n), nil
+			}
+		}
+		d, err := time.ParseDuration(in)
+		if err != nil || d <= 0 {
+			return time.Time{}, fmt.Errorf("invalid --in %q: expected a positive duration like 30m, 2h, or 1d", in)
+		}
+		return now.Add(d), nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, at); err == nil {
+		return t, nil
+	}
+	for _, layout := range []string{"2006-01-02T15:04", "2006-01-02 15:04", "2006-01-02T15:04:05", "2006-01-02 15:04:05"} {
+		if t, err := time.ParseInLocation(layout, at, now.Location()); err == nil {
+			return t, nil
+		}
+	}
+	if clock, err := time.ParseInLocation("15:04", at, now.Location()); err == nil {
+		t := time.Date(now.Year(), now.Month(), now.Day(), clock.Hour(), clock.Minute(), 0, 0, now.Location())
+		if !t.After(now) {
+			t = t.AddDate(0, 0, 1)
+		}
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid --at %q: expected YYYY-MM-DDTHH:MM, RFC 3339, or HH:MM", at)
+}
+
+// scheduleMail stores msg for later delivery according to --at/--in/--cron.
+func scheduleMail(msg *mail.Message) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	entry := &mail.ScheduledMessage{
+		Message:   msg,
+		CreatedBy: msg.From,
+	}
+	if mailSendCron != "" {
+		if _, err := mail.ParseCron(mailSendCron); err != nil {
+			return err
+		}
+		entry.Cron = mailSendCron
+		// Each recurrence starts its own thread unless it is a reply.
+		if msg.ReplyTo == "" {
+			msg.ThreadID = ""
+		}
+	} else {
+		now := time.Now()
+		at, err := parseDeliveryTime(mailSendAt, mailSendIn, now)
+		if err != nil {
+			return err
+		}
+		if !at.After(now) {
+			return fmt.Errorf("delivery time %s is in the past", at.Format(time.RFC3339))
+		}
+		entry.NextAt = at
+	}
+
+	if err := mail.NewScheduleStore(townRoot).Add(entry); err != nil {
+		return fmt.Errorf("scheduling message: %w", err)
+	}
+
+	fmt.Printf("%s Message scheduled for %s (%s)\n", style.Bold.Render("✓"), msg.To, entry.ID)
+	fmt.Printf("  Subject: %s\n", msg.Subject)
+	if entry.Recurring() {
+		fmt.Printf("  Schedule: %s (next %s)\n", entry.Cron, entry.NextAt.Local().Format("2006-01-02 15:04"))
+	} else {
+		fmt.Printf("  Deliver at: %s\n", entry.NextAt.Local().Format("2006-01-02 15:04"))
+	}
+	return nil
+}
+
+func runMailScheduledList(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	entries, err := mail.NewScheduleStore(townRoot).List()
+	if err != nil {
+		return err
+	}
+
+	if mailScheduledJSON {
+		if entries == nil {
+			entries = []*mail.ScheduledMessage{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(entries)
+	}
+
+	if len(entries) == 0 {
+		fmt.Printf("%s\n", style.Dim.Render("No scheduled messages"))
+		return nil
+	}
+
+	fmt.Printf("%s (%d)\n\n", style.Bold.Render("Scheduled messages"), len(entries))
+	for _, e := range entries {
+		when := e.NextAt.Local().Format("2006-01-02 15:04")
+		if e.Recurring() {
+			when += style.Dim.Render(fmt.Sprintf("  (cron %q, sent %d time(s))", e.Cron, e.SendCount))
+		}
+		fmt.Printf("  %s %s\n", style.Bold.Render(e.ID), when)
+		fmt.Printf("    %s → %s: %s\n", e.Message.From, e.Message.To, e.Message.Subject)
+		switch {
+		case e.Failed():
+			fmt.Printf("    %s\n", style.Bold.Render(fmt.Sprintf("FAILED after %d attempts, not retried: %s", e.Failures, e.LastError)))
+		case e.LastError != "":
+			fmt.Printf("    %s\n", style.Dim.Render(fmt.Sprintf("last attempt failed (%d), retrying at %s: %s",
+				e.Failures, e.NextAt.Local().Format("15:04"), e.LastError)))
+		}
+	}
+	return nil
+}
+
+func runMailScheduledCancel(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	store := mail.NewScheduleStore(townRoot)
+	var failed []string
+	for _, id := range args {
+		if err := store.Cancel(id); err != nil {
+			if errors.Is(err, mail.ErrScheduledNotFound) {
+				failed = append(failed, fmt.Sprintf("%s: not found", id))
+			} else {
+				failed = append(failed, fmt.Sprintf("%s: %v", id, err))
+			}
+			continue
+		}
+		fmt.Printf("%s Cancelled %s\n", style.Bold.Render("✓"), id)
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("cancel failed: %s", strings.Join(failed, "; "))
+	}
+	return nil
+}
+
+func runMailScheduledDispatch(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	sent, errs := mail.NewScheduleStore(townRoot).Dispatch(time.Now(), func(e *mail.ScheduledMessage) error {
+		msg := *e.Message
+		msg.ID = ""
+		msg.Timestamp = time.Now()
+		if msg.ThreadID == "" {
+			msg.ThreadID = generateThreadID()
+		}
+		if _, err := deliverMail(townRoot, townRoot, &msg); err != nil {
+			return err
+		}
+		_ = events.LogFeed(events.TypeMail, msg.From, events.MailPayload(e.Message.To, msg.Subject))
+		return nil
+	})
+
+	if sent > 0 {
+		fmt.Printf("Dispatched %d scheduled message(s)\n", sent)
+	}
+	if len(errs) > 0 {
+		msgs := make([]string, len(errs))
+		for i, err := range errs {
+			msgs[i] = err.Error()
+		}
+		return fmt.Errorf("scheduled delivery failed: %s", strings.Join(msgs, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDeliveryTime(t *testing.T) {
+	now := time.Date(2026, 10, 18, 14, 30, 0, 0, time.Local)
+	tests := []struct {
+		at, in string
+		want   time.Time
+	}{
+		{in: "2h", want: now.Add(2 * time.Hour)},
+		{in: "1d", want: now.AddDate(0, 0, 1)},
+		{at: "2026-10-20T09:00", want: time.Date(2026, 10, 20, 9, 0, 0, 0, time.Local)},
+		{at: "2026-10-20 09:00", want: time.Date(2026, 10, 20, 9, 0, 0, 0, time.Local)},
+		{at: "2026-10-20T09:00:00Z", want: time.Date(2026, 10, 20, 9, 0, 0, 0, time.UTC)},
+		{at: "16:00", want: time.Date(2026, 10, 18, 16, 0, 0, 0, time.Local)},
+		{at: "09:00", want: time.Date(2026, 10, 19, 9, 0, 0, 0, time.Local)}, // already passed today
+	}
+	for _, tt := range tests {
+		got, err := parseDeliveryTime(tt.at, tt.in, now)
+		if err != nil {
+			t.Errorf("parseDeliveryTime(%q, %q): %v", tt.at, tt.in, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("parseDeliveryTime(%q, %q) = %v, want %v", tt.at, tt.in, got, tt.want)
+		}
+	}
+
+	for _, bad := range [][2]string{{"tomorrow", ""}, {"", "-1h"}, {"", "0d"}, {"", "soon"}} {
+		if _, err := parseDeliveryTime(bad[0], bad[1], now); err == nil {
+			t.Errorf("parseDeliveryTime(%q, %q) should fail", bad[0], bad[1])
+		}
+	}
+}
@@ -4,15 +4,21 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/mail"
 	"github.com/steveyegge/gastown/internal/style"
 )
 
-// runMailSearch searches for messages matching a pattern.
+// runMailSearch searches for messages matching a query.
+// Multiple arguments are joined, so unquoted queries like
+// `gt mail search from:mayor urgent` work as expected.
 func runMailSearch(cmd *cobra.Command, args []string) error {
-	query := args[0]
+	if len(args) == 0 && !mailSearchReindex {
+		return fmt.Errorf("requires a query (use \"\" to match all messages)")
+	}
+	query := strings.Join(args, " ")
 
 	// Determine which inbox to search
 	address := detectSender()
@@ -30,6 +36,18 @@ func runMailSearch(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("getting mailbox: %w", err)
 	}
 
+	if mailSearchReindex {
+		if err := mailbox.RebuildIndex(); err != nil {
+			return fmt.Errorf("rebuilding mail index: %w", err)
+		}
+		if len(args) == 0 {
+			if !mailSearchJSON {
+				fmt.Printf("%s Rebuilt mail index: %s\n", style.Bold.Render("✓"), mailbox.Index().Path())
+			}
+			return nil
+		}
+	}
+
 	// Build search options
 	opts := mail.SearchOptions{
 		Query:       query,
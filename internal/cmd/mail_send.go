@@ -131,8 +131,45 @@ func runMailSend(cmd *cobra.Command, args []string) error {
 		msg.ThreadID = generateThreadID()
 	}
 
-	// Use address resolver for new address types
+	// Scheduled delivery: store it for the daemon instead of sending now.
+	if mailSendAt != "" || mailSendIn != "" || mailSendCron != "" {
+		return scheduleMail(msg)
+	}
+
 	townRoot, _ := workspace.FindFromCwd()
+	recipientAddrs, err := deliverMail(workDir, townRoot, msg)
+	if err != nil {
+		return err
+	}
+
+	// Log mail event to activity feed
+	_ = events.LogFeed(events.TypeMail, from, events.MailPayload(to, mailSubject))
+
+	fmt.Printf("%s Message sent to %s\n", style.Bold.Render("✓"), to)
+	fmt.Printf("  Subject: %s\n", mailSubject)
+
+	// Show resolved recipients if fan-out occurred
+	if len(recipientAddrs) > 1 || (len(recipientAddrs) == 1 && recipientAddrs[0] != to) {
+		fmt.Printf("  Recipients: %s\n", strings.Join(recipientAddrs, ", "))
+	}
+
+	if len(msg.CC) > 0 {
+		fmt.Printf("  CC: %s\n", strings.Join(msg.CC, ", "))
+	}
+	if msg.Type != mail.TypeNotification {
+		fmt.Printf("  Type: %s\n", msg.Type)
+	}
+
+	return nil
+}
+
+// deliverMail resolves msg.To and sends a copy to each recipient, returning
+// the addresses delivered to. Partial failures are reported on stderr; an
+// error is returned only when nothing could be delivered.
+func deliverMail(workDir, townRoot string, msg *mail.Message) ([]string, error) {
+	to := msg.To
+
+	// Use address resolver for new address types
 	b := beads.New(townRoot)
 	resolver := mail.NewResolver(b, townRoot)
 
@@ -142,12 +179,9 @@ func runMailSend(cmd *cobra.Command, args []string) error {
 		router := mail.NewRouter(workDir)
 		defer router.WaitPendingNotifications()
 		if err := router.Send(msg); err != nil {
-			return fmt.Errorf("sending message: %w", err)
+			return nil, fmt.Errorf("sending message: %w", err)
 		}
-		_ = events.LogFeed(events.TypeMail, from, events.MailPayload(to, mailSubject))
-		fmt.Printf("%s Message sent to %s\n", style.Bold.Render("✓"), to)
-		fmt.Printf("  Subject: %s\n", mailSubject)
-		return nil
+		return []string{to}, nil
 	}
 
 	// Route based on recipient type, collecting errors instead of failing early
@@ -191,30 +225,12 @@ func runMailSend(cmd *cobra.Command, args []string) error {
 
 	if len(sendErrs) > 0 {
 		if len(recipientAddrs) == 0 {
-			return fmt.Errorf("all sends failed: %s", strings.Join(sendErrs, "; "))
+			return nil, fmt.Errorf("all sends failed: %s", strings.Join(sendErrs, "; "))
 		}
 		fmt.Fprintf(os.Stderr, "⚠ Some deliveries failed: %s\n", strings.Join(sendErrs, "; "))
 	}
 
-	// Log mail event to activity feed
-	_ = events.LogFeed(events.TypeMail, from, events.MailPayload(to, mailSubject))
-
-	fmt.Printf("%s Message sent to %s\n", style.Bold.Render("✓"), to)
-	fmt.Printf("  Subject: %s\n", mailSubject)
-
-	// Show resolved recipients if fan-out occurred
-	if len(recipientAddrs) > 1 || (len(recipientAddrs) == 1 && recipientAddrs[0] != to) {
-		fmt.Printf("  Recipients: %s\n", strings.Join(recipientAddrs, ", "))
-	}
-
-	if len(msg.CC) > 0 {
-		fmt.Printf("  CC: %s\n", strings.Join(msg.CC, ", "))
-	}
-	if msg.Type != mail.TypeNotification {
-		fmt.Printf("  Type: %s\n", msg.Type)
-	}
-
-	return nil
+	return recipientAddrs, nil
 }
 
 // generateThreadID creates a random thread ID for new message threads.
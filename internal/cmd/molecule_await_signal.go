@@ -1,15 +1,12 @@
 package cmd
 
 import (
-	"bufio"
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
-	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -318,17 +315,12 @@ func waitForEventsFile(ctx context.Context, eventsPath string) (*AwaitSignalResu
 	if err != nil {
 		return nil, fmt.Errorf("opening events file %s: %w", eventsPath, err)
 	}
-	defer f.Close()
+	_ = f.Close()
 
-	// Seek to end — we only want new events, not historical ones
-	if _, err := f.Seek(0, 2); err != nil {
-		return nil, fmt.Errorf("seeking to end of events file: %w", err)
-	}
-
-	// Poll for new lines using bufio.Reader (not Scanner, which doesn't
-	// resume after EOF). Reader.ReadString properly retries the underlying
-	// file reader, picking up appended data between polls.
-	reader := bufio.NewReader(f)
+	// Follow from the end — we only want new events, not historical ones.
+	// The tailer keeps following across segment rotation.
+	tail := events.NewTailer(eventsPath)
+	defer tail.Close()
 	ticker := time.NewTicker(200 * time.Millisecond)
 	defer ticker.Stop()
 
@@ -339,17 +331,16 @@ func waitForEventsFile(ctx context.Context, eventsPath string) (*AwaitSignalResu
 				Reason: "timeout",
 			}, nil
 		case <-ticker.C:
-			line, err := reader.ReadString('\n')
-			if err == nil && line != "" {
+			line, err := tail.Next()
+			if err != nil {
+				return nil, fmt.Errorf("reading events file: %w", err)
+			}
+			if line != nil {
 				return &AwaitSignalResult{
 					Reason: "signal",
-					Signal: strings.TrimRight(line, "\n"),
+					Signal: string(line),
 				}, nil
 			}
-			// io.EOF means no new data yet — keep polling
-			if err != nil && err != io.EOF {
-				return nil, fmt.Errorf("reading events file: %w", err)
-			}
 		}
 	}
 }
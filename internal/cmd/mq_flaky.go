@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/refinery"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+var mqFlakyJSON bool
+
+var mqFlakyCmd = &cobra.Command{
+	Use:   "flaky <rig>",
+	Short: "List tests quarantined as flaky",
+	Long: `List tests the refinery has classified as flaky for a rig.
+
+A test is flaky when it both passed and failed against the same source tree.
+The refinery records per-test outcomes for gates that declare a test report
+format ("report": "go-json" or "junit"). While quarantined, a flaky test's
+failures do not block merges, and a bead is filed for each newly flaky test.
+
+Examples:
+  gt mq flaky greenplace
+  gt mq flaky greenplace --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runMQFlaky,
+}
+
+func init() {
+	mqFlakyCmd.Flags().BoolVar(&mqFlakyJSON, "json", false, "Output as JSON")
+	mqCmd.AddCommand(mqFlakyCmd)
+}
+
+func runMQFlaky(cmd *cobra.Command, args []string) error {
+	_, r, _, err := getRefineryManager(args[0])
+	if err != nil {
+		return err
+	}
+
+	history, err := refinery.NewFlakyTracker(r.Path).Load()
+	if err != nil {
+		return err
+	}
+	flaky := history.FlakyTests()
+
+	if mqFlakyJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(flaky)
+	}
+
+	if len(flaky) == 0 {
+		fmt.Printf("%s No flaky tests quarantined for %s\n", style.SuccessPrefix, r.Name)
+		return nil
+	}
+
+	fmt.Printf("%s (%d)\n\n", style.Bold.Render(fmt.Sprintf("Flaky tests in %s", r.Name)), len(flaky))
+	for _, rec := range flaky {
+		line := fmt.Sprintf("  %s", rec.Name)
+		if rec.BeadID != "" {
+			line += style.Dim.Render(fmt.Sprintf("  [%s]", rec.BeadID))
+		}
+		fmt.Println(line)
+		fmt.Printf("    %s\n", style.Dim.Render(fmt.Sprintf("flaky since %s, %d recorded run(s)",
+			rec.FlakySince.Local().Format(time.RFC3339), len(rec.Runs))))
+	}
+	return nil
+}
@@ -34,6 +34,9 @@ type MRStatusOutput struct {
 	MergeCommit string `json:"merge_commit,omitempty"`
 	CloseReason string `json:"close_reason,omitempty"`
 
+	// Gates whose passing result the refinery reused from its gate cache
+	CachedGates []string `json:"cached_gates,omitempty"`
+
 	// Dependencies
 	DependsOn []DependencyInfo `json:"depends_on,omitempty"`
 	Blocks    []DependencyInfo `json:"blocks,omitempty"`
@@ -95,6 +98,9 @@ func runMqStatus(cmd *cobra.Command, args []string) error {
 		output.Rig = mrFields.Rig
 		output.MergeCommit = mrFields.MergeCommit
 		output.CloseReason = mrFields.CloseReason
+		if mrFields.CachedGates != "" {
+			output.CachedGates = strings.Split(mrFields.CachedGates, ",")
+		}
 	}
 
 	// Add dependency info from the issue's Dependencies field
@@ -184,6 +190,11 @@ func printMqStatus(issue *beads.Issue, mrFields *beads.MRFields) error {
 		if mrFields.CloseReason != "" {
 			fmt.Printf("   Close Reason: %s\n", mrFields.CloseReason)
 		}
+		if mrFields.CachedGates != "" {
+			fmt.Printf("   Cached Gates: %s %s\n",
+				strings.ReplaceAll(mrFields.CachedGates, ",", ", "),
+				style.Dim.Render("(reused cached results)"))
+		}
 	}
 
 	// Dependencies (what this MR is waiting on)
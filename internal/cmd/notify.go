@@ -23,11 +23,16 @@ Notification levels:
 
 Without arguments, shows the current notification level.
 
+Subcommands:
+  watch   Send terminal/desktop notifications for town events
+  test    Check that the configured notification sinks work
+
 Examples:
   gt notify           # Show current level
   gt notify verbose   # Enable all notifications
   gt notify normal    # Default notification level
   gt notify muted     # Enable DND mode
+  gt notify watch     # Notify on convoy landed, escalations, merge failures
 
 Related: gt dnd - quick toggle for DND mode`,
 	Args: cobra.MaximumNArgs(1),
@@ -0,0 +1,252 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/notify"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var (
+	notifyWatchEvents   []string
+	notifyWatchSinks    []string
+	notifyWatchCommand  string
+	notifyWatchInterval string
+)
+
+var notifyWatchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Notify the overseer about town events as they happen",
+	Long: `Follow the town event feed and send a notification for each
+subscribed event, until interrupted.
+
+Event kinds:
+  convoy_landed   A convoy finished (all tracked issues closed)
+  escalation      An escalation was raised or re-escalated
+  merge_failed    The refinery failed to merge a branch
+  mass_death      Several sessions died at once
+
+Sinks:
+  terminal   Bell plus OSC 9 escape (iTerm2, kitty, WezTerm, Windows Terminal)
+  desktop    notify-send, when installed
+  command    A shell command, with GT_NOTIFY_KIND, GT_NOTIFY_TITLE,
+             GT_NOTIFY_BODY and GT_NOTIFY_CRITICAL in its environment
+
+Defaults come from the "notifier" section of settings/config.json:
+
+  "notifier": {
+    "events": ["convoy_landed", "escalation", "merge_failed"],
+    "sinks": ["terminal", "desktop"],
+    "command": "say \"$GT_NOTIFY_TITLE\""
+  }
+
+While DND is on (gt dnd), only critical escalations and mass deaths are
+delivered. The same notifier runs inside 'gt status --watch --notify' and
+'gt convoy status --watch --notify'.
+
+Examples:
+  gt notify watch                              # Use configured defaults
+  gt notify watch --events escalation          # Escalations only
+  gt notify watch --sink command --command 'ntfy pub gt "$GT_NOTIFY_BODY"'`,
+	Args: cobra.NoArgs,
+	RunE: runNotifyWatch,
+}
+
+var notifyTestCmd = &cobra.Command{
+	Use:   "test",
+	Short: "Send a sample notification through the configured sinks",
+	Long: `Send a sample notification through every configured sink, ignoring
+DND and event subscriptions. Use it to check that the terminal, notify-send,
+or your notifier command works before relying on it.`,
+	Args: cobra.NoArgs,
+	RunE: runNotifyTest,
+}
+
+func init() {
+	notifyWatchCmd.Flags().StringSliceVar(&notifyWatchEvents, "events", nil, "Event kinds to notify on (overrides settings)")
+	notifyWatchCmd.Flags().StringSliceVar(&notifyWatchSinks, "sink", nil, "Sinks to deliver to: terminal, desktop, command (overrides settings)")
+	notifyWatchCmd.Flags().StringVar(&notifyWatchCommand, "command", "", "Shell command to run per notification (overrides settings)")
+	notifyWatchCmd.Flags().StringVar(&notifyWatchInterval, "interval", "2s", "How often to check for new events")
+	notifyCmd.AddCommand(notifyWatchCmd)
+	notifyCmd.AddCommand(notifyTestCmd)
+}
+
+func runNotifyWatch(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	interval, err := parseDuration(notifyWatchInterval)
+	if err != nil || interval <= 0 {
+		return fmt.Errorf("invalid --interval %q", notifyWatchInterval)
+	}
+
+	cfg := loadNotifierConfig(townRoot)
+	if len(notifyWatchEvents) > 0 {
+		cfg.Events = notifyWatchEvents
+	}
+	if len(notifyWatchSinks) > 0 {
+		cfg.Sinks = notifyWatchSinks
+	}
+	if notifyWatchCommand != "" {
+		cfg.Command = notifyWatchCommand
+	}
+	n := buildOverseerNotifier(townRoot, cfg)
+	if len(n.Sinks) == 0 {
+		return fmt.Errorf("no usable notification sinks")
+	}
+
+	kinds := make([]string, 0, len(n.Kinds))
+	for _, k := range notify.Kinds {
+		if n.Kinds[k] {
+			kinds = append(kinds, k)
+		}
+	}
+	sinks := make([]string, 0, len(n.Sinks))
+	for _, s := range n.Sinks {
+		sinks = append(sinks, s.Name())
+	}
+	fmt.Printf("Watching for %s → %s (Ctrl+C to stop)\n",
+		style.Bold.Render(strings.Join(kinds, ", ")), strings.Join(sinks, ", "))
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigChan)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	follower := notify.NewFollower(filepath.Join(townRoot, events.EventsFile))
+	for {
+		select {
+		case <-sigChan:
+			return nil
+		case <-ticker.C:
+		}
+		evs, err := follower.Poll()
+		if err != nil {
+			style.PrintWarning("reading events: %v", err)
+			continue
+		}
+		for _, e := range evs {
+			note, delivered, err := n.Handle(e)
+			if note.Kind == "" || !n.Kinds[note.Kind] {
+				continue
+			}
+			stamp := note.Time.Local().Format("15:04:05")
+			switch {
+			case err != nil:
+				fmt.Printf("%s %s: %s %s\n", stamp, note.Title, note.Body, style.Error.Render("("+err.Error()+")"))
+			case delivered:
+				fmt.Printf("%s %s: %s\n", stamp, style.Bold.Render(note.Title), note.Body)
+			default:
+				fmt.Printf("%s\n", style.Dim.Render(fmt.Sprintf("%s %s: %s (held: DND)", stamp, note.Title, note.Body)))
+			}
+		}
+	}
+}
+
+func runNotifyTest(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	n := newOverseerNotifier(townRoot)
+	if len(n.Sinks) == 0 {
+		return fmt.Errorf("no usable notification sinks")
+	}
+	note := notify.Notification{
+		Kind:  "test",
+		Title: "Gas Town",
+		Body:  "Test notification from gt notify test",
+		Time:  time.Now(),
+	}
+	if err := n.Send(note); err != nil {
+		return fmt.Errorf("sending notification: %w", err)
+	}
+	for _, s := range n.Sinks {
+		fmt.Printf("%s Sent via %s\n", style.SuccessPrefix, s.Name())
+	}
+	return nil
+}
+
+// loadNotifierConfig returns the town's notifier settings, or an empty
+// config (meaning defaults) when none are set.
+func loadNotifierConfig(townRoot string) *config.NotifierConfig {
+	settings, err := config.LoadOrCreateTownSettings(config.TownSettingsPath(townRoot))
+	if err != nil || settings.Notifier == nil {
+		return &config.NotifierConfig{}
+	}
+	cfg := *settings.Notifier
+	return &cfg
+}
+
+// newOverseerNotifier builds the notifier for watch modes from town
+// settings. Configuration problems are printed as warnings, not errors,
+// so a bad sink never stops the watch itself.
+func newOverseerNotifier(townRoot string) *notify.Notifier {
+	return buildOverseerNotifier(townRoot, loadNotifierConfig(townRoot))
+}
+
+func buildOverseerNotifier(townRoot string, cfg *config.NotifierConfig) *notify.Notifier {
+	n, warnings := notify.New(cfg, os.Stdout)
+	for _, w := range warnings {
+		style.PrintWarning("notifier: %s", w)
+	}
+	n.Muted = dndMuted(townRoot)
+	return n
+}
+
+// dndMutedTTL bounds how stale the DND state seen by a watcher can be.
+const dndMutedTTL = 30 * time.Second
+
+// dndMuted reports whether the current agent has DND on (gt dnd), using the
+// same agent bead as gt dnd and gt notify. The level is re-read at most
+// every dndMutedTTL. If no agent bead can be resolved, nothing is muted.
+func dndMuted(townRoot string) func() bool {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil
+	}
+	roleInfo, err := GetRoleWithContext(cwd, townRoot)
+	if err != nil {
+		return nil
+	}
+	agentBeadID := getAgentBeadID(RoleContext{
+		Role:     roleInfo.Role,
+		Rig:      roleInfo.Rig,
+		Polecat:  roleInfo.Polecat,
+		TownRoot: townRoot,
+		WorkDir:  cwd,
+	})
+	if agentBeadID == "" {
+		return nil
+	}
+
+	bd := beads.New(townRoot)
+	var mu sync.Mutex
+	var muted bool
+	var checked time.Time
+	return func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		if time.Since(checked) < dndMutedTTL {
+			return muted
+		}
+		level, err := bd.GetAgentNotificationLevel(agentBeadID)
+		muted = err == nil && level == beads.NotifyMuted
+		checked = time.Now()
+		return muted
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/events"
 	"github.com/steveyegge/gastown/internal/nudge"
+	"github.com/steveyegge/gastown/internal/polecat"
 	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/telemetry"
@@ -179,6 +180,24 @@ func deliverNudge(t *tmux.Tmux, sessionName, message, sender string) error {
 	}
 }
 
+// isPendingSpawn reports whether target is the session of a spawned polecat
+// still waiting for the Deacon to trigger it.
+func isPendingSpawn(townRoot, target string) bool {
+	if townRoot == "" {
+		return false
+	}
+	pending, err := polecat.CheckInboxForSpawns(townRoot)
+	if err != nil {
+		return false
+	}
+	for _, ps := range pending {
+		if ps.Session == target {
+			return true
+		}
+	}
+	return false
+}
+
 // validNudgeModes is the set of allowed --mode values.
 var validNudgeModes = map[string]bool{
 	NudgeModeImmediate: true,
@@ -270,6 +289,20 @@ func runNudge(cmd *cobra.Command, args []string) (retErr error) {
 		}
 	}
 
+	// Patrols nudge by running this command; in shadow mode they only record
+	// it. Triggering a pending spawn finishes starting an agent, which shadow
+	// mode doesn't hold back.
+	townRoot, _ := workspace.FindFromCwd()
+	if isPatrolCaller() && !isPendingSpawn(townRoot, target) && patrolShadowHold(townRoot, events.Receipt{
+		Agent:    target,
+		Rule:     "nudge",
+		Decision: "requested",
+		Action:   "nudged",
+		Reason:   message,
+	}) {
+		return nil
+	}
+
 	// Handle channel syntax: channel:<name>
 	if strings.HasPrefix(target, "channel:") {
 		channelName := strings.TrimPrefix(target, "channel:")
@@ -277,7 +310,6 @@ func runNudge(cmd *cobra.Command, args []string) (retErr error) {
 	}
 
 	// Check DND status for target (unless force flag or channel target)
-	townRoot, _ := workspace.FindFromCwd()
 	if townRoot != "" && !nudgeForceFlag {
 		shouldSend, level, _ := shouldNudgeTarget(townRoot, target, nudgeForceFlag)
 		if !shouldSend {
@@ -25,15 +25,21 @@ var (
 var patrolCmd = &cobra.Command{
 	Use:     "patrol",
 	GroupID: GroupDiag,
-	Short:   "Patrol digest management",
-	Long: `Manage patrol cycle digests.
+	Short:   "Patrol digest management and decision audit",
+	Long: `Manage patrol cycle digests and explain patrol decisions.
 
 Patrol cycles (Deacon, Witness, Refinery) create ephemeral per-cycle digests
 to avoid JSONL pollution. This command aggregates them into daily summaries.
 
+Automated patrol actions record decision receipts; 'gt patrol why' shows
+which rule fired against an agent. 'gt patrol shadow' runs patrols in
+record-only mode so new thresholds can be tried without acting.
+
 Examples:
   gt patrol digest --yesterday  # Aggregate yesterday's patrol digests
-  gt patrol digest --dry-run    # Preview what would be aggregated`,
+  gt patrol digest --dry-run    # Preview what would be aggregated
+  gt patrol why gastown/polecats/max  # Why was this polecat killed?
+  gt patrol shadow on           # Record patrol decisions without acting`,
 }
 
 var patrolDigestCmd = &cobra.Command{
@@ -58,6 +64,8 @@ Examples:
 func init() {
 	patrolCmd.AddCommand(patrolDigestCmd)
 	patrolCmd.AddCommand(patrolNewCmd)
+	patrolCmd.AddCommand(patrolWhyCmd)
+	patrolCmd.AddCommand(patrolShadowCmd)
 	rootCmd.AddCommand(patrolCmd)
 
 	// Patrol digest flags
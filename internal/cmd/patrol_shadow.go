@@ -0,0 +1,475 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/deacon"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var (
+	patrolShadowReason      string
+	patrolShadowReportSince string
+	patrolShadowReportJSON  bool
+
+	patrolShadowHoldRule   string
+	patrolShadowHoldAgent  string
+	patrolShadowHoldBead   string
+	patrolShadowHoldAction string
+	patrolShadowHoldReason string
+)
+
+var patrolShadowCmd = &cobra.Command{
+	Use:   "shadow",
+	Short: "Run patrols in shadow mode: detect and record, but don't act",
+	Long: `Manage town-wide patrol shadow mode.
+
+While shadow mode is on, the daemon, Boot, Deacon and Witness patrols run
+their detection logic as usual but take no action. Every kill, nudge, nuke,
+unhook, warrant execution or re-dispatch they would have made is recorded
+as a shadow decision receipt instead. Use it to try new stuck, stale-hook
+or zombie thresholds on a live town before letting them act.
+
+Starting agents that are missing is not suppressed.
+
+When a patrol runs 'gt nudge', 'gt polecat nuke', 'gt witness restart' or
+'gt refinery restart', the command records the action instead of taking it.
+Formula steps that act through other tools check 'gt patrol shadow hold'
+first.
+
+Examples:
+  gt patrol shadow on --reason "trying 5m stale hook threshold"
+  gt patrol shadow status
+  gt patrol shadow report
+  gt patrol shadow off`,
+	RunE: requireSubcommand,
+}
+
+var patrolShadowOnCmd = &cobra.Command{
+	Use:   "on",
+	Short: "Turn patrol shadow mode on",
+	Args:  cobra.NoArgs,
+	RunE:  runPatrolShadowOn,
+}
+
+var patrolShadowOffCmd = &cobra.Command{
+	Use:   "off",
+	Short: "Turn patrol shadow mode off; patrols act again",
+	Args:  cobra.NoArgs,
+	RunE:  runPatrolShadowOff,
+}
+
+var patrolShadowStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show whether patrol shadow mode is on",
+	Args:  cobra.NoArgs,
+	RunE:  runPatrolShadowStatus,
+}
+
+var patrolShadowHoldCmd = &cobra.Command{
+	Use:   "hold",
+	Short: "Record a patrol action as a shadow decision if shadow mode is on",
+	Long: `Check patrol shadow mode before a formula step acts.
+
+If shadow mode is on, the action described by the flags is recorded as a
+shadow decision receipt and the command exits 0: the step must not act.
+Otherwise it exits 1 and records nothing, and the step acts as usual.
+
+Examples:
+  gt patrol shadow hold --rule orphaned_bead --bead gt-abc --action reset || bd update gt-abc --status=open --assignee=`,
+	Args: cobra.NoArgs,
+	RunE: runPatrolShadowHold,
+}
+
+var patrolShadowReportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Compare shadow decisions with the real ones before them",
+	Long: `Compare what patrols would have done in shadow mode with what they
+actually did before it.
+
+The shadow window is the current shadow period, or the most recent one if
+shadow mode is off (or the last --since). It is compared, rule by rule,
+with a baseline window of the same length just before it, in which patrols
+were acting for real.
+
+Each shadow action is listed with its subject. Subjects that logged activity
+of their own after the decision are flagged: had the action been taken,
+that work would have been interrupted, so the decision is a likely false
+positive.
+
+Examples:
+  gt patrol shadow report
+  gt patrol shadow report --since 24h
+  gt patrol shadow report --json`,
+	Args: cobra.NoArgs,
+	RunE: runPatrolShadowReport,
+}
+
+func init() {
+	patrolShadowOnCmd.Flags().StringVar(&patrolShadowReason, "reason", "", "Why shadow mode is being turned on")
+	patrolShadowReportCmd.Flags().StringVar(&patrolShadowReportSince, "since", "", "Use the last duration as the shadow window (e.g., 24h, 7d)")
+	patrolShadowReportCmd.Flags().BoolVar(&patrolShadowReportJSON, "json", false, "Output as JSON")
+	patrolShadowHoldCmd.Flags().StringVar(&patrolShadowHoldRule, "rule", "", "Patrol rule that fired (required)")
+	patrolShadowHoldCmd.Flags().StringVar(&patrolShadowHoldAction, "action", "", "Action the step would take (required)")
+	patrolShadowHoldCmd.Flags().StringVar(&patrolShadowHoldAgent, "agent", "", "Agent the action applies to")
+	patrolShadowHoldCmd.Flags().StringVar(&patrolShadowHoldBead, "bead", "", "Bead the action applies to")
+	patrolShadowHoldCmd.Flags().StringVar(&patrolShadowHoldReason, "reason", "", "Why the patrol decided to act")
+	_ = patrolShadowHoldCmd.MarkFlagRequired("rule")
+	_ = patrolShadowHoldCmd.MarkFlagRequired("action")
+
+	patrolShadowCmd.AddCommand(patrolShadowOnCmd)
+	patrolShadowCmd.AddCommand(patrolShadowOffCmd)
+	patrolShadowCmd.AddCommand(patrolShadowStatusCmd)
+	patrolShadowCmd.AddCommand(patrolShadowReportCmd)
+	patrolShadowCmd.AddCommand(patrolShadowHoldCmd)
+}
+
+// isPatrolCaller reports whether the command was run by a patrol agent
+// (Deacon, Boot or a Witness), per GT_ROLE. Patrols act by running gt
+// commands from their formulas, so those commands must honor shadow mode.
+func isPatrolCaller() bool {
+	role, _, _ := parseRoleString(os.Getenv("GT_ROLE"))
+	return role == RoleDeacon || role == RoleBoot || role == RoleWitness
+}
+
+// patrolShadowHold reports whether a patrol action should be held back:
+// patrol shadow mode is on in townRoot and a patrol agent ran the command.
+// A held action is recorded as a shadow receipt instead of being taken.
+func patrolShadowHold(townRoot string, r events.Receipt) bool {
+	if townRoot == "" || !isPatrolCaller() || !deacon.InShadow(townRoot) {
+		return false
+	}
+	r.Shadow = true
+	_ = events.LogReceipt(townRoot, detectActor(), r)
+	fmt.Printf("%s Patrol shadow mode: would have %s %s - nothing done\n", style.Dim.Render("○"), r.Action, shadowSubject(r))
+	return true
+}
+
+// shadowSubject names what a receipt's action applies to.
+func shadowSubject(r events.Receipt) string {
+	if r.Agent != "" {
+		return r.Agent
+	}
+	return r.Bead
+}
+
+func runPatrolShadowOn(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwd()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	state, err := deacon.LoadShadowState(townRoot)
+	if err != nil {
+		return fmt.Errorf("reading shadow state: %w", err)
+	}
+	if state.Enabled {
+		fmt.Printf("%s Patrol shadow mode already on (since %s)\n",
+			style.Dim.Render("○"), state.StartedAt.Local().Format("2006-01-02 15:04"))
+		return nil
+	}
+
+	if err := deacon.EnableShadow(townRoot, patrolShadowReason, detectActor()); err != nil {
+		return fmt.Errorf("enabling shadow mode: %w", err)
+	}
+	fmt.Printf("%s Patrol shadow mode on - patrols will record what they would do without acting\n", style.Bold.Render("✓"))
+	fmt.Printf("  Review with 'gt patrol shadow report'; turn off with 'gt patrol shadow off'\n")
+	return nil
+}
+
+func runPatrolShadowOff(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwd()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	state, err := deacon.LoadShadowState(townRoot)
+	if err != nil {
+		return fmt.Errorf("reading shadow state: %w", err)
+	}
+	if !state.Enabled {
+		fmt.Printf("%s Patrol shadow mode is not on\n", style.Dim.Render("○"))
+		return nil
+	}
+
+	if err := deacon.DisableShadow(townRoot); err != nil {
+		return fmt.Errorf("disabling shadow mode: %w", err)
+	}
+	fmt.Printf("%s Patrol shadow mode off after %s - patrols act again\n",
+		style.Bold.Render("✓"), formatDurationAgo(time.Since(state.StartedAt)))
+	fmt.Printf("  Compare decisions with 'gt patrol shadow report'\n")
+	return nil
+}
+
+func runPatrolShadowStatus(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwd()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	state, err := deacon.LoadShadowState(townRoot)
+	if err != nil {
+		// Patrols treat an unreadable file as shadow mode; say so, and
+		// fail so scripts checking the status notice.
+		fmt.Printf("%s Shadow state unreadable - patrols are held in shadow mode until it is fixed\n", style.Warning.Render("⚠"))
+		fmt.Printf("  Repair or remove %s\n", deacon.GetShadowFile(townRoot))
+		return fmt.Errorf("reading shadow state: %w", err)
+	}
+
+	if !state.Enabled {
+		fmt.Printf("Patrol shadow mode: %s\n", style.Dim.Render("off"))
+		if p, ok := state.LatestPeriod(); ok {
+			fmt.Printf("  Last period: %s - %s\n", p.Start.Local().Format("2006-01-02 15:04"), p.End.Local().Format("2006-01-02 15:04"))
+		}
+		return nil
+	}
+
+	fmt.Printf("Patrol shadow mode: %s\n", style.Warning.Render("on"))
+	fmt.Printf("  Since:  %s (%s ago)\n", state.StartedAt.Local().Format("2006-01-02 15:04"), formatDurationAgo(time.Since(state.StartedAt)))
+	if state.StartedBy != "" {
+		fmt.Printf("  By:     %s\n", state.StartedBy)
+	}
+	if state.Reason != "" {
+		fmt.Printf("  Reason: %s\n", state.Reason)
+	}
+	return nil
+}
+
+func runPatrolShadowHold(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwd()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	// Unlike the gated commands, hold is only run by patrol formulas, so
+	// it doesn't check the caller's role.
+	if !deacon.InShadow(townRoot) {
+		return NewSilentExit(1)
+	}
+	r := events.Receipt{
+		Agent:    patrolShadowHoldAgent,
+		Bead:     patrolShadowHoldBead,
+		Rule:     patrolShadowHoldRule,
+		Decision: "requested",
+		Action:   patrolShadowHoldAction,
+		Reason:   patrolShadowHoldReason,
+		Shadow:   true,
+	}
+	if err := events.LogReceipt(townRoot, detectActor(), r); err != nil {
+		return fmt.Errorf("recording shadow receipt: %w", err)
+	}
+	fmt.Printf("%s Patrol shadow mode: would have %s %s - nothing done\n", style.Dim.Render("○"), r.Action, shadowSubject(r))
+	return nil
+}
+
+// ShadowRuleStats compares one patrol rule's shadow decisions with the real
+// actions it took in the baseline window.
+type ShadowRuleStats struct {
+	Rule             string  `json:"rule"`
+	ShadowActions    int     `json:"shadow_actions"`
+	ShadowSubjects   int     `json:"shadow_subjects"`
+	ShadowPerDay     float64 `json:"shadow_per_day"`
+	BaselineActions  int     `json:"baseline_actions"`
+	BaselineSubjects int     `json:"baseline_subjects"`
+	BaselinePerDay   float64 `json:"baseline_per_day"`
+}
+
+// ShadowDecision is an action a patrol would have taken in shadow mode.
+type ShadowDecision struct {
+	events.ReceiptEvent
+
+	// LaterActivity is true if the subject logged events of its own after
+	// the decision, i.e. the action would have interrupted live work.
+	LaterActivity bool `json:"later_activity"`
+}
+
+// ShadowReport compares a shadow window with the baseline window before it.
+type ShadowReport struct {
+	Window    deacon.ShadowPeriod `json:"window"`
+	Baseline  deacon.ShadowPeriod `json:"baseline"`
+	Rules     []ShadowRuleStats   `json:"rules"`
+	Decisions []ShadowDecision    `json:"decisions"`
+}
+
+// buildShadowReport reads the events log and compares shadow receipts in
+// window with real ones in an equally long baseline window just before it.
+func buildShadowReport(eventsPath string, window deacon.ShadowPeriod) (*ShadowReport, error) {
+	length := window.End.Sub(window.Start)
+	report := &ShadowReport{
+		Window:   window,
+		Baseline: deacon.ShadowPeriod{Start: window.Start.Add(-length), End: window.Start},
+	}
+
+	rules := make(map[string]*ShadowRuleStats)
+	ruleStats := func(rule string) *ShadowRuleStats {
+		if rules[rule] == nil {
+			rules[rule] = &ShadowRuleStats{Rule: rule}
+		}
+		return rules[rule]
+	}
+	shadowSubjects := make(map[string]map[string]bool)
+	baselineSubjects := make(map[string]map[string]bool)
+	addSubject := func(m map[string]map[string]bool, rule, subject string) {
+		if m[rule] == nil {
+			m[rule] = make(map[string]bool)
+		}
+		m[rule][subject] = true
+	}
+
+	// Latest event time per actor in the shadow window, for LaterActivity.
+	lastSeen := make(map[string]time.Time)
+
+	q := events.Query{Since: report.Baseline.Start, Until: window.End}
+	err := events.Read(eventsPath, q, func(e events.Event) bool {
+		r, ok := events.ReceiptFromEvent(e)
+		if !ok {
+			if ts, err := time.Parse(time.RFC3339, e.Timestamp); err == nil && !ts.Before(window.Start) {
+				if ts.After(lastSeen[e.Actor]) {
+					lastSeen[e.Actor] = ts
+				}
+			}
+			return true
+		}
+		if !r.Acted() {
+			return true
+		}
+		subject := r.Agent
+		if subject == "" {
+			subject = r.Bead
+		}
+		inWindow := !r.Time.Before(window.Start)
+		switch {
+		case inWindow && r.Shadow:
+			st := ruleStats(r.Rule)
+			st.ShadowActions++
+			addSubject(shadowSubjects, r.Rule, subject)
+			report.Decisions = append(report.Decisions, ShadowDecision{ReceiptEvent: r})
+		case !inWindow && !r.Shadow:
+			st := ruleStats(r.Rule)
+			st.BaselineActions++
+			addSubject(baselineSubjects, r.Rule, subject)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	days := length.Hours() / 24
+	for rule, st := range rules {
+		st.ShadowSubjects = len(shadowSubjects[rule])
+		st.BaselineSubjects = len(baselineSubjects[rule])
+		if days > 0 {
+			st.ShadowPerDay = float64(st.ShadowActions) / days
+			st.BaselinePerDay = float64(st.BaselineActions) / days
+		}
+		report.Rules = append(report.Rules, *st)
+	}
+	sort.Slice(report.Rules, func(i, j int) bool {
+		return report.Rules[i].Rule < report.Rules[j].Rule
+	})
+
+	for i := range report.Decisions {
+		d := &report.Decisions[i]
+		if d.Agent != "" && lastSeen[d.Agent].After(d.Time) {
+			d.LaterActivity = true
+		}
+	}
+	sort.SliceStable(report.Decisions, func(i, j int) bool {
+		return report.Decisions[i].Time.After(report.Decisions[j].Time)
+	})
+	return report, nil
+}
+
+func runPatrolShadowReport(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwd()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	var window deacon.ShadowPeriod
+	if patrolShadowReportSince != "" {
+		d, err := parseDuration(patrolShadowReportSince)
+		if err != nil {
+			return fmt.Errorf("invalid --since duration: %w", err)
+		}
+		now := time.Now().UTC()
+		window = deacon.ShadowPeriod{Start: now.Add(-d), End: now}
+	} else {
+		state, err := deacon.LoadShadowState(townRoot)
+		if err != nil {
+			return fmt.Errorf("reading shadow state: %w", err)
+		}
+		p, ok := state.LatestPeriod()
+		if !ok {
+			return fmt.Errorf("no shadow period recorded (start one with 'gt patrol shadow on', or pass --since)")
+		}
+		window = p
+	}
+
+	report, err := buildShadowReport(filepath.Join(townRoot, events.EventsFile), window)
+	if err != nil {
+		return fmt.Errorf("reading patrol receipts: %w", err)
+	}
+
+	if patrolShadowReportJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	}
+
+	printShadowReport(report)
+	return nil
+}
+
+func printShadowReport(report *ShadowReport) {
+	const stamp = "2006-01-02 15:04"
+	fmt.Printf("%s\n", style.Bold.Render("Patrol shadow report"))
+	fmt.Printf("  Shadow:   %s - %s\n", report.Window.Start.Local().Format(stamp), report.Window.End.Local().Format(stamp))
+	if report.Window.Reason != "" {
+		fmt.Printf("            %s\n", style.Dim.Render(report.Window.Reason))
+	}
+	fmt.Printf("  Baseline: %s - %s\n\n", report.Baseline.Start.Local().Format(stamp), report.Baseline.End.Local().Format(stamp))
+
+	if len(report.Rules) == 0 {
+		fmt.Printf("%s No patrol actions, real or shadow, in either window\n", style.Dim.Render("○"))
+		return
+	}
+
+	fmt.Printf("  %-18s %18s %18s\n", "RULE", "SHADOW (would)", "BASELINE (did)")
+	for _, st := range report.Rules {
+		fmt.Printf("  %-18s %18s %18s\n", st.Rule,
+			fmt.Sprintf("%d (%.1f/day)", st.ShadowActions, st.ShadowPerDay),
+			fmt.Sprintf("%d (%.1f/day)", st.BaselineActions, st.BaselinePerDay))
+	}
+
+	if len(report.Decisions) == 0 {
+		return
+	}
+	fmt.Printf("\n%s (%d, newest first)\n", style.Bold.Render("Shadow decisions"), len(report.Decisions))
+	collateral := 0
+	for _, d := range report.Decisions {
+		subject := d.Agent
+		if subject == "" {
+			subject = d.Bead
+		}
+		line := fmt.Sprintf("  %s  %-16s %-28s %s → %s", d.Time.Local().Format(stamp), d.Rule, subject, d.Decision, d.Action)
+		if d.LaterActivity {
+			collateral++
+			line += " " + style.Warning.Render("[active afterwards]")
+		}
+		fmt.Println(line)
+	}
+	if collateral > 0 {
+		fmt.Printf("\n%s %d decision(s) hit agents that kept working afterwards - likely false positives\n",
+			style.Warning.Render("⚠"), collateral)
+	}
+}
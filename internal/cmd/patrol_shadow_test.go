@@ -0,0 +1,267 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/boot"
+	"github.com/steveyegge/gastown/internal/deacon"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+func TestBuildShadowReport(t *testing.T) {
+	eventsPath := filepath.Join(t.TempDir(), events.EventsFile)
+	now := time.Now().UTC().Truncate(time.Second)
+	window := deacon.ShadowPeriod{Start: now.Add(-24 * time.Hour), End: now}
+
+	var b strings.Builder
+	write := func(ts time.Time, typ, actor string, payload map[string]interface{}) {
+		data, err := json.Marshal(events.Event{
+			Timestamp: ts.Format(time.RFC3339),
+			Type:      typ,
+			Actor:     actor,
+			Payload:   payload,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		b.Write(data)
+		b.WriteString("\n")
+	}
+	receipt := func(ts time.Time, rule, agent, action string, shadow bool) {
+		r := events.Receipt{Agent: agent, Rule: rule, Decision: "d", Action: action, Shadow: shadow}
+		write(ts, events.TypePatrolReceipt, "deacon", r.Payload())
+	}
+
+	// Before the baseline window: ignored.
+	receipt(now.Add(-72*time.Hour), "stale_hook", "gastown/polecats/old", "unhooked", false)
+	// Baseline: real actions only.
+	receipt(now.Add(-30*time.Hour), "stale_hook", "gastown/polecats/ace", "unhooked", false)
+	receipt(now.Add(-29*time.Hour), "stale_hook", "gastown/polecats/ace", "none", false)
+	receipt(now.Add(-28*time.Hour), "warrant", "gastown/polecats/bob", "killed", false)
+	// Shadow window.
+	receipt(now.Add(-5*time.Hour), "stale_hook", "gastown/polecats/max", "unhooked", true)
+	receipt(now.Add(-4*time.Hour), "stale_hook", "gastown/polecats/max", "unhooked", true)
+	receipt(now.Add(-3*time.Hour), "stale_hook", "gastown/polecats/nux", "unhooked", true)
+	receipt(now.Add(-2*time.Hour), "stale_hook", "gastown/polecats/nux", "none", true)
+	// max kept working after its shadow unhook; nux did not.
+	write(now.Add(-time.Hour), events.TypeSling, "gastown/polecats/max", nil)
+	write(now.Add(-4*time.Hour), events.TypeSling, "gastown/polecats/nux", nil)
+	if err := os.WriteFile(eventsPath, []byte(b.String()), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := buildShadowReport(eventsPath, window)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.Baseline.Start.Equal(now.Add(-48*time.Hour)) || !report.Baseline.End.Equal(window.Start) {
+		t.Errorf("baseline = %+v", report.Baseline)
+	}
+
+	if len(report.Rules) != 2 {
+		t.Fatalf("rules = %+v", report.Rules)
+	}
+	stale, warrant := report.Rules[0], report.Rules[1]
+	if stale.Rule != "stale_hook" || stale.ShadowActions != 3 || stale.ShadowSubjects != 2 || stale.BaselineActions != 1 {
+		t.Errorf("stale_hook stats = %+v", stale)
+	}
+	if stale.ShadowPerDay != 3 || stale.BaselinePerDay != 1 {
+		t.Errorf("stale_hook rates = %v/%v", stale.ShadowPerDay, stale.BaselinePerDay)
+	}
+	if warrant.Rule != "warrant" || warrant.ShadowActions != 0 || warrant.BaselineActions != 1 {
+		t.Errorf("warrant stats = %+v", warrant)
+	}
+
+	if len(report.Decisions) != 3 {
+		t.Fatalf("decisions = %+v", report.Decisions)
+	}
+	var got []string
+	for _, d := range report.Decisions {
+		got = append(got, filepath.Base(d.Agent))
+		if d.LaterActivity != (d.Agent == "gastown/polecats/max") {
+			t.Errorf("%s at %v: LaterActivity = %v", d.Agent, d.Time, d.LaterActivity)
+		}
+	}
+	if strings.Join(got, ",") != "nux,max,max" {
+		t.Errorf("decision order = %v, want newest first", got)
+	}
+}
+
+// setupShadowTown creates a town with rig gastown and polecat toast in
+// patrol shadow mode, runs the test from it, and puts a fake tmux and bd on
+// PATH that record each invocation. It returns the town root and the
+// recorded command log.
+func setupShadowTown(t *testing.T) (string, string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("test uses Unix shell script mocks for tmux and bd")
+	}
+	townRoot := setupTestTownForDotDir(t)
+	addRigEntry(t, townRoot, "gastown")
+	if err := os.MkdirAll(filepath.Join(townRoot, "gastown", "polecats", "toast"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := deacon.EnableShadow(townRoot, "test", "test"); err != nil {
+		t.Fatal(err)
+	}
+
+	binDir := t.TempDir()
+	cmdLog := filepath.Join(binDir, "commands.log")
+	writeScript(t, binDir, "tmux", "#!/bin/sh\necho \"tmux $*\" >> "+cmdLog+"\nexit 0\n")
+	writeScript(t, binDir, "bd", "#!/bin/sh\necho \"bd $*\" >> "+cmdLog+"\necho '[]'\n")
+	t.Setenv("PATH", binDir+":"+os.Getenv("PATH"))
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(cwd) })
+	if err := os.Chdir(townRoot); err != nil {
+		t.Fatal(err)
+	}
+	return townRoot, cmdLog
+}
+
+// actingCommands are tmux and bd invocations that change an agent or bead.
+var actingCommands = []string{
+	"tmux -u kill-session", "tmux -u kill-server", "tmux -u send-keys", "tmux -u new-session", "tmux -u respawn-pane",
+	"bd close", "bd update", "bd delete", "bd reopen",
+}
+
+// TestPatrolCommands_ShadowHoldsActions runs each command a patrol uses to
+// act, as that patrol, in a town in shadow mode, and checks that nothing
+// was killed, nudged, nuked or restarted and a shadow receipt was recorded.
+func TestPatrolCommands_ShadowHoldsActions(t *testing.T) {
+	type patrolCommand struct {
+		name string
+		role string
+		rule string
+		run  func() error
+	}
+	tests := []patrolCommand{
+		{"nudge", "gastown/witness", "nudge", func() error {
+			return runNudge(nudgeCmd, []string{"gastown/polecats/toast", "How's progress?"})
+		}},
+		{"polecat nuke", "gastown/witness", "polecat_nuke", func() error {
+			polecatNukeForce = true
+			defer func() { polecatNukeForce = false }()
+			return runPolecatNuke(polecatNukeCmd, []string{"gastown/toast"})
+		}},
+		{"witness restart", "deacon", "witness_restart", func() error {
+			return runWitnessRestart(witnessRestartCmd, []string{"gastown"})
+		}},
+		{"refinery restart", "deacon", "refinery_restart", func() error {
+			return runRefineryRestart(refineryRestartCmd, []string{"gastown"})
+		}},
+		{"deacon health-check", "deacon", "health_check", func() error {
+			return runDeaconHealthCheck(deaconHealthCheckCmd, []string{"gastown/witness"})
+		}},
+		{"deacon force-kill", "deacon", "force_kill", func() error {
+			return runDeaconForceKill(deaconForceKillCmd, []string{"gastown/witness"})
+		}},
+	}
+	// Boot's degraded triage nudges a stale Deacon and kills a very stale one.
+	for _, age := range []time.Duration{20 * time.Minute, time.Hour} {
+		tests = append(tests, patrolCommand{"boot triage " + age.String(), "deacon/boot", "boot_triage", func() error {
+			townRoot, _ := workspace.FindFromCwd()
+			if err := deacon.WriteHeartbeat(townRoot, &deacon.Heartbeat{Timestamp: time.Now().Add(-age)}); err != nil {
+				return err
+			}
+			_, _, err := runDegradedTriage(boot.New(townRoot))
+			return err
+		}})
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			townRoot, cmdLog := setupShadowTown(t)
+			t.Setenv("GT_ROLE", tt.role)
+
+			if err := tt.run(); err != nil {
+				t.Fatalf("%s: %v", tt.name, err)
+			}
+
+			calls, _ := os.ReadFile(cmdLog)
+			for _, line := range strings.Split(string(calls), "\n") {
+				for _, acting := range actingCommands {
+					if strings.HasPrefix(line, acting) {
+						t.Errorf("shadow mode ran %q", line)
+					}
+				}
+			}
+			data, err := os.ReadFile(filepath.Join(townRoot, events.EventsFile))
+			if err != nil {
+				t.Fatalf("reading events: %v", err)
+			}
+			if !strings.Contains(string(data), `"rule":"`+tt.rule+`"`) || !strings.Contains(string(data), `"shadow":true`) {
+				t.Errorf("expected a shadow %s receipt, got: %s", tt.rule, data)
+			}
+		})
+	}
+}
+
+func TestPatrolShadowHold_OnlyPatrolCallers(t *testing.T) {
+	townRoot := t.TempDir()
+	if err := deacon.EnableShadow(townRoot, "test", "test"); err != nil {
+		t.Fatal(err)
+	}
+	r := events.Receipt{Agent: "gastown/polecats/toast", Rule: "nudge", Decision: "requested", Action: "nudged"}
+
+	for role, want := range map[string]bool{
+		"deacon":               true,
+		"deacon/boot":          true,
+		"gastown/witness":      true,
+		"mayor":                false,
+		"gastown/crew/joe":     false,
+		"gastown/refinery":     false,
+		"gastown/polecats/nux": false,
+		"":                     false,
+	} {
+		t.Setenv("GT_ROLE", role)
+		if got := patrolShadowHold(townRoot, r); got != want {
+			t.Errorf("GT_ROLE=%q: patrolShadowHold = %v, want %v", role, got, want)
+		}
+	}
+
+	if err := deacon.DisableShadow(townRoot); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("GT_ROLE", "deacon")
+	if patrolShadowHold(townRoot, r) {
+		t.Error("patrolShadowHold held an action with shadow mode off")
+	}
+}
+
+func TestRunPatrolShadowHold(t *testing.T) {
+	townRoot, _ := setupShadowTown(t)
+	patrolShadowHoldRule, patrolShadowHoldBead, patrolShadowHoldAction = "orphaned_bead", "gt-abc", "reset"
+	defer func() { patrolShadowHoldRule, patrolShadowHoldBead, patrolShadowHoldAction = "", "", "" }()
+
+	if err := runPatrolShadowHold(patrolShadowHoldCmd, nil); err != nil {
+		t.Fatalf("shadow on: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(townRoot, events.EventsFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"rule":"orphaned_bead"`) || !strings.Contains(string(data), `"bead":"gt-abc"`) {
+		t.Errorf("expected a shadow orphaned_bead receipt, got: %s", data)
+	}
+
+	if err := deacon.DisableShadow(townRoot); err != nil {
+		t.Fatal(err)
+	}
+	err = runPatrolShadowHold(patrolShadowHoldCmd, nil)
+	var silent *SilentExitError
+	if !errors.As(err, &silent) || silent.Code != 1 {
+		t.Errorf("shadow off: err = %v, want silent exit 1", err)
+	}
+}
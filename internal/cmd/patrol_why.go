@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var (
+	patrolWhySince string
+	patrolWhyLimit int
+	patrolWhyJSON  bool
+)
+
+var patrolWhyCmd = &cobra.Command{
+	Use:   "why <agent>",
+	Short: "Explain automated patrol actions taken against an agent",
+	Long: `Show the decision receipts patrols recorded for an agent.
+
+Every automated patrol action (stale hook unhooks, zombie kills, health-check
+nudges, force-kills, warrant executions, re-dispatches) logs a receipt with
+the inputs observed, the thresholds applied, the decision and the action
+taken. Use this when an agent was killed or unhooked unexpectedly to see
+which rule fired.
+
+The agent may be given as an address (gastown/polecats/max), a bare name
+(max), a tmux session name, or a bead ID for re-dispatch decisions.
+
+Examples:
+  gt patrol why gastown/polecats/max
+  gt patrol why max --since 24h
+  gt patrol why gt-abc12 --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPatrolWhy,
+}
+
+func init() {
+	patrolWhyCmd.Flags().StringVar(&patrolWhySince, "since", "7d", "Only show decisions newer than this (e.g., 1h, 24h, 7d)")
+	patrolWhyCmd.Flags().IntVarP(&patrolWhyLimit, "limit", "n", 20, "Maximum number of decisions to show (0 = all)")
+	patrolWhyCmd.Flags().BoolVar(&patrolWhyJSON, "json", false, "Output as JSON")
+}
+
+func runPatrolWhy(cmd *cobra.Command, args []string) error {
+	target := args[0]
+
+	townRoot, err := workspace.FindFromCwd()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	var since time.Time
+	if patrolWhySince != "" {
+		d, err := parseDuration(patrolWhySince)
+		if err != nil {
+			return fmt.Errorf("invalid --since duration: %w", err)
+		}
+		since = time.Now().Add(-d)
+	}
+
+	receipts, err := collectPatrolReceipts(filepath.Join(townRoot, events.EventsFile), target, since, patrolWhyLimit)
+	if err != nil {
+		return fmt.Errorf("reading patrol receipts: %w", err)
+	}
+
+	if patrolWhyJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(receipts)
+	}
+
+	if len(receipts) == 0 {
+		fmt.Printf("%s No patrol decisions recorded for %s", style.Dim.Render("○"), target)
+		if patrolWhySince != "" {
+			fmt.Printf(" in the last %s", patrolWhySince)
+		}
+		fmt.Println()
+		return nil
+	}
+
+	fmt.Printf("%s (%d, newest first)\n\n", style.Bold.Render("Patrol decisions for "+target), len(receipts))
+	for _, r := range receipts {
+		printPatrolReceipt(r)
+	}
+	return nil
+}
+
+// collectPatrolReceipts returns the receipts concerning target, newest
+// first, at most limit of them (0 = all).
+func collectPatrolReceipts(eventsPath, target string, since time.Time, limit int) ([]events.ReceiptEvent, error) {
+	var receipts []events.ReceiptEvent
+	q := events.Query{Since: since, Types: []string{events.TypePatrolReceipt}}
+	err := events.Read(eventsPath, q, func(e events.Event) bool {
+		if r, ok := events.ReceiptFromEvent(e); ok && r.Concerns(target) {
+			receipts = append(receipts, r)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(receipts, func(i, j int) bool {
+		return receipts[i].Time.After(receipts[j].Time)
+	})
+	if limit > 0 && len(receipts) > limit {
+		receipts = receipts[:limit]
+	}
+	return receipts, nil
+}
+
+func printPatrolReceipt(r events.ReceiptEvent) {
+	fmt.Printf("%s  %s  %s\n", r.Time.Local().Format("2006-01-02 15:04:05"), r.Actor, style.Bold.Render(r.Rule))
+	subject := r.Agent
+	if r.Session != "" {
+		if subject != "" {
+			subject += " (session " + r.Session + ")"
+		} else {
+			subject = r.Session
+		}
+	}
+	if subject != "" {
+		fmt.Printf("  Agent:      %s\n", subject)
+	}
+	if r.Bead != "" {
+		fmt.Printf("  Bead:       %s\n", r.Bead)
+	}
+	decision := r.Decision
+	if r.Reason != "" {
+		decision += " - " + r.Reason
+	}
+	fmt.Printf("  Decision:   %s\n", decision)
+	if r.Shadow {
+		fmt.Printf("  Action:     %s %s\n", r.Action, style.Dim.Render("(shadow mode - not taken)"))
+	} else {
+		fmt.Printf("  Action:     %s\n", r.Action)
+	}
+	if len(r.Inputs) > 0 {
+		fmt.Printf("  Inputs:     %s\n", formatReceiptFields(r.Inputs))
+	}
+	if len(r.Thresholds) > 0 {
+		fmt.Printf("  Thresholds: %s\n", formatReceiptFields(r.Thresholds))
+	}
+	if r.Error != "" {
+		fmt.Printf("  %s\n", style.Warning.Render("Error:      "+r.Error))
+	}
+	fmt.Println()
+}
+
+// formatReceiptFields renders a receipt's inputs or thresholds as sorted
+// key=value pairs.
+func formatReceiptFields(fields map[string]interface{}) string {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, fmt.Sprintf("%s=%v", k, fields[k]))
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/events"
+)
+
+func TestCollectPatrolReceipts(t *testing.T) {
+	eventsPath := filepath.Join(t.TempDir(), events.EventsFile)
+	now := time.Now().UTC()
+
+	var b strings.Builder
+	write := func(ts time.Time, typ string, payload map[string]interface{}) {
+		data, err := json.Marshal(events.Event{
+			Timestamp: ts.Format(time.RFC3339),
+			Type:      typ,
+			Actor:     "deacon",
+			Payload:   payload,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		b.Write(data)
+		b.WriteString("\n")
+	}
+	receipt := func(agent, decision string) map[string]interface{} {
+		return events.Receipt{Agent: agent, Rule: "stale_hook", Decision: decision, Action: "unhooked"}.Payload()
+	}
+	write(now.Add(-10*24*time.Hour), events.TypePatrolReceipt, receipt("gastown/polecats/max", "too-old"))
+	write(now.Add(-2*time.Hour), events.TypePatrolReceipt, receipt("gastown/polecats/max", "first"))
+	write(now.Add(-time.Hour), events.TypePatrolReceipt, receipt("gastown/polecats/ace", "other-agent"))
+	write(now.Add(-time.Hour), events.TypeSling, map[string]interface{}{"agent": "gastown/polecats/max"})
+	write(now.Add(-time.Minute), events.TypePatrolReceipt, receipt("gastown/polecats/max", "second"))
+	if err := os.WriteFile(eventsPath, []byte(b.String()), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := collectPatrolReceipts(eventsPath, "max", now.Add(-7*24*time.Hour), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decisions []string
+	for _, r := range got {
+		decisions = append(decisions, r.Decision)
+	}
+	if strings.Join(decisions, ",") != "second,first" {
+		t.Errorf("decisions = %v, want newest first and filtered", decisions)
+	}
+
+	got, err = collectPatrolReceipts(eventsPath, "gastown/polecats/max", time.Time{}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].Decision != "second" {
+		t.Errorf("limited = %+v", got)
+	}
+}
@@ -12,10 +12,13 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/cgroup"
+	"github.com/steveyegge/gastown/internal/events"
 	"github.com/steveyegge/gastown/internal/git"
 	"github.com/steveyegge/gastown/internal/polecat"
 	"github.com/steveyegge/gastown/internal/rig"
 	"github.com/steveyegge/gastown/internal/runtime"
+	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/tmux"
 	"github.com/steveyegge/gastown/internal/util"
@@ -599,6 +602,7 @@ type PolecatStatus struct {
 	Windows        int           `json:"windows,omitempty"`
 	CreatedAt      string        `json:"created_at,omitempty"`
 	LastActivity   string        `json:"last_activity,omitempty"`
+	Resources      *cgroup.Usage `json:"resources,omitempty"`
 }
 
 func runPolecatStatus(cmd *cobra.Command, args []string) error {
@@ -630,9 +634,13 @@ func runPolecatStatus(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Resource usage, when the session runs in its own cgroup (non-fatal)
+	usage, _ := cgroup.ReadUsage(sessInfo.SessionID)
+
 	// JSON output
 	if polecatStatusJSON {
 		status := PolecatStatus{
+			Resources:      usage,
 			Rig:            rigName,
 			Name:           polecatName,
 			State:          p.State,
@@ -716,9 +724,41 @@ func runPolecatStatus(cmd *cobra.Command, args []string) error {
 		fmt.Printf("  Status:        %s\n", style.Dim.Render("not running"))
 	}
 
+	if usage != nil {
+		printCgroupUsage(usage)
+	}
+
 	return nil
 }
 
+// printCgroupUsage prints the Resources section of gt polecat status.
+func printCgroupUsage(u *cgroup.Usage) {
+	limit := func(value string, set bool) string {
+		if !set {
+			return style.Dim.Render("(no limit)")
+		}
+		return "/ " + value
+	}
+
+	fmt.Println()
+	fmt.Printf("%s\n", style.Bold.Render("Resources"))
+	fmt.Printf("  Memory:        %s %s\n", formatBytes(u.MemoryCurrent),
+		limit(formatBytes(u.MemoryMax), u.MemoryMax > 0))
+	if u.MemoryPeak > 0 {
+		fmt.Printf("  Memory Peak:   %s\n", formatBytes(u.MemoryPeak))
+	}
+	fmt.Printf("  CPU Time:      %s", (time.Duration(u.CPUUsageUsec) * time.Microsecond).Round(time.Second))
+	if u.CPUWeight > 0 {
+		fmt.Printf(" %s", style.Dim.Render(fmt.Sprintf("(weight %d)", u.CPUWeight)))
+	}
+	fmt.Println()
+	fmt.Printf("  Processes:     %d %s\n", u.PidsCurrent, limit(fmt.Sprint(u.PidsMax), u.PidsMax > 0))
+	if u.OOMKills > 0 {
+		fmt.Printf("  OOM Kills:     %s\n", style.Warning.Render(fmt.Sprint(u.OOMKills)))
+	}
+	fmt.Printf("  Cgroup:        %s\n", style.Dim.Render(u.Path))
+}
+
 // formatActivityTime returns a human-readable relative time string.
 func formatActivityTime(t time.Time) string {
 	d := time.Since(t)
@@ -1114,7 +1154,7 @@ func runPolecatNuke(cmd *cobra.Command, args []string) error {
 
 	// Nuke each polecat
 	var nukeErrors []string
-	nuked := 0
+	nuked, held := 0, 0
 
 	for _, p := range targets {
 		if polecatNukeDryRun {
@@ -1129,6 +1169,17 @@ func runPolecatNuke(cmd *cobra.Command, args []string) error {
 			continue
 		}
 
+		if patrolShadowHold(filepath.Dir(p.r.Path), events.Receipt{
+			Agent:    fmt.Sprintf("%s/polecats/%s", p.rigName, p.polecatName),
+			Session:  session.PolecatSessionName(session.PrefixFor(p.rigName), p.polecatName),
+			Rule:     "polecat_nuke",
+			Decision: "requested",
+			Action:   "nuked",
+		}) {
+			held++
+			continue
+		}
+
 		if polecatNukeForce {
 			fmt.Printf("%s Nuking %s/%s (--force)...\n", style.Warning.Render("⚠"), p.rigName, p.polecatName)
 		} else {
@@ -1162,7 +1213,7 @@ func runPolecatNuke(cmd *cobra.Command, args []string) error {
 
 	// Final cleanup: Kill any orphaned Claude processes that escaped the session termination.
 	// This catches processes that called setsid() or were reparented during session shutdown.
-	if !polecatNukeDryRun {
+	if !polecatNukeDryRun && held == 0 {
 		cleanupOrphanedProcesses()
 	}
 
@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/polecat"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+var polecatSparesJSON bool
+
+var polecatSparesCmd = &cobra.Command{
+	Use:   "spares",
+	Short: "Manage the pre-warmed polecat pool",
+	RunE:  requireSubcommand,
+	Long: `Manage warm spares: polecat worktrees built ahead of time so gt sling
+can claim one instead of creating a polecat from scratch.
+
+A spare is checked out (detached) at the rig's default branch, provisioned
+like a new polecat, has merge_queue.setup_command already run, and holds a
+name from the pool. Claiming it moves it into polecats/<name>/, puts it on
+a fresh polecat branch and creates its agent bead.
+
+Configure the pool size in <rig>/settings/config.json:
+
+  "warm_pool": {"size": 2}
+
+The daemon fills the pool on each heartbeat and refreshes spares when the
+default branch moves (e.g., after merges).
+
+Examples:
+  gt polecat spares list gastown
+  gt polecat spares fill gastown
+  gt polecat spares drain gastown`,
+}
+
+var polecatSparesListCmd = &cobra.Command{
+	Use:   "list <rig>",
+	Short: "List warm spares",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runPolecatSparesList,
+}
+
+var polecatSparesFillCmd = &cobra.Command{
+	Use:   "fill <rig>",
+	Short: "Create and refresh spares to match warm_pool.size",
+	Long: `Fetch origin, refresh spares that are behind the default branch, remove
+spares beyond the configured size, and create new spares until the pool
+is full. Run by the daemon; safe to run by hand. Exits quietly if another
+fill is already running.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPolecatSparesFill,
+}
+
+var polecatSparesDrainCmd = &cobra.Command{
+	Use:   "drain <rig>",
+	Short: "Remove all warm spares",
+	Long: `Remove all warm spares and release their names. The daemon refills the
+pool on its next heartbeat unless warm_pool.size is 0.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPolecatSparesDrain,
+}
+
+func init() {
+	polecatSparesListCmd.Flags().BoolVar(&polecatSparesJSON, "json", false, "Output as JSON")
+
+	polecatSparesCmd.AddCommand(polecatSparesListCmd)
+	polecatSparesCmd.AddCommand(polecatSparesFillCmd)
+	polecatSparesCmd.AddCommand(polecatSparesDrainCmd)
+	polecatCmd.AddCommand(polecatSparesCmd)
+}
+
+func runPolecatSparesList(cmd *cobra.Command, args []string) error {
+	mgr, r, err := getPolecatManager(args[0])
+	if err != nil {
+		return err
+	}
+
+	spares, err := mgr.ListSpares()
+	if err != nil {
+		return err
+	}
+
+	if polecatSparesJSON {
+		if spares == nil {
+			spares = []*polecat.Spare{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(spares)
+	}
+
+	size := 0
+	if cfg := mgr.WarmPoolConfig(); cfg != nil {
+		size = cfg.Size
+	}
+	fmt.Printf("%s %s: %d/%d ready\n\n", style.Bold.Render("Warm pool"), r.Name, len(spares), size)
+	if len(spares) == 0 {
+		if size == 0 {
+			fmt.Printf("%s\n", style.Dim.Render("Pool disabled (set warm_pool.size in settings/config.json)"))
+		}
+		return nil
+	}
+	for _, s := range spares {
+		setup := "no setup"
+		switch {
+		case s.SetupError != "":
+			setup = style.Warning.Render("setup failed: " + s.SetupError)
+		case s.SetupRan:
+			setup = "setup done"
+		}
+		fmt.Printf("  %s  %s @ %s  %s\n", style.Bold.Render(s.Name), s.BaseRef,
+			s.Commit[:min(8, len(s.Commit))], style.Dim.Render(setup))
+		fmt.Printf("    refreshed %s\n", s.RefreshedAt.Local().Format("2006-01-02 15:04"))
+	}
+	return nil
+}
+
+func runPolecatSparesFill(cmd *cobra.Command, args []string) error {
+	mgr, r, err := getPolecatManager(args[0])
+	if err != nil {
+		return err
+	}
+
+	result, err := mgr.FillWarmPool()
+	if errors.Is(err, polecat.ErrWarmPoolBusy) {
+		fmt.Printf("%s %s\n", style.Dim.Render("○"), err)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, name := range result.Created {
+		fmt.Printf("%s Created spare %s/%s\n", style.Bold.Render("✓"), r.Name, name)
+	}
+	for _, name := range result.Refreshed {
+		fmt.Printf("%s Refreshed spare %s/%s\n", style.Bold.Render("✓"), r.Name, name)
+	}
+	for _, name := range result.Removed {
+		fmt.Printf("%s Removed spare %s/%s\n", style.Bold.Render("✓"), r.Name, name)
+	}
+	if len(result.Errors) > 0 {
+		return fmt.Errorf("warm pool fill: %s", strings.Join(result.Errors, "; "))
+	}
+	return nil
+}
+
+func runPolecatSparesDrain(cmd *cobra.Command, args []string) error {
+	mgr, r, err := getPolecatManager(args[0])
+	if err != nil {
+		return err
+	}
+
+	removed, err := mgr.DrainWarmPool()
+	for _, name := range removed {
+		fmt.Printf("%s Removed spare %s/%s\n", style.Bold.Render("✓"), r.Name, name)
+	}
+	if err != nil {
+		return err
+	}
+	if len(removed) == 0 {
+		fmt.Printf("%s No spares to remove\n", style.Dim.Render("○"))
+	}
+	return nil
+}
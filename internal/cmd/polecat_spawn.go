@@ -2,6 +2,7 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -97,16 +98,6 @@ func SpawnPolecatForSling(rigName string, opts SlingSpawnOptions) (*SpawnedPolec
 		return nil, fmt.Errorf("admission control: %w", err)
 	}
 
-	// Allocate a new polecat name
-	polecatName, err := polecatMgr.AllocateName()
-	if err != nil {
-		return nil, fmt.Errorf("allocating polecat name: %w", err)
-	}
-	fmt.Printf("Allocated polecat: %s\n", polecatName)
-
-	// Check if polecat already exists (shouldn't happen - indicates stale state needing repair)
-	existingPolecat, err := polecatMgr.Get(polecatName)
-
 	// Determine base branch for polecat worktree
 	baseBranch := opts.BaseBranch
 	if baseBranch == "" && opts.HookBead != "" {
@@ -138,42 +129,19 @@ func SpawnPolecatForSling(rigName string, opts SlingSpawnOptions) (*SpawnedPolec
 		BaseBranch: baseBranch,
 	}
 
-	if err == nil {
-		// Stale state: polecat exists despite fresh name allocation - repair it
-		// Check for uncommitted work first
-		if !opts.Force {
-			pGit := git.NewGit(existingPolecat.ClonePath)
-			workStatus, checkErr := pGit.CheckUncommittedWork()
-			if checkErr == nil && !workStatus.Clean() {
-				return nil, fmt.Errorf("polecat '%s' has uncommitted work: %s\nUse --force to proceed anyway",
-					polecatName, workStatus.String())
-			}
-		}
-
-		// Check for unmerged MRs - destroying a polecat with pending MR loses work (ne-rn24b)
-		if existingPolecat.Branch != "" {
-			bd := beads.New(r.Path)
-			mr, mrErr := bd.FindMRForBranch(existingPolecat.Branch)
-			if mrErr == nil && mr != nil {
-				return nil, fmt.Errorf("polecat '%s' has unmerged MR: %s\n"+
-					"Wait for MR to merge before respawning, or use:\n"+
-					"  gt polecat nuke --force %s/%s  # to abandon the MR",
-					polecatName, mr.ID, rigName, polecatName)
-			}
-		}
-
-		fmt.Printf("Repairing stale polecat %s with fresh worktree...\n", polecatName)
-		if _, err = polecatMgr.RepairWorktreeWithOptions(polecatName, opts.Force, addOpts); err != nil {
-			return nil, fmt.Errorf("repairing stale polecat: %w", err)
+	// Claim a pre-warmed spare when one is ready for this base; otherwise
+	// build a polecat from scratch.
+	var polecatName string
+	if spare, claimErr := polecatMgr.ClaimSpare(addOpts); claimErr == nil {
+		polecatName = spare.Name
+		fmt.Printf("Claimed warm spare: %s\n", polecatName)
+	} else {
+		if !errors.Is(claimErr, polecat.ErrNoSpare) {
+			style.PrintWarning("could not claim warm spare: %v", claimErr)
 		}
-	} else if err == polecat.ErrPolecatNotFound {
-		// Create new polecat
-		fmt.Printf("Creating polecat %s...\n", polecatName)
-		if _, err = polecatMgr.AddWithOptions(polecatName, addOpts); err != nil {
-			return nil, fmt.Errorf("creating polecat: %w", err)
+		if polecatName, err = createPolecatForSling(polecatMgr, rigName, r.Path, opts.Force, addOpts); err != nil {
+			return nil, err
 		}
-	} else {
-		return nil, fmt.Errorf("getting polecat: %w", err)
 	}
 
 	// Get polecat object for path info
@@ -225,6 +193,60 @@ func SpawnPolecatForSling(rigName string, opts SlingSpawnOptions) (*SpawnedPolec
 	}, nil
 }
 
+// createPolecatForSling allocates a polecat name and builds its worktree,
+// repairing stale state if the name unexpectedly still exists.
+func createPolecatForSling(polecatMgr *polecat.Manager, rigName, rigPath string, force bool, addOpts polecat.AddOptions) (string, error) {
+	// Allocate a new polecat name
+	polecatName, err := polecatMgr.AllocateName()
+	if err != nil {
+		return "", fmt.Errorf("allocating polecat name: %w", err)
+	}
+	fmt.Printf("Allocated polecat: %s\n", polecatName)
+
+	// Check if polecat already exists (shouldn't happen - indicates stale state needing repair)
+	existingPolecat, err := polecatMgr.Get(polecatName)
+
+	if err == nil {
+		// Stale state: polecat exists despite fresh name allocation - repair it
+		// Check for uncommitted work first
+		if !force {
+			pGit := git.NewGit(existingPolecat.ClonePath)
+			workStatus, checkErr := pGit.CheckUncommittedWork()
+			if checkErr == nil && !workStatus.Clean() {
+				return "", fmt.Errorf("polecat '%s' has uncommitted work: %s\nUse --force to proceed anyway",
+					polecatName, workStatus.String())
+			}
+		}
+
+		// Check for unmerged MRs - destroying a polecat with pending MR loses work (ne-rn24b)
+		if existingPolecat.Branch != "" {
+			bd := beads.New(rigPath)
+			mr, mrErr := bd.FindMRForBranch(existingPolecat.Branch)
+			if mrErr == nil && mr != nil {
+				return "", fmt.Errorf("polecat '%s' has unmerged MR: %s\n"+
+					"Wait for MR to merge before respawning, or use:\n"+
+					"  gt polecat nuke --force %s/%s  # to abandon the MR",
+					polecatName, mr.ID, rigName, polecatName)
+			}
+		}
+
+		fmt.Printf("Repairing stale polecat %s with fresh worktree...\n", polecatName)
+		if _, err = polecatMgr.RepairWorktreeWithOptions(polecatName, force, addOpts); err != nil {
+			return "", fmt.Errorf("repairing stale polecat: %w", err)
+		}
+	} else if err == polecat.ErrPolecatNotFound {
+		// Create new polecat
+		fmt.Printf("Creating polecat %s...\n", polecatName)
+		if _, err = polecatMgr.AddWithOptions(polecatName, addOpts); err != nil {
+			return "", fmt.Errorf("creating polecat: %w", err)
+		}
+	} else {
+		return "", fmt.Errorf("getting polecat: %w", err)
+	}
+
+	return polecatName, nil
+}
+
 // StartSession starts the tmux session for a spawned polecat.
 // This is called after the molecule/bead is attached, so the polecat
 // sees its work when gt prime runs on session start.
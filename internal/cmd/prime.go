@@ -18,6 +18,7 @@ import (
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/telemetry"
 	"github.com/steveyegge/gastown/internal/workspace"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 var primeHookMode bool
@@ -92,7 +93,12 @@ func init() {
 type RoleContext = RoleInfo
 
 func runPrime(cmd *cobra.Command, args []string) (retErr error) {
-	defer func() { telemetry.RecordPrime(context.Background(), os.Getenv("GT_ROLE"), primeHookMode, retErr) }()
+	primeCtx, primeSpan := telemetry.StartSpan(context.Background(), "gt.prime",
+		attribute.String("gt.role", os.Getenv("GT_ROLE")))
+	defer func() {
+		telemetry.RecordPrime(primeCtx, os.Getenv("GT_ROLE"), primeHookMode, retErr)
+		telemetry.EndSpan(primeSpan, retErr)
+	}()
 	if err := validatePrimeFlags(); err != nil {
 		return err
 	}
@@ -110,10 +116,11 @@ func runPrime(cmd *cobra.Command, args []string) (retErr error) {
 	}
 
 	// Check for handoff marker (prevents handoff loop bug)
+	var postHandoff bool
 	if primeDryRun {
-		checkHandoffMarkerDryRun(cwd)
+		postHandoff = checkHandoffMarkerDryRun(cwd)
 	} else {
-		checkHandoffMarker(cwd)
+		postHandoff = checkHandoffMarker(cwd)
 	}
 
 	roleInfo, err := GetRoleWithContext(cwd, townRoot)
@@ -149,7 +156,7 @@ func runPrime(cmd *cobra.Command, args []string) (retErr error) {
 		return nil
 	}
 
-	formula, err := outputRoleContext(ctx)
+	formula, handoffDocID, err := outputRoleContext(ctx, postHandoff)
 	if err != nil {
 		return err
 	}
@@ -158,7 +165,7 @@ func runPrime(cmd *cobra.Command, args []string) (retErr error) {
 	// started with. Only emitted when GT telemetry is active (GT_OTEL_LOGS_URL set).
 	telemetry.RecordPrimeContext(context.Background(), formula, os.Getenv("GT_ROLE"), primeHookMode)
 
-	hasSlungWork := checkSlungWork(ctx)
+	hasSlungWork := checkSlungWork(ctx, handoffDocID)
 	explain(hasSlungWork, "Autonomous mode: hooked/in-progress work detected")
 
 	outputMoleculeContext(ctx)
@@ -190,7 +197,7 @@ func runPrimeCompactResume(ctx RoleContext, cwd string) {
 	outputSessionMetadata(ctx)
 
 	// Check for hooked work — critical for resuming after compaction
-	hasSlungWork := checkSlungWork(ctx)
+	hasSlungWork := checkSlungWork(ctx, "")
 
 	// Molecule progress if available
 	outputMoleculeContext(ctx)
@@ -307,21 +314,23 @@ func setupPrimeSession(ctx RoleContext, roleInfo RoleInfo) error {
 }
 
 // outputRoleContext emits session metadata and all role/context output sections.
-// Returns the rendered formula content for OTEL telemetry (empty if using fallback path).
-func outputRoleContext(ctx RoleContext) (string, error) {
+// Returns the rendered formula content for OTEL telemetry (empty if using fallback path)
+// and the ID of the handoff mail rendered as a handoff document, if any.
+func outputRoleContext(ctx RoleContext, postHandoff bool) (string, string, error) {
 	explain(true, "Session metadata: always included for seance discovery")
 	outputSessionMetadata(ctx)
 
 	explain(true, fmt.Sprintf("Role context: detected role is %s", ctx.Role))
 	formula, err := outputPrimeContext(ctx)
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
 
 	outputContextFile(ctx)
 	outputHandoffContent(ctx)
+	handoffDocID := outputHandoffDocument(ctx, postHandoff)
 	outputAttachmentStatus(ctx)
-	return formula, nil
+	return formula, handoffDocID, nil
 }
 
 // runPrimeExternalTools runs bd prime and gt mail check --inject.
@@ -391,7 +400,9 @@ func runMailCheckInject(workDir string) {
 // checkSlungWork checks for hooked work on the agent's hook.
 // If found, displays AUTONOMOUS WORK MODE and tells the agent to execute immediately.
 // Returns true if hooked work was found (caller should skip normal startup directive).
-func checkSlungWork(ctx RoleContext) bool {
+// A hooked bead whose ID is handoffDocID was already shown as the handoff
+// document, so its details aren't repeated.
+func checkSlungWork(ctx RoleContext, handoffDocID string) bool {
 	hookedBead := findAgentWork(ctx)
 	if hookedBead == nil {
 		return false
@@ -403,9 +414,14 @@ func checkSlungWork(ctx RoleContext) bool {
 	outputAutonomousDirective(ctx, hookedBead, hasMolecule)
 	outputHookedBeadDetails(hookedBead)
 
-	if hasMolecule {
+	switch {
+	case hasMolecule:
 		outputMoleculeWorkflow(ctx, attachment)
-	} else {
+	case hookedBead.ID == handoffDocID:
+		// Already rendered in full as the handoff document.
+		fmt.Println(style.Dim.Render("(Bead details: see the Handoff Document above)"))
+		fmt.Println()
+	default:
 		outputBeadPreview(hookedBead)
 	}
 
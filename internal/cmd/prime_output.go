@@ -6,11 +6,14 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/checkpoint"
 	"github.com/steveyegge/gastown/internal/deacon"
+	"github.com/steveyegge/gastown/internal/handoff"
+	"github.com/steveyegge/gastown/internal/mail"
 	"github.com/steveyegge/gastown/internal/rig"
 	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/style"
@@ -378,6 +381,63 @@ func outputHandoffContent(ctx RoleContext) {
 	fmt.Println(style.Dim.Render("(Clear with: gt rig reset --handoff)"))
 }
 
+// outputHandoffDocument renders the structured handoff document from the
+// most recent hooked handoff mail, so the successor starts with the
+// predecessor's goal, progress and next steps instead of re-discovering them.
+// Only post-handoff sessions look it up, sparing every other prime the bd
+// query. Returns the ID of the rendered mail, or "" if none was rendered.
+func outputHandoffDocument(ctx RoleContext, postHandoff bool) string {
+	if !postHandoff {
+		explain(true, "Handoff document: not a post-handoff session")
+		return ""
+	}
+	agentID := getAgentIdentity(ctx)
+	if agentID == "" {
+		return ""
+	}
+	bd := beads.New(ctx.TownRoot).OnMain()
+	hooked, err := bd.List(beads.ListOptions{
+		Status:   beads.StatusHooked,
+		Label:    "gt:message",
+		Assignee: mail.AddressToIdentity(agentID),
+		Priority: -1,
+	})
+	if err != nil {
+		explain(true, "Handoff document: mail lookup failed: "+err.Error())
+		return ""
+	}
+
+	var latest *beads.Issue
+	for _, issue := range hooked {
+		if !strings.Contains(issue.Title, "HANDOFF") {
+			continue
+		}
+		if latest == nil || issue.CreatedAt > latest.CreatedAt {
+			latest = issue
+		}
+	}
+	if latest == nil {
+		explain(true, "Handoff document: no hooked handoff mail")
+		return ""
+	}
+	doc := handoff.Parse(latest.Description)
+	if !doc.IsStructured() {
+		explain(true, "Handoff document: "+latest.ID+" has no structured sections")
+		return ""
+	}
+	explain(true, "Handoff document: rendering "+latest.ID)
+
+	fmt.Println()
+	fmt.Printf("%s\n\n", style.Bold.Render("## 📋 Handoff Document"))
+	fmt.Printf("From %s: %s\n\n", latest.ID, latest.Title)
+	fmt.Print(doc.Render())
+	if missing := doc.Missing(); len(missing) > 0 {
+		fmt.Println()
+		fmt.Println(style.Dim.Render("Predecessor left no: " + strings.Join(missing, ", ") + " (verify before relying on it)"))
+	}
+	return latest.ID
+}
+
 // outputStartupDirective outputs role-specific instructions for the agent.
 // This tells agents like Mayor to announce themselves on startup.
 func outputStartupDirective(ctx RoleContext) {
@@ -641,6 +701,10 @@ func outputCheckpointContext(ctx RoleContext) {
 	if cp.Notes != "" {
 		fmt.Printf("  **Notes:** %s\n", cp.Notes)
 	}
+	if cp.WIPRef != "" {
+		fmt.Printf("  **WIP snapshot:** %s\n", cp.WIPRef)
+		fmt.Println("    If the modified files above are missing, run `gt checkpoint restore`.")
+	}
 	fmt.Println()
 
 	fmt.Println("Use this context to resume work. The checkpoint will be updated as you progress.")
@@ -291,12 +291,13 @@ func detectSessionState(ctx RoleContext) SessionState {
 // This prevents the "handoff loop" bug where a new session sees /handoff in context
 // and incorrectly runs it again. The marker tells the new session: "handoff is DONE,
 // the /handoff you see in context was from YOUR PREDECESSOR, not a request for you."
-func checkHandoffMarker(workDir string) {
+// Returns whether the marker was found, i.e. this is a post-handoff session.
+func checkHandoffMarker(workDir string) bool {
 	markerPath := filepath.Join(workDir, constants.DirRuntime, constants.FileHandoffMarker)
 	data, err := os.ReadFile(markerPath)
 	if err != nil {
 		// No marker = not post-handoff, normal startup
-		return
+		return false
 	}
 
 	// Marker found - this is a post-handoff session
@@ -307,16 +308,17 @@ func checkHandoffMarker(workDir string) {
 
 	// Output prominent warning
 	outputHandoffWarning(prevSession)
+	return true
 }
 
 // checkHandoffMarkerDryRun checks for handoff marker without removing it (for --dry-run).
-func checkHandoffMarkerDryRun(workDir string) {
+func checkHandoffMarkerDryRun(workDir string) bool {
 	markerPath := filepath.Join(workDir, constants.DirRuntime, constants.FileHandoffMarker)
 	data, err := os.ReadFile(markerPath)
 	if err != nil {
 		// No marker = not post-handoff, normal startup
 		explain(true, "Post-handoff: no handoff marker found")
-		return
+		return false
 	}
 
 	// Marker found - this is a post-handoff session
@@ -325,4 +327,5 @@ func checkHandoffMarkerDryRun(workDir string) {
 
 	// Output the warning but don't remove marker
 	outputHandoffWarning(prevSession)
+	return true
 }
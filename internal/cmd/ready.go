@@ -9,11 +9,13 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/doltserver"
 	"github.com/steveyegge/gastown/internal/git"
 	"github.com/steveyegge/gastown/internal/rig"
 	"github.com/steveyegge/gastown/internal/style"
@@ -22,6 +24,7 @@ import (
 
 var readyJSON bool
 var readyRig string
+var readyAsOf string
 
 var readyCmd = &cobra.Command{
 	Use:     "ready",
@@ -36,16 +39,22 @@ Aggregates ready issues from:
 Ready items have no blockers and can be worked immediately.
 Results are sorted by priority (highest first) then by source.
 
+With --as-of, the board is read from Dolt history instead: what was ready
+at that time (open, no open blockers). Accepts a duration ago (6h, 1d),
+"yesterday", a local date or time (2006-01-02 15:04), or a Dolt commit.
+
 Examples:
   gt ready              # Show all ready work
   gt ready --json       # Output as JSON
-  gt ready --rig=gastown  # Show only one rig`,
+  gt ready --rig=gastown  # Show only one rig
+  gt ready --as-of yesterday  # The board at midnight yesterday`,
 	RunE: runReady,
 }
 
 func init() {
 	readyCmd.Flags().BoolVar(&readyJSON, "json", false, "Output as JSON")
 	readyCmd.Flags().StringVar(&readyRig, "rig", "", "Filter to a specific rig")
+	readyCmd.Flags().StringVar(&readyAsOf, "as-of", "", "Show ready work as it was at a past time or Dolt commit")
 	rootCmd.AddCommand(readyCmd)
 }
 
@@ -61,6 +70,7 @@ type ReadyResult struct {
 	Sources  []ReadySource `json:"sources"`
 	Summary  ReadySummary  `json:"summary"`
 	TownRoot string        `json:"town_root,omitempty"`
+	AsOf     string        `json:"as_of,omitempty"`
 }
 
 // ReadySummary provides counts for the ready report.
@@ -81,6 +91,15 @@ func runReady(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("not in a Gas Town workspace: %w", err)
 	}
 
+	var at *doltserver.AsOf
+	if readyAsOf != "" {
+		parsed, err := doltserver.ParseAsOf(readyAsOf, time.Now())
+		if err != nil {
+			return err
+		}
+		at = &parsed
+	}
+
 	// Load rigs config
 	rigsConfigPath := constants.MayorRigsPath(townRoot)
 	rigsConfig, err := config.LoadRigsConfig(rigsConfigPath)
@@ -123,7 +142,7 @@ func runReady(cmd *cobra.Command, args []string) error {
 			defer wg.Done()
 			townBeadsPath := beads.GetTownBeadsPath(townRoot)
 			townBeads := beads.New(townBeadsPath)
-			issues, err := townBeads.Ready()
+			issues, err := readyIssues(townBeads, townRoot, "hq", at)
 
 			mu.Lock()
 			defer mu.Unlock()
@@ -152,7 +171,7 @@ func runReady(cmd *cobra.Command, args []string) error {
 			// Use rig root path where rig-level beads are stored
 			// BeadsPath returns rig root; redirect system handles mayor/rig routing
 			rigBeads := beads.New(r.BeadsPath())
-			issues, err := rigBeads.Ready()
+			issues, err := readyIssues(rigBeads, townRoot, r.Name, at)
 
 			mu.Lock()
 			defer mu.Unlock()
@@ -222,6 +241,9 @@ func runReady(cmd *cobra.Command, args []string) error {
 		Summary:  summary,
 		TownRoot: townRoot,
 	}
+	if at != nil {
+		result.AsOf = at.String()
+	}
 
 	// Check for source errors
 	var failedSources []string
@@ -254,12 +276,16 @@ func runReady(cmd *cobra.Command, args []string) error {
 }
 
 func printReadyHuman(result ReadyResult) error {
+	asOf := ""
+	if result.AsOf != "" {
+		asOf = " as of " + result.AsOf
+	}
 	if result.Summary.Total == 0 {
-		fmt.Println("No ready work across town.")
+		fmt.Printf("No ready work across town%s.\n", asOf)
 		return nil
 	}
 
-	fmt.Printf("%s Ready work across town:\n\n", style.Bold.Render("📋"))
+	fmt.Printf("%s Ready work across town%s:\n\n", style.Bold.Render("📋"), asOf)
 
 	for _, src := range result.Sources {
 		if src.Error != "" {
@@ -326,6 +352,15 @@ func printReadyHuman(result ReadyResult) error {
 	return nil
 }
 
+// readyIssues returns a source's ready issues, now or (with at) as they
+// were in Dolt history. rigName is "hq" for town beads.
+func readyIssues(b *beads.Beads, townRoot, rigName string, at *doltserver.AsOf) ([]*beads.Issue, error) {
+	if at == nil {
+		return b.Ready()
+	}
+	return doltserver.ReadyAsOf(townRoot, doltserver.DatabaseForRig(townRoot, rigName), *at)
+}
+
 // getFormulaNames reads the formulas directory and returns a set of formula names.
 // Formula names are derived from filenames by removing the ".formula.toml" suffix.
 func getFormulaNames(beadsPath string) map[string]bool {
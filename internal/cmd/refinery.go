@@ -5,11 +5,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/events"
 	"github.com/steveyegge/gastown/internal/refinery"
 	"github.com/steveyegge/gastown/internal/rig"
 	"github.com/steveyegge/gastown/internal/session"
@@ -533,7 +535,7 @@ func runRefineryRestart(cmd *cobra.Command, args []string) error {
 		rigName = args[0]
 	}
 
-	mgr, _, rigName, err := getRefineryManager(rigName)
+	mgr, r, rigName, err := getRefineryManager(rigName)
 	if err != nil {
 		return err
 	}
@@ -542,6 +544,16 @@ func runRefineryRestart(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if patrolShadowHold(filepath.Dir(r.Path), events.Receipt{
+		Agent:    rigName + "/refinery",
+		Session:  mgr.SessionName(),
+		Rule:     "refinery_restart",
+		Decision: "requested",
+		Action:   "restarted",
+	}) {
+		return nil
+	}
+
 	fmt.Printf("Restarting refinery for %s...\n", rigName)
 
 	// Stop if running (ignore ErrNotRunning)
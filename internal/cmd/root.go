@@ -83,6 +83,11 @@ var branchCheckExemptCommands = map[string]bool{
 
 // persistentPreRun runs before every command.
 func persistentPreRun(cmd *cobra.Command, args []string) error {
+	// Sandbox launchers sit between tmux and the agent: no warnings, no bd calls.
+	if cmd.HasParent() && cmd.Parent().Name() == "sandbox" && cmd.Hidden {
+		return nil
+	}
+
 	// Check if binary was built properly (via make build, not raw go build).
 	// Raw go build produces unsigned binaries that macOS may kill.
 	// Warning only - doesn't block execution.
@@ -241,6 +246,10 @@ func Execute() int {
 		// Set OTEL_RESOURCE_ATTRIBUTES in the process env so all bd subprocesses
 		// spawned via exec.Command inherit GT context automatically.
 		telemetry.SetProcessOTELAttrs()
+		// Exported log events are redacted with this town's settings.
+		if townRoot, err := workspace.FindFromCwd(); err == nil && townRoot != "" {
+			telemetry.SetTownRoot(townRoot)
+		}
 	}
 
 	if err := rootCmd.Execute(); err != nil {
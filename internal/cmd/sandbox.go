@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/sandbox"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+var (
+	sandboxRigPath string
+	sandboxWorkDir string
+)
+
+var sandboxCmd = &cobra.Command{
+	Use:     "sandbox",
+	GroupID: GroupAgents,
+	Short:   "Namespace sandbox for polecat sessions",
+	RunE:    requireSubcommand,
+	Long: `Run polecat sessions inside Linux user, mount, and network namespaces.
+
+When enabled for a rig, each polecat session sees:
+  - its worktree, git directory, and beads directory: writable
+  - the town's and rig's .runtime state: read-only, except lock files
+    and the nudge queue
+  - the gt and bd binaries: visible
+  - the rest of $HOME: read-only, or hidden behind an empty tmpfs
+  - the network: an allowlist enforced by a proxy (by default only the
+    agent's API host), loopback only, or - if "network" is set to "host" -
+    unchanged
+
+The Dolt server port is forwarded into isolated sessions so bd keeps working.
+A session fails to start rather than run unsandboxed.
+
+Configure in <rig>/settings/config.json:
+
+  "sandbox": {
+    "enabled": true,
+    "home": "hidden",
+    "network": "allowlist",
+    "allow_hosts": ["api.anthropic.com", "github.com", "*.githubusercontent.com"]
+  }
+
+Denied proxy requests are logged to polecats/<name>/.sandbox/denied.log.
+
+Examples:
+  gt sandbox show gastown`,
+}
+
+var sandboxShowCmd = &cobra.Command{
+	Use:   "show <rig>",
+	Short: "Show a rig's sandbox settings",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runSandboxShow,
+}
+
+var sandboxRunCmd = &cobra.Command{
+	Use:    "run --rig-path <path> --workdir <dir> -- <command> [args...]",
+	Short:  "Run a command in the rig's sandbox (session start wrapper)",
+	Hidden: true,
+	Args:   cobra.MinimumNArgs(1),
+	RunE:   runSandboxRun,
+}
+
+var sandboxInitCmd = &cobra.Command{
+	Use:    "init -- <command> [args...]",
+	Short:  "Sandbox entry point inside the namespaces (started by run)",
+	Hidden: true,
+	Args:   cobra.MinimumNArgs(1),
+	RunE:   runSandboxInit,
+}
+
+func init() {
+	sandboxRunCmd.Flags().StringVar(&sandboxRigPath, "rig-path", "", "Rig directory whose settings apply")
+	sandboxRunCmd.Flags().StringVar(&sandboxWorkDir, "workdir", "", "Polecat worktree")
+	_ = sandboxRunCmd.MarkFlagRequired("rig-path")
+	_ = sandboxRunCmd.MarkFlagRequired("workdir")
+
+	sandboxCmd.AddCommand(sandboxShowCmd)
+	sandboxCmd.AddCommand(sandboxRunCmd)
+	sandboxCmd.AddCommand(sandboxInitCmd)
+	rootCmd.AddCommand(sandboxCmd)
+}
+
+func runSandboxShow(cmd *cobra.Command, args []string) error {
+	_, r, err := getRig(args[0])
+	if err != nil {
+		return err
+	}
+	settings, err := config.LoadRigSettings(config.RigSettingsPath(r.Path))
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s %s\n\n", style.Bold.Render("Sandbox"), r.Name)
+	sb := settings.Sandbox
+	if !sb.IsEnabled() {
+		fmt.Printf("  %s\n", style.Dim.Render("Disabled (set sandbox.enabled in settings/config.json)"))
+		return nil
+	}
+
+	orDefault := func(v, def string) string {
+		if v == "" {
+			return def + style.Dim.Render(" (default)")
+		}
+		return v
+	}
+	fmt.Printf("  Home:          %s\n", orDefault(sb.Home, config.SandboxHomeReadOnly))
+	fmt.Printf("  Network:       %s\n", orDefault(sb.Network, config.SandboxNetworkAllowlist))
+	if len(sb.AllowHosts) > 0 {
+		fmt.Printf("  Allow Hosts:   %s\n", strings.Join(sb.AllowHosts, ", "))
+	} else if sb.Network == "" {
+		fmt.Printf("  Allow Hosts:   %s\n", strings.Join(sandbox.DefaultAllowHosts, ", ")+style.Dim.Render(" (default)"))
+	}
+	if len(sb.ForwardPorts) > 0 {
+		fmt.Printf("  Forward Ports: %s\n", strings.Trim(fmt.Sprint(sb.ForwardPorts), "[]"))
+	}
+	if sb.Writable != nil {
+		fmt.Printf("  Writable:      %s\n", strings.Join(sb.Writable, ", "))
+	}
+	if len(sb.ReadOnly) > 0 {
+		fmt.Printf("  Read-only:     %s\n", strings.Join(sb.ReadOnly, ", "))
+	}
+	if !sandbox.Supported() {
+		fmt.Printf("\n  %s\n", style.Warning.Render("User namespaces are unavailable here: polecat sessions will fail to start"))
+	}
+	return nil
+}
+
+func runSandboxRun(cmd *cobra.Command, args []string) error {
+	rigPath, err := filepath.Abs(sandboxRigPath)
+	if err != nil {
+		return err
+	}
+	settings, err := config.LoadRigSettings(config.RigSettingsPath(rigPath))
+	if err != nil {
+		return err
+	}
+	if !settings.Sandbox.IsEnabled() {
+		return fmt.Errorf("sandbox is not enabled for %s", rigPath)
+	}
+
+	policy, err := sandbox.Resolve(settings.Sandbox, filepath.Dir(rigPath), rigPath, sandboxWorkDir)
+	if err != nil {
+		return err
+	}
+	code, err := sandbox.Run(policy, args)
+	if err != nil {
+		return fmt.Errorf("sandbox: %w", err)
+	}
+	if code != 0 {
+		return NewSilentExit(code)
+	}
+	return nil
+}
+
+func runSandboxInit(cmd *cobra.Command, args []string) error {
+	code, err := sandbox.Init(args)
+	if err != nil {
+		return fmt.Errorf("sandbox: %w", err)
+	}
+	if code != 0 {
+		return NewSilentExit(code)
+	}
+	return nil
+}
@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"bufio"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -273,39 +272,27 @@ func runSeanceTalk(sessionID, prompt string) error {
 func discoverSessions(townRoot string) ([]sessionEvent, error) {
 	eventsPath := filepath.Join(townRoot, events.EventsFile)
 
-	file, err := os.Open(eventsPath)
-	if err != nil {
-		if os.IsNotExist(err) {
-			return nil, nil
-		}
-		return nil, err
-	}
-	defer file.Close()
-
+	// The segment index lets this skip segments without session starts.
 	var sessions []sessionEvent
-	scanner := bufio.NewScanner(file)
-
-	// Increase buffer for large lines
-	buf := make([]byte, 0, 64*1024)
-	scanner.Buffer(buf, 1024*1024)
-
-	for scanner.Scan() {
+	q := events.Query{Types: []string{events.TypeSessionStart}}
+	err := events.ReadLines(eventsPath, q, func(line []byte) bool {
 		var event sessionEvent
-		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
-			continue
+		if err := json.Unmarshal(line, &event); err != nil {
+			return true
 		}
 
 		if event.Type == events.TypeSessionStart {
 			sessions = append(sessions, event)
 		}
-	}
+		return true
+	})
 
 	// Sort by timestamp descending (most recent first)
 	sort.Slice(sessions, func(i, j int) bool {
 		return sessions[i].Timestamp > sessions[j].Timestamp
 	})
 
-	return sessions, scanner.Err()
+	return sessions, err
 }
 
 func getPayloadString(payload map[string]interface{}, key string) string {
@@ -0,0 +1,306 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/transcript"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// Replay/transcripts command flags
+var (
+	replayJSON       bool
+	replayFull       bool
+	replayNoThinking bool
+	replayAbsolute   bool
+
+	transcriptsAgent string
+	transcriptsBead  string
+	transcriptsSince string
+	transcriptsLimit int
+	transcriptsJSON  bool
+)
+
+// replayTruncate is how much of each text block and tool result is shown
+// without --full.
+const replayTruncate = 400
+
+var sessionReplayCmd = &cobra.Command{
+	Use:   "replay <session-id>",
+	Short: "Replay an archived session transcript",
+	Long: `Render an archived session transcript.
+
+Transcripts are archived into <town>/.transcripts/ when a session ends
+(gt done, gt session stop, gt crew stop, gt down, and the other role stop
+commands). Replay shows each message, tool call and tool
+result with its offset from session start and the tokens used by each
+assistant turn, followed by a summary of totals.
+
+A unique prefix of the session ID is enough. Use 'gt session transcripts'
+to find sessions by agent or bead.
+
+Examples:
+  gt session replay 3f2a9c              # Replay by ID prefix
+  gt session replay 3f2a9c --full       # Don't truncate long output
+  gt session replay 3f2a9c --json       # Parsed conversation as JSON`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSessionReplay,
+}
+
+var sessionTranscriptsCmd = &cobra.Command{
+	Use:   "transcripts",
+	Short: "List archived session transcripts",
+	Long: `List archived session transcripts, newest first.
+
+Examples:
+  gt session transcripts
+  gt session transcripts --agent gastown/polecats/toast
+  gt session transcripts --bead gt-abc12 --json
+  gt session transcripts --since 7d`,
+	RunE: runSessionTranscripts,
+}
+
+func init() {
+	sessionReplayCmd.Flags().BoolVar(&replayJSON, "json", false, "Output the parsed conversation as JSON")
+	sessionReplayCmd.Flags().BoolVar(&replayFull, "full", false, "Show full text and tool output")
+	sessionReplayCmd.Flags().BoolVar(&replayNoThinking, "no-thinking", false, "Hide thinking blocks")
+	sessionReplayCmd.Flags().BoolVar(&replayAbsolute, "absolute", false, "Show wall-clock times instead of offsets")
+
+	sessionTranscriptsCmd.Flags().StringVar(&transcriptsAgent, "agent", "", "Filter by agent address")
+	sessionTranscriptsCmd.Flags().StringVar(&transcriptsBead, "bead", "", "Filter by bead ID")
+	sessionTranscriptsCmd.Flags().StringVar(&transcriptsSince, "since", "", "Only sessions ended within this duration (e.g. 24h, 7d)")
+	sessionTranscriptsCmd.Flags().IntVarP(&transcriptsLimit, "limit", "n", 50, "Maximum sessions to show (0 for all)")
+	sessionTranscriptsCmd.Flags().BoolVar(&transcriptsJSON, "json", false, "Output as JSON")
+
+	sessionCmd.AddCommand(sessionReplayCmd)
+	sessionCmd.AddCommand(sessionTranscriptsCmd)
+}
+
+func runSessionReplay(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	entry, err := transcript.Find(townRoot, args[0])
+	if err != nil {
+		return err
+	}
+	r, err := transcript.Open(townRoot, entry)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	conv, err := transcript.Parse(r)
+	if err != nil {
+		return fmt.Errorf("parsing transcript: %w", err)
+	}
+
+	if replayJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(struct {
+			Entry        *transcript.Entry        `json:"entry"`
+			Conversation *transcript.Conversation `json:"conversation"`
+		}{entry, conv})
+	}
+
+	printReplayHeader(entry, conv)
+	for _, step := range conv.Steps {
+		if replayNoThinking && step.Kind == transcript.KindThinking {
+			continue
+		}
+		printReplayStep(step, conv.Start)
+	}
+	printReplaySummary(conv)
+	return nil
+}
+
+func printReplayHeader(entry *transcript.Entry, conv *transcript.Conversation) {
+	fmt.Printf("%s %s\n", style.Bold.Render("Session"), entry.SessionID)
+	fmt.Printf("  Agent:  %s\n", entry.Agent)
+	if entry.Bead != "" {
+		fmt.Printf("  Bead:   %s\n", entry.Bead)
+	}
+	if conv.Model != "" {
+		fmt.Printf("  Model:  %s\n", conv.Model)
+	}
+	if !conv.Start.IsZero() {
+		fmt.Printf("  Start:  %s\n", conv.Start.Local().Format("2006-01-02 15:04:05"))
+	}
+	fmt.Println()
+}
+
+func printReplayStep(step transcript.Step, start time.Time) {
+	stamp := replayStamp(step.Time, start)
+	var label, body string
+	switch step.Kind {
+	case transcript.KindText:
+		if step.Role == "assistant" {
+			label = style.Info.Render("assistant")
+		} else {
+			label = style.Bold.Render("user")
+		}
+		body = replayClip(step.Text)
+	case transcript.KindThinking:
+		label = style.Dim.Render("thinking")
+		body = style.Dim.Render(replayClip(step.Text))
+	case transcript.KindToolUse:
+		label = style.Warning.Render("tool")
+		body = step.Tool + " " + style.Dim.Render(replayToolInput(step.Input))
+	case transcript.KindToolResult:
+		label = style.Dim.Render("result")
+		if step.IsError {
+			label = style.Error.Render("error")
+		}
+		body = replayClip(step.Text)
+	case transcript.KindSummary:
+		label = style.Dim.Render("summary")
+		body = replayClip(step.Text)
+	default:
+		return
+	}
+
+	fmt.Printf("%s %s", style.Dim.Render(stamp), label)
+	if step.Usage != nil {
+		fmt.Printf(" %s", style.Dim.Render(fmt.Sprintf("[in %d, cache %d/%d, out %d]",
+			step.Usage.InputTokens, step.Usage.CacheCreationInputTokens,
+			step.Usage.CacheReadInputTokens, step.Usage.OutputTokens)))
+	}
+	fmt.Println()
+	for _, line := range strings.Split(strings.TrimRight(body, "\n"), "\n") {
+		fmt.Printf("    %s\n", line)
+	}
+}
+
+func printReplaySummary(conv *transcript.Conversation) {
+	fmt.Println()
+	fmt.Println(style.Bold.Render("Summary"))
+	if !conv.Start.IsZero() && !conv.End.IsZero() {
+		fmt.Printf("  Duration:   %s\n", formatDuration(conv.End.Sub(conv.Start)))
+	}
+	fmt.Printf("  Steps:      %d\n", len(conv.Steps))
+	u := conv.Usage
+	fmt.Printf("  Tokens:     %d total (in %d, cache write %d, cache read %d, out %d)\n",
+		u.Total(), u.InputTokens, u.CacheCreationInputTokens, u.CacheReadInputTokens, u.OutputTokens)
+
+	if len(conv.ToolCalls) == 0 {
+		return
+	}
+	tools := make([]string, 0, len(conv.ToolCalls))
+	total := 0
+	for name, n := range conv.ToolCalls {
+		tools = append(tools, name)
+		total += n
+	}
+	sort.Slice(tools, func(i, j int) bool {
+		if conv.ToolCalls[tools[i]] != conv.ToolCalls[tools[j]] {
+			return conv.ToolCalls[tools[i]] > conv.ToolCalls[tools[j]]
+		}
+		return tools[i] < tools[j]
+	})
+	parts := make([]string, len(tools))
+	for i, name := range tools {
+		parts[i] = fmt.Sprintf("%s %d", name, conv.ToolCalls[name])
+	}
+	fmt.Printf("  Tool calls: %d (%s)\n", total, strings.Join(parts, ", "))
+}
+
+// replayStamp formats a step time as an offset from session start, or as
+// wall-clock time with --absolute.
+func replayStamp(ts, start time.Time) string {
+	if ts.IsZero() {
+		return "        "
+	}
+	if replayAbsolute || start.IsZero() {
+		return ts.Local().Format("15:04:05")
+	}
+	d := ts.Sub(start)
+	return fmt.Sprintf("+%02d:%02d:%02d", int(d.Hours()), int(d.Minutes())%60, int(d.Seconds())%60)
+}
+
+// replayClip truncates s unless --full was given.
+func replayClip(s string) string {
+	if replayFull || len(s) <= replayTruncate {
+		return s
+	}
+	return s[:replayTruncate] + style.Dim.Render(fmt.Sprintf("… (%d more bytes)", len(s)-replayTruncate))
+}
+
+// replayToolInput renders a tool call's input compactly: a lone command,
+// path or pattern is shown bare, anything else as single-line JSON.
+func replayToolInput(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(raw, &fields); err == nil {
+		for _, key := range []string{"command", "file_path", "pattern", "url"} {
+			if v, ok := fields[key].(string); ok && v != "" {
+				return replayClip(v)
+			}
+		}
+	}
+	compact, err := json.Marshal(json.RawMessage(raw))
+	if err != nil {
+		return ""
+	}
+	return replayClip(string(compact))
+}
+
+func runSessionTranscripts(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	q := transcript.Query{Agent: transcriptsAgent, Bead: transcriptsBead}
+	if transcriptsSince != "" {
+		d, err := parseDuration(transcriptsSince)
+		if err != nil {
+			return fmt.Errorf("invalid --since duration: %w", err)
+		}
+		q.Since = time.Now().Add(-d)
+	}
+	entries, err := transcript.List(townRoot, q)
+	if err != nil {
+		return err
+	}
+	if transcriptsLimit > 0 && len(entries) > transcriptsLimit {
+		entries = entries[:transcriptsLimit]
+	}
+
+	if transcriptsJSON {
+		if entries == nil {
+			entries = []transcript.Entry{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(entries)
+	}
+
+	if len(entries) == 0 {
+		fmt.Println("No archived transcripts.")
+		return nil
+	}
+	for _, e := range entries {
+		bead := e.Bead
+		if bead == "" {
+			bead = "-"
+		}
+		fmt.Printf("%s  %s  %-32s %-12s %5d msgs  %8d tok  %s\n",
+			e.EndedAt.Local().Format("2006-01-02 15:04"),
+			style.Bold.Render(truncate(e.SessionID, 8)),
+			e.Agent, bead, e.Messages, e.Usage.Total(),
+			style.Dim.Render(formatBytes(e.Stored)))
+	}
+	return nil
+}
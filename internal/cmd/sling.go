@@ -18,6 +18,7 @@ import (
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/telemetry"
 	"github.com/steveyegge/gastown/internal/workspace"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 var slingCmd = &cobra.Command{
@@ -156,15 +157,23 @@ func init() {
 }
 
 func runSling(cmd *cobra.Command, args []string) (retErr error) {
+	slingBead, slingTarget := "", ""
+	if len(args) > 0 {
+		slingBead = args[0]
+	}
+	if len(args) > 1 {
+		slingTarget = args[1]
+	}
+	// The sling span is the root of the work's trace. Exporting it as this
+	// process's TRACEPARENT lets the spawned session, the bead fields and
+	// the mail written below all carry it forward.
+	ctx, span := telemetry.StartSpan(context.Background(), "gt.sling",
+		attribute.String("gt.bead", slingBead), attribute.String("gt.target", slingTarget))
+	restoreTraceparent := telemetry.SetProcessTraceparent(ctx)
 	defer func() {
-		bead, target := "", ""
-		if len(args) > 0 {
-			bead = args[0]
-		}
-		if len(args) > 1 {
-			target = args[1]
-		}
-		telemetry.RecordSling(context.Background(), bead, target, retErr)
+		restoreTraceparent()
+		telemetry.RecordSling(ctx, slingBead, slingTarget, retErr)
+		telemetry.EndSpan(span, retErr)
 	}()
 	// Polecats cannot sling - check early before writing anything.
 	// Check GT_ROLE first: coordinators (mayor, witness, etc.) may have a stale
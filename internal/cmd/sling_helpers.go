@@ -307,6 +307,10 @@ func storeFieldsInBead(beadID string, updates beadFieldUpdates) error {
 	if updates.ConvoyOwned {
 		fields.ConvoyOwned = true
 	}
+	// Record the sling's trace so gt done can continue it.
+	if tp := telemetry.Traceparent(context.Background()); tp != "" {
+		fields.Traceparent = tp
+	}
 
 	// Write back once
 	newDesc := beads.SetAttachmentFields(issue, fields)
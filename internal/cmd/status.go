@@ -1,16 +1,13 @@
 package cmd
 
 import (
-	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
 	"os"
-	"os/signal"
 	"path/filepath"
 	"strings"
 	"sync"
-	"syscall"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -33,6 +30,7 @@ var statusFast bool
 var statusWatch bool
 var statusInterval int
 var statusVerbose bool
+var statusNotify bool
 
 var statusCmd = &cobra.Command{
 	Use:     "status",
@@ -44,7 +42,10 @@ var statusCmd = &cobra.Command{
 Shows town name, registered rigs, polecats, and witness status.
 
 Use --fast to skip mail lookups for faster execution.
-Use --watch to continuously refresh status at regular intervals.`,
+Use --watch to continuously refresh status at regular intervals; the
+screen is only redrawn when something changes. Add --notify to also get
+terminal/desktop notifications for subscribed events while watching
+(see 'gt notify watch').`,
 	RunE: runStatus,
 }
 
@@ -53,6 +54,7 @@ func init() {
 	statusCmd.Flags().BoolVar(&statusFast, "fast", false, "Skip mail lookups for faster execution")
 	statusCmd.Flags().BoolVarP(&statusWatch, "watch", "w", false, "Watch mode: refresh status continuously")
 	statusCmd.Flags().IntVarP(&statusInterval, "interval", "n", 2, "Refresh interval in seconds")
+	statusCmd.Flags().BoolVar(&statusNotify, "notify", false, "With --watch: send overseer notifications for subscribed events")
 	statusCmd.Flags().BoolVarP(&statusVerbose, "verbose", "v", false, "Show detailed multi-line output per agent")
 	rootCmd.AddCommand(statusCmd)
 }
@@ -422,13 +424,6 @@ func runStatusWatch(_ *cobra.Command, _ []string) error {
 		return fmt.Errorf("interval must be positive, got %d", statusInterval)
 	}
 
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
-	defer signal.Stop(sigChan)
-
-	ticker := time.NewTicker(time.Duration(statusInterval) * time.Second)
-	defer ticker.Stop()
-
 	isTTY := term.IsTerminal(int(os.Stdout.Fd()))
 
 	// Cache the last successful status to handle transient tmux/beads
@@ -439,21 +434,7 @@ func runStatusWatch(_ *cobra.Command, _ []string) error {
 	var cachedAt time.Time
 	maxStale := time.Duration(statusInterval) * time.Second * 5
 
-	for {
-		var buf bytes.Buffer
-
-		if isTTY {
-			buf.WriteString("\033[H\033[2J") // ANSI: cursor home + clear screen
-		}
-
-		timestamp := time.Now().Format("15:04:05")
-		header := fmt.Sprintf("[%s] gt status --watch (every %ds, Ctrl+C to stop)", timestamp, statusInterval)
-		if isTTY {
-			fmt.Fprintf(&buf, "%s\n\n", style.Dim.Render(header))
-		} else {
-			fmt.Fprintf(&buf, "%s\n\n", header)
-		}
-
+	render := func(w io.Writer) error {
 		status, err := gatherStatus()
 		usedCache := false
 
@@ -488,43 +469,41 @@ func runStatusWatch(_ *cobra.Command, _ []string) error {
 		}
 
 		if err != nil {
-			fmt.Fprintf(&buf, "Error: %v\n", err)
-		} else {
-			if !usedCache {
-				statusCopy := status
-				cachedStatus = &statusCopy
-				cachedAt = time.Now()
-			}
-			if usedCache {
-				staleNote := fmt.Sprintf(
-					"(using cached data from %s)",
-					cachedAt.Format("15:04:05"),
-				)
-				if isTTY {
-					fmt.Fprintf(&buf, "%s\n",
-						style.Dim.Render(staleNote))
-				} else {
-					fmt.Fprintf(&buf, "%s\n", staleNote)
-				}
-			}
-			if err := outputStatusText(&buf, status); err != nil {
-				fmt.Fprintf(&buf, "Error: %v\n", err)
-			}
+			return err
 		}
-
-		// Write the entire frame atomically to prevent the terminal from
-		// rendering a blank screen between the clear and the content.
-		_, _ = os.Stdout.Write(buf.Bytes())
-
-		select {
-		case <-sigChan:
+		if !usedCache {
+			statusCopy := status
+			cachedStatus = &statusCopy
+			cachedAt = time.Now()
+		}
+		if usedCache {
+			staleNote := fmt.Sprintf(
+				"(using cached data from %s)",
+				cachedAt.Format("15:04:05"),
+			)
 			if isTTY {
-				fmt.Println("\nStopped.")
+				fmt.Fprintf(w, "%s\n",
+					style.Dim.Render(staleNote))
+			} else {
+				fmt.Fprintf(w, "%s\n", staleNote)
 			}
-			return nil
-		case <-ticker.C:
 		}
+		return outputStatusText(w, status)
+	}
+
+	loop := &watchLoop{
+		title:    "gt status --watch",
+		interval: time.Duration(statusInterval) * time.Second,
+		render:   render,
+	}
+	if statusNotify {
+		townRoot, err := workspace.FindFromCwdOrError()
+		if err != nil {
+			return fmt.Errorf("not in a Gas Town workspace: %w", err)
+		}
+		loop.attachNotifier(townRoot)
 	}
+	return loop.run()
 }
 
 // countRunningAgents returns the number of agents with Running=true
@@ -0,0 +1,541 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/townlog"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// Timeline command flags
+var (
+	timelineSince   string
+	timelineUntil   string
+	timelineSources []string
+	timelineTypes   []string
+	timelineLimit   int
+	timelineJSON    bool
+)
+
+// Timeline sources.
+const (
+	timelineSourceEvents  = "events"
+	timelineSourceTownlog = "townlog"
+	timelineSourceMail    = "mail"
+	timelineSourceBeads   = "beads"
+	timelineSourceGit     = "git"
+)
+
+var timelineAllSources = []string{
+	timelineSourceEvents,
+	timelineSourceTownlog,
+	timelineSourceMail,
+	timelineSourceBeads,
+	timelineSourceGit,
+}
+
+var timelineCmd = &cobra.Command{
+	Use:     "timeline <agent-or-bead>",
+	GroupID: GroupDiag,
+	Short:   "Show everything an agent or bead went through, in order",
+	Long: `Merge every record of an agent or bead into one chronological view.
+
+Sources:
+  events   .events.jsonl activity (actor or payload mentions the target)
+  townlog  logs/town.log lifecycle events (spawn, crash, handoff, ...)
+  mail     messages sent or received (agent), or mentioning the bead
+  beads    beads created/closed (agent), or the bead's own lifecycle
+  git      commits authored by the agent, or referencing the bead
+
+Every entry carries a source ID so it can be followed up:
+  events   file and line: .events.jsonl:<n> for the active log, or
+           .events/<segment>:<n> for a closed segment (sealed segments
+           are zstd-compressed; read them with zstdcat)
+  townlog  timestamp in logs/town.log
+  mail     message ID (gt mail read <id>)
+  beads    bead ID (bd show <id>)
+  git      commit hash
+
+A target containing "/" (or a bare role like "mayor") is an agent address;
+anything shaped like a bead ID (gt-abc12, hq-cv-xyz) is a bead.
+
+Examples:
+  gt timeline gastown/polecats/toast --since 6h
+  gt timeline gt-abc12 --since 2d
+  gt timeline deacon --source events,townlog
+  gt timeline gastown/witness --type session_death,crash --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTimeline,
+}
+
+func init() {
+	timelineCmd.Flags().StringVar(&timelineSince, "since", "24h", "Show entries newer than this (e.g., 6h, 2d; empty for all)")
+	timelineCmd.Flags().StringVar(&timelineUntil, "until", "", "Show entries older than this (e.g., 1h)")
+	timelineCmd.Flags().StringSliceVar(&timelineSources, "source", nil, "Only these sources (events, townlog, mail, beads, git)")
+	timelineCmd.Flags().StringSliceVar(&timelineTypes, "type", nil, "Only these entry types (e.g., sling, crash, commit)")
+	timelineCmd.Flags().IntVarP(&timelineLimit, "limit", "n", 0, "Show only the most recent N entries (0 = all)")
+	timelineCmd.Flags().BoolVar(&timelineJSON, "json", false, "Output as JSON")
+
+	rootCmd.AddCommand(timelineCmd)
+}
+
+// TimelineEntry is one record in a merged timeline.
+type TimelineEntry struct {
+	Timestamp time.Time `json:"timestamp"`
+	Source    string    `json:"source"`
+	Type      string    `json:"type"`
+	Actor     string    `json:"actor,omitempty"`
+	Summary   string    `json:"summary"`
+	SourceID  string    `json:"source_id"` // where to find the original record
+}
+
+// timelineTarget is what a timeline is about: an agent or a bead.
+type timelineTarget struct {
+	Agent string // agent address, e.g. "gastown/polecats/toast"
+	Bead  string // bead ID, e.g. "gt-abc12"
+}
+
+// parseTimelineTarget decides whether arg names an agent or a bead.
+func parseTimelineTarget(arg string) timelineTarget {
+	if !strings.Contains(arg, "/") && looksLikeIssueID(arg) {
+		return timelineTarget{Bead: arg}
+	}
+	return timelineTarget{Agent: strings.TrimSuffix(arg, "/")}
+}
+
+func (t timelineTarget) String() string {
+	if t.Bead != "" {
+		return t.Bead
+	}
+	return t.Agent
+}
+
+// matchesAgent reports whether an address refers to the target agent.
+// Addresses are compared exactly (modulo trailing slash and case) so that
+// "toast" does not pick up "gastown/polecats/toaster".
+func (t timelineTarget) matchesAgent(addr string) bool {
+	if t.Agent == "" || addr == "" {
+		return false
+	}
+	a := strings.ToLower(strings.TrimSuffix(addr, "/"))
+	want := strings.ToLower(t.Agent)
+	return a == want || (!strings.Contains(want, "/") && strings.HasSuffix(a, "/"+want))
+}
+
+// timelineWindow bounds entries by time; zero values are open-ended.
+type timelineWindow struct {
+	since, until time.Time
+}
+
+func (w timelineWindow) contains(ts time.Time) bool {
+	if !w.since.IsZero() && ts.Before(w.since) {
+		return false
+	}
+	if !w.until.IsZero() && ts.After(w.until) {
+		return false
+	}
+	return true
+}
+
+func runTimeline(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	var window timelineWindow
+	if timelineSince != "" {
+		d, err := parseDuration(timelineSince)
+		if err != nil {
+			return fmt.Errorf("invalid --since duration: %w", err)
+		}
+		window.since = time.Now().Add(-d)
+	}
+	if timelineUntil != "" {
+		d, err := parseDuration(timelineUntil)
+		if err != nil {
+			return fmt.Errorf("invalid --until duration: %w", err)
+		}
+		window.until = time.Now().Add(-d)
+	}
+
+	sources := timelineAllSources
+	if len(timelineSources) > 0 {
+		for _, s := range timelineSources {
+			if !containsString(timelineAllSources, s) {
+				return fmt.Errorf("unknown --source %q (valid: %s)", s, strings.Join(timelineAllSources, ", "))
+			}
+		}
+		sources = timelineSources
+	}
+
+	target := parseTimelineTarget(args[0])
+	collectors := map[string]func(string, timelineTarget, timelineWindow) ([]TimelineEntry, error){
+		timelineSourceEvents:  collectTimelineEvents,
+		timelineSourceTownlog: collectTimelineTownlog,
+		timelineSourceMail:    collectTimelineMail,
+		timelineSourceBeads:   collectTimelineBeads,
+		timelineSourceGit:     collectTimelineGit,
+	}
+
+	var entries []TimelineEntry
+	for _, source := range sources {
+		got, err := collectors[source](townRoot, target, window)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: could not query %s: %v\n", source, err)
+		}
+		entries = append(entries, got...)
+	}
+	entries = finishTimeline(entries, timelineTypes, timelineLimit)
+
+	if timelineJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if entries == nil {
+			entries = []TimelineEntry{}
+		}
+		return enc.Encode(entries)
+	}
+
+	if len(entries) == 0 {
+		fmt.Printf("%s No activity found for %s\n", style.Dim.Render("○"), target)
+		return nil
+	}
+	outputTimelineText(target, entries)
+	return nil
+}
+
+// finishTimeline filters by type, sorts oldest first, and keeps the most
+// recent limit entries.
+func finishTimeline(entries []TimelineEntry, types []string, limit int) []TimelineEntry {
+	if len(types) > 0 {
+		filtered := entries[:0]
+		for _, e := range entries {
+			if containsString(types, e.Type) {
+				filtered = append(filtered, e)
+			}
+		}
+		entries = filtered
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Timestamp.Before(entries[j].Timestamp)
+	})
+	if limit > 0 && len(entries) > limit {
+		entries = entries[len(entries)-limit:]
+	}
+	return entries
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// collectTimelineEvents scans .events.jsonl and its closed segments. Source
+// IDs are the file holding the event and its line there. An event belongs to an agent
+// timeline when the agent is the actor or is named in the payload (sling
+// targets, session deaths); to a bead timeline when the payload mentions
+// the bead ID.
+func collectTimelineEvents(townRoot string, target timelineTarget, window timelineWindow) ([]TimelineEntry, error) {
+	var entries []TimelineEntry
+	err := events.ReadPositioned(filepath.Join(townRoot, events.EventsFile), events.Query{}, func(pos events.Position, raw []byte) bool {
+		var e events.Event
+		if err := json.Unmarshal(raw, &e); err != nil {
+			return true
+		}
+		ts, _ := time.Parse(time.RFC3339, e.Timestamp)
+		if !window.contains(ts) {
+			return true
+		}
+
+		var match bool
+		if target.Bead != "" {
+			match = payloadMentions(e.Payload, func(s string) bool { return strings.Contains(s, target.Bead) })
+		} else {
+			match = target.matchesAgent(e.Actor) || payloadMentions(e.Payload, target.matchesAgent)
+		}
+		if !match {
+			return true
+		}
+
+		entries = append(entries, TimelineEntry{
+			Timestamp: ts,
+			Source:    timelineSourceEvents,
+			Type:      e.Type,
+			Actor:     e.Actor,
+			Summary:   formatFeedSummary(e),
+			SourceID:  pos.String(),
+		})
+		return true
+	})
+	return entries, err
+}
+
+// payloadMentions reports whether any string value in payload satisfies match.
+func payloadMentions(payload map[string]interface{}, match func(string) bool) bool {
+	for _, v := range payload {
+		switch t := v.(type) {
+		case string:
+			if match(t) {
+				return true
+			}
+		case []interface{}:
+			for _, item := range t {
+				if s, ok := item.(string); ok && match(s) {
+					return true
+				}
+			}
+		case map[string]interface{}:
+			if payloadMentions(t, match) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// collectTimelineTownlog reads logs/town.log. Bead IDs only survive in the
+// rendered detail text, so bead timelines match on that.
+func collectTimelineTownlog(townRoot string, target timelineTarget, window timelineWindow) ([]TimelineEntry, error) {
+	all, err := townlog.ReadEvents(townRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []TimelineEntry
+	for _, e := range all {
+		if !window.contains(e.Timestamp) {
+			continue
+		}
+		if target.Bead != "" {
+			if !strings.Contains(e.Detail, target.Bead) {
+				continue
+			}
+		} else if !target.matchesAgent(e.Agent) {
+			continue
+		}
+		summary := e.Detail
+		if summary == "" {
+			summary = formatTownlogSummary(e)
+		}
+		entries = append(entries, TimelineEntry{
+			Timestamp: e.Timestamp,
+			Source:    timelineSourceTownlog,
+			Type:      string(e.Type),
+			Actor:     e.Agent,
+			Summary:   summary,
+			SourceID:  "town.log@" + e.Timestamp.Format("2006-01-02 15:04:05"),
+		})
+	}
+	return entries, nil
+}
+
+// collectTimelineMail lists town mail, read and unread. Agents match as
+// sender or recipient; beads match when the subject or body mentions them.
+func collectTimelineMail(townRoot string, target timelineTarget, window timelineWindow) ([]TimelineEntry, error) {
+	issues, err := beads.New(townRoot).OnMain().List(beads.ListOptions{
+		Status:   "all",
+		Label:    "gt:message",
+		Priority: -1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []TimelineEntry
+	for _, issue := range issues {
+		bm := mail.BeadsMessage{
+			ID:          issue.ID,
+			Title:       issue.Title,
+			Description: issue.Description,
+			Assignee:    issue.Assignee,
+			Labels:      issue.Labels,
+			CreatedAt:   parseBeadsTimestamp(issue.CreatedAt),
+		}
+		msg := bm.ToMessage()
+		if !window.contains(msg.Timestamp) {
+			continue
+		}
+
+		var summary string
+		switch {
+		case target.Bead != "":
+			if !strings.Contains(msg.Subject, target.Bead) && !strings.Contains(msg.Body, target.Bead) {
+				continue
+			}
+			summary = fmt.Sprintf("%s → %s: %s", msg.From, msg.To, msg.Subject)
+		case target.matchesAgent(msg.From):
+			summary = fmt.Sprintf("Sent to %s: %s", msg.To, msg.Subject)
+		case target.matchesAgent(msg.To):
+			summary = fmt.Sprintf("Received from %s: %s", msg.From, msg.Subject)
+		default:
+			continue
+		}
+
+		entries = append(entries, TimelineEntry{
+			Timestamp: msg.Timestamp,
+			Source:    timelineSourceMail,
+			Type:      "mail",
+			Actor:     msg.From,
+			Summary:   summary,
+			SourceID:  msg.ID,
+		})
+	}
+	return entries, nil
+}
+
+// collectTimelineBeads reports bead lifecycle: for an agent, beads it
+// created or was assigned; for a bead, its own creation and closure.
+func collectTimelineBeads(townRoot string, target timelineTarget, window timelineWindow) ([]TimelineEntry, error) {
+	var issues []*beads.Issue
+	if target.Bead != "" {
+		dir := beads.ResolveRoutingTarget(townRoot, target.Bead, townRoot)
+		issue, err := beads.New(dir).OnMain().Show(target.Bead)
+		if err != nil {
+			return nil, err
+		}
+		issues = []*beads.Issue{issue}
+	} else {
+		b := beads.New(timelineAgentRepo(townRoot, target.Agent)).OnMain()
+		assigned, err := b.List(beads.ListOptions{Status: "all", Assignee: target.Agent, Priority: -1})
+		if err != nil {
+			return nil, err
+		}
+		issues = assigned
+	}
+
+	var entries []TimelineEntry
+	add := func(ts time.Time, typ, actor, summary, id string) {
+		if ts.IsZero() || !window.contains(ts) {
+			return
+		}
+		entries = append(entries, TimelineEntry{
+			Timestamp: ts,
+			Source:    timelineSourceBeads,
+			Type:      typ,
+			Actor:     actor,
+			Summary:   summary,
+			SourceID:  id,
+		})
+	}
+	for _, issue := range issues {
+		if issue == nil || beads.HasLabel(issue, "gt:message") {
+			continue
+		}
+		add(parseBeadsTimestamp(issue.CreatedAt), "bead_created", issue.CreatedBy,
+			fmt.Sprintf("Created: %s", issue.Title), issue.ID)
+		if issue.Status == "closed" {
+			add(parseBeadsTimestamp(issue.ClosedAt), "bead_closed", issue.Assignee,
+				fmt.Sprintf("Closed: %s", issue.Title), issue.ID)
+		} else if target.Bead != "" && issue.UpdatedAt != issue.CreatedAt {
+			add(parseBeadsTimestamp(issue.UpdatedAt), "bead_updated", issue.Assignee,
+				fmt.Sprintf("Last update (%s): %s", issue.Status, issue.Title), issue.ID)
+		}
+	}
+	return entries, nil
+}
+
+// collectTimelineGit searches the town repo and the relevant rig clone:
+// commits by the agent, or commits whose message references the bead.
+func collectTimelineGit(townRoot string, target timelineTarget, window timelineWindow) ([]TimelineEntry, error) {
+	args := []string{"log", "--all", "--format=%H|%aI|%an|%s"}
+	if target.Bead != "" {
+		args = append(args, "--fixed-strings", "--grep="+target.Bead)
+	} else {
+		args = append(args, "--author="+extractAuthorName(target.Agent))
+	}
+	if !window.since.IsZero() {
+		args = append(args, "--since="+window.since.Format(time.RFC3339))
+	}
+	if !window.until.IsZero() {
+		args = append(args, "--until="+window.until.Format(time.RFC3339))
+	}
+
+	repos := []string{townRoot}
+	if target.Bead != "" {
+		repos = append(repos, beads.ResolveRoutingTarget(townRoot, target.Bead, townRoot))
+	} else {
+		repos = append(repos, timelineAgentRepo(townRoot, target.Agent))
+	}
+
+	seen := make(map[string]bool)
+	var entries []TimelineEntry
+	for _, repo := range repos {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repo
+		out, err := cmd.Output()
+		if err != nil {
+			continue // not a git repo
+		}
+		for _, line := range strings.Split(string(out), "\n") {
+			parts := strings.SplitN(line, "|", 4)
+			if len(parts) < 4 || seen[parts[0]] {
+				continue
+			}
+			seen[parts[0]] = true
+			ts, _ := time.Parse(time.RFC3339, parts[1])
+			entries = append(entries, TimelineEntry{
+				Timestamp: ts,
+				Source:    timelineSourceGit,
+				Type:      "commit",
+				Actor:     parts[2],
+				Summary:   parts[3],
+				SourceID:  parts[0][:12],
+			})
+		}
+	}
+	return entries, nil
+}
+
+// timelineAgentRepo returns the rig clone for a rig-scoped agent address
+// ("gastown/polecats/toast" → <town>/gastown/mayor/rig), or the town root.
+func timelineAgentRepo(townRoot, agent string) string {
+	rig, _, ok := strings.Cut(agent, "/")
+	if !ok || rig == "" {
+		return townRoot
+	}
+	repo := filepath.Join(townRoot, rig, "mayor", "rig")
+	if _, err := os.Stat(repo); err != nil {
+		return townRoot
+	}
+	return repo
+}
+
+func outputTimelineText(target timelineTarget, entries []TimelineEntry) {
+	fmt.Printf("%s %s\n", style.Bold.Render("Timeline:"), target)
+
+	var currentDate string
+	for _, e := range entries {
+		date := e.Timestamp.Local().Format("2006-01-02")
+		if date != currentDate {
+			fmt.Printf("\n%s\n", style.Bold.Render("─── "+date+" ───────────────────────────────────────────"))
+			currentDate = date
+		}
+		fmt.Printf("%s %s %s %s %s\n",
+			style.Dim.Render(e.Timestamp.Local().Format("15:04:05")),
+			formatSource(e.Source),
+			formatType(e.Type),
+			e.Summary,
+			style.Dim.Render("["+e.SourceID+"]"),
+		)
+		if target.Bead != "" && e.Actor != "" {
+			fmt.Printf("         %s\n", style.Dim.Render("by "+e.Actor))
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/townlog"
+)
+
+func TestParseTimelineTarget(t *testing.T) {
+	tests := []struct {
+		arg       string
+		wantAgent string
+		wantBead  string
+	}{
+		{"gastown/polecats/toast", "gastown/polecats/toast", ""},
+		{"mayor/", "mayor", ""},
+		{"deacon", "deacon", ""},
+		{"gt-abc12", "", "gt-abc12"},
+		{"hq-cv-xyz", "", "hq-cv-xyz"},
+	}
+	for _, tt := range tests {
+		got := parseTimelineTarget(tt.arg)
+		if got.Agent != tt.wantAgent || got.Bead != tt.wantBead {
+			t.Errorf("parseTimelineTarget(%q) = %+v, want agent=%q bead=%q", tt.arg, got, tt.wantAgent, tt.wantBead)
+		}
+	}
+}
+
+func TestTimelineTargetMatchesAgent(t *testing.T) {
+	target := timelineTarget{Agent: "gastown/polecats/toast"}
+	if !target.matchesAgent("gastown/polecats/toast") || !target.matchesAgent("Gastown/Polecats/Toast/") {
+		t.Error("exact address should match")
+	}
+	if target.matchesAgent("gastown/polecats/toaster") || target.matchesAgent("toast") {
+		t.Error("different address should not match")
+	}
+
+	bare := timelineTarget{Agent: "mayor"}
+	if !bare.matchesAgent("mayor/") {
+		t.Error("bare role should match its address")
+	}
+}
+
+func TestCollectTimelineEvents(t *testing.T) {
+	town := t.TempDir()
+	now := time.Now().UTC()
+	lines := []events.Event{
+		{Timestamp: now.Add(-3 * time.Hour).Format(time.RFC3339), Type: events.TypeSling, Actor: "mayor",
+			Payload: map[string]interface{}{"bead": "gt-abc12", "target": "gastown/polecats/toast"}},
+		{Timestamp: now.Add(-2 * time.Hour).Format(time.RFC3339), Type: events.TypeDone, Actor: "gastown/polecats/toast",
+			Payload: map[string]interface{}{"bead": "gt-abc12"}},
+		{Timestamp: now.Add(-48 * time.Hour).Format(time.RFC3339), Type: events.TypeDone, Actor: "gastown/polecats/toast"},
+		{Timestamp: now.Add(-time.Hour).Format(time.RFC3339), Type: events.TypeDone, Actor: "gastown/polecats/nux"},
+	}
+	var data []byte
+	for _, e := range lines {
+		b, _ := json.Marshal(e)
+		data = append(append(data, b...), '\n')
+	}
+	if err := os.WriteFile(filepath.Join(town, events.EventsFile), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	window := timelineWindow{since: now.Add(-24 * time.Hour)}
+
+	got, err := collectTimelineEvents(town, timelineTarget{Agent: "gastown/polecats/toast"}, window)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("agent timeline = %+v, want sling (payload target) and done", got)
+	}
+	if got[0].SourceID != events.EventsFile+":1" || got[1].SourceID != events.EventsFile+":2" {
+		t.Errorf("source IDs = %q, %q; want line references", got[0].SourceID, got[1].SourceID)
+	}
+
+	got, err = collectTimelineEvents(town, timelineTarget{Bead: "gt-abc12"}, window)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 {
+		t.Errorf("bead timeline = %+v, want 2 entries", got)
+	}
+}
+
+func TestCollectTimelineTownlog(t *testing.T) {
+	town := t.TempDir()
+	logger := townlog.NewLogger(town)
+	if err := logger.Log(townlog.EventSpawn, "gastown/polecats/toast", "gt-abc12"); err != nil {
+		t.Fatal(err)
+	}
+	if err := logger.Log(townlog.EventSpawn, "gastown/polecats/nux", "gt-other"); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := collectTimelineTownlog(town, timelineTarget{Bead: "gt-abc12"}, timelineWindow{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].Actor != "gastown/polecats/toast" {
+		t.Fatalf("got %+v, want toast's spawn", got)
+	}
+	if !strings.HasPrefix(got[0].SourceID, "town.log@") {
+		t.Errorf("SourceID = %q", got[0].SourceID)
+	}
+}
+
+func TestFinishTimeline(t *testing.T) {
+	base := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	entries := []TimelineEntry{
+		{Timestamp: base.Add(2 * time.Minute), Type: "commit"},
+		{Timestamp: base, Type: "sling"},
+		{Timestamp: base.Add(time.Minute), Type: "mail"},
+		{Timestamp: base.Add(3 * time.Minute), Type: "sling"},
+	}
+
+	got := finishTimeline(append([]TimelineEntry(nil), entries...), nil, 2)
+	if len(got) != 2 || !got[0].Timestamp.Equal(base.Add(2*time.Minute)) || !got[1].Timestamp.Equal(base.Add(3*time.Minute)) {
+		t.Errorf("limit should keep the newest entries in chronological order, got %+v", got)
+	}
+
+	got = finishTimeline(append([]TimelineEntry(nil), entries...), []string{"sling"}, 0)
+	if len(got) != 2 || !got[0].Timestamp.Equal(base) {
+		t.Errorf("type filter got %+v", got)
+	}
+}
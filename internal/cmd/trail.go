@@ -401,45 +401,12 @@ func readHookTrailEntries(eventsPath string, since time.Time, limit int) ([]Hook
 		return []HookEntry{}, nil
 	}
 
-	data, err := os.ReadFile(eventsPath)
-	if err != nil {
-		if os.IsNotExist(err) {
-			return nil, nil
-		}
-		return nil, fmt.Errorf("reading events file: %w", err)
-	}
-
-	trimmed := strings.TrimSpace(string(data))
-	if trimmed == "" {
-		return nil, nil
-	}
-
-	lines := strings.Split(trimmed, "\n")
-	entryCap := len(lines)
-	if limit < entryCap {
-		entryCap = limit
-	}
-	entries := make([]HookEntry, 0, entryCap)
-	for i := len(lines) - 1; i >= 0; i-- {
-		line := strings.TrimSpace(lines[i])
-		if line == "" {
-			continue
-		}
-
-		var event events.Event
-		if err := json.Unmarshal([]byte(line), &event); err != nil {
-			continue
-		}
-		if event.Type != events.TypeHook && event.Type != events.TypeUnhook {
-			continue
-		}
-
+	var entries []HookEntry
+	q := events.Query{Since: since, Types: []string{events.TypeHook, events.TypeUnhook}}
+	err := events.Read(eventsPath, q, func(event events.Event) bool {
 		ts, err := time.Parse(time.RFC3339, event.Timestamp)
 		if err != nil {
-			continue
-		}
-		if !since.IsZero() && ts.Before(since) {
-			continue
+			return true
 		}
 
 		bead := ""
@@ -459,11 +426,19 @@ func readHookTrailEntries(eventsPath string, since time.Time, limit int) ([]Hook
 			Timestamp: ts,
 			TimeRel:   relativeTime(ts),
 		})
-		if len(entries) >= limit {
-			break
-		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("reading events: %w", err)
 	}
 
+	// Most recent first
+	for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+		entries[i], entries[j] = entries[j], entries[i]
+	}
+	if len(entries) > limit {
+		entries = entries[:limit]
+	}
 	return entries, nil
 }
 
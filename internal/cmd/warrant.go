@@ -10,6 +10,8 @@ import (
 	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/deacon"
+	"github.com/steveyegge/gastown/internal/events"
 	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/tmux"
@@ -33,6 +35,10 @@ type Warrant struct {
 	FiledAt    time.Time  `json:"filed_at"`
 	Executed   bool       `json:"executed,omitempty"`
 	ExecutedAt *time.Time `json:"executed_at,omitempty"`
+
+	// ShadowedAt is when a patrol in shadow mode first passed over this
+	// warrant, so the would-be execution is recorded only once.
+	ShadowedAt *time.Time `json:"shadowed_at,omitempty"`
 }
 
 var warrantCmd = &cobra.Command{
@@ -284,6 +290,7 @@ func runWarrantExecute(cmd *cobra.Command, args []string) error {
 	}
 
 	warrantPath := warrantFilePath(warrantDir, target)
+	townRoot := filepath.Dir(warrantDir)
 	var warrant *Warrant
 
 	// Load warrant if exists
@@ -309,22 +316,35 @@ func runWarrantExecute(cmd *cobra.Command, args []string) error {
 		if err := executeOneWarrant(warrant, warrantPath, tm); err != nil {
 			return fmt.Errorf("executing warrant: %w", err)
 		}
+		if deacon.InShadow(townRoot) {
+			fmt.Printf("%s Patrol shadow mode is on - warrant for %s left pending\n", style.Dim.Render("○"), target)
+			return nil
+		}
 	} else {
 		// --force without a warrant file: just kill the session
 		sessionName, err := targetToSessionName(target)
 		if err != nil {
 			return fmt.Errorf("determining session name: %w", err)
 		}
-		if has, err := tm.HasSession(sessionName); err != nil {
+		has, err := tm.HasSession(sessionName)
+		if err != nil {
 			return fmt.Errorf("checking session %s: %w", sessionName, err)
-		} else if has {
+		}
+		receipt := warrantReceipt(&Warrant{Target: target}, sessionName, has)
+		receipt.Decision = "forced"
+		receipt.Reason = "executed with --force and no warrant on file"
+		if has {
 			if err := tm.KillSessionWithProcesses(sessionName); err != nil {
+				receipt.Action = "kill-failed"
+				receipt.Error = err.Error()
+				_ = events.LogReceipt(townRoot, detectActor(), receipt)
 				return fmt.Errorf("killing session %s: %w", sessionName, err)
 			}
 			fmt.Printf("✓ Terminated session %s\n", sessionName)
 		} else {
 			fmt.Printf("  Session %s not found (already dead)\n", sessionName)
 		}
+		_ = events.LogReceipt(townRoot, detectActor(), receipt)
 	}
 
 	fmt.Printf("✓ Warrant executed for %s\n", style.Bold.Render(target))
@@ -346,8 +366,31 @@ func executeOneWarrant(w *Warrant, warrantPath string, tm *tmux.Tmux) error {
 		return fmt.Errorf("checking session %s: %w", sessionName, err)
 	}
 
+	receipt := warrantReceipt(w, sessionName, has)
+	townRoot := filepath.Dir(filepath.Dir(warrantPath)) // <town>/warrants/<file>
+
+	// In patrol shadow mode the warrant stays pending; turning shadow mode
+	// off lets the next triage cycle execute it for real.
+	if deacon.InShadow(townRoot) {
+		if w.ShadowedAt != nil {
+			return nil
+		}
+		receipt.Shadow = true
+		_ = events.LogReceipt(townRoot, detectActor(), receipt)
+		if has {
+			fmt.Printf("Shadow mode: would terminate session %s (%s)\n", sessionName, w.Target)
+		}
+		now := time.Now()
+		w.ShadowedAt = &now
+		return writeWarrant(w, warrantPath)
+	}
+
+	defer func() { _ = events.LogReceipt(townRoot, detectActor(), receipt) }()
+
 	if has {
 		if err := tm.KillSessionWithProcesses(sessionName); err != nil {
+			receipt.Action = "kill-failed"
+			receipt.Error = err.Error()
 			return fmt.Errorf("killing session %s: %w", sessionName, err)
 		}
 		fmt.Printf("Warrant executed: terminated session %s (%s)\n", sessionName, w.Target)
@@ -358,6 +401,11 @@ func executeOneWarrant(w *Warrant, warrantPath string, tm *tmux.Tmux) error {
 	now := time.Now()
 	w.Executed = true
 	w.ExecutedAt = &now
+	return writeWarrant(w, warrantPath)
+}
+
+// writeWarrant saves w back to its warrant file.
+func writeWarrant(w *Warrant, warrantPath string) error {
 	data, err := json.MarshalIndent(w, "", "  ")
 	if err != nil {
 		return fmt.Errorf("marshaling warrant: %w", err)
@@ -365,10 +413,33 @@ func executeOneWarrant(w *Warrant, warrantPath string, tm *tmux.Tmux) error {
 	if err := os.WriteFile(warrantPath, data, 0644); err != nil {
 		return fmt.Errorf("writing warrant file: %w", err)
 	}
-
 	return nil
 }
 
+// warrantReceipt describes executing a warrant against target's session.
+func warrantReceipt(w *Warrant, sessionName string, sessionAlive bool) events.Receipt {
+	r := events.Receipt{
+		Agent:   w.Target,
+		Session: sessionName,
+		Rule:    "warrant",
+		Inputs: map[string]interface{}{
+			"session_alive": sessionAlive,
+		},
+		Decision: "warrant-filed",
+		Action:   "none",
+		Reason:   w.Reason,
+	}
+	if w.ID != "" {
+		r.Inputs["warrant"] = w.ID
+		r.Inputs["filed_by"] = w.FiledBy
+		r.Inputs["filed_at"] = w.FiledAt.UTC().Format(time.RFC3339)
+	}
+	if sessionAlive {
+		r.Action = "killed"
+	}
+	return r
+}
+
 // targetToSessionName converts a target path to a tmux session name
 func targetToSessionName(target string) (string, error) {
 	parts := strings.Split(target, "/")
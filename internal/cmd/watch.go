@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/notify"
+	"github.com/steveyegge/gastown/internal/style"
+	"golang.org/x/term"
+)
+
+// watchLoop redraws a full-screen view every interval until interrupted.
+// A frame is only written when the rendered body changes, so an idle town
+// doesn't flicker and piped output only records actual changes.
+type watchLoop struct {
+	title    string // shown in the header, e.g. "gt status --watch"
+	interval time.Duration
+	render   func(w io.Writer) error
+
+	// Optional: deliver overseer notifications for events logged while
+	// watching. Both must be set.
+	notifier *notify.Notifier
+	follower *notify.Follower
+}
+
+// watchFrame is the rendered state compared between ticks.
+type watchFrame struct {
+	body     []byte
+	lastNote string
+}
+
+func (l *watchLoop) run() error {
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigChan)
+
+	ticker := time.NewTicker(l.interval)
+	defer ticker.Stop()
+
+	isTTY := term.IsTerminal(int(os.Stdout.Fd()))
+	var prev watchFrame
+	first := true
+
+	for {
+		cur := watchFrame{lastNote: l.pollNotifications(prev.lastNote)}
+
+		var body bytes.Buffer
+		if err := l.render(&body); err != nil {
+			fmt.Fprintf(&body, "Error: %v\n", err)
+		}
+		cur.body = body.Bytes()
+
+		if first || !bytes.Equal(cur.body, prev.body) || cur.lastNote != prev.lastNote {
+			_, _ = os.Stdout.Write(l.frame(cur, isTTY))
+			first = false
+		}
+		prev = cur
+
+		select {
+		case <-sigChan:
+			if isTTY {
+				fmt.Println("\nStopped.")
+			}
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// frame assembles header and body into one buffer so the terminal never
+// renders a blank screen between the clear and the content.
+func (l *watchLoop) frame(f watchFrame, isTTY bool) []byte {
+	var buf bytes.Buffer
+	header := fmt.Sprintf("[%s] %s (every %s, Ctrl+C to stop)",
+		time.Now().Format("15:04:05"), l.title, l.interval)
+	if f.lastNote != "" {
+		header += "\n" + f.lastNote
+	}
+	if isTTY {
+		buf.WriteString("\033[H\033[2J") // ANSI: cursor home + clear screen
+		header = style.Dim.Render(header)
+	}
+	fmt.Fprintf(&buf, "%s\n\n", header)
+	buf.Write(f.body)
+	return buf.Bytes()
+}
+
+// pollNotifications hands new events to the notifier and returns a
+// one-line summary of the most recent delivery (or last unchanged).
+func (l *watchLoop) pollNotifications(last string) string {
+	if l.notifier == nil || l.follower == nil {
+		return last
+	}
+	evs, err := l.follower.Poll()
+	if err != nil {
+		return fmt.Sprintf("notify: %v", err)
+	}
+	for _, e := range evs {
+		note, delivered, err := l.notifier.Handle(e)
+		switch {
+		case err != nil:
+			last = fmt.Sprintf("notify: %v", err)
+		case delivered:
+			last = fmt.Sprintf("🔔 %s %s: %s", note.Time.Local().Format("15:04"), note.Title, note.Body)
+		}
+	}
+	return last
+}
+
+// attachNotifier wires overseer notifications into a watch loop, following
+// the town event feed from now on.
+func (l *watchLoop) attachNotifier(townRoot string) {
+	l.notifier = newOverseerNotifier(townRoot)
+	l.follower = notify.NewFollower(filepath.Join(townRoot, events.EventsFile))
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/notify"
+)
+
+func TestWatchLoopFrame(t *testing.T) {
+	l := &watchLoop{title: "gt status --watch", interval: 2 * time.Second}
+	f := watchFrame{body: []byte("body\n"), lastNote: "🔔 12:00 Convoy landed: hq-cv-1"}
+
+	plain := string(l.frame(f, false))
+	if strings.Contains(plain, "\033[H") {
+		t.Error("non-TTY frame should not clear the screen")
+	}
+	for _, want := range []string{"gt status --watch (every 2s, Ctrl+C to stop)", "Convoy landed: hq-cv-1", "\n\nbody\n"} {
+		if !strings.Contains(plain, want) {
+			t.Errorf("frame missing %q:\n%s", want, plain)
+		}
+	}
+	if tty := string(l.frame(f, true)); !strings.HasPrefix(tty, "\033[H\033[2J") {
+		t.Errorf("TTY frame should start by clearing the screen: %q", tty)
+	}
+}
+
+type watchTestSink struct{ n int }
+
+func (s *watchTestSink) Name() string                   { return "test" }
+func (s *watchTestSink) Send(notify.Notification) error { s.n++; return nil }
+
+func TestWatchLoopPollNotifications(t *testing.T) {
+	path := filepath.Join(t.TempDir(), events.EventsFile)
+	if err := os.WriteFile(path, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	sink := &watchTestSink{}
+	l := &watchLoop{
+		notifier: &notify.Notifier{Kinds: map[string]bool{notify.KindConvoyLanded: true}, Sinks: []notify.Sink{sink}},
+		follower: notify.NewFollower(path),
+	}
+	if got := l.pollNotifications("previous"); got != "previous" {
+		t.Errorf("no events should keep last note, got %q", got)
+	}
+
+	var buf bytes.Buffer
+	for _, e := range []events.Event{
+		{Timestamp: time.Now().UTC().Format(time.RFC3339), Type: events.TypeSling},
+		{Timestamp: time.Now().UTC().Format(time.RFC3339), Type: events.TypeConvoyLanded, Payload: events.ConvoyPayload("hq-cv-9", "Docs")},
+	} {
+		b, _ := json.Marshal(e)
+		buf.Write(append(b, '\n'))
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	got := l.pollNotifications("previous")
+	if !strings.Contains(got, "Convoy landed: hq-cv-9 Docs") || sink.n != 1 {
+		t.Errorf("note = %q, sink calls = %d", got, sink.n)
+	}
+
+	if got := (&watchLoop{}).pollNotifications("x"); got != "x" {
+		t.Errorf("loop without notifier changed note: %q", got)
+	}
+}
+
+func TestRunConvoyStatusWatch_RejectsBadFlags(t *testing.T) {
+	oldJSON, oldEvery := convoyStatusJSON, convoyStatusEvery
+	defer func() { convoyStatusJSON, convoyStatusEvery = oldJSON, oldEvery }()
+
+	convoyStatusJSON, convoyStatusEvery = true, 5
+	if err := runConvoyStatusWatch(nil); err == nil || !strings.Contains(err.Error(), "cannot be used together") {
+		t.Errorf("--json --watch error = %v", err)
+	}
+	convoyStatusJSON, convoyStatusEvery = false, 0
+	if err := runConvoyStatusWatch(nil); err == nil || !strings.Contains(err.Error(), "positive") {
+		t.Errorf("zero interval error = %v", err)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"os/exec"
 
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/events"
 	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/tmux"
@@ -348,10 +349,21 @@ func runWitnessRestart(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	mgr, err := getWitnessManager(rigName)
+	townRoot, r, err := getRig(rigName)
 	if err != nil {
 		return err
 	}
+	mgr := witness.NewManager(r)
+
+	if patrolShadowHold(townRoot, events.Receipt{
+		Agent:    rigName + "/witness",
+		Session:  mgr.SessionName(),
+		Rule:     "witness_restart",
+		Decision: "requested",
+		Action:   "restarted",
+	}) {
+		return nil
+	}
 
 	fmt.Printf("Restarting witness for %s...\n", rigName)
 
@@ -219,6 +219,47 @@ func validateRigSettings(c *RigSettings) error {
 			return err
 		}
 	}
+	if c.Sandbox != nil {
+		if err := validateSandboxConfig(c.Sandbox); err != nil {
+			return fmt.Errorf("sandbox: %w", err)
+		}
+	}
+	if err := c.Resources.Validate(); err != nil {
+		return fmt.Errorf("resources: %w", err)
+	}
+	for role, limits := range c.RoleResources {
+		if err := limits.Validate(); err != nil {
+			return fmt.Errorf("role_resources.%s: %w", role, err)
+		}
+	}
+	return nil
+}
+
+// ErrInvalidSandbox indicates a malformed sandbox section in rig settings.
+var ErrInvalidSandbox = errors.New("invalid sandbox config")
+
+// validateSandboxConfig validates a SandboxConfig.
+func validateSandboxConfig(c *SandboxConfig) error {
+	switch c.Home {
+	case "", SandboxHomeReadOnly, SandboxHomeHidden:
+	default:
+		return fmt.Errorf("%w: home %q, want %q or %q", ErrInvalidSandbox, c.Home, SandboxHomeReadOnly, SandboxHomeHidden)
+	}
+	switch c.Network {
+	case "", SandboxNetworkHost, SandboxNetworkNone:
+	case SandboxNetworkAllowlist:
+		if len(c.AllowHosts) == 0 {
+			return fmt.Errorf("%w: network %q needs allow_hosts", ErrInvalidSandbox, c.Network)
+		}
+	default:
+		return fmt.Errorf("%w: network %q, want %q, %q, or %q", ErrInvalidSandbox, c.Network,
+			SandboxNetworkHost, SandboxNetworkNone, SandboxNetworkAllowlist)
+	}
+	for _, p := range c.ForwardPorts {
+		if p < 1 || p > 65535 {
+			return fmt.Errorf("%w: forward port %d", ErrInvalidSandbox, p)
+		}
+	}
 	return nil
 }
 
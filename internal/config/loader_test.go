@@ -2,6 +2,7 @@ package config
 
 import (
 	"encoding/json"
+	"errors"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -4870,3 +4871,33 @@ func TestBuildPolecatStartupCommand_SettingsFallbackChain(t *testing.T) {
 		t.Errorf("BuildPolecatStartupCommand() = %q, should contain polecat-specific settings %q", cmd, specificSettings)
 	}
 }
+
+func TestLoadRigSettingsSandboxValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		sandbox string
+		wantErr bool
+	}{
+		{"defaults", `{"enabled":true}`, false},
+		{"hidden allowlist", `{"enabled":true,"home":"hidden","network":"allowlist","allow_hosts":["api.anthropic.com"]}`, false},
+		{"bad home", `{"enabled":true,"home":"gone"}`, true},
+		{"allowlist without hosts", `{"enabled":true,"network":"allowlist"}`, true},
+		{"bad port", `{"enabled":true,"network":"none","forward_ports":[70000]}`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.json")
+			data := `{"type":"rig-settings","version":1,"sandbox":` + tt.sandbox + `}`
+			if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+				t.Fatal(err)
+			}
+			_, err := LoadRigSettings(path)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LoadRigSettings err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidSandbox) {
+				t.Errorf("err = %v, want ErrInvalidSandbox", err)
+			}
+		})
+	}
+}
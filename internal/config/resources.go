@@ -0,0 +1,109 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidResourceLimit indicates a malformed resource limit in settings.
+var ErrInvalidResourceLimit = errors.New("invalid resource limit")
+
+// Validate checks that limits are within the ranges cgroup v2 accepts. Nil-safe.
+func (c *ResourceLimitsConfig) Validate() error {
+	if c == nil {
+		return nil
+	}
+	if c.CPUWeight != 0 && (c.CPUWeight < 1 || c.CPUWeight > 10000) {
+		return fmt.Errorf("%w: cpu_weight %d not in 1-10000", ErrInvalidResourceLimit, c.CPUWeight)
+	}
+	if c.PidsMax < 0 {
+		return fmt.Errorf("%w: pids_max %d is negative", ErrInvalidResourceLimit, c.PidsMax)
+	}
+	if _, err := ParseMemorySize(c.MemoryMax); err != nil {
+		return err
+	}
+	return nil
+}
+
+// MemoryMaxBytes returns MemoryMax in bytes, or 0 when unset or "max".
+func (c *ResourceLimitsConfig) MemoryMaxBytes() int64 {
+	if c == nil {
+		return 0
+	}
+	n, _ := ParseMemorySize(c.MemoryMax)
+	return n
+}
+
+// ParseMemorySize parses a memory size such as "512M", "4G", "1.5GiB", or a
+// plain byte count. Suffixes are binary (K=1024). Empty and "max" return 0.
+func ParseMemorySize(s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" || s == "max" {
+		return 0, nil
+	}
+
+	upper := strings.TrimSuffix(strings.TrimSuffix(strings.ToUpper(s), "B"), "I")
+	mult := int64(1)
+	if n := len(upper); n > 0 {
+		switch upper[n-1] {
+		case 'K':
+			mult = 1 << 10
+		case 'M':
+			mult = 1 << 20
+		case 'G':
+			mult = 1 << 30
+		case 'T':
+			mult = 1 << 40
+		}
+		if mult > 1 {
+			upper = upper[:n-1]
+		}
+	}
+
+	v, err := strconv.ParseFloat(upper, 64)
+	if err != nil || v <= 0 {
+		return 0, fmt.Errorf("%w: memory_max %q (want e.g. \"512M\", \"4G\", or \"max\")", ErrInvalidResourceLimit, s)
+	}
+	return int64(v * float64(mult)), nil
+}
+
+// ResolveResourceLimits returns the cgroup limits for a role's sessions.
+// Layers, least to most specific: town role_resources[role], rig resources,
+// rig role_resources[role]. Each layer overrides only the fields it sets.
+// Returns nil when no limits apply.
+func ResolveResourceLimits(role, townRoot, rigPath string) *ResourceLimitsConfig {
+	var layers []*ResourceLimitsConfig
+
+	if townRoot != "" {
+		if townSettings, err := LoadOrCreateTownSettings(TownSettingsPath(townRoot)); err == nil {
+			layers = append(layers, townSettings.RoleResources[role])
+		}
+	}
+	if rigPath != "" {
+		if rigSettings, err := LoadRigSettings(RigSettingsPath(rigPath)); err == nil {
+			layers = append(layers, rigSettings.Resources, rigSettings.RoleResources[role])
+		}
+	}
+
+	resolved := &ResourceLimitsConfig{}
+	for _, l := range layers {
+		if l == nil {
+			continue
+		}
+		if l.CPUWeight != 0 {
+			resolved.CPUWeight = l.CPUWeight
+		}
+		if l.MemoryMax != "" {
+			resolved.MemoryMax = l.MemoryMax
+		}
+		if l.PidsMax != 0 {
+			resolved.PidsMax = l.PidsMax
+		}
+	}
+	if resolved.IsZero() {
+		return nil
+	}
+	return resolved
+}
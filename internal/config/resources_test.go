@@ -0,0 +1,78 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseMemorySize(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    int64
+		wantErr bool
+	}{
+		{"", 0, false},
+		{"max", 0, false},
+		{"1048576", 1 << 20, false},
+		{"512M", 512 << 20, false},
+		{"4G", 4 << 30, false},
+		{"1.5GiB", 3 << 29, false},
+		{"2t", 2 << 40, false},
+		{"lots", 0, true},
+		{"-1G", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseMemorySize(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseMemorySize(%q) = %d, %v; want %d, err=%v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestResolveResourceLimitsLayers(t *testing.T) {
+	townRoot := t.TempDir()
+	rigPath := filepath.Join(townRoot, "gastown")
+	writeJSON := func(path, body string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(body), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if got := ResolveResourceLimits("polecat", townRoot, rigPath); got != nil {
+		t.Fatalf("no settings: got %+v, want nil", got)
+	}
+
+	writeJSON(TownSettingsPath(townRoot), `{"type":"town-settings","version":1,
+		"role_resources":{"polecat":{"cpu_weight":50,"memory_max":"4G","pids_max":1024}}}`)
+	writeJSON(RigSettingsPath(rigPath), `{"type":"rig-settings","version":1,
+		"resources":{"memory_max":"2G"},
+		"role_resources":{"polecat":{"pids_max":256}}}`)
+
+	got := ResolveResourceLimits("polecat", townRoot, rigPath)
+	want := ResourceLimitsConfig{CPUWeight: 50, MemoryMax: "2G", PidsMax: 256}
+	if got == nil || *got != want {
+		t.Errorf("polecat limits = %+v, want %+v", got, want)
+	}
+
+	// Witness only picks up the rig-wide layer.
+	if got := ResolveResourceLimits("witness", townRoot, rigPath); got == nil || *got != (ResourceLimitsConfig{MemoryMax: "2G"}) {
+		t.Errorf("witness limits = %+v", got)
+	}
+}
+
+func TestRigSettingsRejectsBadResources(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(`{"type":"rig-settings","version":1,
+		"role_resources":{"polecat":{"cpu_weight":20000}}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadRigSettings(path); !errors.Is(err, ErrInvalidResourceLimit) {
+		t.Errorf("LoadRigSettings err = %v, want ErrInvalidResourceLimit", err)
+	}
+}
@@ -82,6 +82,19 @@ type TownSettings struct {
 	// Convoy configures convoy behavior settings.
 	Convoy *ConvoyConfig `json:"convoy,omitempty"`
 
+	// RoleResources sets per-role cgroup v2 limits for agent sessions (Linux only).
+	// Keys are role names, as in RoleAgents. Rig settings override these per field.
+	// Example: {"polecat": {"memory_max": "4G", "pids_max": 512}}
+	RoleResources map[string]*ResourceLimitsConfig `json:"role_resources,omitempty"`
+
+	// Redaction tunes secret scrubbing of mail, events, telemetry, and the dashboard.
+	// Built-in detectors always run; this adds patterns and exceptions.
+	Redaction *RedactionConfig `json:"redaction,omitempty"`
+
+	// Notifier configures local notifications for humans watching the town
+	// (gt notify watch, gt status --watch --notify).
+	Notifier *NotifierConfig `json:"notifier,omitempty"`
+
 	// CostTier tracks which cost tier preset was applied (informational).
 	// Actual model assignments live in RoleAgents and Agents.
 	// Values: "standard", "economy", "budget", or empty for custom configs.
@@ -99,6 +112,41 @@ func NewTownSettings() *TownSettings {
 	}
 }
 
+// RedactionConfig adds to the built-in secret detectors used before agent
+// output is stored or displayed (mail, .events.jsonl, OTel logs, web API).
+type RedactionConfig struct {
+	// Patterns maps a short name to a regular expression to redact. Matches
+	// are replaced with "[REDACTED:<name>]". If the expression has a capture
+	// group, only the first group is replaced (e.g., keep "password=").
+	// Example: {"internal-token": "itk_[a-z0-9]{24}"}
+	Patterns map[string]string `json:"patterns,omitempty"`
+
+	// Allow lists literal strings that are never redacted (e.g., a public
+	// test key that trips a detector).
+	Allow []string `json:"allow,omitempty"`
+
+	// DisableEntropy turns off the high-entropy string detector.
+	DisableEntropy bool `json:"disable_entropy,omitempty"`
+}
+
+// NotifierConfig selects which town events reach the overseer and how.
+// Notifications respect the DND level set with gt dnd / gt notify: when
+// muted, only critical escalations get through.
+type NotifierConfig struct {
+	// Events lists event kinds to notify on: "convoy_landed", "escalation",
+	// "merge_failed", "mass_death". Default: convoy_landed, escalation, merge_failed.
+	Events []string `json:"events,omitempty"`
+
+	// Sinks lists delivery methods: "terminal" (bell + OSC 9), "desktop"
+	// (notify-send, when installed), "command". Default: terminal, desktop.
+	Sinks []string `json:"sinks,omitempty"`
+
+	// Command is run with sh -c for each notification when the "command"
+	// sink is enabled. GT_NOTIFY_KIND, GT_NOTIFY_TITLE and GT_NOTIFY_BODY
+	// describe the notification. Setting Command enables the sink.
+	Command string `json:"command,omitempty"`
+}
+
 // WebTimeoutsConfig configures command execution timeouts for the web dashboard.
 type WebTimeoutsConfig struct {
 	// CmdTimeout is the timeout for bd (beads) commands. Default: "15s".
@@ -330,6 +378,8 @@ type RigSettings struct {
 	MergeQueue *MergeQueueConfig `json:"merge_queue,omitempty"` // merge queue settings
 	Theme      *ThemeConfig      `json:"theme,omitempty"`       // tmux theme settings
 	Namepool   *NamepoolConfig   `json:"namepool,omitempty"`    // polecat name pool settings
+	WarmPool   *WarmPoolConfig   `json:"warm_pool,omitempty"`   // pre-warmed polecat spares
+	Sandbox    *SandboxConfig    `json:"sandbox,omitempty"`     // polecat namespace sandbox (Linux)
 	Crew       *CrewConfig       `json:"crew,omitempty"`        // crew startup settings
 	Workflow   *WorkflowConfig   `json:"workflow,omitempty"`    // workflow settings
 	Runtime    *RuntimeConfig    `json:"runtime,omitempty"`     // LLM runtime settings (deprecated: use Agent)
@@ -352,6 +402,96 @@ type RigSettings struct {
 	// Overrides TownSettings.RoleAgents for this specific rig.
 	// Example: {"witness": "claude-haiku", "polecat": "claude-sonnet"}
 	RoleAgents map[string]string `json:"role_agents,omitempty"`
+
+	// Resources sets cgroup v2 limits for every agent session in this rig (Linux only).
+	// Overrides TownSettings.RoleResources field by field.
+	Resources *ResourceLimitsConfig `json:"resources,omitempty"`
+
+	// RoleResources sets per-role cgroup v2 limits for this rig.
+	// Overrides Resources field by field.
+	// Example: {"polecat": {"cpu_weight": 50, "memory_max": "2G"}}
+	RoleResources map[string]*ResourceLimitsConfig `json:"role_resources,omitempty"`
+}
+
+// Sandbox home modes for SandboxConfig.Home.
+const (
+	SandboxHomeReadOnly = "read-only" // $HOME visible but read-only (default)
+	SandboxHomeHidden   = "hidden"    // $HOME replaced by an empty tmpfs
+)
+
+// Sandbox network modes for SandboxConfig.Network.
+const (
+	SandboxNetworkHost      = "host"      // no network namespace (explicit opt-in)
+	SandboxNetworkNone      = "none"      // loopback only (plus forwarded ports)
+	SandboxNetworkAllowlist = "allowlist" // egress only via a proxy that enforces AllowHosts (default)
+)
+
+// SandboxConfig runs polecat sessions inside user, mount, and network
+// namespaces (Linux only). The worktree, its git directory, and its beads
+// directory stay writable; the rest of $HOME is read-only or hidden.
+// Sessions fail to start rather than run unsandboxed when enabled.
+type SandboxConfig struct {
+	// Enabled turns the sandbox on for this rig's polecats.
+	Enabled bool `json:"enabled"`
+
+	// Home is "read-only" (default) or "hidden". Hidden mode keeps the town
+	// itself visible read-only so gt and bd can find their workspace.
+	Home string `json:"home,omitempty"`
+
+	// Network is "allowlist" (default), "none", or "host". Host networking
+	// must be chosen explicitly.
+	Network string `json:"network,omitempty"`
+
+	// AllowHosts lists hosts reachable in allowlist mode, as "host",
+	// "host:port", or "*.domain". The agent's API host must be included.
+	// Default (network unset): ["api.anthropic.com"]; add the git remote's
+	// host if polecats push over HTTPS.
+	// Example: ["api.anthropic.com", "github.com", "*.githubusercontent.com"]
+	AllowHosts []string `json:"allow_hosts,omitempty"`
+
+	// ForwardPorts lists host loopback TCP ports reachable from inside the
+	// network namespace. Default: the Dolt server port.
+	ForwardPorts []int `json:"forward_ports,omitempty"`
+
+	// Writable lists extra paths that stay writable ("~/" expands to $HOME).
+	// Default: ["~/.claude/projects", "~/.claude/.credentials.json"] so
+	// transcripts and refreshed logins reach the host.
+	Writable []string `json:"writable,omitempty"`
+
+	// Private lists paths under $HOME that each session gets its own
+	// writable copy of, refreshed at session start. Changes made inside the
+	// sandbox never reach the originals.
+	// Default: ["~/.claude", "~/.claude.json"], whose hooks and MCP servers
+	// unsandboxed sessions would otherwise run.
+	Private []string `json:"private,omitempty"`
+
+	// ReadOnly lists extra paths kept read-only, and visible in hidden mode.
+	ReadOnly []string `json:"read_only,omitempty"`
+}
+
+// IsEnabled reports whether the sandbox is on. Nil-safe.
+func (c *SandboxConfig) IsEnabled() bool {
+	return c != nil && c.Enabled
+}
+
+// ResourceLimitsConfig sets cgroup v2 resource limits for an agent session.
+// Each session's process tree runs in its own cgroup; zero fields are unlimited.
+// Ignored on platforms without cgroup v2 or without a delegated cgroup subtree.
+type ResourceLimitsConfig struct {
+	// CPUWeight is the relative CPU share (cpu.weight, 1-10000; kernel default 100).
+	CPUWeight int `json:"cpu_weight,omitempty"`
+
+	// MemoryMax is the hard memory limit (memory.max), e.g. "512M", "4G", or "max".
+	// The kernel OOM-kills the session's processes when it is exceeded.
+	MemoryMax string `json:"memory_max,omitempty"`
+
+	// PidsMax caps the number of processes and threads (pids.max).
+	PidsMax int `json:"pids_max,omitempty"`
+}
+
+// IsZero reports whether no limits are set. Nil-safe.
+func (c *ResourceLimitsConfig) IsZero() bool {
+	return c == nil || (c.CPUWeight == 0 && c.MemoryMax == "" && c.PidsMax == 0)
 }
 
 // CrewConfig represents crew workspace settings for a rig.
@@ -975,6 +1115,34 @@ type NamepoolConfig struct {
 	MaxBeforeNumbering int `json:"max_before_numbering,omitempty"`
 }
 
+// WarmPoolConfig configures pre-warmed polecat spares for a rig.
+// Spares are worktrees already checked out at the rig's default branch,
+// with setup run and agent beads created in the "nuked" state that bead
+// audits skip, so gt sling can claim one instead of building a polecat from
+// scratch. The daemon keeps the pool filled and refreshes spares when the
+// default branch moves.
+type WarmPoolConfig struct {
+	// Size is the number of spares to keep ready. Zero disables the pool.
+	Size int `json:"size"`
+
+	// RunSetup controls whether merge_queue.setup_command is run in each
+	// spare so dependencies are installed ahead of time.
+	// Nil defaults to true.
+	RunSetup *bool `json:"run_setup,omitempty"`
+
+	// SetupTimeout bounds each setup run (e.g., "10m"). Default: 10m.
+	SetupTimeout string `json:"setup_timeout,omitempty"`
+}
+
+// IsRunSetupEnabled returns whether spares run the rig's setup command.
+// Nil-safe, defaults to true.
+func (c *WarmPoolConfig) IsRunSetupEnabled() bool {
+	if c == nil || c.RunSetup == nil {
+		return true
+	}
+	return *c.RunSetup
+}
+
 // DefaultNamepoolConfig returns a NamepoolConfig with sensible defaults.
 func DefaultNamepoolConfig() *NamepoolConfig {
 	return &NamepoolConfig{
@@ -19,6 +19,7 @@ import (
 	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/transcript"
 	"github.com/steveyegge/gastown/internal/util"
 )
 
@@ -847,6 +848,7 @@ func (m *Manager) Stop(name string) error {
 	if err := t.KillSessionWithProcesses(sessionID); err != nil {
 		return fmt.Errorf("killing session: %w", err)
 	}
+	transcript.EndSession(filepath.Dir(m.rig.Path), fmt.Sprintf("%s/crew/%s", m.rig.Name, name), "")
 
 	return nil
 }
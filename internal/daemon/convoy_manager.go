@@ -73,6 +73,10 @@ type ConvoyManager struct {
 	// The first cycle advances high-water marks without processing events,
 	// preventing a burst of historical event replay on daemon restart.
 	seeded bool
+
+	// strandedCount is the number of stranded convoys found by the last
+	// successful scan, or -1 before the first one. Read by /metrics.
+	strandedCount atomic.Int64
 }
 
 // NewConvoyManager creates a new convoy manager.
@@ -91,7 +95,7 @@ func NewConvoyManager(townRoot string, logger func(format string, args ...interf
 		isRigParked = func(string) bool { return false }
 	}
 	ctx, cancel := context.WithCancel(context.Background())
-	return &ConvoyManager{
+	m := &ConvoyManager{
 		townRoot:     townRoot,
 		scanInterval: scanInterval,
 		ctx:          ctx,
@@ -102,6 +106,14 @@ func NewConvoyManager(townRoot string, logger func(format string, args ...interf
 		isRigParked:  isRigParked,
 		gtPath:       gtPath,
 	}
+	m.strandedCount.Store(-1)
+	return m
+}
+
+// StrandedCount returns the number of stranded convoys seen by the last
+// scan, or -1 if no scan has succeeded yet.
+func (m *ConvoyManager) StrandedCount() int64 {
+	return m.strandedCount.Load()
 }
 
 // Start begins the convoy manager goroutines (event poll + stranded scan).
@@ -267,6 +279,7 @@ func (m *ConvoyManager) scan() {
 		m.logger("Convoy: stranded scan failed: %s", util.FirstLine(err.Error()))
 		return
 	}
+	m.strandedCount.Store(int64(len(stranded)))
 
 	for _, c := range stranded {
 		select {
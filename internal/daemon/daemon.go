@@ -12,6 +12,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
@@ -30,6 +31,7 @@ import (
 	"github.com/steveyegge/gastown/internal/polecat"
 	"github.com/steveyegge/gastown/internal/refinery"
 	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/sandbox"
 	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/telemetry"
 	"github.com/steveyegge/gastown/internal/tmux"
@@ -82,12 +84,18 @@ type Daemon struct {
 	otelProvider *telemetry.Provider
 	metrics      *daemonMetrics
 
+	// metricsServer serves Prometheus /metrics when enabled in daemon.json.
+	metricsServer *metricsServer
+
 	// escalator sends structured escalation mail to Mayor for LLM judgment cases.
 	escalator *Escalator
 
 	// healthTracker counts consecutive health check failures per agent.
 	// Only accessed from heartbeat loop goroutine - no sync needed.
 	healthTracker *HealthFailureTracker
+
+	// doctorRunning is set while a doctor patrol run is in flight.
+	doctorRunning atomic.Bool
 }
 
 // sessionDeath records a detected session death for mass death analysis.
@@ -170,13 +178,16 @@ func New(config *Config) (*Daemon, error) {
 	if otelErr != nil {
 		logger.Printf("Warning: telemetry init failed: %v", otelErr)
 	}
+	telemetry.SetTownRoot(config.TownRoot)
+	// Daemon metrics also feed the Prometheus endpoint, so they are kept
+	// even when there is no OTLP collector to push to.
 	var dm *daemonMetrics
-	if otelProvider != nil {
+	if otelProvider != nil || MetricsListenAddr(patrolConfig) != "" {
 		dm, err = newDaemonMetrics()
 		if err != nil {
 			logger.Printf("Warning: failed to register daemon metrics: %v", err)
 			dm = nil
-		} else {
+		} else if otelProvider != nil {
 			metricsURL := os.Getenv(telemetry.EnvMetricsURL)
 			if metricsURL == "" {
 				metricsURL = telemetry.DefaultMetricsURL
@@ -299,6 +310,17 @@ func (d *Daemon) Run() error {
 		d.logger.Println("Convoy manager started")
 	}
 
+	// Serve Prometheus /metrics if enabled (opt-in via daemon.json).
+	if addr := MetricsListenAddr(d.patrolConfig); addr != "" {
+		d.metricsServer = newMetricsServer(d.metrics, d.collectTownSnapshot, d.logger.Printf)
+		if err := d.metricsServer.Start(addr); err != nil {
+			d.logger.Printf("Warning: failed to start metrics endpoint: %v", err)
+			d.metricsServer = nil
+		} else {
+			d.logger.Printf("Metrics endpoint serving on http://%s/metrics", addr)
+		}
+	}
+
 	// Start KRC pruner for automatic ephemeral data cleanup
 	krcPruner, err := NewKRCPruner(d.config.TownRoot, d.logger.Printf)
 	if err != nil {
@@ -338,6 +360,26 @@ func (d *Daemon) Run() error {
 		d.logger.Printf("Dolt remotes push ticker started (interval %v)", interval)
 	}
 
+	// Start scheduled mail ticker. Deliveries are minute-granular, so they
+	// can't wait for the 3-minute heartbeat.
+	var scheduledMailChan <-chan time.Time
+	if IsPatrolEnabled(d.patrolConfig, "scheduled_mail") {
+		scheduledMailTicker := time.NewTicker(scheduledMailInterval)
+		scheduledMailChan = scheduledMailTicker.C
+		defer scheduledMailTicker.Stop()
+	}
+
+	// Start doctor patrol ticker if enabled. Checks run on their own
+	// cadence (default 30 min) so drift is recorded between manual runs.
+	var doctorChan <-chan time.Time
+	if IsPatrolEnabled(d.patrolConfig, "doctor") {
+		interval := doctorPatrolInterval(d.patrolConfig)
+		doctorTicker := time.NewTicker(interval)
+		doctorChan = doctorTicker.C
+		defer doctorTicker.Stop()
+		d.logger.Printf("Doctor patrol ticker started (interval %v)", interval)
+	}
+
 	// Note: PATCH-010 uses per-session hooks in deacon/manager.go (SetAutoRespawnHook).
 	// Global pane-died hooks don't fire reliably in tmux 3.2a, so we rely on the
 	// per-session approach which has been tested to work for continuous recovery.
@@ -375,6 +417,16 @@ func (d *Daemon) Run() error {
 				d.pushDoltRemotes()
 			}
 
+		case <-scheduledMailChan:
+			if !d.isShutdownInProgress() {
+				d.dispatchScheduledMail()
+			}
+
+		case <-doctorChan:
+			if !d.isShutdownInProgress() {
+				d.startDoctorPatrol()
+			}
+
 		case <-timer.C:
 			d.heartbeat(state)
 
@@ -501,6 +553,12 @@ func (d *Daemon) heartbeat(state *State) {
 	// branches persist indefinitely. This cleans them up periodically.
 	d.pruneStaleBranches()
 
+	// 16b. Expire old WIP snapshots (refs/gastown/wip/*) written by checkpoints.
+	d.pruneWIPSnapshots()
+
+	// 16c. Keep warm polecat spares filled and current with the default branch.
+	d.fillWarmPools()
+
 	// 17. Run mechanical patrol steps (deacon patrol steps extracted to Go).
 	// These replace equivalent LLM patrol steps with direct Go implementations:
 	// orphan cleanup (replaces former step 13), gate evaluation, dog pool,
@@ -761,7 +819,12 @@ func (d *Daemon) checkDeaconHeartbeat() {
 			// Grace period expired without any heartbeat - Deacon failed to start
 			d.logger.Printf("Deacon started %s ago but hasn't written heartbeat - restarting",
 				timeSinceStart.Round(time.Minute))
-			d.restartStuckDeacon(sessionName)
+			d.restartStuckDeacon(sessionName, events.Receipt{
+				Inputs:     map[string]interface{}{"started_ago": timeSinceStart.Round(time.Second).String()},
+				Thresholds: map[string]interface{}{"grace_period": deaconGracePeriod.String()},
+				Decision:   "no-heartbeat",
+				Reason:     "no heartbeat written within the startup grace period",
+			})
 			return
 		}
 
@@ -776,7 +839,15 @@ func (d *Daemon) checkDeaconHeartbeat() {
 			// Grace period expired but heartbeat still from before start
 			d.logger.Printf("Deacon started %s ago but heartbeat still pre-restart - Deacon stuck at startup",
 				timeSinceStart.Round(time.Minute))
-			d.restartStuckDeacon(sessionName)
+			d.restartStuckDeacon(sessionName, events.Receipt{
+				Inputs: map[string]interface{}{
+					"started_ago":    timeSinceStart.Round(time.Second).String(),
+					"last_heartbeat": hb.Timestamp.UTC().Format(time.RFC3339),
+				},
+				Thresholds: map[string]interface{}{"grace_period": deaconGracePeriod.String()},
+				Decision:   "stuck-at-startup",
+				Reason:     "heartbeat still predates the restart after the grace period",
+			})
 			return
 		}
 
@@ -815,26 +886,57 @@ func (d *Daemon) checkDeaconHeartbeat() {
 	// Session exists but heartbeat is stale - Deacon is stuck
 	// PATCH-002: Reduced from 30m to 10m for faster recovery.
 	// Must be > backoff-max (5m) to avoid false positive kills during legitimate sleep.
+	receipt := events.Receipt{
+		Inputs:     map[string]interface{}{"heartbeat_age": age.Round(time.Second).String()},
+		Thresholds: map[string]interface{}{"kill_after": (10 * time.Minute).String()},
+		Decision:   "heartbeat-stale",
+	}
 	if age > 10*time.Minute {
-		d.restartStuckDeacon(sessionName)
+		receipt.Reason = "heartbeat stale past the kill threshold with session running"
+		d.restartStuckDeacon(sessionName, receipt)
 	} else {
 		// Stuck but not critically - nudge to wake up
-		d.logger.Printf("Deacon stuck for %s - nudging session", age.Round(time.Minute))
-		if err := d.tmux.NudgeSession(sessionName, "HEALTH_CHECK: heartbeat stale, respond to confirm responsiveness"); err != nil {
-			d.logger.Printf("Error nudging stuck Deacon: %v", err)
+		receipt.Agent, receipt.Session, receipt.Rule = "deacon", sessionName, "deacon_heartbeat"
+		receipt.Action = "nudged"
+		receipt.Reason = "heartbeat stale but under the kill threshold"
+		receipt.Shadow = deacon.InShadow(d.config.TownRoot)
+		if receipt.Shadow {
+			d.logger.Printf("Deacon stuck for %s - would nudge session (patrol shadow mode)", age.Round(time.Minute))
+		} else {
+			d.logger.Printf("Deacon stuck for %s - nudging session", age.Round(time.Minute))
+			if err := d.tmux.NudgeSession(sessionName, "HEALTH_CHECK: heartbeat stale, respond to confirm responsiveness"); err != nil {
+				d.logger.Printf("Error nudging stuck Deacon: %v", err)
+				receipt.Error = err.Error()
+			}
 		}
+		_ = events.LogReceipt(d.config.TownRoot, "daemon", receipt)
 	}
 }
 
 // restartStuckDeacon kills and restarts a stuck Deacon session.
-// Extracted for reuse by PATCH-005 grace period logic.
-func (d *Daemon) restartStuckDeacon(sessionName string) {
+// Extracted for reuse by PATCH-005 grace period logic. why carries the
+// decision that led here; it's completed and logged as a patrol receipt.
+func (d *Daemon) restartStuckDeacon(sessionName string, why events.Receipt) {
+	why.Agent, why.Session, why.Rule = "deacon", sessionName, "deacon_heartbeat"
+	why.Action = "restarted"
+	defer func() { _ = events.LogReceipt(d.config.TownRoot, "daemon", why) }()
+
 	// Check if session exists before trying to kill
 	hasSession, _ := d.tmux.HasSession(sessionName)
+	if deacon.InShadow(d.config.TownRoot) {
+		why.Shadow = true
+		if hasSession {
+			why.Action = "killed and restarted"
+		}
+		d.logger.Printf("Patrol shadow mode: not restarting stuck Deacon session %s (would: %s)", sessionName, why.Action)
+		return
+	}
 	if hasSession {
 		d.logger.Printf("Killing stuck Deacon session %s", sessionName)
+		why.Action = "killed and restarted"
 		if err := d.tmux.KillSessionWithProcesses(sessionName); err != nil {
 			d.logger.Printf("Error killing stuck Deacon: %v", err)
+			why.Error = err.Error()
 		}
 	}
 	// Spawn new Deacon immediately
@@ -873,9 +975,7 @@ func (d *Daemon) ensureWitnessRunning(rigName string) {
 	// A hung session has a live process but no tmux activity for an extended period,
 	// indicating Claude is stuck. Kill it so Start() can recreate a fresh one.
 	if status := mgr.IsHealthy(hungSessionThreshold); status == tmux.AgentHung {
-		d.logger.Printf("Witness for %s is hung (no activity for %v), killing for restart", rigName, hungSessionThreshold)
-		t := tmux.NewTmux()
-		_ = t.KillSession(mgr.SessionName())
+		d.killHungSession(rigName+"/witness", mgr.SessionName())
 	}
 
 	if err := mgr.Start(false, "", nil); err != nil {
@@ -893,6 +993,32 @@ func (d *Daemon) ensureWitnessRunning(rigName string) {
 	d.logger.Printf("Witness session for %s started successfully", rigName)
 }
 
+// killHungSession kills a hung rig agent session so that Start can recreate
+// it. In patrol shadow mode the session is left running and the kill is
+// only recorded.
+func (d *Daemon) killHungSession(agent, sessionName string) {
+	receipt := events.Receipt{
+		Agent:      agent,
+		Session:    sessionName,
+		Rule:       "hung_session",
+		Thresholds: map[string]interface{}{"hung_after": hungSessionThreshold.String()},
+		Decision:   "hung",
+		Action:     "killed",
+		Reason:     "agent alive but no session activity within the hung threshold",
+		Shadow:     deacon.InShadow(d.config.TownRoot),
+	}
+	if receipt.Shadow {
+		d.logger.Printf("%s is hung (no activity for %v), would kill for restart (patrol shadow mode)", agent, hungSessionThreshold)
+	} else {
+		d.logger.Printf("%s is hung (no activity for %v), killing for restart", agent, hungSessionThreshold)
+		if err := tmux.NewTmux().KillSession(sessionName); err != nil {
+			receipt.Action = "kill-failed"
+			receipt.Error = err.Error()
+		}
+	}
+	_ = events.LogReceipt(d.config.TownRoot, "daemon", receipt)
+}
+
 // ensureRefineriesRunning ensures refineries are running for configured rigs.
 // Called on each heartbeat to maintain refinery merge queue processing.
 // Respects the rigs filter in daemon.json patrol config.
@@ -925,9 +1051,7 @@ func (d *Daemon) ensureRefineryRunning(rigName string) {
 	// A hung refinery means MRs pile up with no processing. Kill it so Start()
 	// can recreate a fresh one. See: gt-tr3d
 	if status := mgr.IsHealthy(hungSessionThreshold); status == tmux.AgentHung {
-		d.logger.Printf("Refinery for %s is hung (no activity for %v), killing for restart", rigName, hungSessionThreshold)
-		t := tmux.NewTmux()
-		_ = t.KillSession(mgr.SessionName())
+		d.killHungSession(rigName+"/refinery", mgr.SessionName())
 	}
 
 	if err := mgr.Start(false, ""); err != nil {
@@ -1222,6 +1346,12 @@ func (d *Daemon) shutdown(state *State) error { //nolint:unparam // error return
 	}
 	d.beadsStores = nil
 
+	// Stop metrics endpoint
+	if d.metricsServer != nil {
+		d.metricsServer.Stop()
+		d.logger.Println("Metrics endpoint stopped")
+	}
+
 	// Stop KRC pruner
 	if d.krcPruner != nil {
 		d.krcPruner.Stop()
@@ -1618,13 +1748,36 @@ func (d *Daemon) checkPolecatHealth(rigName, polecatName string) {
 	d.logger.Printf("CRASH DETECTED: polecat %s/%s has hook_bead=%s but session %s is dead",
 		rigName, polecatName, info.HookBead, sessionName)
 
+	// Report OOM kills before the restart recreates the session's cgroup
+	if reason, oom := session.ReportOOMDeath(sessionName, fmt.Sprintf("%s/%s", rigName, polecatName), "daemon"); oom {
+		d.logger.Printf("Polecat %s/%s died: %s", rigName, polecatName, reason)
+	}
+
 	// Track this death for mass death detection
 	d.recordSessionDeath(sessionName)
 
 	// Auto-restart the polecat
 	polecatAgentID := fmt.Sprintf("%s/%s", rigName, polecatName)
+	receipt := events.Receipt{
+		Agent:    polecatAgentID,
+		Session:  sessionName,
+		Bead:     info.HookBead,
+		Rule:     "polecat_crash",
+		Inputs:   map[string]interface{}{"agent_state": info.State},
+		Decision: "crashed",
+		Action:   "restarted",
+		Reason:   "work on hook but session dead",
+		Shadow:   deacon.InShadow(d.config.TownRoot),
+	}
+	defer func() { _ = events.LogReceipt(d.config.TownRoot, "daemon", receipt) }()
+	if receipt.Shadow {
+		d.logger.Printf("Patrol shadow mode: not restarting crashed polecat %s/%s", rigName, polecatName)
+		return
+	}
 	if err := d.restartPolecatSession(rigName, polecatName, sessionName); err != nil {
 		d.logger.Printf("Error restarting polecat %s/%s: %v", rigName, polecatName, err)
+		receipt.Action = "witness-notified"
+		receipt.Error = err.Error()
 		// Notify witness as fallback
 		d.notifyWitnessOfCrashedPolecat(rigName, polecatName, info.HookBead, err)
 	} else {
@@ -1745,6 +1898,9 @@ func (d *Daemon) restartPolecatSession(rigName, polecatName, sessionName string)
 	if err := d.tmux.EnsureSessionFresh(sessionName, workDir); err != nil {
 		return fmt.Errorf("creating session: %w", err)
 	}
+	if err := session.ApplyResourceLimits(d.tmux, sessionName, "polecat", d.config.TownRoot, rigPath); err != nil {
+		d.logger.Printf("Warning: resource limits not applied for %s: %v", sessionName, err)
+	}
 
 	// Set environment variables using centralized AgentEnv
 	envVars := config.AgentEnv(config.AgentEnvConfig{
@@ -1783,6 +1939,11 @@ func (d *Daemon) restartPolecatSession(rigName, polecatName, sessionName string)
 	// Launch Claude with environment exported inline
 	// Pass rigPath so rig agent settings are honored (not town-level defaults)
 	startCmd := config.BuildStartupCommand(envVars, rigPath, "")
+	startCmd, err := sandbox.WrapCommand(startCmd, rigPath, workDir)
+	if err != nil {
+		_ = d.tmux.KillSessionWithProcesses(sessionName)
+		return fmt.Errorf("sandboxing session: %w", err)
+	}
 	if err := d.tmux.SendKeys(sessionName, startCmd); err != nil {
 		return fmt.Errorf("sending startup command: %w", err)
 	}
@@ -0,0 +1,125 @@
+package daemon
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// defaultDoctorInterval is how often the doctor patrol runs by default.
+const defaultDoctorInterval = 30 * time.Minute
+
+// defaultDoctorChecks are run when the doctor patrol has no checks
+// configured: drift that builds up quietly between manual gt doctor runs
+// until something downstream breaks.
+var defaultDoctorChecks = []string{
+	"stale-beads-redirect",
+	"beads-redirect-target",
+	"stale-agent-beads",
+	"hook-singleton",
+	"orphaned-attachments",
+	"orphan-sessions",
+	"zombie-sessions",
+	"worktree-gitdir-valid",
+	"dolt-server-reachable",
+	"dolt-orphaned-databases",
+	"patrol-not-stuck",
+}
+
+// doctorTimeoutIntervals bounds a doctor patrol run to this many intervals;
+// a hung check (e.g. an unreachable Dolt server) must not run forever.
+const doctorTimeoutIntervals = 2
+
+// doctorPatrolInterval returns the configured doctor interval, or the
+// default (30m) if unset or invalid.
+func doctorPatrolInterval(config *DaemonPatrolConfig) time.Duration {
+	if config != nil && config.Patrols != nil && config.Patrols.Doctor != nil {
+		if d, err := time.ParseDuration(config.Patrols.Doctor.Interval); err == nil && d > 0 {
+			return d
+		}
+	}
+	return defaultDoctorInterval
+}
+
+// doctorPatrolChecks returns the checks the doctor patrol should run.
+func doctorPatrolChecks(config *DaemonPatrolConfig) []string {
+	if config != nil && config.Patrols != nil && config.Patrols.Doctor != nil && len(config.Patrols.Doctor.Checks) > 0 {
+		return config.Patrols.Doctor.Checks
+	}
+	return defaultDoctorChecks
+}
+
+// startDoctorPatrol runs the doctor patrol in the background so the
+// daemon's loop keeps serving heartbeats while checks run. A tick that
+// arrives while the previous run is still going is skipped.
+func (d *Daemon) startDoctorPatrol() {
+	if !d.doctorRunning.CompareAndSwap(false, true) {
+		d.logger.Printf("Doctor patrol: previous run still in progress, skipping")
+		return
+	}
+	timeout := doctorTimeoutIntervals * doctorPatrolInterval(d.patrolConfig)
+	go func() {
+		defer d.doctorRunning.Store(false)
+		d.runDoctorPatrol(timeout)
+	}()
+}
+
+// doctorPatrolReport is the part of gt doctor's JSON report the patrol reads.
+type doctorPatrolReport struct {
+	Summary struct {
+		Errors int `json:"errors"`
+	} `json:"summary"`
+}
+
+// runDoctorPatrol runs the configured checks through gt doctor, which
+// records them in the doctor history and escalates regressions. The daemon
+// only schedules; gt doctor owns the checks, history and escalation.
+// gt doctor is killed if it runs longer than timeout or the daemon stops.
+func (d *Daemon) runDoctorPatrol(timeout time.Duration) {
+	checks := doctorPatrolChecks(d.patrolConfig)
+	ctx, cancel := context.WithTimeout(d.ctx, timeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, d.gtPath, "doctor", "--record", "--escalate", "--format", "json", "--checks", strings.Join(checks, ",")) //nolint:gosec // G204: args are constructed internally
+	cmd.Dir = d.config.TownRoot
+	cmd.Env = os.Environ()
+	// Don't wait on output pipes held open by check subprocesses once
+	// gt doctor itself is gone.
+	cmd.WaitDelay = 10 * time.Second
+
+	// The report goes to stdout; progress and escalations go to stderr.
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	for _, line := range strings.Split(stderr.String(), "\n") {
+		if strings.Contains(line, "Escalated") {
+			d.logger.Printf("Doctor patrol: %s", strings.TrimSpace(line))
+		}
+	}
+
+	// gt doctor exits non-zero when a check reports an error. That's a
+	// finding (already recorded), not a patrol failure: a run that got as
+	// far as writing its report succeeded, whatever the exit status.
+	var report doctorPatrolReport
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		d.logger.Printf("Doctor patrol: gt doctor timed out after %v", timeout)
+	case json.Unmarshal(stdout.Bytes(), &report) != nil:
+		d.logger.Printf("Doctor patrol: gt doctor failed: %v (%s)", err, lastLine(stderr.String()))
+	case report.Summary.Errors > 0:
+		d.logger.Printf("Doctor patrol: %d check(s) recorded, %d error(s) found (see gt doctor history)", len(checks), report.Summary.Errors)
+	default:
+		d.logger.Printf("Doctor patrol: %d check(s) recorded", len(checks))
+	}
+}
+
+// lastLine returns the last non-empty line of s.
+func lastLine(s string) string {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
@@ -87,4 +87,12 @@ func (p *KRCPruner) prune() {
 			result.BytesBefore-result.BytesAfter,
 			result.Duration.Round(time.Millisecond))
 	}
+	if result.TranscriptsPruned > 0 {
+		p.logger("KRC pruned %d archived transcripts (saved %d bytes)",
+			result.TranscriptsPruned, result.TranscriptBytesFreed)
+	}
+	if result.EventsEvicted > 0 || result.TranscriptsEvicted > 0 {
+		p.logger("KRC evicted %d events and %d transcripts to stay within the %d byte disk budget",
+			result.EventsEvicted, result.TranscriptsEvicted, result.DiskBudget)
+	}
 }
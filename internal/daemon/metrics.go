@@ -13,6 +13,8 @@ const meterName = "github.com/steveyegge/gastown/daemon"
 
 // daemonMetrics holds OTel instruments for the daemon.
 // All methods are nil-safe so callers don't need to guard against disabled telemetry.
+// Counter values are also kept locally so the Prometheus endpoint can serve
+// them without an OTLP collector.
 type daemonMetrics struct {
 	// heartbeatTotal counts daemon heartbeat cycles.
 	heartbeatTotal metric.Int64Counter
@@ -27,6 +29,11 @@ type daemonMetrics struct {
 	doltLatencyMs      float64
 	doltDiskBytes      int64
 	doltHealthy        int64 // 1 = healthy, 0 = unhealthy
+
+	// countMu protects the local copies of the counters.
+	countMu    sync.Mutex
+	heartbeats int64
+	restarts   map[string]int64 // agent type → restarts
 }
 
 // newDaemonMetrics registers all daemon OTel instruments against the global
@@ -114,6 +121,9 @@ func (dm *daemonMetrics) recordHeartbeat(ctx context.Context) {
 		return
 	}
 	dm.heartbeatTotal.Add(ctx, 1)
+	dm.countMu.Lock()
+	dm.heartbeats++
+	dm.countMu.Unlock()
 }
 
 // recordRestart increments the restart counter, labeled with the agent type
@@ -125,6 +135,12 @@ func (dm *daemonMetrics) recordRestart(ctx context.Context, agentType string) {
 	dm.restartTotal.Add(ctx, 1,
 		metric.WithAttributes(attribute.String("agent.type", agentType)),
 	)
+	dm.countMu.Lock()
+	if dm.restarts == nil {
+		dm.restarts = make(map[string]int64)
+	}
+	dm.restarts[agentType]++
+	dm.countMu.Unlock()
 }
 
 // updateDoltHealth stores the latest Dolt health snapshot for observable gauges.
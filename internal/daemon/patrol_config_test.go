@@ -1,9 +1,14 @@
 package daemon
 
 import (
+	"context"
+	"log"
 	"os"
 	"path/filepath"
+	"runtime"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestLoadPatrolConfig(t *testing.T) {
@@ -79,6 +84,20 @@ func TestIsPatrolEnabled_DoltRemotes(t *testing.T) {
 	}
 }
 
+func TestIsPatrolEnabled_ScheduledMail(t *testing.T) {
+	// scheduled_mail defaults to enabled
+	if !IsPatrolEnabled(nil, "scheduled_mail") {
+		t.Error("expected scheduled_mail to be enabled with nil config")
+	}
+
+	config := &DaemonPatrolConfig{
+		Patrols: &PatrolsConfig{ScheduledMail: &PatrolConfig{Enabled: false}},
+	}
+	if IsPatrolEnabled(config, "scheduled_mail") {
+		t.Error("expected scheduled_mail to be disabled when explicitly disabled")
+	}
+}
+
 func TestDoltRemotesInterval(t *testing.T) {
 	// Default interval
 	if got := doltRemotesInterval(nil); got != defaultDoltRemotesInterval {
@@ -98,3 +117,84 @@ func TestDoltRemotesInterval(t *testing.T) {
 		t.Errorf("expected 5m interval, got %v", got)
 	}
 }
+
+func TestIsPatrolEnabled_Doctor(t *testing.T) {
+	// doctor is opt-in like dolt_remotes
+	if IsPatrolEnabled(nil, "doctor") {
+		t.Error("expected doctor to be disabled with nil config")
+	}
+	config := &DaemonPatrolConfig{Patrols: &PatrolsConfig{}}
+	if IsPatrolEnabled(config, "doctor") {
+		t.Error("expected doctor to be disabled by default")
+	}
+	config.Patrols.Doctor = &DoctorPatrolConfig{Enabled: true}
+	if !IsPatrolEnabled(config, "doctor") {
+		t.Error("expected doctor to be enabled when configured")
+	}
+}
+
+func TestDoctorPatrolConfig(t *testing.T) {
+	if got := doctorPatrolInterval(nil); got != defaultDoctorInterval {
+		t.Errorf("default interval = %v, want %v", got, defaultDoctorInterval)
+	}
+	if got := doctorPatrolChecks(nil); len(got) != len(defaultDoctorChecks) {
+		t.Errorf("default checks = %v", got)
+	}
+
+	config := &DaemonPatrolConfig{Patrols: &PatrolsConfig{Doctor: &DoctorPatrolConfig{
+		Enabled:  true,
+		Interval: "10m",
+		Checks:   []string{"orphan-sessions"},
+	}}}
+	if got := doctorPatrolInterval(config); got != 10*time.Minute {
+		t.Errorf("interval = %v, want 10m", got)
+	}
+	if got := doctorPatrolChecks(config); len(got) != 1 || got[0] != "orphan-sessions" {
+		t.Errorf("checks = %v", got)
+	}
+
+	config.Patrols.Doctor.Interval = "soon"
+	if got := doctorPatrolInterval(config); got != defaultDoctorInterval {
+		t.Errorf("invalid interval = %v, want default", got)
+	}
+}
+
+func TestDoctorPatrol_TimesOutOneRunAtATime(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("test uses a Unix shell script mock for gt")
+	}
+	gtPath := filepath.Join(t.TempDir(), "gt")
+	if err := os.WriteFile(gtPath, []byte("#!/bin/sh\nexec sleep 30\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	var logBuf strings.Builder
+	d := &Daemon{
+		config: &Config{TownRoot: t.TempDir()},
+		patrolConfig: &DaemonPatrolConfig{Patrols: &PatrolsConfig{Doctor: &DoctorPatrolConfig{
+			Enabled:  true,
+			Interval: "100ms",
+		}}},
+		logger: log.New(&logBuf, "", 0),
+		gtPath: gtPath,
+		ctx:    context.Background(),
+	}
+
+	d.startDoctorPatrol()
+	d.startDoctorPatrol() // first run still in flight
+	deadline := time.Now().Add(10 * time.Second)
+	for d.doctorRunning.Load() {
+		if time.Now().After(deadline) {
+			t.Fatal("doctor patrol still running well past its timeout")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	got := logBuf.String()
+	if !strings.Contains(got, "previous run still in progress") {
+		t.Errorf("expected the overlapping run to be skipped, got: %q", got)
+	}
+	if !strings.Contains(got, "timed out after 200ms") {
+		t.Errorf("expected gt doctor to time out after two intervals, got: %q", got)
+	}
+}
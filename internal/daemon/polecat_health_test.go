@@ -10,6 +10,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/steveyegge/gastown/internal/deacon"
+	"github.com/steveyegge/gastown/internal/events"
 	"github.com/steveyegge/gastown/internal/tmux"
 )
 
@@ -184,3 +186,44 @@ func TestCheckPolecatHealth_DBStateOverridesDescription(t *testing.T) {
 		t.Errorf("expected CRASH DETECTED when DB state is 'working' with dead session, got: %q", got)
 	}
 }
+
+// TestCheckPolecatHealth_ShadowRecordsWithoutRestart verifies that in patrol
+// shadow mode a crashed polecat is not restarted, and the restart it would
+// have done is recorded as a shadow receipt.
+func TestCheckPolecatHealth_ShadowRecordsWithoutRestart(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("test uses Unix shell script mocks for tmux and bd")
+	}
+	binDir := t.TempDir()
+	writeFakeTestTmux(t, binDir)
+	recentTime := time.Now().UTC().Format(time.RFC3339)
+	bdPath := writeFakeTestBD(t, binDir, "working", "working", "gt-xyz", recentTime)
+	t.Setenv("PATH", binDir+":"+os.Getenv("PATH"))
+
+	townRoot := t.TempDir()
+	if err := deacon.EnableShadow(townRoot, "test", "test"); err != nil {
+		t.Fatal(err)
+	}
+
+	var logBuf strings.Builder
+	d := &Daemon{
+		config: &Config{TownRoot: townRoot},
+		logger: log.New(&logBuf, "", 0),
+		tmux:   tmux.NewTmux(),
+		bdPath: bdPath,
+	}
+
+	d.checkPolecatHealth("myr", "mycat")
+
+	got := logBuf.String()
+	if !strings.Contains(got, "not restarting crashed polecat myr/mycat") || strings.Contains(got, "Error restarting") {
+		t.Errorf("expected shadow mode to skip the restart, got: %q", got)
+	}
+	data, err := os.ReadFile(filepath.Join(townRoot, events.EventsFile))
+	if err != nil {
+		t.Fatalf("reading events: %v", err)
+	}
+	if !strings.Contains(string(data), `"rule":"polecat_crash"`) || !strings.Contains(string(data), `"shadow":true`) {
+		t.Errorf("expected a shadow polecat_crash receipt, got: %s", data)
+	}
+}
@@ -0,0 +1,343 @@
+package daemon
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/nudge"
+	"github.com/steveyegge/gastown/internal/session"
+)
+
+// metricsSnapshotTTL bounds how often a scrape triggers a fresh town
+// collection. Collection shells out to bd once per rig, so scrapers polling
+// every few seconds share one snapshot instead of hammering Dolt.
+const metricsSnapshotTTL = 15 * time.Second
+
+// townSnapshot is the town state exposed on /metrics that the daemon does
+// not already track itself. Maps are keyed by the label value.
+type townSnapshot struct {
+	MergeQueue      map[string]int // rig → open merge requests
+	Polecats        map[string]int // rig → live polecat sessions
+	Stuck           map[string]int // rig ("hq" for town) → stuck agents
+	ConvoysOpen     int
+	ConvoysStranded int64          // -1 when no stranded scan has run
+	NudgeQueue      map[string]int // session → queued nudges
+	MailUnread      map[string]int // recipient → unread messages
+	Errors          map[string]int // source → failed queries in this collection
+}
+
+func newTownSnapshot() *townSnapshot {
+	return &townSnapshot{
+		MergeQueue:      map[string]int{},
+		Polecats:        map[string]int{},
+		Stuck:           map[string]int{},
+		ConvoysStranded: -1,
+		NudgeQueue:      map[string]int{},
+		MailUnread:      map[string]int{},
+		Errors:          map[string]int{},
+	}
+}
+
+// collectTownSnapshot gathers the current town state. Failures are counted
+// per source rather than returned, so one unreachable rig doesn't blank
+// the whole scrape.
+func (d *Daemon) collectTownSnapshot() *townSnapshot {
+	snap := newTownSnapshot()
+	townRoot := d.config.TownRoot
+	rigs := d.getKnownRigs()
+	now := time.Now()
+	for _, rigName := range rigs {
+		snap.MergeQueue[rigName] = 0
+		snap.Polecats[rigName] = 0
+		snap.Stuck[rigName] = 0
+	}
+
+	sessions, err := d.tmux.ListSessions()
+	if err != nil {
+		snap.Errors["sessions"]++
+	}
+	activity := make(map[string]time.Time)
+	for _, name := range sessions {
+		id, err := session.ParseSessionName(name)
+		if err != nil {
+			continue // not a Gas Town session
+		}
+		if id.Role == session.RolePolecat {
+			snap.Polecats[id.Rig]++
+		}
+		if at, err := d.tmux.GetSessionActivity(name); err == nil {
+			activity[agentKey(id.Rig, string(id.Role), id.Name)] = at
+		}
+		if n, err := nudge.Pending(townRoot, name); err == nil {
+			snap.NudgeQueue[name] = n
+		}
+	}
+
+	for _, rigName := range rigs {
+		bd := beads.New(filepath.Join(townRoot, rigName))
+		mrs, err := bd.List(beads.ListOptions{Status: "open", Label: "gt:merge-request", Priority: -1})
+		if err != nil {
+			snap.Errors["merge_queue"]++
+		} else {
+			snap.MergeQueue[rigName] = len(mrs)
+		}
+		agents, err := bd.List(beads.ListOptions{Status: "open", Label: "gt:agent", Priority: -1})
+		if err != nil {
+			snap.Errors["agents"]++
+		} else {
+			snap.Stuck[rigName] = countStuckAgents(agents, activity, now)
+		}
+	}
+
+	town := beads.New(townRoot)
+	if agents, err := town.List(beads.ListOptions{Status: "open", Label: "gt:agent", Priority: -1}); err != nil {
+		snap.Errors["agents"]++
+	} else {
+		snap.Stuck["hq"] = countStuckAgents(agents, activity, now)
+	}
+	if convoys, err := town.List(beads.ListOptions{Status: "open", Type: "convoy", Priority: -1}); err != nil {
+		snap.Errors["convoys"]++
+	} else {
+		snap.ConvoysOpen = len(convoys)
+	}
+	if d.convoyManager != nil {
+		snap.ConvoysStranded = d.convoyManager.StrandedCount()
+	}
+	if msgs, err := town.List(beads.ListOptions{Status: "open", Label: "gt:message", Priority: -1}); err != nil {
+		snap.Errors["mail"]++
+	} else {
+		countUnreadMail(snap.MailUnread, msgs)
+	}
+	return snap
+}
+
+// agentKey identifies an agent across its bead and its tmux session.
+func agentKey(rig, role, name string) string {
+	return rig + "/" + role + "/" + name
+}
+
+// countStuckAgents counts agents that declared themselves stuck, plus those
+// with hooked work whose live session has shown no activity for
+// GUPPViolationTimeout. activity maps agentKey to the session's last
+// activity. The bead's own update time isn't used: a working agent can go
+// far longer than that without touching its hooked bead.
+func countStuckAgents(agents []*beads.Issue, activity map[string]time.Time, now time.Time) int {
+	n := 0
+	for _, a := range agents {
+		if a.AgentState == "stuck" {
+			n++
+			continue
+		}
+		if a.HookBead == "" {
+			continue
+		}
+		rig, role, name, ok := beads.ParseAgentBeadID(a.ID)
+		if !ok {
+			continue
+		}
+		if at, live := activity[agentKey(rig, role, name)]; live && now.Sub(at) > GUPPViolationTimeout {
+			n++
+		}
+	}
+	return n
+}
+
+// countUnreadMail tallies open messages per recipient. Messages marked read
+// by label (but not yet closed) are not backlog.
+func countUnreadMail(into map[string]int, msgs []*beads.Issue) {
+	for _, m := range msgs {
+		if m.Assignee == "" {
+			continue // queue/channel messages have no single recipient
+		}
+		read := false
+		for _, l := range m.Labels {
+			if l == "read" {
+				read = true
+				break
+			}
+		}
+		if !read {
+			into[m.Assignee]++
+		}
+	}
+}
+
+// metricsServer serves daemon and town metrics in the Prometheus text
+// exposition format.
+type metricsServer struct {
+	metrics *daemonMetrics
+	collect func() *townSnapshot
+	logger  func(format string, args ...interface{})
+
+	mu     sync.Mutex
+	snap   *townSnapshot
+	snapAt time.Time
+
+	srv *http.Server
+}
+
+func newMetricsServer(dm *daemonMetrics, collect func() *townSnapshot, logger func(format string, args ...interface{})) *metricsServer {
+	return &metricsServer{metrics: dm, collect: collect, logger: logger}
+}
+
+// Start listens on addr and serves /metrics in the background.
+func (s *metricsServer) Start(addr string) error {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("listening on %s: %w", addr, err)
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", s.handleMetrics)
+	s.srv = &http.Server{Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+	go func() {
+		if err := s.srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.logger("Metrics: server stopped: %v", err)
+		}
+	}()
+	return nil
+}
+
+// Stop shuts the server down, waiting briefly for in-flight scrapes.
+func (s *metricsServer) Stop() {
+	if s.srv == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	_ = s.srv.Shutdown(ctx)
+}
+
+func (s *metricsServer) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	var buf bytes.Buffer
+	s.write(&buf, s.snapshot())
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_, _ = w.Write(buf.Bytes())
+}
+
+// snapshot returns the cached town snapshot, refreshing it when stale.
+// Concurrent scrapes wait for a single refresh.
+func (s *metricsServer) snapshot() *townSnapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.snap == nil || time.Since(s.snapAt) > metricsSnapshotTTL {
+		s.snap = s.collect()
+		s.snapAt = time.Now()
+	}
+	return s.snap
+}
+
+// write renders all metric families.
+func (s *metricsServer) write(w io.Writer, snap *townSnapshot) {
+	if dm := s.metrics; dm != nil {
+		dm.countMu.Lock()
+		heartbeats := dm.heartbeats
+		restarts := make(map[string]int, len(dm.restarts))
+		for k, v := range dm.restarts {
+			restarts[k] = int(v)
+		}
+		dm.countMu.Unlock()
+
+		writePromFamily(w, "gastown_daemon_heartbeat_total", "counter",
+			"Total number of daemon heartbeat cycles", promSample{value: float64(heartbeats)})
+		writePromFamily(w, "gastown_daemon_restart_total", "counter",
+			"Total number of agent session restarts", labeledSamples("agent_type", restarts)...)
+
+		dm.doltMu.RLock()
+		dolt := []struct {
+			name, help string
+			value      float64
+		}{
+			{"gastown_dolt_connections", "Active Dolt server connections", float64(dm.doltConnections)},
+			{"gastown_dolt_max_connections", "Configured maximum Dolt server connections", float64(dm.doltMaxConnections)},
+			{"gastown_dolt_query_latency_ms", "Dolt SELECT 1 round-trip latency in milliseconds", dm.doltLatencyMs},
+			{"gastown_dolt_disk_usage_bytes", "Dolt data directory disk usage", float64(dm.doltDiskBytes)},
+			{"gastown_dolt_healthy", "Dolt server health (1=healthy, 0=unhealthy)", float64(dm.doltHealthy)},
+		}
+		dm.doltMu.RUnlock()
+		for _, g := range dolt {
+			writePromFamily(w, g.name, "gauge", g.help, promSample{value: g.value})
+		}
+	}
+
+	writePromFamily(w, "gastown_merge_queue_depth", "gauge",
+		"Open merge requests per rig", labeledSamples("rig", snap.MergeQueue)...)
+	writePromFamily(w, "gastown_polecats_active", "gauge",
+		"Live polecat sessions per rig", labeledSamples("rig", snap.Polecats)...)
+	writePromFamily(w, "gastown_agents_stuck", "gauge",
+		"Agents marked stuck, or with hooked work and no session activity past the GUPP timeout", labeledSamples("rig", snap.Stuck)...)
+	convoys := []promSample{{labels: []string{"state", "open"}, value: float64(snap.ConvoysOpen)}}
+	if snap.ConvoysStranded >= 0 {
+		convoys = append(convoys, promSample{labels: []string{"state", "stranded"}, value: float64(snap.ConvoysStranded)})
+	}
+	writePromFamily(w, "gastown_convoys", "gauge", "Convoys by state", convoys...)
+	writePromFamily(w, "gastown_nudge_queue_depth", "gauge",
+		"Queued nudges per session", labeledSamples("session", snap.NudgeQueue)...)
+	writePromFamily(w, "gastown_mail_unread", "gauge",
+		"Unread mail per recipient", labeledSamples("recipient", snap.MailUnread)...)
+	writePromFamily(w, "gastown_metrics_collect_errors", "gauge",
+		"Failed queries in the last town metrics collection, by source", labeledSamples("source", snap.Errors)...)
+}
+
+// promSample is one sample line; labels are name/value pairs.
+type promSample struct {
+	labels []string
+	value  float64
+}
+
+// labeledSamples turns a map into samples with one label, sorted by label
+// value so output is stable between scrapes.
+func labeledSamples(label string, m map[string]int) []promSample {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	samples := make([]promSample, 0, len(keys))
+	for _, k := range keys {
+		samples = append(samples, promSample{labels: []string{label, k}, value: float64(m[k])})
+	}
+	return samples
+}
+
+// writePromFamily writes one metric family in the text exposition format.
+// Families with no samples still get their HELP/TYPE lines so dashboards
+// can tell "zero series" from "metric missing".
+func writePromFamily(w io.Writer, name, typ, help string, samples ...promSample) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, typ)
+	for _, s := range samples {
+		var sb strings.Builder
+		sb.WriteString(name)
+		if len(s.labels) > 0 {
+			sb.WriteByte('{')
+			for i := 0; i+1 < len(s.labels); i += 2 {
+				if i > 0 {
+					sb.WriteByte(',')
+				}
+				sb.WriteString(s.labels[i])
+				sb.WriteString(`="`)
+				sb.WriteString(promEscape(s.labels[i+1]))
+				sb.WriteByte('"')
+			}
+			sb.WriteByte('}')
+		}
+		fmt.Fprintf(w, "%s %s\n", sb.String(), strconv.FormatFloat(s.value, 'g', -1, 64))
+	}
+}
+
+var promEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func promEscape(s string) string {
+	return promEscaper.Replace(s)
+}
@@ -0,0 +1,136 @@
+package daemon
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+)
+
+func TestWritePromFamily(t *testing.T) {
+	var buf bytes.Buffer
+	writePromFamily(&buf, "gastown_mail_unread", "gauge", "Unread mail",
+		promSample{labels: []string{"recipient", `odd"name\`}, value: 3},
+		promSample{value: 0.5})
+
+	want := "# HELP gastown_mail_unread Unread mail\n" +
+		"# TYPE gastown_mail_unread gauge\n" +
+		`gastown_mail_unread{recipient="odd\"name\\"} 3` + "\n" +
+		"gastown_mail_unread 0.5\n"
+	if buf.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestMetricsServer_Render(t *testing.T) {
+	dm, err := newDaemonMetrics()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	dm.recordHeartbeat(ctx)
+	dm.recordHeartbeat(ctx)
+	dm.recordRestart(ctx, "witness")
+	dm.updateDoltHealth(4, 100, 1.5, 2048, true)
+
+	calls := 0
+	srv := newMetricsServer(dm, func() *townSnapshot {
+		calls++
+		snap := newTownSnapshot()
+		snap.MergeQueue["gastown"] = 2
+		snap.MergeQueue["beads"] = 0
+		snap.Polecats["gastown"] = 3
+		snap.ConvoysOpen = 5
+		snap.ConvoysStranded = 1
+		snap.MailUnread["gastown/witness"] = 7
+		return snap
+	}, t.Logf)
+
+	for i := 0; i < 2; i++ {
+		rec := httptest.NewRecorder()
+		srv.handleMetrics(rec, httptest.NewRequest("GET", "/metrics", nil))
+		if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+			t.Errorf("Content-Type = %q", ct)
+		}
+		body, _ := io.ReadAll(rec.Body)
+		for _, line := range []string{
+			"gastown_daemon_heartbeat_total 2",
+			`gastown_daemon_restart_total{agent_type="witness"} 1`,
+			"gastown_dolt_connections 4",
+			"gastown_dolt_query_latency_ms 1.5",
+			"gastown_dolt_healthy 1",
+			`gastown_merge_queue_depth{rig="beads"} 0`,
+			`gastown_merge_queue_depth{rig="gastown"} 2`,
+			`gastown_polecats_active{rig="gastown"} 3`,
+			`gastown_convoys{state="open"} 5`,
+			`gastown_convoys{state="stranded"} 1`,
+			`gastown_mail_unread{recipient="gastown/witness"} 7`,
+			"# TYPE gastown_nudge_queue_depth gauge",
+		} {
+			if !strings.Contains(string(body), line+"\n") {
+				t.Errorf("missing %q in:\n%s", line, body)
+			}
+		}
+	}
+	if calls != 1 {
+		t.Errorf("collect called %d times, want 1 (cached within TTL)", calls)
+	}
+}
+
+func TestCountStuckAgents(t *testing.T) {
+	now := time.Now()
+	stale := now.Add(-GUPPViolationTimeout - time.Minute)
+	fresh := now.Add(-time.Minute)
+	activity := map[string]time.Time{
+		agentKey("gastown", "polecat", "toast"): stale,
+		agentKey("gastown", "polecat", "nux"):   fresh,
+		agentKey("gastown", "crew", "max"):      stale,
+	}
+	agents := []*beads.Issue{
+		{ID: "gt-gastown-polecat-slit", AgentState: "stuck"},
+		{ID: "gt-gastown-polecat-toast", HookBead: "gt-1"},
+		// Bead untouched for hours, but the session is busy.
+		{ID: "gt-gastown-polecat-nux", HookBead: "gt-2", UpdatedAt: stale.Add(-time.Hour).Format(time.RFC3339)},
+		{ID: "gt-gastown-crew-max"},                          // idle session, nothing hooked
+		{ID: "gt-gastown-polecat-furiosa", HookBead: "gt-3"}, // no live session
+	}
+	if got := countStuckAgents(agents, activity, now); got != 2 {
+		t.Errorf("countStuckAgents = %d, want 2", got)
+	}
+}
+
+func TestCountUnreadMail(t *testing.T) {
+	got := map[string]int{}
+	countUnreadMail(got, []*beads.Issue{
+		{Assignee: "mayor/"},
+		{Assignee: "mayor/", Labels: []string{"from:deacon", "read"}},
+		{Assignee: "gastown/witness"},
+		{Labels: []string{"queue:work"}},
+	})
+	if got["mayor/"] != 1 || got["gastown/witness"] != 1 || len(got) != 2 {
+		t.Errorf("unread = %v", got)
+	}
+}
+
+func TestMetricsListenAddr(t *testing.T) {
+	if got := MetricsListenAddr(nil); got != "" {
+		t.Errorf("nil config = %q, want disabled", got)
+	}
+	cfg := &DaemonPatrolConfig{Metrics: &MetricsConfig{Enabled: true}}
+	if got := MetricsListenAddr(cfg); got != DefaultMetricsListen {
+		t.Errorf("default = %q", got)
+	}
+	cfg.Metrics.Listen = ":9100"
+	if got := MetricsListenAddr(cfg); got != ":9100" {
+		t.Errorf("override = %q", got)
+	}
+	cfg.Metrics.Enabled = false
+	if got := MetricsListenAddr(cfg); got != "" {
+		t.Errorf("disabled = %q", got)
+	}
+}
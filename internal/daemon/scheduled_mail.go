@@ -0,0 +1,42 @@
+package daemon
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/mail"
+)
+
+// scheduledMailInterval is how often the daemon checks for due scheduled mail.
+const scheduledMailInterval = time.Minute
+
+// dispatchScheduledMail sends scheduled mail that has come due. The schedule
+// file is checked in-process so idle ticks don't spawn gt; delivery itself
+// goes through `gt mail scheduled dispatch`, which shares the address
+// resolution and fan-out of gt mail send.
+func (d *Daemon) dispatchScheduledMail() {
+	due, err := mail.NewScheduleStore(d.config.TownRoot).Due(time.Now())
+	if err != nil {
+		d.logger.Printf("Scheduled mail: %v", err)
+		return
+	}
+	if len(due) == 0 {
+		return
+	}
+
+	cmd := exec.Command(d.gtPath, "mail", "scheduled", "dispatch") //nolint:gosec // G204: args are constructed internally
+	cmd.Dir = d.config.TownRoot
+	cmd.Env = os.Environ()
+
+	output, err := cmd.CombinedOutput()
+	out := strings.TrimSpace(string(output))
+	if err != nil {
+		d.logger.Printf("Scheduled mail: dispatch failed: %v (%s)", err, out)
+		return
+	}
+	if out != "" {
+		d.logger.Printf("Scheduled mail: %s", out)
+	}
+}
@@ -0,0 +1,90 @@
+package daemon
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/deacon"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/tmux"
+)
+
+// writeRecordingTmux creates a fake tmux in dir that appends each
+// invocation to the returned log file. Every session exists and every
+// command succeeds, so any kill or nudge would go through.
+func writeRecordingTmux(t *testing.T, dir string) string {
+	t.Helper()
+	logPath := filepath.Join(dir, "tmux.log")
+	script := "#!/bin/sh\necho \"$*\" >> " + logPath + "\nexit 0\n"
+	if err := os.WriteFile(filepath.Join(dir, "tmux"), []byte(script), 0755); err != nil {
+		t.Fatalf("writing fake tmux: %v", err)
+	}
+	return logPath
+}
+
+// newShadowDaemon returns a daemon for a town in patrol shadow mode whose
+// tmux calls are recorded in the returned log file.
+func newShadowDaemon(t *testing.T) (*Daemon, string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("test uses Unix shell script mocks for tmux")
+	}
+	binDir := t.TempDir()
+	tmuxLog := writeRecordingTmux(t, binDir)
+	t.Setenv("PATH", binDir+":"+os.Getenv("PATH"))
+
+	townRoot := t.TempDir()
+	if err := deacon.EnableShadow(townRoot, "test", "test"); err != nil {
+		t.Fatal(err)
+	}
+	return &Daemon{
+		config: &Config{TownRoot: townRoot},
+		logger: log.New(&strings.Builder{}, "", 0),
+		tmux:   tmux.NewTmux(),
+	}, tmuxLog
+}
+
+// assertShadowOnly checks that nothing but read-only tmux commands ran and
+// that a shadow receipt for rule was recorded.
+func assertShadowOnly(t *testing.T, d *Daemon, tmuxLog, rule string) {
+	t.Helper()
+	calls, _ := os.ReadFile(tmuxLog)
+	for _, line := range strings.Split(strings.TrimSpace(string(calls)), "\n") {
+		for _, acting := range []string{"kill-session", "kill-server", "send-keys", "new-session", "respawn-pane"} {
+			if strings.Contains(line, acting) {
+				t.Errorf("shadow mode ran tmux %q", line)
+			}
+		}
+	}
+	data, err := os.ReadFile(filepath.Join(d.config.TownRoot, events.EventsFile))
+	if err != nil {
+		t.Fatalf("reading events: %v", err)
+	}
+	if !strings.Contains(string(data), `"rule":"`+rule+`"`) || !strings.Contains(string(data), `"shadow":true`) {
+		t.Errorf("expected a shadow %s receipt, got: %s", rule, data)
+	}
+}
+
+func TestCheckDeaconHeartbeat_ShadowDoesNotKill(t *testing.T) {
+	d, tmuxLog := newShadowDaemon(t)
+	if err := deacon.WriteHeartbeat(d.config.TownRoot, &deacon.Heartbeat{Timestamp: time.Now().Add(-time.Hour)}); err != nil {
+		t.Fatal(err)
+	}
+
+	d.checkDeaconHeartbeat()
+
+	assertShadowOnly(t, d, tmuxLog, "deacon_heartbeat")
+}
+
+func TestKillHungSession_ShadowDoesNotKill(t *testing.T) {
+	d, tmuxLog := newShadowDaemon(t)
+
+	d.killHungSession("gastown/witness", "gt-gastown-witness")
+
+	assertShadowOnly(t, d, tmuxLog, "hung_session")
+}
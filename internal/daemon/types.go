@@ -118,6 +118,27 @@ type PatrolsConfig struct {
 	Deacon      *PatrolConfig      `json:"deacon,omitempty"`
 	DoltServer  *DoltServerConfig  `json:"dolt_server,omitempty"`
 	DoltRemotes *DoltRemotesConfig `json:"dolt_remotes,omitempty"`
+
+	// ScheduledMail dispatches mail queued with gt mail send --at/--in/--cron.
+	ScheduledMail *PatrolConfig `json:"scheduled_mail,omitempty"`
+
+	// Doctor runs doctor checks on a schedule and records their history.
+	Doctor *DoctorPatrolConfig `json:"doctor,omitempty"`
+}
+
+// DoctorPatrolConfig holds configuration for the doctor patrol.
+// The patrol runs gt doctor --record --escalate, so results land in the
+// doctor history and checks that regress from OK to error are escalated.
+type DoctorPatrolConfig struct {
+	// Enabled controls whether the doctor patrol runs.
+	Enabled bool `json:"enabled"`
+
+	// Interval is how often to run the checks, e.g. "30m" (default 30m).
+	Interval string `json:"interval,omitempty"`
+
+	// Checks lists the doctor checks to run. If empty, a default set of
+	// drift checks is used.
+	Checks []string `json:"checks,omitempty"`
 }
 
 // DoltRemotesConfig holds configuration for the dolt_remotes patrol.
@@ -140,12 +161,40 @@ type DoltRemotesConfig struct {
 	Branch string `json:"branch,omitempty"`
 }
 
+// MetricsConfig holds configuration for the daemon's Prometheus endpoint.
+// The endpoint is for scrape-based monitoring; OTLP push is configured
+// separately via GT_OTEL_METRICS_URL.
+type MetricsConfig struct {
+	// Enabled controls whether the daemon serves /metrics.
+	Enabled bool `json:"enabled"`
+
+	// Listen is the address to serve on (default 127.0.0.1:9464).
+	Listen string `json:"listen,omitempty"`
+}
+
+// DefaultMetricsListen is the default /metrics address. Loopback only:
+// exposing it further is an explicit choice.
+const DefaultMetricsListen = "127.0.0.1:9464"
+
 // DaemonPatrolConfig is the structure of mayor/daemon.json.
 type DaemonPatrolConfig struct {
 	Type      string         `json:"type"`
 	Version   int            `json:"version"`
 	Heartbeat *PatrolConfig  `json:"heartbeat,omitempty"`
 	Patrols   *PatrolsConfig `json:"patrols,omitempty"`
+	Metrics   *MetricsConfig `json:"metrics,omitempty"`
+}
+
+// MetricsListenAddr returns the address the /metrics endpoint should listen
+// on, or "" if it is disabled (the default).
+func MetricsListenAddr(config *DaemonPatrolConfig) string {
+	if config == nil || config.Metrics == nil || !config.Metrics.Enabled {
+		return ""
+	}
+	if config.Metrics.Listen != "" {
+		return config.Metrics.Listen
+	}
+	return DefaultMetricsListen
 }
 
 // PatrolConfigFile returns the path to the patrol config file.
@@ -171,7 +220,7 @@ func LoadPatrolConfig(townRoot string) *DaemonPatrolConfig {
 
 // IsPatrolEnabled checks if a patrol is enabled in the config.
 // Returns true if the config doesn't exist (default enabled for backwards compatibility).
-// Exception: opt-in patrols (dolt_remotes) default to disabled.
+// Exception: opt-in patrols (dolt_remotes, doctor) default to disabled.
 func IsPatrolEnabled(config *DaemonPatrolConfig, patrol string) bool {
 	// Opt-in patrols: disabled unless explicitly enabled in config.
 	// Must check before the nil-config fallback, otherwise nil config
@@ -182,6 +231,12 @@ func IsPatrolEnabled(config *DaemonPatrolConfig, patrol string) bool {
 		}
 		return config.Patrols.DoltRemotes.Enabled
 	}
+	if patrol == "doctor" {
+		if config == nil || config.Patrols == nil || config.Patrols.Doctor == nil {
+			return false
+		}
+		return config.Patrols.Doctor.Enabled
+	}
 
 	if config == nil || config.Patrols == nil {
 		return true // Default: enabled
@@ -200,6 +255,10 @@ func IsPatrolEnabled(config *DaemonPatrolConfig, patrol string) bool {
 		if config.Patrols.Deacon != nil {
 			return config.Patrols.Deacon.Enabled
 		}
+	case "scheduled_mail":
+		if config.Patrols.ScheduledMail != nil {
+			return config.Patrols.ScheduledMail.Enabled
+		}
 	}
 	return true // Default: enabled
 }
@@ -0,0 +1,47 @@
+package daemon
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+// fillWarmPools starts `gt polecat spares fill` for each rig with a warm
+// pool configured, or with spares left over from a pool since disabled.
+// Fills can take minutes (setup_command runs in each new spare), so they
+// run in the background; the command's own lock makes overlapping starts
+// exit immediately.
+func (d *Daemon) fillWarmPools() {
+	for _, rigName := range d.getKnownRigs() {
+		rigPath := filepath.Join(d.config.TownRoot, rigName)
+		settings, err := config.LoadRigSettings(filepath.Join(rigPath, "settings", "config.json"))
+		enabled := err == nil && settings.WarmPool != nil && settings.WarmPool.Size > 0
+		if !enabled {
+			entries, _ := os.ReadDir(filepath.Join(rigPath, "polecats", ".warm"))
+			if len(entries) == 0 {
+				continue
+			}
+		}
+
+		cmd := exec.Command(d.gtPath, "polecat", "spares", "fill", rigName) //nolint:gosec // G204: args are constructed internally
+		cmd.Dir = d.config.TownRoot
+		cmd.Env = os.Environ()
+		var out bytes.Buffer
+		cmd.Stdout = &out
+		cmd.Stderr = &out
+		if err := cmd.Start(); err != nil {
+			d.logger.Printf("Warm pool: failed to start fill for %s: %v", rigName, err)
+			continue
+		}
+		go func(rigName string) {
+			if err := cmd.Wait(); err != nil {
+				d.logger.Printf("Warm pool: fill for %s failed: %v (%s)", rigName, err, bytes.TrimSpace(out.Bytes()))
+			} else if out.Len() > 0 {
+				d.logger.Printf("Warm pool: %s: %s", rigName, bytes.TrimSpace(out.Bytes()))
+			}
+		}(rigName)
+	}
+}
@@ -0,0 +1,35 @@
+package daemon
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/checkpoint"
+)
+
+// pruneWIPSnapshots expires WIP snapshot refs in each rig's shared repo.
+// Polecat worktrees share refs with the repo they were added from
+// (.repo.git, or mayor/rig for older rigs), so pruning there covers all
+// of a rig's polecats.
+func (d *Daemon) pruneWIPSnapshots() {
+	for _, rigName := range d.getKnownRigs() {
+		rigPath := filepath.Join(d.config.TownRoot, rigName)
+		repo := filepath.Join(rigPath, ".repo.git")
+		if _, err := os.Stat(repo); err != nil {
+			repo = filepath.Join(rigPath, "mayor", "rig")
+			if _, err := os.Stat(repo); err != nil {
+				continue
+			}
+		}
+
+		pruned, err := checkpoint.PruneWIP(repo, checkpoint.DefaultWIPMaxAge, checkpoint.DefaultWIPKeepPerName, time.Now())
+		if err != nil {
+			d.logger.Printf("Warning: WIP snapshot prune failed for %s: %v", rigName, err)
+			continue
+		}
+		if len(pruned) > 0 {
+			d.logger.Printf("WIP prune: removed %d snapshot(s) in %s", len(pruned), rigName)
+		}
+	}
+}
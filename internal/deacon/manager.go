@@ -12,6 +12,7 @@ import (
 	"github.com/steveyegge/gastown/internal/runtime"
 	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/transcript"
 )
 
 // Common errors
@@ -195,6 +196,7 @@ func (m *Manager) Stop() error {
 	if err := t.KillSessionWithProcesses(sessionID); err != nil {
 		return fmt.Errorf("killing session: %w", err)
 	}
+	transcript.EndSession(m.townRoot, "deacon", "")
 
 	return nil
 }
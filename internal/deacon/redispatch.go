@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/events"
 )
 
 // Default parameters for re-dispatch rate-limiting.
@@ -63,6 +64,7 @@ type RedispatchResult struct {
 	Attempts   int    `json:"attempts"`
 	Message    string `json:"message,omitempty"`
 	Error      error  `json:"error,omitempty"`
+	Shadow     bool   `json:"shadow,omitempty"` // patrol shadow mode: Action was not carried out
 }
 
 // RedispatchStateFile returns the path to the re-dispatch state file.
@@ -177,15 +179,61 @@ func (s *BeadRedispatchState) RecordEscalation() {
 //   - sourceRig: the rig from which the bead was recovered (empty = auto-detect from prefix)
 //   - maxAttempts: max re-dispatches before escalating (0 = use default)
 //   - cooldown: min time between re-dispatches (0 = use default)
+//
+// Every outcome is recorded as a patrol receipt. In patrol shadow mode the
+// decision is made but neither slinging nor escalation happens, and no
+// attempt is counted.
 func Redispatch(townRoot, beadID, sourceRig string, maxAttempts int, cooldown time.Duration) *RedispatchResult {
-	result := &RedispatchResult{BeadID: beadID}
-
 	if maxAttempts <= 0 {
 		maxAttempts = DefaultMaxRedispatches
 	}
 	if cooldown <= 0 {
 		cooldown = DefaultRedispatchCooldown
 	}
+	result := redispatch(townRoot, beadID, sourceRig, maxAttempts, cooldown, InShadow(townRoot))
+	_ = events.LogReceipt(townRoot, "deacon", redispatchReceipt(result, sourceRig, maxAttempts, cooldown))
+	return result
+}
+
+// redispatchReceipt explains a re-dispatch outcome.
+func redispatchReceipt(result *RedispatchResult, sourceRig string, maxAttempts int, cooldown time.Duration) events.Receipt {
+	r := events.Receipt{
+		Bead: result.BeadID,
+		Rule: "redispatch",
+		Inputs: map[string]interface{}{
+			"attempts": result.Attempts,
+		},
+		Thresholds: map[string]interface{}{
+			"max_attempts": maxAttempts,
+			"cooldown":     cooldown.String(),
+		},
+		Decision: result.Action,
+		Action:   "none",
+		Reason:   result.Message,
+		Shadow:   result.Shadow,
+	}
+	if sourceRig != "" {
+		r.Inputs["source_rig"] = sourceRig
+	}
+	if result.TargetRig != "" {
+		r.Inputs["target_rig"] = result.TargetRig
+	}
+	switch result.Action {
+	case "redispatched":
+		r.Action = "slung to " + result.TargetRig
+	case "escalated":
+		if result.Error == nil {
+			r.Action = "escalated to mayor"
+		}
+	}
+	if result.Error != nil {
+		r.Error = result.Error.Error()
+	}
+	return r
+}
+
+func redispatch(townRoot, beadID, sourceRig string, maxAttempts int, cooldown time.Duration, shadow bool) *RedispatchResult {
+	result := &RedispatchResult{BeadID: beadID, Shadow: shadow}
 
 	// Load state
 	state, err := LoadRedispatchState(townRoot)
@@ -218,6 +266,11 @@ func Redispatch(townRoot, beadID, sourceRig string, maxAttempts int, cooldown ti
 		result.Action = "escalated"
 		result.Attempts = beadState.AttemptCount
 
+		if shadow {
+			result.Message = fmt.Sprintf("shadow mode: would escalate to Mayor after %d failed re-dispatches", beadState.AttemptCount)
+			return result
+		}
+
 		// Escalate to Mayor
 		err := escalateToMayor(townRoot, beadID, beadState)
 		if err != nil {
@@ -257,6 +310,12 @@ func Redispatch(townRoot, beadID, sourceRig string, maxAttempts int, cooldown ti
 		return result
 	}
 
+	if shadow {
+		result.Action = "redispatched"
+		result.Message = fmt.Sprintf("shadow mode: would re-dispatch to %s (attempt %d/%d)", targetRig, beadState.AttemptCount+1, maxAttempts)
+		return result
+	}
+
 	// Re-dispatch via gt sling
 	err = slingBead(townRoot, beadID, targetRig)
 	if err != nil {
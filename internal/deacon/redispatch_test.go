@@ -203,3 +203,56 @@ func TestRedispatchState_GetBeadState(t *testing.T) {
 		t.Error("expected different bead state for different ID")
 	}
 }
+
+func TestRedispatchReceipt(t *testing.T) {
+	r := redispatchReceipt(&RedispatchResult{
+		BeadID:    "gt-abc",
+		Action:    "redispatched",
+		TargetRig: "gastown",
+		Attempts:  2,
+		Message:   "re-dispatched to gastown (attempt 2/3)",
+	}, "", 3, 5*time.Minute)
+	if r.Bead != "gt-abc" || r.Action != "slung to gastown" || r.Decision != "redispatched" {
+		t.Errorf("receipt = %+v", r)
+	}
+	if r.Thresholds["max_attempts"] != 3 || r.Inputs["target_rig"] != "gastown" {
+		t.Errorf("thresholds = %v inputs = %v", r.Thresholds, r.Inputs)
+	}
+
+	r = redispatchReceipt(&RedispatchResult{BeadID: "gt-abc", Action: "cooldown"}, "gastown", 3, 5*time.Minute)
+	if r.Action != "none" || r.Inputs["source_rig"] != "gastown" {
+		t.Errorf("cooldown receipt = %+v", r)
+	}
+}
+
+func TestRedispatch_ShadowEscalation(t *testing.T) {
+	tmpDir := t.TempDir()
+	state, err := LoadRedispatchState(tmpDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bead := state.GetBeadState("gt-abc")
+	for i := 0; i < 3; i++ {
+		bead.RecordAttempt("gastown")
+	}
+	bead.LastAttemptTime = time.Now().Add(-time.Hour)
+	if err := SaveRedispatchState(tmpDir, state); err != nil {
+		t.Fatal(err)
+	}
+
+	result := redispatch(tmpDir, "gt-abc", "gastown", 3, time.Minute, true)
+	if result.Action != "escalated" || !result.Shadow || result.Error != nil {
+		t.Fatalf("result = %+v", result)
+	}
+	if r := redispatchReceipt(result, "gastown", 3, time.Minute); !r.Shadow || r.Action != "escalated to mayor" {
+		t.Errorf("receipt = %+v", r)
+	}
+
+	loaded, err := LoadRedispatchState(tmpDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.GetBeadState("gt-abc").Escalated {
+		t.Error("shadow redispatch marked the bead escalated")
+	}
+}
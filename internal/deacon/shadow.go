@@ -0,0 +1,161 @@
+// Package deacon provides the Deacon agent infrastructure.
+package deacon
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/events"
+)
+
+// maxShadowPeriods bounds the shadow period history kept for reports.
+const maxShadowPeriods = 20
+
+// ShadowState represents the town-wide patrol shadow mode file. While
+// shadow mode is on, the daemon, Deacon and Witness patrols run their
+// detection logic as usual but don't act: every kill, nudge, nuke, unhook
+// or re-dispatch they would have made is recorded as a shadow patrol
+// receipt instead. This lets new thresholds be tried on a live town.
+type ShadowState struct {
+	// Enabled is true while shadow mode is on.
+	Enabled bool `json:"enabled"`
+
+	// Reason explains why shadow mode was turned on.
+	Reason string `json:"reason,omitempty"`
+
+	// StartedAt is when the current shadow period began.
+	StartedAt time.Time `json:"started_at,omitempty"`
+
+	// StartedBy identifies who turned shadow mode on.
+	StartedBy string `json:"started_by,omitempty"`
+
+	// Periods lists completed shadow periods, oldest first.
+	Periods []ShadowPeriod `json:"periods,omitempty"`
+}
+
+// ShadowPeriod is one completed stretch of shadow mode.
+type ShadowPeriod struct {
+	Start  time.Time `json:"start"`
+	End    time.Time `json:"end"`
+	Reason string    `json:"reason,omitempty"`
+}
+
+// GetShadowFile returns the path to the patrol shadow mode file.
+func GetShadowFile(townRoot string) string {
+	return filepath.Join(townRoot, ".runtime", "patrol", "shadow.json")
+}
+
+// LoadShadowState reads the shadow mode file. A missing file is an empty,
+// disabled state.
+func LoadShadowState(townRoot string) (*ShadowState, error) {
+	data, err := os.ReadFile(GetShadowFile(townRoot)) //nolint:gosec // G304: path is constructed from trusted townRoot
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &ShadowState{}, nil
+		}
+		return nil, err
+	}
+
+	var state ShadowState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+// InShadow reports whether patrols should only record what they would do.
+// An unreadable shadow file counts as shadow mode: a town being tuned
+// should not start acting because its state file got corrupted. Each call
+// that falls back to shadow mode this way logs a receipt carrying the
+// error, so the held-back patrol shows up in `gt patrol why`.
+func InShadow(townRoot string) bool {
+	shadow, err := ShadowMode(townRoot)
+	if err != nil {
+		_ = events.LogReceipt(townRoot, "patrol", events.Receipt{
+			Rule:     "shadow_state",
+			Decision: "shadow state unreadable, patrol held in shadow mode",
+			Action:   "none",
+			Error:    err.Error(),
+			Shadow:   true,
+		})
+	}
+	return shadow
+}
+
+// ShadowMode is InShadow without the receipt. It returns true along with
+// the error when the shadow file can't be read or parsed.
+func ShadowMode(townRoot string) (bool, error) {
+	state, err := LoadShadowState(townRoot)
+	if err != nil {
+		return true, fmt.Errorf("reading %s: %w", GetShadowFile(townRoot), err)
+	}
+	return state.Enabled, nil
+}
+
+// EnableShadow turns on shadow mode. Enabling it again keeps the current
+// period running.
+func EnableShadow(townRoot, reason, startedBy string) error {
+	state, err := LoadShadowState(townRoot)
+	if err != nil {
+		return err
+	}
+	if state.Enabled {
+		return nil
+	}
+	state.Enabled = true
+	state.Reason = reason
+	state.StartedAt = time.Now().UTC()
+	state.StartedBy = startedBy
+	return saveShadowState(townRoot, state)
+}
+
+// DisableShadow turns off shadow mode, closing the current period.
+func DisableShadow(townRoot string) error {
+	state, err := LoadShadowState(townRoot)
+	if err != nil {
+		return err
+	}
+	if !state.Enabled {
+		return nil
+	}
+	state.Periods = append(state.Periods, ShadowPeriod{
+		Start:  state.StartedAt,
+		End:    time.Now().UTC(),
+		Reason: state.Reason,
+	})
+	if len(state.Periods) > maxShadowPeriods {
+		state.Periods = state.Periods[len(state.Periods)-maxShadowPeriods:]
+	}
+	state.Enabled = false
+	state.Reason = ""
+	state.StartedAt = time.Time{}
+	state.StartedBy = ""
+	return saveShadowState(townRoot, state)
+}
+
+// LatestPeriod returns the current shadow period (ending now) or, if
+// shadow mode is off, the most recent completed one.
+func (s *ShadowState) LatestPeriod() (ShadowPeriod, bool) {
+	if s.Enabled {
+		return ShadowPeriod{Start: s.StartedAt, End: time.Now().UTC(), Reason: s.Reason}, true
+	}
+	if len(s.Periods) == 0 {
+		return ShadowPeriod{}, false
+	}
+	return s.Periods[len(s.Periods)-1], true
+}
+
+func saveShadowState(townRoot string, state *ShadowState) error {
+	shadowFile := GetShadowFile(townRoot)
+	if err := os.MkdirAll(filepath.Dir(shadowFile), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(shadowFile, data, 0600)
+}
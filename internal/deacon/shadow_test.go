@@ -0,0 +1,103 @@
+package deacon
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/events"
+)
+
+func TestInShadow_NoFile(t *testing.T) {
+	if InShadow(t.TempDir()) {
+		t.Error("InShadow() should be false when the shadow file doesn't exist")
+	}
+}
+
+func TestShadow_EnableDisable(t *testing.T) {
+	townRoot := t.TempDir()
+
+	if err := EnableShadow(townRoot, "trying new thresholds", "human"); err != nil {
+		t.Fatalf("EnableShadow() error = %v", err)
+	}
+	if !InShadow(townRoot) {
+		t.Fatal("InShadow() should be true after EnableShadow")
+	}
+	state, err := LoadShadowState(townRoot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	started := state.StartedAt
+
+	// Enabling again keeps the running period.
+	if err := EnableShadow(townRoot, "other", "mayor"); err != nil {
+		t.Fatal(err)
+	}
+	state, _ = LoadShadowState(townRoot)
+	if !state.StartedAt.Equal(started) || state.Reason != "trying new thresholds" || state.StartedBy != "human" {
+		t.Errorf("second EnableShadow changed the period: %+v", state)
+	}
+
+	if err := DisableShadow(townRoot); err != nil {
+		t.Fatalf("DisableShadow() error = %v", err)
+	}
+	if InShadow(townRoot) {
+		t.Error("InShadow() should be false after DisableShadow")
+	}
+	state, _ = LoadShadowState(townRoot)
+	if len(state.Periods) != 1 {
+		t.Fatalf("Periods = %+v, want 1", state.Periods)
+	}
+	p, ok := state.LatestPeriod()
+	if !ok || !p.Start.Equal(started) || p.End.Before(p.Start) || p.Reason != "trying new thresholds" {
+		t.Errorf("LatestPeriod() = %+v, %v", p, ok)
+	}
+}
+
+func TestShadow_PeriodsBounded(t *testing.T) {
+	townRoot := t.TempDir()
+	for i := 0; i < maxShadowPeriods+3; i++ {
+		if err := EnableShadow(townRoot, "", ""); err != nil {
+			t.Fatal(err)
+		}
+		if err := DisableShadow(townRoot); err != nil {
+			t.Fatal(err)
+		}
+	}
+	state, _ := LoadShadowState(townRoot)
+	if len(state.Periods) != maxShadowPeriods {
+		t.Errorf("kept %d periods, want %d", len(state.Periods), maxShadowPeriods)
+	}
+}
+
+func TestInShadow_CorruptFile(t *testing.T) {
+	townRoot := t.TempDir()
+	shadowFile := GetShadowFile(townRoot)
+	if err := os.MkdirAll(filepath.Dir(shadowFile), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(shadowFile, []byte("{not json"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if shadow, err := ShadowMode(townRoot); !shadow || err == nil {
+		t.Errorf("ShadowMode() = %v, %v; want true and an error", shadow, err)
+	}
+	if !InShadow(townRoot) {
+		t.Error("InShadow() should be true when the shadow file is unreadable")
+	}
+
+	// The fallback is recorded, so a patrol held back by it is explained.
+	var receipts []events.ReceiptEvent
+	err := events.Read(filepath.Join(townRoot, events.EventsFile), events.Query{}, func(e events.Event) bool {
+		if r, ok := events.ReceiptFromEvent(e); ok {
+			receipts = append(receipts, r)
+		}
+		return true
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(receipts) != 1 || receipts[0].Rule != "shadow_state" || !receipts[0].Shadow || receipts[0].Error == "" {
+		t.Errorf("receipts = %+v, want one shadow_state receipt with the error", receipts)
+	}
+}
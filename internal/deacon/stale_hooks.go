@@ -10,6 +10,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/steveyegge/gastown/internal/events"
 	"github.com/steveyegge/gastown/internal/git"
 	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/tmux"
@@ -64,6 +65,8 @@ type StaleHookScanResult struct {
 	StaleCount  int                `json:"stale_count"`
 	Unhooked    int                `json:"unhooked"`
 	Results     []*StaleHookResult `json:"results"`
+	// Shadow is true if patrol shadow mode kept the scan from unhooking.
+	Shadow bool `json:"shadow,omitempty"`
 }
 
 // ScanStaleHooks finds hooked beads with dead agents and optionally unhooks them.
@@ -80,6 +83,7 @@ func ScanStaleHooks(townRoot string, cfg *StaleHookConfig) (*StaleHookScanResult
 	result := &StaleHookScanResult{
 		ScannedAt: time.Now().UTC(),
 		Results:   make([]*StaleHookResult, 0),
+		Shadow:    InShadow(townRoot),
 	}
 
 	// Get all hooked beads
@@ -103,8 +107,9 @@ func ScanStaleHooks(townRoot string, cfg *StaleHookConfig) (*StaleHookScanResult
 
 		// Check if assignee agent is still alive (regardless of age)
 		sessionChecked := false
+		sessionName := ""
 		if bead.Assignee != "" {
-			sessionName := assigneeToSessionName(bead.Assignee)
+			sessionName = assigneeToSessionName(bead.Assignee)
 			if sessionName != "" {
 				alive, _ := t.HasSession(sessionName)
 				hookResult.AgentAlive = alive
@@ -136,7 +141,7 @@ func ScanStaleHooks(townRoot string, cfg *StaleHookConfig) (*StaleHookScanResult
 		if !hookResult.AgentAlive {
 			checkWorktreeState(townRoot, bead.Assignee, hookResult)
 
-			if !cfg.DryRun {
+			if !cfg.DryRun && !result.Shadow {
 				if err := unhookBead(townRoot, bead.ID); err != nil {
 					hookResult.Error = err.Error()
 				} else {
@@ -147,11 +152,58 @@ func ScanStaleHooks(townRoot string, cfg *StaleHookConfig) (*StaleHookScanResult
 		}
 
 		result.Results = append(result.Results, hookResult)
+		if !cfg.DryRun {
+			receipt := staleHookReceipt(bead, hookResult, sessionName, sessionChecked, cfg)
+			if result.Shadow && !hookResult.AgentAlive {
+				receipt.Shadow = true
+				receipt.Action = "unhooked"
+			}
+			_ = events.LogReceipt(townRoot, "deacon", receipt)
+		}
 	}
 
 	return result, nil
 }
 
+// staleHookReceipt explains why a stale hooked bead was (or wasn't) unhooked.
+func staleHookReceipt(bead *HookedBead, hr *StaleHookResult, sessionName string, sessionChecked bool, cfg *StaleHookConfig) events.Receipt {
+	r := events.Receipt{
+		Agent:   bead.Assignee,
+		Session: sessionName,
+		Bead:    bead.ID,
+		Rule:    "stale_hook",
+		Inputs: map[string]interface{}{
+			"session_checked": sessionChecked,
+			"agent_alive":     hr.AgentAlive,
+			"hooked_for":      hr.Age,
+			"partial_work":    hr.PartialWork,
+		},
+		Thresholds: map[string]interface{}{
+			"max_age": cfg.MaxAge.String(),
+		},
+		Action: "none",
+		Error:  hr.Error,
+	}
+	if sessionChecked {
+		r.Decision = "session-dead"
+		r.Reason = fmt.Sprintf("assignee session %s is not running", sessionName)
+	} else {
+		r.Decision = "hook-expired"
+		r.Reason = fmt.Sprintf("assignee liveness unknown and hooked for %s (max %s)", hr.Age, cfg.MaxAge)
+	}
+	if hr.PartialWork {
+		r.Inputs["worktree_dirty"] = hr.WorktreeDirty
+		r.Inputs["unpushed_commits"] = hr.UnpushedCount
+	}
+	switch {
+	case hr.Unhooked:
+		r.Action = "unhooked"
+	case hr.Error != "":
+		r.Action = "unhook-failed"
+	}
+	return r
+}
+
 // listHookedBeads returns all beads with status=hooked.
 func listHookedBeads(townRoot string) ([]*HookedBead, error) {
 	cmd := exec.Command("bd", "list", "--status=hooked", "--json", "--limit=0")
@@ -4,7 +4,11 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"runtime"
+	"strings"
 	"testing"
+
+	"github.com/steveyegge/gastown/internal/events"
 )
 
 func TestAssigneeToSessionName(t *testing.T) {
@@ -271,3 +275,64 @@ func TestStaleHookResult_PartialWorkFields(t *testing.T) {
 		t.Errorf("UnpushedCount = %d, want 3", result.UnpushedCount)
 	}
 }
+
+func TestStaleHookReceipt(t *testing.T) {
+	cfg := DefaultStaleHookConfig()
+	bead := &HookedBead{ID: "gt-abc", Assignee: "gastown/polecats/max"}
+
+	r := staleHookReceipt(bead, &StaleHookResult{Age: "2h0m0s", Unhooked: true}, "gt-max", true, cfg)
+	if r.Decision != "session-dead" || r.Action != "unhooked" || r.Bead != "gt-abc" {
+		t.Errorf("dead session receipt = %+v", r)
+	}
+	if r.Thresholds["max_age"] != cfg.MaxAge.String() {
+		t.Errorf("thresholds = %v", r.Thresholds)
+	}
+
+	r = staleHookReceipt(bead, &StaleHookResult{Age: "2h0m0s", Error: "bd failed"}, "", false, cfg)
+	if r.Decision != "hook-expired" || r.Action != "unhook-failed" || r.Error != "bd failed" {
+		t.Errorf("expired hook receipt = %+v", r)
+	}
+}
+
+func TestScanStaleHooks_ShadowDoesNotUnhook(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("test uses Unix shell script mocks for tmux and bd")
+	}
+	binDir := t.TempDir()
+	bdLog := filepath.Join(binDir, "bd.log")
+	bd := "#!/bin/sh\necho \"$*\" >> " + bdLog + "\n" +
+		"case \"$1\" in\n" +
+		"  list) echo '[{\"id\":\"gt-abc\",\"status\":\"hooked\",\"assignee\":\"gastown/polecats/max\",\"updated_at\":\"2026-01-01T00:00:00Z\"}]';;\n" +
+		"esac\n"
+	tmux := "#!/bin/sh\nexit 1\n" // every session is dead
+	for name, script := range map[string]string{"bd": bd, "tmux": tmux} {
+		if err := os.WriteFile(filepath.Join(binDir, name), []byte(script), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Setenv("PATH", binDir+":"+os.Getenv("PATH"))
+
+	townRoot := t.TempDir()
+	if err := EnableShadow(townRoot, "test", "test"); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := ScanStaleHooks(townRoot, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.StaleCount != 1 || result.Unhooked != 0 {
+		t.Errorf("stale = %d, unhooked = %d; want 1 stale, none unhooked", result.StaleCount, result.Unhooked)
+	}
+	calls, _ := os.ReadFile(bdLog)
+	if strings.Contains(string(calls), "update") {
+		t.Errorf("shadow mode updated a bead:\n%s", calls)
+	}
+	data, err := os.ReadFile(filepath.Join(townRoot, events.EventsFile))
+	if err != nil {
+		t.Fatalf("reading events: %v", err)
+	}
+	if !strings.Contains(string(data), `"action":"unhooked"`) || !strings.Contains(string(data), `"shadow":true`) {
+		t.Errorf("expected a shadow unhooked receipt, got: %s", data)
+	}
+}
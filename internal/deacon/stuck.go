@@ -103,11 +103,24 @@ func HealthCheckStateFile(townRoot string) string {
 	return filepath.Join(townRoot, "deacon", "health-check-state.json")
 }
 
+// ShadowHealthCheckStateFile returns the path to the health check state
+// kept while patrol shadow mode is on (see ShadowState).
+func ShadowHealthCheckStateFile(townRoot string) string {
+	return filepath.Join(townRoot, "deacon", "health-check-state.shadow.json")
+}
+
 // LoadHealthCheckState loads the health check state from disk.
 // Returns empty state if file doesn't exist.
 func LoadHealthCheckState(townRoot string) (*HealthCheckState, error) {
-	stateFile := HealthCheckStateFile(townRoot)
+	return loadHealthCheckState(HealthCheckStateFile(townRoot))
+}
+
+// LoadShadowHealthCheckState loads the shadow mode health check state.
+func LoadShadowHealthCheckState(townRoot string) (*HealthCheckState, error) {
+	return loadHealthCheckState(ShadowHealthCheckStateFile(townRoot))
+}
 
+func loadHealthCheckState(stateFile string) (*HealthCheckState, error) {
 	data, err := os.ReadFile(stateFile) //nolint:gosec // G304: path is constructed from trusted townRoot
 	if err != nil {
 		if os.IsNotExist(err) {
@@ -133,8 +146,15 @@ func LoadHealthCheckState(townRoot string) (*HealthCheckState, error) {
 
 // SaveHealthCheckState saves the health check state to disk.
 func SaveHealthCheckState(townRoot string, state *HealthCheckState) error {
-	stateFile := HealthCheckStateFile(townRoot)
+	return saveHealthCheckState(HealthCheckStateFile(townRoot), state)
+}
+
+// SaveShadowHealthCheckState saves the shadow mode health check state.
+func SaveShadowHealthCheckState(townRoot string, state *HealthCheckState) error {
+	return saveHealthCheckState(ShadowHealthCheckStateFile(townRoot), state)
+}
 
+func saveHealthCheckState(stateFile string, state *HealthCheckState) error {
 	// Ensure directory exists
 	if err := os.MkdirAll(filepath.Dir(stateFile), 0755); err != nil {
 		return fmt.Errorf("creating deacon directory: %w", err)
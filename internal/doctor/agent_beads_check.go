@@ -31,6 +31,8 @@ func NewAgentBeadsCheck() *AgentBeadsCheck {
 				CheckName:        "agent-beads-exist",
 				CheckDescription: "Verify agent beads exist for all agents",
 				CheckCategory:    CategoryRig,
+				CheckDependsOn:   beadsCheckPrereqs,
+				CheckCost:        CostHeavy,
 			},
 		},
 	}
@@ -299,18 +301,21 @@ func listCrewWorkers(townRoot, rigName string) []string {
 	return workers
 }
 
-// listPolecats returns the names of polecat directories in a rig.
+// listPolecats returns the names of polecat directories in a rig,
+// including warm spares under polecats/.warm/: a spare holds its polecat
+// name, and spares built by older versions also hold an agent bead.
 func listPolecats(townRoot, rigName string) []string {
 	polecatDir := filepath.Join(townRoot, rigName, "polecats")
-	entries, err := os.ReadDir(polecatDir)
-	if err != nil {
-		return nil // No polecats directory or can't read it
-	}
-
 	var polecats []string
-	for _, entry := range entries {
-		if entry.IsDir() && !strings.HasPrefix(entry.Name(), ".") {
-			polecats = append(polecats, entry.Name())
+	for _, dir := range []string{polecatDir, filepath.Join(polecatDir, ".warm")} {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue // No polecats directory or can't read it
+		}
+		for _, entry := range entries {
+			if entry.IsDir() && !strings.HasPrefix(entry.Name(), ".") {
+				polecats = append(polecats, entry.Name())
+			}
 		}
 	}
 	return polecats
@@ -20,6 +20,7 @@ func NewBeadsBinaryCheck() *BeadsBinaryCheck {
 			CheckName:        "beads-binary",
 			CheckDescription: "Check that beads (bd) is installed and meets minimum version",
 			CheckCategory:    CategoryInfrastructure,
+			CheckCost:        CostSubprocess,
 		},
 	}
 }
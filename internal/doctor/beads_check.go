@@ -11,6 +11,11 @@ import (
 	"github.com/steveyegge/gastown/internal/beads"
 )
 
+// beadsCheckPrereqs are the checks that must pass before a check querying
+// beads through bd is worth running: without bd, dolt, or a reachable
+// server every such query fails the same way.
+var beadsCheckPrereqs = []string{"beads-binary", "dolt-binary", "dolt-server-reachable"}
+
 // PrefixConflictCheck detects duplicate prefixes across rigs in routes.jsonl.
 // Duplicate prefixes break prefix-based routing.
 type PrefixConflictCheck struct {
@@ -328,6 +333,8 @@ func NewRoleLabelCheck() *RoleLabelCheck {
 				CheckName:        "role-bead-labels",
 				CheckDescription: "Check that role beads have gt:role label",
 				CheckCategory:    CategoryConfig,
+				CheckDependsOn:   beadsCheckPrereqs,
+				CheckCost:        CostSubprocess,
 			},
 		},
 		labelAdder: &realLabelAdder{},
@@ -448,6 +455,8 @@ func NewDatabasePrefixCheck() *DatabasePrefixCheck {
 				CheckName:        "database-prefix",
 				CheckDescription: "Check rig database issue_prefix matches routes.jsonl",
 				CheckCategory:    CategoryConfig,
+				CheckDependsOn:   beadsCheckPrereqs,
+				CheckCost:        CostSubprocess,
 			},
 		},
 	}
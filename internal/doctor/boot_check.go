@@ -24,6 +24,7 @@ func NewBootHealthCheck() *BootHealthCheck {
 				CheckName:        "boot-health",
 				CheckDescription: "Check Boot watchdog health (the vet checks on the dog)",
 				CheckCategory:    CategoryInfrastructure,
+				CheckDependsOn:   []string{"daemon"},
 			},
 		},
 	}
@@ -26,6 +26,7 @@ func NewBranchCheck() *BranchCheck {
 				CheckName:        "persistent-role-branches",
 				CheckDescription: "Detect persistent roles not on main branch",
 				CheckCategory:    CategoryCleanup,
+				CheckCost:        CostHeavy,
 			},
 		},
 	}
@@ -244,6 +245,7 @@ func NewCloneDivergenceCheck() *CloneDivergenceCheck {
 			CheckName:        "clone-divergence",
 			CheckDescription: "Detect emergency divergence between git clones",
 			CheckCategory:    CategoryCleanup,
+			CheckCost:        CostHeavy,
 		},
 	}
 }
@@ -52,6 +52,7 @@ func NewClaudeSettingsCheck() *ClaudeSettingsCheck {
 				CheckName:        "claude-settings",
 				CheckDescription: "Verify Claude settings.json files match expected templates",
 				CheckCategory:    CategoryConfig,
+				CheckCost:        CostHeavy,
 			},
 		},
 	}
@@ -683,7 +684,7 @@ func (c *ClaudeSettingsCheck) Fix(ctx *CheckContext) error {
 			errors = append(errors, fmt.Sprintf("failed to delete %s: %v", sf.path, err))
 			continue
 		}
-		fmt.Printf("  Deleted stale: %s\n", sf.path)
+		fmt.Fprintf(ctx.Output(), "  Deleted stale: %s\n", sf.path)
 		needsRestart = true
 
 		// Also delete parent .claude directory if empty
@@ -707,8 +708,8 @@ func (c *ClaudeSettingsCheck) Fix(ctx *CheckContext) error {
 			// Town-root files were inherited by ALL agents via directory traversal.
 			// Warn user to restart agents - don't auto-kill sessions as that's too disruptive,
 			// especially since deacon runs gt doctor automatically which would create a loop.
-			fmt.Printf("\n  %s Town-root settings were moved. Restart agents to pick up new config:\n", style.Warning.Render("⚠"))
-			fmt.Printf("      gt up --restart\n\n")
+			fmt.Fprintf(ctx.Output(), "\n  %s Town-root settings were moved. Restart agents to pick up new config:\n", style.Warning.Render("⚠"))
+			fmt.Fprintf(ctx.Output(), "      gt up --restart\n\n")
 			continue
 		}
 
@@ -754,15 +755,15 @@ func (c *ClaudeSettingsCheck) Fix(ctx *CheckContext) error {
 	// Report skipped files as warnings, not errors
 	if len(skipped) > 0 {
 		for _, s := range skipped {
-			fmt.Printf("  Warning: %s\n", s)
+			fmt.Fprintf(ctx.Output(), "  Warning: %s\n", s)
 		}
 	}
 
 	// Tell user to restart agents so they create correct settings
 	if needsRestart && !ctx.RestartSessions {
-		fmt.Printf("\n  %s Restart agents to create new settings:\n", style.Warning.Render("⚠"))
-		fmt.Printf("      gt up --restart\n")
-		fmt.Printf("\n  If you had custom Claude settings edits, re-apply them via 'gt hooks override <role>'.\n\n")
+		fmt.Fprintf(ctx.Output(), "\n  %s Restart agents to create new settings:\n", style.Warning.Render("⚠"))
+		fmt.Fprintf(ctx.Output(), "      gt up --restart\n")
+		fmt.Fprintf(ctx.Output(), "\n  If you had custom Claude settings edits, re-apply them via 'gt hooks override <role>'.\n\n")
 	}
 
 	if len(errors) > 0 {
@@ -771,6 +772,47 @@ func (c *ClaudeSettingsCheck) Fix(ctx *CheckContext) error {
 	return nil
 }
 
+// PlanFix lists the settings files Fix would delete and re-create, and the
+// sessions it would cycle with --restart-sessions. Tracked files are
+// skipped by Fix and left out here.
+func (c *ClaudeSettingsCheck) PlanFix(ctx *CheckContext, result *CheckResult) []PlannedChange {
+	var changes []PlannedChange
+	for _, sf := range c.staleSettings {
+		if (!sf.wrongLocation && len(sf.missing) == 0) || sf.missingFile {
+			continue
+		}
+		if sf.gitStatus == gitStatusTrackedModified || sf.gitStatus == gitStatusTrackedClean {
+			continue
+		}
+		changes = append(changes, PlannedChange{Kind: ChangeFile, Action: "delete", Target: sf.path, Note: "stale settings"})
+
+		claudeDir := filepath.Dir(sf.path)
+		if sf.agentType == "mayor" && !strings.Contains(sf.path, "/mayor/") {
+			if strings.HasSuffix(claudeDir, ".claude") {
+				changes = append(changes, PlannedChange{Kind: ChangeFile, Action: "create",
+					Target: filepath.Join(ctx.TownRoot, "mayor", ".claude"), Note: "mayor settings"})
+			}
+			continue
+		}
+
+		settingsDir := filepath.Dir(claudeDir)
+		if sf.rigName != "" {
+			if sd := config.RoleSettingsDir(sf.agentType, filepath.Join(ctx.TownRoot, sf.rigName)); sd != "" {
+				settingsDir = sd
+			}
+		}
+		changes = append(changes, PlannedChange{Kind: ChangeFile, Action: "create",
+			Target: filepath.Join(settingsDir, ".claude"), Note: sf.agentType + " settings"})
+
+		if ctx.RestartSessions && (sf.agentType == "witness" || sf.agentType == "refinery" ||
+			sf.agentType == "deacon" || sf.agentType == "mayor") {
+			changes = append(changes, PlannedChange{Kind: ChangeSession, Action: "kill", Target: sf.sessionName,
+				Note: "if running, to pick up new settings"})
+		}
+	}
+	return changes
+}
+
 // fileExists checks if a file exists.
 func fileExists(path string) bool {
 	info, err := os.Stat(path)
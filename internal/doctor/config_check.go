@@ -616,6 +616,8 @@ func NewCustomTypesCheck() *CustomTypesCheck {
 				CheckName:        "beads-custom-types",
 				CheckDescription: "Check that Gas Town custom types are registered with beads",
 				CheckCategory:    CategoryConfig,
+				CheckDependsOn:   beadsCheckPrereqs,
+				CheckCost:        CostSubprocess,
 			},
 		},
 	}
@@ -173,6 +173,16 @@ func (c *CrewStateCheck) Fix(ctx *CheckContext) error {
 	return lastErr
 }
 
+// PlanFix lists the state files Fix would rewrite.
+func (c *CrewStateCheck) PlanFix(ctx *CheckContext, result *CheckResult) []PlannedChange {
+	var changes []PlannedChange
+	for _, ic := range c.invalidCrews {
+		changes = append(changes, PlannedChange{Kind: ChangeFile, Action: "write", Target: ic.stateFile,
+			Note: fmt.Sprintf("reset %s/%s state (%s)", ic.rigName, ic.crewName, ic.issue)})
+	}
+	return changes
+}
+
 type crewDir struct {
 	path     string
 	rigName  string
@@ -241,6 +251,7 @@ func NewCrewWorktreeCheck() *CrewWorktreeCheck {
 				CheckName:        "crew-worktrees",
 				CheckDescription: "Detect stale cross-rig worktrees in crew directories",
 				CheckCategory:    CategoryCleanup,
+				CheckCost:        CostHeavy,
 			},
 		},
 	}
@@ -295,6 +306,16 @@ func (c *CrewWorktreeCheck) Fix(ctx *CheckContext) error {
 	return lastErr
 }
 
+// PlanFix lists the worktrees Fix would force-remove.
+func (c *CrewWorktreeCheck) PlanFix(ctx *CheckContext, result *CheckResult) []PlannedChange {
+	var changes []PlannedChange
+	for _, wt := range c.staleWorktrees {
+		changes = append(changes, PlannedChange{Kind: ChangeGit, Action: "remove", Target: wt.path,
+			Note: "git worktree remove --force"})
+	}
+	return changes
+}
+
 // findCrewWorktrees finds cross-rig worktrees in crew directories.
 // These are worktrees with hyphenated names (e.g., "beads-dave") that
 // indicate they were created via `gt worktree` for cross-rig work.
@@ -21,6 +21,7 @@ func NewDaemonCheck() *DaemonCheck {
 				CheckName:        "daemon",
 				CheckDescription: "Check if Gas Town daemon is running",
 				CheckCategory:    CategoryInfrastructure,
+				CheckCost:        CostSubprocess,
 			},
 		},
 	}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 
 	"github.com/steveyegge/gastown/internal/config"
 )
@@ -86,6 +88,22 @@ func (c *DeprecatedMergeQueueKeysCheck) Fix(ctx *CheckContext) error {
 	return nil
 }
 
+// PlanFix lists the settings files Fix would rewrite and the keys removed.
+func (c *DeprecatedMergeQueueKeysCheck) PlanFix(ctx *CheckContext, result *CheckResult) []PlannedChange {
+	paths := make([]string, 0, len(c.affectedFiles))
+	for path := range c.affectedFiles {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var changes []PlannedChange
+	for _, path := range paths {
+		changes = append(changes, PlannedChange{Kind: ChangeFile, Action: "write", Target: path,
+			Note: "remove merge_queue." + strings.Join(c.affectedFiles[path], ", merge_queue.")})
+	}
+	return changes
+}
+
 // findDeprecatedKeys reads a settings file and returns any deprecated merge_queue keys found.
 func findDeprecatedKeys(path string) []string {
 	data, err := os.ReadFile(path)
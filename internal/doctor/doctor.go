@@ -1,16 +1,14 @@
 package doctor
 
 import (
-	"fmt"
 	"io"
 	"time"
-
-	"github.com/steveyegge/gastown/internal/ui"
 )
 
 // Doctor manages and executes health checks.
 type Doctor struct {
-	checks []Check
+	checks  []Check
+	workers int // max concurrent checks; 0 means DefaultWorkers
 }
 
 // NewDoctor creates a new Doctor with no registered checks.
@@ -35,6 +33,38 @@ func (d *Doctor) Checks() []Check {
 	return d.checks
 }
 
+// Only narrows the registered checks to those named, keeping registration
+// order. Returns the names that matched no registered check.
+func (d *Doctor) Only(names []string) []string {
+	want := make(map[string]bool, len(names))
+	for _, name := range names {
+		want[name] = true
+	}
+	kept := d.checks[:0]
+	for _, check := range d.checks {
+		if want[check.Name()] {
+			kept = append(kept, check)
+			delete(want, check.Name())
+		}
+	}
+	d.checks = kept
+
+	var unknown []string
+	for _, name := range names {
+		if want[name] {
+			unknown = append(unknown, name)
+			delete(want, name)
+		}
+	}
+	return unknown
+}
+
+// SetWorkers sets how many checks may run at once. 1 runs them
+// sequentially in registration order; 0 restores DefaultWorkers.
+func (d *Doctor) SetWorkers(n int) {
+	d.workers = n
+}
+
 // categoryGetter interface for checks that provide a category
 type categoryGetter interface {
 	Category() string
@@ -48,60 +78,11 @@ func (d *Doctor) Run(ctx *CheckContext) *Report {
 // RunStreaming executes all registered checks with optional real-time output.
 // If w is non-nil, prints each check name as it starts and result when done.
 // If slowThreshold > 0, shows hourglass icon for slow checks.
+//
+// Independent checks run concurrently (see SetWorkers); results are still
+// streamed and reported in registration order.
 func (d *Doctor) RunStreaming(ctx *CheckContext, w io.Writer, slowThreshold time.Duration) *Report {
-	report := NewReport()
-
-	for _, check := range d.checks {
-		// Stream: print check name before running
-		if w != nil {
-			fmt.Fprintf(w, "  %s  %s...", ui.RenderMuted("○"), check.Name())
-		}
-
-		start := time.Now()
-		result := check.Run(ctx)
-		result.Elapsed = time.Since(start)
-
-		// Ensure check name is populated
-		if result.Name == "" {
-			result.Name = check.Name()
-		}
-		// Set category from check if available
-		if cg, ok := check.(categoryGetter); ok && result.Category == "" {
-			result.Category = cg.Category()
-		}
-
-		// Stream: overwrite line with result
-		if w != nil {
-			var statusIcon string
-			switch result.Status {
-			case StatusOK:
-				statusIcon = ui.RenderPassIcon()
-			case StatusWarning:
-				statusIcon = ui.RenderWarnIcon()
-			case StatusError:
-				statusIcon = ui.RenderFailIcon()
-			}
-			// Check if slow (hourglass replaces spaces to maintain alignment)
-			isSlow := slowThreshold > 0 && result.Elapsed >= slowThreshold
-			slowIndicator := "  "
-			if isSlow {
-				report.Summary.Slow++
-				slowIndicator = "⏳"
-			}
-			fmt.Fprintf(w, "\r  %s%s%s", statusIcon, slowIndicator, result.Name)
-			if result.Message != "" {
-				fmt.Fprintf(w, "%s", ui.RenderMuted(" "+result.Message))
-			}
-			if isSlow {
-				fmt.Fprintf(w, "%s", ui.RenderMuted(" ("+formatDuration(result.Elapsed)+")"))
-			}
-			fmt.Fprintln(w)
-		}
-
-		report.Add(result)
-	}
-
-	return report
+	return d.execute(ctx, w, slowThreshold, false)
 }
 
 // Fix runs all checks with auto-fix enabled where possible.
@@ -113,108 +94,12 @@ func (d *Doctor) Fix(ctx *CheckContext) *Report {
 // FixStreaming runs all checks with auto-fix and optional real-time output.
 // If w is non-nil, prints each check name as it starts and result when done.
 // If slowThreshold > 0, shows hourglass icon for slow checks.
+//
+// Checks run concurrently as in RunStreaming. Fixes wait until every check
+// has run, then are applied one at a time in dependency order; checks that
+// depend on a fixed check are re-run before their own fix.
 func (d *Doctor) FixStreaming(ctx *CheckContext, w io.Writer, slowThreshold time.Duration) *Report {
-	report := NewReport()
-
-	for _, check := range d.checks {
-		// Stream: print check name before running
-		if w != nil {
-			fmt.Fprintf(w, "  %s  %s...", ui.RenderMuted("○"), check.Name())
-		}
-
-		start := time.Now()
-		result := check.Run(ctx)
-		if result.Name == "" {
-			result.Name = check.Name()
-		}
-		// Set category from check if available
-		if cg, ok := check.(categoryGetter); ok && result.Category == "" {
-			result.Category = cg.Category()
-		}
-
-		// Attempt fix if check failed and is fixable
-		if result.Status != StatusOK && check.CanFix() {
-			// Stream: show the problem with fixing indicator (all on same line)
-			if w != nil {
-				var problemIcon string
-				if result.Status == StatusError {
-					problemIcon = ui.RenderFailIcon()
-				} else {
-					problemIcon = ui.RenderWarnIcon()
-				}
-				// Overwrite the "checking" line with problem status + fixing indicator
-				fmt.Fprintf(w, "\r  %s  %s", problemIcon, check.Name())
-				if result.Message != "" {
-					fmt.Fprintf(w, "%s", ui.RenderMuted(" "+result.Message))
-				}
-				fmt.Fprintf(w, "%s", ui.RenderMuted(" (fixing)..."))
-			}
-
-			err := check.Fix(ctx)
-			if err == nil {
-				// Re-run check to verify fix worked
-				result = check.Run(ctx)
-				if result.Name == "" {
-					result.Name = check.Name()
-				}
-				// Set category again after re-run
-				if cg, ok := check.(categoryGetter); ok && result.Category == "" {
-					result.Category = cg.Category()
-				}
-				// Update message to indicate fix was applied
-				if result.Status == StatusOK {
-					result.Message = result.Message + " (fixed)"
-					result.Fixed = true
-				}
-			} else {
-				// Fix failed, add error to details
-				result.Details = append(result.Details, "Fix failed: "+err.Error())
-			}
-		}
-
-		// Record total elapsed time including any fix attempts
-		result.Elapsed = time.Since(start)
-
-		// Stream: overwrite line with final result
-		if w != nil {
-			var statusIcon string
-			if result.Fixed {
-				statusIcon = ui.RenderFixIcon()
-			} else {
-				switch result.Status {
-				case StatusOK:
-					statusIcon = ui.RenderPassIcon()
-				case StatusWarning:
-					statusIcon = ui.RenderWarnIcon()
-				case StatusError:
-					statusIcon = ui.RenderFailIcon()
-				}
-			}
-			// Check if slow (hourglass replaces spaces to maintain alignment)
-			// Fix icon (🔧) is double-width, so use one less padding space
-			isSlow := slowThreshold > 0 && result.Elapsed >= slowThreshold
-			slowIndicator := "  "
-			if result.Fixed {
-				slowIndicator = " "
-			}
-			if isSlow {
-				report.Summary.Slow++
-				slowIndicator = "⏳"
-			}
-			fmt.Fprintf(w, "\r  %s%s%s", statusIcon, slowIndicator, result.Name)
-			if result.Message != "" {
-				fmt.Fprintf(w, "%s", ui.RenderMuted(" "+result.Message))
-			}
-			if isSlow {
-				fmt.Fprintf(w, "%s", ui.RenderMuted(" ("+formatDuration(result.Elapsed)+")"))
-			}
-			fmt.Fprintln(w)
-		}
-
-		report.Add(result)
-	}
-
-	return report
+	return d.execute(ctx, w, slowThreshold, true)
 }
 
 // BaseCheck provides a base implementation for checks that don't support auto-fix.
@@ -222,7 +107,9 @@ func (d *Doctor) FixStreaming(ctx *CheckContext, w io.Writer, slowThreshold time
 type BaseCheck struct {
 	CheckName        string
 	CheckDescription string
-	CheckCategory    string // Category for grouping (e.g., CategoryCore)
+	CheckCategory    string    // Category for grouping (e.g., CategoryCore)
+	CheckDependsOn   []string  // Names of checks that must pass (or be fixed) first
+	CheckCost        CheckCost // Scheduling hint (default CostCheap)
 }
 
 // Category returns the check's category for grouping in output.
@@ -230,6 +117,16 @@ func (b *BaseCheck) Category() string {
 	return b.CheckCategory
 }
 
+// DependsOn returns the names of checks this check needs to have passed.
+func (b *BaseCheck) DependsOn() []string {
+	return b.CheckDependsOn
+}
+
+// Cost returns the check's cost hint.
+func (b *BaseCheck) Cost() CheckCost {
+	return b.CheckCost
+}
+
 // Name returns the check name.
 func (b *BaseCheck) Name() string {
 	return b.CheckName
@@ -2,6 +2,7 @@ package doctor
 
 import (
 	"bytes"
+	"strings"
 	"testing"
 )
 
@@ -54,6 +55,7 @@ func TestCheckStatus_String(t *testing.T) {
 		{StatusOK, "OK"},
 		{StatusWarning, "Warning"},
 		{StatusError, "Error"},
+		{StatusSkipped, "Skipped"},
 		{CheckStatus(99), "Unknown"},
 	}
 
@@ -362,3 +364,21 @@ func TestFixableCheck(t *testing.T) {
 		t.Error("FixableCheck.CanFix() should return true")
 	}
 }
+
+func TestDoctor_Only(t *testing.T) {
+	d := NewDoctor()
+	d.RegisterAll(newMockCheck("a", StatusOK), newMockCheck("b", StatusOK), newMockCheck("c", StatusOK))
+
+	unknown := d.Only([]string{"c", "a", "missing"})
+
+	var names []string
+	for _, c := range d.Checks() {
+		names = append(names, c.Name())
+	}
+	if strings.Join(names, ",") != "a,c" {
+		t.Errorf("Only kept %v, want [a c] in registration order", names)
+	}
+	if len(unknown) != 1 || unknown[0] != "missing" {
+		t.Errorf("unknown = %v, want [missing]", unknown)
+	}
+}
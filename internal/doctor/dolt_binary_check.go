@@ -19,6 +19,7 @@ func NewDoltBinaryCheck() *DoltBinaryCheck {
 			CheckName:        "dolt-binary",
 			CheckDescription: "Check that dolt is installed and in PATH",
 			CheckCategory:    CategoryInfrastructure,
+			CheckCost:        CostSubprocess,
 		},
 	}
 }
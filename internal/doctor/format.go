@@ -0,0 +1,242 @@
+package doctor
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Output formats for `gt doctor --format`.
+const (
+	FormatText  = "text"
+	FormatJSON  = "json"
+	FormatSARIF = "sarif"
+)
+
+// jsonReport is the --format json document.
+type jsonReport struct {
+	Timestamp time.Time   `json:"timestamp"`
+	Healthy   bool        `json:"healthy"`
+	Summary   jsonSummary `json:"summary"`
+	Checks    []jsonCheck `json:"checks"`
+	FixPlan   []*FixPlan  `json:"fix_plan,omitempty"`
+}
+
+type jsonSummary struct {
+	Total    int `json:"total"`
+	OK       int `json:"ok"`
+	Warnings int `json:"warnings"`
+	Errors   int `json:"errors"`
+	Fixed    int `json:"fixed"`
+	Skipped  int `json:"skipped"`
+}
+
+type jsonCheck struct {
+	Name      string      `json:"name"`
+	Category  string      `json:"category,omitempty"`
+	Status    CheckStatus `json:"status"`
+	Message   string      `json:"message,omitempty"`
+	Details   []string    `json:"details,omitempty"`
+	FixHint   string      `json:"fix_hint,omitempty"`
+	Fixed     bool        `json:"fixed,omitempty"`
+	ElapsedMs float64     `json:"elapsed_ms"`
+}
+
+// WriteJSON writes the full report, and fix plans if any, as JSON.
+func WriteJSON(w io.Writer, report *Report, plans []*FixPlan) error {
+	doc := jsonReport{
+		Timestamp: report.Timestamp.UTC(),
+		Healthy:   report.IsHealthy(),
+		Summary: jsonSummary{
+			Total:    report.Summary.Total,
+			OK:       report.Summary.OK,
+			Warnings: report.Summary.Warnings,
+			Errors:   report.Summary.Errors,
+			Fixed:    report.Summary.Fixed,
+			Skipped:  report.Summary.Skipped,
+		},
+		Checks:  make([]jsonCheck, 0, len(report.Checks)),
+		FixPlan: plans,
+	}
+	for _, c := range report.Checks {
+		doc.Checks = append(doc.Checks, jsonCheck{
+			Name:      c.Name,
+			Category:  c.Category,
+			Status:    c.Status,
+			Message:   c.Message,
+			Details:   c.Details,
+			FixHint:   c.FixHint,
+			Fixed:     c.Fixed,
+			ElapsedMs: elapsedMs(c.Elapsed),
+		})
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(doc)
+}
+
+func elapsedMs(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
+
+// SARIF 2.1.0, trimmed to what code-scanning UIs read. Each check is a
+// rule; each check result is a SARIF result.
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifInfoURI = "https://github.com/steveyegge/gastown"
+)
+
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool        sarifTool         `json:"tool"`
+	Invocations []sarifInvocation `json:"invocations"`
+	Results     []sarifResult     `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string          `json:"id"`
+	ShortDescription sarifMessage    `json:"shortDescription"`
+	Properties       sarifProperties `json:"properties,omitempty"`
+}
+
+type sarifInvocation struct {
+	ExecutionSuccessful bool   `json:"executionSuccessful"`
+	StartTimeUTC        string `json:"startTimeUtc"`
+}
+
+type sarifResult struct {
+	RuleID     string          `json:"ruleId"`
+	RuleIndex  int             `json:"ruleIndex"`
+	Kind       string          `json:"kind"`
+	Level      string          `json:"level"`
+	Message    sarifMessage    `json:"message"`
+	Fixes      []sarifFix      `json:"fixes,omitempty"`
+	Properties sarifProperties `json:"properties,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+// sarifFix carries a fix hint or planned change as a description only;
+// doctor fixes aren't expressible as SARIF artifact edits.
+type sarifFix struct {
+	Description sarifMessage `json:"description"`
+}
+
+type sarifProperties map[string]any
+
+// WriteSARIF writes the report as a SARIF 2.1.0 log. checks supplies rule
+// descriptions; plans, if any, are attached to their results as fixes.
+func WriteSARIF(w io.Writer, report *Report, checks []Check, plans []*FixPlan, toolVersion string) error {
+	descriptions := make(map[string]string, len(checks))
+	for _, c := range checks {
+		descriptions[c.Name()] = c.Description()
+	}
+	planFor := make(map[string]*FixPlan, len(plans))
+	for _, p := range plans {
+		planFor[p.Check] = p
+	}
+
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "gt doctor",
+			Version:        toolVersion,
+			InformationURI: sarifInfoURI,
+			Rules:          make([]sarifRule, 0, len(report.Checks)),
+		}},
+		Invocations: []sarifInvocation{{
+			ExecutionSuccessful: true,
+			StartTimeUTC:        report.Timestamp.UTC().Format(time.RFC3339),
+		}},
+		Results: make([]sarifResult, 0, len(report.Checks)),
+	}
+
+	ruleIndex := make(map[string]int)
+	for _, c := range report.Checks {
+		idx, ok := ruleIndex[c.Name]
+		if !ok {
+			idx = len(run.Tool.Driver.Rules)
+			ruleIndex[c.Name] = idx
+			desc := descriptions[c.Name]
+			if desc == "" {
+				desc = c.Name
+			}
+			rule := sarifRule{ID: c.Name, ShortDescription: sarifMessage{Text: desc}}
+			if c.Category != "" {
+				rule.Properties = sarifProperties{"category": c.Category}
+			}
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, rule)
+		}
+
+		kind, level := sarifKindLevel(c.Status)
+		msg := c.Message
+		if msg == "" {
+			msg = c.Name + ": " + c.Status.String()
+		}
+		result := sarifResult{
+			RuleID:    c.Name,
+			RuleIndex: idx,
+			Kind:      kind,
+			Level:     level,
+			Message:   sarifMessage{Text: msg},
+			Properties: sarifProperties{
+				"elapsedMs": elapsedMs(c.Elapsed),
+			},
+		}
+		if len(c.Details) > 0 {
+			result.Properties["details"] = c.Details
+		}
+		if c.Fixed {
+			result.Properties["fixed"] = true
+		}
+		if c.FixHint != "" {
+			result.Fixes = append(result.Fixes, sarifFix{Description: sarifMessage{Text: c.FixHint}})
+		}
+		if plan := planFor[c.Name]; plan != nil {
+			for _, change := range plan.Changes {
+				result.Fixes = append(result.Fixes, sarifFix{Description: sarifMessage{
+					Text: fmt.Sprintf("%s %s %s", change.Action, change.Kind, change.Target),
+				}})
+			}
+			result.Properties["fixPlanExact"] = plan.Exact
+		}
+		run.Results = append(run.Results, result)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(sarifLog{Version: sarifVersion, Schema: sarifSchema, Runs: []sarifRun{run}})
+}
+
+// sarifKindLevel maps a check status to SARIF result kind and level.
+func sarifKindLevel(s CheckStatus) (kind, level string) {
+	switch s {
+	case StatusOK:
+		return "pass", "none"
+	case StatusWarning:
+		return "fail", "warning"
+	case StatusError:
+		return "fail", "error"
+	default:
+		return "notApplicable", "none"
+	}
+}
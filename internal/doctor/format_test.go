@@ -0,0 +1,99 @@
+package doctor
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func sampleReport() *Report {
+	r := NewReport()
+	r.Add(&CheckResult{Name: "good", Status: StatusOK, Category: CategoryCore, Elapsed: 1500 * time.Microsecond})
+	r.Add(&CheckResult{Name: "bad", Status: StatusError, Message: "broken", Details: []string{"a", "b"},
+		FixHint: "run x", Category: CategoryRig})
+	r.Add(&CheckResult{Name: "blocked", Status: StatusSkipped, Message: "skipped (requires bad)"})
+	return r
+}
+
+func TestWriteJSON(t *testing.T) {
+	plans := []*FixPlan{{Check: "bad", Status: StatusError, Exact: true,
+		Changes: []PlannedChange{{Kind: ChangeFile, Action: "delete", Target: "/x"}}}}
+
+	var buf bytes.Buffer
+	if err := WriteJSON(&buf, sampleReport(), plans); err != nil {
+		t.Fatal(err)
+	}
+
+	var doc struct {
+		Healthy bool
+		Summary struct{ Total, Errors, Skipped int }
+		Checks  []struct {
+			Name      string
+			Category  string
+			Status    string
+			Details   []string
+			FixHint   string  `json:"fix_hint"`
+			ElapsedMs float64 `json:"elapsed_ms"`
+		}
+		FixPlan []FixPlan `json:"fix_plan"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+	if doc.Healthy || doc.Summary.Total != 3 || doc.Summary.Errors != 1 || doc.Summary.Skipped != 1 {
+		t.Errorf("summary = %+v healthy=%v", doc.Summary, doc.Healthy)
+	}
+	if len(doc.Checks) != 3 {
+		t.Fatalf("got %d checks", len(doc.Checks))
+	}
+	if doc.Checks[0].ElapsedMs != 1.5 || doc.Checks[0].Status != "ok" {
+		t.Errorf("checks[0] = %+v", doc.Checks[0])
+	}
+	bad := doc.Checks[1]
+	if bad.Status != "error" || bad.Category != CategoryRig || bad.FixHint != "run x" || len(bad.Details) != 2 {
+		t.Errorf("checks[1] = %+v", bad)
+	}
+	if len(doc.FixPlan) != 1 || doc.FixPlan[0].Changes[0].Target != "/x" {
+		t.Errorf("fix_plan = %+v", doc.FixPlan)
+	}
+}
+
+func TestWriteSARIF(t *testing.T) {
+	checks := []Check{newMockCheck("good", StatusOK), newMockCheck("bad", StatusError)}
+
+	var buf bytes.Buffer
+	if err := WriteSARIF(&buf, sampleReport(), checks, nil, "1.2.3"); err != nil {
+		t.Fatal(err)
+	}
+
+	var log sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("invalid SARIF: %v", err)
+	}
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("log = %+v", log)
+	}
+	run := log.Runs[0]
+	if run.Tool.Driver.Version != "1.2.3" || len(run.Tool.Driver.Rules) != 3 {
+		t.Errorf("driver = %+v", run.Tool.Driver)
+	}
+	if got := run.Tool.Driver.Rules[1].ShortDescription.Text; got != "Test check: bad" {
+		t.Errorf("rule description = %q", got)
+	}
+	// Unregistered checks fall back to their name
+	if got := run.Tool.Driver.Rules[2].ShortDescription.Text; got != "blocked" {
+		t.Errorf("rule description = %q", got)
+	}
+
+	want := []struct{ kind, level string }{{"pass", "none"}, {"fail", "error"}, {"notApplicable", "none"}}
+	for i, w := range want {
+		r := run.Results[i]
+		if r.Kind != w.kind || r.Level != w.level || r.RuleIndex != i {
+			t.Errorf("results[%d] = %s/%s idx %d, want %s/%s", i, r.Kind, r.Level, r.RuleIndex, w.kind, w.level)
+		}
+	}
+	if fixes := run.Results[1].Fixes; len(fixes) != 1 || fixes[0].Description.Text != "run x" {
+		t.Errorf("fixes = %+v", fixes)
+	}
+}
@@ -0,0 +1,239 @@
+package doctor
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/gofrs/flock"
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// Doctor history is an append-only JSONL time series of check results,
+// written by `gt doctor --record` (which the daemon's doctor patrol runs on
+// a schedule) and read by `gt doctor history`. Only name, status and
+// message are kept per check; details belong to the live report.
+
+// MaxHistoryEntries bounds the history file. Older entries are dropped when
+// an append pushes the file past it.
+const MaxHistoryEntries = 2000
+
+// FlapThreshold is the number of status changes within a history window at
+// which a check is reported as flapping.
+const FlapThreshold = 3
+
+// HistoryEntry is one recorded doctor run.
+type HistoryEntry struct {
+	Time   time.Time      `json:"time"`
+	Checks []HistoryCheck `json:"checks"`
+}
+
+// HistoryCheck is one check's result within a recorded run.
+type HistoryCheck struct {
+	Name    string      `json:"name"`
+	Status  CheckStatus `json:"status"`
+	Message string      `json:"message,omitempty"`
+}
+
+// HistoryFile returns the path of the doctor history for a town.
+func HistoryFile(townRoot string) string {
+	return filepath.Join(townRoot, "daemon", "doctor-history.jsonl")
+}
+
+// NewHistoryEntry converts a report into a history entry.
+func NewHistoryEntry(report *Report) HistoryEntry {
+	entry := HistoryEntry{Time: report.Timestamp.UTC()}
+	for _, c := range report.Checks {
+		entry.Checks = append(entry.Checks, HistoryCheck{Name: c.Name, Status: c.Status, Message: c.Message})
+	}
+	return entry
+}
+
+// AppendHistory appends an entry to the town's doctor history, trimming the
+// oldest entries once the file exceeds MaxHistoryEntries. The append and
+// trim hold a lock on the history, so a trim can't drop an entry appended
+// by a concurrent run.
+func AppendHistory(townRoot string, entry HistoryEntry) error {
+	path := HistoryFile(townRoot)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating history directory: %w", err)
+	}
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("encoding history entry: %w", err)
+	}
+
+	fl := flock.New(path + ".lock")
+	if err := fl.Lock(); err != nil {
+		return fmt.Errorf("locking history: %w", err)
+	}
+	defer func() { _ = fl.Unlock() }()
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644) //nolint:gosec // G302: history is not sensitive
+	if err != nil {
+		return fmt.Errorf("opening history: %w", err)
+	}
+	_, err = f.Write(append(line, '\n'))
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("writing history: %w", err)
+	}
+	return trimHistory(path, MaxHistoryEntries)
+}
+
+// trimHistory rewrites path keeping only its last max lines.
+func trimHistory(path string, max int) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading history: %w", err)
+	}
+	lines := bytes.SplitAfter(data, []byte("\n"))
+	if len(lines) > 0 && len(lines[len(lines)-1]) == 0 {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) <= max {
+		return nil
+	}
+	return util.AtomicWriteFile(path, bytes.Join(lines[len(lines)-max:], nil), 0644)
+}
+
+// LoadHistory returns the recorded runs at or after since, oldest first.
+// A missing history is empty; malformed lines are skipped.
+func LoadHistory(townRoot string, since time.Time) ([]HistoryEntry, error) {
+	f, err := os.Open(HistoryFile(townRoot))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("opening history: %w", err)
+	}
+	defer f.Close()
+
+	var entries []HistoryEntry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		var entry HistoryEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			continue
+		}
+		if entry.Time.Before(since) {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return entries, fmt.Errorf("reading history: %w", err)
+	}
+	return entries, nil
+}
+
+// LastStatuses returns each check's most recent recorded result. Runs
+// usually cover a subset of checks, so this looks back as far as needed.
+func LastStatuses(entries []HistoryEntry) map[string]HistoryCheck {
+	last := make(map[string]HistoryCheck)
+	for _, e := range entries {
+		for _, c := range e.Checks {
+			if c.Status != StatusSkipped {
+				last[c.Name] = c
+			}
+		}
+	}
+	return last
+}
+
+// Regressions returns the checks in report that are now errors but were OK
+// when last recorded. Checks with no prior record are not regressions:
+// a first run has nothing to regress from.
+func Regressions(last map[string]HistoryCheck, report *Report) []*CheckResult {
+	var regressed []*CheckResult
+	for _, c := range report.Checks {
+		prev, ok := last[c.Name]
+		if ok && prev.Status == StatusOK && c.Status == StatusError {
+			regressed = append(regressed, c)
+		}
+	}
+	return regressed
+}
+
+// CheckTrend summarizes one check across a history window.
+type CheckTrend struct {
+	Name       string        `json:"name"`
+	Runs       int           `json:"runs"`
+	OK         int           `json:"ok"`
+	Warnings   int           `json:"warnings"`
+	Errors     int           `json:"errors"`
+	Changes    int           `json:"changes"` // status transitions, skips ignored
+	Current    CheckStatus   `json:"current"`
+	Message    string        `json:"message,omitempty"`
+	LastChange time.Time     `json:"last_change"`
+	Recent     []CheckStatus `json:"recent"` // oldest first, at most TrendLength
+}
+
+// TrendLength is how many recent results a CheckTrend keeps.
+const TrendLength = 20
+
+// Flapping reports whether the check changed status often enough within
+// the window to be considered unstable.
+func (t *CheckTrend) Flapping() bool {
+	return t.Changes >= FlapThreshold
+}
+
+// Trends summarizes each check across entries. Flapping checks sort first,
+// then checks currently failing, then the rest by name.
+func Trends(entries []HistoryEntry) []*CheckTrend {
+	byName := make(map[string]*CheckTrend)
+	for _, e := range entries {
+		for _, c := range e.Checks {
+			if c.Status == StatusSkipped {
+				continue
+			}
+			t := byName[c.Name]
+			if t == nil {
+				t = &CheckTrend{Name: c.Name, Current: c.Status}
+				byName[c.Name] = t
+			} else if c.Status != t.Current {
+				t.Changes++
+				t.LastChange = e.Time
+			}
+			t.Runs++
+			switch c.Status {
+			case StatusOK:
+				t.OK++
+			case StatusWarning:
+				t.Warnings++
+			case StatusError:
+				t.Errors++
+			}
+			t.Current = c.Status
+			t.Message = c.Message
+			t.Recent = append(t.Recent, c.Status)
+			if len(t.Recent) > TrendLength {
+				t.Recent = t.Recent[1:]
+			}
+		}
+	}
+
+	trends := make([]*CheckTrend, 0, len(byName))
+	for _, t := range byName {
+		trends = append(trends, t)
+	}
+	sort.Slice(trends, func(i, j int) bool {
+		a, b := trends[i], trends[j]
+		if a.Flapping() != b.Flapping() {
+			return a.Flapping()
+		}
+		if a.Current != b.Current {
+			return a.Current > b.Current
+		}
+		return a.Name < b.Name
+	})
+	return trends
+}
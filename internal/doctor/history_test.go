@@ -0,0 +1,169 @@
+package doctor
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCheckStatus_Text(t *testing.T) {
+	for _, s := range []CheckStatus{StatusOK, StatusWarning, StatusError, StatusSkipped} {
+		data, err := json.Marshal(s)
+		if err != nil {
+			t.Fatalf("marshal %v: %v", s, err)
+		}
+		var got CheckStatus
+		if err := json.Unmarshal(data, &got); err != nil || got != s {
+			t.Errorf("round trip %s: got %v, err %v", data, got, err)
+		}
+	}
+	if data, _ := json.Marshal(StatusWarning); string(data) != `"warning"` {
+		t.Errorf("StatusWarning = %s, want \"warning\"", data)
+	}
+	var s CheckStatus
+	if err := json.Unmarshal([]byte(`"bogus"`), &s); err == nil {
+		t.Error("expected error for unknown status")
+	}
+}
+
+func historyReport(at time.Time, statuses map[string]CheckStatus) *Report {
+	r := NewReport()
+	r.Timestamp = at
+	for name, s := range statuses {
+		r.Add(&CheckResult{Name: name, Status: s, Message: name + " " + s.String()})
+	}
+	return r
+}
+
+func TestHistory_AppendLoadTrim(t *testing.T) {
+	town := t.TempDir()
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 3; i++ {
+		r := historyReport(base.Add(time.Duration(i)*time.Hour), map[string]CheckStatus{"a": StatusOK})
+		if err := AppendHistory(town, NewHistoryEntry(r)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	entries, err := LoadHistory(town, base.Add(30*time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 || !entries[0].Time.Equal(base.Add(time.Hour)) {
+		t.Errorf("since filter: got %d entries %+v", len(entries), entries)
+	}
+
+	if err := trimHistory(HistoryFile(town), 2); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(HistoryFile(town))
+	if n := strings.Count(string(data), "\n"); n != 2 {
+		t.Errorf("after trim: %d lines, want 2", n)
+	}
+	if entries, _ := LoadHistory(town, time.Time{}); len(entries) != 2 || !entries[0].Time.Equal(base.Add(time.Hour)) {
+		t.Errorf("trim kept wrong entries: %+v", entries)
+	}
+
+	if entries, err := LoadHistory(t.TempDir(), time.Time{}); err != nil || entries != nil {
+		t.Errorf("missing history = %v, %v; want empty", entries, err)
+	}
+}
+
+func TestHistory_ConcurrentAppendsSurviveTrim(t *testing.T) {
+	town := t.TempDir()
+	old := NewHistoryEntry(historyReport(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), map[string]CheckStatus{"a": StatusOK}))
+	line, _ := json.Marshal(old)
+	full := strings.Repeat(string(line)+"\n", MaxHistoryEntries)
+	if err := os.MkdirAll(filepath.Dir(HistoryFile(town)), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(HistoryFile(town), []byte(full), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Every append trims; without the lock a trim can rewrite the file
+	// from a read taken before another run's append.
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	const runs = 50
+	var wg sync.WaitGroup
+	for i := 0; i < runs; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			r := historyReport(base.Add(time.Duration(i)*time.Minute), map[string]CheckStatus{"a": StatusOK})
+			if err := AppendHistory(town, NewHistoryEntry(r)); err != nil {
+				t.Error(err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	entries, err := LoadHistory(town, base)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != runs {
+		t.Errorf("%d of %d concurrent entries survived", len(entries), runs)
+	}
+}
+
+func TestRegressions(t *testing.T) {
+	now := time.Now()
+	prior := []HistoryEntry{
+		NewHistoryEntry(historyReport(now.Add(-2*time.Hour), map[string]CheckStatus{"ok-then-err": StatusOK, "warn-then-err": StatusOK})),
+		NewHistoryEntry(historyReport(now.Add(-time.Hour), map[string]CheckStatus{"warn-then-err": StatusWarning, "still-err": StatusError, "skipped": StatusOK})),
+		// A skip doesn't overwrite the last real result.
+		NewHistoryEntry(historyReport(now.Add(-time.Minute), map[string]CheckStatus{"skipped": StatusSkipped})),
+	}
+	report := historyReport(now, map[string]CheckStatus{
+		"ok-then-err":   StatusError,
+		"warn-then-err": StatusError,
+		"still-err":     StatusError,
+		"skipped":       StatusError,
+		"new":           StatusError,
+	})
+
+	var got []string
+	for _, c := range Regressions(LastStatuses(prior), report) {
+		got = append(got, c.Name)
+	}
+	gotSet := strings.Join(got, ",")
+	for _, want := range []string{"ok-then-err", "skipped"} {
+		if !strings.Contains(gotSet, want) {
+			t.Errorf("missing regression %s in %v", want, got)
+		}
+	}
+	if len(got) != 2 {
+		t.Errorf("regressions = %v, want ok-then-err and skipped only", got)
+	}
+}
+
+func TestTrends(t *testing.T) {
+	now := time.Now()
+	var entries []HistoryEntry
+	for i, s := range []CheckStatus{StatusOK, StatusError, StatusOK, StatusError, StatusSkipped} {
+		entries = append(entries, NewHistoryEntry(historyReport(now.Add(time.Duration(i)*time.Minute),
+			map[string]CheckStatus{"flappy": s, "steady": StatusOK, "broken": StatusError})))
+	}
+
+	trends := Trends(entries)
+	if len(trends) != 3 {
+		t.Fatalf("got %d trends, want 3", len(trends))
+	}
+	if trends[0].Name != "flappy" || !trends[0].Flapping() || trends[0].Changes != 3 {
+		t.Errorf("first trend = %+v, want flapping 'flappy' with 3 changes", trends[0])
+	}
+	if trends[0].Runs != 4 || trends[0].Current != StatusError || len(trends[0].Recent) != 4 {
+		t.Errorf("flappy = %+v; skipped runs should not count", trends[0])
+	}
+	if trends[1].Name != "broken" || trends[2].Name != "steady" {
+		t.Errorf("order = %s, %s; want failing checks before passing ones", trends[1].Name, trends[2].Name)
+	}
+	if trends[2].Flapping() || trends[2].OK != 5 {
+		t.Errorf("steady = %+v", trends[2])
+	}
+}
@@ -33,6 +33,8 @@ func NewHookAttachmentValidCheck() *HookAttachmentValidCheck {
 				CheckName:        "hook-attachment-valid",
 				CheckDescription: "Verify attached molecules exist and are not closed",
 				CheckCategory:    CategoryHooks,
+				CheckDependsOn:   beadsCheckPrereqs,
+				CheckCost:        CostHeavy,
 			},
 		},
 	}
@@ -209,6 +211,8 @@ func NewHookSingletonCheck() *HookSingletonCheck {
 				CheckName:        "hook-singleton",
 				CheckDescription: "Ensure each agent has at most one handoff bead",
 				CheckCategory:    CategoryHooks,
+				CheckDependsOn:   beadsCheckPrereqs,
+				CheckCost:        CostHeavy,
 			},
 		},
 	}
@@ -349,6 +353,8 @@ func NewOrphanedAttachmentsCheck() *OrphanedAttachmentsCheck {
 			CheckName:        "orphaned-attachments",
 			CheckDescription: "Detect handoff beads for non-existent agents",
 			CheckCategory:    CategoryHooks,
+			CheckDependsOn:   beadsCheckPrereqs,
+			CheckCost:        CostHeavy,
 		},
 	}
 }
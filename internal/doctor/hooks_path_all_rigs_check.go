@@ -24,6 +24,7 @@ func NewHooksPathAllRigsCheck() *HooksPathAllRigsCheck {
 				CheckName:        "hooks-path-all-rigs",
 				CheckDescription: "Check core.hooksPath is set for all clones across all rigs",
 				CheckCategory:    CategoryRig,
+				CheckCost:        CostHeavy,
 			},
 		},
 	}
@@ -140,3 +140,13 @@ func (c *HooksSyncCheck) Fix(ctx *CheckContext) error {
 	}
 	return nil
 }
+
+// PlanFix lists the settings files Fix would rewrite.
+func (c *HooksSyncCheck) PlanFix(ctx *CheckContext, result *CheckResult) []PlannedChange {
+	var changes []PlannedChange
+	for _, target := range c.outOfSync {
+		changes = append(changes, PlannedChange{Kind: ChangeFile, Action: "write", Target: target.Path,
+			Note: "sync hooks for " + target.DisplayKey()})
+	}
+	return changes
+}
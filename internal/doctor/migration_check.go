@@ -31,6 +31,8 @@ func NewDoltMetadataCheck() *DoltMetadataCheck {
 				CheckName:        "dolt-metadata",
 				CheckDescription: "Check that metadata.json has Dolt server config",
 				CheckCategory:    CategoryConfig,
+				CheckDependsOn:   []string{"dolt-binary"},
+				CheckCost:        CostSubprocess,
 			},
 		},
 	}
@@ -243,6 +245,8 @@ func NewDoltServerReachableCheck() *DoltServerReachableCheck {
 			CheckName:        "dolt-server-reachable",
 			CheckDescription: "Check that Dolt server is reachable when server mode is configured",
 			CheckCategory:    CategoryInfrastructure,
+			CheckDependsOn:   []string{"daemon"},
+			CheckCost:        CostSubprocess,
 		},
 	}
 }
@@ -375,6 +379,8 @@ func NewDoltOrphanedDatabaseCheck() *DoltOrphanedDatabaseCheck {
 				CheckName:        "dolt-orphaned-databases",
 				CheckDescription: "Detect orphaned databases in .dolt-data/",
 				CheckCategory:    CategoryCleanup,
+				CheckDependsOn:   []string{"dolt-binary"},
+				CheckCost:        CostSubprocess,
 			},
 		},
 	}
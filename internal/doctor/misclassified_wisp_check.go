@@ -36,6 +36,8 @@ func NewCheckMisclassifiedWisps() *CheckMisclassifiedWisps {
 				CheckName:        "misclassified-wisps",
 				CheckDescription: "Detect issues that should be wisps but aren't marked as ephemeral",
 				CheckCategory:    CategoryCleanup,
+				CheckDependsOn:   beadsCheckPrereqs,
+				CheckCost:        CostHeavy,
 			},
 		},
 		misclassifiedRigs: make(map[string]int),
@@ -278,3 +280,13 @@ func (c *CheckMisclassifiedWisps) Fix(ctx *CheckContext) error {
 
 	return lastErr
 }
+
+// PlanFix lists the issues Fix would mark ephemeral.
+func (c *CheckMisclassifiedWisps) PlanFix(ctx *CheckContext, result *CheckResult) []PlannedChange {
+	var changes []PlannedChange
+	for _, wisp := range c.misclassified {
+		changes = append(changes, PlannedChange{Kind: ChangeBead, Action: "update", Target: wisp.id,
+			Note: "set ephemeral: " + wisp.reason})
+	}
+	return changes
+}
@@ -41,6 +41,7 @@ func NewOrphanSessionCheck() *OrphanSessionCheck {
 				CheckName:        "orphan-sessions",
 				CheckDescription: "Detect orphaned tmux sessions",
 				CheckCategory:    CategoryCleanup,
+				CheckCost:        CostHeavy,
 			},
 		},
 	}
@@ -147,7 +148,7 @@ func (c *OrphanSessionCheck) Fix(ctx *CheckContext) error {
 			continue
 		}
 		// Log pre-death event for crash investigation (before killing)
-		_ = events.LogFeed(events.TypeSessionDeath, sess,
+		_ = events.LogFeedIn(ctx.TownRoot, events.TypeSessionDeath, sess,
 			events.SessionDeathPayload(sess, "unknown", "orphan cleanup", "gt doctor"))
 		// Use KillSessionWithProcesses to ensure all descendant processes are killed.
 		if err := t.KillSessionWithProcesses(sess); err != nil {
@@ -158,6 +159,18 @@ func (c *OrphanSessionCheck) Fix(ctx *CheckContext) error {
 	return lastErr
 }
 
+// PlanFix lists the sessions Fix would kill. Crew sessions are never killed.
+func (c *OrphanSessionCheck) PlanFix(ctx *CheckContext, result *CheckResult) []PlannedChange {
+	var changes []PlannedChange
+	for _, sess := range c.orphanSessions {
+		if isCrewSession(sess) {
+			continue
+		}
+		changes = append(changes, PlannedChange{Kind: ChangeSession, Action: "kill", Target: sess, Note: "and its processes"})
+	}
+	return changes
+}
+
 // isCrewSession returns true if the session name matches the crew pattern.
 // Crew sessions are gt-<rig>-crew-<name> and are protected from auto-cleanup.
 func isCrewSession(sess string) bool {
@@ -290,6 +303,7 @@ func NewOrphanProcessCheck() *OrphanProcessCheck {
 			CheckName:        "orphan-processes",
 			CheckDescription: "Detect runtime processes outside tmux",
 			CheckCategory:    CategoryCleanup,
+			CheckCost:        CostHeavy,
 		},
 	}
 }
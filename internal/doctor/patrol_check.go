@@ -12,6 +12,7 @@ import (
 
 	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/deacon"
 )
 
 // PatrolMoleculesExistCheck verifies that patrol formulas are accessible.
@@ -29,6 +30,8 @@ func NewPatrolMoleculesExistCheck() *PatrolMoleculesExistCheck {
 			CheckName:        "patrol-molecules-exist",
 			CheckDescription: "Check if patrol formulas are accessible",
 			CheckCategory:    CategoryPatrol,
+			CheckDependsOn:   beadsCheckPrereqs,
+			CheckCost:        CostSubprocess,
 		},
 	}
 }
@@ -398,3 +401,55 @@ func discoverRigs(townRoot string) ([]string, error) {
 	}
 	return rigs, nil
 }
+
+// PatrolShadowStateCheck reports patrol shadow mode. Patrols treat an
+// unreadable shadow file as shadow mode, so a corrupt file quietly stops
+// every kill, nudge and nuke; that is an error here.
+type PatrolShadowStateCheck struct {
+	BaseCheck
+}
+
+// NewPatrolShadowStateCheck creates a new patrol shadow state check.
+func NewPatrolShadowStateCheck() *PatrolShadowStateCheck {
+	return &PatrolShadowStateCheck{
+		BaseCheck: BaseCheck{
+			CheckName:        "patrol-shadow-state",
+			CheckDescription: "Check whether patrols are held in shadow mode",
+			CheckCategory:    CategoryPatrol,
+		},
+	}
+}
+
+// Run checks the patrol shadow mode file.
+func (c *PatrolShadowStateCheck) Run(ctx *CheckContext) *CheckResult {
+	state, err := deacon.LoadShadowState(ctx.TownRoot)
+	if err != nil {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusError,
+			Message: "Shadow state unreadable - patrols are held in shadow mode",
+			Details: []string{err.Error()},
+			FixHint: fmt.Sprintf("Repair or remove %s", deacon.GetShadowFile(ctx.TownRoot)),
+		}
+	}
+
+	if state.Enabled {
+		details := []string{"Since " + state.StartedAt.Local().Format("2006-01-02 15:04")}
+		if state.Reason != "" {
+			details = append(details, "Reason: "+state.Reason)
+		}
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusWarning,
+			Message: "Patrols are in shadow mode and take no action",
+			Details: details,
+			FixHint: "Run 'gt patrol shadow off' when done comparing decisions",
+		}
+	}
+
+	return &CheckResult{
+		Name:    c.Name(),
+		Status:  StatusOK,
+		Message: "Patrols are acting (shadow mode off)",
+	}
+}
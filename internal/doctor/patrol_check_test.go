@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/deacon"
 )
 
 func TestNewPatrolHooksWiredCheck(t *testing.T) {
@@ -187,3 +188,28 @@ func TestPatrolHooksWiredCheck_FixPreservesExisting(t *testing.T) {
 		t.Error("existing custom patrol was overwritten")
 	}
 }
+
+func TestPatrolShadowStateCheck(t *testing.T) {
+	townRoot := t.TempDir()
+	check := NewPatrolShadowStateCheck()
+	ctx := &CheckContext{TownRoot: townRoot}
+
+	if result := check.Run(ctx); result.Status != StatusOK {
+		t.Errorf("no shadow file: Status = %v, want OK", result.Status)
+	}
+
+	if err := deacon.EnableShadow(townRoot, "trying thresholds", "human"); err != nil {
+		t.Fatal(err)
+	}
+	if result := check.Run(ctx); result.Status != StatusWarning {
+		t.Errorf("shadow on: Status = %v, want Warning", result.Status)
+	}
+
+	if err := os.WriteFile(deacon.GetShadowFile(townRoot), []byte("{not json"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	result := check.Run(ctx)
+	if result.Status != StatusError || len(result.Details) == 0 || result.FixHint == "" {
+		t.Errorf("unreadable shadow file: result = %+v, want Error with details and a fix hint", result)
+	}
+}
@@ -0,0 +1,132 @@
+package doctor
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/ui"
+)
+
+// ChangeKind classifies what a planned fix touches.
+type ChangeKind string
+
+const (
+	ChangeFile    ChangeKind = "file"
+	ChangeBead    ChangeKind = "bead"
+	ChangeSession ChangeKind = "session"
+	ChangeGit     ChangeKind = "git"
+	ChangeCommand ChangeKind = "command"
+)
+
+// PlannedChange is one change a fix would make.
+type PlannedChange struct {
+	Kind   ChangeKind `json:"kind"`
+	Action string     `json:"action"` // e.g. "delete", "write", "kill", "close"
+	Target string     `json:"target"` // path, bead ID, session name or command
+	Note   string     `json:"note,omitempty"`
+}
+
+// FixPlanner is implemented by fixable checks that can describe their fix
+// without applying it. PlanFix is called after Run on the same check, with
+// Run's result, and must not change anything.
+type FixPlanner interface {
+	PlanFix(ctx *CheckContext, result *CheckResult) []PlannedChange
+}
+
+// FixPlan is what `gt doctor --fix` would do for one failing check.
+type FixPlan struct {
+	Check    string          `json:"check"`
+	Category string          `json:"category,omitempty"`
+	Status   CheckStatus     `json:"status"`
+	Message  string          `json:"message,omitempty"`
+	Exact    bool            `json:"exact"` // false: the check can't itemize its fix
+	Changes  []PlannedChange `json:"changes"`
+	Details  []string        `json:"details,omitempty"` // findings, when not exact
+}
+
+// PlanFixes runs all checks without fixing and returns, for each failing
+// fixable check, the changes its fix would make. Checks that don't
+// implement FixPlanner get an inexact plan listing their findings; see
+// Unpreviewable.
+// Checks skipped because a prerequisite failed aren't planned: what they
+// would fix depends on the prerequisite's fix.
+func (d *Doctor) PlanFixes(ctx *CheckContext, w io.Writer, slowThreshold time.Duration) (*Report, []*FixPlan) {
+	report := d.execute(ctx, w, slowThreshold, false)
+
+	var plans []*FixPlan
+	for i, check := range d.checks {
+		result := report.Checks[i]
+		if result.Status == StatusOK || result.Status == StatusSkipped || !check.CanFix() {
+			continue
+		}
+		plan := &FixPlan{
+			Check:    result.Name,
+			Category: result.Category,
+			Status:   result.Status,
+			Message:  result.Message,
+			Changes:  []PlannedChange{},
+		}
+		if planner, ok := check.(FixPlanner); ok {
+			plan.Exact = true
+			plan.Changes = append(plan.Changes, planner.PlanFix(ctx, result)...)
+		} else {
+			plan.Details = result.Details
+		}
+		plans = append(plans, plan)
+	}
+	return report, plans
+}
+
+// Unpreviewable returns the checks whose plans are inexact: their fix
+// would make changes the plan doesn't list.
+func Unpreviewable(plans []*FixPlan) []string {
+	var names []string
+	for _, plan := range plans {
+		if !plan.Exact {
+			names = append(names, plan.Check)
+		}
+	}
+	return names
+}
+
+// PrintFixPlans prints fix plans for humans.
+func PrintFixPlans(w io.Writer, plans []*FixPlan) {
+	_, _ = fmt.Fprintln(w)
+	if len(plans) == 0 {
+		_, _ = fmt.Fprintln(w, ui.RenderMuted("Nothing to fix."))
+		return
+	}
+	_, _ = fmt.Fprintln(w, ui.RenderCategory("Fix plan (nothing was changed)"))
+	for _, plan := range plans {
+		icon := ui.RenderWarnIcon()
+		if plan.Status == StatusError {
+			icon = ui.RenderFailIcon()
+		}
+		_, _ = fmt.Fprintf(w, "  %s  %s", icon, plan.Check)
+		if plan.Message != "" {
+			_, _ = fmt.Fprintf(w, "%s", ui.RenderMuted(" "+plan.Message))
+		}
+		_, _ = fmt.Fprintln(w)
+
+		switch {
+		case !plan.Exact:
+			_, _ = fmt.Fprintf(w, "     %s%s %s\n", ui.MutedStyle.Render(ui.TreeLast), ui.RenderFailIcon(),
+				"fix can't be previewed; its changes are not listed. It acts on:")
+			for _, detail := range plan.Details {
+				_, _ = fmt.Fprintf(w, "       %s\n", ui.RenderMuted(detail))
+			}
+		case len(plan.Changes) == 0:
+			_, _ = fmt.Fprintf(w, "     %s%s\n", ui.MutedStyle.Render(ui.TreeLast),
+				ui.RenderMuted("no changes (needs manual action)"))
+		default:
+			for _, c := range plan.Changes {
+				line := fmt.Sprintf("%-7s %-6s %s", c.Action, c.Kind, c.Target)
+				if c.Note != "" {
+					line += "  (" + c.Note + ")"
+				}
+				_, _ = fmt.Fprintf(w, "     %s%s\n", ui.MutedStyle.Render(ui.TreeLast), line)
+			}
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package doctor
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// plannedCheck is a fixable mock that itemizes its fix.
+type plannedCheck struct {
+	mockCheck
+	changes []PlannedChange
+}
+
+func (c *plannedCheck) PlanFix(ctx *CheckContext, result *CheckResult) []PlannedChange {
+	return c.changes
+}
+
+func TestDoctor_PlanFixes(t *testing.T) {
+	planned := &plannedCheck{mockCheck: *newMockCheck("planned", StatusWarning),
+		changes: []PlannedChange{{Kind: ChangeFile, Action: "delete", Target: "/tmp/x"}}}
+	planned.fixable = true
+
+	inexact := newMockCheck("inexact", StatusError)
+	inexact.fixable = true
+
+	healthy := newMockCheck("healthy", StatusOK)
+	healthy.fixable = true
+
+	manual := newMockCheck("manual", StatusError) // not fixable
+
+	dependent := newMockCheck("dependent", StatusWarning)
+	dependent.fixable = true
+	dependent.CheckDependsOn = []string{"manual"}
+
+	d := NewDoctor()
+	d.RegisterAll(planned, inexact, healthy, manual, dependent)
+	report, plans := d.PlanFixes(&CheckContext{TownRoot: t.TempDir()}, nil, 0)
+
+	if report.Summary.Total != 5 || report.Summary.Skipped != 1 {
+		t.Errorf("summary = %+v", report.Summary)
+	}
+	for _, c := range []*mockCheck{&planned.mockCheck, inexact, healthy, dependent} {
+		if c.fixCount != 0 {
+			t.Errorf("%s was fixed during planning", c.CheckName)
+		}
+	}
+
+	if len(plans) != 2 {
+		t.Fatalf("got %d plans, want 2: %+v", len(plans), plans)
+	}
+	if plans[0].Check != "planned" || !plans[0].Exact || !reflect.DeepEqual(plans[0].Changes, planned.changes) {
+		t.Errorf("plans[0] = %+v", plans[0])
+	}
+	if plans[1].Check != "inexact" || plans[1].Exact || plans[1].Changes == nil {
+		t.Errorf("plans[1] = %+v", plans[1])
+	}
+	if got := Unpreviewable(plans); !reflect.DeepEqual(got, []string{"inexact"}) {
+		t.Errorf("Unpreviewable() = %v, want [inexact]", got)
+	}
+}
+
+func TestOrphanSessionCheck_PlanFix_KeepsCrew(t *testing.T) {
+	setupTestRegistry(t)
+	check := NewOrphanSessionCheck()
+	check.orphanSessions = []string{"gt-crew-joe", "gt-oldpolecat"}
+
+	changes := check.PlanFix(&CheckContext{}, &CheckResult{})
+	want := []PlannedChange{{Kind: ChangeSession, Action: "kill", Target: "gt-oldpolecat", Note: "and its processes"}}
+	if !reflect.DeepEqual(changes, want) {
+		t.Errorf("PlanFix() = %+v, want %+v", changes, want)
+	}
+}
+
+func TestStaleAgentBeadsCheck_PlanFix(t *testing.T) {
+	check := NewStaleAgentBeadsCheck()
+	changes := check.PlanFix(&CheckContext{}, &CheckResult{Details: []string{"gt-gastown-crew-old"}})
+	if len(changes) != 1 || changes[0].Kind != ChangeBead || changes[0].Action != "close" || changes[0].Target != "gt-gastown-crew-old" {
+		t.Errorf("PlanFix() = %+v", changes)
+	}
+}
+
+func TestDeprecatedMergeQueueKeysCheck_PlanFix_DoesNotWrite(t *testing.T) {
+	town := t.TempDir()
+	rig := filepath.Join(town, "myrig")
+	settings := filepath.Join(rig, "settings", "config.json")
+	if err := os.MkdirAll(filepath.Join(rig, "crew"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Dir(settings), 0755); err != nil {
+		t.Fatal(err)
+	}
+	original := []byte(`{"merge_queue": {"target_branch": "main", "integration_branches": true}}`)
+	if err := os.WriteFile(settings, original, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	check := NewDeprecatedMergeQueueKeysCheck()
+	ctx := &CheckContext{TownRoot: town}
+	result := check.Run(ctx)
+	if result.Status == StatusOK {
+		t.Fatalf("expected deprecated keys to be found: %+v", result)
+	}
+	changes := check.PlanFix(ctx, result)
+	if len(changes) != 1 || changes[0].Target != settings || changes[0].Action != "write" {
+		t.Errorf("PlanFix() = %+v", changes)
+	}
+	if data, _ := os.ReadFile(settings); string(data) != string(original) {
+		t.Errorf("PlanFix modified settings: %s", data)
+	}
+}
@@ -0,0 +1,291 @@
+package doctor
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Plugin checks are executables dropped into <town>/doctor.d/ or
+// <rig>/doctor.d/. Each run prints one JSON object on stdout:
+//
+//	{"status": "warning", "message": "codegen is stale",
+//	 "details": ["api/gen.go"], "fix_hint": "run make generate"}
+//
+// status is one of ok, warning or error. A plugin that declares itself
+// fixable is invoked with --fix by `gt doctor --fix` and reports success
+// through its exit code.
+//
+// Optional metadata is read from "gt-doctor-<key>: value" lines in the
+// first few KB of the file, usually as comments in a script header:
+//
+//	# gt-doctor-description: Generated code is up to date
+//	# gt-doctor-category: Rig
+//	# gt-doctor-timeout: 2m
+//	# gt-doctor-fixable: true
+//	# gt-doctor-depends-on: rig-is-git-repo
+
+// PluginDir is the directory name scanned for plugin checks.
+const PluginDir = "doctor.d"
+
+// DefaultPluginTimeout bounds a plugin run (or fix) that sets no timeout.
+const DefaultPluginTimeout = 30 * time.Second
+
+// pluginHeaderBytes is how much of a plugin is scanned for metadata.
+const pluginHeaderBytes = 4096
+
+// PluginCheck runs an external executable as a doctor check.
+type PluginCheck struct {
+	BaseCheck
+	Path    string        // Executable to run
+	Dir     string        // Working directory (town root or rig)
+	Rig     string        // Rig name, empty for town plugins
+	Timeout time.Duration // Per-invocation timeout
+	Fixable bool          // Plugin supports --fix
+}
+
+// pluginOutput is the JSON a plugin prints on stdout.
+type pluginOutput struct {
+	Status  *CheckStatus `json:"status"`
+	Message string       `json:"message"`
+	Details []string     `json:"details"`
+	FixHint string       `json:"fix_hint"`
+}
+
+// DiscoverPluginChecks returns plugin checks from the town's doctor.d and
+// from each rig's doctor.d. If rigName is set, only that rig is scanned.
+// Town plugins are named after their file (minus extension), rig plugins
+// are prefixed with the rig name ("myrig/codegen"). Plugins that can't be
+// read are reported as warnings on stderr and skipped.
+func DiscoverPluginChecks(townRoot, rigName string) []Check {
+	checks := scanPluginDir(townRoot, "")
+
+	var rigPaths []string
+	if rigName != "" {
+		rigPaths = []string{filepath.Join(townRoot, rigName)}
+	} else {
+		rigPaths = findAllRigs(townRoot)
+	}
+	for _, rigPath := range rigPaths {
+		checks = append(checks, scanPluginDir(rigPath, filepath.Base(rigPath))...)
+	}
+	return checks
+}
+
+// scanPluginDir loads the executables in dir/doctor.d, sorted by name.
+func scanPluginDir(dir, rig string) []Check {
+	pluginDir := filepath.Join(dir, PluginDir)
+	entries, err := os.ReadDir(pluginDir)
+	if err != nil {
+		return nil
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+
+	var checks []Check
+	for _, entry := range entries {
+		name := entry.Name()
+		if strings.HasPrefix(name, ".") || entry.IsDir() {
+			continue
+		}
+		path := filepath.Join(pluginDir, name)
+		info, err := os.Stat(path) // follow symlinks
+		if err != nil || !info.Mode().IsRegular() || info.Mode().Perm()&0111 == 0 {
+			continue
+		}
+		check, err := loadPluginCheck(path, dir, rig)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: skipping doctor plugin %s: %v\n", path, err)
+			continue
+		}
+		checks = append(checks, check)
+	}
+	return checks
+}
+
+// loadPluginCheck builds a PluginCheck from an executable and its header.
+func loadPluginCheck(path, dir, rig string) (*PluginCheck, error) {
+	base := filepath.Base(path)
+	name := strings.TrimSuffix(base, filepath.Ext(base))
+	category := CategoryCore
+	if rig != "" {
+		name = rig + "/" + name
+		category = CategoryRig
+	}
+	check := &PluginCheck{
+		BaseCheck: BaseCheck{
+			CheckName:        name,
+			CheckDescription: "Plugin check " + base,
+			CheckCategory:    category,
+			CheckCost:        CostSubprocess,
+		},
+		Path:    path,
+		Dir:     dir,
+		Rig:     rig,
+		Timeout: DefaultPluginTimeout,
+	}
+
+	header, err := readPluginHeader(path)
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range header {
+		switch key {
+		case "description":
+			check.CheckDescription = value
+		case "category":
+			check.CheckCategory = value
+		case "timeout":
+			d, err := time.ParseDuration(value)
+			if err != nil || d <= 0 {
+				return nil, fmt.Errorf("invalid gt-doctor-timeout %q", value)
+			}
+			check.Timeout = d
+		case "fixable":
+			fixable, err := strconv.ParseBool(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid gt-doctor-fixable %q", value)
+			}
+			check.Fixable = fixable
+		case "depends-on":
+			for _, dep := range strings.Split(value, ",") {
+				if dep = strings.TrimSpace(dep); dep != "" {
+					check.CheckDependsOn = append(check.CheckDependsOn, dep)
+				}
+			}
+		}
+	}
+	return check, nil
+}
+
+// readPluginHeader collects "gt-doctor-<key>: value" lines from the start
+// of a plugin file.
+func readPluginHeader(path string) (map[string]string, error) {
+	f, err := os.Open(path) //nolint:gosec // G304: path comes from doctor.d
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	header := make(map[string]string)
+	scanner := bufio.NewScanner(io.LimitReader(f, pluginHeaderBytes))
+	for scanner.Scan() {
+		line := scanner.Text()
+		idx := strings.Index(line, "gt-doctor-")
+		if idx < 0 {
+			continue
+		}
+		key, value, ok := strings.Cut(line[idx+len("gt-doctor-"):], ":")
+		if !ok {
+			continue
+		}
+		header[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	// A long binary "line" just ends the scan; it's not an error.
+	if err := scanner.Err(); err != nil && !errors.Is(err, bufio.ErrTooLong) {
+		return nil, err
+	}
+	return header, nil
+}
+
+// CanFix reports whether the plugin declared gt-doctor-fixable.
+func (c *PluginCheck) CanFix() bool {
+	return c.Fixable
+}
+
+// Run executes the plugin and converts its JSON output into a result.
+func (c *PluginCheck) Run(ctx *CheckContext) *CheckResult {
+	result := &CheckResult{Name: c.Name(), Category: c.CheckCategory}
+
+	stdout, stderr, err := c.invoke(ctx)
+	var out pluginOutput
+	if jsonErr := json.Unmarshal(bytes.TrimSpace(stdout), &out); jsonErr != nil || out.Status == nil {
+		result.Status = StatusError
+		switch {
+		case errors.Is(err, context.DeadlineExceeded):
+			result.Message = fmt.Sprintf("plugin timed out after %s", c.Timeout)
+		case err != nil:
+			result.Message = fmt.Sprintf("plugin failed: %v", err)
+		case jsonErr != nil:
+			result.Message = fmt.Sprintf("plugin output is not valid JSON: %v", jsonErr)
+		default:
+			result.Message = "plugin output has no status"
+		}
+		result.Details = tailLines(stderr, 5)
+		result.FixHint = "Plugin " + c.Path + " must print a JSON result on stdout"
+		return result
+	}
+
+	result.Status = *out.Status
+	result.Message = out.Message
+	result.Details = out.Details
+	result.FixHint = out.FixHint
+	if result.Status == StatusSkipped {
+		// Skipped is reserved for checks blocked by a failed prerequisite
+		result.Status = StatusWarning
+	}
+	return result
+}
+
+// Fix runs the plugin with --fix. A non-zero exit means the fix failed.
+func (c *PluginCheck) Fix(ctx *CheckContext) error {
+	if !c.Fixable {
+		return ErrCannotFix
+	}
+	_, stderr, err := c.invoke(ctx, "--fix")
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("timed out after %s", c.Timeout)
+	}
+	if tail := tailLines(stderr, 1); len(tail) > 0 {
+		return fmt.Errorf("%w: %s", err, tail[0])
+	}
+	return err
+}
+
+// invoke runs the plugin with a timeout from its town or rig directory.
+// A non-zero exit is returned as an error alongside whatever it printed;
+// plugins may exit 1 while still reporting a well-formed result.
+func (c *PluginCheck) invoke(ctx *CheckContext, args ...string) (stdout, stderr []byte, err error) {
+	runCtx, cancel := context.WithTimeout(context.Background(), c.Timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(runCtx, c.Path, args...) //nolint:gosec // G204: plugins are trusted town content
+	cmd.Dir = c.Dir
+	cmd.Env = append(os.Environ(), "GT_TOWN_ROOT="+ctx.TownRoot, "GT_RIG="+c.Rig)
+	cmd.WaitDelay = time.Second // don't hang on grandchildren holding stdout
+	var outBuf, errBuf bytes.Buffer
+	cmd.Stdout = &outBuf
+	cmd.Stderr = &errBuf
+	err = cmd.Run()
+	if runCtx.Err() == context.DeadlineExceeded {
+		err = context.DeadlineExceeded
+	}
+	return outBuf.Bytes(), errBuf.Bytes(), err
+}
+
+// tailLines returns the last n non-empty lines of output.
+func tailLines(output []byte, n int) []string {
+	var lines []string
+	for _, line := range strings.Split(string(output), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return lines
+}
@@ -0,0 +1,230 @@
+package doctor
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writePlugin(t *testing.T, dir, name, script string) string {
+	t.Helper()
+	pluginDir := filepath.Join(dir, PluginDir)
+	if err := os.MkdirAll(pluginDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(pluginDir, name)
+	if err := os.WriteFile(path, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func skipPluginsOnWindows(t *testing.T) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("plugin tests use shell scripts")
+	}
+}
+
+func TestDiscoverPluginChecks(t *testing.T) {
+	skipPluginsOnWindows(t)
+	town := t.TempDir()
+	rig := filepath.Join(town, "myrig")
+	if err := os.MkdirAll(filepath.Join(rig, "crew"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	writePlugin(t, town, "env-files.sh", "#!/bin/sh\n# gt-doctor-description: Env files present\necho '{}'\n")
+	writePlugin(t, rig, "codegen", "#!/bin/sh\n# gt-doctor-timeout: 2m\n# gt-doctor-fixable: true\n# gt-doctor-depends-on: rig-is-git-repo\necho '{}'\n")
+	// Not executable, dotfile, subdirectory: all ignored
+	if err := os.WriteFile(filepath.Join(town, PluginDir, "README"), []byte("docs"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	writePlugin(t, town, ".hidden", "#!/bin/sh\n")
+	if err := os.MkdirAll(filepath.Join(town, PluginDir, "lib"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	checks := DiscoverPluginChecks(town, "")
+	if len(checks) != 2 {
+		t.Fatalf("got %d checks, want 2", len(checks))
+	}
+
+	townCheck := checks[0].(*PluginCheck)
+	if townCheck.Name() != "env-files" || townCheck.Description() != "Env files present" {
+		t.Errorf("town plugin = %q %q", townCheck.Name(), townCheck.Description())
+	}
+	if townCheck.Category() != CategoryCore || townCheck.Timeout != DefaultPluginTimeout || townCheck.CanFix() {
+		t.Errorf("town plugin defaults wrong: %+v", townCheck)
+	}
+
+	rigCheck := checks[1].(*PluginCheck)
+	if rigCheck.Name() != "myrig/codegen" || rigCheck.Category() != CategoryRig {
+		t.Errorf("rig plugin = %q in %q", rigCheck.Name(), rigCheck.Category())
+	}
+	if rigCheck.Timeout != 2*time.Minute || !rigCheck.CanFix() || rigCheck.Dir != rig {
+		t.Errorf("rig plugin header not applied: %+v", rigCheck)
+	}
+	if deps := rigCheck.DependsOn(); len(deps) != 1 || deps[0] != "rig-is-git-repo" {
+		t.Errorf("DependsOn() = %v", deps)
+	}
+
+	// --rig limits the scan to that rig
+	if got := DiscoverPluginChecks(town, "other"); len(got) != 1 {
+		t.Errorf("with rig filter got %d checks, want 1 (town only)", len(got))
+	}
+}
+
+func TestPluginCheck_Run(t *testing.T) {
+	skipPluginsOnWindows(t)
+	tests := []struct {
+		name        string
+		script      string
+		wantStatus  CheckStatus
+		wantMessage string
+	}{
+		{
+			name:        "ok",
+			script:      `echo '{"status": "ok", "message": "all good"}'`,
+			wantStatus:  StatusOK,
+			wantMessage: "all good",
+		},
+		{
+			name:        "warning with non-zero exit",
+			script:      `echo '{"status": "warning", "message": "stale", "details": ["a.go"], "fix_hint": "make gen"}'; exit 1`,
+			wantStatus:  StatusWarning,
+			wantMessage: "stale",
+		},
+		{
+			name:        "invalid json",
+			script:      `echo 'not json'; echo 'boom' >&2`,
+			wantStatus:  StatusError,
+			wantMessage: "not valid JSON",
+		},
+		{
+			name:        "missing status",
+			script:      `echo '{"message": "hi"}'`,
+			wantStatus:  StatusError,
+			wantMessage: "no status",
+		},
+		{
+			name:        "env",
+			script:      `echo "{\"status\": \"ok\", \"message\": \"$GT_RIG@$(basename $GT_TOWN_ROOT)\"}"`,
+			wantStatus:  StatusOK,
+			wantMessage: "myrig@",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			town := t.TempDir()
+			rig := filepath.Join(town, "myrig")
+			path := writePlugin(t, rig, "check", "#!/bin/sh\n"+tt.script+"\n")
+			check, err := loadPluginCheck(path, rig, "myrig")
+			if err != nil {
+				t.Fatal(err)
+			}
+			result := check.Run(&CheckContext{TownRoot: town})
+			if result.Status != tt.wantStatus {
+				t.Errorf("Status = %v, want %v (%s)", result.Status, tt.wantStatus, result.Message)
+			}
+			if !strings.Contains(result.Message, tt.wantMessage) {
+				t.Errorf("Message = %q, want it to contain %q", result.Message, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestPluginCheck_RunDetailsAndStderr(t *testing.T) {
+	skipPluginsOnWindows(t)
+	town := t.TempDir()
+	path := writePlugin(t, town, "check", "#!/bin/sh\necho '{\"status\": \"error\", \"details\": [\"x\"], \"fix_hint\": \"do y\"}'\n")
+	check, err := loadPluginCheck(path, town, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := check.Run(&CheckContext{TownRoot: town})
+	if len(result.Details) != 1 || result.Details[0] != "x" || result.FixHint != "do y" {
+		t.Errorf("result = %+v", result)
+	}
+
+	path = writePlugin(t, town, "broken", "#!/bin/sh\necho 'line one' >&2\necho 'cannot stat .env' >&2\nexit 3\n")
+	check, err = loadPluginCheck(path, town, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	result = check.Run(&CheckContext{TownRoot: town})
+	if result.Status != StatusError || !strings.Contains(result.Message, "exit status 3") {
+		t.Errorf("result = %+v", result)
+	}
+	if len(result.Details) == 0 || result.Details[len(result.Details)-1] != "cannot stat .env" {
+		t.Errorf("Details = %v, want stderr tail", result.Details)
+	}
+}
+
+func TestPluginCheck_Timeout(t *testing.T) {
+	skipPluginsOnWindows(t)
+	town := t.TempDir()
+	path := writePlugin(t, town, "slow", "#!/bin/sh\nsleep 5\n")
+	check, err := loadPluginCheck(path, town, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	check.Timeout = 100 * time.Millisecond
+
+	start := time.Now()
+	result := check.Run(&CheckContext{TownRoot: town})
+	if result.Status != StatusError || !strings.Contains(result.Message, "timed out") {
+		t.Errorf("result = %+v", result)
+	}
+	if elapsed := time.Since(start); elapsed > 3*time.Second {
+		t.Errorf("timeout took %s", elapsed)
+	}
+}
+
+func TestPluginCheck_Fix(t *testing.T) {
+	skipPluginsOnWindows(t)
+	town := t.TempDir()
+	script := `#!/bin/sh
+# gt-doctor-fixable: true
+if [ "$1" = "--fix" ]; then touch fixed; exit 0; fi
+if [ -f fixed ]; then echo '{"status": "ok"}'; else echo '{"status": "error", "message": "unfixed"}'; fi
+`
+	writePlugin(t, town, "fixme", script)
+	checks := DiscoverPluginChecks(town, "")
+	if len(checks) != 1 {
+		t.Fatalf("got %d checks", len(checks))
+	}
+
+	d := NewDoctor()
+	d.RegisterAll(checks...)
+	report := d.Fix(&CheckContext{TownRoot: town})
+	if len(report.Checks) != 1 || !report.Checks[0].Fixed {
+		t.Fatalf("expected plugin to be fixed, got %+v", report.Checks[0])
+	}
+}
+
+func TestPluginCheck_FixFailure(t *testing.T) {
+	skipPluginsOnWindows(t)
+	town := t.TempDir()
+	path := writePlugin(t, town, "nofix", "#!/bin/sh\n# gt-doctor-fixable: yes-please\n")
+	if _, err := loadPluginCheck(path, town, ""); err == nil {
+		t.Error("expected invalid fixable header to be rejected")
+	}
+
+	path = writePlugin(t, town, "failfix", "#!/bin/sh\necho 'permission denied' >&2\nexit 1\n")
+	check, err := loadPluginCheck(path, town, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := check.Fix(&CheckContext{TownRoot: town}); err != ErrCannotFix {
+		t.Errorf("Fix() on non-fixable plugin = %v, want ErrCannotFix", err)
+	}
+	check.Fixable = true
+	err = check.Fix(&CheckContext{TownRoot: town})
+	if err == nil || !strings.Contains(err.Error(), "permission denied") {
+		t.Errorf("Fix() = %v, want stderr in error", err)
+	}
+}
@@ -0,0 +1,105 @@
+package doctor
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/redact"
+)
+
+// RedactionCheck reports secrets scrubbed from mail, events, telemetry and
+// the dashboard. A recent redaction means an agent printed a credential,
+// which usually deserves a look (and a key rotation) even though it never
+// reached disk. Also flags user redaction patterns that fail to compile.
+type RedactionCheck struct {
+	BaseCheck
+}
+
+// NewRedactionCheck creates a new redaction check.
+func NewRedactionCheck() *RedactionCheck {
+	return &RedactionCheck{
+		BaseCheck: BaseCheck{
+			CheckName:        "secret-redaction",
+			CheckDescription: "Check for secrets redacted from agent output",
+			CheckCategory:    CategoryConfig,
+		},
+	}
+}
+
+// redactionWindow is how recent a redaction must be to warrant a warning.
+const redactionWindow = 24 * time.Hour
+
+// Run loads redaction settings and stats for the town.
+func (c *RedactionCheck) Run(ctx *CheckContext) *CheckResult {
+	var details []string
+	status := StatusOK
+
+	settings, err := config.LoadOrCreateTownSettings(config.TownSettingsPath(ctx.TownRoot))
+	if err == nil {
+		if invalid := redact.New(settings.Redaction).Invalid; len(invalid) > 0 {
+			status = StatusWarning
+			for _, inv := range invalid {
+				details = append(details, "Invalid redaction pattern "+inv)
+			}
+		}
+	}
+
+	stats, err := redact.LoadStats(ctx.TownRoot)
+	if err != nil {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusWarning,
+			Message: "Could not read redaction stats",
+			Details: []string{err.Error()},
+		}
+	}
+
+	message := "No secrets redacted"
+	if stats.Total > 0 {
+		message = fmt.Sprintf("%d secret(s) redacted since %s", stats.Total, stats.First.Local().Format("2006-01-02"))
+		if time.Since(stats.Last) < redactionWindow {
+			status = StatusWarning
+			message = fmt.Sprintf("%d secret(s) redacted, most recently %s ago",
+				stats.Total, time.Since(stats.Last).Round(time.Minute))
+			details = append(details,
+				"By sink: "+formatCounts(stats.BySink),
+				"By detector: "+formatCounts(stats.ByDetector))
+		}
+	}
+	if stats.Total == 0 && status == StatusWarning {
+		message = "Invalid redaction patterns in town settings"
+	}
+
+	result := &CheckResult{
+		Name:    c.Name(),
+		Status:  status,
+		Message: message,
+		Details: details,
+	}
+	if status == StatusWarning {
+		result.FixHint = "Rotate any exposed credentials; fix patterns under \"redaction\" in settings/config.json"
+	}
+	return result
+}
+
+// formatCounts renders a count map as "a=3, b=1", largest first.
+func formatCounts(m map[string]int) string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if m[keys[i]] != m[keys[j]] {
+			return m[keys[i]] > m[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = fmt.Sprintf("%s=%d", k, m[k])
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,54 @@
+package doctor
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/redact"
+)
+
+func TestRedactionCheck_NoRedactions(t *testing.T) {
+	town := t.TempDir()
+	result := NewRedactionCheck().Run(&CheckContext{TownRoot: town})
+	if result.Status != StatusOK {
+		t.Errorf("Status = %v, want OK (%s)", result.Status, result.Message)
+	}
+}
+
+func TestRedactionCheck_RecentRedactionWarns(t *testing.T) {
+	town := t.TempDir()
+	if err := redact.Record(town, redact.SinkMail, redact.Counts{"github-token": 2}); err != nil {
+		t.Fatal(err)
+	}
+	result := NewRedactionCheck().Run(&CheckContext{TownRoot: town})
+	if result.Status != StatusWarning {
+		t.Fatalf("Status = %v, want Warning (%s)", result.Status, result.Message)
+	}
+	if len(result.Details) != 2 || result.Details[0] != "By sink: mail=2" {
+		t.Errorf("Details = %v", result.Details)
+	}
+}
+
+func TestRedactionCheck_InvalidPattern(t *testing.T) {
+	town := t.TempDir()
+	settings := map[string]interface{}{
+		"type":      "town-settings",
+		"version":   1,
+		"redaction": map[string]interface{}{"patterns": map[string]string{"bad": "(["}},
+	}
+	data, _ := json.Marshal(settings)
+	path := filepath.Join(town, "settings", "config.json")
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	result := NewRedactionCheck().Run(&CheckContext{TownRoot: town})
+	if result.Status != StatusWarning {
+		t.Errorf("Status = %v, want Warning for invalid pattern (%s)", result.Status, result.Message)
+	}
+}
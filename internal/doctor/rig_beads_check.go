@@ -25,6 +25,8 @@ func NewRigBeadsCheck() *RigBeadsCheck {
 				CheckName:        "rig-beads-exist",
 				CheckDescription: "Verify rig identity beads exist for all rigs",
 				CheckCategory:    CategoryRig,
+				CheckDependsOn:   beadsCheckPrereqs,
+				CheckCost:        CostSubprocess,
 			},
 		},
 	}
@@ -674,6 +674,7 @@ func NewPolecatClonesValidCheck() *PolecatClonesValidCheck {
 			CheckName:        "polecat-clones-valid",
 			CheckDescription: "Verify polecat directories are valid git clones",
 			CheckCategory:    CategoryRig,
+			CheckCost:        CostHeavy,
 		},
 	}
 }
@@ -809,6 +810,7 @@ func NewBeadsConfigValidCheck() *BeadsConfigValidCheck {
 				CheckName:        "beads-config-valid",
 				CheckDescription: "Verify beads configuration if .beads/ exists",
 				CheckCategory:    CategoryRig,
+				CheckDependsOn:   beadsCheckPrereqs,
 			},
 		},
 	}
@@ -1285,6 +1287,7 @@ func NewDefaultBranchAllRigsCheck() *DefaultBranchAllRigsCheck {
 			CheckName:        "default-branch-all-rigs",
 			CheckDescription: "Verify default_branch exists on remote for all rigs",
 			CheckCategory:    CategoryRig,
+			CheckCost:        CostHeavy,
 		},
 	}
 }
@@ -26,6 +26,8 @@ func NewRoutingModeCheck() *RoutingModeCheck {
 				CheckName:        "routing-mode",
 				CheckDescription: "Check beads routing.mode is explicit (prevents .beads-planning routing)",
 				CheckCategory:    CategoryConfig,
+				CheckDependsOn:   beadsCheckPrereqs,
+				CheckCost:        CostSubprocess,
 			},
 		},
 	}
@@ -0,0 +1,434 @@
+package doctor
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/ui"
+)
+
+// DefaultWorkers is how many checks run at once by default. Most checks
+// spend their time waiting on bd, git or tmux rather than on CPU.
+const DefaultWorkers = 8
+
+// dependencyGetter interface for checks that declare prerequisites
+type dependencyGetter interface {
+	DependsOn() []string
+}
+
+// costGetter interface for checks that provide a cost hint
+type costGetter interface {
+	Cost() CheckCost
+}
+
+// checkEvent is sent by a worker when its check finishes.
+type checkEvent struct {
+	idx    int
+	result *CheckResult
+}
+
+// execute runs all checks as a dependency graph: a check starts once its
+// prerequisites have finished, with up to d.workers checks in flight.
+// A check whose prerequisite errored (or was itself skipped) is skipped.
+// Output and report order always follow registration order.
+//
+// With fix set, fixing is a separate phase that starts once every check has
+// run, so no fix changes state under a check that is still running. Fixes
+// are applied one at a time in dependency order (registration order among
+// independent checks). A check skipped for a prerequisite that then gets
+// fixed, or that depends on a check that was fixed, is re-run first.
+func (d *Doctor) execute(ctx *CheckContext, w io.Writer, slowThreshold time.Duration, fix bool) *Report {
+	report := NewReport()
+	n := len(d.checks)
+	if n == 0 {
+		return report
+	}
+
+	workers := d.workers
+	if workers <= 0 {
+		workers = DefaultWorkers
+	}
+
+	deps, dependents := d.dependencyGraph()
+	waiting := make([]int, n) // unfinished prerequisites per check
+	var ready []int
+	for i := range d.checks {
+		waiting[i] = len(deps[i])
+		if waiting[i] == 0 {
+			ready = append(ready, i)
+		}
+	}
+
+	out := &streamPrinter{w: w, checks: d.checks, slowThreshold: slowThreshold, report: report,
+		results: make([]*CheckResult, n), started: make([]bool, n), fixing: make(map[int]*CheckResult)}
+
+	// In fix mode a result is held for the fix phase if the check may be
+	// fixed, was skipped for a prerequisite, or depends on a held check.
+	held := make([]bool, n)
+	depSkipped := make([]bool, n)
+	results := make([]*CheckResult, n)
+	finished := 0
+	var finish func(i int, result *CheckResult)
+	finish = func(i int, result *CheckResult) {
+		results[i] = result
+		finished++
+		if fix {
+			held[i] = depSkipped[i] || (result.Status != StatusOK && d.checks[i].CanFix())
+			for _, j := range deps[i] {
+				held[i] = held[i] || held[j]
+			}
+		}
+		if !held[i] {
+			out.done(i, result)
+		}
+		for _, j := range dependents[i] {
+			if results[j] != nil {
+				continue // already skipped via another prerequisite
+			}
+			if result.Status == StatusError || result.Status == StatusSkipped {
+				depSkipped[j] = true
+				finish(j, skippedResult(d.checks[j], result.Name))
+				continue
+			}
+			waiting[j]--
+			if waiting[j] == 0 {
+				ready = append(ready, j)
+			}
+		}
+	}
+
+	events := make(chan checkEvent)
+	running := 0
+	for finished < n {
+		d.sortReady(ready, workers)
+		for running < workers && len(ready) > 0 {
+			i := ready[0]
+			ready = ready[1:]
+			if results[i] != nil {
+				continue
+			}
+			running++
+			out.start(i)
+			go func(i int) {
+				events <- checkEvent{idx: i, result: runTimed(ctx, d.checks[i])}
+			}(i)
+		}
+		ev := <-events
+		running--
+		finish(ev.idx, ev.result)
+	}
+
+	if fix {
+		d.fixPhase(ctx, deps, results, held, depSkipped, out)
+	}
+	return report
+}
+
+// fixPhase settles the held results of a fix run, in dependency order.
+func (d *Doctor) fixPhase(ctx *CheckContext, deps [][]int, results []*CheckResult, held, depSkipped []bool, out *streamPrinter) {
+	for _, i := range topoOrder(deps) {
+		if !held[i] {
+			continue
+		}
+		check := d.checks[i]
+		result := results[i]
+
+		prereqFixed, failed := false, ""
+		for _, j := range deps[i] {
+			prereqFixed = prereqFixed || results[j].Fixed
+			if failed == "" && (results[j].Status == StatusError || results[j].Status == StatusSkipped) {
+				failed = results[j].Name
+			}
+		}
+		switch {
+		case failed != "":
+			result = skippedResult(check, failed)
+		case depSkipped[i] || prereqFixed:
+			out.start(i)
+			result = runTimed(ctx, check)
+		}
+
+		if result.Status != StatusOK && result.Status != StatusSkipped && check.CanFix() {
+			out.startFixing(i, result)
+			start := time.Now()
+			elapsed := result.Elapsed
+			if err := check.Fix(ctx); err == nil {
+				// Re-run check to verify fix worked
+				result = runLabeled(ctx, check)
+				if result.Status == StatusOK {
+					result.Message = result.Message + " (fixed)"
+					result.Fixed = true
+				}
+			} else {
+				result.Details = append(result.Details, "Fix failed: "+err.Error())
+			}
+			// Record total elapsed time including the fix attempt
+			result.Elapsed = elapsed + time.Since(start)
+		}
+		results[i] = result
+		out.done(i, result)
+	}
+}
+
+// topoOrder returns check indices with every check after its
+// prerequisites, otherwise in registration order. deps must be acyclic
+// (see dependencyGraph).
+func topoOrder(deps [][]int) []int {
+	n := len(deps)
+	placed := make([]bool, n)
+	order := make([]int, 0, n)
+	var place func(i int)
+	place = func(i int) {
+		if placed[i] {
+			return
+		}
+		placed[i] = true
+		for _, j := range deps[i] {
+			place(j)
+		}
+		order = append(order, i)
+	}
+	for i := 0; i < n; i++ {
+		place(i)
+	}
+	return order
+}
+
+// dependencyGraph resolves declared dependency names to check indices.
+// Unknown names (e.g. rig checks not registered for a town-wide run) are
+// ignored, and dependencies of checks caught in a cycle are dropped so the
+// run can't deadlock.
+func (d *Doctor) dependencyGraph() (deps, dependents [][]int) {
+	n := len(d.checks)
+	byName := make(map[string][]int, n)
+	for i, c := range d.checks {
+		byName[c.Name()] = append(byName[c.Name()], i)
+	}
+
+	deps = make([][]int, n)
+	for i, c := range d.checks {
+		dg, ok := c.(dependencyGetter)
+		if !ok {
+			continue
+		}
+		seen := make(map[int]bool)
+		for _, name := range dg.DependsOn() {
+			for _, j := range byName[name] {
+				if j != i && !seen[j] {
+					seen[j] = true
+					deps[i] = append(deps[i], j)
+				}
+			}
+		}
+	}
+
+	// Kahn's algorithm: anything never freed is in (or behind) a cycle.
+	waiting := make([]int, n)
+	rev := make([][]int, n)
+	var queue []int
+	for i := range deps {
+		waiting[i] = len(deps[i])
+		for _, j := range deps[i] {
+			rev[j] = append(rev[j], i)
+		}
+		if waiting[i] == 0 {
+			queue = append(queue, i)
+		}
+	}
+	freed := make([]bool, n)
+	for len(queue) > 0 {
+		i := queue[0]
+		queue = queue[1:]
+		freed[i] = true
+		for _, j := range rev[i] {
+			waiting[j]--
+			if waiting[j] == 0 {
+				queue = append(queue, j)
+			}
+		}
+	}
+
+	dependents = make([][]int, n)
+	for i := range deps {
+		if !freed[i] {
+			deps[i] = nil
+			continue
+		}
+		for _, j := range deps[i] {
+			dependents[j] = append(dependents[j], i)
+		}
+	}
+	return deps, dependents
+}
+
+// sortReady orders ready checks: registration order when sequential,
+// costliest first (then registration order) when running concurrently.
+func (d *Doctor) sortReady(ready []int, workers int) {
+	cost := func(i int) CheckCost {
+		if cg, ok := d.checks[i].(costGetter); ok {
+			return cg.Cost()
+		}
+		return CostCheap
+	}
+	sort.SliceStable(ready, func(a, b int) bool {
+		if workers > 1 {
+			if ca, cb := cost(ready[a]), cost(ready[b]); ca != cb {
+				return ca > cb
+			}
+		}
+		return ready[a] < ready[b]
+	})
+}
+
+// runTimed runs one check and records how long it took.
+func runTimed(ctx *CheckContext, check Check) *CheckResult {
+	start := time.Now()
+	result := runLabeled(ctx, check)
+	result.Elapsed = time.Since(start)
+	return result
+}
+
+// runLabeled runs a check and fills in its name and category if the check
+// left them empty.
+func runLabeled(ctx *CheckContext, check Check) *CheckResult {
+	result := check.Run(ctx)
+	if result.Name == "" {
+		result.Name = check.Name()
+	}
+	if cg, ok := check.(categoryGetter); ok && result.Category == "" {
+		result.Category = cg.Category()
+	}
+	return result
+}
+
+// skippedResult is the result recorded for a check whose prerequisite failed.
+func skippedResult(check Check, failed string) *CheckResult {
+	result := &CheckResult{
+		Name:    check.Name(),
+		Status:  StatusSkipped,
+		Message: fmt.Sprintf("skipped (requires %s)", failed),
+	}
+	if cg, ok := check.(categoryGetter); ok {
+		result.Category = cg.Category()
+	}
+	return result
+}
+
+// streamPrinter prints results in registration order as they complete.
+// The first unfinished check is shown as in progress ("○ name..." or
+// "(fixing)...") once it has started; finished checks after it are held
+// until it completes.
+type streamPrinter struct {
+	w             io.Writer
+	checks        []Check
+	slowThreshold time.Duration
+	report        *Report
+
+	results []*CheckResult
+	started []bool               // checks that have begun running
+	fixing  map[int]*CheckResult // checks currently being fixed
+	next    int                  // first check not yet printed
+}
+
+// start records that check i began running, showing its in-progress line
+// if it is next in order.
+func (p *streamPrinter) start(i int) {
+	if p.started[i] {
+		return
+	}
+	p.started[i] = true
+	if i == p.next {
+		p.printPending()
+	}
+}
+
+// startFixing records that check i is being fixed, redrawing its line if
+// it is the one currently shown.
+func (p *streamPrinter) startFixing(i int, result *CheckResult) {
+	p.started[i] = true
+	p.fixing[i] = result
+	if i == p.next && p.w != nil {
+		fmt.Fprint(p.w, "\r")
+		p.printPending()
+	}
+}
+
+// done records check i's result and prints every result that is now next
+// in order.
+func (p *streamPrinter) done(i int, result *CheckResult) {
+	p.results[i] = result
+	delete(p.fixing, i)
+	for p.next < len(p.checks) && p.results[p.next] != nil {
+		p.printResult(p.results[p.next])
+		p.report.Add(p.results[p.next])
+		p.next++
+		p.printPending()
+	}
+}
+
+// printPending prints the in-progress line for the next check, if it has
+// started. A check that hasn't started prints nothing until it does.
+func (p *streamPrinter) printPending() {
+	if p.w == nil || p.next >= len(p.checks) || !p.started[p.next] {
+		return
+	}
+	check := p.checks[p.next]
+	result, fixing := p.fixing[p.next]
+	if !fixing {
+		fmt.Fprintf(p.w, "  %s  %s...", ui.RenderMuted("○"), check.Name())
+		return
+	}
+	// Show the problem with fixing indicator (all on same line)
+	problemIcon := ui.RenderWarnIcon()
+	if result.Status == StatusError {
+		problemIcon = ui.RenderFailIcon()
+	}
+	fmt.Fprintf(p.w, "  %s  %s", problemIcon, check.Name())
+	if result.Message != "" {
+		fmt.Fprintf(p.w, "%s", ui.RenderMuted(" "+result.Message))
+	}
+	fmt.Fprintf(p.w, "%s", ui.RenderMuted(" (fixing)..."))
+}
+
+// printResult overwrites the in-progress line with the final result.
+func (p *streamPrinter) printResult(result *CheckResult) {
+	if p.w == nil {
+		return
+	}
+	var statusIcon string
+	if result.Fixed {
+		statusIcon = ui.RenderFixIcon()
+	} else {
+		switch result.Status {
+		case StatusOK:
+			statusIcon = ui.RenderPassIcon()
+		case StatusWarning:
+			statusIcon = ui.RenderWarnIcon()
+		case StatusError:
+			statusIcon = ui.RenderFailIcon()
+		case StatusSkipped:
+			statusIcon = ui.RenderSkipIcon()
+		}
+	}
+	// Check if slow (hourglass replaces spaces to maintain alignment)
+	// Fix icon (🔧) is double-width, so use one less padding space
+	isSlow := p.slowThreshold > 0 && result.Elapsed >= p.slowThreshold
+	slowIndicator := "  "
+	if result.Fixed {
+		slowIndicator = " "
+	}
+	if isSlow {
+		p.report.Summary.Slow++
+		slowIndicator = "⏳"
+	}
+	fmt.Fprintf(p.w, "\r  %s%s%s", statusIcon, slowIndicator, result.Name)
+	if result.Message != "" {
+		fmt.Fprintf(p.w, "%s", ui.RenderMuted(" "+result.Message))
+	}
+	if isSlow {
+		fmt.Fprintf(p.w, "%s", ui.RenderMuted(" ("+formatDuration(result.Elapsed)+")"))
+	}
+	fmt.Fprintln(p.w)
+}
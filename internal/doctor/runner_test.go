@@ -0,0 +1,272 @@
+package doctor
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// recordingCheck logs when it runs and fixes, and can block until released.
+type recordingCheck struct {
+	mockCheck
+	log     *eventLog
+	release chan struct{}
+}
+
+type eventLog struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (l *eventLog) add(e string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.events = append(l.events, e)
+}
+
+func (l *eventLog) index(e string) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for i, got := range l.events {
+		if got == e {
+			return i
+		}
+	}
+	return -1
+}
+
+func newRecordingCheck(log *eventLog, name string, status CheckStatus, deps ...string) *recordingCheck {
+	c := &recordingCheck{mockCheck: *newMockCheck(name, status), log: log}
+	c.CheckDependsOn = deps
+	return c
+}
+
+func (c *recordingCheck) Run(ctx *CheckContext) *CheckResult {
+	if c.release != nil {
+		<-c.release
+	}
+	c.log.add("run:" + c.CheckName)
+	return c.mockCheck.Run(ctx)
+}
+
+func (c *recordingCheck) Fix(ctx *CheckContext) error {
+	c.log.add("fix:" + c.CheckName)
+	return c.mockCheck.Fix(ctx)
+}
+
+func reportNames(r *Report) []string {
+	var names []string
+	for _, c := range r.Checks {
+		names = append(names, c.Name)
+	}
+	return names
+}
+
+func TestDoctor_RunStreaming_RegistrationOrder(t *testing.T) {
+	log := &eventLog{}
+	slow := newRecordingCheck(log, "slow", StatusOK)
+	slow.release = make(chan struct{})
+	fast := newRecordingCheck(log, "fast", StatusWarning)
+
+	d := NewDoctor()
+	d.RegisterAll(slow, fast)
+
+	// Release the first check only after the second has finished, so the
+	// second completes first.
+	go func() {
+		for log.index("run:fast") < 0 {
+			time.Sleep(time.Millisecond)
+		}
+		close(slow.release)
+	}()
+
+	var buf bytes.Buffer
+	report := d.RunStreaming(&CheckContext{TownRoot: "/test"}, &buf, 0)
+
+	if got := strings.Join(reportNames(report), ","); got != "slow,fast" {
+		t.Errorf("report order = %s, want slow,fast", got)
+	}
+	out := buf.String()
+	if strings.Index(out, "slow mock result") > strings.Index(out, "fast mock result") {
+		t.Errorf("output not in registration order:\n%s", out)
+	}
+	if report.Summary.OK != 1 || report.Summary.Warnings != 1 {
+		t.Errorf("summary = %+v", report.Summary)
+	}
+}
+
+func TestDoctor_RunStreaming_SkipsDependents(t *testing.T) {
+	log := &eventLog{}
+	d := NewDoctor()
+	d.RegisterAll(
+		newRecordingCheck(log, "child", StatusOK, "parent"),
+		newRecordingCheck(log, "parent", StatusError),
+		newRecordingCheck(log, "grandchild", StatusOK, "child"),
+		newRecordingCheck(log, "unrelated", StatusOK, "not-registered"),
+		newRecordingCheck(log, "after-warning", StatusOK, "warn"),
+		newRecordingCheck(log, "warn", StatusWarning),
+	)
+
+	report := d.Run(&CheckContext{TownRoot: "/test"})
+
+	want := map[string]CheckStatus{
+		"child":         StatusSkipped,
+		"parent":        StatusError,
+		"grandchild":    StatusSkipped,
+		"unrelated":     StatusOK,
+		"after-warning": StatusOK,
+		"warn":          StatusWarning,
+	}
+	for _, r := range report.Checks {
+		if r.Status != want[r.Name] {
+			t.Errorf("%s: status = %v, want %v", r.Name, r.Status, want[r.Name])
+		}
+	}
+	if log.index("run:child") >= 0 || log.index("run:grandchild") >= 0 {
+		t.Errorf("skipped checks ran: %v", log.events)
+	}
+	if log.index("run:after-warning") < log.index("run:warn") {
+		t.Errorf("dependent ran before its prerequisite: %v", log.events)
+	}
+	if report.Summary.Skipped != 2 || report.Summary.Errors != 1 {
+		t.Errorf("summary = %+v", report.Summary)
+	}
+	if !strings.Contains(report.Checks[2].Message, "child") {
+		t.Errorf("skip message = %q, want it to name the failed prerequisite", report.Checks[2].Message)
+	}
+}
+
+func TestDoctor_FixStreaming_FixesPrerequisitesFirst(t *testing.T) {
+	log := &eventLog{}
+	parent := newRecordingCheck(log, "parent", StatusError)
+	parent.fixable = true
+	child := newRecordingCheck(log, "child", StatusWarning, "parent")
+	child.fixable = true
+
+	d := NewDoctor()
+	d.RegisterAll(child, parent)
+	report := d.Fix(&CheckContext{TownRoot: "/test"})
+
+	if log.index("fix:parent") > log.index("run:child") {
+		t.Errorf("child ran before parent was fixed: %v", log.events)
+	}
+	for _, r := range report.Checks {
+		if !r.Fixed {
+			t.Errorf("%s not fixed: %+v", r.Name, r)
+		}
+	}
+}
+
+// concurrencyCheck tracks how many checks and fixes are in flight at once,
+// and counts fixes that started while a check was still running.
+type concurrencyCheck struct {
+	mockCheck
+	running, maxRunning *int32
+	fixing, maxFixing   *int32
+	overlaps            *int32
+}
+
+func trackMax(cur, max *int32) func() {
+	n := atomic.AddInt32(cur, 1)
+	for {
+		m := atomic.LoadInt32(max)
+		if n <= m || atomic.CompareAndSwapInt32(max, m, n) {
+			break
+		}
+	}
+	time.Sleep(5 * time.Millisecond)
+	return func() { atomic.AddInt32(cur, -1) }
+}
+
+func (c *concurrencyCheck) Run(ctx *CheckContext) *CheckResult {
+	defer trackMax(c.running, c.maxRunning)()
+	return c.mockCheck.Run(ctx)
+}
+
+func (c *concurrencyCheck) Fix(ctx *CheckContext) error {
+	if atomic.LoadInt32(c.running) > 0 {
+		atomic.AddInt32(c.overlaps, 1)
+	}
+	defer trackMax(c.fixing, c.maxFixing)()
+	return c.mockCheck.Fix(ctx)
+}
+
+func TestDoctor_Workers(t *testing.T) {
+	for _, tt := range []struct {
+		workers     int
+		wantMaxRuns int32 // upper bound on concurrent runs
+	}{
+		{workers: 1, wantMaxRuns: 1},
+		{workers: 3, wantMaxRuns: 3},
+	} {
+		var running, maxRunning, fixing, maxFixing, overlaps int32
+		d := NewDoctor()
+		d.SetWorkers(tt.workers)
+		for i := 0; i < 8; i++ {
+			c := &concurrencyCheck{
+				mockCheck: *newMockCheck(string(rune('a'+i)), StatusError),
+				running:   &running, maxRunning: &maxRunning,
+				fixing: &fixing, maxFixing: &maxFixing,
+				overlaps: &overlaps,
+			}
+			c.fixable = true
+			d.Register(c)
+		}
+
+		report := d.Fix(&CheckContext{TownRoot: "/test"})
+
+		if maxRunning > tt.wantMaxRuns {
+			t.Errorf("workers=%d: %d checks ran at once", tt.workers, maxRunning)
+		}
+		if maxFixing != 1 {
+			t.Errorf("workers=%d: %d fixes ran at once, want 1", tt.workers, maxFixing)
+		}
+		if overlaps != 0 {
+			t.Errorf("workers=%d: %d fixes started while checks were running", tt.workers, overlaps)
+		}
+		if got := strings.Join(reportNames(report), ""); got != "abcdefgh" {
+			t.Errorf("workers=%d: report order = %s", tt.workers, got)
+		}
+	}
+}
+
+func TestStreamPrinter_PendingOnlyForStartedChecks(t *testing.T) {
+	var buf bytes.Buffer
+	checks := []Check{newMockCheck("first", StatusOK), newMockCheck("second", StatusOK)}
+	p := &streamPrinter{w: &buf, checks: checks, report: NewReport(),
+		results: make([]*CheckResult, 2), started: make([]bool, 2), fixing: make(map[int]*CheckResult)}
+
+	p.start(0)
+	p.done(0, &CheckResult{Name: "first", Status: StatusOK})
+	if strings.Contains(buf.String(), "second...") {
+		t.Errorf("pending line printed for a check that hasn't started: %q", buf.String())
+	}
+	p.start(1)
+	if !strings.Contains(buf.String(), "second...") {
+		t.Errorf("no pending line once the check started: %q", buf.String())
+	}
+}
+
+func TestDoctor_DependencyCycle(t *testing.T) {
+	log := &eventLog{}
+	d := NewDoctor()
+	d.RegisterAll(
+		newRecordingCheck(log, "a", StatusOK, "b"),
+		newRecordingCheck(log, "b", StatusOK, "a"),
+		newRecordingCheck(log, "self", StatusOK, "self"),
+	)
+
+	done := make(chan *Report)
+	go func() { done <- d.Run(&CheckContext{TownRoot: "/test"}) }()
+	select {
+	case report := <-done:
+		if report.Summary.OK != 3 {
+			t.Errorf("summary = %+v, want all 3 checks run", report.Summary)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("dependency cycle deadlocked the run")
+	}
+}
@@ -27,6 +27,8 @@ func NewStaleAgentBeadsCheck() *StaleAgentBeadsCheck {
 				CheckName:        "stale-agent-beads",
 				CheckDescription: "Detect agent beads for removed workers (crew and polecats)",
 				CheckCategory:    CategoryRig,
+				CheckDependsOn:   beadsCheckPrereqs,
+				CheckCost:        CostHeavy,
 			},
 		},
 	}
@@ -184,3 +186,12 @@ func (c *StaleAgentBeadsCheck) Fix(ctx *CheckContext) error {
 
 	return nil
 }
+
+// PlanFix lists the agent beads Fix would close (Run reports them as details).
+func (c *StaleAgentBeadsCheck) PlanFix(ctx *CheckContext, result *CheckResult) []PlannedChange {
+	var changes []PlannedChange
+	for _, beadID := range result.Details {
+		changes = append(changes, PlannedChange{Kind: ChangeBead, Action: "close", Target: beadID, Note: "worker no longer on disk"})
+	}
+	return changes
+}
@@ -98,3 +98,26 @@ func TestStaleAgentBeadsCheck_CrewOnDisk(t *testing.T) {
 	result := check.Run(ctx)
 	t.Logf("Stale agent beads check: status=%v, message=%s", result.Status, result.Message)
 }
+
+func TestListPolecats_IncludesWarmSpares(t *testing.T) {
+	tmpDir := t.TempDir()
+	polecatsDir := filepath.Join(tmpDir, "myrig", "polecats")
+	for _, dir := range []string{"Toast", ".warm/Nux/myrig", ".hidden"} {
+		if err := os.MkdirAll(filepath.Join(polecatsDir, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// A spare's name must count as on disk, or StaleAgentBeadsCheck would
+	// close the bead of any spare that has one.
+	got := listPolecats(tmpDir, "myrig")
+	want := map[string]bool{"Toast": true, "Nux": true}
+	if len(got) != len(want) {
+		t.Fatalf("listPolecats = %v, want Toast and Nux", got)
+	}
+	for _, name := range got {
+		if !want[name] {
+			t.Errorf("listPolecats = %v, want Toast and Nux", got)
+		}
+	}
+}
@@ -180,6 +180,26 @@ func (c *StaleBeadsRedirectCheck) Fix(ctx *CheckContext) error {
 	return nil
 }
 
+// PlanFix lists the beads directories Fix would clean and the redirects it
+// would write.
+func (c *StaleBeadsRedirectCheck) PlanFix(ctx *CheckContext, result *CheckResult) []PlannedChange {
+	var changes []PlannedChange
+	for _, relPath := range c.staleLocations {
+		changes = append(changes, PlannedChange{Kind: ChangeFile, Action: "delete", Target: filepath.Join(ctx.TownRoot, relPath),
+			Note: "stale beads files beside redirect"})
+	}
+	for _, issue := range c.missingRedirects {
+		changes = append(changes, PlannedChange{Kind: ChangeFile, Action: "create",
+			Target: filepath.Join(issue.worktreePath, ".beads", "redirect"), Note: "-> " + issue.expectedTarget})
+	}
+	for _, issue := range c.incorrectRedirects {
+		changes = append(changes, PlannedChange{Kind: ChangeFile, Action: "write",
+			Target: filepath.Join(issue.worktreePath, ".beads", "redirect"),
+			Note:   fmt.Sprintf("%s -> %s", issue.currentTarget, issue.expectedTarget)})
+	}
+	return changes
+}
+
 // findRigDirs returns all rig directories in the town.
 func findRigDirs(townRoot string) ([]string, error) {
 	var rigs []string
@@ -363,7 +383,7 @@ func (c *StaleBeadsRedirectCheck) verifyRedirectTopology(ctx *CheckContext, rigD
 			// Other errors may indicate real problems - log them in verbose mode.
 			if ctx.Verbose && !strings.Contains(err.Error(), "no beads found") {
 				relPath, _ := filepath.Rel(townRoot, worktreePath)
-				fmt.Printf("  [verbose] skipping %s: %v\n", relPath, err)
+				fmt.Fprintf(ctx.Output(), "  [verbose] skipping %s: %v\n", relPath, err)
 			}
 			continue
 		}
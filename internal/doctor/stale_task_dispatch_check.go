@@ -174,3 +174,13 @@ func (c *StaleTaskDispatchCheck) Fix(ctx *CheckContext) error {
 	}
 	return nil
 }
+
+// PlanFix lists the settings files Fix would rewrite.
+func (c *StaleTaskDispatchCheck) PlanFix(ctx *CheckContext, result *CheckResult) []PlannedChange {
+	var changes []PlannedChange
+	for _, target := range c.staleTargets {
+		changes = append(changes, PlannedChange{Kind: ChangeFile, Action: "write", Target: target.Path,
+			Note: "drop task-dispatch guard for " + target.DisplayKey()})
+	}
+	return changes
+}
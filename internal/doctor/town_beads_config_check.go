@@ -85,3 +85,11 @@ func (c *TownBeadsConfigCheck) Fix(ctx *CheckContext) error {
 	beadsDir := filepath.Join(ctx.TownRoot, ".beads")
 	return beads.EnsureConfigYAMLFromMetadataIfMissing(beadsDir, "hq")
 }
+
+// PlanFix reports the config file Fix would create.
+func (c *TownBeadsConfigCheck) PlanFix(ctx *CheckContext, result *CheckResult) []PlannedChange {
+	if !c.missingConfig {
+		return nil
+	}
+	return []PlannedChange{{Kind: ChangeFile, Action: "create", Target: filepath.Join(ctx.TownRoot, ".beads", "config.yaml")}}
+}
@@ -21,6 +21,7 @@ func NewTownGitCheck() *TownGitCheck {
 			CheckName:        "town-git",
 			CheckDescription: "Verify town root is under version control",
 			CheckCategory:    CategoryCore,
+			CheckCost:        CostSubprocess,
 		},
 	}
 }
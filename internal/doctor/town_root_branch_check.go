@@ -22,6 +22,7 @@ func NewTownRootBranchCheck() *TownRootBranchCheck {
 				CheckName:        "town-root-branch",
 				CheckDescription: "Verify town root is on main branch",
 				CheckCategory:    CategoryCore,
+				CheckCost:        CostSubprocess,
 			},
 		},
 	}
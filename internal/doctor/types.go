@@ -4,6 +4,8 @@ package doctor
 import (
 	"fmt"
 	"io"
+	"os"
+	"strings"
 	"time"
 
 	"github.com/steveyegge/gastown/internal/ui"
@@ -41,6 +43,9 @@ const (
 	StatusWarning
 	// StatusError indicates a critical problem.
 	StatusError
+	// StatusSkipped indicates the check did not run because a check it
+	// depends on failed.
+	StatusSkipped
 )
 
 // String returns a human-readable status.
@@ -52,17 +57,53 @@ func (s CheckStatus) String() string {
 		return "Warning"
 	case StatusError:
 		return "Error"
+	case StatusSkipped:
+		return "Skipped"
 	default:
 		return "Unknown"
 	}
 }
 
+// MarshalText encodes the status as a lowercase name ("ok", "warning",
+// "error", "skipped") so persisted and machine-readable results don't
+// depend on the enum's numbering.
+func (s CheckStatus) MarshalText() ([]byte, error) {
+	switch s {
+	case StatusOK, StatusWarning, StatusError, StatusSkipped:
+		return []byte(strings.ToLower(s.String())), nil
+	default:
+		return nil, fmt.Errorf("unknown check status %d", int(s))
+	}
+}
+
+// UnmarshalText decodes a status written by MarshalText.
+func (s *CheckStatus) UnmarshalText(text []byte) error {
+	for _, status := range []CheckStatus{StatusOK, StatusWarning, StatusError, StatusSkipped} {
+		if strings.EqualFold(string(text), status.String()) {
+			*s = status
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown check status %q", text)
+}
+
 // CheckContext provides context for running checks.
 type CheckContext struct {
 	TownRoot        string // Root directory of the Gas Town workspace
 	RigName         string // Rig name (empty for town-level checks)
 	Verbose         bool   // Enable verbose output
 	RestartSessions bool   // Restart patrol sessions when fixing (requires explicit --restart-sessions flag)
+
+	// Out receives progress and notes printed by fixes. Nil means stdout.
+	Out io.Writer
+}
+
+// Output returns the writer fixes print to.
+func (ctx *CheckContext) Output() io.Writer {
+	if ctx.Out == nil {
+		return os.Stdout
+	}
+	return ctx.Out
 }
 
 // RigPath returns the full path to the rig directory.
@@ -74,6 +115,20 @@ func (ctx *CheckContext) RigPath() string {
 	return ctx.TownRoot + "/" + ctx.RigName
 }
 
+// CheckCost is a hint of how long a check takes. When checks run
+// concurrently, costlier ones start first so they overlap with the rest
+// instead of trailing at the end.
+type CheckCost int
+
+const (
+	// CostCheap is in-process work: reading files, parsing config.
+	CostCheap CheckCost = iota
+	// CostSubprocess is a handful of bd, git, dolt or tmux calls.
+	CostSubprocess
+	// CostHeavy is subprocesses per rig, session or bead.
+	CostHeavy
+)
+
 // DefaultSlowThreshold is the default duration above which a check is considered slow.
 const DefaultSlowThreshold = 1 * time.Second
 
@@ -115,6 +170,7 @@ type ReportSummary struct {
 	Warnings    int
 	Errors      int
 	Fixed       int           // Checks that were auto-fixed
+	Skipped     int           // Checks skipped because a prerequisite failed
 	Slow        int           // Checks that took longer than threshold (counted during Print)
 	SlowestName string        // Name of the slowest check
 	SlowestTime time.Duration // Duration of the slowest check
@@ -147,6 +203,8 @@ func (r *Report) Add(result *CheckResult) {
 		r.Summary.Warnings++
 	case StatusError:
 		r.Summary.Errors++
+	case StatusSkipped:
+		r.Summary.Skipped++
 	}
 
 	// Track fixed checks
@@ -184,7 +242,7 @@ func (r *Report) PrintSummaryOnly(w io.Writer, verbose bool, slowThreshold time.
 	// Collect warnings/errors for summary section
 	var warnings []*CheckResult
 	for _, check := range r.Checks {
-		if check.Status != StatusOK {
+		if check.Status == StatusWarning || check.Status == StatusError {
 			warnings = append(warnings, check)
 		}
 	}
@@ -217,10 +275,14 @@ func (r *Report) Print(w io.Writer, verbose bool, slowThreshold time.Duration) {
 
 	// Group checks by category
 	checksByCategory := make(map[string][]*CheckResult)
+	known := make(map[string]bool, len(CategoryOrder))
+	for _, category := range CategoryOrder {
+		known[category] = true
+	}
 	for _, check := range r.Checks {
 		cat := check.Category
-		if cat == "" {
-			cat = "Other"
+		if !known[cat] {
+			cat = "Other" // includes plugin-defined categories
 		}
 		checksByCategory[cat] = append(checksByCategory[cat], check)
 	}
@@ -241,7 +303,7 @@ func (r *Report) Print(w io.Writer, verbose bool, slowThreshold time.Duration) {
 		// Print each check in this category
 		for _, check := range checks {
 			r.printCheck(w, check, verbose, slowThreshold)
-			if check.Status != StatusOK {
+			if check.Status == StatusWarning || check.Status == StatusError {
 				warnings = append(warnings, check)
 			}
 		}
@@ -253,7 +315,7 @@ func (r *Report) Print(w io.Writer, verbose bool, slowThreshold time.Duration) {
 		_, _ = fmt.Fprintln(w, ui.RenderCategory("Other"))
 		for _, check := range otherChecks {
 			r.printCheck(w, check, verbose, slowThreshold)
-			if check.Status != StatusOK {
+			if check.Status == StatusWarning || check.Status == StatusError {
 				warnings = append(warnings, check)
 			}
 		}
@@ -278,6 +340,8 @@ func (r *Report) printCheck(w io.Writer, check *CheckResult, verbose bool, slowT
 		statusIcon = ui.RenderWarnIcon()
 	case StatusError:
 		statusIcon = ui.RenderFailIcon()
+	case StatusSkipped:
+		statusIcon = ui.RenderSkipIcon()
 	}
 
 	// Add hourglass for slow checks (only when --slow is enabled)
@@ -302,7 +366,7 @@ func (r *Report) printCheck(w io.Writer, check *CheckResult, verbose bool, slowT
 	_, _ = fmt.Fprintln(w)
 
 	// Print details in verbose mode or for non-OK results (with tree connector)
-	if len(check.Details) > 0 && (verbose || check.Status != StatusOK) {
+	if len(check.Details) > 0 && (verbose || check.Status == StatusWarning || check.Status == StatusError) {
 		for _, detail := range check.Details {
 			_, _ = fmt.Fprintf(w, "     %s%s\n", ui.MutedStyle.Render(ui.TreeLast), ui.RenderMuted(detail))
 		}
@@ -341,6 +405,9 @@ func (r *Report) printSummary(w io.Writer, slowThreshold time.Duration) {
 	if r.Summary.Fixed > 0 {
 		summary += fmt.Sprintf("  🔧 %d fixed", r.Summary.Fixed)
 	}
+	if r.Summary.Skipped > 0 {
+		summary += fmt.Sprintf("  %s %d skipped", ui.RenderSkipIcon(), r.Summary.Skipped)
+	}
 	if slowThreshold > 0 && r.Summary.Slow > 0 {
 		summary += fmt.Sprintf("  ⏳ %d slow (slowest: %s %s)",
 			r.Summary.Slow,
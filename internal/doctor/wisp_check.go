@@ -7,6 +7,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
 	"time"
 
 	"github.com/steveyegge/gastown/internal/beads"
@@ -29,6 +30,8 @@ func NewWispGCCheck() *WispGCCheck {
 				CheckName:        "wisp-gc",
 				CheckDescription: "Detect and clean orphaned wisps (>1h old)",
 				CheckCategory:    CategoryCleanup,
+				CheckDependsOn:   beadsCheckPrereqs,
+				CheckCost:        CostSubprocess,
 			},
 		},
 		threshold:     1 * time.Hour,
@@ -145,3 +148,23 @@ func (c *WispGCCheck) Fix(ctx *CheckContext) error {
 
 	return lastErr
 }
+
+// PlanFix lists the rigs Fix would garbage-collect wisps in.
+func (c *WispGCCheck) PlanFix(ctx *CheckContext, result *CheckResult) []PlannedChange {
+	rigs := make([]string, 0, len(c.abandonedRigs))
+	for rigName := range c.abandonedRigs {
+		rigs = append(rigs, rigName)
+	}
+	sort.Strings(rigs)
+
+	var changes []PlannedChange
+	for _, rigName := range rigs {
+		changes = append(changes, PlannedChange{
+			Kind:   ChangeCommand,
+			Action: "run",
+			Target: "bd mol wisp gc",
+			Note:   fmt.Sprintf("in %s: %d abandoned wisp(s)", rigName, c.abandonedRigs[rigName]),
+		})
+	}
+	return changes
+}
@@ -261,6 +261,25 @@ func (c *WorktreeGitdirCheck) Fix(ctx *CheckContext) error {
 	return lastErr
 }
 
+// PlanFix lists the worktrees Fix would re-create. Worktrees it can't fix
+// (no .repo.git) are left out; they need a re-clone.
+func (c *WorktreeGitdirCheck) PlanFix(ctx *CheckContext, result *CheckResult) []PlannedChange {
+	var changes []PlannedChange
+	for _, bw := range c.brokenWorktrees {
+		if bw.bareRepoPath == "" {
+			continue
+		}
+		if _, err := os.Stat(bw.bareRepoPath); err != nil {
+			continue
+		}
+		changes = append(changes,
+			PlannedChange{Kind: ChangeFile, Action: "delete", Target: filepath.Join(bw.worktreePath, ".git"), Note: bw.reason},
+			PlannedChange{Kind: ChangeGit, Action: "add", Target: bw.worktreePath, Note: "git worktree add from " + bw.bareRepoPath},
+		)
+	}
+	return changes
+}
+
 // isRigDir checks if a directory looks like a rig (has config.json or known subdirectories).
 func isRigDir(path string) bool {
 	// Check for config.json (most reliable indicator)
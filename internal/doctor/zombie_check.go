@@ -24,6 +24,7 @@ func NewZombieSessionCheck() *ZombieSessionCheck {
 				CheckName:        "zombie-sessions",
 				CheckDescription: "Detect tmux sessions with dead Claude processes",
 				CheckCategory:    CategoryCleanup,
+				CheckCost:        CostHeavy,
 			},
 		},
 	}
@@ -132,7 +133,7 @@ func (c *ZombieSessionCheck) Fix(ctx *CheckContext) error {
 		}
 
 		// Log pre-death event for audit trail
-		_ = events.LogFeed(events.TypeSessionDeath, sess,
+		_ = events.LogFeedIn(ctx.TownRoot, events.TypeSessionDeath, sess,
 			events.SessionDeathPayload(sess, "unknown", "zombie cleanup", "gt doctor"))
 
 		// Use KillSessionWithProcesses to ensure all descendant processes are killed.
@@ -143,3 +144,16 @@ func (c *ZombieSessionCheck) Fix(ctx *CheckContext) error {
 
 	return lastErr
 }
+
+// PlanFix lists the zombie sessions Fix would kill.
+func (c *ZombieSessionCheck) PlanFix(ctx *CheckContext, result *CheckResult) []PlannedChange {
+	var changes []PlannedChange
+	for _, sess := range c.zombieSessions {
+		if isCrewSession(sess) {
+			continue
+		}
+		changes = append(changes, PlannedChange{Kind: ChangeSession, Action: "kill", Target: sess,
+			Note: "unless its agent is alive again"})
+	}
+	return changes
+}
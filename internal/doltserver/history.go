@@ -0,0 +1,405 @@
+package doltserver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+)
+
+// Time-travel queries over bead history.
+//
+// Dolt versions every write bd makes, so the state of any bead at any past
+// commit is still queryable: "AS OF" reads a table at a point in history and
+// the dolt_diff_<table> system tables list row-level changes per commit.
+// These helpers run read-only SQL against the issues, labels and
+// dependencies tables bd maintains in each rig database.
+
+// AsOf is a point in Dolt history: a wall-clock time or a commit ref
+// (hash, branch, tag, or HEAD~N).
+type AsOf struct {
+	Time time.Time
+	Ref  string
+}
+
+// refPattern limits refs to characters that can appear in Dolt commit
+// hashes, branch names and ancestry specs.
+var refPattern = regexp.MustCompile(`^[A-Za-z0-9_./~^-]+$`)
+
+// ParseAsOf parses an --as-of value relative to now. Accepts a duration ago
+// ("2h", "1d"), "yesterday", a date or date-time in local time
+// ("2006-01-02", "2006-01-02 15:04"), RFC3339, or a commit ref.
+func ParseAsOf(s string, now time.Time) (AsOf, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return AsOf{}, fmt.Errorf("empty --as-of value")
+	}
+	if s == "yesterday" {
+		y := now.AddDate(0, 0, -1)
+		return AsOf{Time: time.Date(y.Year(), y.Month(), y.Day(), 0, 0, 0, 0, now.Location())}, nil
+	}
+	if d, ok := parseAgo(s); ok {
+		return AsOf{Time: now.Add(-d)}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return AsOf{Time: t}, nil
+	}
+	for _, layout := range []string{"2006-01-02 15:04:05", "2006-01-02 15:04", "2006-01-02T15:04", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, s, now.Location()); err == nil {
+			return AsOf{Time: t}, nil
+		}
+	}
+	if refPattern.MatchString(s) {
+		return AsOf{Ref: s}, nil
+	}
+	return AsOf{}, fmt.Errorf("invalid --as-of %q: use a time (2h, yesterday, 2006-01-02 15:04) or a commit ref", s)
+}
+
+// parseAgo parses Go durations plus a "d" (day) suffix.
+func parseAgo(s string) (time.Duration, bool) {
+	if strings.HasSuffix(s, "d") {
+		days, err := strconv.Atoi(strings.TrimSuffix(s, "d"))
+		if err != nil || days < 0 {
+			return 0, false
+		}
+		return time.Duration(days) * 24 * time.Hour, true
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, false
+	}
+	return d, true
+}
+
+// String renders the point in history for display.
+func (a AsOf) String() string {
+	if a.Ref != "" {
+		return a.Ref
+	}
+	return a.Time.Local().Format("2006-01-02 15:04:05")
+}
+
+// clause returns the SQL "AS OF" suffix for a table reference. Dolt commit
+// dates are UTC, so times are converted before formatting.
+func (a AsOf) clause() string {
+	if a.Ref != "" {
+		return fmt.Sprintf("AS OF '%s'", EscapeSQL(a.Ref))
+	}
+	return fmt.Sprintf("AS OF TIMESTAMP('%s')", a.Time.UTC().Format("2006-01-02 15:04:05"))
+}
+
+// table returns a database-qualified table name.
+func table(db, name string) string {
+	return "`" + strings.ReplaceAll(db, "`", "``") + "`.`" + name + "`"
+}
+
+// DatabaseForRig returns the Dolt database holding a rig's beads, as
+// recorded in its metadata.json. Use "hq" for town beads.
+func DatabaseForRig(townRoot, rigName string) string {
+	if db := readExistingDoltDatabase(FindRigBeadsDir(townRoot, rigName)); db != "" {
+		return db
+	}
+	return rigName
+}
+
+// DatabaseForBead returns the Dolt database holding a bead, resolved from
+// its ID prefix through routes.jsonl.
+func DatabaseForBead(townRoot, beadID string) string {
+	rigName := beads.GetRigNameForPrefix(townRoot, beads.ExtractPrefix(beadID))
+	if rigName == "" {
+		rigName = "hq"
+	}
+	return DatabaseForRig(townRoot, rigName)
+}
+
+// queryRows runs a read-only query and returns its rows as column maps.
+// Numbers decode as float64 and NULLs as nil.
+func queryRows(townRoot, query string) ([]map[string]interface{}, error) {
+	config := DefaultConfig(townRoot)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	cmd := buildDoltSQLCmd(ctx, config, "-r", "json", "-q", query)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("dolt sql query failed: %w (%s)", err, strings.TrimSpace(stderr.String()))
+	}
+	output = extractJSON(output)
+	if len(bytes.TrimSpace(output)) == 0 {
+		return nil, nil
+	}
+	var result struct {
+		Rows []map[string]interface{} `json:"rows"`
+	}
+	if err := json.Unmarshal(output, &result); err != nil {
+		return nil, fmt.Errorf("parsing dolt sql output: %w", err)
+	}
+	return result.Rows, nil
+}
+
+// cell renders a column value as a string ("" for NULL).
+func cell(row map[string]interface{}, col string) string {
+	switch v := row[col].(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// issueColumns are the issue fields read by snapshot queries.
+const issueColumns = "i.id, i.title, i.description, i.status, i.priority, i.issue_type, i.assignee, i.created_at, i.updated_at, i.closed_at"
+
+// issueFromRow builds a bead from a snapshot row. Labels come from an
+// optional comma-joined "labels" column.
+func issueFromRow(row map[string]interface{}) *beads.Issue {
+	issue := &beads.Issue{
+		ID:          cell(row, "id"),
+		Title:       cell(row, "title"),
+		Description: cell(row, "description"),
+		Status:      cell(row, "status"),
+		Type:        cell(row, "issue_type"),
+		Assignee:    cell(row, "assignee"),
+		CreatedAt:   cell(row, "created_at"),
+		UpdatedAt:   cell(row, "updated_at"),
+		ClosedAt:    cell(row, "closed_at"),
+	}
+	issue.Priority, _ = strconv.Atoi(cell(row, "priority"))
+	if labels := cell(row, "labels"); labels != "" {
+		issue.Labels = strings.Split(labels, ",")
+	}
+	return issue
+}
+
+// labelsColumn selects a bead's labels at the given point as one column.
+func labelsColumn(db string, at AsOf) string {
+	return fmt.Sprintf("(SELECT GROUP_CONCAT(l.label ORDER BY l.label) FROM %s %s AS l WHERE l.issue_id = i.id) AS labels",
+		table(db, "labels"), at.clause())
+}
+
+// BeadsAsOf returns the listed beads as they were at the given point,
+// keyed by ID. Beads that did not exist yet are absent from the map.
+func BeadsAsOf(townRoot string, ids []string, at AsOf) (map[string]*beads.Issue, error) {
+	byDB := make(map[string][]string)
+	for _, id := range ids {
+		db := DatabaseForBead(townRoot, id)
+		byDB[db] = append(byDB[db], "'"+EscapeSQL(id)+"'")
+	}
+
+	result := make(map[string]*beads.Issue, len(ids))
+	for db, quoted := range byDB {
+		query := fmt.Sprintf("SELECT %s, %s FROM %s %s AS i WHERE i.id IN (%s)",
+			issueColumns, labelsColumn(db, at), table(db, "issues"), at.clause(), strings.Join(quoted, ", "))
+		rows, err := queryRows(townRoot, query)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s as of %s: %w", db, at, err)
+		}
+		for _, row := range rows {
+			issue := issueFromRow(row)
+			result[issue.ID] = issue
+		}
+	}
+	return result, nil
+}
+
+// ReadyAsOf returns the beads in a database that were ready (open with no
+// open blockers) at the given point, highest priority first.
+func ReadyAsOf(townRoot, db string, at AsOf) ([]*beads.Issue, error) {
+	query := fmt.Sprintf(`SELECT %s, %s FROM %s %s AS i
+WHERE i.status = 'open' AND NOT EXISTS (
+  SELECT 1 FROM %s %s AS d JOIN %s %s AS b ON b.id = d.depends_on_id
+  WHERE d.issue_id = i.id AND d.type = 'blocks' AND b.status <> 'closed')
+ORDER BY i.priority, i.id`,
+		issueColumns, labelsColumn(db, at), table(db, "issues"), at.clause(),
+		table(db, "dependencies"), at.clause(), table(db, "issues"), at.clause())
+	rows, err := queryRows(townRoot, query)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s as of %s: %w", db, at, err)
+	}
+	issues := make([]*beads.Issue, 0, len(rows))
+	for _, row := range rows {
+		issues = append(issues, issueFromRow(row))
+	}
+	return issues, nil
+}
+
+// OpenIssuesAsOf returns the open beads of one issue type in a database at
+// the given point, e.g. the convoys that were still in flight.
+func OpenIssuesAsOf(townRoot, db, issueType string, at AsOf) ([]*beads.Issue, error) {
+	query := fmt.Sprintf("SELECT %s, %s FROM %s %s AS i WHERE i.issue_type = '%s' AND i.status <> 'closed' ORDER BY i.id",
+		issueColumns, labelsColumn(db, at), table(db, "issues"), at.clause(), EscapeSQL(issueType))
+	rows, err := queryRows(townRoot, query)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s as of %s: %w", db, at, err)
+	}
+	issues := make([]*beads.Issue, 0, len(rows))
+	for _, row := range rows {
+		issues = append(issues, issueFromRow(row))
+	}
+	return issues, nil
+}
+
+// TrackedAsOf returns the IDs a convoy tracked at the given point. Cross-rig
+// IDs in external:prefix:id form are unwrapped.
+func TrackedAsOf(townRoot, convoyID string, at AsOf) ([]string, error) {
+	db := DatabaseForBead(townRoot, convoyID)
+	query := fmt.Sprintf("SELECT d.depends_on_id FROM %s %s AS d WHERE d.issue_id = '%s' AND d.type = 'tracks' ORDER BY d.depends_on_id",
+		table(db, "dependencies"), at.clause(), EscapeSQL(convoyID))
+	rows, err := queryRows(townRoot, query)
+	if err != nil {
+		return nil, fmt.Errorf("reading tracked issues for %s as of %s: %w", convoyID, at, err)
+	}
+	ids := make([]string, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, beads.ExtractIssueID(cell(row, "depends_on_id")))
+	}
+	return ids, nil
+}
+
+// FieldChange is one field's value before and after a commit.
+type FieldChange struct {
+	Field string `json:"field"`
+	From  string `json:"from,omitempty"`
+	To    string `json:"to,omitempty"`
+}
+
+// HistoryEntry is everything one Dolt commit changed about a bead.
+// Commit is "WORKING" for changes not yet committed.
+type HistoryEntry struct {
+	Commit    string        `json:"commit"`
+	Date      time.Time     `json:"date,omitempty"`
+	Committer string        `json:"committer,omitempty"`
+	Message   string        `json:"message,omitempty"`
+	Created   bool          `json:"created,omitempty"`
+	Deleted   bool          `json:"deleted,omitempty"`
+	Changes   []FieldChange `json:"changes"`
+}
+
+// historyFields are the issue columns diffed by BeadHistory, in display order.
+var historyFields = []string{
+	"title", "status", "priority", "issue_type", "assignee",
+	"description", "design", "acceptance_criteria", "notes", "closed_at",
+}
+
+// BeadHistory returns field-level changes to a bead over its whole
+// history, oldest first, including label and dependency changes.
+func BeadHistory(townRoot, beadID string) ([]HistoryEntry, error) {
+	db := DatabaseForBead(townRoot, beadID)
+	id := EscapeSQL(beadID)
+	logJoin := fmt.Sprintf("LEFT JOIN %s AS lg ON lg.commit_hash = d.to_commit", table(db, "dolt_log"))
+	commitCols := "d.to_commit, d.to_commit_date, d.diff_type, lg.committer, lg.message"
+
+	var cols []string
+	for _, f := range historyFields {
+		cols = append(cols, "d.from_"+f, "d.to_"+f)
+	}
+	issueRows, err := queryRows(townRoot, fmt.Sprintf("SELECT %s, %s FROM %s AS d %s WHERE d.to_id = '%s' OR d.from_id = '%s'",
+		commitCols, strings.Join(cols, ", "), table(db, "dolt_diff_issues"), logJoin, id, id))
+	if err != nil {
+		return nil, fmt.Errorf("reading history of %s: %w", beadID, err)
+	}
+	labelRows, err := queryRows(townRoot, fmt.Sprintf("SELECT %s, d.from_label, d.to_label FROM %s AS d %s WHERE d.to_issue_id = '%s' OR d.from_issue_id = '%s'",
+		commitCols, table(db, "dolt_diff_labels"), logJoin, id, id))
+	if err != nil {
+		return nil, fmt.Errorf("reading label history of %s: %w", beadID, err)
+	}
+	depRows, err := queryRows(townRoot, fmt.Sprintf("SELECT %s, d.from_depends_on_id, d.to_depends_on_id, d.from_type, d.to_type FROM %s AS d %s WHERE d.to_issue_id = '%s' OR d.from_issue_id = '%s'",
+		commitCols, table(db, "dolt_diff_dependencies"), logJoin, id, id))
+	if err != nil {
+		return nil, fmt.Errorf("reading dependency history of %s: %w", beadID, err)
+	}
+	return buildHistory(issueRows, labelRows, depRows), nil
+}
+
+// buildHistory merges dolt_diff rows from the issues, labels and
+// dependencies tables into one entry per commit, oldest first.
+func buildHistory(issueRows, labelRows, depRows []map[string]interface{}) []HistoryEntry {
+	byCommit := make(map[string]*HistoryEntry)
+	entry := func(row map[string]interface{}) *HistoryEntry {
+		commit := cell(row, "to_commit")
+		if e, ok := byCommit[commit]; ok {
+			return e
+		}
+		e := &HistoryEntry{
+			Commit:    commit,
+			Date:      parseCommitDate(cell(row, "to_commit_date")),
+			Committer: cell(row, "committer"),
+			Message:   strings.TrimSpace(cell(row, "message")),
+		}
+		byCommit[commit] = e
+		return e
+	}
+
+	for _, row := range issueRows {
+		e := entry(row)
+		switch cell(row, "diff_type") {
+		case "added":
+			e.Created = true
+		case "removed":
+			e.Deleted = true
+		}
+		for _, f := range historyFields {
+			from, to := cell(row, "from_"+f), cell(row, "to_"+f)
+			if from != to {
+				e.Changes = append(e.Changes, FieldChange{Field: f, From: from, To: to})
+			}
+		}
+	}
+	for _, row := range labelRows {
+		e := entry(row)
+		e.Changes = append(e.Changes, FieldChange{Field: "label", From: cell(row, "from_label"), To: cell(row, "to_label")})
+	}
+	for _, row := range depRows {
+		e := entry(row)
+		dep := func(side string) string {
+			id := beads.ExtractIssueID(cell(row, side+"_depends_on_id"))
+			if id == "" {
+				return ""
+			}
+			return cell(row, side+"_type") + " " + id
+		}
+		e.Changes = append(e.Changes, FieldChange{Field: "dependency", From: dep("from"), To: dep("to")})
+	}
+
+	entries := make([]HistoryEntry, 0, len(byCommit))
+	for _, e := range byCommit {
+		entries = append(entries, *e)
+	}
+	// Uncommitted (WORKING) changes have no date and sort last.
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i].Date, entries[j].Date
+		if a.IsZero() != b.IsZero() {
+			return b.IsZero()
+		}
+		if !a.Equal(b) {
+			return a.Before(b)
+		}
+		return entries[i].Commit < entries[j].Commit
+	})
+	return entries
+}
+
+// parseCommitDate parses the datetime formats Dolt emits for commit dates.
+func parseCommitDate(s string) time.Time {
+	for _, layout := range []string{"2006-01-02 15:04:05.999999999", "2006-01-02 15:04:05", time.RFC3339Nano} {
+		if t, err := time.ParseInLocation(layout, s, time.UTC); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
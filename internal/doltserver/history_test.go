@@ -0,0 +1,131 @@
+package doltserver
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseAsOf(t *testing.T) {
+	loc := time.FixedZone("test", 2*3600)
+	now := time.Date(2026, 3, 10, 15, 30, 0, 0, loc)
+
+	tests := []struct {
+		in   string
+		want AsOf
+	}{
+		{"2h", AsOf{Time: now.Add(-2 * time.Hour)}},
+		{"1d", AsOf{Time: now.Add(-24 * time.Hour)}},
+		{"yesterday", AsOf{Time: time.Date(2026, 3, 9, 0, 0, 0, 0, loc)}},
+		{"2026-03-01", AsOf{Time: time.Date(2026, 3, 1, 0, 0, 0, 0, loc)}},
+		{"2026-03-01 09:15", AsOf{Time: time.Date(2026, 3, 1, 9, 15, 0, 0, loc)}},
+		{"2026-03-01T09:15:00Z", AsOf{Time: time.Date(2026, 3, 1, 9, 15, 0, 0, time.UTC)}},
+		{"HEAD~3", AsOf{Ref: "HEAD~3"}},
+		{"k2nq5rfe0gvvb3c5mfdpc1tns5bmdnij", AsOf{Ref: "k2nq5rfe0gvvb3c5mfdpc1tns5bmdnij"}},
+	}
+	for _, tt := range tests {
+		got, err := ParseAsOf(tt.in, now)
+		if err != nil {
+			t.Errorf("ParseAsOf(%q): %v", tt.in, err)
+			continue
+		}
+		if got.Ref != tt.want.Ref || !got.Time.Equal(tt.want.Time) {
+			t.Errorf("ParseAsOf(%q) = %+v, want %+v", tt.in, got, tt.want)
+		}
+	}
+
+	for _, bad := range []string{"", "main'; DROP TABLE issues", "last tuesday"} {
+		if _, err := ParseAsOf(bad, now); err == nil {
+			t.Errorf("ParseAsOf(%q) should fail", bad)
+		}
+	}
+}
+
+func TestAsOfClause(t *testing.T) {
+	at := AsOf{Time: time.Date(2026, 3, 1, 11, 0, 0, 0, time.FixedZone("test", 2*3600))}
+	if got := at.clause(); got != "AS OF TIMESTAMP('2026-03-01 09:00:00')" {
+		t.Errorf("time clause = %s", got)
+	}
+	if got := (AsOf{Ref: "HEAD~1"}).clause(); got != "AS OF 'HEAD~1'" {
+		t.Errorf("ref clause = %s", got)
+	}
+	if got := table("we`ird", "issues"); got != "`we``ird`.`issues`" {
+		t.Errorf("table = %s", got)
+	}
+}
+
+func TestIssueFromRow(t *testing.T) {
+	issue := issueFromRow(map[string]interface{}{
+		"id": "gt-abc", "title": "Fix it", "status": "open", "priority": float64(1),
+		"issue_type": "bug", "assignee": nil, "labels": "gt:merge,urgent",
+	})
+	if issue.ID != "gt-abc" || issue.Priority != 1 || issue.Assignee != "" || issue.Type != "bug" {
+		t.Errorf("issue = %+v", issue)
+	}
+	if !reflect.DeepEqual(issue.Labels, []string{"gt:merge", "urgent"}) {
+		t.Errorf("labels = %v", issue.Labels)
+	}
+}
+
+func TestBuildHistory(t *testing.T) {
+	commit := func(hash, date, committer string) map[string]interface{} {
+		return map[string]interface{}{"to_commit": hash, "to_commit_date": date, "committer": committer, "message": "bd: update\n"}
+	}
+	with := func(row map[string]interface{}, kv ...interface{}) map[string]interface{} {
+		for i := 0; i < len(kv); i += 2 {
+			row[kv[i].(string)] = kv[i+1]
+		}
+		return row
+	}
+
+	issueRows := []map[string]interface{}{
+		with(commit("c2", "2026-03-02 10:00:00.5", "nux"), "diff_type", "modified",
+			"from_status", "open", "to_status", "in_progress",
+			"from_assignee", nil, "to_assignee", "gastown/polecats/nux",
+			"from_title", "Fix it", "to_title", "Fix it"),
+		with(commit("WORKING", "", ""), "diff_type", "modified",
+			"from_status", "in_progress", "to_status", "closed"),
+		with(commit("c1", "2026-03-01 09:00:00", "mayor"), "diff_type", "added",
+			"from_title", nil, "to_title", "Fix it", "to_status", "open", "to_priority", float64(2)),
+	}
+	labelRows := []map[string]interface{}{
+		with(commit("c2", "2026-03-02 10:00:00.5", "nux"), "from_label", nil, "to_label", "urgent"),
+	}
+	depRows := []map[string]interface{}{
+		with(commit("c3", "2026-03-02 11:00:00", "mayor"), "from_depends_on_id", "external:bd:bd-9", "from_type", "blocks",
+			"to_depends_on_id", nil, "to_type", nil),
+	}
+
+	entries := buildHistory(issueRows, labelRows, depRows)
+	var commits []string
+	for _, e := range entries {
+		commits = append(commits, e.Commit)
+	}
+	if !reflect.DeepEqual(commits, []string{"c1", "c2", "c3", "WORKING"}) {
+		t.Fatalf("commit order = %v", commits)
+	}
+
+	created := entries[0]
+	if !created.Created || created.Committer != "mayor" || created.Message != "bd: update" {
+		t.Errorf("created entry = %+v", created)
+	}
+	if !reflect.DeepEqual(created.Changes, []FieldChange{
+		{Field: "title", To: "Fix it"}, {Field: "status", To: "open"}, {Field: "priority", To: "2"},
+	}) {
+		t.Errorf("created changes = %+v", created.Changes)
+	}
+
+	if got := entries[1].Changes; !reflect.DeepEqual(got, []FieldChange{
+		{Field: "status", From: "open", To: "in_progress"},
+		{Field: "assignee", To: "gastown/polecats/nux"},
+		{Field: "label", To: "urgent"},
+	}) {
+		t.Errorf("c2 changes = %+v", got)
+	}
+	if got := entries[2].Changes; !reflect.DeepEqual(got, []FieldChange{{Field: "dependency", From: "blocks bd-9"}}) {
+		t.Errorf("c3 changes = %+v", got)
+	}
+	if !entries[3].Date.IsZero() {
+		t.Errorf("WORKING entry should have no date: %v", entries[3].Date)
+	}
+}
@@ -1,7 +1,9 @@
 // Package events provides event logging for the gt activity feed.
 //
 // Events are written to ~/gt/.events.jsonl (raw audit log) and later
-// curated by the feed daemon into ~/.feed.jsonl (user-facing).
+// curated by the feed daemon into ~/.feed.jsonl (user-facing). The raw log
+// is segmented (see segment.go); use Read for history and Tailer to follow
+// new events.
 package events
 
 import (
@@ -12,6 +14,7 @@ import (
 	"time"
 
 	"github.com/gofrs/flock"
+	"github.com/steveyegge/gastown/internal/redact"
 	"github.com/steveyegge/gastown/internal/workspace"
 )
 
@@ -62,12 +65,16 @@ const (
 	TypeEscalationAcked  = "escalation_acked"
 	TypeEscalationClosed = "escalation_closed"
 	TypePatrolComplete   = "patrol_complete"
+	TypePatrolReceipt    = "patrol_receipt" // Automated patrol decision (see Receipt)
 
 	// Merge queue events (emitted by refinery)
 	TypeMergeStarted = "merge_started"
 	TypeMerged       = "merged"
 	TypeMergeFailed  = "merge_failed"
 	TypeMergeSkipped = "merge_skipped"
+
+	// Convoy events
+	TypeConvoyLanded = "convoy_landed" // all tracked work done, convoy closed
 )
 
 // EventsFile is the name of the raw events log.
@@ -77,7 +84,11 @@ const EventsFile = ".events.jsonl"
 // The event is appended to ~/gt/.events.jsonl.
 // Returns nil if logging fails (events are best-effort).
 func Log(eventType, actor string, payload map[string]interface{}, visibility string) error {
-	event := Event{
+	return write(newEvent(eventType, actor, payload, visibility))
+}
+
+func newEvent(eventType, actor string, payload map[string]interface{}, visibility string) Event {
+	return Event{
 		Timestamp:  time.Now().UTC().Format(time.RFC3339),
 		Source:     "gt",
 		Type:       eventType,
@@ -85,7 +96,6 @@ func Log(eventType, actor string, payload map[string]interface{}, visibility str
 		Payload:    payload,
 		Visibility: visibility,
 	}
-	return write(event)
 }
 
 // LogFeed is a convenience wrapper for feed-visible events.
@@ -98,6 +108,12 @@ func LogAudit(eventType, actor string, payload map[string]interface{}) error {
 	return Log(eventType, actor, payload, VisibilityAudit)
 }
 
+// LogFeedIn is like LogFeed but writes to townRoot's events log instead of
+// resolving the town from the working directory.
+func LogFeedIn(townRoot, eventType, actor string, payload map[string]interface{}) error {
+	return writeIn(townRoot, newEvent(eventType, actor, payload, VisibilityFeed))
+}
+
 // write appends an event to the events file.
 // Uses flock for cross-process synchronization — sync.Mutex only protects
 // intra-process goroutines, but multiple gt processes write concurrently.
@@ -108,9 +124,24 @@ func write(event Event) error {
 		// Silently ignore - we're not in a Gas Town workspace
 		return nil
 	}
+	return writeIn(townRoot, event)
+}
 
+// writeIn appends an event to townRoot's events file, or hands it to the
+// sandbox host when running sandboxed (see EnvRelay).
+func writeIn(townRoot string, event Event) error {
+	if socket := os.Getenv(EnvRelay); socket != "" {
+		return relay(socket, event)
+	}
 	eventsPath := filepath.Join(townRoot, EventsFile)
 
+	// Scrub secrets from payload values before they reach disk
+	if event.Payload != nil {
+		var counts redact.Counts
+		event.Payload, counts = redact.ForTown(townRoot).Map(event.Payload)
+		_ = redact.Record(townRoot, redact.SinkEvents, counts)
+	}
+
 	// Marshal event to JSON
 	data, err := json.Marshal(event)
 	if err != nil {
@@ -118,28 +149,61 @@ func write(event Event) error {
 	}
 	data = append(data, '\n')
 
-	// Acquire cross-process file lock
+	rotated, err := appendEvent(eventsPath, data, time.Now())
+	if err != nil {
+		return err
+	}
+	if rotated {
+		// Compress outside the lock so other writers aren't held up.
+		_ = Seal(eventsPath)
+	}
+	return nil
+}
+
+// Lock takes the cross-process lock held by writers while they rotate and
+// append to the events file at eventsPath. Anything that rewrites the
+// active segment must hold it from reading the segment until replacing
+// it, or it can resurrect rotated events and drop appended ones.
+func Lock(eventsPath string) (*flock.Flock, error) {
 	fl := flock.New(eventsPath + ".lock")
 	if err := fl.Lock(); err != nil {
-		return fmt.Errorf("acquiring events file lock: %w", err)
+		return nil, fmt.Errorf("acquiring events file lock: %w", err)
+	}
+	return fl, nil
+}
+
+// appendEvent appends one encoded event to the active segment, first
+// rotating it if it's due. Returns whether it rotated.
+func appendEvent(eventsPath string, data []byte, now time.Time) (bool, error) {
+	// Acquire cross-process file lock
+	fl, err := Lock(eventsPath)
+	if err != nil {
+		return false, err
 	}
 	defer fl.Unlock() //nolint:errcheck // best-effort unlock
 
+	// A writer that can't rotate (e.g. without write access to the town
+	// root) keeps appending; the next writer that can will rotate.
+	rotated, err := rotateIfNeeded(eventsPath, now)
+	if err != nil {
+		rotated = false
+	}
+
 	f, err := os.OpenFile(eventsPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644) //nolint:gosec // G302: events file is non-sensitive operational data
 	if err != nil {
-		return fmt.Errorf("opening events file: %w", err)
+		return rotated, fmt.Errorf("opening events file: %w", err)
 	}
 
 	if _, err := f.Write(data); err != nil {
 		_ = f.Close()
-		return fmt.Errorf("writing event: %w", err)
+		return rotated, fmt.Errorf("writing event: %w", err)
 	}
 
 	if err := f.Close(); err != nil {
-		return fmt.Errorf("closing events file: %w", err)
+		return rotated, fmt.Errorf("closing events file: %w", err)
 	}
 
-	return nil
+	return rotated, nil
 }
 
 // Payload helpers for common event structures.
@@ -170,6 +234,14 @@ func HandoffPayload(subject string, toSession bool) map[string]interface{} {
 	return p
 }
 
+// ConvoyPayload creates a payload for convoy events.
+func ConvoyPayload(convoyID, title string) map[string]interface{} {
+	return map[string]interface{}{
+		"convoy_id": convoyID,
+		"title":     title,
+	}
+}
+
 // DonePayload creates a payload for done events.
 func DonePayload(beadID, branch string) map[string]interface{} {
 	return map[string]interface{}{
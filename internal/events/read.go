@@ -0,0 +1,161 @@
+package events
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Query narrows a read of the events log. Zero fields match everything.
+type Query struct {
+	Since time.Time
+	Until time.Time
+	Types []string
+}
+
+// matches reports whether an event passes the query's filters. Events
+// with unparseable timestamps only match queries without a time range.
+func (q Query) matches(e *Event) bool {
+	if len(q.Types) > 0 {
+		found := false
+		for _, t := range q.Types {
+			if e.Type == t {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if q.Since.IsZero() && q.Until.IsZero() {
+		return true
+	}
+	ts, err := time.Parse(time.RFC3339, e.Timestamp)
+	if err != nil {
+		return false
+	}
+	return (q.Since.IsZero() || !ts.Before(q.Since)) && (q.Until.IsZero() || !ts.After(q.Until))
+}
+
+// Read calls fn for each event in the events log at eventsPath that
+// matches q: closed segments oldest first, then the active segment.
+// Segments whose index rules them out are not opened. Malformed lines are
+// skipped. Reading stops early when fn returns false.
+func Read(eventsPath string, q Query, fn func(Event) bool) error {
+	return ReadLines(eventsPath, q, func(line []byte) bool {
+		var e Event
+		if json.Unmarshal(line, &e) != nil || !q.matches(&e) {
+			return true
+		}
+		return fn(e)
+	})
+}
+
+// ReadLines is like Read but passes raw lines, filtering only whole
+// segments by q; callers filter individual lines themselves. The slice
+// passed to fn is only valid until fn returns.
+func ReadLines(eventsPath string, q Query, fn func(line []byte) bool) error {
+	return ReadPositioned(eventsPath, q, func(_ Position, line []byte) bool {
+		return fn(line)
+	})
+}
+
+// Position locates a raw line of the events log: the file holding it,
+// relative to the log's directory, and its 1-based line number there.
+// Sealed segments keep the line numbering of the file they were sealed from.
+type Position struct {
+	File string
+	Line int
+}
+
+func (p Position) String() string {
+	return fmt.Sprintf("%s:%d", p.File, p.Line)
+}
+
+// ReadPositioned is like ReadLines but also passes each line's position.
+func ReadPositioned(eventsPath string, q Query, fn func(pos Position, line []byte) bool) error {
+	segments, err := Segments(eventsPath)
+	if err != nil {
+		return err
+	}
+	dir := SegmentsDir(eventsPath)
+	for _, seg := range segments {
+		if !seg.matches(q) {
+			continue
+		}
+		name := seg.File
+		r, err := openSegment(dir, name)
+		if os.IsNotExist(err) && !seg.Sealed() {
+			// Sealed since we listed it
+			name = strings.TrimSuffix(seg.File, segmentExt) + sealedExt
+			r, err = openSegment(dir, name)
+		}
+		if os.IsNotExist(err) {
+			continue // removed by a concurrent prune
+		}
+		if err != nil {
+			return fmt.Errorf("opening event segment %s: %w", seg.File, err)
+		}
+		file := filepath.Join(SegmentDir, name)
+		more, err := scanLines(r, func(n int, line []byte) bool {
+			return fn(Position{File: file, Line: n}, line)
+		})
+		_ = r.Close()
+		if err != nil {
+			return fmt.Errorf("reading event segment %s: %w", seg.File, err)
+		}
+		if !more {
+			return nil
+		}
+	}
+
+	f, err := os.Open(eventsPath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	file := filepath.Base(eventsPath)
+	_, err = scanLines(f, func(n int, line []byte) bool {
+		return fn(Position{File: file, Line: n}, line)
+	})
+	return err
+}
+
+// ReadSegment calls fn for each raw line of one closed segment.
+func ReadSegment(eventsPath string, seg Segment, fn func(line []byte) bool) error {
+	r, err := openSegment(SegmentsDir(eventsPath), seg.File)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	_, err = scanLines(r, func(_ int, line []byte) bool { return fn(line) })
+	return err
+}
+
+// scanLines feeds non-empty lines and their 1-based line numbers to fn,
+// reporting whether fn wanted more.
+func scanLines(r io.Reader, fn func(n int, line []byte) bool) (bool, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	n := 0
+	for scanner.Scan() {
+		n++
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		if !fn(n, line) {
+			return false, nil
+		}
+	}
+	return true, scanner.Err()
+}
@@ -0,0 +1,110 @@
+package events
+
+import (
+	"encoding/json"
+	"path"
+	"strings"
+	"time"
+)
+
+// Receipt explains one automated patrol decision: what the patrol observed,
+// the thresholds it applied, what it concluded and what it did about it.
+// Receipts are logged as TypePatrolReceipt events so that when an agent is
+// killed, nudged or unhooked, `gt patrol why` can show which rule fired.
+type Receipt struct {
+	Agent      string                 `json:"agent,omitempty"`   // subject address, e.g. gastown/polecats/max
+	Session    string                 `json:"session,omitempty"` // subject tmux session
+	Bead       string                 `json:"bead,omitempty"`    // work bead involved, if any
+	Rule       string                 `json:"rule"`              // which check fired, e.g. "stale_hook"
+	Inputs     map[string]interface{} `json:"inputs,omitempty"`
+	Thresholds map[string]interface{} `json:"thresholds,omitempty"`
+	Decision   string                 `json:"decision"` // what the rule concluded
+	Action     string                 `json:"action"`   // what was done; "none" if nothing
+	Reason     string                 `json:"reason,omitempty"`
+	Error      string                 `json:"error,omitempty"`
+
+	// Shadow marks a decision made in patrol shadow mode: Action is what
+	// would have been done, and nothing was.
+	Shadow bool `json:"shadow,omitempty"`
+}
+
+// LogReceipt records a patrol decision made by actor in townRoot's events
+// log.
+func LogReceipt(townRoot, actor string, r Receipt) error {
+	return writeIn(townRoot, newEvent(TypePatrolReceipt, actor, r.Payload(), VisibilityAudit))
+}
+
+// Payload returns the receipt as an event payload.
+func (r Receipt) Payload() map[string]interface{} {
+	p := map[string]interface{}{
+		"rule":     r.Rule,
+		"decision": r.Decision,
+		"action":   r.Action,
+	}
+	for k, v := range map[string]string{
+		"agent":   r.Agent,
+		"session": r.Session,
+		"bead":    r.Bead,
+		"reason":  r.Reason,
+		"error":   r.Error,
+	} {
+		if v != "" {
+			p[k] = v
+		}
+	}
+	if len(r.Inputs) > 0 {
+		p["inputs"] = r.Inputs
+	}
+	if len(r.Thresholds) > 0 {
+		p["thresholds"] = r.Thresholds
+	}
+	if r.Shadow {
+		p["shadow"] = true
+	}
+	return p
+}
+
+// ReceiptEvent is a receipt read back from the events log.
+type ReceiptEvent struct {
+	Time  time.Time `json:"ts"`
+	Actor string    `json:"actor"`
+	Receipt
+}
+
+// ReceiptFromEvent decodes a TypePatrolReceipt event.
+func ReceiptFromEvent(e Event) (ReceiptEvent, bool) {
+	if e.Type != TypePatrolReceipt {
+		return ReceiptEvent{}, false
+	}
+	data, err := json.Marshal(e.Payload)
+	if err != nil {
+		return ReceiptEvent{}, false
+	}
+	re := ReceiptEvent{Actor: e.Actor}
+	if err := json.Unmarshal(data, &re.Receipt); err != nil {
+		return ReceiptEvent{}, false
+	}
+	re.Time, _ = time.Parse(time.RFC3339, e.Timestamp)
+	return re, true
+}
+
+// Acted reports whether the receipt records an action, taken or (in shadow
+// mode) withheld.
+func (r Receipt) Acted() bool {
+	return r.Action != "" && r.Action != "none"
+}
+
+// Concerns reports whether the receipt is about target, which may be an
+// agent address ("gastown/polecats/max" or just "max"), a tmux session
+// name, or a bead ID.
+func (r Receipt) Concerns(target string) bool {
+	target = strings.TrimSuffix(target, "/")
+	if target == "" {
+		return false
+	}
+	agent := strings.TrimSuffix(r.Agent, "/")
+	return target == agent ||
+		(agent != "" && target == path.Base(agent)) ||
+		target == r.Session ||
+		target == r.Bead
+}
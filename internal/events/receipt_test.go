@@ -0,0 +1,72 @@
+package events
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestReceipt_RoundTrip(t *testing.T) {
+	r := Receipt{
+		Agent:      "gastown/polecats/max",
+		Session:    "gt-max",
+		Rule:       "stale_hook",
+		Inputs:     map[string]interface{}{"agent_alive": false},
+		Thresholds: map[string]interface{}{"max_age": "1h0m0s"},
+		Decision:   "session-dead",
+		Action:     "unhooked",
+	}
+
+	// Through JSON, as the events log stores it
+	data, err := json.Marshal(Event{
+		Timestamp: "2026-10-17T12:00:00Z",
+		Type:      TypePatrolReceipt,
+		Actor:     "deacon",
+		Payload:   r.Payload(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var e Event
+	if err := json.Unmarshal(data, &e); err != nil {
+		t.Fatal(err)
+	}
+
+	got, ok := ReceiptFromEvent(e)
+	if !ok {
+		t.Fatal("ReceiptFromEvent rejected a receipt event")
+	}
+	if got.Actor != "deacon" || !got.Time.Equal(time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("actor = %q time = %v", got.Actor, got.Time)
+	}
+	if got.Agent != r.Agent || got.Decision != r.Decision || got.Action != r.Action {
+		t.Errorf("receipt = %+v", got.Receipt)
+	}
+	if got.Inputs["agent_alive"] != false || got.Thresholds["max_age"] != "1h0m0s" {
+		t.Errorf("inputs = %v thresholds = %v", got.Inputs, got.Thresholds)
+	}
+	if _, ok := got.Receipt.Payload()["error"]; ok {
+		t.Error("empty fields should be left out of the payload")
+	}
+
+	if _, ok := ReceiptFromEvent(Event{Type: TypeSling}); ok {
+		t.Error("ReceiptFromEvent accepted a sling event")
+	}
+}
+
+func TestReceipt_Concerns(t *testing.T) {
+	r := Receipt{Agent: "gastown/polecats/max", Session: "gt-max", Bead: "gt-abc"}
+	for _, target := range []string{"gastown/polecats/max", "gastown/polecats/max/", "max", "gt-max", "gt-abc"} {
+		if !r.Concerns(target) {
+			t.Errorf("Concerns(%q) = false", target)
+		}
+	}
+	for _, target := range []string{"", "ace", "gastown/polecats/ace"} {
+		if r.Concerns(target) {
+			t.Errorf("Concerns(%q) = true", target)
+		}
+	}
+	if (Receipt{Bead: "gt-abc"}).Concerns(".") {
+		t.Error("receipt without agent matched \".\"")
+	}
+}
@@ -0,0 +1,72 @@
+package events
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net"
+	"time"
+)
+
+// EnvRelay names the Unix socket a sandboxed session sends its events to.
+// The sandbox host serves it with ServeRelay and appends the events itself,
+// so sandboxed processes need no write access to the log: they couldn't
+// rotate it, and a file bound into the sandbox stops being the active
+// segment as soon as the host rotates it.
+const EnvRelay = "GT_EVENTS_RELAY"
+
+// relayTimeout bounds how long a sandboxed writer waits for the host.
+const relayTimeout = 5 * time.Second
+
+// ServeRelay appends events received on l to townRoot's events log until
+// l is closed. Each connection carries JSON-encoded events, one per line;
+// each is acknowledged with a newline once it is on disk. Lines that
+// aren't events are dropped.
+func ServeRelay(l net.Listener, townRoot string) error {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return err
+		}
+		go serveRelayConn(conn, townRoot)
+	}
+}
+
+func serveRelayConn(conn net.Conn, townRoot string) {
+	defer conn.Close()
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		var event Event
+		if json.Unmarshal(scanner.Bytes(), &event) == nil && event.Type != "" {
+			// Re-encoding on this side keeps one event per line, and
+			// writeIn redacts with the host's view of the town.
+			_ = writeIn(townRoot, event)
+		}
+		if _, err := conn.Write([]byte{'\n'}); err != nil {
+			return
+		}
+	}
+}
+
+// relay sends an event to the sandbox host listening on socket and waits
+// for it to be written.
+func relay(socket string, event Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("marshaling event: %w", err)
+	}
+	conn, err := net.DialTimeout("unix", socket, relayTimeout)
+	if err != nil {
+		return fmt.Errorf("connecting to events relay: %w", err)
+	}
+	defer conn.Close()
+	_ = conn.SetDeadline(time.Now().Add(relayTimeout))
+	if _, err := conn.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("sending event: %w", err)
+	}
+	if _, err := bufio.NewReader(conn).ReadByte(); err != nil {
+		return fmt.Errorf("waiting for events relay: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,455 @@
+package events
+
+// The events log is stored in segments. New events are always appended to
+// the active segment, EventsFile, so appenders and tailers keep working on
+// a single well-known path. When the active segment holds events from an
+// earlier UTC day, or grows past maxSegmentBytes, the next writer moves it
+// into SegmentDir. Closed segments are then sealed: compressed with zstd
+// and recorded in a small index (time range, count and types per segment)
+// that lets readers skip segments a query can't match.
+//
+//	.events.jsonl                      active segment
+//	.events/20261017T000312Z.jsonl.zst sealed segment
+//	.events/index.json                 segment index
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofrs/flock"
+	"github.com/klauspost/compress/zstd"
+)
+
+// SegmentDir is the directory, relative to the town root, of closed segments.
+const SegmentDir = ".events"
+
+const (
+	segmentIndexFile = "index.json"
+	segmentSealLock  = ".seal.lock"
+	segmentExt       = ".jsonl"
+	sealedExt        = ".jsonl.zst"
+)
+
+// maxSegmentBytes rotates the active segment before it reaches this size,
+// even within a day. A variable so tests can lower it.
+var maxSegmentBytes int64 = 64 << 20
+
+// Segment describes a closed segment of the events log. Segments that are
+// not sealed yet have only File set.
+type Segment struct {
+	File   string         `json:"file"` // relative to SegmentDir
+	Start  time.Time      `json:"start"`
+	End    time.Time      `json:"end"`
+	Count  int            `json:"count"`
+	Bytes  int64          `json:"bytes"`        // uncompressed
+	Stored int64          `json:"stored_bytes"` // on disk
+	Types  map[string]int `json:"types,omitempty"`
+}
+
+// Sealed reports whether the segment is compressed and indexed.
+func (s Segment) Sealed() bool {
+	return strings.HasSuffix(s.File, sealedExt)
+}
+
+// matches reports whether the segment may hold events matching q.
+// Unsealed segments have no index entry, so they always may.
+func (s Segment) matches(q Query) bool {
+	if !s.Sealed() || s.Start.IsZero() {
+		return true
+	}
+	if !q.Since.IsZero() && s.End.Before(q.Since) {
+		return false
+	}
+	if !q.Until.IsZero() && s.Start.After(q.Until) {
+		return false
+	}
+	if len(q.Types) > 0 {
+		for _, t := range q.Types {
+			if s.Types[t] > 0 {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// SegmentsDir returns the directory holding the closed segments of the
+// events file at eventsPath.
+func SegmentsDir(eventsPath string) string {
+	return filepath.Join(filepath.Dir(eventsPath), SegmentDir)
+}
+
+// Segments returns the closed segments of the events file at eventsPath,
+// oldest first.
+func Segments(eventsPath string) ([]Segment, error) {
+	dir := SegmentsDir(eventsPath)
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading event segments: %w", err)
+	}
+	index, err := readSegmentIndex(dir)
+	if err != nil {
+		return nil, err
+	}
+	indexed := make(map[string]Segment, len(index))
+	for _, s := range index {
+		indexed[s.File] = s
+	}
+
+	names := make(map[string]bool)
+	for _, e := range entries {
+		names[e.Name()] = true
+	}
+	var segments []Segment
+	for _, e := range entries {
+		name := e.Name()
+		switch {
+		case strings.HasSuffix(name, sealedExt):
+			// A crash mid-seal can leave both; the source wins.
+			if names[strings.TrimSuffix(name, sealedExt)+segmentExt] {
+				continue
+			}
+			seg, ok := indexed[name]
+			if !ok {
+				seg = Segment{File: name}
+			}
+			segments = append(segments, seg)
+		case strings.HasSuffix(name, segmentExt), strings.HasSuffix(name, ".jsonl.gz"):
+			segments = append(segments, Segment{File: name})
+		}
+	}
+	sort.Slice(segments, func(i, j int) bool { return segments[i].File < segments[j].File })
+	return segments, nil
+}
+
+// rotateIfNeeded moves the active segment into SegmentDir when it holds
+// events from before now's UTC day or has reached maxSegmentBytes. The
+// caller must hold the events lock. Returns whether it rotated.
+func rotateIfNeeded(eventsPath string, now time.Time) (bool, error) {
+	info, err := os.Stat(eventsPath)
+	if err != nil || info.Size() == 0 {
+		return false, nil
+	}
+	first, ok := segmentStart(eventsPath, info)
+	if ok && !sameUTCDay(first, now) {
+		// The cache is keyed by inode, which a newer active segment can
+		// reuse once an old one is sealed; confirm before rotating.
+		first, ok = firstEventTime(eventsPath)
+	}
+	if info.Size() < maxSegmentBytes && (!ok || sameUTCDay(first, now)) {
+		return false, nil
+	}
+	if !ok {
+		first = info.ModTime()
+	}
+
+	dir := SegmentsDir(eventsPath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return false, fmt.Errorf("creating segment dir: %w", err)
+	}
+	stem := first.UTC().Format("20060102T150405Z")
+	name := stem + segmentExt
+	for i := 1; segmentExists(dir, name); i++ {
+		name = fmt.Sprintf("%s-%d%s", stem, i, segmentExt)
+	}
+	if err := os.Rename(eventsPath, filepath.Join(dir, name)); err != nil {
+		return false, fmt.Errorf("rotating events file: %w", err)
+	}
+	return true, nil
+}
+
+func segmentExists(dir, name string) bool {
+	for _, n := range []string{name, strings.TrimSuffix(name, segmentExt) + sealedExt} {
+		if _, err := os.Stat(filepath.Join(dir, n)); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+func sameUTCDay(a, b time.Time) bool {
+	ay, am, ad := a.UTC().Date()
+	by, bm, bd := b.UTC().Date()
+	return ay == by && am == bm && ad == bd
+}
+
+// segmentStarts caches the first event time of active segments by path, so
+// appends don't reread the first line. An entry holds only while the path
+// is still the same file: rotation and rewrites replace it.
+var segmentStarts = struct {
+	sync.Mutex
+	m map[string]cachedStart
+}{m: make(map[string]cachedStart)}
+
+type cachedStart struct {
+	file  os.FileInfo
+	first time.Time
+}
+
+// segmentStart is firstEventTime for the active segment, whose current
+// stat is info, served from segmentStarts when possible.
+func segmentStart(eventsPath string, info os.FileInfo) (time.Time, bool) {
+	segmentStarts.Lock()
+	defer segmentStarts.Unlock()
+	if c, ok := segmentStarts.m[eventsPath]; ok && os.SameFile(c.file, info) {
+		return c.first, true
+	}
+	first, ok := firstEventTime(eventsPath)
+	if ok {
+		segmentStarts.m[eventsPath] = cachedStart{file: info, first: first}
+	}
+	return first, ok
+}
+
+// firstEventTime returns the timestamp of the first event in a file.
+func firstEventTime(path string) (time.Time, bool) {
+	f, err := os.Open(path)
+	if err != nil {
+		return time.Time{}, false
+	}
+	defer f.Close()
+	line, err := bufio.NewReaderSize(f, 64*1024).ReadBytes('\n')
+	if err != nil && len(line) == 0 {
+		return time.Time{}, false
+	}
+	var e struct {
+		Timestamp string `json:"ts"`
+	}
+	if json.Unmarshal(line, &e) != nil {
+		return time.Time{}, false
+	}
+	ts, err := time.Parse(time.RFC3339, e.Timestamp)
+	return ts, err == nil
+}
+
+// Seal compresses and indexes the closed segments of the events file at
+// eventsPath that aren't sealed yet. Writers seal after rotating; the
+// daemon seals periodically to catch segments left by interrupted writers.
+// If another process is already sealing, Seal returns without waiting.
+func Seal(eventsPath string) error {
+	dir := SegmentsDir(eventsPath)
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return nil
+	}
+	fl := flock.New(filepath.Join(dir, segmentSealLock))
+	locked, err := fl.TryLock()
+	if err != nil {
+		return fmt.Errorf("acquiring seal lock: %w", err)
+	}
+	if !locked {
+		return nil
+	}
+	defer fl.Unlock() //nolint:errcheck // best-effort unlock
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("reading event segments: %w", err)
+	}
+	for _, e := range entries {
+		if !strings.HasSuffix(e.Name(), segmentExt) {
+			continue
+		}
+		if err := sealSegment(dir, e.Name()); err != nil {
+			return fmt.Errorf("sealing %s: %w", e.Name(), err)
+		}
+	}
+	return nil
+}
+
+// sealSegment compresses one segment, indexes it, then removes the source.
+// Each step is safe to repeat if a previous attempt was interrupted.
+func sealSegment(dir, name string) error {
+	src, err := os.Open(filepath.Join(dir, name))
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	sealedName := strings.TrimSuffix(name, segmentExt) + sealedExt
+	tmpPath := filepath.Join(dir, sealedName+".tmp")
+	dst, err := os.Create(tmpPath)
+	if err != nil {
+		return err
+	}
+	enc, err := zstd.NewWriter(dst)
+	if err != nil {
+		_ = dst.Close()
+		_ = os.Remove(tmpPath)
+		return err
+	}
+
+	seg := Segment{File: sealedName, Types: make(map[string]int)}
+	var writeErr error
+	scanner := bufio.NewScanner(src)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		seg.Bytes += int64(len(line)) + 1
+		if _, writeErr = enc.Write(line); writeErr == nil {
+			_, writeErr = enc.Write([]byte{'\n'})
+		}
+		if writeErr != nil {
+			break
+		}
+		var e struct {
+			Timestamp string `json:"ts"`
+			Type      string `json:"type"`
+		}
+		if json.Unmarshal(line, &e) != nil {
+			continue
+		}
+		seg.Count++
+		seg.Types[e.Type]++
+		if ts, err := time.Parse(time.RFC3339, e.Timestamp); err == nil {
+			if seg.Start.IsZero() || ts.Before(seg.Start) {
+				seg.Start = ts
+			}
+			if ts.After(seg.End) {
+				seg.End = ts
+			}
+		}
+	}
+	err = writeErr
+	if err == nil {
+		err = scanner.Err()
+	}
+	if closeErr := enc.Close(); err == nil {
+		err = closeErr
+	}
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Rename(tmpPath, filepath.Join(dir, sealedName)); err != nil {
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	if info, err := os.Stat(filepath.Join(dir, sealedName)); err == nil {
+		seg.Stored = info.Size()
+	}
+
+	if err := updateSegmentIndex(dir, func(index []Segment) []Segment {
+		return append(withoutSegment(index, sealedName), seg)
+	}); err != nil {
+		return err
+	}
+	_ = src.Close()
+	return os.Remove(filepath.Join(dir, name))
+}
+
+// RemoveSegment deletes a closed segment and its index entry. Used by
+// retention pruning.
+func RemoveSegment(eventsPath string, seg Segment) error {
+	dir := SegmentsDir(eventsPath)
+	fl := flock.New(filepath.Join(dir, segmentSealLock))
+	if err := fl.Lock(); err != nil {
+		return fmt.Errorf("acquiring seal lock: %w", err)
+	}
+	defer fl.Unlock() //nolint:errcheck // best-effort unlock
+
+	if err := os.Remove(filepath.Join(dir, seg.File)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return updateSegmentIndex(dir, func(index []Segment) []Segment {
+		return withoutSegment(index, seg.File)
+	})
+}
+
+func withoutSegment(index []Segment, file string) []Segment {
+	kept := index[:0]
+	for _, s := range index {
+		if s.File != file {
+			kept = append(kept, s)
+		}
+	}
+	return kept
+}
+
+func readSegmentIndex(dir string) ([]Segment, error) {
+	data, err := os.ReadFile(filepath.Join(dir, segmentIndexFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading segment index: %w", err)
+	}
+	var index []Segment
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("parsing segment index: %w", err)
+	}
+	return index, nil
+}
+
+// updateSegmentIndex rewrites the index atomically. The caller must hold
+// the seal lock.
+func updateSegmentIndex(dir string, update func([]Segment) []Segment) error {
+	index, err := readSegmentIndex(dir)
+	if err != nil {
+		return err
+	}
+	index = update(index)
+	sort.Slice(index, func(i, j int) bool { return index[i].File < index[j].File })
+	data, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := filepath.Join(dir, segmentIndexFile+".tmp")
+	if err := os.WriteFile(tmp, data, 0644); err != nil { //nolint:gosec // G306: index is non-sensitive
+		return fmt.Errorf("writing segment index: %w", err)
+	}
+	if err := os.Rename(tmp, filepath.Join(dir, segmentIndexFile)); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("writing segment index: %w", err)
+	}
+	return nil
+}
+
+// openSegment opens a closed segment for reading, decompressing it if
+// sealed. Gzip segments (.jsonl.gz) are read too, for segments compressed
+// by hand or by external tooling.
+func openSegment(dir, name string) (io.ReadCloser, error) {
+	f, err := os.Open(filepath.Join(dir, name))
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case strings.HasSuffix(name, sealedExt):
+		dec, err := zstd.NewReader(f)
+		if err != nil {
+			_ = f.Close()
+			return nil, err
+		}
+		return &segmentReader{Reader: dec, close: func() error { dec.Close(); return f.Close() }}, nil
+	case strings.HasSuffix(name, ".gz"):
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			_ = f.Close()
+			return nil, err
+		}
+		return &segmentReader{Reader: gz, close: func() error { _ = gz.Close(); return f.Close() }}, nil
+	}
+	return f, nil
+}
+
+type segmentReader struct {
+	io.Reader
+	close func() error
+}
+
+func (r *segmentReader) Close() error { return r.close() }
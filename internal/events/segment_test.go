@@ -0,0 +1,237 @@
+package events
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func appendTestEvent(t *testing.T, eventsPath string, typ string, ts time.Time) {
+	t.Helper()
+	data, err := json.Marshal(Event{Timestamp: ts.UTC().Format(time.RFC3339), Type: typ, Actor: "mayor"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rotated, err := appendEvent(eventsPath, append(data, '\n'), ts)
+	if err != nil {
+		t.Fatalf("appendEvent: %v", err)
+	}
+	if rotated {
+		if err := Seal(eventsPath); err != nil {
+			t.Fatalf("Seal: %v", err)
+		}
+	}
+}
+
+func readTypes(t *testing.T, eventsPath string, q Query) []string {
+	t.Helper()
+	var types []string
+	err := Read(eventsPath, q, func(e Event) bool {
+		types = append(types, e.Type)
+		return true
+	})
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	return types
+}
+
+func TestAppendEvent_RotatesByDay(t *testing.T) {
+	eventsPath := filepath.Join(t.TempDir(), EventsFile)
+	day1 := time.Date(2026, 10, 16, 23, 0, 0, 0, time.UTC)
+	day2 := day1.Add(2 * time.Hour)
+
+	appendTestEvent(t, eventsPath, "sling", day1)
+	appendTestEvent(t, eventsPath, "hook", day1.Add(time.Minute))
+	appendTestEvent(t, eventsPath, "done", day2)
+
+	segs, err := Segments(eventsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(segs) != 1 {
+		t.Fatalf("got %d segments, want 1: %+v", len(segs), segs)
+	}
+	seg := segs[0]
+	if !seg.Sealed() || !strings.HasSuffix(seg.File, sealedExt) {
+		t.Errorf("segment %s not sealed", seg.File)
+	}
+	if seg.Count != 2 || seg.Types["sling"] != 1 || seg.Types["hook"] != 1 {
+		t.Errorf("segment index = %+v", seg)
+	}
+	if !seg.Start.Equal(day1) || !seg.End.Equal(day1.Add(time.Minute)) {
+		t.Errorf("segment range = %v..%v", seg.Start, seg.End)
+	}
+	if _, err := os.Stat(filepath.Join(SegmentsDir(eventsPath), strings.TrimSuffix(seg.File, sealedExt)+segmentExt)); !os.IsNotExist(err) {
+		t.Errorf("uncompressed source left behind: %v", err)
+	}
+
+	if got := readTypes(t, eventsPath, Query{}); strings.Join(got, ",") != "sling,hook,done" {
+		t.Errorf("Read = %v", got)
+	}
+}
+
+func TestAppendEvent_RotatesBySize(t *testing.T) {
+	old := maxSegmentBytes
+	maxSegmentBytes = 1
+	defer func() { maxSegmentBytes = old }()
+
+	eventsPath := filepath.Join(t.TempDir(), EventsFile)
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < 3; i++ {
+		// Same second, so rotated names collide
+		appendTestEvent(t, eventsPath, "nudge", now)
+	}
+
+	segs, err := Segments(eventsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(segs) != 2 || segs[0].File == segs[1].File {
+		t.Fatalf("segments = %+v", segs)
+	}
+	if got := readTypes(t, eventsPath, Query{}); len(got) != 3 {
+		t.Errorf("Read returned %d events, want 3", len(got))
+	}
+}
+
+func TestRead_FiltersByQuery(t *testing.T) {
+	eventsPath := filepath.Join(t.TempDir(), EventsFile)
+	base := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+	appendTestEvent(t, eventsPath, "sling", base)
+	appendTestEvent(t, eventsPath, "mail", base.Add(24*time.Hour))
+	appendTestEvent(t, eventsPath, "sling", base.Add(48*time.Hour))
+
+	got := readTypes(t, eventsPath, Query{Since: base.Add(time.Hour)})
+	if strings.Join(got, ",") != "mail,sling" {
+		t.Errorf("Since: got %v", got)
+	}
+	got = readTypes(t, eventsPath, Query{Types: []string{"sling"}})
+	if len(got) != 2 {
+		t.Errorf("Types: got %v", got)
+	}
+	got = readTypes(t, eventsPath, Query{Until: base.Add(time.Hour), Types: []string{"sling"}})
+	if len(got) != 1 {
+		t.Errorf("Until: got %v", got)
+	}
+
+	// Segments the index rules out are never opened.
+	segs, err := Segments(eventsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(SegmentsDir(eventsPath), segs[0].File), []byte("garbage"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	got = readTypes(t, eventsPath, Query{Types: []string{"mail"}})
+	if len(got) != 1 {
+		t.Errorf("after corrupting skipped segment: got %v", got)
+	}
+}
+
+func TestTailer_FollowsRotationAndRewrite(t *testing.T) {
+	eventsPath := filepath.Join(t.TempDir(), EventsFile)
+	day1 := time.Date(2026, 10, 16, 23, 0, 0, 0, time.UTC)
+
+	tail := NewTailer(eventsPath)
+	defer tail.Close()
+
+	next := func() string {
+		t.Helper()
+		line, err := tail.Next()
+		if err != nil {
+			t.Fatalf("Next: %v", err)
+		}
+		if line == nil {
+			return ""
+		}
+		var e Event
+		if err := json.Unmarshal(line, &e); err != nil {
+			t.Fatal(err)
+		}
+		return e.Type
+	}
+
+	// Created after the tailer started
+	appendTestEvent(t, eventsPath, "sling", day1)
+	if got := next(); got != "sling" {
+		t.Fatalf("first = %q", got)
+	}
+	if got := next(); got != "" {
+		t.Fatalf("expected nothing new, got %q", got)
+	}
+
+	// Appended before rotation, then rotated away
+	appendTestEvent(t, eventsPath, "hook", day1)
+	appendTestEvent(t, eventsPath, "done", day1.Add(2*time.Hour))
+	if got := next(); got != "hook" {
+		t.Errorf("before rotation = %q", got)
+	}
+	if got := next(); got != "done" {
+		t.Errorf("after rotation = %q", got)
+	}
+
+	// Rewritten in place (pruned): resume after the last line seen
+	data, err := os.ReadFile(eventsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmp := eventsPath + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(tmp, eventsPath); err != nil {
+		t.Fatal(err)
+	}
+	appendTestEvent(t, eventsPath, "mail", day1.Add(2*time.Hour))
+	if got := next(); got != "mail" {
+		t.Errorf("after rewrite = %q", got)
+	}
+}
+
+func TestReadPositioned_NamesSegmentAndLine(t *testing.T) {
+	eventsPath := filepath.Join(t.TempDir(), EventsFile)
+	day1 := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	appendTestEvent(t, eventsPath, "sling", day1)
+	appendTestEvent(t, eventsPath, "hook", day1.Add(time.Minute))
+	appendTestEvent(t, eventsPath, "done", day1.Add(24*time.Hour))
+
+	var got []string
+	err := ReadPositioned(eventsPath, Query{}, func(pos Position, _ []byte) bool {
+		got = append(got, pos.String())
+		return true
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sealed := filepath.Join(SegmentDir, "20261016T120000Z"+sealedExt)
+	want := []string{sealed + ":1", sealed + ":2", EventsFile + ":1"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("positions = %v, want %v", got, want)
+	}
+}
+
+func TestAppendEvent_SegmentStartFollowsRewrite(t *testing.T) {
+	eventsPath := filepath.Join(t.TempDir(), EventsFile)
+	day1 := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	day2 := day1.Add(24 * time.Hour)
+	appendTestEvent(t, eventsPath, "sling", day1)
+
+	// A retention rewrite replaces the file; its first event is now day2's.
+	data, _ := json.Marshal(Event{Timestamp: day2.Format(time.RFC3339), Type: "hook"})
+	tmp := eventsPath + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(tmp, eventsPath); err != nil {
+		t.Fatal(err)
+	}
+
+	appendTestEvent(t, eventsPath, "done", day2.Add(time.Hour))
+	if segs, _ := Segments(eventsPath); len(segs) != 0 {
+		t.Errorf("rotated on a stale segment start: %+v", segs)
+	}
+}
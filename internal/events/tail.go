@@ -0,0 +1,151 @@
+package events
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"os"
+)
+
+// Tailer follows the active events segment for newly appended lines. It
+// survives rotation: when EventsFile is moved into SegmentDir and a new
+// active segment starts, the Tailer finishes the old file and continues
+// with the new one. When the file is instead rewritten in place of the old
+// one (KRC pruning) or truncated, it resumes after the last line it
+// returned if that line is still there, and from the start otherwise.
+type Tailer struct {
+	path    string
+	file    *os.File
+	reader  *bufio.Reader
+	offset  int64  // bytes consumed from file, including pending
+	pending []byte // incomplete trailing line
+	last    []byte // last complete line returned
+}
+
+// NewTailer starts following path from its current end. If path doesn't
+// exist yet, everything written to it once created is returned.
+func NewTailer(path string) *Tailer {
+	t := &Tailer{path: path}
+	if f, err := os.Open(path); err == nil {
+		if end, err := f.Seek(0, io.SeekEnd); err == nil {
+			t.attach(f, end)
+		} else {
+			_ = f.Close()
+		}
+	}
+	return t
+}
+
+func (t *Tailer) attach(f *os.File, offset int64) {
+	t.file = f
+	t.offset = offset
+	t.pending = nil
+	t.reader = bufio.NewReaderSize(f, 64*1024)
+}
+
+// Next returns the next complete line, without its newline, or nil if no
+// new line is available yet. A partial trailing line is held until its
+// newline is written.
+func (t *Tailer) Next() ([]byte, error) {
+	if t.file == nil {
+		f, err := os.Open(t.path)
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		t.attach(f, 0)
+	}
+
+	if line, err := t.readLine(); line != nil || err != nil {
+		return line, err
+	}
+	if !t.replaced() {
+		return nil, nil
+	}
+	// Drain anything appended to the old file before it was replaced.
+	if line, err := t.readLine(); line != nil || err != nil {
+		return line, err
+	}
+
+	_ = t.file.Close()
+	t.file = nil
+	f, err := os.Open(t.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	t.attach(f, t.resumeOffset(f))
+	return t.readLine()
+}
+
+// readLine returns the next complete line, or nil at end of file.
+func (t *Tailer) readLine() ([]byte, error) {
+	for {
+		chunk, err := t.reader.ReadBytes('\n')
+		t.offset += int64(len(chunk))
+		t.pending = append(t.pending, chunk...)
+		if err == io.EOF {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		line := bytes.TrimRight(t.pending, "\r\n")
+		t.pending = nil
+		if len(line) == 0 {
+			continue
+		}
+		t.last = append(t.last[:0], line...)
+		return line, nil
+	}
+}
+
+// replaced reports whether path no longer names the file being read, or
+// the file was truncated below what has been read.
+func (t *Tailer) replaced() bool {
+	cur, err := t.file.Stat()
+	if err != nil {
+		return true
+	}
+	info, err := os.Stat(t.path)
+	if err != nil {
+		return true
+	}
+	return !os.SameFile(cur, info) || info.Size() < t.offset
+}
+
+// resumeOffset finds where to continue in a replacement file: just after
+// the last line already returned, if present, else the start.
+func (t *Tailer) resumeOffset(f *os.File) int64 {
+	if len(t.last) == 0 {
+		return 0
+	}
+	var offset, resume int64
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		offset += int64(len(line)) + 1
+		if bytes.Equal(line, t.last) {
+			resume = offset
+		}
+	}
+	if _, err := f.Seek(resume, io.SeekStart); err != nil {
+		return 0
+	}
+	return resume
+}
+
+// Close releases the file being followed.
+func (t *Tailer) Close() error {
+	if t.file == nil {
+		return nil
+	}
+	err := t.file.Close()
+	t.file = nil
+	return err
+}
@@ -98,22 +98,19 @@ func (c *Curator) Start() error {
 	c.startOnce.Do(func() {
 		eventsPath := filepath.Join(c.townRoot, events.EventsFile)
 
-		// Open events file, creating if needed
+		// Create events file if needed
 		file, err := os.OpenFile(eventsPath, os.O_RDONLY|os.O_CREATE, 0600)
 		if err != nil {
 			c.startErr = fmt.Errorf("opening events file: %w", err)
 			return
 		}
+		_ = file.Close()
 
-		// Seek to end to only process new events
-		if _, err := file.Seek(0, io.SeekEnd); err != nil {
-			_ = file.Close() //nolint:gosec // G104: best effort cleanup on error
-			c.startErr = fmt.Errorf("seeking to end: %w", err)
-			return
-		}
+		// Follow from the current end to only process new events
+		tail := events.NewTailer(eventsPath)
 
 		c.wg.Add(1)
-		go c.run(file)
+		go c.run(tail)
 	})
 	return c.startErr
 }
@@ -126,11 +123,10 @@ func (c *Curator) Stop() {
 
 // run is the main curator loop.
 // ZFC: No in-memory state to clean up - state is derived from the events file.
-func (c *Curator) run(file *os.File) {
+func (c *Curator) run(tail *events.Tailer) {
 	defer c.wg.Done()
-	defer file.Close()
+	defer tail.Close()
 
-	reader := bufio.NewReader(file)
 	ticker := time.NewTicker(100 * time.Millisecond)
 	defer ticker.Stop()
 
@@ -142,11 +138,11 @@ func (c *Curator) run(file *os.File) {
 		case <-ticker.C:
 			// Read available lines
 			for {
-				line, err := reader.ReadString('\n')
-				if err != nil {
+				line, err := tail.Next()
+				if err != nil || line == nil {
 					break // No more data available
 				}
-				c.processLine(line)
+				c.processLine(string(line))
 			}
 		}
 	}
@@ -279,58 +275,18 @@ func (c *Curator) readRecentFeedEvents(window time.Duration) ([]FeedEvent, error
 	return result, nil
 }
 
-// readRecentEvents reads events from the events file within the given time window.
+// readRecentEvents reads events from the events log within the given time window.
 // ZFC: This is the observable state that replaces in-memory caching.
-// Reads at most tailReadSize bytes from the end to bound memory usage.
+// Closed segments are included, so a window spanning a rotation is complete.
 func (c *Curator) readRecentEvents(window time.Duration) ([]events.Event, error) {
 	eventsPath := filepath.Join(c.townRoot, events.EventsFile)
-	f, err := os.Open(eventsPath)
-	if err != nil {
-		if os.IsNotExist(err) {
-			return nil, nil
-		}
-		return nil, fmt.Errorf("opening events file: %w", err)
-	}
-	defer f.Close()
-
-	info, err := f.Stat()
-	if err != nil {
-		return nil, fmt.Errorf("stat events file: %w", err)
-	}
-	if info.Size() == 0 {
-		return nil, nil
-	}
-
-	seekTo := info.Size() - tailReadSize
-	if seekTo < 0 {
-		seekTo = 0
-	}
-	if _, err := f.Seek(seekTo, io.SeekStart); err != nil {
-		return nil, fmt.Errorf("seeking events file: %w", err)
-	}
-
-	scanner := bufio.NewScanner(f)
-	if seekTo > 0 {
-		scanner.Scan() // skip potential partial first line at cut point
-	}
-
-	cutoff := time.Now().Add(-window)
 	var result []events.Event
-	for scanner.Scan() {
-		var event events.Event
-		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
-			continue
-		}
-		ts, err := time.Parse(time.RFC3339, event.Timestamp)
-		if err != nil {
-			continue
-		}
-		if !ts.Before(cutoff) {
-			result = append(result, event)
-		}
-	}
-	if err := scanner.Err(); err != nil {
-		return result, fmt.Errorf("scanning events file: %w", err)
+	err := events.Read(eventsPath, events.Query{Since: time.Now().Add(-window)}, func(e events.Event) bool {
+		result = append(result, e)
+		return true
+	})
+	if err != nil {
+		return result, fmt.Errorf("reading events: %w", err)
 	}
 	return result, nil
 }
@@ -762,8 +762,8 @@ func TestCurator_ReadRecentEvents_ScannerError(t *testing.T) {
 	data, _ := json.Marshal(ev)
 	f.Write(append(data, '\n'))
 
-	// Oversized line triggers scanner.Err()
-	longLine := make([]byte, 70*1024)
+	// Line over the events reader's 4MB limit triggers scanner.Err()
+	longLine := make([]byte, 5*1024*1024)
 	for i := range longLine {
 		longLine[i] = 'y'
 	}
@@ -777,11 +777,47 @@ func TestCurator_ReadRecentEvents_ScannerError(t *testing.T) {
 	if err == nil {
 		t.Fatal("readRecentEvents should return scanner error for oversized line")
 	}
-	if !strings.Contains(err.Error(), "scanning events file") {
-		t.Errorf("error should mention scanning, got: %v", err)
+	if !strings.Contains(err.Error(), "reading events") {
+		t.Errorf("error should mention reading events, got: %v", err)
 	}
 	// Partial results returned
 	if len(result) != 1 {
 		t.Errorf("expected 1 partial result before scanner error, got %d", len(result))
 	}
 }
+
+// TestCurator_ReadRecentEvents_ClosedSegments verifies that events rotated
+// out of the active segment still count toward the window.
+func TestCurator_ReadRecentEvents_ClosedSegments(t *testing.T) {
+	tmpDir := t.TempDir()
+	eventsPath := filepath.Join(tmpDir, events.EventsFile)
+	segDir := events.SegmentsDir(eventsPath)
+	if err := os.MkdirAll(segDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now().UTC()
+	line := func(ts time.Time, actor string) string {
+		data, _ := json.Marshal(events.Event{Timestamp: ts.Format(time.RFC3339), Type: events.TypeSling, Actor: actor})
+		return string(data) + "\n"
+	}
+	// Rotated: one event inside the window, one outside it
+	rotated := line(now.Add(-3*time.Hour), "stale") + line(now.Add(-30*time.Minute), "rotated")
+	if err := os.WriteFile(filepath.Join(segDir, "rotated.jsonl"), []byte(rotated), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(eventsPath, []byte(line(now, "active")), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := NewCurator(tmpDir).readRecentEvents(time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var actors []string
+	for _, e := range result {
+		actors = append(actors, e.Actor)
+	}
+	if got := strings.Join(actors, ","); got != "rotated,active" {
+		t.Errorf("read %s, want rotated,active", got)
+	}
+}
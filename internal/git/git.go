@@ -669,6 +669,38 @@ func (g *Git) CheckConflicts(source, target string) ([]string, error) {
 	return nil, nil
 }
 
+// MergeTree returns the tree SHA that merging source into target would
+// produce, without touching the index or working tree. Needs git 2.38+.
+func (g *Git) MergeTree(target, source string) (string, error) {
+	out, err := g.run("merge-tree", "--write-tree", target, source)
+	if err != nil {
+		return "", err
+	}
+	tree, _, _ := strings.Cut(out, "\n")
+	return tree, nil
+}
+
+// ChangedFiles returns the paths changed on head since it diverged from base
+// (git diff base...head). Renames are reported as a delete plus an add so
+// callers see both the old and new paths.
+func (g *Git) ChangedFiles(base, head string) ([]string, error) {
+	out, err := g.run("diff", "--name-only", "--no-renames", base+"..."+head)
+	if err != nil {
+		return nil, err
+	}
+	if out == "" {
+		return nil, nil
+	}
+
+	var files []string
+	for _, f := range strings.Split(out, "\n") {
+		if f != "" {
+			files = append(files, f)
+		}
+	}
+	return files, nil
+}
+
 // runMergeCheck runs a git merge command and returns error info from both stdout and stderr.
 // ZFC: Returns GitError with raw output for agent observation.
 func (g *Git) runMergeCheck(args ...string) (string, error) {
@@ -954,6 +986,13 @@ func (g *Git) WorktreePrune() error {
 	return err
 }
 
+// WorktreeRepair fixes worktree administrative links after worktree
+// directories have been moved.
+func (g *Git) WorktreeRepair(paths ...string) error {
+	_, err := g.run(append([]string{"worktree", "repair"}, paths...)...)
+	return err
+}
+
 // Worktree represents a git worktree.
 type Worktree struct {
 	Path   string
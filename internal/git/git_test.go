@@ -423,6 +423,59 @@ func TestCheckConflicts_NoConflict(t *testing.T) {
 	}
 }
 
+func TestChangedFiles(t *testing.T) {
+	dir := initTestRepo(t)
+	g := NewGit(dir)
+	mainBranch, _ := g.CurrentBranch()
+
+	if err := g.CreateBranch("feature"); err != nil {
+		t.Fatalf("CreateBranch: %v", err)
+	}
+	if err := g.Checkout("feature"); err != nil {
+		t.Fatalf("Checkout feature: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "web"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "web", "app.js"), []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cmd := exec.Command("git", "mv", "README.md", "DOCS.md")
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git mv: %v\n%s", err, out)
+	}
+	if err := g.Add("."); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if err := g.Commit("feature work"); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+
+	// Commits on the base after the branch point must not show up.
+	if err := g.Checkout(mainBranch); err != nil {
+		t.Fatalf("Checkout main: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "main.txt"), []byte("m"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Add("main.txt"); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if err := g.Commit("main work"); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+
+	files, err := g.ChangedFiles(mainBranch, "feature")
+	if err != nil {
+		t.Fatalf("ChangedFiles: %v", err)
+	}
+	want := "DOCS.md,README.md,web/app.js"
+	if got := strings.Join(files, ","); got != want {
+		t.Errorf("ChangedFiles = %q, want %q", got, want)
+	}
+}
+
 func TestCheckConflicts_WithConflict(t *testing.T) {
 	dir := initTestRepo(t)
 	g := NewGit(dir)
@@ -0,0 +1,253 @@
+// Package handoff defines the structured handoff document that one agent
+// session leaves for its successor.
+//
+// A handoff document is markdown with a fixed set of "## " sections (goal,
+// done, in progress, next steps, open questions, relevant files, commands
+// to rerun). Documents are sent as handoff mail bodies, so they stay
+// readable in any mail client; Parse recovers the structure, Missing and
+// Score grade completeness, and Render produces the canonical form that
+// gt prime shows the successor. Content under headings that are not part
+// of the schema (collected session state, free-form notes) is preserved.
+package handoff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Section keys, in canonical order.
+const (
+	SectionGoal          = "goal"
+	SectionDone          = "done"
+	SectionInProgress    = "in_progress"
+	SectionNextSteps     = "next_steps"
+	SectionOpenQuestions = "open_questions"
+	SectionFiles         = "files"
+	SectionCommands      = "commands"
+)
+
+// Section describes one part of the schema.
+type Section struct {
+	Key     string
+	Title   string   // canonical heading
+	Aliases []string // other accepted headings, lowercase
+	Weight  int      // contribution to Score; weights sum to 100
+}
+
+// Sections is the handoff schema in render order.
+var Sections = []Section{
+	{SectionGoal, "Goal", []string{"objective", "context"}, 25},
+	{SectionDone, "Done", []string{"completed", "accomplished"}, 10},
+	{SectionInProgress, "In Progress", []string{"in-progress", "wip", "current state"}, 15},
+	{SectionNextSteps, "Next Steps", []string{"next", "todo", "to do"}, 25},
+	{SectionOpenQuestions, "Open Questions", []string{"questions", "blockers", "open issues"}, 5},
+	{SectionFiles, "Relevant Files", []string{"files", "key files"}, 10},
+	{SectionCommands, "Commands to Rerun", []string{"commands", "rerun", "commands to run"}, 10},
+}
+
+// PassingScore is the score below which a handoff is reported as weak.
+const PassingScore = 60
+
+// Doc is a parsed handoff document. Each schema section holds its lines
+// with list markers stripped; Extra holds everything outside the schema,
+// in original order.
+type Doc struct {
+	Sections map[string][]string
+	Extra    string
+}
+
+// New returns an empty document.
+func New() *Doc {
+	return &Doc{Sections: make(map[string][]string)}
+}
+
+// lookupSection maps a heading to a schema key.
+func lookupSection(heading string) (string, bool) {
+	h := strings.ToLower(strings.TrimSpace(strings.TrimRight(heading, ":")))
+	for _, s := range Sections {
+		if h == strings.ToLower(s.Title) || h == s.Key {
+			return s.Key, true
+		}
+		for _, a := range s.Aliases {
+			if h == a {
+				return s.Key, true
+			}
+		}
+	}
+	return "", false
+}
+
+// Parse extracts schema sections from a markdown body. Headings of any
+// level ("#", "##", ...) are recognized. Text that is not under a schema
+// heading is kept verbatim in Extra.
+func Parse(body string) *Doc {
+	d := New()
+	var extra []string
+	current := ""
+	for _, line := range strings.Split(body, "\n") {
+		if heading, ok := headingText(line); ok {
+			if key, ok := lookupSection(heading); ok {
+				current = key
+				if _, seen := d.Sections[key]; !seen {
+					d.Sections[key] = nil
+				}
+				continue
+			}
+			current = ""
+		}
+		if current == "" {
+			extra = append(extra, line)
+			continue
+		}
+		if item := stripListMarker(line); item != "" {
+			d.Sections[current] = append(d.Sections[current], item)
+		}
+	}
+	d.Extra = strings.TrimSpace(strings.Join(extra, "\n"))
+	return d
+}
+
+func headingText(line string) (string, bool) {
+	trimmed := strings.TrimSpace(line)
+	if !strings.HasPrefix(trimmed, "#") {
+		return "", false
+	}
+	text := strings.TrimLeft(trimmed, "#")
+	if text == "" || text[0] != ' ' {
+		return "", false
+	}
+	return strings.TrimSpace(text), true
+}
+
+// stripListMarker trims "- ", "* ", "1. " and surrounding space.
+func stripListMarker(line string) string {
+	s := strings.TrimSpace(line)
+	if s == "-" || s == "*" || strings.HasPrefix(s, "```") {
+		return "" // unfilled template bullet or code fence
+	}
+	if strings.HasPrefix(s, "- ") || strings.HasPrefix(s, "* ") {
+		return strings.TrimSpace(s[2:])
+	}
+	if i := strings.Index(s, ". "); i > 0 && i <= 3 {
+		digits := true
+		for _, r := range s[:i] {
+			if r < '0' || r > '9' {
+				digits = false
+				break
+			}
+		}
+		if digits {
+			return strings.TrimSpace(s[i+2:])
+		}
+	}
+	return s
+}
+
+// Add appends items to a section, skipping blanks.
+func (d *Doc) Add(key string, items ...string) {
+	for _, item := range items {
+		if item = strings.TrimSpace(item); item != "" {
+			d.Sections[key] = append(d.Sections[key], item)
+		}
+	}
+}
+
+// Has reports whether a section has content.
+func (d *Doc) Has(key string) bool {
+	return len(d.Sections[key]) > 0
+}
+
+// IsStructured reports whether any schema section has content.
+func (d *Doc) IsStructured() bool {
+	for _, s := range Sections {
+		if d.Has(s.Key) {
+			return true
+		}
+	}
+	return false
+}
+
+// Missing returns the titles of empty sections, in schema order.
+// "None" is an acceptable answer for any section and does not count as
+// missing: an explicit "no open questions" is still information.
+func (d *Doc) Missing() []string {
+	var missing []string
+	for _, s := range Sections {
+		if !d.Has(s.Key) {
+			missing = append(missing, s.Title)
+		}
+	}
+	return missing
+}
+
+// Score grades completeness from 0 to 100 using the section weights.
+func (d *Doc) Score() int {
+	score := 0
+	for _, s := range Sections {
+		if d.Has(s.Key) {
+			score += s.Weight
+		}
+	}
+	return score
+}
+
+// Merge adds o's section items and extra text to d.
+func (d *Doc) Merge(o *Doc) {
+	for _, s := range Sections {
+		d.Add(s.Key, o.Sections[s.Key]...)
+	}
+	if o.Extra != "" {
+		if d.Extra != "" {
+			d.Extra += "\n\n"
+		}
+		d.Extra += o.Extra
+	}
+}
+
+// Render returns the canonical markdown form: schema sections that have
+// content, in order, then any extra text.
+func (d *Doc) Render() string {
+	var b strings.Builder
+	for _, s := range Sections {
+		items := d.Sections[s.Key]
+		if len(items) == 0 {
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "## %s\n", s.Title)
+		if s.Key == SectionGoal && len(items) == 1 {
+			fmt.Fprintf(&b, "%s\n", items[0])
+			continue
+		}
+		for _, item := range items {
+			if s.Key == SectionCommands || s.Key == SectionFiles {
+				fmt.Fprintf(&b, "- `%s`\n", strings.Trim(item, "`"))
+			} else {
+				fmt.Fprintf(&b, "- %s\n", item)
+			}
+		}
+	}
+	if d.Extra != "" {
+		if b.Len() > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(d.Extra)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// Template returns an empty document with every section heading, for
+// agents writing a handoff by hand.
+func Template() string {
+	var b strings.Builder
+	for i, s := range Sections {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "## %s\n- \n", s.Title)
+	}
+	return b.String()
+}
@@ -0,0 +1,167 @@
+package handoff
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/events"
+)
+
+func TestSectionWeightsSumTo100(t *testing.T) {
+	total := 0
+	for _, s := range Sections {
+		total += s.Weight
+	}
+	if total != 100 {
+		t.Errorf("section weights sum to %d, want 100", total)
+	}
+}
+
+func TestParse(t *testing.T) {
+	body := `Some preamble.
+
+## Goal
+Fix the flaky merge test
+
+### next:
+1. Add a lock around the fixture
+2. Rerun CI
+
+## Hooked Work
+gt-abc: something
+
+## Commands to Rerun
+` + "```bash" + `
+go test ./internal/refinery/...
+` + "```" + `
+
+## Open Questions
+- None
+`
+	d := Parse(body)
+	want := map[string][]string{
+		SectionGoal:          {"Fix the flaky merge test"},
+		SectionNextSteps:     {"Add a lock around the fixture", "Rerun CI"},
+		SectionCommands:      {"go test ./internal/refinery/..."},
+		SectionOpenQuestions: {"None"},
+	}
+	for key, items := range want {
+		if !reflect.DeepEqual(d.Sections[key], items) {
+			t.Errorf("section %s = %q, want %q", key, d.Sections[key], items)
+		}
+	}
+	if !strings.Contains(d.Extra, "Some preamble.") || !strings.Contains(d.Extra, "## Hooked Work\ngt-abc: something") {
+		t.Errorf("extra lost non-schema content: %q", d.Extra)
+	}
+	if got := d.Missing(); !reflect.DeepEqual(got, []string{"Done", "In Progress", "Relevant Files"}) {
+		t.Errorf("Missing() = %v", got)
+	}
+	if got := d.Score(); got != 65 {
+		t.Errorf("Score() = %d, want 65", got)
+	}
+}
+
+func TestTemplateIsEmpty(t *testing.T) {
+	d := Parse(Template())
+	if d.IsStructured() {
+		t.Errorf("unfilled template parsed as structured: %v", d.Sections)
+	}
+	if d.Score() != 0 || len(d.Missing()) != len(Sections) {
+		t.Errorf("template score=%d missing=%v", d.Score(), d.Missing())
+	}
+}
+
+func TestRenderRoundTrip(t *testing.T) {
+	d := New()
+	d.Add(SectionGoal, "Ship it")
+	d.Add(SectionDone, "wrote code", "  ")
+	d.Add(SectionFiles, "internal/foo.go")
+	d.Add(SectionCommands, "`make test`")
+	d.Extra = "## Inbox\n(empty)"
+
+	out := d.Render()
+	if !strings.HasPrefix(out, "## Goal\nShip it\n") {
+		t.Errorf("render should start with goal paragraph:\n%s", out)
+	}
+	if !strings.Contains(out, "- `make test`") || strings.Contains(out, "``make") {
+		t.Errorf("commands should be code-quoted once:\n%s", out)
+	}
+
+	back := Parse(out)
+	if !reflect.DeepEqual(back.Sections[SectionDone], []string{"wrote code"}) {
+		t.Errorf("done round-trip = %q", back.Sections[SectionDone])
+	}
+	if back.Extra != d.Extra {
+		t.Errorf("extra round-trip = %q", back.Extra)
+	}
+	if back.Score() != d.Score() {
+		t.Errorf("score changed on round-trip: %d vs %d", back.Score(), d.Score())
+	}
+}
+
+func TestMerge(t *testing.T) {
+	d := New()
+	d.Add(SectionNextSteps, "from flag")
+	d.Merge(Parse("loose notes\n\n## Next Steps\n- from body"))
+	if !reflect.DeepEqual(d.Sections[SectionNextSteps], []string{"from flag", "from body"}) {
+		t.Errorf("merged next steps = %q", d.Sections[SectionNextSteps])
+	}
+	if d.Extra != "loose notes" {
+		t.Errorf("merged extra = %q", d.Extra)
+	}
+}
+
+func TestGrades(t *testing.T) {
+	path := filepath.Join(t.TempDir(), events.EventsFile)
+	now := time.Now().UTC()
+	weak := Parse("## Goal\nsomething")
+	strong := Parse("## Goal\ng\n## Next Steps\n- n\n## In Progress\n- p\n## Done\n- d")
+
+	lines := []events.Event{
+		{Timestamp: now.Add(-2 * time.Hour).Format(time.RFC3339), Type: events.TypeHandoff, Actor: "gastown/crew/max",
+			Payload: AddQuality(events.HandoffPayload("old", true), strong)},
+		{Timestamp: now.Format(time.RFC3339), Type: events.TypeHandoff, Actor: "gastown/crew/max",
+			Payload: AddQuality(events.HandoffPayload("weak one", true), weak)},
+		{Timestamp: now.Format(time.RFC3339), Type: events.TypeHandoff, Actor: "gastown/witness",
+			Payload: AddQuality(events.HandoffPayload("strong one", true), strong)},
+		{Timestamp: now.Format(time.RFC3339), Type: events.TypeHandoff, Actor: "beads/crew/joe",
+			Payload: AddQuality(events.HandoffPayload("other rig", true), weak)},
+		{Timestamp: now.Format(time.RFC3339), Type: events.TypeHandoff, Actor: "gastown/refinery",
+			Payload: events.HandoffPayload("ungraded", true)},
+		{Timestamp: now.Format(time.RFC3339), Type: events.TypeSling, Actor: "gastown/crew/max"},
+	}
+	var data []byte
+	for _, e := range lines {
+		b, err := json.Marshal(e)
+		if err != nil {
+			t.Fatal(err)
+		}
+		data = append(append(data, b...), '\n')
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	grades, err := Grades(path, "gastown/", now.Add(-time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(grades) != 2 {
+		t.Fatalf("got %d grades, want 2: %+v", len(grades), grades)
+	}
+	if g := grades[0]; g.Subject != "weak one" || !g.Weak() || g.Score != 25 || len(g.Missing) != 6 {
+		t.Errorf("weak grade = %+v", g)
+	}
+	if g := grades[1]; g.Subject != "strong one" || g.Weak() || g.Score != 75 {
+		t.Errorf("strong grade = %+v", g)
+	}
+
+	if grades, err := Grades(filepath.Join(t.TempDir(), "missing.jsonl"), "", time.Time{}); err != nil || grades != nil {
+		t.Errorf("missing file = %v, %v", grades, err)
+	}
+}
@@ -0,0 +1,63 @@
+package handoff
+
+import (
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/events"
+)
+
+// Grade is the recorded quality of one handoff.
+type Grade struct {
+	Time    time.Time `json:"time"`
+	Agent   string    `json:"agent"`
+	Subject string    `json:"subject,omitempty"`
+	Score   int       `json:"score"`
+	Missing []string  `json:"missing,omitempty"`
+}
+
+// Weak reports whether the handoff scored below PassingScore.
+func (g Grade) Weak() bool {
+	return g.Score < PassingScore
+}
+
+// AddQuality records d's score and missing sections in a handoff event
+// payload so patrols can grade handoffs after the fact.
+func AddQuality(payload map[string]interface{}, d *Doc) map[string]interface{} {
+	payload["quality"] = d.Score()
+	if missing := d.Missing(); len(missing) > 0 {
+		payload["missing"] = missing
+	}
+	return payload
+}
+
+// Grades reads graded handoff events from an events log, including its
+// closed segments, oldest first.
+// Only events at or after since whose actor starts with actorPrefix are
+// returned; handoffs logged before grading existed are skipped.
+func Grades(eventsPath, actorPrefix string, since time.Time) ([]Grade, error) {
+	var grades []Grade
+	q := events.Query{Since: since, Types: []string{events.TypeHandoff}}
+	err := events.Read(eventsPath, q, func(e events.Event) bool {
+		if !strings.HasPrefix(e.Actor, actorPrefix) {
+			return true
+		}
+		score, ok := e.Payload["quality"].(float64)
+		if !ok {
+			return true
+		}
+		ts, _ := time.Parse(time.RFC3339, e.Timestamp)
+		g := Grade{Time: ts, Agent: e.Actor, Score: int(score)}
+		g.Subject, _ = e.Payload["subject"].(string)
+		if missing, ok := e.Payload["missing"].([]interface{}); ok {
+			for _, m := range missing {
+				if s, ok := m.(string); ok {
+					g.Missing = append(g.Missing, s)
+				}
+			}
+		}
+		grades = append(grades, g)
+		return true
+	})
+	return grades, err
+}
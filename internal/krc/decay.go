@@ -58,6 +58,12 @@ var defaultDecayCurves = map[string]DecayCurve{
 	"recovery":   DecaySlow,
 	"escalation": DecaySlow,
 
+	// Why an agent was killed or unhooked stays relevant after the patrol
+	"patrol_receipt": DecaySlow,
+
+	// Archived session transcripts (keyed by role)
+	"transcript_*": DecaySlow,
+
 	// Flat: audit-critical events that retain full value
 	"mail":          DecayFlat,
 	"session_death": DecayFlat,
@@ -25,6 +25,7 @@ import (
 	"time"
 
 	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/transcript"
 )
 
 // Config defines TTL settings for ephemeral records.
@@ -45,6 +46,26 @@ type Config struct {
 	// MinRetainCount keeps at least N events even if expired (for debugging).
 	// Default: 100
 	MinRetainCount int `json:"min_retain_count"`
+
+	// DiskBudget caps the bytes kept in event logs and the transcript
+	// archive. Over budget, records are evicted lowest ForensicScore first,
+	// before their TTL. Zero disables the budget.
+	// Default: 1 GiB
+	DiskBudget int64 `json:"disk_budget"`
+
+	// DeathProtection is how long a session death keeps the dying agent's
+	// preceding events and transcript from being pruned.
+	// Default: 7 days
+	DeathProtection time.Duration `json:"death_protection"`
+
+	// ContextWindow is how far before an escalation or death the actor's
+	// events are protected.
+	// Default: 1 hour
+	ContextWindow time.Duration `json:"context_window"`
+
+	// RollupTTL is how long summaries of pruned records are kept.
+	// Default: 365 days
+	RollupTTL time.Duration `json:"rollup_ttl"`
 }
 
 // DefaultConfig returns the default KRC configuration.
@@ -53,6 +74,10 @@ func DefaultConfig() *Config {
 		DefaultTTL:    7 * 24 * time.Hour, // 7 days
 		PruneInterval: 1 * time.Hour,
 		MinRetainCount: 100,
+		DiskBudget:      1 << 30,
+		DeathProtection: 7 * 24 * time.Hour,
+		ContextWindow:   time.Hour,
+		RollupTTL:       365 * 24 * time.Hour,
 		TTLs: map[string]time.Duration{
 			// Patrol events decay fastest - low forensic value after hours
 			"patrol_*":       24 * time.Hour,  // 1 day
@@ -78,12 +103,24 @@ func DefaultConfig() *Config {
 			"session_death": 30 * 24 * time.Hour, // 30 days
 			"mass_death":    90 * 24 * time.Hour, // 90 days
 
+			// Patrol decision receipts explain deaths, so they outlive
+			// ordinary patrol chatter
+			"patrol_receipt": 30 * 24 * time.Hour, // 30 days
+
 			// Merge events - important for audit
 			"merge_*":       30 * 24 * time.Hour, // 30 days
+
+			// Archived session transcripts, keyed by role (transcript_polecat, ...)
+			"transcript_*": 30 * 24 * time.Hour, // 30 days
 		},
 	}
 }
 
+// TranscriptType is the TTL key for archived transcripts of a role.
+func TranscriptType(role string) string {
+	return "transcript_" + role
+}
+
 // ConfigFile returns the path to the KRC config file.
 func ConfigFile(townRoot string) string {
 	return filepath.Join(townRoot, ".krc.yaml")
@@ -165,12 +202,24 @@ func matchGlob(pattern, s string) bool {
 // PruneResult contains statistics from a prune operation.
 type PruneResult struct {
 	EventsProcessed int            `json:"events_processed"`
-	EventsPruned    int            `json:"events_pruned"`
+	EventsPruned    int            `json:"events_pruned"` // expired or evicted
 	EventsRetained  int            `json:"events_retained"`
 	BytesBefore     int64          `json:"bytes_before"`
 	BytesAfter      int64          `json:"bytes_after"`
 	PrunedByType    map[string]int `json:"pruned_by_type"`
 	Duration        time.Duration  `json:"duration"`
+
+	// Transcript archive (see internal/transcript)
+	TranscriptsPruned    int   `json:"transcripts_pruned,omitempty"`
+	TranscriptBytesFreed int64 `json:"transcript_bytes_freed,omitempty"`
+
+	// Adaptive retention (see retention.go)
+	EventsEvicted      int   `json:"events_evicted,omitempty"`      // removed before TTL to meet the disk budget
+	EventsProtected    int   `json:"events_protected,omitempty"`    // expired but kept for an escalation or death
+	TranscriptsEvicted int   `json:"transcripts_evicted,omitempty"` // removed before TTL to meet the disk budget
+	Rollups            int   `json:"rollups,omitempty"`             // type/day rollups written
+	DiskBudget         int64 `json:"disk_budget,omitempty"`
+	BytesRetained      int64 `json:"bytes_retained"` // event logs plus transcript archive
 }
 
 // Pruner handles the pruning of expired events.
@@ -187,190 +236,287 @@ func NewPruner(townRoot string, config *Config) *Pruner {
 	}
 }
 
-// Prune removes expired events from the events and feed files.
-// It operates atomically by writing to temp files then renaming.
+// Prune removes expired events from the events and feed files and expired
+// transcripts from the archive, then evicts the lowest-value records until
+// everything fits the disk budget. Records tied to open escalations or
+// recent session deaths are kept regardless. Files are rewritten
+// atomically via temp files.
 func (p *Pruner) Prune() (*PruneResult, error) {
+	return p.run(true)
+}
+
+// DryRun reports what Prune would remove without changing anything.
+func (p *Pruner) DryRun() (*PruneResult, error) {
+	return p.run(false)
+}
+
+func (p *Pruner) run(apply bool) (*PruneResult, error) {
 	start := time.Now()
+	now := start
 	result := &PruneResult{
 		PrunedByType: make(map[string]int),
+		DiskBudget:   p.config.DiskBudget,
 	}
 
-	// Prune events file
-	eventsResult, err := p.pruneFile(filepath.Join(p.townRoot, events.EventsFile))
+	eventsPath := filepath.Join(p.townRoot, events.EventsFile)
+	if apply {
+		// Pick up closed segments an interrupted writer left unsealed
+		if err := events.Seal(eventsPath); err != nil {
+			return nil, fmt.Errorf("sealing event segments: %w", err)
+		}
+	}
+	eventsLog, err := loadEventLog(eventsPath)
 	if err != nil {
 		return nil, fmt.Errorf("pruning events: %w", err)
 	}
-	result.EventsProcessed += eventsResult.EventsProcessed
-	result.EventsPruned += eventsResult.EventsPruned
-	result.EventsRetained += eventsResult.EventsRetained
-	result.BytesBefore += eventsResult.BytesBefore
-	result.BytesAfter += eventsResult.BytesAfter
-	for k, v := range eventsResult.PrunedByType {
-		result.PrunedByType[k] += v
+	segments, err := loadSegments(eventsPath)
+	if err != nil {
+		return nil, fmt.Errorf("pruning events: %w", err)
 	}
-
-	// Prune feed file
-	feedResult, err := p.pruneFile(filepath.Join(p.townRoot, ".feed.jsonl"))
+	feedLog, err := loadEventLog(filepath.Join(p.townRoot, ".feed.jsonl"))
 	if err != nil {
 		return nil, fmt.Errorf("pruning feed: %w", err)
 	}
-	result.EventsProcessed += feedResult.EventsProcessed
-	result.EventsPruned += feedResult.EventsPruned
-	result.EventsRetained += feedResult.EventsRetained
-	result.BytesBefore += feedResult.BytesBefore
-	result.BytesAfter += feedResult.BytesAfter
-	for k, v := range feedResult.PrunedByType {
-		result.PrunedByType[k] += v
+	transcripts, err := transcript.List(p.townRoot, transcript.Query{})
+	if err != nil {
+		return nil, fmt.Errorf("pruning transcripts: %w", err)
 	}
+	logs := []*eventLog{eventsLog, feedLog}
 
-	result.Duration = time.Since(start)
-	return result, nil
-}
-
-// pruneFile prunes a single JSONL file.
-func (p *Pruner) pruneFile(filePath string) (result *PruneResult, err error) {
-	result = &PruneResult{
-		PrunedByType: make(map[string]int),
+	// Protections come from the raw event log; the feed is derived from it.
+	var guardRecords []*logRecord
+	for _, seg := range segments {
+		if seg.hasAny(guardTypes) {
+			if err := seg.load(); err != nil {
+				return nil, fmt.Errorf("pruning events: %w", err)
+			}
+			guardRecords = append(guardRecords, seg.records...)
+		}
 	}
+	// Unknown bead status protects every escalation rather than none.
+	open, _ := openEscalations(p.townRoot)
+	guard := newRetentionGuard(append(guardRecords, eventsLog.records...), open, now, p.config)
 
-	// Get file size before
-	info, err := os.Stat(filePath)
-	if os.IsNotExist(err) {
-		return result, nil
-	}
-	if err != nil {
-		return nil, err
+	// TTL pass
+	for _, l := range logs {
+		for _, r := range l.records {
+			if !r.parsed {
+				continue
+			}
+			r.protected = guard.protects(r)
+			if now.Sub(r.ts) <= p.config.GetTTL(r.typ) {
+				continue
+			}
+			if r.protected {
+				result.EventsProtected++
+				continue
+			}
+			r.evict = evictTTL
+		}
 	}
-	result.BytesBefore = info.Size()
-
-	// Open source file
-	srcFile, err := os.Open(filePath)
-	if err != nil {
-		return nil, err
+	for _, seg := range segments {
+		if !seg.expired(now, p.config) {
+			continue
+		}
+		protected, err := seg.protectedCount(guard)
+		if err != nil {
+			return nil, fmt.Errorf("pruning events: %w", err)
+		}
+		if protected > 0 {
+			result.EventsProtected += protected
+			continue
+		}
+		seg.drop = evictTTL
 	}
-	srcClosed := false
-	defer func() {
-		if srcClosed {
-			return
+	dropTranscripts := make(map[string]string) // archive path -> reason
+	protectedTranscripts := make(map[string]bool)
+	for _, e := range transcripts {
+		if guard.protectsTranscript(e) {
+			protectedTranscripts[e.Path] = true
+			continue
 		}
-		if closeErr := srcFile.Close(); closeErr != nil && err == nil {
-			err = closeErr
+		ttl := p.config.GetTTL(TranscriptType(e.Role))
+		if ttl > 0 && now.Sub(e.EndedAt) > ttl {
+			dropTranscripts[e.Path] = evictTTL
 		}
-	}()
-
-	// Create temp file for output
-	tmpPath := filePath + ".tmp"
-	tmpFile, err := os.Create(tmpPath)
-	if err != nil {
-		return nil, err
 	}
-	tmpClosed := false
-	defer func() {
-		if err == nil {
-			return
+
+	// Budget pass
+	if p.config.DiskBudget > 0 {
+		var total int64
+		var candidates []evictionCandidate
+		for _, l := range logs {
+			total += l.keptSize()
+			for _, r := range l.records {
+				if !r.parsed || r.protected || r.evict != "" {
+					continue
+				}
+				r := r
+				ttl := p.config.GetTTL(r.typ)
+				candidates = append(candidates, evictionCandidate{
+					score: ForensicScore(r.typ, now.Sub(r.ts), ttl),
+					ts:    r.ts,
+					size:  r.size,
+					evict: func() bool { r.evict = evictBudget; return true },
+				})
+			}
 		}
-		if !tmpClosed {
-			if closeErr := tmpFile.Close(); closeErr != nil && err == nil {
-				err = closeErr
+		for _, e := range transcripts {
+			if _, dropped := dropTranscripts[e.Path]; dropped {
+				continue
 			}
+			size := transcriptSize(e)
+			total += size
+			if protectedTranscripts[e.Path] {
+				continue
+			}
+			typ := TranscriptType(e.Role)
+			path := e.Path
+			candidates = append(candidates, evictionCandidate{
+				score: ForensicScore(typ, now.Sub(e.EndedAt), p.config.GetTTL(typ)),
+				ts:    e.EndedAt,
+				size:  size,
+				evict: func() bool { dropTranscripts[path] = evictBudget; return true },
+			})
 		}
-		if removeErr := os.Remove(tmpPath); removeErr != nil && !os.IsNotExist(removeErr) && err == nil {
-			err = removeErr
+		// Closed segments go whole, oldest and least valuable first, unless
+		// reading them turns up something the guard protects.
+		var segErr error
+		for _, seg := range segments {
+			if seg.drop != "" {
+				continue
+			}
+			total += seg.seg.Stored
+			seg := seg
+			candidates = append(candidates, evictionCandidate{
+				score: seg.score(now, p.config),
+				ts:    seg.seg.End,
+				size:  seg.seg.Stored,
+				evict: func() bool {
+					protected, err := seg.protectedCount(guard)
+					if err != nil {
+						segErr = err
+						return false
+					}
+					if protected > 0 {
+						return false
+					}
+					seg.drop = evictBudget
+					return true
+				},
+			})
 		}
-	}()
-
-	now := time.Now()
-	scanner := bufio.NewScanner(srcFile)
-	// Increase buffer size for potentially long lines
-	scanner.Buffer(make([]byte, 1024*1024), 1024*1024)
-
-	var retained []string
-
-	for scanner.Scan() {
-		line := scanner.Text()
-		if line == "" {
-			continue
+		evictToBudget(candidates, total, p.config.DiskBudget)
+		if segErr != nil {
+			return nil, fmt.Errorf("pruning events: %w", segErr)
 		}
-		result.EventsProcessed++
+	}
 
-		// Parse event to check TTL
-		var event struct {
-			Timestamp string `json:"ts"`
-			Type      string `json:"type"`
+	// Tally and roll up what goes. Only the raw event log and transcripts
+	// are rolled up; feed lines duplicate events already counted.
+	rollups := make(rollupSet)
+	for _, l := range logs {
+		for _, r := range l.records {
+			result.EventsProcessed++
+			if r.evict == "" {
+				result.EventsRetained++
+				continue
+			}
+			result.EventsPruned++
+			result.PrunedByType[r.typ]++
+			if r.evict == evictBudget {
+				result.EventsEvicted++
+			}
+			if l == eventsLog {
+				rollups.add(r.typ, r.ts, r.actor, r.size, r.evict)
+			}
 		}
-		if err := json.Unmarshal([]byte(line), &event); err != nil {
-			// Keep malformed lines (might be important)
-			retained = append(retained, line)
+		result.BytesBefore += l.size
+		result.BytesAfter += l.keptSize()
+	}
+	for _, seg := range segments {
+		result.EventsProcessed += seg.seg.Count
+		result.BytesBefore += seg.seg.Stored
+		if seg.drop == "" {
+			result.EventsRetained += seg.seg.Count
+			result.BytesAfter += seg.seg.Stored
 			continue
 		}
-
-		// Parse timestamp
-		ts, err := time.Parse(time.RFC3339, event.Timestamp)
-		if err != nil {
-			// Keep events with unparseable timestamps
-			retained = append(retained, line)
-			continue
+		if err := seg.load(); err != nil {
+			return nil, fmt.Errorf("pruning events: %w", err)
 		}
-
-		// Check if event has expired
-		ttl := p.config.GetTTL(event.Type)
-		if now.Sub(ts) > ttl {
+		for _, r := range seg.records {
+			if !r.parsed {
+				continue
+			}
 			result.EventsPruned++
-			result.PrunedByType[event.Type]++
-		} else {
-			retained = append(retained, line)
+			result.PrunedByType[r.typ]++
+			if seg.drop == evictBudget {
+				result.EventsEvicted++
+			}
+			rollups.add(r.typ, r.ts, r.actor, r.size, seg.drop)
 		}
 	}
-
-	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("scanning file: %w", err)
-	}
-
-	// Ensure we keep minimum retain count (most recent events)
-	if len(retained) > p.config.MinRetainCount {
-		result.EventsRetained = len(retained)
-	} else {
-		// If we're below minimum, recalculate - keep the minimum from original
-		result.EventsRetained = len(retained)
+	result.BytesRetained = result.BytesAfter
+	for _, e := range transcripts {
+		reason, dropped := dropTranscripts[e.Path]
+		if !dropped {
+			result.BytesRetained += transcriptSize(e)
+			continue
+		}
+		result.TranscriptsPruned++
+		result.TranscriptBytesFreed += transcriptSize(e)
+		if reason == evictBudget {
+			result.TranscriptsEvicted++
+		}
+		rollups.add(TranscriptType(e.Role), e.EndedAt, e.Agent, transcriptSize(e), reason)
 	}
+	result.Rollups = len(rollups)
 
-	// Write retained events
-	for _, line := range retained {
-		if _, err := tmpFile.WriteString(line + "\n"); err != nil {
+	if apply {
+		for _, l := range logs {
+			if !l.hasEvictions() {
+				continue
+			}
+			write := l.write
+			if l == eventsLog {
+				write = l.rewriteActive
+			}
+			if err := write(); err != nil {
+				return nil, fmt.Errorf("pruning %s: %w", filepath.Base(l.path), err)
+			}
+		}
+		for _, seg := range segments {
+			if seg.drop == "" {
+				continue
+			}
+			if err := events.RemoveSegment(eventsPath, seg.seg); err != nil {
+				return nil, fmt.Errorf("pruning event segment %s: %w", seg.seg.File, err)
+			}
+		}
+		if len(dropTranscripts) > 0 {
+			removed, err := transcript.Remove(p.townRoot, func(e transcript.Entry) bool {
+				_, drop := dropTranscripts[e.Path]
+				return drop
+			})
+			if err != nil {
+				return nil, fmt.Errorf("pruning transcripts: %w", err)
+			}
+			result.TranscriptBytesFreed = removed.BytesFreed
+		}
+		if err := saveRollups(p.townRoot, rollups, p.config.RollupTTL, now); err != nil {
 			return nil, err
 		}
 	}
 
-	// Get final size
-	tmpInfo, err := tmpFile.Stat()
-	if err != nil {
-		return nil, err
-	}
-	result.BytesAfter = tmpInfo.Size()
-
-	// Close files before rename
-	if err := tmpFile.Close(); err != nil {
-		tmpClosed = true
-		return nil, err
-	}
-	tmpClosed = true
-	if err := srcFile.Close(); err != nil {
-		srcClosed = true
-		return nil, err
-	}
-	srcClosed = true
-
-	// Atomic replace
-	if err := os.Rename(tmpPath, filePath); err != nil {
-		return nil, fmt.Errorf("replacing file: %w", err)
-	}
-
+	result.Duration = time.Since(start)
 	return result, nil
 }
 
 // Stats contains statistics about the current ephemeral data.
 type Stats struct {
 	EventsFile   FileStats          `json:"events_file"`
+	Segments     SegmentStats       `json:"segments"`
 	FeedFile     FileStats          `json:"feed_file"`
 	ByType       map[string]int     `json:"by_type"`
 	ByAge        map[string]int     `json:"by_age"` // "0-1d", "1-7d", "7-30d", "30d+"
@@ -386,6 +532,14 @@ type FileStats struct {
 	EventCount int    `json:"event_count"`
 }
 
+// SegmentStats summarizes the closed segments of the events log.
+type SegmentStats struct {
+	Count      int   `json:"count"`
+	Size       int64 `json:"size"` // on disk (compressed)
+	Bytes      int64 `json:"bytes"`
+	EventCount int   `json:"event_count"`
+}
+
 // TTLInfo contains TTL information for an event type.
 type TTLInfo struct {
 	TTL       time.Duration `json:"ttl"`
@@ -395,59 +549,85 @@ type TTLInfo struct {
 }
 
 // GetStats returns statistics about ephemeral data.
+// Sealed segments are tallied from the segment index without being read:
+// their events are aged by the segment's newest event, which is accurate
+// to within the day a segment spans.
 func GetStats(townRoot string, config *Config) (*Stats, error) {
 	stats := &Stats{
 		ByType:       make(map[string]int),
 		ByAge:        make(map[string]int),
 		TTLBreakdown: make(map[string]TTLInfo),
 	}
-
-	now := time.Now()
+	c := &statsCollector{stats: stats, config: config, now: time.Now()}
 
 	// Process events file
 	eventsPath := filepath.Join(townRoot, events.EventsFile)
-	eventsStats, oldest, newest, err := getFileStats(eventsPath, config, now, stats.ByType, stats.ByAge, stats.TTLBreakdown)
+	eventsStats, err := c.file(eventsPath)
 	if err != nil && !os.IsNotExist(err) {
 		return nil, err
 	}
 	stats.EventsFile = eventsStats
-	if !oldest.IsZero() {
-		stats.OldestEvent = oldest
+
+	// Process closed segments
+	segments, err := events.Segments(eventsPath)
+	if err != nil {
+		return nil, err
 	}
-	if !newest.IsZero() {
-		stats.NewestEvent = newest
+	for _, seg := range segments {
+		stats.Segments.Count++
+		if seg.Sealed() && !seg.Start.IsZero() {
+			stats.Segments.Size += seg.Stored
+			stats.Segments.Bytes += seg.Bytes
+			stats.Segments.EventCount += seg.Count
+			c.observe(seg.Start)
+			for typ, n := range seg.Types {
+				c.add(typ, seg.End, n)
+			}
+			continue
+		}
+		if info, err := os.Stat(filepath.Join(events.SegmentsDir(eventsPath), seg.File)); err == nil {
+			stats.Segments.Size += info.Size()
+		}
+		err := events.ReadSegment(eventsPath, seg, func(line []byte) bool {
+			stats.Segments.EventCount++
+			stats.Segments.Bytes += int64(len(line)) + 1
+			c.line(line)
+			return true
+		})
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
 	}
 
 	// Process feed file
-	feedPath := filepath.Join(townRoot, ".feed.jsonl")
-	feedStats, oldest2, newest2, err := getFileStats(feedPath, config, now, stats.ByType, stats.ByAge, stats.TTLBreakdown)
+	feedStats, err := c.file(filepath.Join(townRoot, ".feed.jsonl"))
 	if err != nil && !os.IsNotExist(err) {
 		return nil, err
 	}
 	stats.FeedFile = feedStats
-	if !oldest2.IsZero() && (stats.OldestEvent.IsZero() || oldest2.Before(stats.OldestEvent)) {
-		stats.OldestEvent = oldest2
-	}
-	if !newest2.IsZero() && newest2.After(stats.NewestEvent) {
-		stats.NewestEvent = newest2
-	}
 
 	return stats, nil
 }
 
-func getFileStats(filePath string, config *Config, now time.Time, byType, byAge map[string]int, ttlBreakdown map[string]TTLInfo) (FileStats, time.Time, time.Time, error) {
+// statsCollector accumulates event counts into Stats.
+type statsCollector struct {
+	stats  *Stats
+	config *Config
+	now    time.Time
+}
+
+func (c *statsCollector) file(filePath string) (FileStats, error) {
 	stats := FileStats{Path: filePath}
-	var oldest, newest time.Time
 
 	info, err := os.Stat(filePath)
 	if err != nil {
-		return stats, oldest, newest, err
+		return stats, err
 	}
 	stats.Size = info.Size()
 
 	file, err := os.Open(filePath)
 	if err != nil {
-		return stats, oldest, newest, err
+		return stats, err
 	}
 	defer file.Close()
 
@@ -455,64 +635,76 @@ func getFileStats(filePath string, config *Config, now time.Time, byType, byAge
 	scanner.Buffer(make([]byte, 1024*1024), 1024*1024)
 
 	for scanner.Scan() {
-		line := scanner.Text()
-		if line == "" {
+		line := scanner.Bytes()
+		if len(line) == 0 {
 			continue
 		}
 		stats.EventCount++
+		c.line(line)
+	}
 
-		var event struct {
-			Timestamp string `json:"ts"`
-			Type      string `json:"type"`
-		}
-		if err := json.Unmarshal([]byte(line), &event); err != nil {
-			continue
-		}
-
-		byType[event.Type]++
+	return stats, scanner.Err()
+}
 
-		ts, err := time.Parse(time.RFC3339, event.Timestamp)
-		if err != nil {
-			continue
-		}
+// line counts one raw event line.
+func (c *statsCollector) line(line []byte) {
+	var event struct {
+		Timestamp string `json:"ts"`
+		Type      string `json:"type"`
+	}
+	if err := json.Unmarshal(line, &event); err != nil {
+		return
+	}
 
-		// Track oldest/newest
-		if oldest.IsZero() || ts.Before(oldest) {
-			oldest = ts
-		}
-		if newest.IsZero() || ts.After(newest) {
-			newest = ts
-		}
+	ts, err := time.Parse(time.RFC3339, event.Timestamp)
+	if err != nil {
+		c.stats.ByType[event.Type]++
+		return
+	}
+	c.add(event.Type, ts, 1)
+}
 
-		// Age bucket
-		age := now.Sub(ts)
-		switch {
-		case age < 24*time.Hour:
-			byAge["0-1d"]++
-		case age < 7*24*time.Hour:
-			byAge["1-7d"]++
-		case age < 30*24*time.Hour:
-			byAge["7-30d"]++
-		default:
-			byAge["30d+"]++
-		}
+// observe tracks the oldest and newest event seen.
+func (c *statsCollector) observe(ts time.Time) {
+	if c.stats.OldestEvent.IsZero() || ts.Before(c.stats.OldestEvent) {
+		c.stats.OldestEvent = ts
+	}
+	if c.stats.NewestEvent.IsZero() || ts.After(c.stats.NewestEvent) {
+		c.stats.NewestEvent = ts
+	}
+}
 
-		// TTL breakdown
-		ttl := config.GetTTL(event.Type)
-		info := ttlBreakdown[event.Type]
-		info.TTL = ttl
-		info.Count++
-		if age > ttl {
-			info.Expired++
-		} else {
-			// Calculate time until this event expires
-			expiresIn := ttl - age
-			if info.ExpiresIn == 0 || expiresIn < info.ExpiresIn {
-				info.ExpiresIn = expiresIn
-			}
+// add counts n events of a type logged at ts.
+func (c *statsCollector) add(eventType string, ts time.Time, n int) {
+	c.stats.ByType[eventType] += n
+	c.observe(ts)
+
+	// Age bucket
+	age := c.now.Sub(ts)
+	switch {
+	case age < 24*time.Hour:
+		c.stats.ByAge["0-1d"] += n
+	case age < 7*24*time.Hour:
+		c.stats.ByAge["1-7d"] += n
+	case age < 30*24*time.Hour:
+		c.stats.ByAge["7-30d"] += n
+	default:
+		c.stats.ByAge["30d+"] += n
+	}
+
+	// TTL breakdown
+	ttl := c.config.GetTTL(eventType)
+	info := c.stats.TTLBreakdown[eventType]
+	info.TTL = ttl
+	info.Count += n
+	if age > ttl {
+		info.Expired += n
+	} else {
+		// Calculate time until this event expires
+		expiresIn := ttl - age
+		if info.ExpiresIn == 0 || expiresIn < info.ExpiresIn {
+			info.ExpiresIn = expiresIn
 		}
-		ttlBreakdown[event.Type] = info
 	}
-
-	return stats, oldest, newest, scanner.Err()
+	c.stats.TTLBreakdown[eventType] = info
 }
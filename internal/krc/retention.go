@@ -0,0 +1,690 @@
+package krc
+
+// This file implements adaptive retention. TTLs cap how long a record may
+// live; the disk budget decides what goes first when ephemeral data (the
+// event logs plus archived transcripts) outgrows it. Records are evicted in
+// ascending ForensicScore order, so decayed heartbeats go long before mail
+// or deaths. Records that explain an open escalation or a recent session
+// death are never evicted, and whatever is removed from the raw event log
+// or the transcript archive is summarized into daily rollups.
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/transcript"
+)
+
+// RollupFile is the filename, relative to the town root, of rollup records.
+const RollupFile = ".krc-rollups.jsonl"
+
+// maxRollupActors bounds the per-actor counts kept in a rollup; the rest
+// are counted under "other".
+const maxRollupActors = 10
+
+// Eviction reasons, as recorded in rollups.
+const (
+	evictTTL    = "ttl"
+	evictBudget = "budget"
+)
+
+// logRecord is one line of an event log.
+type logRecord struct {
+	line      string
+	size      int64 // bytes on disk, including the newline
+	parsed    bool  // has a type and a valid timestamp
+	ts        time.Time
+	typ       string
+	actor     string
+	payload   map[string]interface{}
+	protected bool
+	evict     string // "", evictTTL or evictBudget
+}
+
+// eventLog is a JSONL event file loaded for pruning.
+type eventLog struct {
+	path    string
+	records []*logRecord
+	size    int64
+}
+
+// parseLogRecord parses one event log line. Malformed lines and
+// unparseable timestamps are kept as unparsed records (might be important).
+func parseLogRecord(line string) *logRecord {
+	rec := &logRecord{line: line, size: int64(len(line)) + 1}
+	var event struct {
+		Timestamp string                 `json:"ts"`
+		Type      string                 `json:"type"`
+		Actor     string                 `json:"actor"`
+		Payload   map[string]interface{} `json:"payload"`
+	}
+	if err := json.Unmarshal([]byte(line), &event); err == nil {
+		if ts, err := time.Parse(time.RFC3339, event.Timestamp); err == nil {
+			rec.parsed = true
+			rec.ts = ts
+			rec.typ = event.Type
+			rec.actor = event.Actor
+			rec.payload = event.Payload
+		}
+	}
+	return rec
+}
+
+// loadEventLog reads an event log. A missing file is an empty log.
+func loadEventLog(path string) (*eventLog, error) {
+	log := &eventLog{path: path}
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return log, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	if info, err := f.Stat(); err == nil {
+		log.size = info.Size()
+	}
+
+	scanner := bufio.NewScanner(f)
+	// Increase buffer size for potentially long lines
+	scanner.Buffer(make([]byte, 1024*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+		log.records = append(log.records, parseLogRecord(line))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("scanning file: %w", err)
+	}
+	return log, nil
+}
+
+// keptSize is the size of the log once evicted records are removed.
+func (l *eventLog) keptSize() int64 {
+	var n int64
+	for _, r := range l.records {
+		if r.evict == "" {
+			n += r.size
+		}
+	}
+	return n
+}
+
+func (l *eventLog) hasEvictions() bool {
+	for _, r := range l.records {
+		if r.evict != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// write replaces the log with its retained records, atomically.
+func (l *eventLog) write() error {
+	tmpPath := l.path + ".tmp"
+	tmpFile, err := os.Create(tmpPath)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(tmpFile)
+	for _, r := range l.records {
+		if r.evict != "" {
+			continue
+		}
+		if _, err := w.WriteString(r.line + "\n"); err != nil {
+			_ = tmpFile.Close()
+			_ = os.Remove(tmpPath)
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		_ = tmpFile.Close()
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	if err := tmpFile.Close(); err != nil {
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Rename(tmpPath, l.path); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("replacing file: %w", err)
+	}
+	return nil
+}
+
+// rewriteActive replaces the active segment of the raw event log, of which
+// l is an earlier snapshot, without the records evicted from l. Writers
+// keep appending and rotating while pruning decides, so the segment is
+// re-read under the events lock just before the write: records rotated out
+// since stay in their segment and records appended since are kept.
+func (l *eventLog) rewriteActive() error {
+	fl, err := events.Lock(l.path)
+	if err != nil {
+		return err
+	}
+	defer fl.Unlock() //nolint:errcheck // best-effort unlock
+
+	evicted := make(map[string][]string) // line -> eviction reasons
+	for _, r := range l.records {
+		if r.evict != "" {
+			evicted[r.line] = append(evicted[r.line], r.evict)
+		}
+	}
+	current, err := loadEventLog(l.path)
+	if err != nil {
+		return err
+	}
+	for _, r := range current.records {
+		if reasons := evicted[r.line]; len(reasons) > 0 {
+			r.evict = reasons[0]
+			evicted[r.line] = reasons[1:]
+		}
+	}
+	if !current.hasEvictions() {
+		return nil
+	}
+	return current.write()
+}
+
+// guardTypes are the event types the retention guard derives protections
+// from; closed segments holding them are read to build the guard.
+var guardTypes = []string{
+	events.TypeEscalationSent,
+	events.TypeSessionDeath,
+	events.TypeMassDeath,
+}
+
+// eventSegment is a closed segment of the raw event log (see
+// events.Segment). Segments are kept or dropped whole, judged from the
+// segment index; records are only read when needed to build the guard,
+// check protection, or roll up a dropped segment.
+type eventSegment struct {
+	eventsPath string
+	seg        events.Segment
+	records    []*logRecord
+	loaded     bool
+	drop       string // "", evictTTL or evictBudget
+}
+
+// loadSegments lists the closed segments of the event log. Segments that
+// aren't sealed have no index entry, so they're read and described here.
+func loadSegments(eventsPath string) ([]*eventSegment, error) {
+	segs, err := events.Segments(eventsPath)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*eventSegment, 0, len(segs))
+	for _, seg := range segs {
+		s := &eventSegment{eventsPath: eventsPath, seg: seg}
+		if !seg.Sealed() || seg.Start.IsZero() {
+			if err := s.describe(); err != nil {
+				return nil, err
+			}
+		}
+		out = append(out, s)
+	}
+	return out, nil
+}
+
+// load reads the segment's records.
+func (s *eventSegment) load() error {
+	if s.loaded {
+		return nil
+	}
+	err := events.ReadSegment(s.eventsPath, s.seg, func(line []byte) bool {
+		s.records = append(s.records, parseLogRecord(string(line)))
+		return true
+	})
+	if err != nil {
+		return fmt.Errorf("reading event segment %s: %w", s.seg.File, err)
+	}
+	s.loaded = true
+	return nil
+}
+
+// describe fills in index fields from the segment's records.
+func (s *eventSegment) describe() error {
+	if err := s.load(); err != nil {
+		return err
+	}
+	s.seg.Types = make(map[string]int)
+	s.seg.Count, s.seg.Bytes = 0, 0
+	for _, r := range s.records {
+		s.seg.Bytes += r.size
+		if !r.parsed {
+			continue
+		}
+		s.seg.Count++
+		s.seg.Types[r.typ]++
+		if s.seg.Start.IsZero() || r.ts.Before(s.seg.Start) {
+			s.seg.Start = r.ts
+		}
+		if r.ts.After(s.seg.End) {
+			s.seg.End = r.ts
+		}
+	}
+	if info, err := os.Stat(filepath.Join(events.SegmentsDir(s.eventsPath), s.seg.File)); err == nil {
+		s.seg.Stored = info.Size()
+	}
+	return nil
+}
+
+// hasAny reports whether the segment holds events of any of the types.
+func (s *eventSegment) hasAny(types []string) bool {
+	for _, t := range types {
+		if s.seg.Types[t] > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// expired reports whether every event in the segment is past its TTL.
+func (s *eventSegment) expired(now time.Time, cfg *Config) bool {
+	if s.seg.End.IsZero() {
+		return false
+	}
+	for typ := range s.seg.Types {
+		if now.Sub(s.seg.End) <= cfg.GetTTL(typ) {
+			return false
+		}
+	}
+	return true
+}
+
+// score is the segment's forensic value: that of its most valuable type,
+// aged by the segment's newest event.
+func (s *eventSegment) score(now time.Time, cfg *Config) float64 {
+	var best float64
+	for typ := range s.seg.Types {
+		if score := ForensicScore(typ, now.Sub(s.seg.End), cfg.GetTTL(typ)); score > best {
+			best = score
+		}
+	}
+	return best
+}
+
+// protectedCount reads the segment and counts records the guard protects.
+func (s *eventSegment) protectedCount(g *retentionGuard) (int, error) {
+	if err := s.load(); err != nil {
+		return 0, err
+	}
+	n := 0
+	for _, r := range s.records {
+		if g.protects(r) {
+			n++
+		}
+	}
+	return n, nil
+}
+
+// timeWindow is an inclusive time range.
+type timeWindow struct {
+	from, to time.Time
+}
+
+func (w timeWindow) contains(t time.Time) bool {
+	return !t.Before(w.from) && !t.After(w.to)
+}
+
+// retentionGuard decides which records must survive pruning: everything
+// tied to an escalation that is still open, and the lead-up to recent
+// session deaths (the dying agent's own events in the ContextWindow before
+// it died, and its archived transcript).
+type retentionGuard struct {
+	openEscalations map[string]bool
+	pinned          map[string]bool         // recordKey of records kept outright
+	windows         map[string][]timeWindow // subject (agent/session) -> protected ranges
+	slack           time.Duration
+}
+
+// openEscalations returns the IDs of escalation beads that are still open.
+// A variable so tests can stand in for bd.
+var openEscalations = func(townRoot string) (map[string]bool, error) {
+	issues, err := beads.New(beads.ResolveBeadsDir(townRoot)).ListEscalations()
+	if err != nil {
+		return nil, err
+	}
+	open := make(map[string]bool, len(issues))
+	for _, issue := range issues {
+		open[issue.ID] = true
+	}
+	return open, nil
+}
+
+// newRetentionGuard derives protections from the raw event log. open holds
+// the IDs of escalation beads that are still open; nil means bead status
+// is unknown, and every escalation in the log is treated as open.
+func newRetentionGuard(records []*logRecord, open map[string]bool, now time.Time, cfg *Config) *retentionGuard {
+	g := &retentionGuard{
+		openEscalations: make(map[string]bool),
+		pinned:          make(map[string]bool),
+		windows:         make(map[string][]timeWindow),
+		slack:           cfg.ContextWindow,
+	}
+
+	// Whether an escalation is open comes from its bead: the log may be
+	// missing the escalation_closed event, or never get one.
+	for _, r := range records {
+		if !r.parsed || r.typ != events.TypeEscalationSent {
+			continue
+		}
+		id := escalationID(r)
+		if id == "" || g.openEscalations[id] || (open != nil && !open[id]) {
+			continue
+		}
+		g.openEscalations[id] = true
+		g.protect(r.ts, r.actor)
+	}
+
+	for _, r := range records {
+		if !r.parsed || now.Sub(r.ts) > cfg.DeathProtection {
+			continue
+		}
+		switch r.typ {
+		case events.TypeSessionDeath:
+			g.protect(r.ts, recordSubjects(r)...)
+		case events.TypeMassDeath:
+			g.pinned[recordKey(r)] = true
+			if sessions, ok := r.payload["sessions"].([]interface{}); ok {
+				for _, s := range sessions {
+					if name, ok := s.(string); ok {
+						g.protect(r.ts, name)
+					}
+				}
+			}
+		}
+	}
+	return g
+}
+
+// protect keeps the subjects' records from the ContextWindow before at.
+func (g *retentionGuard) protect(at time.Time, subjects ...string) {
+	w := timeWindow{from: at.Add(-g.slack), to: at}
+	for _, s := range subjects {
+		if s != "" && s != "unknown" {
+			g.windows[s] = append(g.windows[s], w)
+		}
+	}
+}
+
+func (g *retentionGuard) covers(subject string, t time.Time) bool {
+	for _, w := range g.windows[subject] {
+		if w.contains(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// protects reports whether an event record must be retained.
+func (g *retentionGuard) protects(r *logRecord) bool {
+	if !r.parsed {
+		return false
+	}
+	if g.pinned[recordKey(r)] {
+		return true
+	}
+	if id := escalationID(r); id != "" && g.openEscalations[id] {
+		return true
+	}
+	for _, s := range recordSubjects(r) {
+		if g.covers(s, r.ts) {
+			return true
+		}
+	}
+	return false
+}
+
+// protectsTranscript reports whether an archived transcript must be
+// retained: it belongs to a session that died recently. Deaths are often
+// logged just after the session ends, hence the extra slack.
+func (g *retentionGuard) protectsTranscript(e transcript.Entry) bool {
+	for _, s := range []string{e.Agent, strings.TrimSuffix(e.Agent, "/"), e.SessionID} {
+		for _, w := range g.windows[s] {
+			if (timeWindow{from: w.from, to: w.to.Add(g.slack)}).contains(e.EndedAt) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// recordSubjects returns the agents and sessions a record is about.
+func recordSubjects(r *logRecord) []string {
+	subjects := []string{r.actor}
+	for _, key := range []string{"session", "agent"} {
+		if s, ok := r.payload[key].(string); ok && s != "" && s != r.actor {
+			subjects = append(subjects, s)
+		}
+	}
+	return subjects
+}
+
+// escalationID returns the escalation bead a record refers to, if any.
+// Escalations raised before escalation_id was recorded carry the ID in
+// the payload's rig field (see events.EscalationPayload).
+func escalationID(r *logRecord) string {
+	if id, ok := r.payload["escalation_id"].(string); ok && id != "" {
+		return id
+	}
+	if r.typ == events.TypeEscalationSent {
+		if id, ok := r.payload["rig"].(string); ok {
+			return id
+		}
+	}
+	return ""
+}
+
+// recordKey identifies a record across the event log and the feed.
+func recordKey(r *logRecord) string {
+	return r.ts.Format(time.RFC3339) + "|" + r.typ + "|" + r.actor
+}
+
+// evictionCandidate is a record or transcript that may go to meet the budget.
+type evictionCandidate struct {
+	score float64
+	ts    time.Time
+	size  int64
+	evict func() bool // false if the candidate turned out to be protected
+}
+
+// evictToBudget evicts the lowest-scoring candidates until total fits the
+// budget, returning the new total. Ties go to the oldest record first.
+func evictToBudget(candidates []evictionCandidate, total, budget int64) int64 {
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].score != candidates[j].score {
+			return candidates[i].score < candidates[j].score
+		}
+		return candidates[i].ts.Before(candidates[j].ts)
+	})
+	for _, c := range candidates {
+		if total <= budget {
+			break
+		}
+		if c.evict() {
+			total -= c.size
+		}
+	}
+	return total
+}
+
+// Rollup summarizes the records of one type evicted on one day.
+type Rollup struct {
+	Type    string         `json:"type"`
+	Day     string         `json:"day"` // UTC, YYYY-MM-DD
+	Count   int            `json:"count"`
+	Bytes   int64          `json:"bytes"`
+	First   time.Time      `json:"first"`
+	Last    time.Time      `json:"last"`
+	Actors  map[string]int `json:"actors,omitempty"`
+	Reasons map[string]int `json:"reasons,omitempty"` // "ttl" or "budget"
+}
+
+// RollupPath returns the path to the rollup file.
+func RollupPath(townRoot string) string {
+	return filepath.Join(townRoot, RollupFile)
+}
+
+// rollupSet accumulates rollups keyed by type and day.
+type rollupSet map[string]*Rollup
+
+func (s rollupSet) get(typ, day string) *Rollup {
+	key := typ + "|" + day
+	r := s[key]
+	if r == nil {
+		r = &Rollup{Type: typ, Day: day, Actors: make(map[string]int), Reasons: make(map[string]int)}
+		s[key] = r
+	}
+	return r
+}
+
+// add counts one evicted record.
+func (s rollupSet) add(typ string, ts time.Time, actor string, size int64, reason string) {
+	r := s.get(typ, ts.UTC().Format("2006-01-02"))
+	r.Count++
+	r.Bytes += size
+	if r.First.IsZero() || ts.Before(r.First) {
+		r.First = ts
+	}
+	if ts.After(r.Last) {
+		r.Last = ts
+	}
+	r.countActor(actor, 1)
+	r.Reasons[reason]++
+}
+
+// merge folds an existing rollup into the set.
+func (s rollupSet) merge(o Rollup) {
+	r := s.get(o.Type, o.Day)
+	r.Count += o.Count
+	r.Bytes += o.Bytes
+	if r.First.IsZero() || (!o.First.IsZero() && o.First.Before(r.First)) {
+		r.First = o.First
+	}
+	if o.Last.After(r.Last) {
+		r.Last = o.Last
+	}
+	for a, n := range o.Actors {
+		r.countActor(a, n)
+	}
+	for reason, n := range o.Reasons {
+		r.Reasons[reason] += n
+	}
+}
+
+func (r *Rollup) countActor(actor string, n int) {
+	if actor == "" {
+		return
+	}
+	named := len(r.Actors)
+	if _, ok := r.Actors["other"]; ok {
+		named--
+	}
+	if _, ok := r.Actors[actor]; !ok && named >= maxRollupActors {
+		actor = "other"
+	}
+	r.Actors[actor] += n
+}
+
+// LoadRollups returns the town's rollups, oldest day first.
+func LoadRollups(townRoot string) ([]Rollup, error) {
+	f, err := os.Open(RollupPath(townRoot))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading rollups: %w", err)
+	}
+	defer f.Close()
+
+	var rollups []Rollup
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var r Rollup
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			continue
+		}
+		rollups = append(rollups, r)
+	}
+	return rollups, scanner.Err()
+}
+
+// saveRollups merges added into the rollup file, dropping rollups whose
+// newest record is older than the rollup TTL.
+func saveRollups(townRoot string, added rollupSet, ttl time.Duration, now time.Time) error {
+	existing, err := LoadRollups(townRoot)
+	if err != nil {
+		return err
+	}
+	if len(existing) == 0 && len(added) == 0 {
+		return nil
+	}
+
+	all := make(rollupSet)
+	for _, r := range existing {
+		all.merge(r)
+	}
+	for _, r := range added {
+		all.merge(*r)
+	}
+
+	rollups := make([]*Rollup, 0, len(all))
+	for _, r := range all {
+		if ttl > 0 && now.Sub(r.Last) > ttl {
+			continue
+		}
+		rollups = append(rollups, r)
+	}
+	sort.Slice(rollups, func(i, j int) bool {
+		if rollups[i].Day != rollups[j].Day {
+			return rollups[i].Day < rollups[j].Day
+		}
+		return rollups[i].Type < rollups[j].Type
+	})
+
+	path := RollupPath(townRoot)
+	tmp := path + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return fmt.Errorf("writing rollups: %w", err)
+	}
+	enc := json.NewEncoder(f)
+	for _, r := range rollups {
+		if err := enc.Encode(r); err != nil {
+			_ = f.Close()
+			_ = os.Remove(tmp)
+			return fmt.Errorf("writing rollups: %w", err)
+		}
+	}
+	if err := f.Close(); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("writing rollups: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("replacing rollups: %w", err)
+	}
+	return nil
+}
+
+// transcriptSize is an archived transcript's size on disk.
+func transcriptSize(e transcript.Entry) int64 {
+	if e.Stored > 0 {
+		return e.Stored
+	}
+	return e.Bytes
+}
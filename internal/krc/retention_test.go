@@ -0,0 +1,399 @@
+package krc
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/events"
+)
+
+type testEvent struct {
+	ts      time.Time
+	typ     string
+	actor   string
+	payload map[string]interface{}
+}
+
+func writeTestEvents(t *testing.T, path string, evs []testEvent) {
+	t.Helper()
+	var b strings.Builder
+	for _, e := range evs {
+		data, err := json.Marshal(map[string]interface{}{
+			"ts":      e.ts.Format(time.RFC3339),
+			"type":    e.typ,
+			"actor":   e.actor,
+			"payload": e.payload,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		b.Write(data)
+		b.WriteString("\n")
+	}
+	if err := os.WriteFile(path, []byte(b.String()), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readTestEventTypes(t *testing.T, path string) []string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var types []string
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		if line == "" {
+			continue
+		}
+		var e struct {
+			Type  string `json:"type"`
+			Actor string `json:"actor"`
+		}
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			t.Fatal(err)
+		}
+		types = append(types, e.Type+"@"+e.Actor)
+	}
+	return types
+}
+
+// stubOpenEscalations makes ids the open escalation beads, or bead status
+// unknown if ids is nil.
+func stubOpenEscalations(t *testing.T, ids []string) {
+	t.Helper()
+	orig := openEscalations
+	t.Cleanup(func() { openEscalations = orig })
+	openEscalations = func(string) (map[string]bool, error) {
+		if ids == nil {
+			return nil, errors.New("bd unavailable")
+		}
+		open := make(map[string]bool)
+		for _, id := range ids {
+			open[id] = true
+		}
+		return open, nil
+	}
+}
+
+func TestPruner_ProtectsOpenEscalations(t *testing.T) {
+	stubOpenEscalations(t, []string{"hq-esc1"})
+	tmpDir := t.TempDir()
+	eventsPath := filepath.Join(tmpDir, ".events.jsonl")
+	now := time.Now().UTC()
+	old := now.Add(-20 * 24 * time.Hour)
+
+	writeTestEvents(t, eventsPath, []testEvent{
+		// Open escalation (legacy payload: ID in the rig field) and the
+		// escalating agent's lead-up
+		{old.Add(-30 * time.Minute), "nudge", "gastown/polecats/max", nil},
+		{old, "escalation_sent", "gastown/polecats/max", map[string]interface{}{"rig": "hq-esc1"}},
+		{old.Add(time.Hour), "escalation_acked", "mayor", map[string]interface{}{"escalation_id": "hq-esc1"}},
+		// Closed escalation: prunable
+		{old, "escalation_sent", "gastown/polecats/ace", map[string]interface{}{"escalation_id": "hq-esc2"}},
+		{old.Add(time.Hour), "escalation_closed", "mayor", map[string]interface{}{"escalation_id": "hq-esc2"}},
+		// Unrelated old event
+		{old, "nudge", "gastown/polecats/ace", nil},
+	})
+
+	result, err := NewPruner(tmpDir, DefaultConfig()).Prune()
+	if err != nil {
+		t.Fatalf("Prune failed: %v", err)
+	}
+
+	got := readTestEventTypes(t, eventsPath)
+	want := []string{"nudge@gastown/polecats/max", "escalation_sent@gastown/polecats/max", "escalation_acked@mayor"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("retained %v, want %v", got, want)
+	}
+	if result.EventsProtected != 3 {
+		t.Errorf("EventsProtected = %d, want 3", result.EventsProtected)
+	}
+	if result.EventsPruned != 3 {
+		t.Errorf("EventsPruned = %d, want 3", result.EventsPruned)
+	}
+}
+
+func TestPruner_EscalationOpenByBeadStatus(t *testing.T) {
+	now := time.Now().UTC()
+	old := now.Add(-20 * 24 * time.Hour)
+	// Neither escalation has an escalation_closed event.
+	evs := []testEvent{
+		{old, "escalation_sent", "gastown/polecats/max", map[string]interface{}{"escalation_id": "hq-esc1"}},
+		{old, "escalation_sent", "gastown/polecats/ace", map[string]interface{}{"escalation_id": "hq-esc2"}},
+	}
+
+	tests := []struct {
+		name string
+		open []string
+		want string
+	}{
+		{"closed bead is pruned", []string{"hq-esc1"}, "escalation_sent@gastown/polecats/max"},
+		{"unknown status keeps all", nil, "escalation_sent@gastown/polecats/max,escalation_sent@gastown/polecats/ace"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stubOpenEscalations(t, tt.open)
+			tmpDir := t.TempDir()
+			eventsPath := filepath.Join(tmpDir, ".events.jsonl")
+			writeTestEvents(t, eventsPath, evs)
+
+			if _, err := NewPruner(tmpDir, DefaultConfig()).Prune(); err != nil {
+				t.Fatalf("Prune failed: %v", err)
+			}
+			if got := strings.Join(readTestEventTypes(t, eventsPath), ","); got != tt.want {
+				t.Errorf("retained %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPruner_ProtectsRecentDeaths(t *testing.T) {
+	tmpDir := t.TempDir()
+	eventsPath := filepath.Join(tmpDir, ".events.jsonl")
+	now := time.Now().UTC()
+	died := now.Add(-2 * 24 * time.Hour)
+
+	config := DefaultConfig()
+	config.TTLs["patrol_*"] = time.Hour
+
+	writeTestEvents(t, eventsPath, []testEvent{
+		// In the context window before the death: kept
+		{died.Add(-10 * time.Minute), "patrol_started", "gastown/witness", map[string]interface{}{"session": "gt-gastown-max"}},
+		// Outside the window: pruned
+		{died.Add(-3 * time.Hour), "patrol_started", "gastown/witness", map[string]interface{}{"session": "gt-gastown-max"}},
+		// Another agent in the window: pruned
+		{died.Add(-10 * time.Minute), "patrol_started", "gastown/witness", map[string]interface{}{"session": "gt-gastown-ace"}},
+		{died, "session_death", "daemon", map[string]interface{}{"session": "gt-gastown-max", "agent": "gastown/polecats/max"}},
+	})
+
+	result, err := NewPruner(tmpDir, config).Prune()
+	if err != nil {
+		t.Fatalf("Prune failed: %v", err)
+	}
+	if result.EventsPruned != 2 || result.EventsProtected != 1 {
+		t.Errorf("pruned %d, protected %d; want 2 and 1", result.EventsPruned, result.EventsProtected)
+	}
+
+	// Past the protection period, the lead-up expires normally.
+	writeTestEvents(t, eventsPath, []testEvent{
+		{died.Add(-10 * time.Minute), "patrol_started", "gastown/witness", map[string]interface{}{"session": "gt-gastown-max"}},
+		{died, "session_death", "daemon", map[string]interface{}{"session": "gt-gastown-max"}},
+	})
+	config.DeathProtection = 24 * time.Hour
+	result, err = NewPruner(tmpDir, config).Prune()
+	if err != nil {
+		t.Fatalf("Prune failed: %v", err)
+	}
+	if result.EventsPruned != 1 || result.EventsProtected != 0 {
+		t.Errorf("pruned %d, protected %d; want 1 and 0", result.EventsPruned, result.EventsProtected)
+	}
+}
+
+func TestPruner_DiskBudgetEvictsLowestScore(t *testing.T) {
+	tmpDir := t.TempDir()
+	eventsPath := filepath.Join(tmpDir, ".events.jsonl")
+	now := time.Now().UTC()
+
+	// All on one UTC day, so they share a rollup
+	base := now.Add(-12 * time.Hour).Truncate(24 * time.Hour)
+
+	var evs []testEvent
+	for i := 0; i < 20; i++ {
+		// Patrols decay rapidly: well into their TTL they're near zero
+		evs = append(evs, testEvent{base.Add(time.Duration(i) * time.Minute), "patrol_started", "deacon", nil})
+	}
+	for i := 0; i < 5; i++ {
+		evs = append(evs, testEvent{base, "mail", "mayor", nil})
+	}
+	writeTestEvents(t, eventsPath, evs)
+
+	info, err := os.Stat(eventsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := DefaultConfig()
+	config.TTLs["patrol_*"] = 48 * time.Hour
+	config.DiskBudget = info.Size() / 2
+
+	pruner := NewPruner(tmpDir, config)
+	preview, err := pruner.DryRun()
+	if err != nil {
+		t.Fatalf("DryRun failed: %v", err)
+	}
+	if after, _ := os.Stat(eventsPath); after.Size() != info.Size() {
+		t.Fatal("DryRun modified the events file")
+	}
+
+	result, err := pruner.Prune()
+	if err != nil {
+		t.Fatalf("Prune failed: %v", err)
+	}
+	if result.EventsEvicted != preview.EventsEvicted {
+		t.Errorf("DryRun predicted %d evictions, Prune made %d", preview.EventsEvicted, result.EventsEvicted)
+	}
+	if result.EventsEvicted == 0 || result.PrunedByType["mail"] != 0 {
+		t.Errorf("expected only patrols evicted, got %v", result.PrunedByType)
+	}
+	if result.BytesAfter > config.DiskBudget {
+		t.Errorf("BytesAfter = %d, over budget %d", result.BytesAfter, config.DiskBudget)
+	}
+
+	got := readTestEventTypes(t, eventsPath)
+	if len(got) != 25-result.EventsEvicted {
+		t.Errorf("file has %d events, want %d", len(got), 25-result.EventsEvicted)
+	}
+
+	rollups, err := LoadRollups(tmpDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rollups) != 1 {
+		t.Fatalf("got %d rollups, want 1: %+v", len(rollups), rollups)
+	}
+	r := rollups[0]
+	if r.Type != "patrol_started" || r.Count != result.EventsEvicted || r.Reasons["budget"] != r.Count || r.Actors["deacon"] != r.Count {
+		t.Errorf("rollup = %+v", r)
+	}
+	// Oldest patrols go first
+	if !r.First.Equal(base) {
+		t.Errorf("first evicted = %v, want %v", r.First, base)
+	}
+}
+
+func TestSaveRollups_MergesAndExpires(t *testing.T) {
+	tmpDir := t.TempDir()
+	now := time.Now().UTC()
+	day := now.Add(-24 * time.Hour)
+
+	first := make(rollupSet)
+	first.add("nudge", day, "a", 10, evictTTL)
+	first.add("nudge", now.Add(-400*24*time.Hour), "a", 10, evictTTL)
+	if err := saveRollups(tmpDir, first, 0, now); err != nil {
+		t.Fatal(err)
+	}
+
+	second := make(rollupSet)
+	second.add("nudge", day, "b", 5, evictBudget)
+	for i := 0; i < maxRollupActors+2; i++ {
+		second.add("nudge", day, strings.Repeat("x", i+1), 1, evictTTL)
+	}
+	if err := saveRollups(tmpDir, second, 365*24*time.Hour, now); err != nil {
+		t.Fatal(err)
+	}
+
+	rollups, err := LoadRollups(tmpDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rollups) != 1 {
+		t.Fatalf("got %d rollups, want 1 (old one expired)", len(rollups))
+	}
+	r := rollups[0]
+	if r.Count != 2+maxRollupActors+2 || r.Bytes != 15+maxRollupActors+2 {
+		t.Errorf("rollup counts = %d/%d", r.Count, r.Bytes)
+	}
+	if r.Reasons["ttl"] != 1+maxRollupActors+2 || r.Reasons["budget"] != 1 {
+		t.Errorf("reasons = %v", r.Reasons)
+	}
+	if len(r.Actors) > maxRollupActors+1 || r.Actors["other"] == 0 {
+		t.Errorf("actors not capped: %v", r.Actors)
+	}
+}
+
+func TestPruner_DropsExpiredSegments(t *testing.T) {
+	tmpDir := t.TempDir()
+	eventsPath := filepath.Join(tmpDir, ".events.jsonl")
+	segDir := events.SegmentsDir(eventsPath)
+	if err := os.MkdirAll(segDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now().UTC()
+	old := now.Add(-20 * 24 * time.Hour)
+	recent := now.Add(-24 * time.Hour)
+
+	// Closed segments, left unsealed as if the writer died before sealing
+	writeTestEvents(t, filepath.Join(segDir, "old.jsonl"), []testEvent{
+		{old, "nudge", "gastown/polecats/ace", nil},
+		{old.Add(time.Minute), "patrol_started", "deacon", nil},
+	})
+	writeTestEvents(t, filepath.Join(segDir, "recent.jsonl"), []testEvent{
+		{recent, "mail", "mayor", nil},
+	})
+	writeTestEvents(t, eventsPath, []testEvent{{now, "sling", "mayor", nil}})
+
+	result, err := NewPruner(tmpDir, DefaultConfig()).Prune()
+	if err != nil {
+		t.Fatalf("Prune failed: %v", err)
+	}
+	if result.EventsProcessed != 4 || result.EventsPruned != 2 || result.EventsRetained != 2 {
+		t.Errorf("processed %d, pruned %d, retained %d; want 4, 2, 2",
+			result.EventsProcessed, result.EventsPruned, result.EventsRetained)
+	}
+
+	segs, err := events.Segments(eventsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(segs) != 1 || !segs[0].Sealed() || segs[0].Types["mail"] != 1 {
+		t.Errorf("remaining segments = %+v", segs)
+	}
+
+	rollups, err := LoadRollups(tmpDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rollups) != 2 {
+		t.Errorf("got %d rollups, want 2", len(rollups))
+	}
+}
+
+func TestPruner_KeepsEventsAppendedDuringPrune(t *testing.T) {
+	tmpDir := t.TempDir()
+	eventsPath := filepath.Join(tmpDir, events.EventsFile)
+	now := time.Now().UTC()
+	writeTestEvents(t, eventsPath, []testEvent{
+		{ts: now.Add(-10 * 24 * time.Hour), typ: "test_event", actor: "old"},
+		{ts: now, typ: "test_event", actor: "new"},
+	})
+
+	// A writer holds the lock while Prune works out what to drop; Prune
+	// must wait for it and keep what it appended.
+	fl, err := events.Lock(eventsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan error, 1)
+	go func() {
+		_, err := NewPruner(tmpDir, DefaultConfig()).Prune()
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		t.Fatalf("Prune finished while the events lock was held: %v", err)
+	case <-time.After(200 * time.Millisecond):
+	}
+	f, err := os.OpenFile(eventsPath, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.WriteString(`{"ts":"` + now.Format(time.RFC3339) + `","type":"test_event","actor":"appended"}` + "\n"); err != nil {
+		t.Fatal(err)
+	}
+	_ = f.Close()
+	if err := fl.Unlock(); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	got := readTestEventTypes(t, eventsPath)
+	if want := "test_event@new,test_event@appended"; strings.Join(got, ",") != want {
+		t.Errorf("after prune: %v, want %s", got, want)
+	}
+}
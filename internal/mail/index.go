@@ -0,0 +1,466 @@
+package mail
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/gofrs/flock"
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// indexDirName is the mail search index, stored in the .runtime directory
+// next to the .beads database that holds the mail.
+const indexDirName = "mail-index"
+
+// Files inside the index directory. The snapshot is written by a full
+// rebuild; every incremental update is appended to the journal, which is
+// replayed over the snapshot on load and cleared by the next rebuild.
+const (
+	indexSnapshotFile = "snapshot.json"
+	indexJournalFile  = "journal.jsonl"
+	indexLockFile     = "index.lock"
+)
+
+// indexMaxAge bounds how long an index may go without a full rebuild.
+// Incremental updates cover mail sent through gt; the periodic rebuild
+// picks up anything written to beads by other means.
+const indexMaxAge = 24 * time.Hour
+
+// subjectBoost weights subject matches above body matches when ranking.
+const subjectBoost = 3.0
+
+// IndexedMessage is a message as stored in the search index. The body is
+// not stored: only its term counts and adjacent word pairs, which is all
+// ranking and phrase matching need. Read the message for its contents.
+type IndexedMessage struct {
+	ID        string         `json:"id"`
+	From      string         `json:"from"`
+	To        string         `json:"to"`
+	CC        []string       `json:"cc,omitempty"`
+	Subject   string         `json:"subject"`
+	BodyTerms map[string]int `json:"body_terms,omitempty"` // word → occurrences
+	BodyPairs map[string]int `json:"body_pairs,omitempty"` // "word next" → occurrences
+	ThreadID  string         `json:"thread_id,omitempty"`
+	ReplyTo   string         `json:"reply_to,omitempty"`
+	Timestamp time.Time      `json:"timestamp"`
+	Read      bool           `json:"read,omitempty"`
+	Priority  Priority       `json:"priority,omitempty"`
+	Type      MessageType    `json:"type,omitempty"`
+	Wisp      bool           `json:"wisp,omitempty"`
+}
+
+// indexSnapshot is the persisted form of a full rebuild.
+type indexSnapshot struct {
+	Docs []*IndexedMessage `json:"docs"`
+}
+
+// Journal operations.
+const (
+	journalAdd  = "add"
+	journalRead = "read"
+)
+
+// journalEntry is one incremental update, appended as a JSON line.
+type journalEntry struct {
+	Op   string          `json:"op"`
+	Doc  *IndexedMessage `json:"doc,omitempty"`
+	ID   string          `json:"id,omitempty"`
+	Read bool            `json:"read,omitempty"`
+}
+
+// indexData is the in-memory index: the snapshot with the journal applied
+// and postings built over the result.
+type indexData struct {
+	Docs     map[string]*IndexedMessage
+	Postings map[string][]string // term → sorted doc IDs
+}
+
+// Index is a persistent inverted index over mail subjects and bodies.
+// Sending or reading mail appends one journal line, so updates cost the
+// same however much mail the town holds. The index is rebuilt in full
+// when missing or older than indexMaxAge.
+//
+// Concurrent gt processes coordinate through a flock in the index directory.
+type Index struct {
+	dir string
+}
+
+// NewIndex returns the index for mail stored in beadsDir.
+func NewIndex(beadsDir string) *Index {
+	return &Index{
+		dir: filepath.Join(filepath.Dir(beadsDir), ".runtime", indexDirName),
+	}
+}
+
+// Path returns the index directory.
+func (ix *Index) Path() string {
+	return ix.dir
+}
+
+func (ix *Index) lock() (*flock.Flock, error) {
+	if err := os.MkdirAll(ix.dir, 0755); err != nil {
+		return nil, fmt.Errorf("creating index dir: %w", err)
+	}
+	fl := flock.New(filepath.Join(ix.dir, indexLockFile))
+	if err := fl.Lock(); err != nil {
+		return nil, fmt.Errorf("acquiring index lock: %w", err)
+	}
+	return fl, nil
+}
+
+func (ix *Index) load() (*indexData, error) {
+	d := &indexData{
+		Docs:     make(map[string]*IndexedMessage),
+		Postings: make(map[string][]string),
+	}
+	data, err := os.ReadFile(filepath.Join(ix.dir, indexSnapshotFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return d, nil
+		}
+		return nil, fmt.Errorf("reading mail index: %w", err)
+	}
+	var snap indexSnapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, fmt.Errorf("parsing mail index: %w", err)
+	}
+	for _, doc := range snap.Docs {
+		if doc.ID != "" {
+			d.Docs[doc.ID] = doc
+		}
+	}
+
+	journal, err := os.ReadFile(filepath.Join(ix.dir, indexJournalFile))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("reading mail index journal: %w", err)
+	}
+	for _, line := range bytes.Split(journal, []byte("\n")) {
+		var entry journalEntry
+		if len(line) == 0 || json.Unmarshal(line, &entry) != nil {
+			continue // a torn final line from an interrupted append
+		}
+		switch entry.Op {
+		case journalAdd:
+			if entry.Doc != nil && entry.Doc.ID != "" {
+				d.Docs[entry.Doc.ID] = entry.Doc
+			}
+		case journalRead:
+			if doc := d.Docs[entry.ID]; doc != nil {
+				doc.Read = entry.Read
+			}
+		}
+	}
+
+	for id, doc := range d.Docs {
+		for term := range docTerms(doc) {
+			d.Postings[term] = append(d.Postings[term], id)
+		}
+	}
+	for _, ids := range d.Postings {
+		sort.Strings(ids)
+	}
+	return d, nil
+}
+
+// appendJournal records an incremental update under the lock. Updates to
+// an index that has never been built are dropped: the first search
+// rebuilds it from beads anyway.
+func (ix *Index) appendJournal(entry journalEntry) error {
+	fl, err := ix.lock()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = fl.Unlock() }()
+
+	if _, err := os.Stat(filepath.Join(ix.dir, indexSnapshotFile)); err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("checking mail index: %w", err)
+	}
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("encoding mail index entry: %w", err)
+	}
+	f, err := os.OpenFile(filepath.Join(ix.dir, indexJournalFile), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("opening mail index journal: %w", err)
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("appending to mail index journal: %w", err)
+	}
+	return f.Close()
+}
+
+// Stale reports whether the index needs a full rebuild.
+func (ix *Index) Stale() bool {
+	info, err := os.Stat(filepath.Join(ix.dir, indexSnapshotFile))
+	if err != nil {
+		return true
+	}
+	return timeNow().Sub(info.ModTime()) > indexMaxAge
+}
+
+// Rebuild replaces the index contents with the messages list returns and
+// clears the journal. Later messages with the same ID replace earlier ones.
+// list runs under the index lock, so updates journaled while it lists
+// wait for the rebuild instead of being cleared with the journal.
+func (ix *Index) Rebuild(list func() ([]*Message, error)) error {
+	fl, err := ix.lock()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = fl.Unlock() }()
+
+	messages, err := list()
+	if err != nil {
+		return err
+	}
+	docs := make(map[string]*IndexedMessage, len(messages))
+	for _, msg := range messages {
+		if msg.ID != "" {
+			docs[msg.ID] = indexedFromMessage(msg)
+		}
+	}
+	snap := indexSnapshot{Docs: make([]*IndexedMessage, 0, len(docs))}
+	for _, doc := range docs {
+		snap.Docs = append(snap.Docs, doc)
+	}
+	sort.Slice(snap.Docs, func(i, j int) bool { return snap.Docs[i].ID < snap.Docs[j].ID })
+
+	if err := util.AtomicWriteJSON(filepath.Join(ix.dir, indexSnapshotFile), snap); err != nil {
+		return err
+	}
+	if err := os.Remove(filepath.Join(ix.dir, indexJournalFile)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("clearing mail index journal: %w", err)
+	}
+	return nil
+}
+
+// Add indexes (or re-indexes) a message.
+func (ix *Index) Add(msg *Message) error {
+	if msg.ID == "" {
+		return nil
+	}
+	return ix.appendJournal(journalEntry{Op: journalAdd, Doc: indexedFromMessage(msg)})
+}
+
+// MarkRead records that a message was read or archived.
+func (ix *Index) MarkRead(id string, read bool) error {
+	return ix.appendJournal(journalEntry{Op: journalRead, ID: id, Read: read})
+}
+
+func indexedFromMessage(msg *Message) *IndexedMessage {
+	doc := &IndexedMessage{
+		ID:        msg.ID,
+		From:      msg.From,
+		To:        msg.To,
+		CC:        msg.CC,
+		Subject:   msg.Subject,
+		ThreadID:  msg.ThreadID,
+		ReplyTo:   msg.ReplyTo,
+		Timestamp: msg.Timestamp,
+		Read:      msg.Read,
+		Priority:  msg.Priority,
+		Type:      msg.Type,
+		Wisp:      msg.Wisp,
+	}
+	words := tokenize(msg.Body)
+	if len(words) > 0 {
+		doc.BodyTerms = make(map[string]int)
+		doc.BodyPairs = make(map[string]int)
+	}
+	for i, w := range words {
+		doc.BodyTerms[w]++
+		if i+1 < len(words) {
+			doc.BodyPairs[w+" "+words[i+1]]++
+		}
+	}
+	return doc
+}
+
+// toMessage converts an indexed document back to a Message for display.
+// The Body is left empty.
+func (doc *IndexedMessage) toMessage() *Message {
+	return &Message{
+		ID:        doc.ID,
+		From:      doc.From,
+		To:        doc.To,
+		CC:        doc.CC,
+		Subject:   doc.Subject,
+		ThreadID:  doc.ThreadID,
+		ReplyTo:   doc.ReplyTo,
+		Timestamp: doc.Timestamp,
+		Read:      doc.Read,
+		Priority:  doc.Priority,
+		Type:      doc.Type,
+		Wisp:      doc.Wisp,
+	}
+}
+
+// docTerms returns the set of terms a document is posted under.
+func docTerms(doc *IndexedMessage) map[string]bool {
+	terms := make(map[string]bool, len(doc.BodyTerms))
+	for _, term := range tokenize(doc.Subject) {
+		terms[term] = true
+	}
+	for term := range doc.BodyTerms {
+		terms[term] = true
+	}
+	return terms
+}
+
+// tokenize lowercases text and splits it into letter/digit runs.
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// IndexSearchOptions scopes an index search.
+type IndexSearchOptions struct {
+	// Recipients restricts results to messages addressed (To or CC) to one
+	// of these beads identities. Empty means no restriction.
+	Recipients []string
+
+	// SubjectOnly / BodyOnly restrict which fields free-text terms match.
+	SubjectOnly bool
+	BodyOnly    bool
+}
+
+// Search evaluates q against the index. Results are ranked by relevance
+// (TF-IDF, subject matches boosted) when q has free-text terms, otherwise
+// newest first.
+func (ix *Index) Search(q *Query, opts IndexSearchOptions) ([]*Message, error) {
+	d, err := ix.load()
+	if err != nil {
+		return nil, err
+	}
+
+	recipients := make(map[string]bool, len(opts.Recipients))
+	for _, r := range opts.Recipients {
+		recipients[r] = true
+	}
+
+	// Candidate set: intersect postings for every required term so only
+	// documents containing all of them are examined.
+	var candidates []string
+	required := q.requiredTerms()
+	if len(required) == 0 {
+		for id := range d.Docs {
+			candidates = append(candidates, id)
+		}
+	} else {
+		candidates = d.matchTerm(required[0])
+		for _, term := range required[1:] {
+			candidates = intersectSorted(candidates, d.matchTerm(term))
+			if len(candidates) == 0 {
+				break
+			}
+		}
+	}
+
+	type scored struct {
+		doc   *IndexedMessage
+		score float64
+	}
+	var results []scored
+	for _, id := range candidates {
+		doc := d.Docs[id]
+		if doc == nil {
+			continue
+		}
+		if len(recipients) > 0 && !addressedTo(doc, recipients) {
+			continue
+		}
+		score, ok := q.match(doc, d, opts)
+		if !ok {
+			continue
+		}
+		results = append(results, scored{doc: doc, score: score})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].score != results[j].score {
+			return results[i].score > results[j].score
+		}
+		return results[i].doc.Timestamp.After(results[j].doc.Timestamp)
+	})
+
+	messages := make([]*Message, 0, len(results))
+	for _, r := range results {
+		messages = append(messages, r.doc.toMessage())
+	}
+	return messages, nil
+}
+
+// matchTerm returns the sorted doc IDs containing term. A trailing "*"
+// makes it a prefix match.
+func (d *indexData) matchTerm(term string) []string {
+	prefix, ok := strings.CutSuffix(term, "*")
+	if !ok {
+		return d.Postings[term]
+	}
+	set := make(map[string]bool)
+	for t, ids := range d.Postings {
+		if strings.HasPrefix(t, prefix) {
+			for _, id := range ids {
+				set[id] = true
+			}
+		}
+	}
+	ids := make([]string, 0, len(set))
+	for id := range set {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func intersectSorted(a, b []string) []string {
+	var out []string
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			out = append(out, a[i])
+			i++
+			j++
+		case a[i] < b[j]:
+			i++
+		default:
+			j++
+		}
+	}
+	return out
+}
+
+func addressedTo(doc *IndexedMessage, recipients map[string]bool) bool {
+	if recipients[AddressToIdentity(doc.To)] {
+		return true
+	}
+	for _, cc := range doc.CC {
+		if recipients[AddressToIdentity(cc)] {
+			return true
+		}
+	}
+	return false
+}
+
+// idf is the inverse document frequency of term in d.
+func (d *indexData) idf(term string) float64 {
+	n := len(d.matchTerm(term))
+	if n == 0 {
+		return 0
+	}
+	return math.Log(1 + float64(len(d.Docs))/float64(n))
+}
@@ -0,0 +1,255 @@
+package mail
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestIndex(t *testing.T, messages ...*Message) *Index {
+	t.Helper()
+	ix := NewIndex(filepath.Join(t.TempDir(), ".beads"))
+	if err := ix.Rebuild(listed(messages...)); err != nil {
+		t.Fatalf("Rebuild: %v", err)
+	}
+	return ix
+}
+
+// listed returns a Rebuild list function returning messages.
+func listed(messages ...*Message) func() ([]*Message, error) {
+	return func() ([]*Message, error) { return messages, nil }
+}
+
+func searchIDs(t *testing.T, ix *Index, query string, opts IndexSearchOptions) []string {
+	t.Helper()
+	q, err := ParseQuery(query)
+	if err != nil {
+		t.Fatalf("ParseQuery(%q): %v", query, err)
+	}
+	msgs, err := ix.Search(q, opts)
+	if err != nil {
+		t.Fatalf("Search(%q): %v", query, err)
+	}
+	ids := make([]string, 0, len(msgs))
+	for _, m := range msgs {
+		ids = append(ids, m.ID)
+	}
+	return ids
+}
+
+func assertIDs(t *testing.T, query string, got []string, want ...string) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("search %q = %v, want %v", query, got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("search %q = %v, want %v", query, got, want)
+		}
+	}
+}
+
+func TestParseQuery(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	oldNow := timeNow
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = oldNow }()
+
+	q, err := ParseQuery(`merge from:witness to:gastown/ "build failed" after:7d before:2026-03-09 thread:t-1 deploy* foo-bar`)
+	if err != nil {
+		t.Fatalf("ParseQuery: %v", err)
+	}
+	if len(q.Terms) != 2 || q.Terms[0] != "merge" || q.Terms[1] != "deploy*" {
+		t.Errorf("Terms = %v, want [merge deploy*]", q.Terms)
+	}
+	if len(q.Phrases) != 2 || len(q.Phrases[0]) != 2 || q.Phrases[0][1] != "failed" || q.Phrases[1][0] != "foo" {
+		t.Errorf("Phrases = %v, want [[build failed] [foo bar]]", q.Phrases)
+	}
+	if q.From != "witness" || q.To != "gastown/" || q.Thread != "t-1" {
+		t.Errorf("fields = from %q to %q thread %q", q.From, q.To, q.Thread)
+	}
+	if !q.After.Equal(now.AddDate(0, 0, -7)) {
+		t.Errorf("After = %v, want 7 days before %v", q.After, now)
+	}
+	if q.Before.Day() != 9 {
+		t.Errorf("Before = %v, want 2026-03-09", q.Before)
+	}
+
+	if _, err := ParseQuery("after:yesterday"); err == nil {
+		t.Error("ParseQuery(after:yesterday) should fail")
+	}
+	if q, _ := ParseQuery("http://example.com"); len(q.Phrases) != 1 {
+		t.Errorf("unknown field prefix should be searched as text, got %+v", q)
+	}
+}
+
+func TestIndexSearch(t *testing.T) {
+	base := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	ix := newTestIndex(t,
+		&Message{ID: "m1", From: "gastown/witness", To: "mayor/", Subject: "Build failed on main",
+			Body: "The build failed after merge.", ThreadID: "t-1", Timestamp: base},
+		&Message{ID: "m2", From: "gastown/refinery", To: "mayor/", Subject: "Merged",
+			Body: "Merge complete; build failed once then passed.", Timestamp: base.Add(time.Hour)},
+		&Message{ID: "m3", From: "gastown/witness", To: "gastown/polecats/Toast", Subject: "Patrol",
+			Body: "Deployment looks healthy", CC: []string{"mayor/"}, Timestamp: base.Add(2 * time.Hour)},
+		&Message{ID: "m4", From: "deacon/", To: "gastown/polecats/Toast", Subject: "build",
+			Body: "private", Timestamp: base.Add(3 * time.Hour)},
+	)
+	mayor := IndexSearchOptions{Recipients: []string{"mayor/"}}
+
+	// Subject match outranks a body-only match.
+	assertIDs(t, "build", searchIDs(t, ix, "build", mayor), "m1", "m2")
+
+	// Phrases require adjacent words; both messages contain "build failed".
+	assertIDs(t, `"failed build"`, searchIDs(t, ix, `"failed build"`, mayor))
+	assertIDs(t, `"build failed"`, searchIDs(t, ix, `"build failed"`, mayor), "m1", "m2")
+
+	// Field filters, with no free text, return newest first. CC counts as addressed.
+	assertIDs(t, "from:witness", searchIDs(t, ix, "from:witness", mayor), "m3", "m1")
+	assertIDs(t, "thread:t-1", searchIDs(t, ix, "thread:t-1", mayor), "m1")
+	assertIDs(t, "to:toast", searchIDs(t, ix, "to:toast", mayor), "m3")
+	assertIDs(t, "after/before", searchIDs(t, ix, "after:2026-03-01T00:30:00Z before:2026-03-01T02:00:00Z", mayor), "m2")
+
+	// Prefix terms.
+	assertIDs(t, "deploy*", searchIDs(t, ix, "deploy*", mayor), "m3")
+
+	// Field restriction.
+	assertIDs(t, "merge --subject", searchIDs(t, ix, "merge", IndexSearchOptions{Recipients: []string{"mayor/"}, SubjectOnly: true}))
+
+	// Other mailboxes' mail is excluded.
+	assertIDs(t, "private", searchIDs(t, ix, "private", mayor))
+	assertIDs(t, "private (unscoped)", searchIDs(t, ix, "private", IndexSearchOptions{}), "m4")
+}
+
+func TestIndexIncrementalUpdates(t *testing.T) {
+	ix := newTestIndex(t,
+		&Message{ID: "m1", From: "deacon/", To: "mayor/", Subject: "alpha", Body: "first"},
+	)
+
+	if err := ix.Add(&Message{ID: "m2", From: "deacon/", To: "mayor/", Subject: "beta", Body: "second"}); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	assertIDs(t, "beta", searchIDs(t, ix, "beta", IndexSearchOptions{}), "m2")
+
+	// Re-adding replaces old postings.
+	if err := ix.Add(&Message{ID: "m1", From: "deacon/", To: "mayor/", Subject: "gamma", Body: "first"}); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	assertIDs(t, "alpha", searchIDs(t, ix, "alpha", IndexSearchOptions{}))
+	assertIDs(t, "gamma", searchIDs(t, ix, "gamma", IndexSearchOptions{}), "m1")
+
+	if err := ix.MarkRead("m1", true); err != nil {
+		t.Fatalf("MarkRead: %v", err)
+	}
+	q, _ := ParseQuery("gamma")
+	msgs, _ := ix.Search(q, IndexSearchOptions{})
+	if len(msgs) != 1 || !msgs[0].Read {
+		t.Errorf("after MarkRead, got %+v", msgs)
+	}
+}
+
+func TestIndexAppendsAndKeepsBodiesOut(t *testing.T) {
+	ix := newTestIndex(t,
+		&Message{ID: "m1", From: "deacon/", To: "mayor/", Subject: "alpha", Body: "the secret token is hunter2"},
+	)
+	snapshot := filepath.Join(ix.Path(), indexSnapshotFile)
+	before, err := os.ReadFile(snapshot)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ix.Add(&Message{ID: "m2", From: "deacon/", To: "mayor/", Subject: "beta", Body: "token rotation done"}); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if err := ix.MarkRead("m1", true); err != nil {
+		t.Fatalf("MarkRead: %v", err)
+	}
+
+	// Updates go to the journal; the snapshot is only written by Rebuild.
+	after, err := os.ReadFile(snapshot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(after) != string(before) {
+		t.Error("incremental update rewrote the snapshot")
+	}
+	journal, err := os.ReadFile(filepath.Join(ix.Path(), indexJournalFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(string(journal), "\n"); n != 2 {
+		t.Errorf("journal has %d lines, want 2", n)
+	}
+	for _, data := range [][]byte{after, journal} {
+		if strings.Contains(string(data), "the secret token is hunter2") || strings.Contains(string(data), "token rotation done") {
+			t.Errorf("index stores message bodies: %s", data)
+		}
+	}
+
+	// Phrase and term matches still work from the stored word pairs.
+	assertIDs(t, `"token is hunter2"`, searchIDs(t, ix, `"token is hunter2"`, IndexSearchOptions{}), "m1")
+	assertIDs(t, "rotation", searchIDs(t, ix, "rotation", IndexSearchOptions{}), "m2")
+
+	// Rebuild folds everything back into the snapshot.
+	if err := ix.Rebuild(listed()); err != nil {
+		t.Fatalf("Rebuild: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(ix.Path(), indexJournalFile)); !os.IsNotExist(err) {
+		t.Errorf("Rebuild left the journal in place (stat err = %v)", err)
+	}
+}
+
+func TestIndexStaleness(t *testing.T) {
+	ix := NewIndex(filepath.Join(t.TempDir(), ".beads"))
+	if !ix.Stale() {
+		t.Error("missing index should be stale")
+	}
+
+	// Incremental updates don't create an index that was never built.
+	if err := ix.Add(&Message{ID: "m1", Subject: "hello"}); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if !ix.Stale() {
+		t.Error("Add should not mark an unbuilt index as fresh")
+	}
+
+	if err := ix.Rebuild(listed()); err != nil {
+		t.Fatalf("Rebuild: %v", err)
+	}
+	if ix.Stale() {
+		t.Error("freshly built index should not be stale")
+	}
+
+	oldNow := timeNow
+	timeNow = func() time.Time { return time.Now().Add(indexMaxAge + time.Minute) }
+	defer func() { timeNow = oldNow }()
+	if !ix.Stale() {
+		t.Error("index older than indexMaxAge should be stale")
+	}
+}
+
+func TestIndexRebuildKeepsAddsDuringListing(t *testing.T) {
+	ix := newTestIndex(t, &Message{ID: "m1", Subject: "alpha"})
+
+	// A message sent while the rebuild lists beads must survive it.
+	added := make(chan error, 1)
+	err := ix.Rebuild(func() ([]*Message, error) {
+		go func() { added <- ix.Add(&Message{ID: "m2", Subject: "alpha"}) }()
+		select {
+		case err := <-added:
+			t.Errorf("Add finished during listing (err = %v); it should wait for the rebuild", err)
+			added <- err
+		case <-time.After(100 * time.Millisecond):
+		}
+		return []*Message{{ID: "m1", Subject: "alpha"}}, nil
+	})
+	if err != nil {
+		t.Fatalf("Rebuild: %v", err)
+	}
+	if err := <-added; err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	assertIDs(t, "alpha", searchIDs(t, ix, "alpha", IndexSearchOptions{}), "m1", "m2")
+}
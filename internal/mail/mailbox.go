@@ -325,7 +325,11 @@ func (m *Mailbox) MarkRead(id string) error {
 
 func (m *Mailbox) markReadBeads(id string) error {
 	// Single DB - wisps and persistent messages in same store
-	return m.closeInDir(id, m.beadsDir)
+	if err := m.closeInDir(id, m.beadsDir); err != nil {
+		return err
+	}
+	m.indexMarkRead(id, true)
+	return nil
 }
 
 // closeInDir closes a message in a specific beads directory.
@@ -456,6 +460,7 @@ func (m *Mailbox) markUnreadBeads(id string) error {
 		return err
 	}
 
+	m.indexMarkRead(id, false)
 	return nil
 }
 
@@ -738,7 +743,7 @@ func (m *Mailbox) rewriteArchive(messages []*Message) error {
 
 // SearchOptions specifies search parameters.
 type SearchOptions struct {
-	Query       string // Regex pattern to search for
+	Query       string // Search query (see ParseQuery for syntax)
 	FromFilter  string // Optional: only match messages from this sender
 	SubjectOnly bool   // Only search subject
 	BodyOnly    bool   // Only search body
@@ -746,8 +751,100 @@ type SearchOptions struct {
 
 // Search finds messages matching the given criteria.
 // Returns messages from both inbox and archive.
-// Query and FromFilter are treated as literal strings (not regex) to prevent ReDoS.
+//
+// Beads mailboxes search the persistent mail index, rebuilding it first if
+// it is missing or stale. Results are ranked by relevance, newest first
+// among equals, and carry no body. Legacy mailboxes fall back to a literal
+// substring scan.
 func (m *Mailbox) Search(opts SearchOptions) ([]*Message, error) {
+	if m.legacy {
+		return m.searchScan(opts)
+	}
+
+	q, err := ParseQuery(opts.Query)
+	if err != nil {
+		return nil, err
+	}
+	if opts.FromFilter != "" {
+		q.From = opts.FromFilter
+	}
+
+	ix := m.Index()
+	if ix.Stale() {
+		if err := m.RebuildIndex(); err != nil {
+			return nil, fmt.Errorf("building mail index: %w", err)
+		}
+	}
+	return ix.Search(q, IndexSearchOptions{
+		Recipients:  m.identityVariants(),
+		SubjectOnly: opts.SubjectOnly,
+		BodyOnly:    opts.BodyOnly,
+	})
+}
+
+// Index returns the search index covering this mailbox's beads database.
+func (m *Mailbox) Index() *Index {
+	beadsDir := m.beadsDir
+	if beadsDir == "" {
+		beadsDir = beads.ResolveBeadsDir(m.workDir)
+	}
+	return NewIndex(beadsDir)
+}
+
+// RebuildIndex rebuilds the mail index from every message in the beads
+// database (open and closed) plus the archive file.
+func (m *Mailbox) RebuildIndex() error {
+	if m.legacy {
+		return nil
+	}
+	if err := beads.EnsureCustomTypes(m.beadsDir); err != nil {
+		return fmt.Errorf("ensuring custom types: %w", err)
+	}
+	return m.Index().Rebuild(m.listAllMessages)
+}
+
+// listAllMessages returns every message in the beads database (open and
+// closed) and the archive file, archived copies first.
+func (m *Mailbox) listAllMessages() ([]*Message, error) {
+	args := []string{"list",
+		"--label", "gt:message",
+		"--all",
+		"--json",
+		"--limit", "0",
+	}
+	ctx, cancel := bdReadCtx()
+	defer cancel()
+	stdout, err := runBdCommand(ctx, args, m.workDir, m.beadsDir)
+	if err != nil {
+		return nil, err
+	}
+	var beadsMsgs []BeadsMessage
+	if len(stdout) > 0 && string(stdout) != "null" {
+		if err := json.Unmarshal(stdout, &beadsMsgs); err != nil {
+			return nil, fmt.Errorf("parsing bd list output: %w", err)
+		}
+	}
+
+	// Archive first so the live bead wins when a message appears in both.
+	all, err := m.ListArchived()
+	if err != nil {
+		return nil, err
+	}
+	for i := range beadsMsgs {
+		all = append(all, beadsMsgs[i].ToMessage())
+	}
+	return all, nil
+}
+
+// indexMarkRead records a read-state change in the search index. The index
+// is a cache over beads, so failures are ignored; the next rebuild repairs it.
+func (m *Mailbox) indexMarkRead(id string, read bool) {
+	_ = m.Index().MarkRead(id, read)
+}
+
+// searchScan filters every inbox and archived message in memory.
+// Query and FromFilter are treated as literal strings (not regex) to prevent ReDoS.
+func (m *Mailbox) searchScan(opts SearchOptions) ([]*Message, error) {
 	// Use QuoteMeta to escape special regex chars - prevents ReDoS attacks
 	// and provides intuitive literal string matching for users
 	re, err := regexp.Compile("(?i)" + regexp.QuoteMeta(opts.Query))
@@ -0,0 +1,292 @@
+package mail
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Query is a parsed mail search query.
+//
+// Syntax (space-separated, all clauses must match):
+//
+//	word          message contains the word (case-insensitive)
+//	word*         message contains a word starting with "word"
+//	"two words"   message contains the exact phrase
+//	from:NAME     sender contains NAME
+//	to:NAME       recipient (To or CC) contains NAME
+//	thread:ID     message belongs to thread ID
+//	after:DATE    sent on or after DATE
+//	before:DATE   sent before DATE
+//
+// DATE is YYYY-MM-DD, RFC 3339, or a relative age such as 7d or 12h.
+type Query struct {
+	Terms   []string   // single words; a trailing "*" means prefix match
+	Phrases [][]string // tokenized phrases, matched as consecutive words
+	From    string
+	To      string
+	Thread  string
+	After   time.Time
+	Before  time.Time
+}
+
+// ParseQuery parses a search query string.
+func ParseQuery(s string) (*Query, error) {
+	q := &Query{}
+	for _, tok := range splitQuery(s) {
+		if tok.quoted {
+			if words := tokenize(tok.text); len(words) > 0 {
+				q.Phrases = append(q.Phrases, words)
+			}
+			continue
+		}
+
+		if field, value, ok := strings.Cut(tok.text, ":"); ok && value != "" {
+			handled := true
+			var err error
+			switch strings.ToLower(field) {
+			case "from":
+				q.From = value
+			case "to":
+				q.To = value
+			case "thread":
+				q.Thread = value
+			case "after":
+				q.After, err = parseQueryTime(value)
+			case "before":
+				q.Before, err = parseQueryTime(value)
+			default:
+				handled = false
+			}
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s: value %q: %w", field, value, err)
+			}
+			if handled {
+				continue
+			}
+		}
+
+		prefix := strings.HasSuffix(tok.text, "*")
+		words := tokenize(tok.text)
+		switch {
+		case len(words) == 0:
+		case len(words) == 1 && prefix:
+			q.Terms = append(q.Terms, words[0]+"*")
+		case len(words) == 1:
+			q.Terms = append(q.Terms, words[0])
+		default:
+			// "foo-bar" is indexed as two words; match them adjacently.
+			q.Phrases = append(q.Phrases, words)
+		}
+	}
+	return q, nil
+}
+
+type queryToken struct {
+	text   string
+	quoted bool
+}
+
+// splitQuery splits on whitespace, keeping double-quoted runs together.
+// An unterminated quote extends to the end of the input.
+func splitQuery(s string) []queryToken {
+	var tokens []queryToken
+	var cur strings.Builder
+	inQuote := false
+	flush := func(quoted bool) {
+		if cur.Len() > 0 || quoted {
+			tokens = append(tokens, queryToken{text: cur.String(), quoted: quoted})
+		}
+		cur.Reset()
+	}
+	for _, r := range s {
+		switch {
+		case r == '"':
+			if inQuote {
+				flush(true)
+			} else {
+				flush(false)
+			}
+			inQuote = !inQuote
+		case !inQuote && (r == ' ' || r == '\t' || r == '\n'):
+			flush(false)
+		default:
+			cur.WriteRune(r)
+		}
+	}
+	flush(inQuote)
+	return tokens
+}
+
+// parseQueryTime parses an absolute date or a relative age ("7d", "12h").
+func parseQueryTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return t, nil
+	}
+	if n, ok := strings.CutSuffix(value, "d"); ok {
+		days, err := strconv.Atoi(n)
+		if err == nil && days >= 0 {
+			return timeNow().AddDate(0, 0, -days), nil
+		}
+	}
+	if d, err := time.ParseDuration(value); err == nil && d >= 0 {
+		return timeNow().Add(-d), nil
+	}
+	return time.Time{}, fmt.Errorf("expected YYYY-MM-DD, RFC 3339, or an age like 7d")
+}
+
+// IsEmpty reports whether the query has no clauses at all.
+func (q *Query) IsEmpty() bool {
+	return len(q.Terms) == 0 && len(q.Phrases) == 0 && q.From == "" && q.To == "" &&
+		q.Thread == "" && q.After.IsZero() && q.Before.IsZero()
+}
+
+// requiredTerms returns every index term a matching document must contain.
+func (q *Query) requiredTerms() []string {
+	terms := append([]string(nil), q.Terms...)
+	for _, phrase := range q.Phrases {
+		terms = append(terms, phrase...)
+	}
+	return terms
+}
+
+// match reports whether doc satisfies q and returns its relevance score.
+func (q *Query) match(doc *IndexedMessage, d *indexData, opts IndexSearchOptions) (float64, bool) {
+	if q.From != "" && !containsFold(doc.From, q.From) {
+		return 0, false
+	}
+	if q.To != "" && !containsFold(doc.To, q.To) {
+		matched := false
+		for _, cc := range doc.CC {
+			if containsFold(cc, q.To) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return 0, false
+		}
+	}
+	if q.Thread != "" && doc.ThreadID != q.Thread {
+		return 0, false
+	}
+	if !q.After.IsZero() && doc.Timestamp.Before(q.After) {
+		return 0, false
+	}
+	if !q.Before.IsZero() && !doc.Timestamp.Before(q.Before) {
+		return 0, false
+	}
+
+	var subject []string
+	if !opts.BodyOnly {
+		subject = tokenize(doc.Subject)
+	}
+	body := doc
+	if opts.SubjectOnly {
+		body = nil
+	}
+
+	score := 0.0
+	for _, term := range q.Terms {
+		s, b := countTerm(subject, term), body.countTerm(term)
+		if s+b == 0 {
+			return 0, false
+		}
+		score += d.idf(term) * (subjectBoost*tfWeight(s) + tfWeight(b))
+	}
+	for _, phrase := range q.Phrases {
+		s, b := countPhrase(subject, phrase), body.countPhrase(phrase)
+		if s+b == 0 {
+			return 0, false
+		}
+		idf := 0.0
+		for _, w := range phrase {
+			idf += d.idf(w)
+		}
+		score += idf * (subjectBoost*tfWeight(s) + tfWeight(b))
+	}
+	return score, true
+}
+
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
+
+// tfWeight dampens raw term frequency so repetition helps sublinearly.
+func tfWeight(n int) float64 {
+	if n == 0 {
+		return 0
+	}
+	return 1 + math.Log(float64(n))
+}
+
+func countTerm(words []string, term string) int {
+	prefix, isPrefix := strings.CutSuffix(term, "*")
+	n := 0
+	for _, w := range words {
+		if w == term || (isPrefix && strings.HasPrefix(w, prefix)) {
+			n++
+		}
+	}
+	return n
+}
+
+func countPhrase(words, phrase []string) int {
+	n := 0
+	for i := 0; i+len(phrase) <= len(words); i++ {
+		matched := true
+		for j, w := range phrase {
+			if words[i+j] != w {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			n++
+		}
+	}
+	return n
+}
+
+// countTerm counts term in the body. A nil doc has no body to match.
+func (doc *IndexedMessage) countTerm(term string) int {
+	if doc == nil {
+		return 0
+	}
+	prefix, isPrefix := strings.CutSuffix(term, "*")
+	if !isPrefix {
+		return doc.BodyTerms[term]
+	}
+	n := 0
+	for w, c := range doc.BodyTerms {
+		if strings.HasPrefix(w, prefix) {
+			n += c
+		}
+	}
+	return n
+}
+
+// countPhrase estimates occurrences of phrase in the body from its word
+// pairs: the rarest adjacent pair bounds how often the phrase can appear.
+// Longer phrases can over-match when their pairs occur apart.
+func (doc *IndexedMessage) countPhrase(phrase []string) int {
+	if doc == nil || len(phrase) == 0 {
+		return 0
+	}
+	if len(phrase) == 1 {
+		return doc.BodyTerms[phrase[0]]
+	}
+	n := -1
+	for i := 0; i+1 < len(phrase); i++ {
+		c := doc.BodyPairs[phrase[i]+" "+phrase[i+1]]
+		if n < 0 || c < n {
+			n = c
+		}
+	}
+	return n
+}
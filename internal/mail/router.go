@@ -1,6 +1,7 @@
 package mail
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -12,7 +13,9 @@ import (
 	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/nudge"
+	"github.com/steveyegge/gastown/internal/redact"
 	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/telemetry"
 	"github.com/steveyegge/gastown/internal/tmux"
 	"github.com/steveyegge/gastown/internal/workspace"
 )
@@ -767,6 +770,15 @@ func (r *Router) shouldBeWisp(msg *Message) bool {
 // - Queues (queue:name) - stores single message for worker claiming
 // - Announces (announce:name) - bulletin board, no claiming, retention-limited
 func (r *Router) Send(msg *Message) error {
+	// Scrub secrets before the message fans out to any mailbox. Done in
+	// place so the caller sees exactly what was stored.
+	r.redactMessage(msg)
+
+	// Stamp the sender's trace so the recipient's work can join it.
+	if msg.Traceparent == "" {
+		msg.Traceparent = telemetry.Traceparent(context.Background())
+	}
+
 	// Check for mailing list address
 	if isListAddress(msg.To) {
 		return r.sendToList(msg)
@@ -796,6 +808,18 @@ func (r *Router) Send(msg *Message) error {
 	return r.sendToSingle(msg)
 }
 
+// redactMessage replaces secrets in msg's subject and body.
+func (r *Router) redactMessage(msg *Message) {
+	red := redact.ForTown(r.townRoot)
+	counts := redact.Counts{}
+	var c redact.Counts
+	msg.Subject, c = red.String(msg.Subject)
+	counts.Add(c)
+	msg.Body, c = red.String(msg.Body)
+	counts.Add(c)
+	_ = redact.Record(r.townRoot, redact.SinkMail, counts)
+}
+
 // sendToGroup resolves a @group address and sends individual messages to each member.
 func (r *Router) sendToGroup(msg *Message) error {
 	group := parseGroupAddress(msg.To)
@@ -905,6 +929,9 @@ func (r *Router) sendToSingle(msg *Message) error {
 	if msg.ThreadID != "" {
 		labels = append(labels, "thread:"+msg.ThreadID)
 	}
+	if msg.Traceparent != "" {
+		labels = append(labels, "traceparent:"+msg.Traceparent)
+	}
 	if msg.ReplyTo != "" {
 		labels = append(labels, "reply-to:"+msg.ReplyTo)
 	}
@@ -939,6 +966,9 @@ func (r *Router) sendToSingle(msg *Message) error {
 		args = append(args, "--ephemeral")
 	}
 
+	// JSON output carries the bead ID, which the search index needs.
+	args = append(args, "--json")
+
 	// End flag parsing with --, then add subject as positional argument.
 	// This prevents subjects like "--help" or "--json" from being parsed as flags.
 	args = append(args, "--", msg.Subject)
@@ -949,10 +979,11 @@ func (r *Router) sendToSingle(msg *Message) error {
 	}
 	ctx, cancel := bdWriteCtx()
 	defer cancel()
-	_, err := runBdCommand(ctx, args, filepath.Dir(beadsDir), beadsDir)
+	stdout, err := runBdCommand(ctx, args, filepath.Dir(beadsDir), beadsDir)
 	if err != nil {
 		return fmt.Errorf("sending message: %w", err)
 	}
+	r.indexSent(beadsDir, msg, stdout)
 
 	// Notify recipient if they have an active session (best-effort notification).
 	// Skip when the caller explicitly suppressed notification (--no-notify)
@@ -972,6 +1003,26 @@ func (r *Router) sendToSingle(msg *Message) error {
 	return nil
 }
 
+// indexSent adds a just-created message to the search index. bd create's
+// JSON output supplies the bead ID; if it can't be parsed the message is
+// left for the next full rebuild. Index errors never fail a send.
+func (r *Router) indexSent(beadsDir string, msg *Message, createOutput []byte) {
+	var created struct {
+		ID        string    `json:"id"`
+		CreatedAt time.Time `json:"created_at"`
+	}
+	if err := json.Unmarshal(createOutput, &created); err != nil || created.ID == "" {
+		return
+	}
+	indexed := *msg
+	indexed.ID = created.ID
+	indexed.Timestamp = created.CreatedAt
+	if indexed.Timestamp.IsZero() {
+		indexed.Timestamp = timeNow()
+	}
+	_ = NewIndex(beadsDir).Add(&indexed)
+}
+
 // sendToList expands a mailing list and sends individual copies to each recipient.
 // Each recipient gets their own message copy with the same content.
 // Collects all delivery errors and reports partial failures.
@@ -1034,6 +1085,9 @@ func (r *Router) sendToQueue(msg *Message) error {
 	if msg.ThreadID != "" {
 		labels = append(labels, "thread:"+msg.ThreadID)
 	}
+	if msg.Traceparent != "" {
+		labels = append(labels, "traceparent:"+msg.Traceparent)
+	}
 	if msg.ReplyTo != "" {
 		labels = append(labels, "reply-to:"+msg.ReplyTo)
 	}
@@ -1118,6 +1172,9 @@ func (r *Router) sendToAnnounce(msg *Message) error {
 	if msg.ThreadID != "" {
 		labels = append(labels, "thread:"+msg.ThreadID)
 	}
+	if msg.Traceparent != "" {
+		labels = append(labels, "traceparent:"+msg.Traceparent)
+	}
 	if msg.ReplyTo != "" {
 		labels = append(labels, "reply-to:"+msg.ReplyTo)
 	}
@@ -1204,6 +1261,9 @@ func (r *Router) sendToChannel(msg *Message) error {
 	if msg.ThreadID != "" {
 		labels = append(labels, "thread:"+msg.ThreadID)
 	}
+	if msg.Traceparent != "" {
+		labels = append(labels, "traceparent:"+msg.Traceparent)
+	}
 	if msg.ReplyTo != "" {
 		labels = append(labels, "reply-to:"+msg.ReplyTo)
 	}
@@ -0,0 +1,274 @@
+package mail
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/gofrs/flock"
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// scheduleFileName holds pending scheduled deliveries, in the town's .runtime dir.
+const scheduleFileName = "mail-scheduled.json"
+
+// Failed deliveries are retried with exponential backoff from
+// scheduleRetryBase up to scheduleRetryMax. A one-shot delivery that fails
+// maxScheduleFailures times in a row is marked failed and no longer tried.
+const (
+	scheduleRetryBase   = time.Minute
+	scheduleRetryMax    = time.Hour
+	maxScheduleFailures = 8
+)
+
+// ErrScheduledNotFound is returned when a scheduled delivery ID is unknown.
+var ErrScheduledNotFound = errors.New("scheduled message not found")
+
+// ScheduledMessage is a message waiting to be sent at a later time.
+// One-shot deliveries are removed once sent; recurring ones (Cron set)
+// advance NextAt after each send.
+type ScheduledMessage struct {
+	ID string `json:"id"`
+
+	// Message is the template to send. To is the original address as
+	// given to gt mail send (it is resolved at delivery time, so lists and
+	// groups pick up membership changes).
+	Message *Message `json:"message"`
+
+	// NextAt is when the message is next due.
+	NextAt time.Time `json:"next_at"`
+
+	// Cron is the recurrence schedule; empty for one-shot deliveries.
+	Cron string `json:"cron,omitempty"`
+
+	CreatedAt  time.Time `json:"created_at"`
+	CreatedBy  string    `json:"created_by"`
+	LastSentAt time.Time `json:"last_sent_at,omitempty"`
+	SendCount  int       `json:"send_count,omitempty"`
+
+	// LastError and Failures record the most recent failed attempts.
+	// A failed delivery is retried after a backoff; FailedAt is set when a
+	// one-shot delivery gives up, and it then stays listed until cancelled.
+	LastError string    `json:"last_error,omitempty"`
+	Failures  int       `json:"failures,omitempty"`
+	FailedAt  time.Time `json:"failed_at,omitempty"`
+}
+
+// Recurring reports whether the delivery repeats on a cron schedule.
+func (s *ScheduledMessage) Recurring() bool {
+	return s.Cron != ""
+}
+
+// Failed reports whether delivery was given up on.
+func (s *ScheduledMessage) Failed() bool {
+	return !s.FailedAt.IsZero()
+}
+
+// retryDelay is the backoff before the next attempt after failures
+// consecutive failed sends.
+func retryDelay(failures int) time.Duration {
+	delay := scheduleRetryBase
+	for i := 1; i < failures && delay < scheduleRetryMax; i++ {
+		delay *= 2
+	}
+	return min(delay, scheduleRetryMax)
+}
+
+// ScheduleStore persists scheduled deliveries for a town. The daemon
+// dispatches due entries; gt mail send --at/--in/--cron adds them.
+type ScheduleStore struct {
+	path string
+}
+
+// NewScheduleStore returns the schedule store for townRoot.
+func NewScheduleStore(townRoot string) *ScheduleStore {
+	return &ScheduleStore{path: filepath.Join(townRoot, ".runtime", scheduleFileName)}
+}
+
+func (s *ScheduleStore) lock() (*flock.Flock, error) {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return nil, fmt.Errorf("creating schedule dir: %w", err)
+	}
+	fl := flock.New(s.path + ".lock")
+	if err := fl.Lock(); err != nil {
+		return nil, fmt.Errorf("acquiring schedule lock: %w", err)
+	}
+	return fl, nil
+}
+
+func (s *ScheduleStore) load() ([]*ScheduledMessage, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading scheduled mail: %w", err)
+	}
+	var entries []*ScheduledMessage
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("parsing scheduled mail: %w", err)
+	}
+	return entries, nil
+}
+
+// update runs fn on the entries under the lock and saves the result.
+func (s *ScheduleStore) update(fn func(entries []*ScheduledMessage) ([]*ScheduledMessage, error)) error {
+	fl, err := s.lock()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = fl.Unlock() }()
+
+	entries, err := s.load()
+	if err != nil {
+		return err
+	}
+	entries, err = fn(entries)
+	if err != nil {
+		return err
+	}
+	if entries == nil {
+		entries = []*ScheduledMessage{}
+	}
+	return util.AtomicWriteJSON(s.path, entries)
+}
+
+// Add stores a new scheduled delivery, assigning its ID and CreatedAt.
+// For recurring deliveries with no NextAt, the first occurrence is
+// computed from the cron schedule.
+func (s *ScheduleStore) Add(entry *ScheduledMessage) error {
+	if entry.Message == nil {
+		return fmt.Errorf("scheduled delivery has no message")
+	}
+	if entry.Recurring() {
+		sched, err := ParseCron(entry.Cron)
+		if err != nil {
+			return err
+		}
+		if entry.NextAt.IsZero() {
+			entry.NextAt = sched.Next(timeNow())
+		}
+	}
+	if entry.NextAt.IsZero() {
+		return fmt.Errorf("scheduled delivery has no delivery time")
+	}
+	if entry.ID == "" {
+		b := make([]byte, 4)
+		_, _ = rand.Read(b)
+		entry.ID = "sched-" + hex.EncodeToString(b)
+	}
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = timeNow().UTC()
+	}
+	return s.update(func(entries []*ScheduledMessage) ([]*ScheduledMessage, error) {
+		return append(entries, entry), nil
+	})
+}
+
+// List returns all scheduled deliveries, soonest first.
+func (s *ScheduleStore) List() ([]*ScheduledMessage, error) {
+	entries, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].NextAt.Before(entries[j].NextAt) })
+	return entries, nil
+}
+
+// Due returns deliveries whose NextAt is at or before now, soonest first.
+// Failed deliveries are never due.
+func (s *ScheduleStore) Due(now time.Time) ([]*ScheduledMessage, error) {
+	entries, err := s.List()
+	if err != nil {
+		return nil, err
+	}
+	var due []*ScheduledMessage
+	for _, e := range entries {
+		if !e.Failed() && !e.NextAt.After(now) {
+			due = append(due, e)
+		}
+	}
+	return due, nil
+}
+
+// Cancel removes a scheduled delivery.
+func (s *ScheduleStore) Cancel(id string) error {
+	return s.update(func(entries []*ScheduledMessage) ([]*ScheduledMessage, error) {
+		for i, e := range entries {
+			if e.ID == id {
+				return append(entries[:i], entries[i+1:]...), nil
+			}
+		}
+		return nil, ErrScheduledNotFound
+	})
+}
+
+// Dispatch sends every delivery due at now using send. Sent one-shot
+// deliveries are removed; recurring ones advance to their next occurrence
+// after now, so a daemon that was down fires a missed recurrence once
+// rather than once per missed slot. Failed sends record the error and are
+// retried after a backoff (a recurring one no later than its next
+// occurrence); a one-shot that keeps failing is marked failed. It returns
+// the number of messages sent and any send errors.
+//
+// The store lock is held for the whole dispatch so concurrent dispatchers
+// cannot double-send.
+func (s *ScheduleStore) Dispatch(now time.Time, send func(*ScheduledMessage) error) (int, []error) {
+	sent := 0
+	var errs []error
+	err := s.update(func(entries []*ScheduledMessage) ([]*ScheduledMessage, error) {
+		kept := entries[:0]
+		for _, e := range entries {
+			if e.Failed() || e.NextAt.After(now) {
+				kept = append(kept, e)
+				continue
+			}
+			if err := send(e); err != nil {
+				e.LastError = err.Error()
+				e.Failures++
+				errs = append(errs, fmt.Errorf("%s: %w", e.ID, err))
+				e.NextAt = now.Add(retryDelay(e.Failures))
+				if e.Recurring() {
+					if sched, err := ParseCron(e.Cron); err == nil {
+						if next := sched.Next(now); !next.IsZero() && next.Before(e.NextAt) {
+							e.NextAt = next
+						}
+					}
+				} else if e.Failures >= maxScheduleFailures {
+					e.FailedAt = now.UTC()
+					errs = append(errs, fmt.Errorf("%s: giving up after %d failed attempts", e.ID, e.Failures))
+				}
+				kept = append(kept, e)
+				continue
+			}
+			sent++
+			e.LastSentAt = now.UTC()
+			e.SendCount++
+			e.LastError = ""
+			e.Failures = 0
+			if !e.Recurring() {
+				continue
+			}
+			sched, err := ParseCron(e.Cron)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", e.ID, err))
+				continue
+			}
+			if e.NextAt = sched.Next(now); e.NextAt.IsZero() {
+				continue
+			}
+			kept = append(kept, e)
+		}
+		return kept, nil
+	})
+	if err != nil {
+		errs = append(errs, err)
+	}
+	return sent, errs
+}
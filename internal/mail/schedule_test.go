@@ -0,0 +1,162 @@
+package mail
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseCronNext(t *testing.T) {
+	loc := time.UTC
+	tests := []struct {
+		expr string
+		from time.Time
+		want time.Time
+	}{
+		{"0 9 * * *", time.Date(2026, 10, 18, 8, 59, 30, 0, loc), time.Date(2026, 10, 18, 9, 0, 0, 0, loc)},
+		{"0 9 * * *", time.Date(2026, 10, 18, 9, 0, 0, 0, loc), time.Date(2026, 10, 19, 9, 0, 0, 0, loc)},
+		{"*/15 * * * *", time.Date(2026, 10, 18, 10, 7, 0, 0, loc), time.Date(2026, 10, 18, 10, 15, 0, 0, loc)},
+		// 2026-10-18 is a Sunday; next weekday 09:00 is Monday.
+		{"0 9 * * 1-5", time.Date(2026, 10, 18, 12, 0, 0, 0, loc), time.Date(2026, 10, 19, 9, 0, 0, 0, loc)},
+		{"0 0 1 * *", time.Date(2026, 12, 15, 0, 0, 0, 0, loc), time.Date(2027, 1, 1, 0, 0, 0, 0, loc)},
+		{"@weekly", time.Date(2026, 10, 19, 0, 0, 0, 0, loc), time.Date(2026, 10, 25, 0, 0, 0, 0, loc)},
+		// Both day fields restricted: either matches (the 20th, or a Sunday).
+		{"0 0 20 * 0", time.Date(2026, 10, 18, 1, 0, 0, 0, loc), time.Date(2026, 10, 20, 0, 0, 0, 0, loc)},
+		{"0 0 7 * 7", time.Date(2026, 10, 18, 1, 0, 0, 0, loc), time.Date(2026, 10, 25, 0, 0, 0, 0, loc)},
+		// A "*/N" day-of-month is a star: both fields must match (an odd-day Wednesday).
+		{"0 0 */2 * 3", time.Date(2026, 10, 18, 1, 0, 0, 0, loc), time.Date(2026, 10, 21, 0, 0, 0, 0, loc)},
+	}
+	for _, tt := range tests {
+		s, err := ParseCron(tt.expr)
+		if err != nil {
+			t.Fatalf("ParseCron(%q): %v", tt.expr, err)
+		}
+		if got := s.Next(tt.from); !got.Equal(tt.want) {
+			t.Errorf("ParseCron(%q).Next(%v) = %v, want %v", tt.expr, tt.from, got, tt.want)
+		}
+	}
+
+	for _, bad := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "*/0 * * * *", "5-1 * * * *", "x * * * *"} {
+		if _, err := ParseCron(bad); err == nil {
+			t.Errorf("ParseCron(%q) should fail", bad)
+		}
+	}
+
+	impossible, _ := ParseCron("0 0 31 2 *")
+	if got := impossible.Next(time.Date(2026, 1, 1, 0, 0, 0, 0, loc)); !got.IsZero() {
+		t.Errorf("Feb 31 should never fire, got %v", got)
+	}
+}
+
+func TestScheduleStoreDispatch(t *testing.T) {
+	now := time.Date(2026, 10, 18, 9, 0, 0, 0, time.Local)
+	oldNow := timeNow
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = oldNow }()
+
+	store := NewScheduleStore(t.TempDir())
+	oneShot := &ScheduledMessage{Message: &Message{From: "deacon/", To: "gastown/witness", Subject: "remind"}, NextAt: now.Add(time.Hour)}
+	recurring := &ScheduledMessage{Message: &Message{From: "mayor/", To: "list:oncall", Subject: "report"}, Cron: "0 * * * *"}
+	failing := &ScheduledMessage{Message: &Message{From: "mayor/", To: "nobody", Subject: "fails"}, NextAt: now.Add(30 * time.Minute)}
+	for _, e := range []*ScheduledMessage{oneShot, recurring, failing} {
+		if err := store.Add(e); err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+	}
+	if !recurring.NextAt.Equal(now.Add(time.Hour)) {
+		t.Fatalf("recurring first occurrence = %v, want %v", recurring.NextAt, now.Add(time.Hour))
+	}
+
+	// Nothing due yet.
+	sent, errs := store.Dispatch(now, func(*ScheduledMessage) error {
+		t.Fatal("nothing should be sent before it is due")
+		return nil
+	})
+	if sent != 0 || len(errs) != 0 {
+		t.Fatalf("early dispatch sent=%d errs=%v", sent, errs)
+	}
+
+	// Two hours later (daemon was down): each due entry fires once.
+	later := now.Add(2*time.Hour + 5*time.Minute)
+	var subjects []string
+	sent, errs = store.Dispatch(later, func(e *ScheduledMessage) error {
+		if e.Message.Subject == "fails" {
+			return errors.New("no such recipient")
+		}
+		subjects = append(subjects, e.Message.Subject)
+		return nil
+	})
+	if sent != 2 || len(errs) != 1 {
+		t.Fatalf("dispatch sent=%d errs=%v, want 2 sent and 1 error", sent, errs)
+	}
+	if len(subjects) != 2 || subjects[0] != "remind" || subjects[1] != "report" {
+		t.Errorf("sent subjects = %v, want [remind report]", subjects)
+	}
+
+	entries, err := store.List()
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("after dispatch %d entries remain, want 2 (failed + recurring)", len(entries))
+	}
+	for _, e := range entries {
+		switch e.ID {
+		case failing.ID:
+			if e.Failures != 1 || e.LastError == "" {
+				t.Errorf("failed entry = %+v, want failure recorded", e)
+			}
+		case recurring.ID:
+			if want := now.Add(3 * time.Hour); !e.NextAt.Equal(want) || e.SendCount != 1 {
+				t.Errorf("recurring entry next=%v count=%d, want next=%v count=1", e.NextAt, e.SendCount, want)
+			}
+		default:
+			t.Errorf("unexpected entry %s", e.ID)
+		}
+	}
+
+	if err := store.Cancel(failing.ID); err != nil {
+		t.Fatalf("Cancel: %v", err)
+	}
+	if err := store.Cancel(failing.ID); !errors.Is(err, ErrScheduledNotFound) {
+		t.Errorf("second Cancel = %v, want ErrScheduledNotFound", err)
+	}
+}
+
+func TestScheduleStoreDispatchBacksOffAndGivesUp(t *testing.T) {
+	now := time.Date(2026, 10, 18, 9, 0, 0, 0, time.Local)
+	store := NewScheduleStore(t.TempDir())
+	entry := &ScheduledMessage{Message: &Message{From: "mayor/", To: "nobody", Subject: "fails"}, NextAt: now}
+	if err := store.Add(entry); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	fail := func(*ScheduledMessage) error { return errors.New("no such recipient") }
+
+	// A failed send is not retried until its backoff has passed.
+	store.Dispatch(now, fail)
+	if due, _ := store.Due(now.Add(30 * time.Second)); len(due) != 0 {
+		t.Fatalf("retried before backoff: %d due", len(due))
+	}
+
+	var last *ScheduledMessage
+	for attempt := 1; attempt < maxScheduleFailures; attempt++ {
+		entries, _ := store.List()
+		last = entries[0]
+		if want := retryDelay(attempt); last.NextAt.Sub(now) != want {
+			t.Fatalf("after %d failures next attempt in %v, want %v", attempt, last.NextAt.Sub(now), want)
+		}
+		now = last.NextAt
+		store.Dispatch(now, fail)
+	}
+
+	entries, _ := store.List()
+	if len(entries) != 1 || !entries[0].Failed() || entries[0].Failures != maxScheduleFailures {
+		t.Fatalf("after %d failures entry = %+v, want it marked failed", maxScheduleFailures, entries[0])
+	}
+	if due, _ := store.Due(now.Add(24 * time.Hour)); len(due) != 0 {
+		t.Error("a failed delivery should never be due again")
+	}
+	if retryDelay(100) != scheduleRetryMax {
+		t.Errorf("retryDelay(100) = %v, want cap %v", retryDelay(100), scheduleRetryMax)
+	}
+}
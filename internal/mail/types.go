@@ -96,6 +96,10 @@ type Message struct {
 	// ReplyTo is the ID of the message this is replying to.
 	ReplyTo string `json:"reply_to,omitempty"`
 
+	// Traceparent is the W3C trace context of the work that sent this
+	// message, so the recipient's handling can join the same trace.
+	Traceparent string `json:"traceparent,omitempty"`
+
 	// Pinned marks the message as pinned (won't be auto-archived).
 	Pinned bool `json:"pinned,omitempty"`
 
@@ -299,20 +303,21 @@ type BeadsMessage struct {
 	Priority    int       `json:"priority"`    // 0=urgent, 1=high, 2=normal, 3=low
 	Status      string    `json:"status"`      // open=unread, closed=read
 	CreatedAt   time.Time `json:"created_at"`
-	Labels      []string  `json:"labels"` // Metadata labels (from:X, thread:X, reply-to:X, msg-type:X, cc:X, queue:X, channel:X, claimed-by:X, claimed-at:X)
+	Labels      []string  `json:"labels"` // Metadata labels (from:X, thread:X, reply-to:X, traceparent:X, msg-type:X, cc:X, queue:X, channel:X, claimed-by:X, claimed-at:X)
 	Pinned      bool      `json:"pinned,omitempty"`
 	Wisp        bool      `json:"wisp,omitempty"` // Ephemeral message (filtered from JSONL export)
 
 	// Cached parsed values (populated by ParseLabels)
-	sender    string
-	threadID  string
-	replyTo   string
-	msgType   string
-	cc        []string   // CC recipients
-	queue     string     // Queue name (for queue messages)
-	channel   string     // Channel name (for broadcast messages)
-	claimedBy string     // Who claimed the queue message
-	claimedAt *time.Time // When the queue message was claimed
+	sender      string
+	threadID    string
+	replyTo     string
+	traceparent string
+	msgType     string
+	cc          []string   // CC recipients
+	queue       string     // Queue name (for queue messages)
+	channel     string     // Channel name (for broadcast messages)
+	claimedBy   string     // Who claimed the queue message
+	claimedAt   *time.Time // When the queue message was claimed
 	// Two-phase delivery metadata
 	deliveryState   string
 	deliveryAckedBy string
@@ -325,6 +330,7 @@ func (bm *BeadsMessage) ParseLabels() {
 	bm.sender = ""
 	bm.threadID = ""
 	bm.replyTo = ""
+	bm.traceparent = ""
 	bm.msgType = ""
 	bm.cc = nil
 	bm.queue = ""
@@ -342,6 +348,8 @@ func (bm *BeadsMessage) ParseLabels() {
 			bm.threadID = strings.TrimPrefix(label, "thread:")
 		} else if strings.HasPrefix(label, "reply-to:") {
 			bm.replyTo = strings.TrimPrefix(label, "reply-to:")
+		} else if strings.HasPrefix(label, "traceparent:") {
+			bm.traceparent = strings.TrimPrefix(label, "traceparent:")
 		} else if strings.HasPrefix(label, "msg-type:") {
 			bm.msgType = strings.TrimPrefix(label, "msg-type:")
 		} else if strings.HasPrefix(label, "cc:") {
@@ -421,6 +429,7 @@ func (bm *BeadsMessage) ToMessage() *Message {
 		Type:            msgType,
 		ThreadID:        bm.threadID,
 		ReplyTo:         bm.replyTo,
+		Traceparent:     bm.traceparent,
 		Wisp:            bm.Wisp,
 		CC:              ccAddrs,
 		Queue:           bm.queue,
@@ -9,6 +9,7 @@ import (
 
 	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/transcript"
 )
 
 // Common errors
@@ -115,6 +116,7 @@ func (m *Manager) Stop() error {
 	if err := t.KillSessionWithProcesses(sessionID); err != nil {
 		return fmt.Errorf("killing session: %w", err)
 	}
+	transcript.EndSession(m.townRoot, "mayor", "")
 
 	return nil
 }
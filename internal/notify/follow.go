@@ -0,0 +1,44 @@
+package notify
+
+import (
+	"encoding/json"
+
+	"github.com/steveyegge/gastown/internal/events"
+)
+
+// Follower reads events appended to an events file since the last Poll.
+// It follows the active segment across rotation (see events.Tailer).
+type Follower struct {
+	tail *events.Tailer
+}
+
+// NewFollower starts following path from its current end, so only events
+// written afterwards are reported.
+func NewFollower(path string) *Follower {
+	return &Follower{tail: events.NewTailer(path)}
+}
+
+// Poll returns complete events appended since the previous call. If the
+// file was rotated, truncated or rewritten, reading continues in the new
+// file without replaying events already returned.
+func (f *Follower) Poll() ([]events.Event, error) {
+	var out []events.Event
+	for {
+		line, err := f.tail.Next()
+		if err != nil {
+			return out, err
+		}
+		if line == nil {
+			return out, nil
+		}
+		var e events.Event
+		if json.Unmarshal(line, &e) == nil {
+			out = append(out, e)
+		}
+	}
+}
+
+// Close releases the followed file.
+func (f *Follower) Close() error {
+	return f.tail.Close()
+}
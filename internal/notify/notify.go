@@ -0,0 +1,294 @@
+// Package notify delivers town events to the human overseer: a terminal
+// bell with an OSC 9 desktop notification, notify-send, or a user command.
+//
+// Agents talk to each other through mail and nudges; this package is for
+// the person watching the town, so they can stop polling gt status. It
+// turns selected events from .events.jsonl (convoy landed, escalation
+// raised, merge failed) into Notifications and fans them out to sinks,
+// holding back everything but critical escalations while DND is on.
+package notify
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/events"
+)
+
+// Event kinds users can subscribe to.
+const (
+	KindConvoyLanded = "convoy_landed"
+	KindEscalation   = "escalation"
+	KindMergeFailed  = "merge_failed"
+	KindMassDeath    = "mass_death"
+)
+
+// Kinds lists every subscribable kind.
+var Kinds = []string{KindConvoyLanded, KindEscalation, KindMergeFailed, KindMassDeath}
+
+// DefaultKinds is the subscription used when none is configured.
+var DefaultKinds = []string{KindConvoyLanded, KindEscalation, KindMergeFailed}
+
+// Sink names.
+const (
+	SinkTerminal = "terminal"
+	SinkDesktop  = "desktop"
+	SinkCommand  = "command"
+)
+
+// Notification is one message for the overseer.
+type Notification struct {
+	Kind     string
+	Title    string
+	Body     string
+	Critical bool // delivered even in DND
+	Time     time.Time
+}
+
+// FromEvent converts an event to a notification. Returns false for events
+// that are not notifiable.
+func FromEvent(e events.Event) (Notification, bool) {
+	ts, _ := time.Parse(time.RFC3339, e.Timestamp)
+	n := Notification{Time: ts}
+	str := func(key string) string {
+		s, _ := e.Payload[key].(string)
+		return s
+	}
+
+	switch e.Type {
+	case events.TypeConvoyLanded:
+		n.Kind = KindConvoyLanded
+		n.Title = "Convoy landed"
+		n.Body = strings.TrimSpace(str("convoy_id") + " " + str("title"))
+	case events.TypeEscalationSent:
+		n.Kind = KindEscalation
+		severity := str("severity")
+		if severity == "" {
+			severity = str("new_severity")
+		}
+		n.Critical = severity == config.SeverityCritical
+		n.Title = "Escalation"
+		if severity != "" {
+			n.Title = fmt.Sprintf("Escalation (%s)", severity)
+		}
+		if e.Payload["reescalated"] == true {
+			n.Body = fmt.Sprintf("%s re-escalated, still unacknowledged", str("escalation_id"))
+		} else {
+			n.Body = fmt.Sprintf("%s: %s", e.Actor, str("reason"))
+		}
+	case events.TypeMergeFailed:
+		n.Kind = KindMergeFailed
+		n.Title = "Merge failed"
+		n.Body = strings.TrimSpace(fmt.Sprintf("%s %s", str("branch"), str("reason")))
+		if rig := str("rig"); rig != "" {
+			n.Title = fmt.Sprintf("Merge failed in %s", rig)
+		}
+	case events.TypeMassDeath:
+		n.Kind = KindMassDeath
+		n.Critical = true
+		n.Title = "Mass session death"
+		n.Body = str("possible_cause")
+		if count, ok := e.Payload["count"].(float64); ok {
+			n.Body = strings.TrimSpace(fmt.Sprintf("%d sessions died. %s", int(count), n.Body))
+		}
+	default:
+		return Notification{}, false
+	}
+	if n.Body == "" {
+		n.Body = e.Type
+	}
+	return n, true
+}
+
+// Sink delivers notifications.
+type Sink interface {
+	Name() string
+	Send(n Notification) error
+}
+
+// Terminal rings the bell and emits an OSC 9 escape, which most terminal
+// emulators (iTerm2, kitty, WezTerm, Windows Terminal) show as a desktop
+// notification. Inside tmux the escape is wrapped for passthrough.
+type Terminal struct {
+	W    io.Writer
+	Tmux bool
+}
+
+// Name implements Sink.
+func (t *Terminal) Name() string { return SinkTerminal }
+
+// Send implements Sink.
+func (t *Terminal) Send(n Notification) error {
+	msg := sanitize(n.Title + ": " + n.Body)
+	osc := "\033]9;" + msg + "\a"
+	if t.Tmux {
+		osc = "\033Ptmux;" + strings.ReplaceAll(osc, "\033", "\033\033") + "\033\\"
+	}
+	_, err := io.WriteString(t.W, "\a"+osc)
+	return err
+}
+
+// sanitize strips control characters that would end the escape early.
+func sanitize(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f {
+			return ' '
+		}
+		return r
+	}, s)
+}
+
+// Desktop sends notifications with notify-send.
+type Desktop struct {
+	Path string
+}
+
+// NewDesktop returns a Desktop sink, or false when notify-send is not installed.
+func NewDesktop() (*Desktop, bool) {
+	path, err := exec.LookPath("notify-send")
+	if err != nil {
+		return nil, false
+	}
+	return &Desktop{Path: path}, true
+}
+
+// Name implements Sink.
+func (d *Desktop) Name() string { return SinkDesktop }
+
+// Send implements Sink.
+func (d *Desktop) Send(n Notification) error {
+	urgency := "normal"
+	if n.Critical {
+		urgency = "critical"
+	}
+	return exec.Command(d.Path, "--app-name=Gas Town", "--urgency="+urgency, n.Title, n.Body).Run() //nolint:gosec // G204: fixed binary, args are not shell-interpreted
+}
+
+// Command runs a user-configured shell command per notification.
+type Command struct {
+	Cmd string
+}
+
+// Name implements Sink.
+func (c *Command) Name() string { return SinkCommand }
+
+// Send implements Sink.
+func (c *Command) Send(n Notification) error {
+	cmd := exec.Command("sh", "-c", c.Cmd) //nolint:gosec // G204: command comes from the user's own town settings
+	cmd.Env = append(os.Environ(),
+		"GT_NOTIFY_KIND="+n.Kind,
+		"GT_NOTIFY_TITLE="+n.Title,
+		"GT_NOTIFY_BODY="+n.Body,
+		fmt.Sprintf("GT_NOTIFY_CRITICAL=%t", n.Critical),
+	)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("notify command: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// Notifier filters notifications by subscription and DND, then delivers
+// them to every sink.
+type Notifier struct {
+	Kinds map[string]bool
+	Sinks []Sink
+	// Muted reports whether DND is on. Nil means never muted.
+	Muted func() bool
+}
+
+// New builds a Notifier from settings. Unknown event kinds and sinks that
+// are unavailable on this machine are returned as warnings.
+func New(cfg *config.NotifierConfig, w io.Writer) (*Notifier, []string) {
+	if cfg == nil {
+		cfg = &config.NotifierConfig{}
+	}
+	var warnings []string
+	n := &Notifier{Kinds: make(map[string]bool)}
+
+	kinds := cfg.Events
+	if len(kinds) == 0 {
+		kinds = DefaultKinds
+	}
+	for _, k := range kinds {
+		if !isKind(k) {
+			warnings = append(warnings, fmt.Sprintf("unknown notifier event %q (valid: %s)", k, strings.Join(Kinds, ", ")))
+			continue
+		}
+		n.Kinds[k] = true
+	}
+
+	sinks := cfg.Sinks
+	if len(sinks) == 0 {
+		sinks = []string{SinkTerminal, SinkDesktop}
+	}
+	if cfg.Command != "" && !containsString(sinks, SinkCommand) {
+		sinks = append(sinks, SinkCommand)
+	}
+	for _, s := range sinks {
+		switch s {
+		case SinkTerminal:
+			n.Sinks = append(n.Sinks, &Terminal{W: w, Tmux: os.Getenv("TMUX") != ""})
+		case SinkDesktop:
+			if d, ok := NewDesktop(); ok {
+				n.Sinks = append(n.Sinks, d)
+			} else if len(cfg.Sinks) > 0 {
+				warnings = append(warnings, "desktop sink: notify-send not found")
+			}
+		case SinkCommand:
+			if cfg.Command == "" {
+				warnings = append(warnings, "command sink enabled but notifier.command is empty")
+				continue
+			}
+			n.Sinks = append(n.Sinks, &Command{Cmd: cfg.Command})
+		default:
+			warnings = append(warnings, fmt.Sprintf("unknown notifier sink %q", s))
+		}
+	}
+	return n, warnings
+}
+
+func isKind(k string) bool {
+	return containsString(Kinds, k)
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// Handle delivers the event if it is subscribed and not held back by DND.
+// Returns the notification and whether it was delivered; sink errors are
+// joined into err but do not stop other sinks.
+func (n *Notifier) Handle(e events.Event) (Notification, bool, error) {
+	note, ok := FromEvent(e)
+	if !ok || !n.Kinds[note.Kind] {
+		return note, false, nil
+	}
+	if !note.Critical && n.Muted != nil && n.Muted() {
+		return note, false, nil
+	}
+	return note, true, n.Send(note)
+}
+
+// Send delivers a notification to every sink unconditionally.
+func (n *Notifier) Send(note Notification) error {
+	var errs []string
+	for _, s := range n.Sinks {
+		if err := s.Send(note); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", s.Name(), err))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,237 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/events"
+)
+
+func TestFromEvent(t *testing.T) {
+	tests := []struct {
+		name     string
+		event    events.Event
+		kind     string
+		critical bool
+		body     string
+	}{
+		{
+			name:  "convoy landed",
+			event: events.Event{Type: events.TypeConvoyLanded, Payload: events.ConvoyPayload("hq-cv-abc", "Auth rewrite")},
+			kind:  KindConvoyLanded,
+			body:  "hq-cv-abc Auth rewrite",
+		},
+		{
+			name: "high escalation",
+			event: events.Event{Type: events.TypeEscalationSent, Actor: "gastown/witness",
+				Payload: map[string]interface{}{"severity": config.SeverityHigh, "reason": "stuck polecat"}},
+			kind: KindEscalation,
+			body: "gastown/witness: stuck polecat",
+		},
+		{
+			name: "critical re-escalation",
+			event: events.Event{Type: events.TypeEscalationSent,
+				Payload: map[string]interface{}{"new_severity": config.SeverityCritical, "escalation_id": "hq-esc1", "reescalated": true}},
+			kind:     KindEscalation,
+			critical: true,
+			body:     "hq-esc1 re-escalated, still unacknowledged",
+		},
+		{
+			name: "merge failed",
+			event: events.Event{Type: events.TypeMergeFailed,
+				Payload: map[string]interface{}{"rig": "gastown", "branch": "polecat/nux", "reason": "conflict"}},
+			kind: KindMergeFailed,
+			body: "polecat/nux conflict",
+		},
+		{
+			name:     "mass death",
+			event:    events.Event{Type: events.TypeMassDeath, Payload: map[string]interface{}{"count": float64(4)}},
+			kind:     KindMassDeath,
+			critical: true,
+			body:     "4 sessions died.",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n, ok := FromEvent(tt.event)
+			if !ok {
+				t.Fatal("event not notifiable")
+			}
+			if n.Kind != tt.kind || n.Critical != tt.critical || n.Body != tt.body {
+				t.Errorf("got kind=%q critical=%v body=%q", n.Kind, n.Critical, n.Body)
+			}
+		})
+	}
+
+	if _, ok := FromEvent(events.Event{Type: events.TypeSling}); ok {
+		t.Error("sling should not be notifiable")
+	}
+}
+
+type recordSink struct{ got []Notification }
+
+func (r *recordSink) Name() string { return "record" }
+
+func (r *recordSink) Send(n Notification) error {
+	r.got = append(r.got, n)
+	return nil
+}
+
+func TestHandleFiltersAndDND(t *testing.T) {
+	sink := &recordSink{}
+	muted := false
+	n := &Notifier{
+		Kinds: map[string]bool{KindConvoyLanded: true, KindEscalation: true},
+		Sinks: []Sink{sink},
+		Muted: func() bool { return muted },
+	}
+	landed := events.Event{Type: events.TypeConvoyLanded, Payload: events.ConvoyPayload("hq-cv-1", "x")}
+	critical := events.Event{Type: events.TypeEscalationSent,
+		Payload: map[string]interface{}{"severity": config.SeverityCritical, "reason": "down"}}
+	mergeFailed := events.Event{Type: events.TypeMergeFailed, Payload: map[string]interface{}{"branch": "b"}}
+
+	if _, delivered, _ := n.Handle(mergeFailed); delivered {
+		t.Error("unsubscribed kind was delivered")
+	}
+	if _, delivered, _ := n.Handle(landed); !delivered {
+		t.Error("subscribed kind was not delivered")
+	}
+
+	muted = true
+	if _, delivered, _ := n.Handle(landed); delivered {
+		t.Error("non-critical notification delivered during DND")
+	}
+	if _, delivered, _ := n.Handle(critical); !delivered {
+		t.Error("critical escalation held back by DND")
+	}
+	if len(sink.got) != 2 {
+		t.Errorf("sink received %d notifications, want 2", len(sink.got))
+	}
+}
+
+func TestTerminalSink(t *testing.T) {
+	note := Notification{Title: "Convoy landed", Body: "hq-cv-1\nsneaky\033]"}
+
+	var buf bytes.Buffer
+	if err := (&Terminal{W: &buf}).Send(note); err != nil {
+		t.Fatal(err)
+	}
+	want := "\a\033]9;Convoy landed: hq-cv-1 sneaky ]\a"
+	if buf.String() != want {
+		t.Errorf("terminal output = %q, want %q", buf.String(), want)
+	}
+
+	buf.Reset()
+	if err := (&Terminal{W: &buf, Tmux: true}).Send(note); err != nil {
+		t.Fatal(err)
+	}
+	if out := buf.String(); !strings.HasPrefix(out, "\a\033Ptmux;\033\033]9;") || !strings.HasSuffix(out, "\a\033\\") {
+		t.Errorf("tmux output not wrapped for passthrough: %q", out)
+	}
+}
+
+func TestCommandSink(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "out")
+	c := &Command{Cmd: `printf '%s|%s|%s|%s' "$GT_NOTIFY_KIND" "$GT_NOTIFY_TITLE" "$GT_NOTIFY_BODY" "$GT_NOTIFY_CRITICAL" > ` + out}
+	if err := c.Send(Notification{Kind: KindEscalation, Title: "Escalation", Body: "help", Critical: true}); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "escalation|Escalation|help|true" {
+		t.Errorf("command saw %q", data)
+	}
+
+	if err := (&Command{Cmd: "echo boom; exit 3"}).Send(Notification{}); err == nil || !strings.Contains(err.Error(), "boom") {
+		t.Errorf("failing command error = %v", err)
+	}
+}
+
+func TestNewWarnings(t *testing.T) {
+	n, warnings := New(&config.NotifierConfig{
+		Events: []string{KindEscalation, "coffee_ready"},
+		Sinks:  []string{SinkTerminal, "pager", SinkCommand},
+	}, &bytes.Buffer{})
+	if !n.Kinds[KindEscalation] || n.Kinds["coffee_ready"] {
+		t.Errorf("kinds = %v", n.Kinds)
+	}
+	joined := strings.Join(warnings, "\n")
+	for _, want := range []string{`"coffee_ready"`, `"pager"`, "notifier.command is empty"} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("warnings missing %s: %v", want, warnings)
+		}
+	}
+
+	n, warnings = New(nil, &bytes.Buffer{})
+	if len(warnings) != 0 {
+		t.Errorf("defaults produced warnings: %v", warnings)
+	}
+	for _, k := range DefaultKinds {
+		if !n.Kinds[k] {
+			t.Errorf("default kind %s not subscribed", k)
+		}
+	}
+	if len(n.Sinks) == 0 || n.Sinks[0].Name() != SinkTerminal {
+		t.Errorf("default sinks = %v", n.Sinks)
+	}
+
+	n, _ = New(&config.NotifierConfig{Sinks: []string{SinkTerminal}, Command: "true"}, &bytes.Buffer{})
+	if len(n.Sinks) != 2 || n.Sinks[1].Name() != SinkCommand {
+		t.Errorf("command should add the command sink: %v", n.Sinks)
+	}
+}
+
+func TestFollower(t *testing.T) {
+	path := filepath.Join(t.TempDir(), events.EventsFile)
+	line := func(typ string) string {
+		b, _ := json.Marshal(events.Event{Timestamp: time.Now().UTC().Format(time.RFC3339), Type: typ})
+		return string(b) + "\n"
+	}
+	appendTo := func(s string) {
+		f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+		if _, err := f.WriteString(s); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	appendTo(line("old"))
+	f := NewFollower(path)
+	if evs, _ := f.Poll(); len(evs) != 0 {
+		t.Fatalf("follower replayed existing events: %v", evs)
+	}
+
+	next := line("second")
+	appendTo(line("first") + next[:10])
+	evs, err := f.Poll()
+	if err != nil || len(evs) != 1 || evs[0].Type != "first" {
+		t.Fatalf("poll = %v, %v", evs, err)
+	}
+	appendTo(next[10:])
+	if evs, _ := f.Poll(); len(evs) != 1 || evs[0].Type != "second" {
+		t.Fatalf("partial line not completed: %v", evs)
+	}
+
+	if err := os.WriteFile(path, []byte(line("rotated")), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if evs, _ := f.Poll(); len(evs) != 1 || evs[0].Type != "rotated" {
+		t.Errorf("after truncation got %v", evs)
+	}
+
+	missing := NewFollower(filepath.Join(t.TempDir(), "none.jsonl"))
+	if evs, err := missing.Poll(); err != nil || evs != nil {
+		t.Errorf("missing file = %v, %v", evs, err)
+	}
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/steveyegge/gastown/internal/telemetry"
 	"github.com/steveyegge/gastown/internal/tmux"
 	"github.com/steveyegge/gastown/internal/workspace"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // Retry constants for Dolt operations (matching hook update pattern in sling.go).
@@ -604,7 +605,12 @@ func (m *Manager) Add(name string) (*Polecat, error) {
 // This allows setting hook_bead atomically at creation time, avoiding
 // cross-beads routing issues when slinging work to new polecats.
 func (m *Manager) AddWithOptions(name string, opts AddOptions) (_ *Polecat, retErr error) {
-	defer func() { telemetry.RecordPolecatSpawn(context.Background(), name, retErr) }()
+	ctx, span := telemetry.StartSpan(context.Background(), "polecat.spawn",
+		attribute.String("gt.rig", m.rig.Name), attribute.String("gt.polecat", name))
+	defer func() {
+		telemetry.RecordPolecatSpawn(ctx, name, retErr)
+		telemetry.EndSpan(span, retErr)
+	}()
 	// Acquire per-polecat file lock to prevent concurrent Add/Remove/Repair races
 	fl, err := m.lockPolecat(name)
 	if err != nil {
@@ -685,15 +691,9 @@ func (m *Manager) AddWithOptions(name string, opts AddOptions) (_ *Polecat, retE
 	}
 
 	// Determine the start point for the new worktree
-	var startPoint string
-	if opts.BaseBranch != "" {
-		startPoint = opts.BaseBranch
-	} else {
-		defaultBranch := "main"
-		if rigCfg, err := rig.LoadRigConfig(m.rig.Path); err == nil && rigCfg.DefaultBranch != "" {
-			defaultBranch = rigCfg.DefaultBranch
-		}
-		startPoint = fmt.Sprintf("origin/%s", defaultBranch)
+	startPoint := opts.BaseBranch
+	if startPoint == "" {
+		startPoint = m.defaultStartPoint()
 	}
 
 	// Validate that startPoint ref exists before attempting worktree creation
@@ -724,6 +724,49 @@ func (m *Manager) AddWithOptions(name string, opts AddOptions) (_ *Polecat, retE
 	// Only ~/gt/CLAUDE.md (town-root identity anchor) exists on disk.
 	// Full context is injected ephemerally via SessionStart hook (gt prime).
 
+	m.provisionWorktree(name, clonePath)
+
+	// NOTE: Slash commands (.claude/commands/) are provisioned at town level by gt install.
+	// All agents inherit them via Claude's directory traversal - no per-workspace copies needed.
+
+	// Create or reopen agent bead for ZFC compliance (self-report state).
+	// State starts as "spawning" - will be updated to "working" when Claude starts.
+	// HookBead is set atomically at creation time if provided (avoids cross-beads routing issues).
+	// Uses CreateOrReopenAgentBead to handle re-spawning with same name (GH #332).
+	// Retries with backoff — a polecat without an agent bead is untrackable (gt-94llt7).
+	agentID := m.agentBeadID(name)
+	if err = m.createAgentBeadWithRetry(agentID, &beads.AgentFields{
+		RoleType:   "polecat",
+		Rig:        m.rig.Name,
+		AgentState: "spawning",
+		HookBead:   opts.HookBead, // Set atomically at spawn time
+	}); err != nil {
+		// Hard fail — an untrackable polecat is worse than no polecat
+		cleanupOnError()
+		return nil, fmt.Errorf("agent bead required for polecat tracking: %w", err)
+	}
+
+	// Return polecat with working state (transient model: polecats are spawned with work)
+	// State is derived from beads, not stored in state.json
+	now := time.Now()
+	polecat := &Polecat{
+		Name:      name,
+		Rig:       m.rig.Name,
+		State:     StateWorking, // Transient model: polecat spawns with work
+		ClonePath: clonePath,
+		Branch:    branchName,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	return polecat, nil
+}
+
+// provisionWorktree performs the non-git setup of a new polecat worktree:
+// shared beads redirect, PRIME.md, overlay files, .gitignore patterns,
+// runtime settings and setup hooks. Every step is best-effort; failures are
+// reported as warnings.
+func (m *Manager) provisionWorktree(name, clonePath string) {
 	// Set up shared beads: polecat uses rig's .beads via redirect file.
 	// This eliminates git sync overhead - all polecats share one database.
 	if err := m.setupSharedBeads(clonePath); err != nil {
@@ -752,16 +795,7 @@ func (m *Manager) AddWithOptions(name string, opts AddOptions) (_ *Polecat, retE
 		style.PrintWarning("could not update .gitignore: %v", err)
 	}
 
-	// Install runtime settings in the shared polecats parent directory.
-	// Settings are passed to Claude Code via --settings flag using the fallback chain:
-	// polecats/<name>/.claude/settings.json → polecats/.claude/settings.json → rig/.claude/settings.json → defaults
-	townRoot := filepath.Dir(m.rig.Path)
-	runtimeConfig := config.ResolvePolecatRuntimeConfig(name, townRoot, m.rig.Path)
-	polecatSettingsDir := config.RoleSettingsDir("polecat", m.rig.Path)
-	if err := runtime.EnsureSettingsForRole(polecatSettingsDir, clonePath, "polecat", runtimeConfig); err != nil {
-		// Non-fatal - log warning but continue
-		style.PrintWarning("could not install runtime settings: %v", err)
-	}
+	m.installRuntimeSettings(name, clonePath)
 
 	// Run setup hooks from .runtime/setup-hooks/.
 	// These hooks can inject local git config, copy secrets, or perform other setup tasks.
@@ -769,41 +803,29 @@ func (m *Manager) AddWithOptions(name string, opts AddOptions) (_ *Polecat, retE
 		// Non-fatal - log warning but continue
 		style.PrintWarning("could not run setup hooks: %v", err)
 	}
+}
 
-	// NOTE: Slash commands (.claude/commands/) are provisioned at town level by gt install.
-	// All agents inherit them via Claude's directory traversal - no per-workspace copies needed.
-
-	// Create or reopen agent bead for ZFC compliance (self-report state).
-	// State starts as "spawning" - will be updated to "working" when Claude starts.
-	// HookBead is set atomically at creation time if provided (avoids cross-beads routing issues).
-	// Uses CreateOrReopenAgentBead to handle re-spawning with same name (GH #332).
-	// Retries with backoff — a polecat without an agent bead is untrackable (gt-94llt7).
-	agentID := m.agentBeadID(name)
-	if err = m.createAgentBeadWithRetry(agentID, &beads.AgentFields{
-		RoleType:   "polecat",
-		Rig:        m.rig.Name,
-		AgentState: "spawning",
-		HookBead:   opts.HookBead, // Set atomically at spawn time
-	}); err != nil {
-		// Hard fail — an untrackable polecat is worse than no polecat
-		cleanupOnError()
-		return nil, fmt.Errorf("agent bead required for polecat tracking: %w", err)
+// installRuntimeSettings installs runtime settings in the shared polecats parent directory.
+// Settings are passed to Claude Code via --settings flag using the fallback chain:
+// polecats/<name>/.claude/settings.json → polecats/.claude/settings.json → rig/.claude/settings.json → defaults
+func (m *Manager) installRuntimeSettings(name, clonePath string) {
+	townRoot := filepath.Dir(m.rig.Path)
+	runtimeConfig := config.ResolvePolecatRuntimeConfig(name, townRoot, m.rig.Path)
+	polecatSettingsDir := config.RoleSettingsDir("polecat", m.rig.Path)
+	if err := runtime.EnsureSettingsForRole(polecatSettingsDir, clonePath, "polecat", runtimeConfig); err != nil {
+		// Non-fatal - log warning but continue
+		style.PrintWarning("could not install runtime settings: %v", err)
 	}
+}
 
-	// Return polecat with working state (transient model: polecats are spawned with work)
-	// State is derived from beads, not stored in state.json
-	now := time.Now()
-	polecat := &Polecat{
-		Name:      name,
-		Rig:       m.rig.Name,
-		State:     StateWorking, // Transient model: polecat spawns with work
-		ClonePath: clonePath,
-		Branch:    branchName,
-		CreatedAt: now,
-		UpdatedAt: now,
+// defaultStartPoint returns the ref new polecat worktrees start from when
+// no base branch is given: origin/<default_branch>.
+func (m *Manager) defaultStartPoint() string {
+	defaultBranch := "main"
+	if rigCfg, err := rig.LoadRigConfig(m.rig.Path); err == nil && rigCfg.DefaultBranch != "" {
+		defaultBranch = rigCfg.DefaultBranch
 	}
-
-	return polecat, nil
+	return fmt.Sprintf("origin/%s", defaultBranch)
 }
 
 // Remove deletes a polecat worktree.
@@ -1159,15 +1181,9 @@ func (m *Manager) RepairWorktreeWithOptions(name string, force bool, opts AddOpt
 	}
 
 	// Determine the start point for the new worktree
-	var startPoint string
-	if opts.BaseBranch != "" {
-		startPoint = opts.BaseBranch
-	} else {
-		defaultBranch := "main"
-		if rigCfg, err := rig.LoadRigConfig(m.rig.Path); err == nil && rigCfg.DefaultBranch != "" {
-			defaultBranch = rigCfg.DefaultBranch
-		}
-		startPoint = fmt.Sprintf("origin/%s", defaultBranch)
+	startPoint := opts.BaseBranch
+	if startPoint == "" {
+		startPoint = m.defaultStartPoint()
 	}
 
 	// Validate that startPoint ref exists before attempting worktree creation
@@ -1319,6 +1335,9 @@ func (m *Manager) reconcilePoolInternal() {
 		}
 	}
 
+	// Warm spares hold their names until claimed or drained.
+	namesWithDirs = append(namesWithDirs, m.spareNames()...)
+
 	// Get names with tmux sessions
 	var namesWithSessions []string
 	if m.tmux != nil {
@@ -1574,6 +1574,7 @@ esac
 			if strings.Contains(line, clonePath) {
 				t.Errorf("stale worktree entry for %s still registered in git after rollback", clonePath)
 			}
+		}
 	}
 }
 
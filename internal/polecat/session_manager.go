@@ -18,9 +18,12 @@ import (
 	"github.com/steveyegge/gastown/internal/git"
 	"github.com/steveyegge/gastown/internal/rig"
 	"github.com/steveyegge/gastown/internal/runtime"
+	"github.com/steveyegge/gastown/internal/sandbox"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/telemetry"
 	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/transcript"
 )
 
 // debugSession logs non-fatal errors during session startup when GT_DEBUG_SESSION=1.
@@ -308,14 +311,29 @@ func (m *SessionManager) Start(polecat string, opts SessionStartOptions) error {
 	if polecatGitBranch != "" {
 		envVarsToInject["GT_BRANCH"] = polecatGitBranch
 	}
+	// Hand the slinger's trace to the session so gt prime and gt done join it.
+	if tp := telemetry.Traceparent(context.Background()); tp != "" {
+		envVarsToInject[telemetry.EnvTraceparent] = tp
+	}
 	command = config.PrependEnv(command, envVarsToInject)
 
+	// Wrap in the rig's namespace sandbox, if enabled. Never start unsandboxed.
+	command, err = sandbox.WrapCommand(command, m.rig.Path, workDir)
+	if err != nil {
+		return fmt.Errorf("sandboxing session: %w", err)
+	}
+
 	// Create session with command directly to avoid send-keys race condition.
 	// See: https://github.com/anthropics/gastown/issues/280
 	if err := m.tmux.NewSessionWithCommand(sessionID, workDir, command); err != nil {
 		return fmt.Errorf("creating session: %w", err)
 	}
 
+	// Apply cgroup resource limits before the agent forks much (non-fatal)
+	if err := session.ApplyResourceLimits(m.tmux, sessionID, "polecat", townRoot, m.rig.Path); err != nil {
+		style.PrintWarning("resource limits not applied for %s: %v", sessionID, err)
+	}
+
 	// Set environment (non-fatal: session works without these)
 	// Use centralized AgentEnv for consistency across all role startup paths
 	// Note: townRoot already defined above for ResolveRoleAgentConfig
@@ -470,11 +488,16 @@ func (m *SessionManager) Stop(polecat string, force bool) error {
 		session.WaitForSessionExit(m.tmux, sessionID, constants.GracefulShutdownTimeout)
 	}
 
+	// Archive the transcript while the runtime's files are still in place.
+	// gt done normally does this itself; this catches externally stopped sessions.
+	transcript.EndSession(filepath.Dir(m.rig.Path), fmt.Sprintf("%s/polecats/%s", m.rig.Name, polecat), "")
+
 	// Use KillSessionWithProcesses to ensure all descendant processes are killed.
 	// This prevents orphan bash processes from Claude's Bash tool surviving session termination.
 	if err := m.tmux.KillSessionWithProcesses(sessionID); err != nil {
 		return fmt.Errorf("killing session: %w", err)
 	}
+	session.RemoveResourceGroup(sessionID)
 
 	return nil
 }
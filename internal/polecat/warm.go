@@ -0,0 +1,543 @@
+package polecat
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/gofrs/flock"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/telemetry"
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// Warm spares live in polecats/.warm/<name>/<rigname>/. The directory is
+// dot-prefixed so List, the witness and orphan cleanup never see a spare
+// as a polecat. A spare holds its name from the name pool and has its
+// agent bead created up front in the "nuked" state that nuke leaves
+// reusable beads in, which bead audits skip, so nothing mistakes it for a
+// dead polecat. Claiming one is a rename, a branch checkout and an agent
+// state update.
+const (
+	warmDirName    = ".warm"
+	spareStateFile = "spare.json"
+
+	// defaultSpareSetupTimeout bounds setup_command in a spare.
+	defaultSpareSetupTimeout = 10 * time.Minute
+)
+
+var (
+	// ErrNoSpare is returned by ClaimSpare when no suitable spare is ready.
+	ErrNoSpare = errors.New("no warm spare available")
+
+	// ErrWarmPoolBusy is returned by FillWarmPool when another fill is running.
+	ErrWarmPoolBusy = errors.New("warm pool fill already in progress")
+)
+
+// Spare is a pre-warmed polecat worktree waiting to be claimed.
+type Spare struct {
+	Name        string    `json:"name"`
+	BaseRef     string    `json:"base_ref"` // e.g. "origin/main"
+	Commit      string    `json:"commit"`   // commit the worktree is checked out at
+	CreatedAt   time.Time `json:"created_at"`
+	RefreshedAt time.Time `json:"refreshed_at"`
+	SetupRan    bool      `json:"setup_ran,omitempty"`
+	SetupError  string    `json:"setup_error,omitempty"`
+
+	ClonePath string `json:"-"`
+}
+
+// WarmPoolResult summarizes a FillWarmPool run.
+type WarmPoolResult struct {
+	Created   []string `json:"created,omitempty"`
+	Refreshed []string `json:"refreshed,omitempty"`
+	Removed   []string `json:"removed,omitempty"`
+	Errors    []string `json:"errors,omitempty"`
+}
+
+func (m *Manager) warmDir() string {
+	return filepath.Join(m.rig.Path, "polecats", warmDirName)
+}
+
+func (m *Manager) spareDir(name string) string {
+	return filepath.Join(m.warmDir(), name)
+}
+
+func (m *Manager) spareClonePath(name string) string {
+	return filepath.Join(m.spareDir(name), m.rig.Name)
+}
+
+// WarmPoolConfig returns the rig's warm pool settings, or nil when the
+// pool is not configured.
+func (m *Manager) WarmPoolConfig() *config.WarmPoolConfig {
+	settings, err := config.LoadRigSettings(filepath.Join(m.rig.Path, "settings", "config.json"))
+	if err != nil || settings == nil {
+		return nil
+	}
+	return settings.WarmPool
+}
+
+// tryLockPolecat is lockPolecat without blocking. It reports false when
+// another process holds the lock, so callers can skip a busy spare.
+func (m *Manager) tryLockPolecat(name string) (*flock.Flock, bool) {
+	lockDir := filepath.Join(m.rig.Path, ".runtime", "locks")
+	if err := os.MkdirAll(lockDir, 0755); err != nil {
+		return nil, false
+	}
+	fl := flock.New(filepath.Join(lockDir, fmt.Sprintf("polecat-%s.lock", name)))
+	ok, err := fl.TryLock()
+	if err != nil || !ok {
+		return nil, false
+	}
+	return fl, true
+}
+
+// spareNames returns the names held by spare directories, complete or not.
+func (m *Manager) spareNames() []string {
+	entries, err := os.ReadDir(m.warmDir())
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for _, e := range entries {
+		if e.IsDir() {
+			names = append(names, e.Name())
+		}
+	}
+	return names
+}
+
+func (m *Manager) loadSpare(name string) (*Spare, error) {
+	data, err := os.ReadFile(filepath.Join(m.spareDir(name), spareStateFile))
+	if err != nil {
+		return nil, err
+	}
+	var s Spare
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("parsing spare state: %w", err)
+	}
+	s.Name = name
+	s.ClonePath = m.spareClonePath(name)
+	return &s, nil
+}
+
+func (m *Manager) saveSpare(s *Spare) error {
+	return util.AtomicWriteJSON(filepath.Join(m.spareDir(s.Name), spareStateFile), s)
+}
+
+// ListSpares returns the rig's ready spares, oldest first. Spares still
+// being built (no state file yet) are not included.
+func (m *Manager) ListSpares() ([]*Spare, error) {
+	var spares []*Spare
+	for _, name := range m.spareNames() {
+		s, err := m.loadSpare(name)
+		if err != nil {
+			continue
+		}
+		spares = append(spares, s)
+	}
+	sort.Slice(spares, func(i, j int) bool { return spares[i].CreatedAt.Before(spares[j].CreatedAt) })
+	return spares, nil
+}
+
+// runSpareSetup runs the rig's merge_queue.setup_command in a spare so
+// dependencies are installed before the spare is claimed. It reports
+// whether a command ran; output goes to setup.log beside the worktree.
+func (m *Manager) runSpareSetup(name string, cfg *config.WarmPoolConfig) (bool, error) {
+	if !cfg.IsRunSetupEnabled() {
+		return false, nil
+	}
+	settings, err := config.LoadRigSettings(filepath.Join(m.rig.Path, "settings", "config.json"))
+	if err != nil || settings.MergeQueue == nil || settings.MergeQueue.SetupCommand == "" {
+		return false, nil
+	}
+
+	timeout := defaultSpareSetupTimeout
+	if cfg != nil && cfg.SetupTimeout != "" {
+		if d, err := time.ParseDuration(cfg.SetupTimeout); err == nil && d > 0 {
+			timeout = d
+		}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	logFile, err := os.Create(filepath.Join(m.spareDir(name), "setup.log"))
+	if err != nil {
+		return false, fmt.Errorf("creating setup log: %w", err)
+	}
+	defer func() { _ = logFile.Close() }()
+
+	cmd := exec.CommandContext(ctx, "sh", "-c", settings.MergeQueue.SetupCommand) //nolint:gosec // G204: command from rig settings
+	cmd.Dir = m.spareClonePath(name)
+	cmd.Env = os.Environ()
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return true, fmt.Errorf("setup command timed out after %v", timeout)
+		}
+		return true, fmt.Errorf("setup command failed: %w", err)
+	}
+	return true, nil
+}
+
+// createSpare builds one spare: a detached worktree at startPoint,
+// provisioned like a new polecat, with setup run and a "nuked" agent bead.
+func (m *Manager) createSpare(repoGit *git.Git, startPoint string, cfg *config.WarmPoolConfig) (_ *Spare, retErr error) {
+	name, err := m.AllocateName()
+	if err != nil {
+		return nil, fmt.Errorf("allocating name: %w", err)
+	}
+
+	fl, err := m.lockPolecat(name)
+	if err != nil {
+		_ = os.Remove(m.pendingPath(name))
+		return nil, err
+	}
+	defer func() { _ = fl.Unlock() }()
+
+	dir := m.spareDir(name)
+	clonePath := m.spareClonePath(name)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		_ = os.Remove(m.pendingPath(name))
+		return nil, fmt.Errorf("creating spare dir: %w", err)
+	}
+	// The spare directory now holds the name (see reconcilePoolInternal).
+	_ = os.Remove(m.pendingPath(name))
+	if err := os.MkdirAll(filepath.Join(dir, ".claude"), 0755); err != nil {
+		style.PrintWarning("could not create polecat .claude dir: %v", err)
+	}
+
+	worktreeCreated := false
+	defer func() {
+		if retErr == nil {
+			return
+		}
+		if worktreeCreated {
+			_ = repoGit.WorktreeRemove(clonePath, true)
+		}
+		_ = os.RemoveAll(dir)
+		m.namePool.Release(name)
+		_ = m.namePool.Save()
+	}()
+
+	// Detached HEAD: no polecat/* branch exists until the spare is claimed,
+	// so branch pruning never touches spares.
+	if err := repoGit.WorktreeAddDetached(clonePath, startPoint); err != nil {
+		return nil, fmt.Errorf("creating worktree from %s: %w", startPoint, err)
+	}
+	worktreeCreated = true
+
+	m.provisionWorktree(name, clonePath)
+
+	// Created now so a claim is only a state update. A bead left behind by
+	// a failed build is in the same state nuke leaves beads in.
+	if err := m.createAgentBeadWithRetry(m.agentBeadID(name), &beads.AgentFields{
+		RoleType:   "polecat",
+		Rig:        m.rig.Name,
+		AgentState: "nuked",
+	}); err != nil {
+		return nil, fmt.Errorf("creating agent bead: %w", err)
+	}
+
+	now := time.Now().UTC()
+	s := &Spare{Name: name, BaseRef: startPoint, CreatedAt: now, RefreshedAt: now, ClonePath: clonePath}
+	s.Commit, _ = git.NewGit(clonePath).Rev("HEAD")
+
+	// A failed setup still leaves a usable spare: the polecat's formula
+	// runs setup again after claiming.
+	ran, err := m.runSpareSetup(name, cfg)
+	s.SetupRan = ran && err == nil
+	if err != nil {
+		s.SetupError = err.Error()
+	}
+
+	if err := m.saveSpare(s); err != nil {
+		return nil, fmt.Errorf("saving spare state: %w", err)
+	}
+	return s, nil
+}
+
+// refreshSpare moves a spare to the current tip of its base ref and
+// re-runs setup. The caller holds the spare's lock.
+func (m *Manager) refreshSpare(s *Spare, tip string, cfg *config.WarmPoolConfig) error {
+	g := git.NewGit(s.ClonePath)
+	if err := g.ResetHard(tip); err != nil {
+		return fmt.Errorf("resetting to %s: %w", s.BaseRef, err)
+	}
+	if err := git.InitSubmodules(s.ClonePath); err != nil {
+		style.PrintWarning("could not update submodules in spare %s: %v", s.Name, err)
+	}
+	s.Commit = tip
+	s.RefreshedAt = time.Now().UTC()
+	ran, err := m.runSpareSetup(s.Name, cfg)
+	s.SetupRan = ran && err == nil
+	s.SetupError = ""
+	if err != nil {
+		s.SetupError = err.Error()
+	}
+	if saveErr := m.saveSpare(s); saveErr != nil {
+		return saveErr
+	}
+	return err
+}
+
+// removeSpare deletes a spare and returns its name to the pool. The
+// caller holds the spare's lock.
+func (m *Manager) removeSpare(name string) error {
+	if repoGit, err := m.repoBase(); err == nil {
+		_ = repoGit.WorktreeRemove(m.spareClonePath(name), true)
+		defer func() { _ = repoGit.WorktreePrune() }()
+	}
+	if err := os.RemoveAll(m.spareDir(name)); err != nil {
+		return fmt.Errorf("removing spare %s: %w", name, err)
+	}
+	m.namePool.Release(name)
+	_ = m.namePool.Save()
+	return nil
+}
+
+// FillWarmPool brings the rig's spares in line with its warm_pool config:
+// spares behind the current tip of the default branch are refreshed,
+// spares on a different base (or beyond the configured size) are removed,
+// and new spares are created until the pool is full. Spares that are
+// locked (being claimed) are left alone.
+func (m *Manager) FillWarmPool() (*WarmPoolResult, error) {
+	lockDir := filepath.Join(m.rig.Path, ".runtime", "locks")
+	if err := os.MkdirAll(lockDir, 0755); err != nil {
+		return nil, fmt.Errorf("creating lock dir: %w", err)
+	}
+	fl := flock.New(filepath.Join(lockDir, "polecat-warm.lock"))
+	if ok, err := fl.TryLock(); err != nil {
+		return nil, fmt.Errorf("acquiring warm pool lock: %w", err)
+	} else if !ok {
+		return nil, ErrWarmPoolBusy
+	}
+	defer func() { _ = fl.Unlock() }()
+
+	cfg := m.WarmPoolConfig()
+	size := 0
+	if cfg != nil {
+		size = cfg.Size
+	}
+	result := &WarmPoolResult{}
+
+	repoGit, err := m.repoBase()
+	if err != nil {
+		return nil, fmt.Errorf("finding repo base: %w", err)
+	}
+	if size > 0 {
+		if err := repoGit.Fetch("origin"); err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("fetch: %v", err))
+		}
+	}
+	startPoint := m.defaultStartPoint()
+	tip, _ := repoGit.Rev(startPoint)
+
+	// Spare directories without a state file are leftovers from a fill
+	// that crashed mid-build (builds only happen under the fill lock).
+	ready := make(map[string]*Spare)
+	spares, _ := m.ListSpares()
+	for _, s := range spares {
+		ready[s.Name] = s
+	}
+	for _, name := range m.spareNames() {
+		if ready[name] != nil {
+			continue
+		}
+		if sfl, ok := m.tryLockPolecat(name); ok {
+			if err := m.removeSpare(name); err != nil {
+				result.Errors = append(result.Errors, err.Error())
+			} else {
+				result.Removed = append(result.Removed, name)
+			}
+			_ = sfl.Unlock()
+		}
+	}
+
+	kept := 0
+	for _, s := range spares {
+		sfl, ok := m.tryLockPolecat(s.Name)
+		if !ok {
+			continue // being claimed
+		}
+		if _, err := os.Stat(m.spareDir(s.Name)); err != nil {
+			_ = sfl.Unlock()
+			continue // claimed since listing
+		}
+
+		switch {
+		case kept >= size || s.BaseRef != startPoint || tip == "":
+			if err := m.removeSpare(s.Name); err != nil {
+				result.Errors = append(result.Errors, err.Error())
+			} else {
+				result.Removed = append(result.Removed, s.Name)
+			}
+		case s.Commit != tip:
+			if err := m.refreshSpare(s, tip, cfg); err != nil {
+				result.Errors = append(result.Errors, fmt.Sprintf("refresh %s: %v", s.Name, err))
+			}
+			result.Refreshed = append(result.Refreshed, s.Name)
+			kept++
+		default:
+			kept++
+		}
+		_ = sfl.Unlock()
+	}
+
+	if tip == "" {
+		if size > 0 {
+			result.Errors = append(result.Errors, fmt.Sprintf("%s not found in repo base", startPoint))
+		}
+		return result, nil
+	}
+	for ; kept < size; kept++ {
+		s, err := m.createSpare(repoGit, startPoint, cfg)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("create: %v", err))
+			break
+		}
+		result.Created = append(result.Created, s.Name)
+	}
+	return result, nil
+}
+
+// DrainWarmPool removes every spare that is not being claimed and returns
+// the names removed.
+func (m *Manager) DrainWarmPool() ([]string, error) {
+	var removed []string
+	for _, name := range m.spareNames() {
+		sfl, ok := m.tryLockPolecat(name)
+		if !ok {
+			continue
+		}
+		err := m.removeSpare(name)
+		_ = sfl.Unlock()
+		if err != nil {
+			return removed, err
+		}
+		removed = append(removed, name)
+	}
+	return removed, nil
+}
+
+// ClaimSpare turns a warm spare into a working polecat: the spare is moved
+// to polecats/<name>/, brought up to the local tip of its base ref (no
+// fetch), put on a fresh polecat branch, and its agent bead is moved to the
+// spawning state with opts.HookBead. Only spares built from the requested base
+// (opts.BaseBranch, or the default branch) qualify. Returns ErrNoSpare
+// when none is ready; callers fall back to AddWithOptions.
+func (m *Manager) ClaimSpare(opts AddOptions) (_ *Polecat, retErr error) {
+	startPoint := opts.BaseBranch
+	if startPoint == "" {
+		startPoint = m.defaultStartPoint()
+	}
+
+	spares, err := m.ListSpares()
+	if err != nil || len(spares) == 0 {
+		return nil, ErrNoSpare
+	}
+	repoGit, err := m.repoBase()
+	if err != nil {
+		return nil, ErrNoSpare
+	}
+	tip, _ := repoGit.Rev(startPoint)
+
+	// Prefer spares already at the tip; they need no checkout at all.
+	sort.SliceStable(spares, func(i, j int) bool {
+		return spares[i].Commit == tip && spares[j].Commit != tip
+	})
+
+	for _, s := range spares {
+		if s.BaseRef != startPoint {
+			continue
+		}
+		fl, ok := m.tryLockPolecat(s.Name)
+		if !ok {
+			continue
+		}
+		if _, err := os.Stat(m.spareDir(s.Name)); err != nil || m.exists(s.Name) {
+			_ = fl.Unlock()
+			continue
+		}
+		p, err := m.claimSpareLocked(s, repoGit, tip, opts)
+		_ = fl.Unlock()
+		telemetry.RecordPolecatSpawn(context.Background(), s.Name, err)
+		return p, err
+	}
+	return nil, ErrNoSpare
+}
+
+func (m *Manager) claimSpareLocked(s *Spare, repoGit *git.Git, tip string, opts AddOptions) (_ *Polecat, retErr error) {
+	name := s.Name
+	polecatDir := m.polecatDir(name)
+	clonePath := filepath.Join(polecatDir, m.rig.Name)
+
+	if err := os.Rename(m.spareDir(name), polecatDir); err != nil {
+		return nil, fmt.Errorf("moving spare %s: %w", name, err)
+	}
+	_ = os.Remove(filepath.Join(polecatDir, spareStateFile))
+
+	// From here on the spare is a polecat; undo it completely on failure.
+	// (RemoveWithOptions would deadlock on the polecat lock we hold.)
+	defer func() {
+		if retErr == nil {
+			return
+		}
+		_ = m.beads.ResetAgentBeadForReuse(m.agentBeadID(name), "spare claim rollback")
+		_ = repoGit.WorktreeRemove(clonePath, true)
+		_ = os.RemoveAll(polecatDir)
+		_ = repoGit.WorktreePrune()
+		m.namePool.Release(name)
+		_ = m.namePool.Save()
+	}()
+
+	if err := repoGit.WorktreeRepair(clonePath); err != nil {
+		return nil, fmt.Errorf("repairing moved worktree: %w", err)
+	}
+
+	pg := git.NewGit(clonePath)
+	if tip != "" && tip != s.Commit {
+		if err := pg.ResetHard(tip); err != nil {
+			return nil, fmt.Errorf("updating spare to %s: %w", s.BaseRef, err)
+		}
+	}
+	branchName := m.buildBranchName(name, opts.HookBead)
+	if err := pg.CreateBranchFrom(branchName, "HEAD"); err != nil {
+		return nil, fmt.Errorf("creating branch %s: %w", branchName, err)
+	}
+	if err := pg.Checkout(branchName); err != nil {
+		return nil, fmt.Errorf("checking out %s: %w", branchName, err)
+	}
+
+	// The beads redirect and runtime settings depend on the worktree path.
+	if err := m.setupSharedBeads(clonePath); err != nil {
+		style.PrintWarning("could not set up shared beads: %v", err)
+	}
+	m.installRuntimeSettings(name, clonePath)
+
+	hook := opts.HookBead
+	if err := m.beads.UpdateAgentState(m.agentBeadID(name), "spawning", &hook); err != nil {
+		return nil, fmt.Errorf("agent bead required for polecat tracking: %w", err)
+	}
+
+	now := time.Now()
+	return &Polecat{
+		Name:      name,
+		Rig:       m.rig.Name,
+		State:     StateWorking,
+		ClonePath: clonePath,
+		Branch:    branchName,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
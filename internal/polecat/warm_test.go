@@ -0,0 +1,227 @@
+package polecat
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/rig"
+)
+
+func runGitCmd(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// setupWarmPoolRig creates a rig whose mayor/rig clone is its own origin,
+// so fetches in FillWarmPool pick up new commits on main.
+func setupWarmPoolRig(t *testing.T, size int) (*Manager, string) {
+	t.Helper()
+	installMockBd(t)
+
+	root := t.TempDir()
+	mayorRig := filepath.Join(root, "mayor", "rig")
+	if err := os.MkdirAll(mayorRig, 0755); err != nil {
+		t.Fatal(err)
+	}
+	runGitCmd(t, mayorRig, "init", "-q", "-b", "main")
+	runGitCmd(t, mayorRig, "config", "user.email", "test@example.com")
+	runGitCmd(t, mayorRig, "config", "user.name", "Test")
+	if err := os.WriteFile(filepath.Join(mayorRig, "README.md"), []byte("v1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGitCmd(t, mayorRig, "add", ".")
+	runGitCmd(t, mayorRig, "commit", "-q", "-m", "v1")
+	runGitCmd(t, mayorRig, "remote", "add", "origin", mayorRig)
+	runGitCmd(t, mayorRig, "fetch", "-q", "origin")
+
+	settings := fmt.Sprintf(`{"type":"rig-settings","version":1,`+
+		`"merge_queue":{"on_conflict":"assign_back","setup_command":"echo ok > .deps-installed"},`+
+		`"warm_pool":{"size":%d}}`, size)
+	if err := os.MkdirAll(filepath.Join(root, "settings"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "settings", "config.json"), []byte(settings), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	r := &rig.Rig{Name: "rig", Path: root}
+	return NewManager(r, git.NewGit(root), nil), mayorRig
+}
+
+func TestWarmPoolFillRefreshClaimDrain(t *testing.T) {
+	m, mayorRig := setupWarmPoolRig(t, 2)
+
+	result, err := m.FillWarmPool()
+	if err != nil {
+		t.Fatalf("FillWarmPool: %v", err)
+	}
+	if len(result.Created) != 2 || len(result.Errors) != 0 {
+		t.Fatalf("first fill = %+v, want 2 created", result)
+	}
+
+	spares, _ := m.ListSpares()
+	if len(spares) != 2 {
+		t.Fatalf("ListSpares = %d, want 2", len(spares))
+	}
+	for _, s := range spares {
+		if !s.SetupRan {
+			t.Errorf("spare %s: setup did not run (%s)", s.Name, s.SetupError)
+		}
+		if _, err := os.Stat(filepath.Join(s.ClonePath, ".deps-installed")); err != nil {
+			t.Errorf("spare %s: setup output missing: %v", s.Name, err)
+		}
+	}
+
+	// Spares are not polecats, and their names stay reserved.
+	if polecats, _ := m.List(); len(polecats) != 0 {
+		t.Errorf("List() = %d polecats, want 0 (spares are hidden)", len(polecats))
+	}
+	name, err := m.AllocateName()
+	if err != nil {
+		t.Fatalf("AllocateName: %v", err)
+	}
+	for _, s := range spares {
+		if s.Name == name {
+			t.Errorf("AllocateName returned spare name %s", name)
+		}
+	}
+	m.ReleaseName(name)
+	_ = os.Remove(m.pendingPath(name))
+
+	// A second fill with nothing new is a no-op.
+	if result, err = m.FillWarmPool(); err != nil || len(result.Created)+len(result.Refreshed)+len(result.Removed) != 0 {
+		t.Fatalf("idle fill = %+v, %v; want no changes", result, err)
+	}
+
+	// A merge to main makes the spares stale; the next fill refreshes them.
+	if err := os.WriteFile(filepath.Join(mayorRig, "README.md"), []byte("v2\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGitCmd(t, mayorRig, "commit", "-q", "-am", "v2")
+	tip := runGitCmd(t, mayorRig, "rev-parse", "HEAD")
+	if result, err = m.FillWarmPool(); err != nil || len(result.Refreshed) != 2 {
+		t.Fatalf("fill after merge = %+v, %v; want 2 refreshed", result, err)
+	}
+
+	// Claiming for a different base finds nothing.
+	if _, err := m.ClaimSpare(AddOptions{BaseBranch: "origin/integration/x"}); !errors.Is(err, ErrNoSpare) {
+		t.Errorf("ClaimSpare(other base) err = %v, want ErrNoSpare", err)
+	}
+
+	p, err := m.ClaimSpare(AddOptions{HookBead: "gt-abc"})
+	if err != nil {
+		t.Fatalf("ClaimSpare: %v", err)
+	}
+	if want := filepath.Join(m.rig.Path, "polecats", p.Name, "rig"); p.ClonePath != want {
+		t.Errorf("ClonePath = %s, want %s", p.ClonePath, want)
+	}
+	if got := runGitCmd(t, p.ClonePath, "rev-parse", "HEAD"); got != tip {
+		t.Errorf("claimed HEAD = %s, want %s", got, tip)
+	}
+	if got := runGitCmd(t, p.ClonePath, "branch", "--show-current"); got != p.Branch || !strings.HasPrefix(got, "polecat/"+p.Name) {
+		t.Errorf("claimed branch = %q, polecat.Branch = %q", got, p.Branch)
+	}
+	if _, err := os.Stat(filepath.Join(p.ClonePath, ".deps-installed")); err != nil {
+		t.Errorf("claimed worktree lost setup output: %v", err)
+	}
+	if !strings.Contains(runGitCmd(t, mayorRig, "worktree", "list"), p.ClonePath) {
+		t.Errorf("git worktree list does not show moved worktree %s", p.ClonePath)
+	}
+	if _, err := m.Get(p.Name); err != nil {
+		t.Errorf("Get(%s) after claim: %v", p.Name, err)
+	}
+	if spares, _ := m.ListSpares(); len(spares) != 1 {
+		t.Errorf("ListSpares after claim = %d, want 1", len(spares))
+	}
+
+	removed, err := m.DrainWarmPool()
+	if err != nil || len(removed) != 1 {
+		t.Fatalf("DrainWarmPool = %v, %v; want 1 removed", removed, err)
+	}
+	if entries, _ := os.ReadDir(m.warmDir()); len(entries) != 0 {
+		t.Errorf("warm dir not empty after drain: %d entries", len(entries))
+	}
+}
+
+func TestWarmPoolShrinksToSize(t *testing.T) {
+	m, _ := setupWarmPoolRig(t, 2)
+	if _, err := m.FillWarmPool(); err != nil {
+		t.Fatalf("FillWarmPool: %v", err)
+	}
+
+	settings := filepath.Join(m.rig.Path, "settings", "config.json")
+	data, _ := os.ReadFile(settings)
+	if err := os.WriteFile(settings, []byte(strings.Replace(string(data), `"size":2`, `"size":0`, 1)), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := m.FillWarmPool()
+	if err != nil || len(result.Removed) != 2 {
+		t.Fatalf("fill with size 0 = %+v, %v; want 2 removed", result, err)
+	}
+	if _, err := m.ClaimSpare(AddOptions{}); !errors.Is(err, ErrNoSpare) {
+		t.Errorf("ClaimSpare on empty pool err = %v, want ErrNoSpare", err)
+	}
+}
+
+func TestWarmPoolAgentBeadCreatedAtFill(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("test uses a Unix shell script wrapper for bd")
+	}
+	m, _ := setupWarmPoolRig(t, 1)
+	if err := os.MkdirAll(filepath.Join(m.rig.Path, ".beads"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	// Record every bd call, then hand it to the mock bd already on PATH.
+	mockBd, err := exec.LookPath("bd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	binDir := t.TempDir()
+	bdLog := filepath.Join(binDir, "bd.log")
+	script := "#!/bin/sh\necho \"$*\" >> " + bdLog + "\nexec " + mockBd + " \"$@\"\n"
+	if err := os.WriteFile(filepath.Join(binDir, "bd"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	calls := func() string {
+		data, _ := os.ReadFile(bdLog)
+		_ = os.Remove(bdLog)
+		return string(data)
+	}
+
+	if _, err := m.FillWarmPool(); err != nil {
+		t.Fatalf("FillWarmPool: %v", err)
+	}
+	fill := calls()
+	if !strings.Contains(fill, "create") || !strings.Contains(fill, "agent_state: nuked") {
+		t.Errorf("fill did not create a nuked agent bead; bd calls:\n%s", fill)
+	}
+
+	p, err := m.ClaimSpare(AddOptions{HookBead: "gt-abc"})
+	if err != nil {
+		t.Fatalf("ClaimSpare: %v", err)
+	}
+	claim := calls()
+	if strings.Contains(claim, "create") {
+		t.Errorf("claim created an agent bead; bd calls:\n%s", claim)
+	}
+	id := m.agentBeadID(p.Name)
+	if !strings.Contains(claim, "agent state "+id+" spawning") || !strings.Contains(claim, "slot set "+id+" hook gt-abc") {
+		t.Errorf("claim did not move %s to spawning with its hook; bd calls:\n%s", id, claim)
+	}
+}
@@ -0,0 +1,303 @@
+// Package redact scrubs secrets from agent output before it is stored or
+// displayed: mail, .events.jsonl payloads, OTel logs, and web API responses.
+//
+// Built-in detectors cover common API keys and tokens, private key blocks,
+// credentials in URLs and assignments, and high-entropy strings. Towns can
+// add patterns and exceptions in settings/config.json ("redaction").
+// Matches are replaced with "[REDACTED:<detector>]".
+package redact
+
+import (
+	"math"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// Detector finds one kind of secret.
+type Detector struct {
+	Name string
+	re   *regexp.Regexp
+}
+
+// builtinPatterns are always active. A capture group, when present, marks
+// the part to replace so surrounding context ("password=") stays readable.
+var builtinPatterns = []struct{ name, pattern string }{
+	{"private-key", `(?s)-----BEGIN [A-Z0-9 ]*PRIVATE KEY( BLOCK)?-----.*?-----END [A-Z0-9 ]*PRIVATE KEY( BLOCK)?-----`},
+	{"anthropic-key", `sk-ant-[A-Za-z0-9_-]{20,}`},
+	{"openai-key", `sk-(?:proj-|svcacct-)?[A-Za-z0-9_-]{32,}`},
+	{"github-token", `(?:gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{40,})`},
+	{"aws-access-key", `\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`},
+	{"slack-token", `xox[abprs]-[A-Za-z0-9-]{10,}`},
+	{"google-api-key", `AIza[0-9A-Za-z_-]{35}`},
+	{"stripe-key", `\b[sr]k_(?:live|test)_[0-9A-Za-z]{24,}`},
+	{"jwt", `eyJ[A-Za-z0-9_-]{10,}\.eyJ[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}`},
+	{"bearer-token", `(?i)\bbearer\s+([A-Za-z0-9._~+/-]{20,}=*)`},
+	{"url-credentials", `[a-zA-Z][a-zA-Z0-9+.-]*://[^/\s:@]+:([^/\s@]{3,})@`},
+	{"assignment", `(?i)(?:password|passwd|pwd|secret|token|api[_-]?key|access[_-]?key|client[_-]?secret)\s*[:=]\s*["']?([^\s"',;]{8,})`},
+}
+
+var builtinDetectors = compileBuiltins()
+
+func compileBuiltins() []Detector {
+	ds := make([]Detector, len(builtinPatterns))
+	for i, p := range builtinPatterns {
+		ds[i] = Detector{Name: p.name, re: regexp.MustCompile(p.pattern)}
+	}
+	return ds
+}
+
+// entropyCandidate matches tokens long enough to be keys. Dots and other
+// separators break tokens, so file paths and hostnames rarely qualify.
+var entropyCandidate = regexp.MustCompile(`[A-Za-z0-9+/_-]{32,}={0,2}`)
+
+// entropyThreshold is bits per character. Random base62/base64 tokens of
+// 32+ characters score ~4.6-5.5; identifiers and prose stay below ~4.2.
+const entropyThreshold = 4.5
+
+// Redactor applies a set of detectors.
+type Redactor struct {
+	detectors []Detector
+	allow     []string
+	entropy   bool
+
+	// Invalid lists user patterns that failed to compile ("name: error").
+	Invalid []string
+}
+
+// Default returns a Redactor with only the built-in detectors.
+func Default() *Redactor {
+	return &Redactor{detectors: builtinDetectors, entropy: true}
+}
+
+// New returns a Redactor with the built-in detectors plus cfg's additions.
+func New(cfg *config.RedactionConfig) *Redactor {
+	r := Default()
+	if cfg == nil {
+		return r
+	}
+	r.allow = cfg.Allow
+	r.entropy = !cfg.DisableEntropy
+
+	names := make([]string, 0, len(cfg.Patterns))
+	for name := range cfg.Patterns {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	detectors := append([]Detector(nil), builtinDetectors...)
+	for _, name := range names {
+		re, err := regexp.Compile(cfg.Patterns[name])
+		if err != nil {
+			r.Invalid = append(r.Invalid, name+": "+err.Error())
+			continue
+		}
+		detectors = append(detectors, Detector{Name: name, re: re})
+	}
+	r.detectors = detectors
+	return r
+}
+
+// Counts tallies redactions by detector name.
+type Counts map[string]int
+
+// Total returns the number of redactions.
+func (c Counts) Total() int {
+	n := 0
+	for _, v := range c {
+		n += v
+	}
+	return n
+}
+
+// Add merges o into c.
+func (c Counts) Add(o Counts) {
+	for k, v := range o {
+		c[k] += v
+	}
+}
+
+// String returns s with secrets replaced, and what was replaced.
+func (r *Redactor) String(s string) (string, Counts) {
+	counts := Counts{}
+	s = r.scrub(s, counts)
+	return s, counts
+}
+
+// Map returns a deep copy of m with every string value scrubbed. Keys are
+// kept as-is.
+func (r *Redactor) Map(m map[string]interface{}) (map[string]interface{}, Counts) {
+	counts := Counts{}
+	if m == nil {
+		return nil, counts
+	}
+	out, _ := r.value(m, counts).(map[string]interface{})
+	return out, counts
+}
+
+func (r *Redactor) value(v interface{}, counts Counts) interface{} {
+	switch t := v.(type) {
+	case string:
+		return r.scrub(t, counts)
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(t))
+		for k, vv := range t {
+			out[k] = r.value(vv, counts)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(t))
+		for i, vv := range t {
+			out[i] = r.value(vv, counts)
+		}
+		return out
+	case []string:
+		out := make([]string, len(t))
+		for i, vv := range t {
+			out[i] = r.scrub(vv, counts)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+func (r *Redactor) scrub(s string, counts Counts) string {
+	if len(s) < 8 {
+		return s
+	}
+	for _, d := range r.detectors {
+		s = r.replace(s, d, counts)
+	}
+	if r.entropy {
+		s = entropyCandidate.ReplaceAllStringFunc(s, func(tok string) string {
+			if !highEntropy(tok) || r.allowed(tok) {
+				return tok
+			}
+			counts["high-entropy"]++
+			return placeholder("high-entropy")
+		})
+	}
+	return s
+}
+
+// replace substitutes each match of d (or its first capture group).
+func (r *Redactor) replace(s string, d Detector, counts Counts) string {
+	matches := d.re.FindAllStringSubmatchIndex(s, -1)
+	if matches == nil {
+		return s
+	}
+	var b strings.Builder
+	last := 0
+	for _, m := range matches {
+		start, end := m[0], m[1]
+		if len(m) >= 4 && m[2] >= 0 {
+			start, end = m[2], m[3]
+		}
+		if start < last || r.allowed(s[start:end]) || strings.HasPrefix(s[start:end], "[REDACTED:") {
+			continue
+		}
+		b.WriteString(s[last:start])
+		b.WriteString(placeholder(d.Name))
+		last = end
+		counts[d.Name]++
+	}
+	b.WriteString(s[last:])
+	return b.String()
+}
+
+func (r *Redactor) allowed(secret string) bool {
+	for _, a := range r.allow {
+		if a != "" && strings.Contains(secret, a) {
+			return true
+		}
+	}
+	return false
+}
+
+func placeholder(name string) string {
+	return "[REDACTED:" + name + "]"
+}
+
+// highEntropy reports whether tok looks like a random key: mixed letters
+// and digits, not plain hex (git SHAs, UUIDs), and high Shannon entropy.
+func highEntropy(tok string) bool {
+	var hasLetter, hasDigit, nonHex bool
+	freq := make(map[rune]int, 64)
+	for _, c := range tok {
+		switch {
+		case c >= '0' && c <= '9':
+			hasDigit = true
+		case (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F'):
+			hasLetter = true
+		case (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z'):
+			hasLetter, nonHex = true, true
+		}
+		freq[c]++
+	}
+	if !hasLetter || !hasDigit || !nonHex {
+		return false
+	}
+	var h float64
+	n := float64(len(tok))
+	for _, f := range freq {
+		p := float64(f) / n
+		h -= p * math.Log2(p)
+	}
+	return h >= entropyThreshold
+}
+
+// townCache holds one Redactor per town, reloaded when settings change.
+var townCache = struct {
+	sync.Mutex
+	entries map[string]townEntry
+}{entries: make(map[string]townEntry)}
+
+type townEntry struct {
+	r       *Redactor
+	modTime time.Time
+}
+
+// ForTown returns the Redactor for a town's settings (built-ins if the
+// town has none). Safe for concurrent use; cheap to call repeatedly.
+func ForTown(townRoot string) *Redactor {
+	if townRoot == "" {
+		return Default()
+	}
+	path := config.TownSettingsPath(townRoot)
+	var modTime time.Time
+	if info, err := os.Stat(path); err == nil {
+		modTime = info.ModTime()
+	}
+
+	townCache.Lock()
+	defer townCache.Unlock()
+	if e, ok := townCache.entries[townRoot]; ok && e.modTime.Equal(modTime) {
+		return e.r
+	}
+	r := Default()
+	if settings, err := config.LoadOrCreateTownSettings(path); err == nil {
+		r = New(settings.Redaction)
+	}
+	townCache.entries[townRoot] = townEntry{r: r, modTime: modTime}
+	return r
+}
+
+var (
+	cwdTownOnce sync.Once
+	cwdTown     string
+)
+
+// CurrentTown returns the town root for the working directory, looked up
+// once per process. Empty outside a town.
+func CurrentTown() string {
+	cwdTownOnce.Do(func() {
+		cwdTown, _ = workspace.FindFromCwd()
+	})
+	return cwdTown
+}
@@ -0,0 +1,108 @@
+package redact
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gofrs/flock"
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// StatsFile is the redaction tally, relative to the town root.
+const StatsFile = ".runtime/redactions.json"
+
+// Sink names passed to Record.
+const (
+	SinkEvents      = "events"
+	SinkMail        = "mail"
+	SinkTelemetry   = "telemetry"
+	SinkWeb         = "web"
+	SinkTranscripts = "transcripts"
+)
+
+// Stats is the persisted redaction tally for a town. Only counts are kept;
+// the redacted values themselves are never written anywhere.
+type Stats struct {
+	Total      int            `json:"total"`
+	BySink     map[string]int `json:"by_sink"`
+	ByDetector map[string]int `json:"by_detector"`
+	First      time.Time      `json:"first,omitempty"`
+	Last       time.Time      `json:"last,omitempty"`
+}
+
+// Record adds counts for sink to the town's tally. A no-op when nothing
+// was redacted or townRoot is empty.
+func Record(townRoot, sink string, counts Counts) error {
+	n := counts.Total()
+	if n == 0 || townRoot == "" {
+		return nil
+	}
+	path := filepath.Join(townRoot, StatsFile)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating runtime dir: %w", err)
+	}
+
+	fl := flock.New(path + ".lock")
+	if err := fl.Lock(); err != nil {
+		return fmt.Errorf("acquiring redaction stats lock: %w", err)
+	}
+	defer fl.Unlock() //nolint:errcheck // best-effort unlock
+
+	stats, err := LoadStats(townRoot)
+	if err != nil {
+		stats = &Stats{}
+	}
+	if stats.BySink == nil {
+		stats.BySink = make(map[string]int)
+	}
+	if stats.ByDetector == nil {
+		stats.ByDetector = make(map[string]int)
+	}
+	now := time.Now().UTC()
+	if stats.First.IsZero() {
+		stats.First = now
+	}
+	stats.Last = now
+	stats.Total += n
+	stats.BySink[sink] += n
+	for name, c := range counts {
+		stats.ByDetector[name] += c
+	}
+
+	data, err := json.MarshalIndent(stats, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling redaction stats: %w", err)
+	}
+	if err := util.AtomicWriteFile(path, data, 0644); err != nil { //nolint:gosec // G306: counts only, no secrets
+		return fmt.Errorf("writing redaction stats: %w", err)
+	}
+	return nil
+}
+
+// LoadStats reads the town's tally. A town that has never redacted
+// anything returns an empty Stats.
+func LoadStats(townRoot string) (*Stats, error) {
+	data, err := os.ReadFile(filepath.Join(townRoot, StatsFile))
+	if os.IsNotExist(err) {
+		return &Stats{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading redaction stats: %w", err)
+	}
+	var stats Stats
+	if err := json.Unmarshal(data, &stats); err != nil {
+		return nil, fmt.Errorf("parsing redaction stats: %w", err)
+	}
+	return &stats, nil
+}
+
+// Scrub redacts s with the town's redactor and records what was removed
+// under sink. Convenience for call sites with a single string.
+func Scrub(townRoot, sink, s string) string {
+	out, counts := ForTown(townRoot).String(s)
+	_ = Record(townRoot, sink, counts)
+	return out
+}
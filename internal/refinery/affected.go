@@ -0,0 +1,229 @@
+package refinery
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// PackagesPlaceholder is substituted in a gate's Cmd with the Go packages
+// affected by the MR (e.g., "go test {packages}"). When every package is
+// affected, or the affected set cannot be computed or is empty, it expands
+// to "./...".
+const PackagesPlaceholder = "{packages}"
+
+// ChangeScope describes which files an MR changed, so gates whose path
+// filters don't match can be skipped. A nil *ChangeScope means the change
+// set is unknown and every gate must run.
+type ChangeScope struct {
+	// Files are the changed paths relative to the repository root,
+	// using forward slashes.
+	Files []string
+}
+
+// gateApplies reports whether a gate must run for the given change scope.
+// Gates without path filters always run, as do all gates when the scope
+// is unknown.
+func gateApplies(gate *GateConfig, scope *ChangeScope) bool {
+	if scope == nil || len(gate.Paths) == 0 {
+		return true
+	}
+	for _, f := range scope.Files {
+		for _, pattern := range gate.Paths {
+			if matchPathGlob(pattern, f) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matchPathGlob matches a slash-separated path against a glob pattern.
+// In addition to path.Match syntax, a "**" segment matches zero or more
+// path segments (e.g., "web/**" matches everything under web/).
+func matchPathGlob(pattern, name string) bool {
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+func matchSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			rest := pattern[1:]
+			if len(rest) == 0 {
+				return true
+			}
+			for i := 0; i <= len(name); i++ {
+				if matchSegments(rest, name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, err := path.Match(pattern[0], name[0]); err != nil || !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
+
+// goListPackage is the subset of `go list -json` output used for
+// affected-package computation.
+type goListPackage struct {
+	ImportPath      string   `json:"ImportPath"`
+	Dir             string   `json:"Dir"`
+	Deps            []string `json:"Deps"`
+	TestImports     []string `json:"TestImports"`
+	XTestImports    []string `json:"XTestImports"`
+	EmbedFiles      []string `json:"EmbedFiles"`
+	TestEmbedFiles  []string `json:"TestEmbedFiles"`
+	XTestEmbedFiles []string `json:"XTestEmbedFiles"`
+}
+
+// globalGoFiles are files whose change can affect every package in a module.
+var globalGoFiles = map[string]bool{
+	"go.mod":  true,
+	"go.sum":  true,
+	"go.work": true,
+}
+
+// AffectedGoPackages returns the import paths of packages in the Go module at
+// moduleDir whose build or tests depend on any of the changed files. A package
+// is affected when it embeds a changed file or a changed file lives under its
+// directory (and no deeper package's), when it transitively imports such a
+// package, or when its tests import an affected package. all is true when the
+// change touches module-wide inputs (go.mod, go.sum, vendor/) and every
+// package must be treated as affected.
+func AffectedGoPackages(ctx context.Context, moduleDir string, changed []string) (pkgs []string, all bool, err error) {
+	for _, f := range changed {
+		if globalGoFiles[f] || strings.HasPrefix(f, "vendor/") {
+			return nil, true, nil
+		}
+	}
+
+	listed, err := goList(ctx, moduleDir)
+	if err != nil {
+		return nil, false, err
+	}
+	return affectedFromList(moduleDir, listed, changed), false, nil
+}
+
+// goList runs `go list -e -json ./...` in dir and decodes the package stream.
+func goList(ctx context.Context, dir string) ([]goListPackage, error) {
+	cmd := exec.CommandContext(ctx, "go", "list", "-e", "-json", "./...")
+	cmd.Dir = dir
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("go list: %v: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	var pkgs []goListPackage
+	dec := json.NewDecoder(&stdout)
+	for dec.More() {
+		var p goListPackage
+		if err := dec.Decode(&p); err != nil {
+			return nil, fmt.Errorf("decoding go list output: %w", err)
+		}
+		pkgs = append(pkgs, p)
+	}
+	return pkgs, nil
+}
+
+// affectedFromList computes affected import paths from go list data.
+func affectedFromList(moduleDir string, listed []goListPackage, changed []string) []string {
+	// Files embedded with //go:embed belong to the package that embeds
+	// them, which need not be the nearest one. Test embeds only affect
+	// that package's own tests.
+	byDir := make(map[string]string, len(listed)) // repo-relative dir → import path
+	embeds := make(map[string]string)             // repo-relative file → import path
+	testEmbeds := make(map[string]string)         // likewise, for _test.go embeds
+	for _, p := range listed {
+		rel, err := filepath.Rel(moduleDir, p.Dir)
+		if err != nil {
+			continue
+		}
+		dir := filepath.ToSlash(rel)
+		byDir[dir] = p.ImportPath
+		for _, f := range p.EmbedFiles {
+			embeds[path.Join(dir, f)] = p.ImportPath
+		}
+		for _, f := range p.TestEmbedFiles {
+			testEmbeds[path.Join(dir, f)] = p.ImportPath
+		}
+		for _, f := range p.XTestEmbedFiles {
+			testEmbeds[path.Join(dir, f)] = p.ImportPath
+		}
+	}
+
+	// Packages directly containing a changed file. Any other file (data
+	// files, testdata, templates read at run time) belongs to the nearest
+	// enclosing package.
+	direct := make(map[string]bool)
+	testDirect := make(map[string]bool)
+	for _, f := range changed {
+		if ip, ok := embeds[f]; ok {
+			direct[ip] = true
+			continue
+		}
+		if ip, ok := testEmbeds[f]; ok {
+			testDirect[ip] = true
+			continue
+		}
+		for d := path.Dir(f); ; d = path.Dir(d) {
+			if ip, ok := byDir[d]; ok {
+				direct[ip] = true
+				break
+			}
+			if d == "." || d == "/" {
+				break
+			}
+		}
+	}
+
+	// Deps is the transitive closure of non-test imports, so a package's
+	// build is affected iff it or one of its Deps changed directly.
+	buildAffected := make(map[string]bool)
+	for _, p := range listed {
+		if direct[p.ImportPath] || anyIn(p.Deps, direct) {
+			buildAffected[p.ImportPath] = true
+		}
+	}
+
+	// Tests are affected if the package is, or if a test import is.
+	var affected []string
+	for _, p := range listed {
+		if buildAffected[p.ImportPath] || testDirect[p.ImportPath] ||
+			anyIn(p.TestImports, buildAffected) || anyIn(p.XTestImports, buildAffected) {
+			affected = append(affected, p.ImportPath)
+		}
+	}
+	sort.Strings(affected)
+	return affected
+}
+
+func anyIn(list []string, set map[string]bool) bool {
+	for _, s := range list {
+		if set[s] {
+			return true
+		}
+	}
+	return false
+}
+
+// isGoModule reports whether dir is the root of a Go module.
+func isGoModule(dir string) bool {
+	_, err := os.Stat(filepath.Join(dir, "go.mod"))
+	return err == nil
+}
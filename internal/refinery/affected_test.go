@@ -0,0 +1,182 @@
+package refinery
+
+import (
+	"context"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/rig"
+)
+
+func TestMatchPathGlob(t *testing.T) {
+	tests := []struct {
+		pattern, name string
+		want          bool
+	}{
+		{"web/**", "web/app.js", true},
+		{"web/**", "web/src/deep/app.js", true},
+		{"web/**", "webapp/app.js", false},
+		{"**/*.go", "main.go", true},
+		{"**/*.go", "internal/foo/bar.go", true},
+		{"**/*.go", "internal/foo/bar.md", false},
+		{"docs/*.md", "docs/a.md", true},
+		{"docs/*.md", "docs/sub/a.md", false},
+		{"go.mod", "go.mod", true},
+		{"internal/**/testdata/**", "internal/a/b/testdata/x.json", true},
+	}
+	for _, tt := range tests {
+		if got := matchPathGlob(tt.pattern, tt.name); got != tt.want {
+			t.Errorf("matchPathGlob(%q, %q) = %v, want %v", tt.pattern, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestGateApplies(t *testing.T) {
+	web := &GateConfig{Cmd: "npm test", Paths: []string{"web/**"}}
+	always := &GateConfig{Cmd: "make lint"}
+	docsOnly := &ChangeScope{Files: []string{"docs/readme.md"}}
+
+	if gateApplies(web, docsOnly) {
+		t.Error("web gate should not apply to docs-only change")
+	}
+	if !gateApplies(always, docsOnly) {
+		t.Error("gate without paths should always apply")
+	}
+	if !gateApplies(web, nil) {
+		t.Error("unknown scope should run every gate")
+	}
+	if !gateApplies(web, &ChangeScope{Files: []string{"docs/a.md", "web/x.ts"}}) {
+		t.Error("web gate should apply when any web file changed")
+	}
+}
+
+func TestAffectedFromList(t *testing.T) {
+	root := "/repo"
+	listed := []goListPackage{
+		{ImportPath: "m/util", Dir: "/repo/util"},
+		{ImportPath: "m/core", Dir: "/repo/core", Deps: []string{"fmt", "m/util"}},
+		{ImportPath: "m/cli", Dir: "/repo/cli", Deps: []string{"m/core", "m/util"}},
+		{ImportPath: "m/other", Dir: "/repo/other", XTestImports: []string{"m/core"}},
+		{ImportPath: "m/lone", Dir: "/repo/lone"},
+		{ImportPath: "m/web", Dir: "/repo/web", EmbedFiles: []string{"templates/page/x.html"}, TestEmbedFiles: []string{"golden/y.txt"}},
+		{ImportPath: "m/web/templates/page", Dir: "/repo/web/templates/page"},
+	}
+
+	tests := []struct {
+		name    string
+		changed []string
+		want    []string
+	}{
+		{"leaf change propagates to dependents and tests", []string{"util/u.go"}, []string{"m/cli", "m/core", "m/other", "m/util"}},
+		{"test-only importer", []string{"core/c.go"}, []string{"m/cli", "m/core", "m/other"}},
+		{"testdata belongs to enclosing package", []string{"lone/testdata/golden/x.json"}, []string{"m/lone"}},
+		{"data file belongs to enclosing package", []string{"lone/data/table.csv"}, []string{"m/lone"}},
+		{"embedded file belongs to the embedder, not the nearest package", []string{"web/templates/page/x.html"}, []string{"m/web"}},
+		{"test embed affects only the embedder's tests", []string{"web/golden/y.txt"}, []string{"m/web"}},
+		{"docs only", []string{"docs/readme.md"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := affectedFromList(root, listed, tt.changed)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("affected = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAffectedGoPackages_ModuleWideChange(t *testing.T) {
+	_, all, err := AffectedGoPackages(context.Background(), t.TempDir(), []string{"go.sum"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !all {
+		t.Error("go.sum change should affect all packages")
+	}
+}
+
+func TestRunGates_PathFiltersSkipUnaffectedGates(t *testing.T) {
+	for _, parallel := range []bool{false, true} {
+		r := &rig.Rig{Name: "test-rig", Path: t.TempDir()}
+		e := NewEngineer(r)
+		e.workDir = t.TempDir()
+		e.output = io.Discard
+		e.config.GatesParallel = parallel
+		e.config.Gates = map[string]*GateConfig{
+			"web":  {Cmd: "exit 1", Paths: []string{"web/**"}},
+			"lint": {Cmd: "true"},
+		}
+
+		result := e.runGates(context.Background(), &ChangeScope{Files: []string{"docs/a.md"}}, "")
+		if !result.Success {
+			t.Errorf("parallel=%v: expected skipped web gate, got: %s", parallel, result.Error)
+		}
+
+		result = e.runGates(context.Background(), &ChangeScope{Files: []string{"web/app.ts"}}, "")
+		if result.Success {
+			t.Errorf("parallel=%v: expected web gate to run and fail", parallel)
+		}
+	}
+}
+
+func TestPlanGates_ExpandsAffectedPackages(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go toolchain not available")
+	}
+	workDir := t.TempDir()
+	files := map[string]string{
+		"go.mod":             "module example.com/m\n\ngo 1.21\n",
+		"a/a.go":             "package a\n\nimport \"embed\"\n\n//go:embed templates\nvar templates embed.FS\n",
+		"a/templates/x.tmpl": "{{.}}\n",
+		"b/b.go":             "package b\n\nimport _ \"example.com/m/a\"\n",
+		"c/c.go":             "package c\n",
+		"docs/x.txt":         "docs\n",
+	}
+	for name, content := range files {
+		p := filepath.Join(workDir, name)
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	r := &rig.Rig{Name: "test-rig", Path: t.TempDir()}
+	e := NewEngineer(r)
+	e.workDir = workDir
+	e.output = io.Discard
+	e.config.Gates = map[string]*GateConfig{
+		"test": {Cmd: "go test " + PackagesPlaceholder},
+	}
+
+	plan := e.planGates(context.Background(), []string{"test"}, &ChangeScope{Files: []string{"a/a.go"}})
+	if got, want := plan["test"].Cmd, "go test example.com/m/a example.com/m/b"; got != want {
+		t.Errorf("Cmd = %q, want %q", got, want)
+	}
+	if e.config.Gates["test"].Cmd != "go test "+PackagesPlaceholder {
+		t.Error("planGates must not mutate the shared gate config")
+	}
+
+	// An embedded file in a subdirectory that isn't a package.
+	plan = e.planGates(context.Background(), []string{"test"}, &ChangeScope{Files: []string{"a/templates/x.tmpl"}})
+	if got, want := plan["test"].Cmd, "go test example.com/m/a example.com/m/b"; got != want {
+		t.Errorf("embedded file: Cmd = %q, want %q", got, want)
+	}
+
+	// A change that maps to no package tests everything rather than
+	// skipping the gate.
+	plan = e.planGates(context.Background(), []string{"test"}, &ChangeScope{Files: []string{"docs/x.txt"}})
+	if got, want := plan["test"].Cmd, "go test ./..."; got != want {
+		t.Errorf("docs-only: Cmd = %q, want %q", got, want)
+	}
+
+	plan = e.planGates(context.Background(), []string{"test"}, nil)
+	if got, want := plan["test"].Cmd, "go test ./..."; got != want {
+		t.Errorf("Cmd = %q, want %q", got, want)
+	}
+}
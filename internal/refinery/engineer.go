@@ -24,6 +24,8 @@ import (
 	"github.com/steveyegge/gastown/internal/mail"
 	"github.com/steveyegge/gastown/internal/protocol"
 	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/telemetry"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // DefaultStaleClaimTimeout is the default duration after which a claimed MR
@@ -55,6 +57,26 @@ type GateConfig struct {
 	// Timeout is the maximum time the gate command may run.
 	// Zero means no timeout (inherits context deadline).
 	Timeout time.Duration `json:"timeout"`
+
+	// Report is the test report format the gate produces: "go-json" (parsed
+	// from stdout) or "junit" (read from ReportPath). Empty means the gate's
+	// output is not parsed and per-test flaky tracking is disabled for it.
+	Report string `json:"report,omitempty"`
+
+	// ReportPath is the JUnit XML file the gate writes, relative to the
+	// refinery worktree. Only used when Report is "junit".
+	ReportPath string `json:"report_path,omitempty"`
+
+	// Paths restricts the gate to MRs that change at least one matching file.
+	// Patterns are slash-separated globs relative to the repo root, where "**"
+	// matches any number of directories (e.g., "web/**", "**/*.go").
+	// Empty means the gate always runs.
+	Paths []string `json:"paths,omitempty"`
+
+	// CacheEnv lists environment variables whose values are folded into the
+	// gate cache key, for inputs that change outcomes without changing the
+	// tree (e.g., "GOFLAGS", "NODE_VERSION").
+	CacheEnv []string `json:"cache_env,omitempty"`
 }
 
 // GateResult holds the outcome of a single gate execution.
@@ -63,6 +85,22 @@ type GateResult struct {
 	Success bool
 	Error   string
 	Elapsed time.Duration
+
+	// Tests holds per-test outcomes parsed from the gate's report, if any.
+	Tests []TestOutcome
+
+	// Quarantined lists failed tests that were ignored because they are
+	// classified as flaky. Non-empty only when the gate passed by quarantine.
+	Quarantined []string
+
+	// Skipped is true when the gate did not run because the MR changed
+	// nothing it covers. Skipped gates count as passed.
+	Skipped bool
+
+	// Cached is true when the result was reused from the gate cache instead
+	// of running the command. CachedAt is when the reused result was produced.
+	Cached   bool
+	CachedAt time.Time
 }
 
 // MergeQueueConfig holds configuration for the merge queue processor.
@@ -88,8 +126,19 @@ type MergeQueueConfig struct {
 	DeleteMergedBranches bool `json:"delete_merged_branches"`
 
 	// RetryFlakyTests is the number of times to retry flaky tests.
+	// Applies to the legacy TestCommand and to each failing gate. Gates with
+	// a test report always get one re-run when tests fail, since a flip on
+	// the same tree is what detects a flaky test.
 	RetryFlakyTests int `json:"retry_flaky_tests"`
 
+	// QuarantineFlakyTests allows a gate to pass when every failed test in its
+	// report is classified as flaky in the rig's test history.
+	QuarantineFlakyTests bool `json:"quarantine_flaky_tests"`
+
+	// FlakyClearAfter is how many consecutive passes lift a test's flaky
+	// classification. Zero uses DefaultFlakyClearAfter.
+	FlakyClearAfter int `json:"flaky_clear_after"`
+
 	// PollInterval is how often to check for new MRs.
 	PollInterval time.Duration `json:"poll_interval"`
 
@@ -112,6 +161,19 @@ type MergeQueueConfig struct {
 	// GatesParallel controls whether gates run concurrently.
 	// When true, all gates start simultaneously; any failure = overall failure.
 	GatesParallel bool `json:"gates_parallel"`
+
+	// GateCache enables reuse of passing gate results for identical
+	// (command, tree, environment) inputs, e.g., when an MR is retried after
+	// a conflict-free rebase. Entries live under the rig's .runtime directory.
+	GateCache bool `json:"gate_cache"`
+
+	// GateCacheTTL is how long a cached gate result stays valid.
+	// Zero uses DefaultGateCacheTTL.
+	GateCacheTTL time.Duration `json:"gate_cache_ttl"`
+
+	// GateCacheMaxEntries caps the number of cached results per rig; the
+	// oldest are evicted first. Zero uses DefaultGateCacheMaxEntries.
+	GateCacheMaxEntries int `json:"gate_cache_max_entries"`
 }
 
 // DefaultMergeQueueConfig returns sensible defaults for merge queue configuration.
@@ -123,6 +185,7 @@ func DefaultMergeQueueConfig() *MergeQueueConfig {
 		TestCommand:          "",
 		DeleteMergedBranches: true,
 		RetryFlakyTests:      1,
+		QuarantineFlakyTests: true,
 		PollInterval:         30 * time.Second,
 		MaxConcurrent:        1,
 		StaleClaimTimeout:    DefaultStaleClaimTimeout,
@@ -146,6 +209,7 @@ type MRInfo struct {
 	ConvoyCreatedAt *time.Time // Convoy creation time
 	CreatedAt       time.Time  // MR creation time
 	BlockedBy       string     // Task ID blocking this MR
+	Traceparent     string     // W3C traceparent of the gt done that submitted this MR
 
 	// Raw data for agent-side queue health analysis (ZFC: agent decides, Go transports)
 	UpdatedAt          time.Time // When the MR was last updated
@@ -196,6 +260,8 @@ type Engineer struct {
 	mergeSlotRelease      func(holder string) error
 	mergeSlotMaxRetries   int           // Max retries for slot acquisition (0 = no retry)
 	mergeSlotRetryBackoff time.Duration // Initial backoff between retries
+	flaky                 *FlakyTracker // Per-rig test history for flaky classification
+	fileFlakyBead         func(title, description string) (string, error)
 }
 
 // NewEngineer creates a new Engineer for the given rig.
@@ -230,6 +296,20 @@ func NewEngineer(r *rig.Rig) *Engineer {
 		},
 		mergeSlotMaxRetries:   10,
 		mergeSlotRetryBackoff: 500 * time.Millisecond,
+		flaky:                 NewFlakyTracker(r.Path),
+		fileFlakyBead: func(title, description string) (string, error) {
+			issue, err := beadsClient.Create(beads.CreateOptions{
+				Title:       title,
+				Type:        "bug",
+				Priority:    2,
+				Description: description,
+				Actor:       r.Name + "/refinery",
+			})
+			if err != nil {
+				return "", err
+			}
+			return issue.ID, nil
+		},
 	}
 }
 
@@ -267,17 +347,22 @@ func (e *Engineer) LoadConfig() error {
 	// Parse merge_queue section into our config struct
 	// We need special handling for poll_interval (string -> Duration)
 	var mqRaw struct {
-		Enabled              *bool                      `json:"enabled"`
-		OnConflict           *string                    `json:"on_conflict"`
-		RunTests             *bool                      `json:"run_tests"`
-		TestCommand          *string                    `json:"test_command"`
-		DeleteMergedBranches *bool                      `json:"delete_merged_branches"`
-		RetryFlakyTests      *int                       `json:"retry_flaky_tests"`
-		PollInterval         *string                    `json:"poll_interval"`
-		MaxConcurrent        *int                       `json:"max_concurrent"`
-		StaleClaimTimeout    *string                    `json:"stale_claim_timeout"`
-		Gates                map[string]*gateConfigRaw  `json:"gates"`
-		GatesParallel        *bool                      `json:"gates_parallel"`
+		Enabled              *bool                     `json:"enabled"`
+		OnConflict           *string                   `json:"on_conflict"`
+		RunTests             *bool                     `json:"run_tests"`
+		TestCommand          *string                   `json:"test_command"`
+		DeleteMergedBranches *bool                     `json:"delete_merged_branches"`
+		RetryFlakyTests      *int                      `json:"retry_flaky_tests"`
+		QuarantineFlakyTests *bool                     `json:"quarantine_flaky_tests"`
+		FlakyClearAfter      *int                      `json:"flaky_clear_after"`
+		PollInterval         *string                   `json:"poll_interval"`
+		MaxConcurrent        *int                      `json:"max_concurrent"`
+		StaleClaimTimeout    *string                   `json:"stale_claim_timeout"`
+		Gates                map[string]*gateConfigRaw `json:"gates"`
+		GatesParallel        *bool                     `json:"gates_parallel"`
+		GateCache            *bool                     `json:"gate_cache"`
+		GateCacheTTL         *string                   `json:"gate_cache_ttl"`
+		GateCacheMaxEntries  *int                      `json:"gate_cache_max_entries"`
 	}
 
 	if err := json.Unmarshal(rawConfig.MergeQueue, &mqRaw); err != nil {
@@ -303,6 +388,18 @@ func (e *Engineer) LoadConfig() error {
 	if mqRaw.RetryFlakyTests != nil {
 		e.config.RetryFlakyTests = *mqRaw.RetryFlakyTests
 	}
+	if mqRaw.QuarantineFlakyTests != nil {
+		e.config.QuarantineFlakyTests = *mqRaw.QuarantineFlakyTests
+	}
+	if mqRaw.FlakyClearAfter != nil {
+		if *mqRaw.FlakyClearAfter < 0 {
+			return fmt.Errorf("flaky_clear_after must be non-negative, got %d", *mqRaw.FlakyClearAfter)
+		}
+		e.config.FlakyClearAfter = *mqRaw.FlakyClearAfter
+		if e.flaky != nil && e.config.FlakyClearAfter > 0 {
+			e.flaky.ClearAfter = e.config.FlakyClearAfter
+		}
+	}
 	if mqRaw.MaxConcurrent != nil {
 		e.config.MaxConcurrent = *mqRaw.MaxConcurrent
 	}
@@ -328,7 +425,13 @@ func (e *Engineer) LoadConfig() error {
 	if mqRaw.Gates != nil {
 		e.config.Gates = make(map[string]*GateConfig, len(mqRaw.Gates))
 		for name, raw := range mqRaw.Gates {
-			gc := &GateConfig{Cmd: raw.Cmd}
+			gc := &GateConfig{Cmd: raw.Cmd, Report: raw.Report, ReportPath: raw.ReportPath, Paths: raw.Paths, CacheEnv: raw.CacheEnv}
+			if !ValidReportFormat(raw.Report) {
+				return fmt.Errorf("gate %q: unsupported report format %q (want %q or %q)", name, raw.Report, ReportGoJSON, ReportJUnit)
+			}
+			if raw.Report == ReportJUnit && raw.ReportPath == "" {
+				return fmt.Errorf("gate %q: report_path is required for junit reports", name)
+			}
 			if raw.Timeout != "" {
 				dur, err := time.ParseDuration(raw.Timeout)
 				if err != nil {
@@ -345,6 +448,25 @@ func (e *Engineer) LoadConfig() error {
 	if mqRaw.GatesParallel != nil {
 		e.config.GatesParallel = *mqRaw.GatesParallel
 	}
+	if mqRaw.GateCache != nil {
+		e.config.GateCache = *mqRaw.GateCache
+	}
+	if mqRaw.GateCacheTTL != nil {
+		dur, err := time.ParseDuration(*mqRaw.GateCacheTTL)
+		if err != nil {
+			return fmt.Errorf("invalid gate_cache_ttl %q: %w", *mqRaw.GateCacheTTL, err)
+		}
+		if dur <= 0 {
+			return fmt.Errorf("gate_cache_ttl must be positive, got %v", dur)
+		}
+		e.config.GateCacheTTL = dur
+	}
+	if mqRaw.GateCacheMaxEntries != nil {
+		if *mqRaw.GateCacheMaxEntries < 0 {
+			return fmt.Errorf("gate_cache_max_entries must be non-negative, got %d", *mqRaw.GateCacheMaxEntries)
+		}
+		e.config.GateCacheMaxEntries = *mqRaw.GateCacheMaxEntries
+	}
 
 	return nil
 }
@@ -352,8 +474,12 @@ func (e *Engineer) LoadConfig() error {
 // gateConfigRaw is the JSON-friendly representation of a gate config
 // with timeout as a string duration.
 type gateConfigRaw struct {
-	Cmd     string `json:"cmd"`
-	Timeout string `json:"timeout"`
+	Cmd        string   `json:"cmd"`
+	Timeout    string   `json:"timeout"`
+	Report     string   `json:"report"`
+	ReportPath string   `json:"report_path"`
+	Paths      []string `json:"paths"`
+	CacheEnv   []string `json:"cache_env"`
 }
 
 // Config returns the current merge queue configuration.
@@ -368,7 +494,8 @@ type ProcessResult struct {
 	Error       string
 	Conflict    bool
 	TestsFailed bool
-	SlotTimeout bool // Merge slot contention timeout (distinct from build/test failure)
+	SlotTimeout bool     // Merge slot contention timeout (distinct from build/test failure)
+	CachedGates []string // Gates whose passing result was reused from the gate cache
 }
 
 // doMerge performs the actual git merge operation.
@@ -453,12 +580,15 @@ func (e *Engineer) doMerge(ctx context.Context, branch, target, sourceIssue stri
 	}
 
 	// Step 4: Run quality gates (or legacy tests) if configured
+	var cachedGates []string
 	if len(e.config.Gates) > 0 {
-		// New gates system: run configured quality gates
-		gateResult := e.runGates(ctx)
+		// New gates system: run configured quality gates, skipping those
+		// whose path filters don't match what the branch changed
+		gateResult := e.runGates(ctx, e.changeScope(target, branch), e.gateTree(target, branch))
 		if !gateResult.Success {
 			return gateResult
 		}
+		cachedGates = gateResult.CachedGates
 	} else if e.config.RunTests && e.config.TestCommand != "" {
 		// Legacy test command path (backward compatible)
 		_, _ = fmt.Fprintf(e.output, "[Engineer] Running tests: %s\n", e.config.TestCommand)
@@ -564,6 +694,7 @@ func (e *Engineer) doMerge(ctx context.Context, branch, target, sourceIssue stri
 	return ProcessResult{
 		Success:     true,
 		MergeCommit: mergeCommit,
+		CachedGates: cachedGates,
 	}
 }
 
@@ -683,6 +814,8 @@ func (e *Engineer) runTests(ctx context.Context) ProcessResult {
 }
 
 // runGate executes a single quality gate command and returns the result.
+// When the gate declares a test report format, per-test outcomes are parsed
+// into the result so flaky tests can be tracked and quarantined.
 func (e *Engineer) runGate(ctx context.Context, name string, gate *GateConfig) GateResult {
 	start := time.Now()
 
@@ -695,6 +828,14 @@ func (e *Engineer) runGate(ctx context.Context, name string, gate *GateConfig) G
 		}
 	}
 
+	// Remove any stale JUnit report so a gate that dies before writing one
+	// isn't judged by the previous MR's results.
+	var reportPath string
+	if gate.Report == ReportJUnit && gate.ReportPath != "" {
+		reportPath = filepath.Join(e.workDir, gate.ReportPath)
+		_ = os.Remove(reportPath)
+	}
+
 	// Apply per-gate timeout if configured
 	gateCtx := ctx
 	if gate.Timeout > 0 {
@@ -712,11 +853,22 @@ func (e *Engineer) runGate(ctx context.Context, name string, gate *GateConfig) G
 	err := cmd.Run()
 	elapsed := time.Since(start)
 
+	var tests []TestOutcome
+	switch gate.Report {
+	case ReportGoJSON:
+		tests, _ = ParseTestReport(gate.Report, stdout.Bytes())
+	case ReportJUnit:
+		if data, readErr := os.ReadFile(reportPath); readErr == nil {
+			tests, _ = ParseTestReport(gate.Report, data)
+		}
+	}
+
 	if err == nil {
 		return GateResult{
 			Name:    name,
 			Success: true,
 			Elapsed: elapsed,
+			Tests:   tests,
 		}
 	}
 
@@ -724,7 +876,9 @@ func (e *Engineer) runGate(ctx context.Context, name string, gate *GateConfig) G
 	if gateCtx.Err() == context.DeadlineExceeded {
 		errMsg = fmt.Sprintf("timed out after %v", gate.Timeout)
 	}
-	if stderrStr := strings.TrimSpace(stderr.String()); stderrStr != "" {
+	if failed := failedTests(tests); len(failed) > 0 {
+		errMsg = fmt.Sprintf("%s: failed tests: %s", errMsg, summarizeTestNames(failed, 10))
+	} else if stderrStr := strings.TrimSpace(stderr.String()); stderrStr != "" {
 		// Cap stderr to avoid huge error messages
 		if len(stderrStr) > 500 {
 			stderrStr = stderrStr[:500] + "..."
@@ -737,13 +891,225 @@ func (e *Engineer) runGate(ctx context.Context, name string, gate *GateConfig) G
 		Success: false,
 		Error:   errMsg,
 		Elapsed: elapsed,
+		Tests:   tests,
 	}
 }
 
+// runGateWithRetries runs a gate up to RetryFlakyTests times, recording the
+// per-test outcomes of every attempt against tree. A test that fails and then
+// passes on the same tree is what classifies it as flaky, so a gate whose
+// report names failed tests gets at least one re-run.
+func (e *Engineer) runGateWithRetries(ctx context.Context, name string, gate *GateConfig, tree string) GateResult {
+	cache := e.gateResultCache()
+	var cacheKey string
+	if cache != nil && tree != "" {
+		cacheKey = GateCacheKey(gate.Cmd, tree, EnvFingerprint(gate.CacheEnv))
+		if entry := cache.Get(cacheKey); entry != nil {
+			return GateResult{
+				Name:     name,
+				Success:  true,
+				Elapsed:  entry.Elapsed,
+				Tests:    entry.Tests,
+				Cached:   true,
+				CachedAt: entry.CreatedAt,
+			}
+		}
+	}
+	_, _ = fmt.Fprintf(e.output, "[Engineer] Gate %q: starting (%s)\n", name, gate.Cmd)
+
+	maxAttempts := e.config.RetryFlakyTests
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+
+	var result GateResult
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		if attempt > 1 {
+			_, _ = fmt.Fprintf(e.output, "[Engineer] Gate %q: retrying (attempt %d/%d)\n", name, attempt, maxAttempts)
+		}
+		result = e.runGate(ctx, name, gate)
+		e.recordTestOutcomes(name, tree, result.Tests)
+		if result.Success || ctx.Err() != nil {
+			break
+		}
+		if attempt == 1 && maxAttempts < 2 && tree != "" && e.flaky != nil && len(failedTests(result.Tests)) > 0 {
+			maxAttempts = 2
+		}
+	}
+
+	if result.Success && cacheKey != "" {
+		err := cache.Put(&GateCacheEntry{
+			Key:            cacheKey,
+			Gate:           name,
+			Cmd:            gate.Cmd,
+			Tree:           tree,
+			EnvFingerprint: EnvFingerprint(gate.CacheEnv),
+			Elapsed:        result.Elapsed,
+			Tests:          result.Tests,
+		})
+		if err != nil {
+			_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to cache gate %q result: %v\n", name, err)
+		}
+	}
+	return result
+}
+
+// gateResultCache returns the rig's gate cache, or nil if caching is disabled.
+func (e *Engineer) gateResultCache() *GateCache {
+	if !e.config.GateCache {
+		return nil
+	}
+	return NewGateCache(e.rig.Path, e.config.GateCacheTTL, e.config.GateCacheMaxEntries)
+}
+
+// gateTree returns the tree that merging branch into target produces, which
+// keys cached gate results and per-test history. The refinery worktree's own
+// HEAD is the target branch while gates run, shared by every MR against it,
+// so it cannot serve as the key.
+// Returns "" if it cannot be determined; such runs are neither cached nor
+// classified as flaky.
+func (e *Engineer) gateTree(target, branch string) string {
+	tree, err := e.git.MergeTree(target, branch)
+	if err != nil {
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: could not compute merged tree for %s into %s: %v\n", branch, target, err)
+		return ""
+	}
+	return tree
+}
+
+// recordTestOutcomes appends test outcomes to the rig's history, files a
+// bead for each test that just became flaky and reports lifted
+// quarantines. Failures are logged, not fatal: flaky tracking must never
+// block a merge on its own.
+func (e *Engineer) recordTestOutcomes(gateName, tree string, tests []TestOutcome) {
+	if e.flaky == nil || len(tests) == 0 {
+		return
+	}
+	newlyFlaky, cleared, err := e.flaky.Record(tree, tests)
+	if err != nil {
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to record test history: %v\n", err)
+		return
+	}
+	for _, rec := range newlyFlaky {
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Gate %q: test %s is flaky (flipped on tree %s)\n", gateName, rec.Name, shortSHA(tree))
+		if e.fileFlakyBead == nil {
+			continue
+		}
+		title := fmt.Sprintf("Flaky test: %s", rec.Name)
+		desc := fmt.Sprintf("Test %s in gate %q of rig %s both passed and failed against tree %s.\n\n"+
+			"It is quarantined: merge queue gates ignore its failures while it stays flaky.\n"+
+			"The quarantine lifts after %d consecutive passes, or when it is removed from %s.",
+			rec.Name, gateName, e.rig.Name, tree, e.flaky.ClearAfter, e.flaky.Path())
+		beadID, err := e.fileFlakyBead(title, desc)
+		if err != nil {
+			_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to file flaky test bead for %s: %v\n", rec.Name, err)
+			continue
+		}
+		if err := e.flaky.SetBeadID(rec.Name, beadID); err != nil {
+			_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to record bead for flaky test %s: %v\n", rec.Name, err)
+		}
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Filed flaky test bead %s for %s\n", beadID, rec.Name)
+	}
+	for _, rec := range cleared {
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Gate %q: test %s passed %d times in a row, lifting its quarantine", gateName, rec.Name, e.flaky.ClearAfter)
+		if rec.BeadID != "" {
+			_, _ = fmt.Fprintf(e.output, " (bead %s can be closed)", rec.BeadID)
+		}
+		_, _ = fmt.Fprintln(e.output)
+	}
+}
+
+// applyQuarantine turns a failed gate into a pass when every failed test in
+// its report is classified as flaky. Gates that failed without attributable
+// test failures (build errors, timeouts, missing reports) are never rescued.
+func (e *Engineer) applyQuarantine(r *GateResult) {
+	if r.Success || !e.config.QuarantineFlakyTests || e.flaky == nil {
+		return
+	}
+	failed := failedTests(r.Tests)
+	if len(failed) == 0 {
+		return
+	}
+	quarantined, err := e.flaky.Quarantined(failed)
+	if err != nil {
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to check flaky quarantine: %v\n", err)
+		return
+	}
+	if len(quarantined) != len(failed) {
+		return
+	}
+	r.Success = true
+	r.Quarantined = failed
+}
+
+// changeScope computes which files branch changed relative to target.
+// Returns nil (run every gate) if the diff cannot be computed.
+func (e *Engineer) changeScope(target, branch string) *ChangeScope {
+	files, err := e.git.ChangedFiles(target, branch)
+	if err != nil {
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: could not diff %s...%s, running all gates: %v\n", target, branch, err)
+		return nil
+	}
+	return &ChangeScope{Files: files}
+}
+
+// resolvePackages computes the {packages} substitution for scope. A change
+// that touches no Go package still tests everything: a file that maps to
+// no package can't be shown to be unused.
+func (e *Engineer) resolvePackages(ctx context.Context, scope *ChangeScope) string {
+	if scope == nil || !isGoModule(e.workDir) {
+		return "./..."
+	}
+	pkgs, all, err := AffectedGoPackages(ctx, e.workDir, scope.Files)
+	if err != nil {
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: could not compute affected packages, testing all: %v\n", err)
+		return "./..."
+	}
+	if all {
+		return "./..."
+	}
+	if len(pkgs) == 0 {
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Change maps to no Go package, testing all\n")
+		return "./..."
+	}
+	_, _ = fmt.Fprintf(e.output, "[Engineer] %d Go package(s) affected by change\n", len(pkgs))
+	return strings.Join(pkgs, " ")
+}
+
+// planGates decides which gates run for scope and returns the effective
+// config for each, with {packages} expanded. Gates that don't apply map to
+// nil. The shared config is never mutated, so the plan is safe to hand to
+// parallel gate goroutines.
+func (e *Engineer) planGates(ctx context.Context, names []string, scope *ChangeScope) map[string]*GateConfig {
+	plan := make(map[string]*GateConfig, len(names))
+	var pkgArg string
+	for _, name := range names {
+		gate := e.config.Gates[name]
+		if !gateApplies(gate, scope) {
+			plan[name] = nil
+			continue
+		}
+		if !strings.Contains(gate.Cmd, PackagesPlaceholder) {
+			plan[name] = gate
+			continue
+		}
+		if pkgArg == "" {
+			pkgArg = e.resolvePackages(ctx, scope)
+		}
+		expanded := *gate
+		expanded.Cmd = strings.ReplaceAll(gate.Cmd, PackagesPlaceholder, pkgArg)
+		plan[name] = &expanded
+	}
+	return plan
+}
+
 // runGates executes all configured quality gates and returns a ProcessResult.
 // Gates run in parallel if GatesParallel is true; otherwise sequentially.
-// Any single gate failure means overall failure.
-func (e *Engineer) runGates(ctx context.Context) ProcessResult {
+// Gates whose path filters don't match scope are skipped; a nil scope runs
+// everything. tree is the merged tree under test (see gateTree); "" disables
+// caching and flaky classification. Any single gate failure means overall
+// failure, unless every failed test in that gate is a quarantined flaky test.
+func (e *Engineer) runGates(ctx context.Context, scope *ChangeScope, tree string) ProcessResult {
 	gates := e.config.Gates
 	if len(gates) == 0 {
 		return ProcessResult{Success: true}
@@ -758,24 +1124,35 @@ func (e *Engineer) runGates(ctx context.Context) ProcessResult {
 
 	_, _ = fmt.Fprintf(e.output, "[Engineer] Running %d quality gate(s) (parallel=%v)\n", len(names), e.config.GatesParallel)
 
+	plan := e.planGates(ctx, names, scope)
 	var results []GateResult
 
 	if e.config.GatesParallel {
 		results = make([]GateResult, len(names))
 		var wg sync.WaitGroup
 		for i, name := range names {
+			gate := plan[name]
+			if gate == nil {
+				results[i] = GateResult{Name: name, Success: true, Skipped: true}
+				continue
+			}
 			wg.Add(1)
-			go func(idx int, gateName string) {
+			go func(idx int, gateName string, gate *GateConfig) {
 				defer wg.Done()
-				_, _ = fmt.Fprintf(e.output, "[Engineer] Gate %q: starting (%s)\n", gateName, gates[gateName].Cmd)
-				results[idx] = e.runGate(ctx, gateName, gates[gateName])
-			}(i, name)
+				results[idx] = e.runGateWithRetries(ctx, gateName, gate, tree)
+				e.applyQuarantine(&results[idx])
+			}(i, name, gate)
 		}
 		wg.Wait()
 	} else {
 		for _, name := range names {
-			_, _ = fmt.Fprintf(e.output, "[Engineer] Gate %q: starting (%s)\n", name, gates[name].Cmd)
-			result := e.runGate(ctx, name, gates[name])
+			gate := plan[name]
+			if gate == nil {
+				results = append(results, GateResult{Name: name, Success: true, Skipped: true})
+				continue
+			}
+			result := e.runGateWithRetries(ctx, name, gate, tree)
+			e.applyQuarantine(&result)
 			results = append(results, result)
 			if !result.Success {
 				// Sequential mode: stop on first failure
@@ -785,11 +1162,20 @@ func (e *Engineer) runGates(ctx context.Context) ProcessResult {
 	}
 
 	// Report results
-	var failures []string
+	var failures, cached []string
 	for _, r := range results {
-		if r.Success {
+		switch {
+		case r.Skipped:
+			_, _ = fmt.Fprintf(e.output, "[Engineer] Gate %q: skipped (no affected paths)\n", r.Name)
+		case r.Cached:
+			_, _ = fmt.Fprintf(e.output, "[Engineer] Gate %q: passed (cached result from %s)\n", r.Name, r.CachedAt.Local().Format(time.RFC3339))
+			cached = append(cached, r.Name)
+		case r.Success && len(r.Quarantined) > 0:
+			_, _ = fmt.Fprintf(e.output, "[Engineer] Gate %q: passed with %d quarantined flaky test(s) (%v): %s\n",
+				r.Name, len(r.Quarantined), r.Elapsed.Truncate(time.Millisecond), summarizeTestNames(r.Quarantined, 10))
+		case r.Success:
 			_, _ = fmt.Fprintf(e.output, "[Engineer] Gate %q: passed (%v)\n", r.Name, r.Elapsed.Truncate(time.Millisecond))
-		} else {
+		default:
 			_, _ = fmt.Fprintf(e.output, "[Engineer] Gate %q: FAILED (%v) - %s\n", r.Name, r.Elapsed.Truncate(time.Millisecond), r.Error)
 			failures = append(failures, fmt.Sprintf("%s: %s", r.Name, r.Error))
 		}
@@ -804,7 +1190,7 @@ func (e *Engineer) runGates(ctx context.Context) ProcessResult {
 	}
 
 	_, _ = fmt.Fprintln(e.output, "[Engineer] All quality gates passed")
-	return ProcessResult{Success: true}
+	return ProcessResult{Success: true, CachedGates: cached}
 }
 
 // syncCrewWorkspaces pulls latest changes to all crew workspaces.
@@ -840,7 +1226,19 @@ func (e *Engineer) syncCrewWorkspaces() {
 }
 
 // ProcessMRInfo processes a merge request from MRInfo.
-func (e *Engineer) ProcessMRInfo(ctx context.Context, mr *MRInfo) ProcessResult {
+func (e *Engineer) ProcessMRInfo(ctx context.Context, mr *MRInfo) (result ProcessResult) {
+	// The merge is the last hop of the trace the sling started.
+	ctx, span := telemetry.StartSpan(telemetry.ContextWithTraceparent(ctx, mr.Traceparent), "refinery.merge",
+		attribute.String("gt.mr", mr.ID), attribute.String("gt.bead", mr.SourceIssue),
+		attribute.String("gt.branch", mr.Branch), attribute.String("gt.target", mr.Target))
+	defer func() {
+		var err error
+		if !result.Success {
+			err = errors.New(result.Error)
+		}
+		telemetry.EndSpan(span, err)
+	}()
+
 	// MR fields are directly on the struct
 	_, _ = fmt.Fprintln(e.output, "[Engineer] Processing MR:")
 	_, _ = fmt.Fprintf(e.output, "  Branch: %s\n", mr.Branch)
@@ -879,6 +1277,7 @@ func (e *Engineer) HandleMRInfoSuccess(mr *MRInfo, result ProcessResult) {
 			}
 			mrFields.MergeCommit = result.MergeCommit
 			mrFields.CloseReason = "merged"
+			mrFields.CachedGates = strings.Join(result.CachedGates, ",")
 			newDesc := beads.SetMRFields(mrBead, mrFields)
 			if err := e.beads.Update(mr.ID, beads.UpdateOptions{Description: &newDesc}); err != nil {
 				_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to update MR %s with merge commit: %v\n", mr.ID, err)
@@ -1165,6 +1564,7 @@ func issueToMRInfo(issue *beads.Issue, fields *beads.MRFields) *MRInfo {
 		RetryCount:      fields.RetryCount,
 		ConvoyID:        fields.ConvoyID,
 		ConvoyCreatedAt: convoyCreatedAt,
+		Traceparent:     fields.Traceparent,
 		CreatedAt:       createdAt,
 		UpdatedAt:       updatedAt,
 		Assignee:        issue.Assignee,
@@ -1492,7 +1892,7 @@ func (e *Engineer) notifyDeaconConvoyFeeding(mr *MRInfo) {
 
 	// Emit event to wake deacon from await-signal (router.Send doesn't write
 	// to .events.jsonl, but await-signal watches the events file).
-	_ = events.LogFeed(events.TypeMail, e.rig.Name+"/refinery", events.MailPayload("deacon/", "CONVOY_NEEDS_FEEDING "+mr.ConvoyID))
+	_ = events.LogFeedIn(filepath.Dir(e.rig.Path), events.TypeMail, e.rig.Name+"/refinery", events.MailPayload("deacon/", "CONVOY_NEEDS_FEEDING "+mr.ConvoyID))
 }
 
 // convoyInfo holds minimal info about a closed convoy for post-merge processing.
@@ -1620,6 +2020,8 @@ func (e *Engineer) checkAndCloseCompletedConvoys(townRoot, townBeads string) []c
 
 // notifyConvoyCompletion sends notifications to convoy owner and notify addresses.
 func (e *Engineer) notifyConvoyCompletion(townRoot, convoyID, title, description string) {
+	_ = events.LogFeedIn(townRoot, events.TypeConvoyLanded, e.rig.Name+"/refinery", events.ConvoyPayload(convoyID, title))
+
 	notified := make(map[string]bool)
 
 	for _, line := range strings.Split(description, "\n") {
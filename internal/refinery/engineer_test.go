@@ -7,12 +7,14 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"runtime"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/steveyegge/gastown/internal/git"
 	"github.com/steveyegge/gastown/internal/rig"
 )
 
@@ -438,7 +440,7 @@ func TestRunGates_Sequential_AllPass(t *testing.T) {
 	}
 	e.config.GatesParallel = false
 
-	result := e.runGates(context.Background())
+	result := e.runGates(context.Background(), nil, "")
 	if !result.Success {
 		t.Errorf("expected success, got error: %s", result.Error)
 	}
@@ -462,7 +464,7 @@ func TestRunGates_Sequential_StopsOnFirstFailure(t *testing.T) {
 	}
 	e.config.GatesParallel = false
 
-	result := e.runGates(context.Background())
+	result := e.runGates(context.Background(), nil, "")
 	if result.Success {
 		t.Error("expected failure")
 	}
@@ -489,7 +491,7 @@ func TestRunGates_Parallel_AllPass(t *testing.T) {
 	}
 	e.config.GatesParallel = true
 
-	result := e.runGates(context.Background())
+	result := e.runGates(context.Background(), nil, "")
 	if !result.Success {
 		t.Errorf("expected success, got error: %s", result.Error)
 	}
@@ -507,7 +509,7 @@ func TestRunGates_Parallel_AnyFailure(t *testing.T) {
 	}
 	e.config.GatesParallel = true
 
-	result := e.runGates(context.Background())
+	result := e.runGates(context.Background(), nil, "")
 	if result.Success {
 		t.Error("expected failure when any gate fails")
 	}
@@ -526,12 +528,227 @@ func TestRunGates_Empty(t *testing.T) {
 	e.output = io.Discard
 	e.config.Gates = nil
 
-	result := e.runGates(context.Background())
+	result := e.runGates(context.Background(), nil, "")
 	if !result.Success {
 		t.Error("expected success with no gates configured")
 	}
 }
 
+func TestRunGates_QuarantinesKnownFlakyFailures(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("gate commands run via sh -c")
+	}
+	r := &rig.Rig{Name: "test-rig", Path: t.TempDir()}
+	e := NewEngineer(r)
+	e.workDir = t.TempDir()
+	e.output = io.Discard
+	e.fileFlakyBead = nil
+
+	// Seed history: TestFlaky flipped on the same tree.
+	for _, passed := range []bool{true, false} {
+		if _, _, err := e.flaky.Record("seed", []TestOutcome{{Name: "p.TestFlaky", Passed: passed}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	flakyOnly := `echo '{"Action":"fail","Package":"p","Test":"TestFlaky"}'; exit 1`
+	e.config.Gates = map[string]*GateConfig{"test": {Cmd: flakyOnly, Report: ReportGoJSON}}
+
+	result := e.runGates(context.Background(), nil, "")
+	if !result.Success {
+		t.Fatalf("expected quarantine to allow merge, got: %s", result.Error)
+	}
+
+	// A real failure alongside the flaky one still blocks.
+	mixed := `echo '{"Action":"fail","Package":"p","Test":"TestFlaky"}'; echo '{"Action":"fail","Package":"p","Test":"TestReal"}'; exit 1`
+	e.config.Gates = map[string]*GateConfig{"test": {Cmd: mixed, Report: ReportGoJSON}}
+	result = e.runGates(context.Background(), nil, "")
+	if result.Success {
+		t.Fatal("expected failure when a non-flaky test fails")
+	}
+	if !strings.Contains(result.Error, "p.TestReal") {
+		t.Errorf("expected error to name failed test, got: %s", result.Error)
+	}
+
+	// Quarantine disabled: flaky failures block again.
+	e.config.QuarantineFlakyTests = false
+	e.config.Gates = map[string]*GateConfig{"test": {Cmd: flakyOnly, Report: ReportGoJSON}}
+	if result := e.runGates(context.Background(), nil, ""); result.Success {
+		t.Error("expected failure with quarantine disabled")
+	}
+}
+
+func TestRunGates_NoQuarantineWithoutAttributedFailures(t *testing.T) {
+	r := &rig.Rig{Name: "test-rig", Path: t.TempDir()}
+	e := NewEngineer(r)
+	e.workDir = t.TempDir()
+	e.output = io.Discard
+	e.config.Gates = map[string]*GateConfig{"test": {Cmd: "exit 1", Report: ReportGoJSON}}
+
+	if result := e.runGates(context.Background(), nil, ""); result.Success {
+		t.Error("gate failing without parsed test failures must not be quarantined")
+	}
+}
+
+// initGateRepo creates a git repo whose main branch has one commit, plus a
+// branch per name that adds a file named after it, so gates have merged trees
+// to key flaky history and cache entries on.
+func initGateRepo(t *testing.T, branches ...string) string {
+	t.Helper()
+	workDir := t.TempDir()
+	gitRun := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-c", "user.name=t", "-c", "user.email=t@t"}, args...)...)
+		cmd.Dir = workDir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	gitRun("init", "-q", "-b", "main")
+	gitRun("commit", "-q", "--allow-empty", "-m", "init")
+	for _, name := range branches {
+		gitRun("checkout", "-q", "-b", name, "main")
+		file := filepath.Base(name)
+		if err := os.WriteFile(filepath.Join(workDir, file), []byte(name), 0644); err != nil {
+			t.Fatal(err)
+		}
+		gitRun("add", file)
+		gitRun("commit", "-q", "-m", name)
+	}
+	gitRun("checkout", "-q", "main")
+	return workDir
+}
+
+func TestRunGates_RetryFlipFilesFlakyBead(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("gate commands run via sh -c")
+	}
+	workDir := initGateRepo(t, "polecat/a")
+
+	r := &rig.Rig{Name: "test-rig", Path: t.TempDir()}
+	e := NewEngineer(r)
+	e.workDir = workDir
+	e.git = git.NewGit(workDir)
+	e.output = io.Discard
+	// RetryFlakyTests stays at its default of 1: a gate with a test report
+	// still gets the re-run that exposes the flip.
+	if e.config.RetryFlakyTests != 1 {
+		t.Fatalf("default RetryFlakyTests = %d, want 1", e.config.RetryFlakyTests)
+	}
+
+	var filed []string
+	e.fileFlakyBead = func(title, description string) (string, error) {
+		filed = append(filed, title)
+		return "gt-flaky1", nil
+	}
+
+	// Fails on the first attempt, passes on the second.
+	marker := filepath.Join(t.TempDir(), "ran")
+	gateCmd := fmt.Sprintf(`if [ -f %[1]s ]; then echo '{"Action":"pass","Package":"p","Test":"TestX"}'; else touch %[1]s; echo '{"Action":"fail","Package":"p","Test":"TestX"}'; exit 1; fi`, marker)
+	e.config.Gates = map[string]*GateConfig{"test": {Cmd: gateCmd, Report: ReportGoJSON}}
+
+	if result := e.runGates(context.Background(), nil, e.gateTree("main", "polecat/a")); !result.Success {
+		t.Fatalf("expected retry to pass, got: %s", result.Error)
+	}
+	if len(filed) != 1 || filed[0] != "Flaky test: p.TestX" {
+		t.Fatalf("filed beads = %v, want one for p.TestX", filed)
+	}
+
+	h, err := e.flaky.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rec := h.Tests["p.TestX"]; rec == nil || !rec.Flaky || rec.BeadID != "gt-flaky1" {
+		t.Errorf("history record = %+v, want flaky with bead gt-flaky1", rec)
+	}
+}
+
+func TestRunGates_ReusesCachedPass(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("gate commands run via sh -c")
+	}
+	workDir := initGateRepo(t, "polecat/a", "polecat/b")
+
+	r := &rig.Rig{Name: "test-rig", Path: t.TempDir()}
+	e := NewEngineer(r)
+	e.workDir = workDir
+	e.git = git.NewGit(workDir)
+	e.output = io.Discard
+	e.config.GateCache = true
+
+	counter := filepath.Join(t.TempDir(), "runs")
+	e.config.Gates = map[string]*GateConfig{
+		"test": {Cmd: fmt.Sprintf("echo run >> %s", counter)},
+	}
+
+	treeA := e.gateTree("main", "polecat/a")
+	first := e.runGates(context.Background(), nil, treeA)
+	if !first.Success || len(first.CachedGates) != 0 {
+		t.Fatalf("first run = %+v, want uncached success", first)
+	}
+	second := e.runGates(context.Background(), nil, treeA)
+	if !second.Success || len(second.CachedGates) != 1 || second.CachedGates[0] != "test" {
+		t.Fatalf("second run = %+v, want cached success for gate test", second)
+	}
+
+	// Another MR against the same target merges to different content: the
+	// refinery worktree's HEAD is identical, but the cache must miss.
+	treeB := e.gateTree("main", "polecat/b")
+	if treeB == "" || treeB == treeA {
+		t.Fatalf("merged trees = %q, %q, want distinct", treeA, treeB)
+	}
+	third := e.runGates(context.Background(), nil, treeB)
+	if !third.Success || len(third.CachedGates) != 0 {
+		t.Fatalf("run for polecat/b = %+v, want uncached success", third)
+	}
+
+	data, err := os.ReadFile(counter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if runs := strings.Count(string(data), "run"); runs != 2 {
+		t.Errorf("gate command ran %d times, want 2", runs)
+	}
+}
+
+func TestEngineer_LoadConfig_GateReport(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeConfig := func(gate map[string]interface{}) {
+		config := map[string]interface{}{
+			"merge_queue": map[string]interface{}{
+				"quarantine_flaky_tests": false,
+				"gates":                  map[string]interface{}{"test": gate},
+			},
+		}
+		data, _ := json.Marshal(config)
+		if err := os.WriteFile(filepath.Join(tmpDir, "config.json"), data, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	writeConfig(map[string]interface{}{"cmd": "make test", "report": "junit", "report_path": "out/junit.xml"})
+	e := NewEngineer(&rig.Rig{Name: "test-rig", Path: tmpDir})
+	if err := e.LoadConfig(); err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	if g := e.config.Gates["test"]; g.Report != ReportJUnit || g.ReportPath != "out/junit.xml" {
+		t.Errorf("gate = %+v, want junit report at out/junit.xml", g)
+	}
+	if e.config.QuarantineFlakyTests {
+		t.Error("expected quarantine_flaky_tests=false to be applied")
+	}
+
+	writeConfig(map[string]interface{}{"cmd": "make test", "report": "junit"})
+	if err := NewEngineer(&rig.Rig{Name: "test-rig", Path: tmpDir}).LoadConfig(); err == nil {
+		t.Error("expected error for junit report without report_path")
+	}
+
+	writeConfig(map[string]interface{}{"cmd": "make test", "report": "tap"})
+	if err := NewEngineer(&rig.Rig{Name: "test-rig", Path: tmpDir}).LoadConfig(); err == nil {
+		t.Error("expected error for unsupported report format")
+	}
+}
+
 func TestEngineer_DeleteMergedBranchesConfig(t *testing.T) {
 	// Test that DeleteMergedBranches is true by default
 	cfg := DefaultMergeQueueConfig()
@@ -0,0 +1,228 @@
+package refinery
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// testHistoryFile is the per-rig test history, relative to the rig's .runtime dir.
+const testHistoryFile = "refinery/test-history.json"
+
+// maxTestRuns caps how many recent runs are kept per test. Older runs carry
+// little signal and would make the history file grow without bound.
+const maxTestRuns = 50
+
+// DefaultFlakyClearAfter is how many consecutive passes lift a test's flaky
+// classification, and with it the quarantine.
+const DefaultFlakyClearAfter = 10
+
+// TestRun is one recorded outcome of a test against a specific source tree.
+type TestRun struct {
+	Tree   string    `json:"tree"` // Git tree SHA the test ran against
+	Passed bool      `json:"passed"`
+	At     time.Time `json:"at"`
+}
+
+// TestRecord is the pass/fail history and flaky classification of one test.
+type TestRecord struct {
+	Name       string    `json:"name"`
+	Runs       []TestRun `json:"runs"`
+	Flaky      bool      `json:"flaky,omitempty"`
+	FlakySince time.Time `json:"flaky_since,omitempty"`
+	BeadID     string    `json:"bead_id,omitempty"` // Bead filed when the test became flaky
+}
+
+// TestHistory is the persisted per-rig test history.
+type TestHistory struct {
+	Tests map[string]*TestRecord `json:"tests"`
+}
+
+// FlakyTracker records per-test outcomes for a rig and classifies tests as
+// flaky when they both pass and fail against the same tree (i.e., the result
+// flipped without any code change).
+//
+// Only one refinery runs per rig, so the tracker guards concurrent gates with
+// an in-process mutex rather than a file lock.
+type FlakyTracker struct {
+	path string
+	mu   sync.Mutex
+
+	// ClearAfter is how many consecutive passes clear a flaky
+	// classification. Zero never clears it.
+	ClearAfter int
+}
+
+// NewFlakyTracker returns a tracker persisting to the rig's .runtime directory.
+func NewFlakyTracker(rigPath string) *FlakyTracker {
+	return &FlakyTracker{
+		path:       filepath.Join(rigPath, ".runtime", testHistoryFile),
+		ClearAfter: DefaultFlakyClearAfter,
+	}
+}
+
+// Path returns the history file path.
+func (t *FlakyTracker) Path() string {
+	return t.path
+}
+
+// Load reads the test history. A missing file yields an empty history.
+func (t *FlakyTracker) Load() (*TestHistory, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.load()
+}
+
+func (t *FlakyTracker) load() (*TestHistory, error) {
+	h := &TestHistory{Tests: make(map[string]*TestRecord)}
+	data, err := os.ReadFile(t.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return h, nil
+		}
+		return nil, fmt.Errorf("reading test history: %w", err)
+	}
+	if err := json.Unmarshal(data, h); err != nil {
+		return nil, fmt.Errorf("parsing test history: %w", err)
+	}
+	if h.Tests == nil {
+		h.Tests = make(map[string]*TestRecord)
+	}
+	return h, nil
+}
+
+// Record appends outcomes observed against tree and reclassifies the affected
+// tests. It returns the records of tests that became flaky with this call,
+// and of flaky tests that passed ClearAfter times in a row and no longer are.
+func (t *FlakyTracker) Record(tree string, outcomes []TestOutcome) (newlyFlaky, cleared []*TestRecord, err error) {
+	if len(outcomes) == 0 {
+		return nil, nil, nil
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	h, err := t.load()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now().UTC()
+	for _, o := range outcomes {
+		rec := h.Tests[o.Name]
+		if rec == nil {
+			rec = &TestRecord{Name: o.Name}
+			h.Tests[o.Name] = rec
+		}
+		rec.Runs = append(rec.Runs, TestRun{Tree: tree, Passed: o.Passed, At: now})
+		if len(rec.Runs) > maxTestRuns {
+			rec.Runs = rec.Runs[len(rec.Runs)-maxTestRuns:]
+		}
+		switch {
+		case !rec.Flaky && flippedOnSameTree(rec.Runs):
+			rec.Flaky = true
+			rec.FlakySince = now
+			newlyFlaky = append(newlyFlaky, rec)
+		case rec.Flaky && t.ClearAfter > 0 && passStreak(rec.Runs) >= t.ClearAfter:
+			// Keep only the streak: the old flip would reclassify it at once.
+			rec.Runs = rec.Runs[len(rec.Runs)-passStreak(rec.Runs):]
+			rec.Flaky = false
+			rec.FlakySince = time.Time{}
+			cleared = append(cleared, rec)
+		}
+	}
+
+	if err := t.save(h); err != nil {
+		return nil, nil, err
+	}
+	return newlyFlaky, cleared, nil
+}
+
+// SetBeadID records the bead filed for a flaky test.
+func (t *FlakyTracker) SetBeadID(name, beadID string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	h, err := t.load()
+	if err != nil {
+		return err
+	}
+	rec := h.Tests[name]
+	if rec == nil {
+		return fmt.Errorf("test %q not in history", name)
+	}
+	rec.BeadID = beadID
+	return t.save(h)
+}
+
+// Quarantined returns the subset of names currently classified as flaky.
+func (t *FlakyTracker) Quarantined(names []string) (map[string]bool, error) {
+	h, err := t.Load()
+	if err != nil {
+		return nil, err
+	}
+	quarantined := make(map[string]bool)
+	for _, name := range names {
+		if rec := h.Tests[name]; rec != nil && rec.Flaky {
+			quarantined[name] = true
+		}
+	}
+	return quarantined, nil
+}
+
+// FlakyTests returns all tests currently classified as flaky, sorted by name.
+func (h *TestHistory) FlakyTests() []*TestRecord {
+	var flaky []*TestRecord
+	for _, rec := range h.Tests {
+		if rec.Flaky {
+			flaky = append(flaky, rec)
+		}
+	}
+	sort.Slice(flaky, func(i, j int) bool { return flaky[i].Name < flaky[j].Name })
+	return flaky
+}
+
+func (t *FlakyTracker) save(h *TestHistory) error {
+	return util.EnsureDirAndWriteJSON(t.path, h)
+}
+
+// flippedOnSameTree reports whether any tree has both a passing and a
+// failing run — the defining signal of a flaky test.
+func flippedOnSameTree(runs []TestRun) bool {
+	type seen struct{ pass, fail bool }
+	byTree := make(map[string]*seen)
+	for _, r := range runs {
+		if r.Tree == "" {
+			continue
+		}
+		s := byTree[r.Tree]
+		if s == nil {
+			s = &seen{}
+			byTree[r.Tree] = s
+		}
+		if r.Passed {
+			s.pass = true
+		} else {
+			s.fail = true
+		}
+		if s.pass && s.fail {
+			return true
+		}
+	}
+	return false
+}
+
+// passStreak counts the passing runs at the end of runs.
+func passStreak(runs []TestRun) int {
+	n := 0
+	for i := len(runs) - 1; i >= 0 && runs[i].Passed; i-- {
+		n++
+	}
+	return n
+}
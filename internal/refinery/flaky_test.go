@@ -0,0 +1,136 @@
+package refinery
+
+import (
+	"testing"
+)
+
+func TestFlakyTracker_ClassifiesFlipOnSameTree(t *testing.T) {
+	tracker := NewFlakyTracker(t.TempDir())
+
+	newly, _, err := tracker.Record("tree1", []TestOutcome{{Name: "TestA", Passed: false}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(newly) != 0 {
+		t.Fatalf("single failure should not be flaky, got %d", len(newly))
+	}
+
+	// Passing on a different tree is a code change, not a flake.
+	newly, _, err = tracker.Record("tree2", []TestOutcome{{Name: "TestA", Passed: true}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(newly) != 0 {
+		t.Fatalf("flip across trees should not be flaky, got %d", len(newly))
+	}
+
+	newly, _, err = tracker.Record("tree1", []TestOutcome{{Name: "TestA", Passed: true}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(newly) != 1 || newly[0].Name != "TestA" {
+		t.Fatalf("expected TestA newly flaky, got %+v", newly)
+	}
+
+	// Already flaky: not reported again.
+	newly, _, err = tracker.Record("tree1", []TestOutcome{{Name: "TestA", Passed: false}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(newly) != 0 {
+		t.Errorf("already-flaky test reported again: %+v", newly)
+	}
+
+	q, err := tracker.Quarantined([]string{"TestA", "TestB"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !q["TestA"] || q["TestB"] {
+		t.Errorf("quarantined = %v, want only TestA", q)
+	}
+}
+
+func TestFlakyTracker_EmptyTreeNeverFlaky(t *testing.T) {
+	tracker := NewFlakyTracker(t.TempDir())
+	for _, passed := range []bool{false, true, false} {
+		newly, _, err := tracker.Record("", []TestOutcome{{Name: "TestA", Passed: passed}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(newly) != 0 {
+			t.Fatalf("runs without a tree must not classify as flaky")
+		}
+	}
+}
+
+func TestFlakyTracker_CapsRunsAndPersistsBead(t *testing.T) {
+	tracker := NewFlakyTracker(t.TempDir())
+	for i := 0; i < maxTestRuns+10; i++ {
+		if _, _, err := tracker.Record("t", []TestOutcome{{Name: "TestA", Passed: i%2 == 0}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tracker.SetBeadID("TestA", "gt-flaky1"); err != nil {
+		t.Fatal(err)
+	}
+
+	h, err := tracker.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec := h.Tests["TestA"]
+	if len(rec.Runs) != maxTestRuns {
+		t.Errorf("runs = %d, want %d", len(rec.Runs), maxTestRuns)
+	}
+	if rec.BeadID != "gt-flaky1" {
+		t.Errorf("BeadID = %q, want gt-flaky1", rec.BeadID)
+	}
+	if flaky := h.FlakyTests(); len(flaky) != 1 {
+		t.Errorf("FlakyTests() = %d, want 1", len(flaky))
+	}
+
+	if err := tracker.SetBeadID("TestMissing", "x"); err == nil {
+		t.Error("expected error for unknown test")
+	}
+}
+
+func TestFlakyTracker_ClearsAfterPassStreak(t *testing.T) {
+	tracker := NewFlakyTracker(t.TempDir())
+	tracker.ClearAfter = 3
+	record := func(passed bool) (newly, cleared []*TestRecord) {
+		t.Helper()
+		newly, cleared, err := tracker.Record("tree1", []TestOutcome{{Name: "TestA", Passed: passed}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return newly, cleared
+	}
+
+	record(false)
+	if newly, _ := record(true); len(newly) != 1 {
+		t.Fatalf("expected TestA newly flaky, got %+v", newly)
+	}
+	// The flip's own pass starts the streak; a failure resets it.
+	record(true)
+	record(false)
+	for i := 0; i < 2; i++ {
+		if _, cleared := record(true); len(cleared) != 0 {
+			t.Fatalf("cleared after %d passes, want 3", i+1)
+		}
+	}
+	if _, cleared := record(true); len(cleared) != 1 || cleared[0].Flaky {
+		t.Fatalf("expected TestA cleared after 3 passes, got %+v", cleared)
+	}
+	if q, _ := tracker.Quarantined([]string{"TestA"}); q["TestA"] {
+		t.Error("cleared test still quarantined")
+	}
+
+	// The flips from before don't count against it: failing on a new tree
+	// is a code change, while failing again on the passing tree is a flake.
+	if newly, _, err := tracker.Record("tree2", []TestOutcome{{Name: "TestA", Passed: false}}); err != nil || len(newly) != 0 {
+		t.Fatalf("failure on a new tree reclassified TestA: %+v, %v", newly, err)
+	}
+	if newly, _ := record(false); len(newly) != 1 {
+		t.Errorf("a new flip should classify TestA again, got %+v", newly)
+	}
+}
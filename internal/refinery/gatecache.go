@@ -0,0 +1,165 @@
+package refinery
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// gateCacheDir is the gate result cache location, relative to the rig's .runtime dir.
+const gateCacheDir = "refinery/gate-cache"
+
+// Gate cache defaults, used when the config leaves the limits unset.
+const (
+	DefaultGateCacheTTL        = 24 * time.Hour
+	DefaultGateCacheMaxEntries = 500
+)
+
+// GateCacheEntry is a cached gate result, stored as one JSON file per key.
+type GateCacheEntry struct {
+	Key            string        `json:"key"`
+	Gate           string        `json:"gate"`
+	Cmd            string        `json:"cmd"`
+	Tree           string        `json:"tree"`
+	EnvFingerprint string        `json:"env_fingerprint"`
+	Elapsed        time.Duration `json:"elapsed"`
+	Tests          []TestOutcome `json:"tests,omitempty"`
+	CreatedAt      time.Time     `json:"created_at"`
+}
+
+// GateCache is a content-addressed cache of passing gate results, keyed by
+// (gate command, tree SHA, environment fingerprint). Only passes are cached:
+// a failure might be flaky, and re-running it is what lets the refinery tell.
+type GateCache struct {
+	dir        string
+	ttl        time.Duration
+	maxEntries int
+	now        func() time.Time
+}
+
+// NewGateCache returns a cache stored under the rig's .runtime directory.
+// Non-positive limits fall back to the defaults.
+func NewGateCache(rigPath string, ttl time.Duration, maxEntries int) *GateCache {
+	if ttl <= 0 {
+		ttl = DefaultGateCacheTTL
+	}
+	if maxEntries <= 0 {
+		maxEntries = DefaultGateCacheMaxEntries
+	}
+	return &GateCache{
+		dir:        filepath.Join(rigPath, ".runtime", gateCacheDir),
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		now:        time.Now,
+	}
+}
+
+// GateCacheKey derives the cache key for a gate command run against tree
+// in the environment identified by envFingerprint.
+func GateCacheKey(cmd, tree, envFingerprint string) string {
+	h := sha256.New()
+	for _, part := range []string{cmd, tree, envFingerprint} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// EnvFingerprint hashes the parts of the environment that can change a
+// gate's outcome without changing the tree: the platform and the values of
+// the listed environment variables (e.g., toolchain versions, CI flags).
+func EnvFingerprint(envVars []string) string {
+	vars := append([]string(nil), envVars...)
+	sort.Strings(vars)
+	h := sha256.New()
+	_, _ = fmt.Fprintf(h, "%s/%s\n", runtime.GOOS, runtime.GOARCH)
+	for _, name := range vars {
+		_, _ = fmt.Fprintf(h, "%s=%s\n", name, os.Getenv(name))
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+func (c *GateCache) entryPath(key string) string {
+	return filepath.Join(c.dir, key+".json")
+}
+
+// Get returns the cached entry for key, or nil if absent or expired.
+func (c *GateCache) Get(key string) *GateCacheEntry {
+	data, err := os.ReadFile(c.entryPath(key))
+	if err != nil {
+		return nil
+	}
+	var entry GateCacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil
+	}
+	if c.now().Sub(entry.CreatedAt) > c.ttl {
+		_ = os.Remove(c.entryPath(key))
+		return nil
+	}
+	return &entry
+}
+
+// Put stores entry and then enforces the TTL and size limits.
+func (c *GateCache) Put(entry *GateCacheEntry) error {
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = c.now().UTC()
+	}
+	if err := util.EnsureDirAndWriteJSON(c.entryPath(entry.Key), entry); err != nil {
+		return fmt.Errorf("writing gate cache entry: %w", err)
+	}
+	return c.Prune()
+}
+
+// Prune removes expired entries, then the oldest entries beyond maxEntries.
+// Entries are aged by their CreatedAt, as in Get; unreadable ones are dropped.
+func (c *GateCache) Prune() error {
+	dirEntries, err := os.ReadDir(c.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("reading gate cache: %w", err)
+	}
+
+	type cached struct {
+		path      string
+		createdAt time.Time
+	}
+	var live []cached
+	now := c.now()
+	for _, de := range dirEntries {
+		if de.IsDir() || !strings.HasSuffix(de.Name(), ".json") {
+			continue
+		}
+		path := filepath.Join(c.dir, de.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		var entry GateCacheEntry
+		if err := json.Unmarshal(data, &entry); err != nil || now.Sub(entry.CreatedAt) > c.ttl {
+			_ = os.Remove(path)
+			continue
+		}
+		live = append(live, cached{path: path, createdAt: entry.CreatedAt})
+	}
+
+	if len(live) <= c.maxEntries {
+		return nil
+	}
+	sort.Slice(live, func(i, j int) bool { return live[i].createdAt.Before(live[j].createdAt) })
+	for _, e := range live[:len(live)-c.maxEntries] {
+		_ = os.Remove(e.path)
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package refinery
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestGateCache_PutGet(t *testing.T) {
+	c := NewGateCache(t.TempDir(), time.Hour, 10)
+	key := GateCacheKey("go test ./...", "tree1", EnvFingerprint(nil))
+
+	if c.Get(key) != nil {
+		t.Fatal("expected miss on empty cache")
+	}
+	if err := c.Put(&GateCacheEntry{Key: key, Gate: "test", Elapsed: time.Second}); err != nil {
+		t.Fatal(err)
+	}
+	entry := c.Get(key)
+	if entry == nil {
+		t.Fatal("expected hit after Put")
+	}
+	if entry.Gate != "test" || entry.CreatedAt.IsZero() {
+		t.Errorf("entry = %+v", entry)
+	}
+
+	// Expired entries are misses.
+	c.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	if c.Get(key) != nil {
+		t.Error("expected miss after TTL")
+	}
+}
+
+func TestGateCacheKey_DistinguishesInputs(t *testing.T) {
+	base := GateCacheKey("make test", "tree1", "env1")
+	for _, other := range []string{
+		GateCacheKey("make lint", "tree1", "env1"),
+		GateCacheKey("make test", "tree2", "env1"),
+		GateCacheKey("make test", "tree1", "env2"),
+	} {
+		if other == base {
+			t.Error("keys for different inputs must differ")
+		}
+	}
+}
+
+func TestEnvFingerprint_TracksListedVars(t *testing.T) {
+	t.Setenv("GT_TEST_CACHE_ENV", "a")
+	a := EnvFingerprint([]string{"GT_TEST_CACHE_ENV"})
+	t.Setenv("GT_TEST_CACHE_ENV", "b")
+	b := EnvFingerprint([]string{"GT_TEST_CACHE_ENV"})
+	if a == b {
+		t.Error("fingerprint should change when a listed variable changes")
+	}
+	if EnvFingerprint([]string{"X", "Y"}) != EnvFingerprint([]string{"Y", "X"}) {
+		t.Error("fingerprint should not depend on variable order")
+	}
+}
+
+func TestGateCache_PruneEnforcesSize(t *testing.T) {
+	rigPath := t.TempDir()
+	c := NewGateCache(rigPath, time.Hour, 2)
+	old := time.Now().Add(-30 * time.Minute)
+	for i, key := range []string{"k1", "k2", "k3"} {
+		if err := c.Put(&GateCacheEntry{Key: key, CreatedAt: old.Add(time.Duration(i) * time.Minute)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := c.Prune(); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := os.ReadDir(filepath.Join(rigPath, ".runtime", gateCacheDir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("entries = %d, want 2", len(entries))
+	}
+	if c.Get("k1") != nil {
+		t.Error("oldest entry should have been evicted")
+	}
+}
+
+func TestGateCache_PruneAgesByCreatedAt(t *testing.T) {
+	c := NewGateCache(t.TempDir(), time.Hour, 10)
+	if err := c.Put(&GateCacheEntry{Key: "stale", CreatedAt: time.Now().Add(-2 * time.Hour)}); err != nil {
+		t.Fatal(err)
+	}
+	// Freshly written, but created outside the TTL: Prune must agree with Get.
+	if _, err := os.Stat(c.entryPath("stale")); !os.IsNotExist(err) {
+		t.Errorf("entry created before the TTL survived Prune (stat err = %v)", err)
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/steveyegge/gastown/internal/rig"
 	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/transcript"
 )
 
 // Common errors
@@ -220,7 +221,11 @@ func (m *Manager) Stop() error {
 	if !hasSession {
 		return ErrNotRunning
 	}
-	return t.KillSession(sessionID)
+	if err := t.KillSession(sessionID); err != nil {
+		return err
+	}
+	transcript.EndSession(filepath.Dir(m.rig.Path), m.rig.Name+"/refinery", "")
+	return nil
 }
 
 // Queue returns the current merge queue.
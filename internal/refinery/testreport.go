@@ -0,0 +1,220 @@
+package refinery
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Test report formats understood by the refinery when a gate declares one.
+const (
+	// ReportGoJSON parses `go test -json` output from the gate's stdout.
+	ReportGoJSON = "go-json"
+
+	// ReportJUnit parses a JUnit XML file written by the gate (ReportPath).
+	ReportJUnit = "junit"
+)
+
+// TestOutcome is the pass/fail result of a single test within a gate run.
+type TestOutcome struct {
+	// Name is the fully qualified test name (e.g., "pkg/foo.TestBar/sub").
+	Name   string `json:"name"`
+	Passed bool   `json:"passed"`
+}
+
+// ValidReportFormat reports whether format is a supported test report format.
+// The empty string is valid and means the gate has no parseable report.
+func ValidReportFormat(format string) bool {
+	switch format {
+	case "", ReportGoJSON, ReportJUnit:
+		return true
+	}
+	return false
+}
+
+// ParseTestReport parses test output in the given format into per-test outcomes.
+// Outcomes are returned sorted by name for deterministic processing.
+func ParseTestReport(format string, data []byte) ([]TestOutcome, error) {
+	switch format {
+	case ReportGoJSON:
+		return parseGoTestJSON(data)
+	case ReportJUnit:
+		return parseJUnitXML(data)
+	default:
+		return nil, fmt.Errorf("unsupported test report format %q", format)
+	}
+}
+
+// goTestEvent is the subset of a `go test -json` (test2json) event we need.
+type goTestEvent struct {
+	Action  string `json:"Action"`
+	Package string `json:"Package"`
+	Test    string `json:"Test"`
+}
+
+// parseGoTestJSON extracts per-test outcomes from `go test -json` output.
+// Non-JSON lines (build errors, shell noise) are ignored. When a test reports
+// more than once (e.g., -count=2), any failure wins. A package that fails
+// without any failing test (build failure, TestMain panic) is reported as a
+// failed outcome named after the package so it can never be quarantined.
+func parseGoTestJSON(data []byte) ([]TestOutcome, error) {
+	type testKey struct{ pkg, test string }
+	tests := make(map[testKey]bool)
+	failedPkgs := make(map[string]bool)
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 || line[0] != '{' {
+			continue
+		}
+		var ev goTestEvent
+		if err := json.Unmarshal(line, &ev); err != nil {
+			continue
+		}
+		if ev.Action != "pass" && ev.Action != "fail" {
+			continue
+		}
+		if ev.Test == "" {
+			if ev.Action == "fail" && ev.Package != "" {
+				failedPkgs[ev.Package] = true
+			}
+			continue
+		}
+		key := testKey{ev.Package, ev.Test}
+		if prev, seen := tests[key]; seen && !prev {
+			continue
+		}
+		tests[key] = ev.Action == "pass"
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading go test output: %w", err)
+	}
+
+	// A parent test's result just mirrors its subtests; keeping it would
+	// classify the parent as flaky alongside its one flaky subtest.
+	var parents []testKey
+	for key := range tests {
+		for i, c := range key.test {
+			if c == '/' {
+				parents = append(parents, testKey{key.pkg, key.test[:i]})
+			}
+		}
+	}
+	for _, parent := range parents {
+		delete(tests, parent)
+	}
+
+	results := make(map[string]bool, len(tests))
+	pkgsWithFailedTests := make(map[string]bool)
+	for key, passed := range tests {
+		name := key.test
+		if key.pkg != "" {
+			name = key.pkg + "." + key.test
+		}
+		results[name] = passed
+		if !passed {
+			pkgsWithFailedTests[key.pkg] = true
+		}
+	}
+	for pkg := range failedPkgs {
+		if !pkgsWithFailedTests[pkg] {
+			results[pkg] = false
+		}
+	}
+	return sortedOutcomes(results), nil
+}
+
+// junitTestCase is a single <testcase> element.
+type junitTestCase struct {
+	Name      string    `xml:"name,attr"`
+	Classname string    `xml:"classname,attr"`
+	Failure   *struct{} `xml:"failure"`
+	Error     *struct{} `xml:"error"`
+	Skipped   *struct{} `xml:"skipped"`
+}
+
+// junitTestSuite is a <testsuite> element; suites may nest.
+type junitTestSuite struct {
+	Name      string           `xml:"name,attr"`
+	TestCases []junitTestCase  `xml:"testcase"`
+	Suites    []junitTestSuite `xml:"testsuite"`
+}
+
+// parseJUnitXML extracts per-test outcomes from a JUnit XML report.
+// Both <testsuites> and bare <testsuite> roots are accepted. Skipped tests
+// are omitted since they carry no pass/fail signal.
+func parseJUnitXML(data []byte) ([]TestOutcome, error) {
+	var root struct {
+		XMLName   xml.Name
+		Name      string           `xml:"name,attr"`
+		TestCases []junitTestCase  `xml:"testcase"`
+		Suites    []junitTestSuite `xml:"testsuite"`
+	}
+	if err := xml.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("parsing junit xml: %w", err)
+	}
+
+	results := make(map[string]bool)
+	var walk func(s junitTestSuite)
+	walk = func(s junitTestSuite) {
+		for _, tc := range s.TestCases {
+			if tc.Skipped != nil {
+				continue
+			}
+			prefix := tc.Classname
+			if prefix == "" {
+				prefix = s.Name
+			}
+			name := tc.Name
+			if prefix != "" {
+				name = prefix + "." + tc.Name
+			}
+			passed := tc.Failure == nil && tc.Error == nil
+			if prev, seen := results[name]; seen && !prev {
+				continue
+			}
+			results[name] = passed
+		}
+		for _, child := range s.Suites {
+			walk(child)
+		}
+	}
+	walk(junitTestSuite{Name: root.Name, TestCases: root.TestCases, Suites: root.Suites})
+
+	return sortedOutcomes(results), nil
+}
+
+// sortedOutcomes converts a name→passed map into a name-sorted slice.
+func sortedOutcomes(results map[string]bool) []TestOutcome {
+	outcomes := make([]TestOutcome, 0, len(results))
+	for name, passed := range results {
+		outcomes = append(outcomes, TestOutcome{Name: name, Passed: passed})
+	}
+	sort.Slice(outcomes, func(i, j int) bool { return outcomes[i].Name < outcomes[j].Name })
+	return outcomes
+}
+
+// failedTests returns the names of failed tests in outcomes.
+func failedTests(outcomes []TestOutcome) []string {
+	var failed []string
+	for _, o := range outcomes {
+		if !o.Passed {
+			failed = append(failed, o.Name)
+		}
+	}
+	return failed
+}
+
+// summarizeTestNames joins test names for log/error output, capping the list.
+func summarizeTestNames(names []string, limit int) string {
+	if len(names) <= limit {
+		return strings.Join(names, ", ")
+	}
+	return fmt.Sprintf("%s (+%d more)", strings.Join(names[:limit], ", "), len(names)-limit)
+}
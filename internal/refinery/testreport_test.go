@@ -0,0 +1,84 @@
+package refinery
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseTestReport_GoJSON(t *testing.T) {
+	out := `{"Action":"run","Package":"example.com/a","Test":"TestPass"}
+{"Action":"pass","Package":"example.com/a","Test":"TestPass"}
+{"Action":"fail","Package":"example.com/a","Test":"TestFail"}
+{"Action":"fail","Package":"example.com/a","Test":"TestParent/sub"}
+{"Action":"fail","Package":"example.com/a","Test":"TestParent"}
+not json: build noise
+{"Action":"fail","Package":"example.com/a"}
+{"Action":"fail","Package":"example.com/broken"}
+`
+	got, err := ParseTestReport(ReportGoJSON, []byte(out))
+	if err != nil {
+		t.Fatalf("ParseTestReport: %v", err)
+	}
+	want := []TestOutcome{
+		{Name: "example.com/a.TestFail", Passed: false},
+		{Name: "example.com/a.TestParent/sub", Passed: false},
+		{Name: "example.com/a.TestPass", Passed: true},
+		{Name: "example.com/broken", Passed: false},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("outcomes = %+v, want %+v", got, want)
+	}
+}
+
+func TestParseTestReport_GoJSON_FailureWinsOnRepeat(t *testing.T) {
+	out := `{"Action":"fail","Package":"p","Test":"TestX"}
+{"Action":"pass","Package":"p","Test":"TestX"}
+`
+	got, err := ParseTestReport(ReportGoJSON, []byte(out))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].Passed {
+		t.Errorf("expected single failed outcome, got %+v", got)
+	}
+}
+
+func TestParseTestReport_JUnit(t *testing.T) {
+	xml := `<?xml version="1.0"?>
+<testsuites>
+  <testsuite name="suite">
+    <testcase classname="pkg.Foo" name="ok"/>
+    <testcase classname="pkg.Foo" name="bad"><failure message="boom"/></testcase>
+    <testcase name="errored"><error/></testcase>
+    <testcase classname="pkg.Foo" name="skipped"><skipped/></testcase>
+    <testsuite name="nested">
+      <testcase name="inner"/>
+    </testsuite>
+  </testsuite>
+</testsuites>`
+	got, err := ParseTestReport(ReportJUnit, []byte(xml))
+	if err != nil {
+		t.Fatalf("ParseTestReport: %v", err)
+	}
+	want := []TestOutcome{
+		{Name: "nested.inner", Passed: true},
+		{Name: "pkg.Foo.bad", Passed: false},
+		{Name: "pkg.Foo.ok", Passed: true},
+		{Name: "suite.errored", Passed: false},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("outcomes = %+v, want %+v", got, want)
+	}
+}
+
+func TestParseTestReport_UnknownFormat(t *testing.T) {
+	if _, err := ParseTestReport("tap", nil); err == nil {
+		t.Error("expected error for unsupported format")
+	}
+	if ValidReportFormat("tap") {
+		t.Error("tap should not be a valid format")
+	}
+	if !ValidReportFormat("") {
+		t.Error("empty format should be valid")
+	}
+}
@@ -0,0 +1,165 @@
+package sandbox
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Proxy is an HTTP proxy that only connects to allowlisted hosts. It
+// handles CONNECT tunnels (HTTPS, git over HTTPS) and plain HTTP requests.
+type Proxy struct {
+	// Allow entries are "host", "host:port", or "*.domain" (subdomains only).
+	Allow []string
+
+	// Logf, if set, receives one line per denied request.
+	Logf func(format string, args ...interface{})
+
+	transport http.RoundTripper
+	once      sync.Once
+}
+
+// Allowed reports whether host:port may be reached.
+func (p *Proxy) Allowed(host, port string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	for _, entry := range p.Allow {
+		entry = strings.ToLower(entry)
+		entryHost, entryPort := entry, ""
+		if h, pt, err := net.SplitHostPort(entry); err == nil {
+			entryHost, entryPort = h, pt
+		}
+		if entryPort != "" && entryPort != port {
+			continue
+		}
+		if suffix, ok := strings.CutPrefix(entryHost, "*."); ok {
+			if strings.HasSuffix(host, "."+suffix) {
+				return true
+			}
+		} else if host == entryHost {
+			return true
+		}
+	}
+	return false
+}
+
+// Serve accepts proxy connections on l until it is closed.
+func (p *Proxy) Serve(l net.Listener) error {
+	srv := &http.Server{Handler: p, ReadHeaderTimeout: 30 * time.Second}
+	return srv.Serve(l)
+}
+
+// ServeHTTP implements http.Handler.
+func (p *Proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	host, port := r.URL.Hostname(), r.URL.Port()
+	if r.Method == http.MethodConnect {
+		host, port, _ = net.SplitHostPort(r.Host)
+	} else if port == "" {
+		port = "80"
+		if r.URL.Scheme == "https" {
+			port = "443"
+		}
+	}
+	if host == "" || !p.Allowed(host, port) {
+		if p.Logf != nil {
+			p.Logf("sandbox proxy: denied %s %s", r.Method, net.JoinHostPort(host, port))
+		}
+		http.Error(w, "blocked by gt sandbox: "+host+" is not in allow_hosts", http.StatusForbidden)
+		return
+	}
+
+	if r.Method == http.MethodConnect {
+		p.tunnel(w, net.JoinHostPort(host, port))
+		return
+	}
+	p.forward(w, r)
+}
+
+func (p *Proxy) tunnel(w http.ResponseWriter, addr string) {
+	upstream, err := net.DialTimeout("tcp", addr, 30*time.Second)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		_ = upstream.Close()
+		http.Error(w, "hijacking not supported", http.StatusInternalServerError)
+		return
+	}
+	client, buf, err := hijacker.Hijack()
+	if err != nil {
+		_ = upstream.Close()
+		return
+	}
+	_, _ = client.Write([]byte("HTTP/1.1 200 Connection Established\r\n\r\n"))
+	// Bytes the client sent after the CONNECT header belong to the tunnel.
+	if n := buf.Reader.Buffered(); n > 0 {
+		pending, _ := buf.Reader.Peek(n)
+		_, _ = upstream.Write(pending)
+	}
+	pipe(client, upstream)
+}
+
+func (p *Proxy) forward(w http.ResponseWriter, r *http.Request) {
+	p.once.Do(func() {
+		if p.transport == nil {
+			// No Proxy func: never chain to an outer proxy from the environment.
+			p.transport = &http.Transport{}
+		}
+	})
+
+	out := r.Clone(r.Context())
+	out.RequestURI = ""
+	out.Header.Del("Proxy-Connection")
+	out.Header.Del("Proxy-Authorization")
+	resp, err := p.transport.RoundTrip(out)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	for k, vs := range resp.Header {
+		for _, v := range vs {
+			w.Header().Add(k, v)
+		}
+	}
+	w.WriteHeader(resp.StatusCode)
+	_, _ = io.Copy(w, resp.Body)
+}
+
+// Forward accepts connections on l and bridges each one to a connection
+// from dial, until l is closed.
+func Forward(l net.Listener, dial func() (net.Conn, error)) error {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return err
+		}
+		go func() {
+			upstream, err := dial()
+			if err != nil {
+				_ = conn.Close()
+				return
+			}
+			pipe(conn, upstream)
+		}()
+	}
+}
+
+// pipe copies in both directions and closes both ends when either side is done.
+func pipe(a, b net.Conn) {
+	done := make(chan struct{}, 2)
+	cp := func(dst, src net.Conn) {
+		_, _ = io.Copy(dst, src)
+		done <- struct{}{}
+	}
+	go cp(a, b)
+	go cp(b, a)
+	<-done
+	_ = a.Close()
+	_ = b.Close()
+}
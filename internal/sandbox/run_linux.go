@@ -0,0 +1,501 @@
+//go:build linux
+
+package sandbox
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/events"
+)
+
+// Supported reports whether unprivileged user namespaces are available.
+func Supported() bool {
+	if _, err := os.Stat("/proc/self/ns/user"); err != nil {
+		return false
+	}
+	for _, knob := range []string{"/proc/sys/user/max_user_namespaces", "/proc/sys/kernel/unprivileged_userns_clone"} {
+		if data, err := os.ReadFile(knob); err == nil && strings.TrimSpace(string(data)) == "0" {
+			return false
+		}
+	}
+	return true
+}
+
+// Run starts argv under gt sandbox init in new namespaces and waits for it.
+// It serves the events relay, egress proxy and port forwards for the
+// session's lifetime and returns the child's exit code.
+func Run(p *Policy, argv []string) (int, error) {
+	if p.SocketDir != "" {
+		relay, err := listenUnix(p.EventsSocket())
+		if err != nil {
+			return 1, err
+		}
+		defer func() { _ = relay.Close() }()
+		go func() { _ = events.ServeRelay(relay, p.TownRoot) }()
+	}
+
+	if p.Isolated() {
+		if p.Network == config.SandboxNetworkAllowlist {
+			l, err := listenUnix(p.ProxySocket())
+			if err != nil {
+				return 1, err
+			}
+			defer func() { _ = l.Close() }()
+			proxy := &Proxy{Allow: p.AllowHosts, Logf: deniedLogger(p.SocketDir)}
+			go func() { _ = proxy.Serve(l) }()
+		}
+		for _, port := range p.ForwardPorts {
+			l, err := listenUnix(p.PortSocket(port))
+			if err != nil {
+				return 1, err
+			}
+			defer func() { _ = l.Close() }()
+			addr := net.JoinHostPort("127.0.0.1", strconv.Itoa(port))
+			go func() {
+				_ = Forward(l, func() (net.Conn, error) { return net.DialTimeout("tcp", addr, 10*time.Second) })
+			}()
+		}
+	}
+
+	data, err := json.Marshal(p)
+	if err != nil {
+		return 1, err
+	}
+	self, err := os.Executable()
+	if err != nil {
+		return 1, fmt.Errorf("finding gt executable: %w", err)
+	}
+
+	// A PID namespace keeps host processes (this one, the tmux server) out
+	// of reach: their /proc/<pid>/root would lead back to the host mounts.
+	flags := uintptr(syscall.CLONE_NEWUSER | syscall.CLONE_NEWNS | syscall.CLONE_NEWPID)
+	if p.Isolated() {
+		flags |= syscall.CLONE_NEWNET
+	}
+	cmd := exec.Command(self, append([]string{"sandbox", "init", "--"}, argv...)...) //nolint:gosec // G204: re-exec of gt itself
+	cmd.Env = append(os.Environ(), EnvPolicy+"="+string(data))
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Cloneflags:                 flags,
+		UidMappings:                []syscall.SysProcIDMap{{ContainerID: 0, HostID: p.UID, Size: 1}},
+		GidMappings:                []syscall.SysProcIDMap{{ContainerID: 0, HostID: p.GID, Size: 1}},
+		GidMappingsEnableSetgroups: false,
+		Pdeathsig:                  syscall.SIGKILL,
+	}
+	return runChild(cmd)
+}
+
+// Init runs inside the namespaces created by Run: it builds the mount view
+// and loopback bridges, then runs argv as the real uid and waits for it.
+// Init is PID 1 of the session's PID namespace, so it also reaps orphaned
+// descendants, and everything left in the namespace dies when it exits.
+func Init(argv []string) (int, error) {
+	data := os.Getenv(EnvPolicy)
+	_ = os.Unsetenv(EnvPolicy)
+	if data == "" {
+		return 1, errors.New("gt sandbox init must be started by gt sandbox run")
+	}
+	var p Policy
+	if err := json.Unmarshal([]byte(data), &p); err != nil {
+		return 1, fmt.Errorf("decoding sandbox policy: %w", err)
+	}
+
+	if err := setupMounts(&p); err != nil {
+		return 1, fmt.Errorf("setting up mounts: %w", err)
+	}
+
+	env := os.Environ()
+	if p.SocketDir != "" {
+		env = append(env, events.EnvRelay+"="+p.EventsSocket())
+	}
+	if p.Isolated() {
+		if err := loopbackUp(); err != nil {
+			return 1, fmt.Errorf("bringing up loopback: %w", err)
+		}
+		for _, port := range p.ForwardPorts {
+			l, err := net.Listen("tcp", net.JoinHostPort("127.0.0.1", strconv.Itoa(port)))
+			if err != nil {
+				return 1, err
+			}
+			sock := p.PortSocket(port)
+			go func() { _ = Forward(l, func() (net.Conn, error) { return net.Dial("unix", sock) }) }()
+		}
+		if p.Network == config.SandboxNetworkAllowlist {
+			l, err := net.Listen("tcp", "127.0.0.1:0")
+			if err != nil {
+				return 1, err
+			}
+			sock := p.ProxySocket()
+			go func() { _ = Forward(l, func() (net.Conn, error) { return net.Dial("unix", sock) }) }()
+			for k, v := range ProxyEnv(l.Addr().String()) {
+				env = append(env, k+"="+v)
+			}
+		}
+	}
+
+	path, err := exec.LookPath(argv[0])
+	if err != nil {
+		return 127, err
+	}
+	cmd := exec.Command(path, argv[1:]...) //nolint:gosec // G204: argv is the session's own start command
+	cmd.Dir = p.WorkDir
+	cmd.Env = env
+	// A nested user namespace maps the real uid back, so the agent is not
+	// root here and holds no capabilities over the mounts above.
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Cloneflags:                 syscall.CLONE_NEWUSER,
+		UidMappings:                []syscall.SysProcIDMap{{ContainerID: p.UID, HostID: 0, Size: 1}},
+		GidMappings:                []syscall.SysProcIDMap{{ContainerID: p.GID, HostID: 0, Size: 1}},
+		GidMappingsEnableSetgroups: false,
+		Pdeathsig:                  syscall.SIGKILL,
+	}
+	return runInit(cmd)
+}
+
+// runInit is runChild for PID 1: it waits for any child, reaping orphans
+// reparented to it, until cmd itself exits.
+func runInit(cmd *exec.Cmd) (int, error) {
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := cmd.Start(); err != nil {
+		return 1, err
+	}
+
+	sigs := make(chan os.Signal, 4)
+	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
+	defer signal.Stop(sigs)
+	go func() {
+		for sig := range sigs {
+			_ = cmd.Process.Signal(sig)
+		}
+	}()
+
+	for {
+		var ws unix.WaitStatus
+		pid, err := unix.Wait4(-1, &ws, 0, nil)
+		if err == unix.EINTR {
+			continue
+		}
+		if err != nil {
+			return 1, err
+		}
+		if pid != cmd.Process.Pid {
+			continue
+		}
+		if ws.Signaled() {
+			return 128 + int(ws.Signal()), nil
+		}
+		return ws.ExitStatus(), nil
+	}
+}
+
+// runChild runs cmd with inherited stdio, forwarding termination signals,
+// and returns its exit code (128+signal when killed by a signal).
+func runChild(cmd *exec.Cmd) (int, error) {
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := cmd.Start(); err != nil {
+		return 1, err
+	}
+
+	sigs := make(chan os.Signal, 4)
+	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
+	defer signal.Stop(sigs)
+	go func() {
+		for sig := range sigs {
+			_ = cmd.Process.Signal(sig)
+		}
+	}()
+
+	err := cmd.Wait()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		if ws, ok := exitErr.Sys().(syscall.WaitStatus); ok && ws.Signaled() {
+			return 128 + int(ws.Signal()), nil
+		}
+		return exitErr.ExitCode(), nil
+	}
+	if err != nil {
+		return 1, err
+	}
+	return 0, nil
+}
+
+func listenUnix(path string) (net.Listener, error) {
+	_ = os.Remove(path) // stale socket from a previous session
+	l, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(path, 0600); err != nil {
+		_ = l.Close()
+		return nil, err
+	}
+	return l, nil
+}
+
+// deniedLogger appends denied proxy requests to <socketDir>/denied.log,
+// keeping them out of the agent's terminal.
+func deniedLogger(dir string) func(string, ...interface{}) {
+	return func(format string, args ...interface{}) {
+		f, err := os.OpenFile(filepath.Join(dir, "denied.log"), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600) //nolint:gosec // G304: path is constructed internally
+		if err != nil {
+			return
+		}
+		defer func() { _ = f.Close() }()
+		_, _ = fmt.Fprintf(f, "%s "+format+"\n", append([]interface{}{time.Now().Format(time.RFC3339)}, args...)...)
+	}
+}
+
+// setupMounts builds the session's view of /proc and $HOME. Runs as root
+// in fresh user+mount+pid namespaces, so nothing here is visible outside
+// the sandbox.
+func setupMounts(p *Policy) error {
+	if err := unix.Mount("", "/", "", unix.MS_REC|unix.MS_PRIVATE, ""); err != nil {
+		return fmt.Errorf("making / private: %w", err)
+	}
+	// Cover the host procfs with one for the session's PID namespace.
+	if err := unix.Mount("proc", "/proc", "proc", unix.MS_NOSUID|unix.MS_NODEV|unix.MS_NOEXEC, ""); err != nil {
+		return fmt.Errorf("mounting /proc: %w", err)
+	}
+	if p.HomeMode == config.SandboxHomeHidden {
+		if err := hideHome(p); err != nil {
+			return err
+		}
+	} else if err := readOnlyHome(p); err != nil {
+		return err
+	}
+
+	// Read-only paths outside $HOME (a repo's git config and hooks, the
+	// town's .runtime) are protected whatever the home mode, with any
+	// writable paths inside them mounted back on top.
+	return mountEntries(outsideEntries(p))
+}
+
+// outsideEntries lists the policy's read-only paths outside $HOME and the
+// writable paths inside them, parents before children.
+func outsideEntries(p *Policy) []mountEntry {
+	var entries []mountEntry
+	var roots []string
+	for _, path := range p.ReadOnly {
+		if !within(path, p.Home) {
+			entries = append(entries, mountEntry{path, path, true})
+			roots = append(roots, path)
+		}
+	}
+	for _, path := range p.Writable {
+		if within(path, p.Home) {
+			continue
+		}
+		for _, root := range roots {
+			if path != root && within(path, root) {
+				entries = append(entries, mountEntry{path, path, false})
+				break
+			}
+		}
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].path < entries[j].path })
+	return entries
+}
+
+// mountEntry is one path in the session's view of $HOME and where its
+// contents come from.
+type mountEntry struct {
+	path     string
+	src      string
+	readOnly bool
+}
+
+// homeEntries lists the policy's paths under $HOME, parents before
+// children so inner holes are mounted on top.
+func homeEntries(p *Policy) []mountEntry {
+	var entries []mountEntry
+	for _, b := range p.Private {
+		entries = append(entries, mountEntry{b.Path, b.Source, false})
+	}
+	for _, path := range p.Writable {
+		if within(path, p.Home) {
+			entries = append(entries, mountEntry{path, path, false})
+		}
+	}
+	for _, path := range p.ReadOnly {
+		if within(path, p.Home) {
+			entries = append(entries, mountEntry{path, path, true})
+		}
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].path < entries[j].path })
+	return entries
+}
+
+// readOnlyHome binds $HOME onto itself, mounts the policy's paths on top,
+// then makes only the top mount read-only so the holes stay writable.
+func readOnlyHome(p *Policy) error {
+	if err := bind(p.Home, p.Home); err != nil {
+		return err
+	}
+	if err := mountEntries(homeEntries(p)); err != nil {
+		return err
+	}
+	return remountReadOnly(p.Home)
+}
+
+// mountEntries binds each entry's source over its path, parents first.
+// Sources are opened before anything is mounted: a private copy covers
+// the real paths beneath it, and a writable path inside a read-only one
+// must be bound from the original, writable mount.
+func mountEntries(entries []mountEntry) error {
+	fds := make([]int, len(entries))
+	for i, e := range entries {
+		fd, err := unix.Open(e.src, unix.O_PATH|unix.O_CLOEXEC, 0)
+		if err != nil {
+			return fmt.Errorf("opening %s: %w", e.src, err)
+		}
+		defer func() { _ = unix.Close(fd) }()
+		fds[i] = fd
+	}
+	for i, e := range entries {
+		if err := bind("/proc/self/fd/"+strconv.Itoa(fds[i]), e.path); err != nil {
+			return err
+		}
+		if e.readOnly {
+			if err := remountReadOnly(e.path); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// hideHome replaces $HOME with an empty tmpfs holding only the policy's
+// paths. Those are bound to a staging tmpfs first, since the originals
+// become unreachable once $HOME is covered.
+func hideHome(p *Policy) error {
+	keeps := homeEntries(p)
+
+	staging, err := os.MkdirTemp("", "gt-sandbox-")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(staging) }()
+	if err := unix.Mount("tmpfs", staging, "tmpfs", 0, "mode=0700"); err != nil {
+		return fmt.Errorf("mounting staging tmpfs: %w", err)
+	}
+	defer func() { _ = unix.Unmount(staging, unix.MNT_DETACH) }()
+
+	for i, k := range keeps {
+		dst := filepath.Join(staging, strconv.Itoa(i))
+		if err := mountpoint(k.src, dst); err != nil {
+			return err
+		}
+		if err := bind(k.src, dst); err != nil {
+			return err
+		}
+	}
+
+	if err := unix.Mount("tmpfs", p.Home, "tmpfs", unix.MS_NOSUID|unix.MS_NODEV, "mode=0755"); err != nil {
+		return fmt.Errorf("mounting tmpfs on %s: %w", p.Home, err)
+	}
+
+	for i, k := range keeps {
+		src := filepath.Join(staging, strconv.Itoa(i))
+		if err := mountpoint(src, k.path); err != nil {
+			return err
+		}
+		if err := unix.Mount(src, k.path, "", unix.MS_MOVE, ""); err != nil {
+			return fmt.Errorf("moving %s into place: %w", k.path, err)
+		}
+		if k.readOnly {
+			if err := remountReadOnly(k.path); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func bind(src, dst string) error {
+	if err := unix.Mount(src, dst, "", unix.MS_BIND|unix.MS_REC, ""); err != nil {
+		return fmt.Errorf("binding %s: %w", src, err)
+	}
+	return nil
+}
+
+// remountReadOnly makes the mount at path read-only. Flags the kernel has
+// locked for this user namespace (nosuid, nodev, ...) must be repeated or
+// the remount is refused.
+func remountReadOnly(path string) error {
+	var st unix.Statfs_t
+	if err := unix.Statfs(path, &st); err != nil {
+		return err
+	}
+	flags := uintptr(unix.MS_REMOUNT | unix.MS_BIND | unix.MS_RDONLY)
+	for stFlag, msFlag := range map[int64]uintptr{
+		unix.ST_NOSUID:     unix.MS_NOSUID,
+		unix.ST_NODEV:      unix.MS_NODEV,
+		unix.ST_NOEXEC:     unix.MS_NOEXEC,
+		unix.ST_NOATIME:    unix.MS_NOATIME,
+		unix.ST_NODIRATIME: unix.MS_NODIRATIME,
+		unix.ST_RELATIME:   unix.MS_RELATIME,
+	} {
+		if int64(st.Flags)&stFlag != 0 { // Flags is int32 on 32-bit platforms
+			flags |= msFlag
+		}
+	}
+	if err := unix.Mount("", path, "", flags, ""); err != nil {
+		return fmt.Errorf("remounting %s read-only: %w", path, err)
+	}
+	return nil
+}
+
+// mountpoint creates dst as an empty directory or file matching src's type.
+func mountpoint(src, dst string) error {
+	info, err := os.Stat(src)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Lstat(dst); err == nil {
+		return nil // may sit under a read-only mount already
+	}
+	if info.IsDir() {
+		return os.MkdirAll(dst, 0755)
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY, 0644) //nolint:gosec // G304: mount point inside the sandbox
+	if err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+// loopbackUp brings up lo in a fresh network namespace.
+func loopbackUp() error {
+	fd, err := unix.Socket(unix.AF_INET, unix.SOCK_DGRAM|unix.SOCK_CLOEXEC, 0)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = unix.Close(fd) }()
+
+	ifr, err := unix.NewIfreq("lo")
+	if err != nil {
+		return err
+	}
+	if err := unix.IoctlIfreq(fd, unix.SIOCGIFFLAGS, ifr); err != nil {
+		return err
+	}
+	ifr.SetUint16(ifr.Uint16() | unix.IFF_UP)
+	return unix.IoctlIfreq(fd, unix.SIOCSIFFLAGS, ifr)
+}
@@ -0,0 +1,335 @@
+//go:build linux
+
+package sandbox
+
+import (
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/events"
+)
+
+// TestMain lets Run re-exec the test binary as "gt sandbox init", and
+// as a gt that logs one event ("log-event <town>") inside the sandbox.
+func TestMain(m *testing.M) {
+	if len(os.Args) > 3 && os.Args[1] == "sandbox" && os.Args[2] == "init" {
+		code, err := Init(os.Args[4:])
+		if err != nil {
+			os.Stderr.WriteString(err.Error() + "\n")
+		}
+		os.Exit(code)
+	}
+	if len(os.Args) == 3 && os.Args[1] == "log-event" {
+		if err := events.LogFeedIn(os.Args[2], "relayed", "polecat", nil); err != nil {
+			os.Stderr.WriteString(err.Error() + "\n")
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+func requireUserNS(t *testing.T) {
+	t.Helper()
+	if !Supported() {
+		t.Skip("user namespaces unavailable")
+	}
+	cmd := exec.Command("true")
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Cloneflags:  syscall.CLONE_NEWUSER | syscall.CLONE_NEWNS | syscall.CLONE_NEWPID | syscall.CLONE_NEWNET,
+		UidMappings: []syscall.SysProcIDMap{{ContainerID: 0, HostID: os.Getuid(), Size: 1}},
+		GidMappings: []syscall.SysProcIDMap{{ContainerID: 0, HostID: os.Getgid(), Size: 1}},
+	}
+	if err := cmd.Run(); err != nil {
+		t.Skipf("cannot create namespaces here: %v", err)
+	}
+}
+
+func sandboxHome(t *testing.T) (home, work, secret string) {
+	t.Helper()
+	home = t.TempDir()
+	work = filepath.Join(home, "gt", "rig", "polecats", "Toast", "rig")
+	if err := os.MkdirAll(work, 0755); err != nil {
+		t.Fatal(err)
+	}
+	secret = filepath.Join(home, ".ssh", "id_ed25519")
+	if err := os.MkdirAll(filepath.Dir(secret), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(secret, []byte("KEY"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return home, work, secret
+}
+
+func TestRunReadOnlyHome(t *testing.T) {
+	requireUserNS(t)
+	home, work, secret := sandboxHome(t)
+
+	p := &Policy{Home: home, HomeMode: config.SandboxHomeReadOnly, WorkDir: work,
+		Writable: []string{work}, Network: config.SandboxNetworkNone, UID: os.Getuid(), GID: os.Getgid()}
+	script := `touch ok && cat "$1" >/dev/null && ! touch "$2" 2>/dev/null && [ "$(id -u)" = "$3" ]`
+	code, err := Run(p, []string{"sh", "-c", script, "sh", secret, filepath.Join(home, "new"), strconv.Itoa(os.Getuid())})
+	if err != nil || code != 0 {
+		t.Fatalf("Run = %d, %v", code, err)
+	}
+	if _, err := os.Stat(filepath.Join(work, "ok")); err != nil {
+		t.Errorf("write to worktree did not land: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(home, "new")); err == nil {
+		t.Error("write outside worktree landed in $HOME")
+	}
+}
+
+func TestRunProcDoesNotReachHost(t *testing.T) {
+	requireUserNS(t)
+	home, work, _ := sandboxHome(t)
+
+	p := &Policy{Home: home, HomeMode: config.SandboxHomeReadOnly, WorkDir: work,
+		Writable: []string{work}, Network: config.SandboxNetworkHost, UID: os.Getuid(), GID: os.Getgid()}
+	// $1 is this test process, the sandbox's host-side parent. Neither it
+	// nor PID 1 (gt sandbox init) may lead to a writable host $HOME.
+	script := `[ ! -e "/proc/$1" ] &&
+		tr '\0' ' ' </proc/1/cmdline | grep -q "sandbox init" &&
+		! touch "/proc/$1/root$2" 2>/dev/null &&
+		! touch "/proc/1/root$2" 2>/dev/null`
+	escape := filepath.Join(home, "escape")
+	code, err := Run(p, []string{"sh", "-c", script, "sh", strconv.Itoa(os.Getpid()), escape})
+	if err != nil || code != 0 {
+		t.Fatalf("Run = %d, %v", code, err)
+	}
+	if _, err := os.Stat(escape); err == nil {
+		t.Error("write through /proc/<pid>/root landed in the host $HOME")
+	}
+}
+
+func TestRunHiddenHome(t *testing.T) {
+	requireUserNS(t)
+	home, work, secret := sandboxHome(t)
+	bin := filepath.Join(home, "bin", "tool")
+	if err := os.MkdirAll(filepath.Dir(bin), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(bin, []byte("#!/bin/sh\necho tool\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	p := &Policy{Home: home, HomeMode: config.SandboxHomeHidden, WorkDir: work,
+		Writable: []string{work}, ReadOnly: []string{bin}, Network: config.SandboxNetworkHost,
+		UID: os.Getuid(), GID: os.Getgid()}
+	script := `touch ok && [ ! -e "$1" ] && [ "$("$2")" = tool ] && ! touch "$2" 2>/dev/null`
+	code, err := Run(p, []string{"sh", "-c", script, "sh", secret, bin})
+	if err != nil || code != 0 {
+		t.Fatalf("Run = %d, %v", code, err)
+	}
+	if data, _ := os.ReadFile(secret); !strings.Contains(string(data), "KEY") {
+		t.Error("host secret was modified")
+	}
+}
+
+func TestRunExitCode(t *testing.T) {
+	requireUserNS(t)
+	home, work, _ := sandboxHome(t)
+	p := &Policy{Home: home, HomeMode: config.SandboxHomeReadOnly, WorkDir: work,
+		Writable: []string{work}, Network: config.SandboxNetworkHost, UID: os.Getuid(), GID: os.Getgid()}
+	if code, err := Run(p, []string{"sh", "-c", "exit 7"}); err != nil || code != 7 {
+		t.Errorf("Run = %d, %v; want 7", code, err)
+	}
+}
+
+func TestRunForwardsLoopbackPort(t *testing.T) {
+	requireUserNS(t)
+	bash, err := exec.LookPath("bash")
+	if err != nil {
+		t.Skip("bash not available")
+	}
+	home, work, _ := sandboxHome(t)
+
+	echo, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = echo.Close() }()
+	go func() {
+		for {
+			c, err := echo.Accept()
+			if err != nil {
+				return
+			}
+			go func() { _, _ = io.Copy(c, c); _ = c.Close() }()
+		}
+	}()
+	port := echo.Addr().(*net.TCPAddr).Port
+
+	p := &Policy{Home: home, HomeMode: config.SandboxHomeReadOnly, WorkDir: work,
+		Writable: []string{work}, Network: config.SandboxNetworkNone, ForwardPorts: []int{port},
+		SocketDir: t.TempDir(), UID: os.Getuid(), GID: os.Getgid()}
+	script := `exec 3<>/dev/tcp/127.0.0.1/$1 && echo hi >&3 && read -r line <&3 && [ "$line" = hi ]`
+	if code, err := Run(p, []string{bash, "-c", script, "bash", strconv.Itoa(port)}); err != nil || code != 0 {
+		t.Errorf("forwarded port: Run = %d, %v", code, err)
+	}
+}
+
+func TestRunSharedGitAndClaudeState(t *testing.T) {
+	requireUserNS(t)
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	for _, mode := range []string{config.SandboxHomeReadOnly, config.SandboxHomeHidden} {
+		t.Run(mode, func(t *testing.T) {
+			home := t.TempDir()
+			t.Setenv("HOME", home)
+			town := filepath.Join(home, "gt")
+			rigPath := filepath.Join(town, "rig")
+			repo := filepath.Join(rigPath, ".repo.git")
+			work := filepath.Join(rigPath, "polecats", "Toast", "rig")
+			git := func(args ...string) {
+				t.Helper()
+				cmd := exec.Command("git", append([]string{"-c", "user.name=t", "-c", "user.email=t@t"}, args...)...)
+				if out, err := cmd.CombinedOutput(); err != nil {
+					t.Fatalf("git %v: %v\n%s", args, err, out)
+				}
+			}
+			git("init", "-q", "--bare", "-b", "main", repo)
+			seed := filepath.Join(t.TempDir(), "seed")
+			git("clone", "-q", repo, seed)
+			git("-C", seed, "commit", "-q", "--allow-empty", "-m", "init")
+			git("-C", seed, "push", "-q", "origin", "main")
+			git("-C", repo, "worktree", "add", "-q", "-b", "polecat/Toast", work, "main")
+
+			settings := filepath.Join(home, ".claude", "settings.json")
+			projects := filepath.Join(home, ".claude", "projects")
+			if err := os.MkdirAll(projects, 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(settings, []byte("{}"), 0600); err != nil {
+				t.Fatal(err)
+			}
+
+			p, err := Resolve(&config.SandboxConfig{Enabled: true, Home: mode}, town, rigPath, work)
+			if err != nil {
+				t.Fatalf("Resolve: %v", err)
+			}
+			script := `git -c user.name=t -c user.email=t@t commit -q --allow-empty -m work &&
+				! sh -c 'echo "[core]" >>"$1"' sh "$1" 2>/dev/null &&
+				! touch "$2/post-commit" 2>/dev/null &&
+				echo '{"hooks":1}' >"$3" &&
+				touch "$4/transcript"`
+			code, err := Run(p, []string{"sh", "-c", script, "sh",
+				filepath.Join(repo, "config"), filepath.Join(repo, "hooks"), settings, projects})
+			if err != nil || code != 0 {
+				t.Fatalf("Run = %d, %v", code, err)
+			}
+
+			if out, err := exec.Command("git", "-C", repo, "log", "--format=%s", "-1", "polecat/Toast").Output(); err != nil || strings.TrimSpace(string(out)) != "work" {
+				t.Errorf("commit from the sandbox did not land: %q, %v", out, err)
+			}
+			if data, _ := os.ReadFile(settings); string(data) != "{}" {
+				t.Errorf("sandbox rewrote the host's Claude settings: %s", data)
+			}
+			if _, err := os.Stat(filepath.Join(projects, "transcript")); err != nil {
+				t.Errorf("transcript did not reach the host: %v", err)
+			}
+		})
+	}
+}
+
+func TestRunRuntimeStateReadOnly(t *testing.T) {
+	requireUserNS(t)
+	for _, outside := range []bool{false, true} {
+		t.Run(map[bool]string{false: "town in home", true: "town outside home"}[outside], func(t *testing.T) {
+			home := t.TempDir()
+			t.Setenv("HOME", home)
+			town := filepath.Join(home, "gt")
+			if outside {
+				town = filepath.Join(t.TempDir(), "gt")
+			}
+			rigPath := filepath.Join(town, "rig")
+			work := filepath.Join(rigPath, "polecats", "Toast", "rig")
+			hooks := filepath.Join(rigPath, ".runtime", "setup-hooks")
+			gateCache := filepath.Join(rigPath, ".runtime", "refinery", "gate-cache")
+			for _, d := range []string{work, hooks, gateCache} {
+				if err := os.MkdirAll(d, 0755); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			// Host networking: the long temp paths here don't fit the
+			// port-forward sockets in sun_path, and networking isn't tested.
+			p, err := Resolve(&config.SandboxConfig{Enabled: true, Network: config.SandboxNetworkHost}, town, rigPath, work)
+			if err != nil {
+				t.Fatalf("Resolve: %v", err)
+			}
+			// Setup hooks run on the host and cached gate passes skip the
+			// refinery's gates: neither may be written from inside.
+			script := `! sh -c 'echo evil >"$1/00-evil"' sh "$1" 2>/dev/null &&
+				! touch "$2/forged.json" 2>/dev/null &&
+				touch "$3/polecat.lock" && touch "$4/mine"`
+			code, err := Run(p, []string{"sh", "-c", script, "sh", hooks, gateCache,
+				filepath.Join(rigPath, ".runtime", "locks"), work})
+			if err != nil || code != 0 {
+				t.Fatalf("Run = %d, %v", code, err)
+			}
+			for _, path := range []string{filepath.Join(hooks, "00-evil"), filepath.Join(gateCache, "forged.json")} {
+				if _, err := os.Stat(path); err == nil {
+					t.Errorf("sandboxed write landed in %s", path)
+				}
+			}
+			if _, err := os.Stat(filepath.Join(rigPath, ".runtime", "locks", "polecat.lock")); err != nil {
+				t.Errorf("lock file did not reach the host: %v", err)
+			}
+		})
+	}
+}
+
+func TestRunRelaysEvents(t *testing.T) {
+	requireUserNS(t)
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	town := filepath.Join(home, "gt")
+	rigPath := filepath.Join(town, "rig")
+	work := filepath.Join(rigPath, "polecats", "Toast", "rig")
+	if err := os.MkdirAll(work, 0755); err != nil {
+		t.Fatal(err)
+	}
+	// An event from yesterday, so the relayed append rotates the log.
+	eventsPath := filepath.Join(town, events.EventsFile)
+	old := `{"ts":"` + time.Now().UTC().Add(-48*time.Hour).Format(time.RFC3339) + `","type":"old"}` + "\n"
+	if err := os.WriteFile(eventsPath, []byte(old), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := Resolve(&config.SandboxConfig{Enabled: true}, town, rigPath, work)
+	if err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+	self, err := os.Executable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	script := `"$1" log-event "$2" && ! touch "$2/.events/forged" 2>/dev/null`
+	code, err := Run(p, []string{"sh", "-c", script, "sh", self, town})
+	if err != nil || code != 0 {
+		t.Fatalf("Run = %d, %v", code, err)
+	}
+
+	data, err := os.ReadFile(eventsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"type":"relayed"`) || strings.Contains(string(data), `"type":"old"`) {
+		t.Errorf("active segment = %q, want only the relayed event", data)
+	}
+	if _, err := os.Stat(filepath.Join(town, events.SegmentDir, "forged")); err == nil {
+		t.Error("sandboxed write landed in the closed segments")
+	}
+}
@@ -0,0 +1,18 @@
+//go:build !linux
+
+package sandbox
+
+// Supported reports whether the sandbox can run here. Linux only.
+func Supported() bool {
+	return false
+}
+
+// Run is unavailable outside Linux.
+func Run(p *Policy, argv []string) (int, error) {
+	return 1, ErrUnsupported
+}
+
+// Init is unavailable outside Linux.
+func Init(argv []string) (int, error) {
+	return 1, ErrUnsupported
+}
@@ -0,0 +1,404 @@
+// Package sandbox confines polecat sessions with Linux user, mount, and
+// network namespaces.
+//
+// A sandboxed session runs as a chain of three processes:
+//
+//	gt sandbox run   host namespaces; serves the egress proxy, port
+//	                 forwards and the events relay on Unix sockets, then
+//	                 starts...
+//	gt sandbox init  new user+mount+pid(+net) namespaces as namespace root
+//	                 and PID 1; mounts a fresh /proc, builds the mount
+//	                 view, brings up loopback, bridges
+//	                 loopback ports to the sockets, then starts...
+//	<agent>          a nested user namespace mapped back to the real uid,
+//	                 so the agent is not root and cannot undo the mounts.
+package sandbox
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/doltserver"
+	"github.com/steveyegge/gastown/internal/events"
+)
+
+// EnvPolicy carries the resolved Policy from gt sandbox run to gt sandbox init.
+const EnvPolicy = "GT_SANDBOX_POLICY"
+
+// ErrUnsupported is returned when the platform can't create the namespaces.
+var ErrUnsupported = errors.New("sandbox requires Linux with unprivileged user namespaces")
+
+// defaultWritable keeps Claude Code's transcripts and credentials writable.
+// Neither is read as configuration by anything else.
+var defaultWritable = []string{"~/.claude/projects", "~/.claude/.credentials.json"}
+
+// defaultPrivate gives each session its own copy of Claude Code's settings.
+// Hooks and MCP servers configured there run in unsandboxed sessions too,
+// so a sandboxed agent must not be able to change the shared files.
+var defaultPrivate = []string{"~/.claude", "~/.claude.json"}
+
+// privateShared are parts of the private paths bound read-only instead of
+// copied: Claude Code's local install and plugins are large, and plugins
+// carry hooks of their own.
+var privateShared = []string{"~/.claude/local", "~/.claude/plugins"}
+
+// DefaultAllowHosts are the hosts reachable when the network mode isn't
+// set: the agent's API, and nothing else. Host networking is opt-in.
+var DefaultAllowHosts = []string{"api.anthropic.com"}
+
+// Bind mounts Source over Path inside the sandbox.
+type Bind struct {
+	Path   string `json:"path"`
+	Source string `json:"source"`
+}
+
+// Policy is a sandbox resolved for one session: concrete, existing paths
+// and the network mode.
+type Policy struct {
+	Home         string   `json:"home"`
+	HomeMode     string   `json:"home_mode"`
+	WorkDir      string   `json:"work_dir"`
+	Writable     []string `json:"writable"`
+	ReadOnly     []string `json:"read_only,omitempty"`
+	Private      []Bind   `json:"private,omitempty"`
+	Network      string   `json:"network"`
+	AllowHosts   []string `json:"allow_hosts,omitempty"`
+	ForwardPorts []int    `json:"forward_ports,omitempty"`
+	SocketDir    string   `json:"socket_dir,omitempty"`
+	TownRoot     string   `json:"town_root"`
+	UID          int      `json:"uid"`
+	GID          int      `json:"gid"`
+}
+
+// Isolated reports whether the session gets its own network namespace.
+func (p *Policy) Isolated() bool {
+	return p.Network == config.SandboxNetworkNone || p.Network == config.SandboxNetworkAllowlist
+}
+
+// ProxySocket is the Unix socket serving the allowlist proxy.
+func (p *Policy) ProxySocket() string {
+	return filepath.Join(p.SocketDir, "proxy.sock")
+}
+
+// EventsSocket is the Unix socket relaying the session's events to the
+// host, which appends them to the town's events log.
+func (p *Policy) EventsSocket() string {
+	return filepath.Join(p.SocketDir, "events.sock")
+}
+
+// PortSocket is the Unix socket forwarding to a host loopback port.
+func (p *Policy) PortSocket(port int) string {
+	return filepath.Join(p.SocketDir, "port-"+strconv.Itoa(port)+".sock")
+}
+
+// Resolve turns rig sandbox settings into a Policy for a session in workDir.
+func Resolve(cfg *config.SandboxConfig, townRoot, rigPath, workDir string) (*Policy, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("finding home directory: %w", err)
+	}
+	workDir, err = filepath.Abs(workDir)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Policy{
+		Home:     home,
+		HomeMode: cfg.Home,
+		WorkDir:  workDir,
+		TownRoot: townRoot,
+		Network:  cfg.Network,
+		UID:      os.Getuid(),
+		GID:      os.Getgid(),
+	}
+	if p.HomeMode == "" {
+		p.HomeMode = config.SandboxHomeReadOnly
+	}
+	if p.Network == "" {
+		p.Network = config.SandboxNetworkAllowlist
+	}
+
+	// The worktree's commits land in the shared repo (.repo.git or
+	// mayor/rig/.git), and bd writes through the .beads redirect. Only the
+	// repo's objects, refs and this worktree's own metadata are writable:
+	// its config and hooks are run by every unsandboxed git user.
+	writable := []string{workDir, beads.ResolveBeadsDir(workDir)}
+	var readOnly []string
+	if common, err := gitDir(workDir, "--git-common-dir"); err == nil {
+		// Bare repos start without logs/; create it so reflogs have a
+		// writable home rather than failing every ref update.
+		_ = os.MkdirAll(filepath.Join(common, "logs"), 0755)
+		for _, sub := range []string{"objects", "refs", "logs"} {
+			writable = append(writable, filepath.Join(common, sub))
+		}
+		readOnly = append(readOnly, filepath.Join(common, "config"), filepath.Join(common, "hooks"))
+	}
+	if own, err := gitDir(workDir, "--git-dir"); err == nil {
+		writable = append(writable, own)
+	}
+	// Runtime state is read by unsandboxed processes (setup hooks, the gate
+	// cache, patrol and mail schedules), so .runtime stays read-only and
+	// only the paths a session writes for itself are holes in it.
+	for _, dir := range []string{filepath.Join(rigPath, ".runtime"), filepath.Join(townRoot, ".runtime")} {
+		_ = os.MkdirAll(dir, 0755)
+		readOnly = append(readOnly, dir)
+	}
+	for _, dir := range sessionRuntimeDirs(townRoot, rigPath) {
+		_ = os.MkdirAll(dir, 0755)
+		writable = append(writable, dir)
+	}
+	writable = append(writable, filepath.Join(townRoot, ".runtime", "keepalive.json"))
+	// Events go through the host (see EventsSocket). The closed segments,
+	// which patrol receipts are read back from, stay read-only; the active
+	// segment isn't bound at all, since rotation replaces it.
+	_ = os.MkdirAll(filepath.Join(townRoot, events.SegmentDir), 0755)
+	readOnly = append(readOnly, filepath.Join(townRoot, events.SegmentDir))
+	extra := cfg.Writable
+	if extra == nil {
+		extra = defaultWritable
+	}
+	writable = append(writable, expandHome(extra, home)...)
+
+	for _, bin := range []string{"gt", "bd"} {
+		if path, err := exec.LookPath(bin); err == nil {
+			if resolved, err := filepath.EvalSymlinks(path); err == nil {
+				path = resolved
+			}
+			readOnly = append(readOnly, path)
+		}
+	}
+	if p.HomeMode == config.SandboxHomeHidden {
+		readOnly = append(readOnly, townRoot)
+	}
+	readOnly = append(readOnly, expandHome(cfg.ReadOnly, home)...)
+
+	// Per-worktree state next to the worktree: writable, visible, and
+	// short enough for sun_path.
+	stateDir := filepath.Join(filepath.Dir(workDir), ".sandbox")
+
+	private := cfg.Private
+	if private == nil {
+		private = defaultPrivate
+	}
+	if len(private) > 0 {
+		readOnly = append(readOnly, expandHome(privateShared, home)...)
+		skip := append(append([]string{}, writable...), readOnly...)
+		binds, err := copyPrivate(expandHome(private, home), home, filepath.Join(stateDir, "home"), skip)
+		if err != nil {
+			return nil, fmt.Errorf("copying private paths: %w", err)
+		}
+		p.Private = binds
+	}
+
+	if p.Isolated() {
+		p.AllowHosts = cfg.AllowHosts
+		if p.AllowHosts == nil && p.Network == config.SandboxNetworkAllowlist {
+			p.AllowHosts = DefaultAllowHosts
+		}
+		p.ForwardPorts = cfg.ForwardPorts
+		if p.ForwardPorts == nil {
+			if dolt := doltserver.DefaultConfig(townRoot); !dolt.IsRemote() {
+				p.ForwardPorts = []int{dolt.Port}
+			}
+		}
+	}
+	p.SocketDir = stateDir
+	if err := os.MkdirAll(p.SocketDir, 0700); err != nil {
+		return nil, fmt.Errorf("creating socket dir: %w", err)
+	}
+	writable = append(writable, p.SocketDir)
+
+	p.Writable = existingPaths(writable)
+	p.ReadOnly = existingPaths(readOnly)
+	return p, nil
+}
+
+// WrapCommand wraps a session start command in gt sandbox run when the rig
+// has the sandbox enabled, and returns it unchanged otherwise. It fails
+// rather than fall back to an unsandboxed session.
+func WrapCommand(command, rigPath, workDir string) (string, error) {
+	settings, err := config.LoadRigSettings(config.RigSettingsPath(rigPath))
+	if err != nil {
+		if errors.Is(err, config.ErrNotFound) {
+			return command, nil
+		}
+		return "", fmt.Errorf("loading rig settings: %w", err)
+	}
+	if !settings.Sandbox.IsEnabled() {
+		return command, nil
+	}
+	if !Supported() {
+		return "", ErrUnsupported
+	}
+	return fmt.Sprintf("exec gt sandbox run --rig-path %s --workdir %s -- sh -c %s",
+		config.ShellQuote(rigPath), config.ShellQuote(workDir), config.ShellQuote(command)), nil
+}
+
+// ProxyEnv points common HTTP clients at the in-sandbox proxy listener.
+func ProxyEnv(addr string) map[string]string {
+	url := "http://" + addr
+	return map[string]string{
+		"HTTP_PROXY":  url,
+		"HTTPS_PROXY": url,
+		"ALL_PROXY":   url,
+		"http_proxy":  url,
+		"https_proxy": url,
+		"all_proxy":   url,
+		"NO_PROXY":    "localhost,127.0.0.1,::1",
+		"no_proxy":    "localhost,127.0.0.1,::1",
+	}
+}
+
+// sessionRuntimeDirs are the parts of .runtime a sandboxed session writes:
+// lock files and nudges it queues for other agents.
+func sessionRuntimeDirs(townRoot, rigPath string) []string {
+	return []string{
+		filepath.Join(rigPath, ".runtime", "locks"),
+		filepath.Join(townRoot, ".runtime", "locks"),
+		filepath.Join(townRoot, ".runtime", "nudge_queue"),
+	}
+}
+
+// gitDir runs git rev-parse with flag (--git-dir or --git-common-dir)
+// and returns the absolute path.
+func gitDir(workDir, flag string) (string, error) {
+	out, err := exec.Command("git", "-C", workDir, "rev-parse", "--path-format=absolute", flag).Output() //nolint:gosec // G204: flag is a constant
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+func expandHome(paths []string, home string) []string {
+	out := make([]string, 0, len(paths))
+	for _, p := range paths {
+		if p == "~" {
+			p = home
+		} else if rest, ok := strings.CutPrefix(p, "~/"); ok {
+			p = filepath.Join(home, rest)
+		}
+		out = append(out, filepath.Clean(p))
+	}
+	return out
+}
+
+// copyPrivate refreshes a copy of each existing private path under root and
+// returns the binds that put the copies in place. Parts of a private path
+// listed in skip are left out as empty mount points: the real ones are
+// bound on top of the copy.
+func copyPrivate(private []string, home, root string, skip []string) ([]Bind, error) {
+	var binds []Bind
+	for _, path := range private {
+		if _, err := os.Lstat(path); err != nil {
+			continue
+		}
+		rel, err := filepath.Rel(home, path)
+		if err != nil || !within(path, home) {
+			return nil, fmt.Errorf("private path %s is outside $HOME", path)
+		}
+		dst := filepath.Join(root, rel)
+		if err := os.RemoveAll(dst); err != nil {
+			return nil, err
+		}
+		if err := copyTree(path, dst, skip); err != nil {
+			return nil, err
+		}
+		binds = append(binds, Bind{Path: path, Source: dst})
+	}
+	sort.Slice(binds, func(i, j int) bool { return binds[i].Path < binds[j].Path })
+	return binds, nil
+}
+
+// copyTree copies regular files, directories and symlinks from src to dst.
+// Paths in skip get an empty directory or file instead.
+func copyTree(src, dst string, skip []string) error {
+	skipped := make(map[string]bool, len(skip))
+	for _, path := range skip {
+		skipped[path] = true
+	}
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		skip := skipped[path] && path != src
+		switch {
+		case info.IsDir():
+			if err := os.MkdirAll(target, info.Mode().Perm()|0700); err != nil {
+				return err
+			}
+			if skip {
+				return filepath.SkipDir
+			}
+			return nil
+		case skip:
+			return os.WriteFile(target, nil, 0600)
+		case info.Mode()&os.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(link, target)
+		case info.Mode().IsRegular():
+			return copyFile(path, target, info.Mode().Perm())
+		}
+		return nil // sockets, fifos, devices
+	})
+}
+
+func copyFile(src, dst string, perm os.FileMode) error {
+	in, err := os.Open(src) //nolint:gosec // G304: copying the user's own agent settings
+	if err != nil {
+		return err
+	}
+	defer func() { _ = in.Close() }()
+	if err := os.MkdirAll(filepath.Dir(dst), 0700); err != nil {
+		return err
+	}
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, perm) //nolint:gosec // G304: destination is the sandbox state dir
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// existingPaths drops missing paths and duplicates, sorted so parents
+// are mounted before their children.
+func existingPaths(paths []string) []string {
+	seen := make(map[string]bool)
+	var out []string
+	for _, p := range paths {
+		if p == "" || seen[p] {
+			continue
+		}
+		if _, err := os.Stat(p); err != nil {
+			continue
+		}
+		seen[p] = true
+		out = append(out, p)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func within(path, dir string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, "../")
+}
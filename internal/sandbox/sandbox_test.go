@@ -0,0 +1,238 @@
+package sandbox
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+func TestProxyAllowed(t *testing.T) {
+	p := &Proxy{Allow: []string{"api.anthropic.com", "*.githubusercontent.com", "example.org:8443"}}
+	tests := []struct {
+		host, port string
+		want       bool
+	}{
+		{"api.anthropic.com", "443", true},
+		{"API.Anthropic.com.", "443", true},
+		{"evil-api.anthropic.com", "443", false},
+		{"raw.githubusercontent.com", "443", true},
+		{"githubusercontent.com", "443", false},
+		{"example.org", "8443", true},
+		{"example.org", "443", false},
+		{"github.com", "443", false},
+	}
+	for _, tt := range tests {
+		if got := p.Allowed(tt.host, tt.port); got != tt.want {
+			t.Errorf("Allowed(%s, %s) = %v, want %v", tt.host, tt.port, got, tt.want)
+		}
+	}
+}
+
+func startProxy(t *testing.T, allow ...string) string {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = l.Close() })
+	go func() { _ = (&Proxy{Allow: allow}).Serve(l) }()
+	return l.Addr().String()
+}
+
+func TestProxyForwardsAllowedHTTP(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "hello from "+r.URL.Path)
+	}))
+	defer upstream.Close()
+
+	proxyURL, _ := url.Parse("http://" + startProxy(t, "127.0.0.1"))
+	client := &http.Client{Transport: &http.Transport{Proxy: http.ProxyURL(proxyURL)}}
+
+	resp, err := client.Get(upstream.URL + "/x")
+	if err != nil {
+		t.Fatalf("GET via proxy: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || string(body) != "hello from /x" {
+		t.Errorf("allowed GET = %d %q", resp.StatusCode, body)
+	}
+}
+
+func TestProxyTunnelsAndDenies(t *testing.T) {
+	echo, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = echo.Close() }()
+	go func() {
+		for {
+			c, err := echo.Accept()
+			if err != nil {
+				return
+			}
+			go func() { _, _ = io.Copy(c, c); _ = c.Close() }()
+		}
+	}()
+
+	connect := func(proxyAddr, target string) (net.Conn, *bufio.Reader, string) {
+		t.Helper()
+		c, err := net.Dial("tcp", proxyAddr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _ = fmt.Fprintf(c, "CONNECT %s HTTP/1.1\r\nHost: %s\r\n\r\n", target, target)
+		r := bufio.NewReader(c)
+		status, _ := r.ReadString('\n')
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil || line == "\r\n" {
+				break
+			}
+		}
+		return c, r, status
+	}
+
+	c, r, status := connect(startProxy(t, "127.0.0.1"), echo.Addr().String())
+	if !strings.Contains(status, "200") {
+		t.Fatalf("allowed CONNECT status = %q", status)
+	}
+	_, _ = io.WriteString(c, "ping\n")
+	if line, _ := r.ReadString('\n'); line != "ping\n" {
+		t.Errorf("tunnel echo = %q", line)
+	}
+	_ = c.Close()
+
+	c, _, status = connect(startProxy(t, "api.anthropic.com"), echo.Addr().String())
+	if !strings.Contains(status, "403") {
+		t.Errorf("denied CONNECT status = %q, want 403", status)
+	}
+	_ = c.Close()
+}
+
+func TestResolvePolicy(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	town := filepath.Join(home, "gt")
+	rigPath := filepath.Join(town, "gastown")
+	workDir := filepath.Join(rigPath, "polecats", "Toast", "gastown")
+	for _, d := range []string{workDir, filepath.Join(rigPath, ".runtime"), filepath.Join(home, ".claude"), filepath.Join(home, "notes")} {
+		if err := os.MkdirAll(d, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	p, err := Resolve(&config.SandboxConfig{Enabled: true, Home: "hidden", Network: "none", ForwardPorts: []int{3307},
+		Writable: []string{"~/notes", "~/missing"}}, town, rigPath, workDir)
+	if err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+
+	has := func(list []string, path string) bool {
+		for _, p := range list {
+			if p == path {
+				return true
+			}
+		}
+		return false
+	}
+	for _, want := range []string{workDir, filepath.Join(rigPath, ".runtime", "locks"), filepath.Join(home, "notes"), p.SocketDir} {
+		if !has(p.Writable, want) {
+			t.Errorf("Writable %v missing %s", p.Writable, want)
+		}
+	}
+	for _, want := range []string{filepath.Join(rigPath, ".runtime"), filepath.Join(town, ".runtime"), filepath.Join(town, ".events")} {
+		if !has(p.ReadOnly, want) || has(p.Writable, want) {
+			t.Errorf("%s should be read-only: Writable = %v, ReadOnly = %v", want, p.Writable, p.ReadOnly)
+		}
+	}
+	// Explicit writable list replaces the default, and missing paths are dropped.
+	if has(p.Writable, filepath.Join(home, ".claude")) || has(p.Writable, filepath.Join(home, "missing")) {
+		t.Errorf("Writable = %v", p.Writable)
+	}
+	if !has(p.ReadOnly, town) {
+		t.Errorf("hidden mode should keep the town visible: ReadOnly = %v", p.ReadOnly)
+	}
+	if p.SocketDir != filepath.Join(rigPath, "polecats", "Toast", ".sandbox") || !p.Isolated() {
+		t.Errorf("SocketDir = %s, Isolated = %v", p.SocketDir, p.Isolated())
+	}
+	// ~/.claude is a per-session copy; ~/.claude.json doesn't exist here.
+	want := Bind{Path: filepath.Join(home, ".claude"), Source: filepath.Join(p.SocketDir, "home", ".claude")}
+	if len(p.Private) != 1 || p.Private[0] != want {
+		t.Errorf("Private = %+v, want [%+v]", p.Private, want)
+	}
+}
+
+func TestWrapCommand(t *testing.T) {
+	rigPath := t.TempDir()
+	if got, err := WrapCommand("claude", rigPath, "/w"); err != nil || got != "claude" {
+		t.Errorf("no settings: WrapCommand = %q, %v", got, err)
+	}
+
+	if err := os.MkdirAll(filepath.Join(rigPath, "settings"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	settings := `{"type":"rig-settings","version":1,"sandbox":{"enabled":true,"network":"none"}}`
+	if err := os.WriteFile(config.RigSettingsPath(rigPath), []byte(settings), 0644); err != nil {
+		t.Fatal(err)
+	}
+	got, err := WrapCommand("export A=1 && claude", rigPath, "/w")
+	if !Supported() {
+		if err != ErrUnsupported {
+			t.Errorf("unsupported platform: err = %v, want ErrUnsupported", err)
+		}
+		return
+	}
+	if err != nil {
+		t.Fatalf("WrapCommand: %v", err)
+	}
+	if !strings.HasPrefix(got, "exec gt sandbox run --rig-path ") || !strings.HasSuffix(got, "-- sh -c 'export A=1 && claude'") {
+		t.Errorf("WrapCommand = %q", got)
+	}
+}
+
+func TestResolveNetworkDefault(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	town := filepath.Join(home, "gt")
+	rigPath := filepath.Join(town, "gastown")
+	workDir := filepath.Join(rigPath, "polecats", "Toast", "gastown")
+	if err := os.MkdirAll(workDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		cfg       config.SandboxConfig
+		wantNet   string
+		wantHosts []string
+	}{
+		{"unset", config.SandboxConfig{Enabled: true}, config.SandboxNetworkAllowlist, DefaultAllowHosts},
+		{"hosts only", config.SandboxConfig{Enabled: true, AllowHosts: []string{"github.com"}}, config.SandboxNetworkAllowlist, []string{"github.com"}},
+		{"host opt-in", config.SandboxConfig{Enabled: true, Network: "host"}, config.SandboxNetworkHost, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := Resolve(&tt.cfg, town, rigPath, workDir)
+			if err != nil {
+				t.Fatalf("Resolve: %v", err)
+			}
+			if p.Network != tt.wantNet || strings.Join(p.AllowHosts, ",") != strings.Join(tt.wantHosts, ",") {
+				t.Errorf("Network = %q, AllowHosts = %v; want %q, %v", p.Network, p.AllowHosts, tt.wantNet, tt.wantHosts)
+			}
+			if p.Isolated() != (tt.wantNet != config.SandboxNetworkHost) {
+				t.Errorf("Isolated = %v", p.Isolated())
+			}
+		})
+	}
+}
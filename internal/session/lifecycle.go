@@ -188,6 +188,13 @@ func StartSession(t *tmux.Tmux, cfg SessionConfig) (_ *StartResult, retErr error
 		_ = t.SetRemainOnExit(cfg.SessionID, true)
 	}
 
+	// 5b. Move the session into its own cgroup if resource limits are configured.
+	if cfg.TownRoot != "" {
+		if err := ApplyResourceLimits(t, cfg.SessionID, cfg.Role, cfg.TownRoot, cfg.RigPath); err != nil {
+			fmt.Printf("warning: resource limits not applied for %s: %v\n", cfg.SessionID, err)
+		}
+	}
+
 	// 6. Set environment variables.
 	envVars := config.AgentEnv(config.AgentEnvConfig{
 		Role:             cfg.Role,
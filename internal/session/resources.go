@@ -0,0 +1,67 @@
+package session
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/cgroup"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/tmux"
+)
+
+// ApplyResourceLimits moves a session's process tree into its own cgroup
+// with the limits configured for role (see config.ResolveResourceLimits).
+// It is a no-op when no limits are configured. Errors are advisory: callers
+// should warn and let the session run unconstrained.
+func ApplyResourceLimits(t *tmux.Tmux, sessionID, role, townRoot, rigPath string) error {
+	limits := config.ResolveResourceLimits(role, townRoot, rigPath)
+	if limits.IsZero() {
+		return nil
+	}
+
+	pidStr, err := t.GetPanePID(sessionID)
+	if err != nil {
+		return fmt.Errorf("getting pane PID: %w", err)
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(pidStr))
+	if err != nil {
+		return fmt.Errorf("parsing PID %q: %w", pidStr, err)
+	}
+
+	_, err = cgroup.Apply(sessionID, pid, cgroup.Limits{
+		CPUWeight: limits.CPUWeight,
+		MemoryMax: limits.MemoryMaxBytes(),
+		PidsMax:   limits.PidsMax,
+	})
+	return err
+}
+
+// ReportOOMDeath checks whether a dead session's cgroup recorded OOM kills.
+// If so it emits a session_death event with the reason, removes the cgroup
+// so the report isn't repeated, and returns the reason.
+func ReportOOMDeath(sessionID, agent, caller string) (string, bool) {
+	usage, err := cgroup.ReadUsage(sessionID)
+	if err != nil || usage.OOMKills == 0 {
+		return "", false
+	}
+
+	reason := "oom-killed"
+	if usage.MemoryMax > 0 {
+		reason = fmt.Sprintf("oom-killed: exceeded memory_max %dM", usage.MemoryMax>>20)
+	}
+	if usage.OOMKills > 1 {
+		reason += fmt.Sprintf(" (%d kills)", usage.OOMKills)
+	}
+
+	_ = events.LogFeed(events.TypeSessionDeath, agent,
+		events.SessionDeathPayload(sessionID, agent, reason, caller))
+	_ = cgroup.Remove(sessionID)
+	return reason, true
+}
+
+// RemoveResourceGroup deletes a stopped session's cgroup, if any (best-effort).
+func RemoveResourceGroup(sessionID string) {
+	_ = cgroup.Remove(sessionID)
+}
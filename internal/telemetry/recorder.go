@@ -9,8 +9,11 @@ import (
 	"os"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 	"unicode/utf8"
 
+	"github.com/steveyegge/gastown/internal/redact"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	otellog "go.opentelemetry.io/otel/log"
@@ -125,16 +128,54 @@ func statusStr(err error) string {
 	return "ok"
 }
 
+// logsEnabled is set once Init installs a log provider. Until then the
+// global provider is a no-op, and emit skips redaction for records that
+// would be dropped anyway.
+var logsEnabled atomic.Bool
+
+// townRoot is the town whose redaction settings scrub telemetry and whose
+// stats count it; see SetTownRoot.
+var townRoot atomic.Value // string
+
+// SetTownRoot sets the town whose redaction settings apply to exported log
+// events. Without one, the default detectors are used and nothing is
+// counted.
+func SetTownRoot(root string) {
+	townRoot.Store(root)
+}
+
 // emit sends an OTel log event with the given body and key-value attributes.
+// A no-op unless Init installed a log exporter.
 func emit(ctx context.Context, body string, sev otellog.Severity, attrs ...otellog.KeyValue) {
+	if !logsEnabled.Load() {
+		return
+	}
 	logger := global.GetLoggerProvider().Logger(loggerName)
 	var r otellog.Record
-	r.SetBody(otellog.StringValue(body))
+	r.SetBody(otellog.StringValue(scrub(body)))
+	for i, kv := range attrs {
+		if kv.Value.Kind() == otellog.KindString {
+			attrs[i] = otellog.String(kv.Key, scrub(kv.Value.AsString()))
+		}
+	}
 	r.SetSeverity(sev)
 	r.AddAttributes(attrs...)
 	logger.Emit(ctx, r)
 }
 
+// scrub redacts secrets from s using the town's redaction settings and
+// records the count for gt doctor. s is returned as is when no log events
+// are exported.
+func scrub(s string) string {
+	if !logsEnabled.Load() {
+		return s
+	}
+	town, _ := townRoot.Load().(string)
+	out, counts := redact.ForTown(town).String(s)
+	_ = redact.Record(town, redact.SinkTelemetry, counts)
+	return out
+}
+
 // errKV returns a log KeyValue with the error message, or empty string if nil.
 func errKV(err error) otellog.KeyValue {
 	if err != nil {
@@ -161,6 +202,8 @@ const (
 // truncateOutput trims s to max bytes and appends "…" when truncated.
 // Avoids splitting multi-byte UTF-8 characters at the boundary.
 func truncateOutput(s string, max int) string {
+	// Redact first: a key cut in half by truncation no longer matches.
+	s = scrub(s)
 	if len(s) <= max {
 		return s
 	}
@@ -208,6 +251,8 @@ func RecordBDCall(ctx context.Context, args []string, durationMs float64, err er
 		)
 	}
 	emit(ctx, "bd.call", severity(err), kvs...)
+	start := time.Now().Add(-time.Duration(durationMs * float64(time.Millisecond)))
+	recordSpan(ctx, "bd "+subcommand, start, err, attribute.String("bd.subcommand", subcommand))
 }
 
 // RecordSessionStart records an agent session start (metrics + log event).
@@ -1,6 +1,7 @@
 package telemetry
 
 import (
+	"context"
 	"os"
 	"strings"
 )
@@ -62,13 +63,17 @@ func SetProcessOTELAttrs() {
 // (beads.go run, mail/bd.go runBdCommand) so the vars aren't lost when the
 // explicit env slice is built from scratch instead of os.Environ().
 //
-// Returns nil when GT telemetry is not active (GT_OTEL_METRICS_URL not set).
+// TRACEPARENT is passed through whenever this process has one, so bd calls
+// join the caller's trace even when metrics are off.
+//
+// Returns nil when GT telemetry is not active (GT_OTEL_METRICS_URL not set)
+// and there is no trace to propagate.
 func OTELEnvForSubprocess() []string {
+	env := TraceEnv(context.Background())
 	metricsURL := os.Getenv(EnvMetricsURL)
 	if metricsURL == "" {
-		return nil
+		return env
 	}
-	var env []string
 	if attrs := buildGTResourceAttrs(); attrs != "" {
 		env = append(env, "OTEL_RESOURCE_ATTRIBUTES="+attrs)
 	}
@@ -1,13 +1,17 @@
-// Package telemetry initializes OpenTelemetry providers for metric and log export.
+// Package telemetry initializes OpenTelemetry providers for metric, log and
+// trace export.
 //
 // Metrics → VictoriaMetrics via OTLP HTTP
 // Logs    → VictoriaLogs via OTLP HTTP
+// Traces  → any OTLP HTTP backend (Jaeger, Tempo, collector)
 //
-// Enabled by setting at least one of:
+// Metrics and logs are enabled by setting at least one of:
 //
 //	GT_OTEL_METRICS_URL  (default: http://localhost:8428/opentelemetry/api/v1/push)
 //	GT_OTEL_LOGS_URL     (default: http://localhost:9428/insert/opentelemetry/v1/logs)
 //
+// Traces are enabled separately by GT_OTEL_TRACES_URL (see trace.go).
+//
 // Telemetry is best-effort: initialization errors are returned but do not
 // affect normal gt operation — callers should log and continue.
 //
@@ -94,13 +98,16 @@ func (p *Provider) Shutdown(ctx context.Context) error {
 // issue. If multiple packages call Init, ensure the entry-point (main or
 // cobra root) calls it first with the correct service name.
 //
-// Returns (nil, nil) if neither GT_OTEL_METRICS_URL nor GT_OTEL_LOGS_URL is set,
-// so that telemetry is strictly opt-in. Set either variable to activate.
+// Returns (nil, nil) if none of GT_OTEL_METRICS_URL, GT_OTEL_LOGS_URL and
+// GT_OTEL_TRACES_URL is set, so that telemetry is strictly opt-in.
 //
-// When active, defaults are used for any unset endpoint:
+// When metrics or logs are active, defaults are used for whichever of the
+// two endpoints is unset:
 //
 //	metrics → http://localhost:8428/opentelemetry/api/v1/push
 //	logs    → http://localhost:9428/insert/opentelemetry/v1/logs
+//
+// Tracing is only enabled by its own variable.
 func Init(ctx context.Context, serviceName, serviceVersion string) (*Provider, error) {
 	initMu.Lock()
 	defer initMu.Unlock()
@@ -110,19 +117,14 @@ func Init(ctx context.Context, serviceName, serviceVersion string) (*Provider, e
 
 	metricsURL := os.Getenv(EnvMetricsURL)
 	logsURL := os.Getenv(EnvLogsURL)
+	tracesURL := os.Getenv(EnvTracesURL)
 
-	// Both unset → telemetry disabled, not an error.
-	if metricsURL == "" && logsURL == "" {
+	// All unset → telemetry disabled, not an error.
+	if metricsURL == "" && logsURL == "" && tracesURL == "" {
 		initDone = true
 		globalProvider = nil
 		return nil, nil
 	}
-	if metricsURL == "" {
-		metricsURL = DefaultMetricsURL
-	}
-	if logsURL == "" {
-		logsURL = DefaultLogsURL
-	}
 
 	res, err := resource.New(ctx,
 		resource.WithAttributes(
@@ -138,6 +140,26 @@ func Init(ctx context.Context, serviceName, serviceVersion string) (*Provider, e
 
 	p := &Provider{}
 
+	if tracesURL != "" {
+		shutdown, err := initTracing(ctx, tracesURL, res)
+		if err != nil {
+			return nil, err
+		}
+		p.shutdowns = append(p.shutdowns, shutdown)
+	}
+
+	if metricsURL == "" && logsURL == "" {
+		initDone = true
+		globalProvider = p
+		return p, nil
+	}
+	if metricsURL == "" {
+		metricsURL = DefaultMetricsURL
+	}
+	if logsURL == "" {
+		logsURL = DefaultLogsURL
+	}
+
 	// Metrics → VictoriaMetrics
 	metricExp, err := otlpmetrichttp.New(ctx,
 		otlpmetrichttp.WithEndpointURL(metricsURL),
@@ -170,6 +192,7 @@ func Init(ctx context.Context, serviceName, serviceVersion string) (*Provider, e
 	)
 	global.SetLoggerProvider(lp)
 	p.shutdowns = append(p.shutdowns, lp.Shutdown)
+	logsEnabled.Store(true)
 
 	initDone = true
 	globalProvider = p
@@ -27,6 +27,7 @@ func TestInit_BothURLsUnset_ReturnsNil(t *testing.T) {
 	resetInitState(t)
 	t.Setenv(EnvMetricsURL, "")
 	t.Setenv(EnvLogsURL, "")
+	t.Setenv(EnvTracesURL, "")
 
 	p, err := Init(context.Background(), "test-svc", "0.0.1")
 	if err != nil {
@@ -41,6 +42,7 @@ func TestInit_Idempotent_ReturnsFirstProvider(t *testing.T) {
 	resetInitState(t)
 	t.Setenv(EnvMetricsURL, "")
 	t.Setenv(EnvLogsURL, "")
+	t.Setenv(EnvTracesURL, "")
 
 	p1, _ := Init(context.Background(), "test-svc", "0.0.1")
 	p2, _ := Init(context.Background(), "test-svc", "0.0.1")
@@ -0,0 +1,178 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Tracing follows one piece of work across every process that touches it.
+//
+// A sling starts a trace; its W3C traceparent travels to the processes
+// that continue the work through three carriers:
+//
+//   - TRACEPARENT in the environment of subprocesses and agent sessions
+//   - a "traceparent:" field on the hooked bead and on the MR bead
+//   - a "traceparent:" label on mail
+//
+// so sling → spawn → prime → done → MR → merge shows up as one trace.
+// Propagation works even when this process exports nothing: spans started
+// without a tracer provider still carry the parent's trace ID onward.
+
+const (
+	// EnvTracesURL is the env var for the OTLP HTTP traces endpoint, e.g.
+	// http://localhost:4318/v1/traces for Jaeger, Tempo or the OTel
+	// collector. Tracing is enabled only when it is set; there is no default.
+	EnvTracesURL = "GT_OTEL_TRACES_URL"
+
+	// EnvTraceparent and EnvTracestate carry W3C trace context in the
+	// environment, per the OpenTelemetry env carrier convention.
+	EnvTraceparent = "TRACEPARENT"
+	EnvTracestate  = "TRACESTATE"
+
+	tracerName = "github.com/steveyegge/gastown"
+)
+
+var propagator = propagation.TraceContext{}
+
+func init() {
+	otel.SetTextMapPropagator(propagator)
+}
+
+// initTracing installs the global tracer provider exporting to url.
+func initTracing(ctx context.Context, url string, res *resource.Resource) (func(context.Context) error, error) {
+	exp, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(url))
+	if err != nil {
+		return nil, fmt.Errorf("creating OTLP trace exporter: %w", err)
+	}
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithResource(res),
+		sdktrace.WithBatcher(exp),
+	)
+	otel.SetTracerProvider(tp)
+	return tp.Shutdown, nil
+}
+
+// ContextFromEnv returns ctx carrying the trace context from TRACEPARENT,
+// or ctx unchanged if the variable is unset or malformed.
+func ContextFromEnv(ctx context.Context) context.Context {
+	return ContextWithTraceparent(ctx, os.Getenv(EnvTraceparent), os.Getenv(EnvTracestate))
+}
+
+// ContextWithTraceparent returns ctx carrying the given W3C trace context,
+// e.g. one read back from a bead field or mail label.
+func ContextWithTraceparent(ctx context.Context, traceparent string, tracestate ...string) context.Context {
+	if traceparent == "" {
+		return ctx
+	}
+	carrier := propagation.MapCarrier{"traceparent": traceparent}
+	if len(tracestate) > 0 && tracestate[0] != "" {
+		carrier["tracestate"] = tracestate[0]
+	}
+	return propagator.Extract(ctx, carrier)
+}
+
+// Traceparent returns the W3C traceparent for the span in ctx, falling back
+// to the process's TRACEPARENT. Returns "" when there is no trace.
+func Traceparent(ctx context.Context) string {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		ctx = ContextFromEnv(ctx)
+	}
+	carrier := propagation.MapCarrier{}
+	propagator.Inject(ctx, carrier)
+	return carrier["traceparent"]
+}
+
+// TraceEnv returns TRACEPARENT (and TRACESTATE) entries for a subprocess
+// or session environment, or nil when there is no trace.
+func TraceEnv(ctx context.Context) []string {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		ctx = ContextFromEnv(ctx)
+	}
+	carrier := propagation.MapCarrier{}
+	propagator.Inject(ctx, carrier)
+	if carrier["traceparent"] == "" {
+		return nil
+	}
+	env := []string{EnvTraceparent + "=" + carrier["traceparent"]}
+	if ts := carrier["tracestate"]; ts != "" {
+		env = append(env, EnvTracestate+"="+ts)
+	}
+	return env
+}
+
+// StartSpan starts a span. When ctx has no span, the process's TRACEPARENT
+// (if any) becomes the parent, so call sites holding only
+// context.Background() still join the trace they were launched under.
+func StartSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		ctx = ContextFromEnv(ctx)
+	}
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// EndSpan records err (if any) on span and ends it.
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// LinkTraceparent links the span in ctx to the trace in traceparent when
+// that trace is not the one the span already belongs to. gt done uses it
+// when its session was started outside the sling's trace (e.g. a reused
+// polecat) but the hooked bead still records the sling.
+func LinkTraceparent(ctx context.Context, traceparent string) {
+	linked := trace.SpanContextFromContext(ContextWithTraceparent(context.Background(), traceparent))
+	span := trace.SpanFromContext(ctx)
+	if !linked.IsValid() || linked.TraceID() == span.SpanContext().TraceID() {
+		return
+	}
+	span.AddLink(trace.Link{SpanContext: linked})
+}
+
+// SetProcessTraceparent exports the span in ctx as this process's
+// TRACEPARENT, so subprocesses and sessions started afterwards inherit it.
+// Returns a func restoring the previous value.
+func SetProcessTraceparent(ctx context.Context) (restore func()) {
+	prev, had := os.LookupEnv(EnvTraceparent)
+	tp := Traceparent(ctx)
+	if tp == "" {
+		return func() {}
+	}
+	_ = os.Setenv(EnvTraceparent, tp)
+	return func() {
+		if had {
+			_ = os.Setenv(EnvTraceparent, prev)
+		} else {
+			_ = os.Unsetenv(EnvTraceparent)
+		}
+	}
+}
+
+// recordSpan records an already-finished operation as a child span of ctx
+// (or of the process trace), e.g. a bd call timed by its caller.
+func recordSpan(ctx context.Context, name string, start time.Time, err error, attrs ...attribute.KeyValue) {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		ctx = ContextFromEnv(ctx)
+	}
+	_, span := otel.Tracer(tracerName).Start(ctx, name,
+		trace.WithTimestamp(start), trace.WithAttributes(attrs...))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,69 @@
+package telemetry
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+const testTraceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+func TestTraceparent_RoundTrip(t *testing.T) {
+	t.Setenv(EnvTraceparent, "")
+
+	if got := Traceparent(context.Background()); got != "" {
+		t.Errorf("Traceparent with no trace = %q, want empty", got)
+	}
+	ctx := ContextWithTraceparent(context.Background(), testTraceparent)
+	if got := Traceparent(ctx); got != testTraceparent {
+		t.Errorf("Traceparent = %q, want %q", got, testTraceparent)
+	}
+	if got := Traceparent(ContextWithTraceparent(context.Background(), "garbage")); got != "" {
+		t.Errorf("malformed traceparent should be ignored, got %q", got)
+	}
+}
+
+func TestTraceEnv(t *testing.T) {
+	t.Setenv(EnvTraceparent, "")
+	if env := TraceEnv(context.Background()); env != nil {
+		t.Errorf("TraceEnv with no trace = %v, want nil", env)
+	}
+
+	t.Setenv(EnvTraceparent, testTraceparent)
+	env := TraceEnv(context.Background())
+	if len(env) != 1 || env[0] != EnvTraceparent+"="+testTraceparent {
+		t.Errorf("TraceEnv = %v", env)
+	}
+}
+
+func TestStartSpan_JoinsEnvTrace(t *testing.T) {
+	t.Setenv(EnvTraceparent, testTraceparent)
+
+	ctx, span := StartSpan(context.Background(), "test")
+	defer EndSpan(span, nil)
+
+	sc := trace.SpanContextFromContext(ctx)
+	if got := sc.TraceID().String(); got != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("span trace ID = %s, want the TRACEPARENT trace", got)
+	}
+	if !strings.Contains(Traceparent(ctx), "4bf92f3577b34da6a3ce929d0e0e4736") {
+		t.Errorf("Traceparent(ctx) = %q should carry the env trace", Traceparent(ctx))
+	}
+}
+
+func TestSetProcessTraceparent_Restores(t *testing.T) {
+	t.Setenv(EnvTraceparent, "")
+	_ = os.Unsetenv(EnvTraceparent)
+
+	restore := SetProcessTraceparent(ContextWithTraceparent(context.Background(), testTraceparent))
+	if got := os.Getenv(EnvTraceparent); got != testTraceparent {
+		t.Errorf("TRACEPARENT = %q, want %q", got, testTraceparent)
+	}
+	restore()
+	if _, ok := os.LookupEnv(EnvTraceparent); ok {
+		t.Error("restore should unset TRACEPARENT when it was not set before")
+	}
+}
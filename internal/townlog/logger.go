@@ -48,6 +48,11 @@ type Event struct {
 	Type      EventType `json:"type"`
 	Agent     string    `json:"agent"`            // e.g., "gastown/crew/max" or "gastown/polecats/Toast"
 	Context   string    `json:"context,omitempty"` // Additional context (issue ID, error message, etc.)
+
+	// Detail is the rendered text after the agent ("spawned for gt-xyz").
+	// Only set on events parsed back from the log file, where Context is
+	// no longer separable.
+	Detail string `json:"detail,omitempty"`
 }
 
 // Logger handles writing events to the town log file.
@@ -267,7 +272,8 @@ func parseLogLine(line string) (Event, error) {
 	if len(line) < 19 {
 		return event, fmt.Errorf("line too short")
 	}
-	ts, err := time.Parse("2006-01-02 15:04:05", line[:19])
+	// Lines are written in local time without a zone.
+	ts, err := time.ParseInLocation("2006-01-02 15:04:05", line[:19], time.Local)
 	if err != nil {
 		return event, fmt.Errorf("parsing timestamp: %w", err)
 	}
@@ -311,7 +317,7 @@ func parseLogLine(line string) (Event, error) {
 		event.Agent = rest
 	} else {
 		event.Agent = rest[:spaceIdx]
-		// The rest is context info (not worth parsing further)
+		event.Detail = rest[spaceIdx+1:]
 	}
 
 	return event, nil
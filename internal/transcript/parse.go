@@ -0,0 +1,224 @@
+package transcript
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"strings"
+	"time"
+)
+
+// Usage is token usage, summed over assistant messages.
+type Usage struct {
+	InputTokens              int `json:"input_tokens"`
+	CacheCreationInputTokens int `json:"cache_creation_input_tokens"`
+	CacheReadInputTokens     int `json:"cache_read_input_tokens"`
+	OutputTokens             int `json:"output_tokens"`
+}
+
+// Add accumulates o into u.
+func (u *Usage) Add(o Usage) {
+	u.InputTokens += o.InputTokens
+	u.CacheCreationInputTokens += o.CacheCreationInputTokens
+	u.CacheReadInputTokens += o.CacheReadInputTokens
+	u.OutputTokens += o.OutputTokens
+}
+
+// Total returns all tokens counted against the session.
+func (u Usage) Total() int {
+	return u.InputTokens + u.CacheCreationInputTokens + u.CacheReadInputTokens + u.OutputTokens
+}
+
+// record is one line of a Claude Code transcript. Lines of other types
+// (summary, system, file snapshots) decode with a nil Message.
+type record struct {
+	Type      string         `json:"type"`
+	Timestamp string         `json:"timestamp"`
+	SessionID string         `json:"sessionId"`
+	CWD       string         `json:"cwd"`
+	IsMeta    bool           `json:"isMeta"`
+	Message   *recordMessage `json:"message"`
+	Summary   string         `json:"summary"`
+}
+
+type recordMessage struct {
+	Role    string          `json:"role"`
+	Model   string          `json:"model"`
+	Content json.RawMessage `json:"content"`
+	Usage   *Usage          `json:"usage"`
+}
+
+type contentBlock struct {
+	Type      string          `json:"type"`
+	Text      string          `json:"text"`
+	Thinking  string          `json:"thinking"`
+	Name      string          `json:"name"`
+	ID        string          `json:"id"`
+	Input     json.RawMessage `json:"input"`
+	ToolUseID string          `json:"tool_use_id"`
+	Content   json.RawMessage `json:"content"`
+	IsError   bool            `json:"is_error"`
+}
+
+func parseRecord(line []byte) (*record, bool) {
+	var r record
+	if err := json.Unmarshal(line, &r); err != nil {
+		return nil, false
+	}
+	return &r, !r.IsMeta && (r.Type == "user" || r.Type == "assistant")
+}
+
+// Step kinds.
+const (
+	KindText       = "text"
+	KindThinking   = "thinking"
+	KindToolUse    = "tool_use"
+	KindToolResult = "tool_result"
+	KindSummary    = "summary"
+)
+
+// Step is one rendered unit of a conversation: a message's text, a tool
+// call, or a tool result.
+type Step struct {
+	Time    time.Time       `json:"time,omitempty"`
+	Role    string          `json:"role"` // user, assistant
+	Kind    string          `json:"kind"`
+	Text    string          `json:"text,omitempty"`
+	Tool    string          `json:"tool,omitempty"`
+	ToolID  string          `json:"tool_id,omitempty"`
+	Input   json.RawMessage `json:"input,omitempty"`
+	IsError bool            `json:"is_error,omitempty"`
+	Usage   *Usage          `json:"usage,omitempty"` // on the first step of each assistant message
+}
+
+// Conversation is a parsed transcript.
+type Conversation struct {
+	SessionID string         `json:"session_id,omitempty"`
+	Model     string         `json:"model,omitempty"`
+	Start     time.Time      `json:"start,omitempty"`
+	End       time.Time      `json:"end,omitempty"`
+	Steps     []Step         `json:"steps"`
+	Usage     Usage          `json:"usage"`
+	ToolCalls map[string]int `json:"tool_calls"`
+}
+
+// Parse reads a transcript's JSONL into a Conversation. Malformed lines
+// are skipped.
+func Parse(r io.Reader) (*Conversation, error) {
+	conv := &Conversation{ToolCalls: make(map[string]int)}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 256*1024), 64*1024*1024)
+	for scanner.Scan() {
+		var rec record
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			continue
+		}
+		ts, _ := time.Parse(time.RFC3339Nano, rec.Timestamp)
+		if !ts.IsZero() {
+			if conv.Start.IsZero() || ts.Before(conv.Start) {
+				conv.Start = ts
+			}
+			if ts.After(conv.End) {
+				conv.End = ts
+			}
+		}
+		if conv.SessionID == "" {
+			conv.SessionID = rec.SessionID
+		}
+
+		if rec.Type == "summary" && rec.Summary != "" {
+			conv.Steps = append(conv.Steps, Step{Time: ts, Role: "system", Kind: KindSummary, Text: rec.Summary})
+			continue
+		}
+		if rec.Message == nil || rec.IsMeta || (rec.Type != "user" && rec.Type != "assistant") {
+			continue
+		}
+		msg := rec.Message
+		if conv.Model == "" && msg.Model != "" {
+			conv.Model = msg.Model
+		}
+		role := msg.Role
+		if role == "" {
+			role = rec.Type
+		}
+
+		steps := contentSteps(ts, role, msg.Content)
+		if msg.Usage != nil {
+			conv.Usage.Add(*msg.Usage)
+			if len(steps) > 0 {
+				u := *msg.Usage
+				steps[0].Usage = &u
+			}
+		}
+		for _, s := range steps {
+			if s.Kind == KindToolUse {
+				conv.ToolCalls[s.Tool]++
+			}
+		}
+		conv.Steps = append(conv.Steps, steps...)
+	}
+	return conv, scanner.Err()
+}
+
+// contentSteps expands message content, which is either a plain string or
+// a list of typed blocks.
+func contentSteps(ts time.Time, role string, raw json.RawMessage) []Step {
+	if len(raw) == 0 {
+		return nil
+	}
+	var text string
+	if err := json.Unmarshal(raw, &text); err == nil {
+		if strings.TrimSpace(text) == "" {
+			return nil
+		}
+		return []Step{{Time: ts, Role: role, Kind: KindText, Text: text}}
+	}
+
+	var blocks []contentBlock
+	if err := json.Unmarshal(raw, &blocks); err != nil {
+		return nil
+	}
+	var steps []Step
+	for _, b := range blocks {
+		switch b.Type {
+		case "text":
+			if strings.TrimSpace(b.Text) != "" {
+				steps = append(steps, Step{Time: ts, Role: role, Kind: KindText, Text: b.Text})
+			}
+		case "thinking":
+			if b.Thinking != "" {
+				steps = append(steps, Step{Time: ts, Role: role, Kind: KindThinking, Text: b.Thinking})
+			}
+		case "tool_use":
+			steps = append(steps, Step{Time: ts, Role: role, Kind: KindToolUse, Tool: b.Name, ToolID: b.ID, Input: b.Input})
+		case "tool_result":
+			steps = append(steps, Step{Time: ts, Role: role, Kind: KindToolResult, ToolID: b.ToolUseID,
+				Text: resultText(b.Content), IsError: b.IsError})
+		}
+	}
+	return steps
+}
+
+// resultText flattens a tool result, which may be a string or text blocks.
+func resultText(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	var blocks []contentBlock
+	if err := json.Unmarshal(raw, &blocks); err != nil {
+		return ""
+	}
+	var parts []string
+	for _, b := range blocks {
+		if b.Type == "text" {
+			parts = append(parts, b.Text)
+		} else if b.Type != "" {
+			parts = append(parts, "["+b.Type+"]")
+		}
+	}
+	return strings.Join(parts, "\n")
+}
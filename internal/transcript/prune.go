@@ -0,0 +1,56 @@
+package transcript
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// PruneResult summarizes a retention pass over the archive.
+type PruneResult struct {
+	Pruned     int            `json:"pruned"`
+	Retained   int            `json:"retained"`
+	BytesFreed int64          `json:"bytes_freed"`
+	ByRole     map[string]int `json:"by_role,omitempty"`
+}
+
+// Prune removes archived transcripts older than ttl(role), measured from
+// when the session ended. A ttl of zero or less keeps the role forever.
+func Prune(townRoot string, ttl func(role string) time.Duration, now time.Time) (*PruneResult, error) {
+	return Remove(townRoot, func(e Entry) bool {
+		limit := ttl(e.Role)
+		return limit > 0 && now.Sub(e.EndedAt) > limit
+	})
+}
+
+// Remove deletes the archived transcripts for which drop returns true and
+// drops them from the index.
+func Remove(townRoot string, drop func(Entry) bool) (*PruneResult, error) {
+	result := &PruneResult{ByRole: make(map[string]int)}
+	if _, err := os.Stat(indexPath(townRoot)); os.IsNotExist(err) {
+		return result, nil
+	}
+
+	err := updateIndex(townRoot, func(entries []Entry) []Entry {
+		kept := entries[:0]
+		for _, e := range entries {
+			if !drop(e) {
+				kept = append(kept, e)
+				continue
+			}
+			path := filepath.Join(ArchiveDir(townRoot), e.Path)
+			if info, err := os.Stat(path); err == nil {
+				result.BytesFreed += info.Size()
+			}
+			_ = os.Remove(path)
+			result.Pruned++
+			result.ByRole[e.Role]++
+		}
+		result.Retained = len(kept)
+		return kept
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,228 @@
+package transcript
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// sessionStart is a session_start event recorded by gt prime.
+type sessionStart struct {
+	SessionID string
+	CWD       string
+	Time      time.Time
+}
+
+// pendingSessions returns the agent's session_start events since its last
+// session_end: every runtime session (including handoff restarts) that has
+// not been archived yet. Reading starts at the agent's cursor (see
+// endCursorPath), so only events since its last EndSession are scanned.
+func pendingSessions(townRoot, agent string) ([]sessionStart, error) {
+	want := strings.TrimSuffix(agent, "/")
+	var pending []sessionStart
+	seen := make(map[string]bool)
+	q := events.Query{
+		Since: readEndCursor(townRoot, want),
+		Types: []string{events.TypeSessionStart, events.TypeSessionEnd},
+	}
+	err := events.Read(filepath.Join(townRoot, events.EventsFile), q, func(e events.Event) bool {
+		if strings.TrimSuffix(e.Actor, "/") != want {
+			return true
+		}
+		switch e.Type {
+		case events.TypeSessionEnd:
+			pending, seen = nil, make(map[string]bool)
+		case events.TypeSessionStart:
+			id, _ := e.Payload["session_id"].(string)
+			if id == "" || seen[id] {
+				return true
+			}
+			seen[id] = true
+			cwd, _ := e.Payload["cwd"].(string)
+			ts, _ := time.Parse(time.RFC3339, e.Timestamp)
+			pending = append(pending, sessionStart{SessionID: id, CWD: cwd, Time: ts})
+		}
+		return true
+	})
+	return pending, err
+}
+
+// endCursorDir holds, per agent, the time of its last session_end, relative
+// to Dir. Event timestamps have second resolution and the cursor is
+// inclusive, so a cursor never skips events written in the same second.
+const endCursorDir = "cursors"
+
+func endCursorPath(townRoot, agent string) string {
+	return filepath.Join(ArchiveDir(townRoot), endCursorDir, safeName(agent))
+}
+
+// readEndCursor returns the agent's cursor, or the zero time (read
+// everything) when it has none.
+func readEndCursor(townRoot, agent string) time.Time {
+	data, err := os.ReadFile(endCursorPath(townRoot, agent))
+	if err != nil {
+		return time.Time{}
+	}
+	t, _ := time.Parse(time.RFC3339, strings.TrimSpace(string(data)))
+	return t
+}
+
+func writeEndCursor(townRoot, agent string, t time.Time) error {
+	path := endCursorPath(townRoot, agent)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return util.AtomicWriteFile(path, []byte(t.UTC().Format(time.RFC3339)+"\n"), 0644)
+}
+
+// configDirs lists runtime config directories that may hold transcripts:
+// CLAUDE_CONFIG_DIR, ~/.claude, and every registered account.
+func configDirs(townRoot string) []string {
+	var dirs []string
+	add := func(d string) {
+		if d == "" {
+			return
+		}
+		if strings.HasPrefix(d, "~/") {
+			if home, err := os.UserHomeDir(); err == nil {
+				d = filepath.Join(home, d[2:])
+			}
+		}
+		for _, existing := range dirs {
+			if existing == d {
+				return
+			}
+		}
+		dirs = append(dirs, d)
+	}
+	add(os.Getenv("CLAUDE_CONFIG_DIR"))
+	if home, err := os.UserHomeDir(); err == nil {
+		add(filepath.Join(home, ".claude"))
+	}
+	if townRoot != "" {
+		if cfg, err := config.LoadAccountsConfig(constants.MayorAccountsPath(townRoot)); err == nil {
+			for _, acct := range cfg.Accounts {
+				add(acct.ConfigDir)
+			}
+		}
+	}
+	return dirs
+}
+
+// projectDirName mirrors how Claude Code names per-project transcript
+// directories: every character outside [A-Za-z0-9-] becomes "-".
+var projectDirChars = regexp.MustCompile(`[^A-Za-z0-9-]`)
+
+func projectDirName(workDir string) string {
+	return projectDirChars.ReplaceAllString(workDir, "-")
+}
+
+// Locate finds the runtime's transcript file for a session. It checks the
+// project directory for workDir first, then every project directory, and
+// finally, for transcripts not named after the session, any transcript in
+// the project directory written after startedAt whose records carry the
+// session ID. It never guesses: no match returns "".
+func Locate(townRoot, sessionID, workDir string, startedAt time.Time) string {
+	dirs := configDirs(townRoot)
+	name := safeName(sessionID) + ".jsonl"
+
+	for _, dir := range dirs {
+		if workDir == "" {
+			break
+		}
+		for _, proj := range []string{projectDirName(workDir), strings.ReplaceAll(workDir, "/", "-")} {
+			path := filepath.Join(dir, "projects", proj, name)
+			if _, err := os.Stat(path); err == nil {
+				return path
+			}
+		}
+	}
+	for _, dir := range dirs {
+		if matches, _ := filepath.Glob(filepath.Join(dir, "projects", "*", name)); len(matches) > 0 {
+			return matches[0]
+		}
+	}
+
+	if workDir == "" || startedAt.IsZero() {
+		return ""
+	}
+	for _, dir := range dirs {
+		matches, _ := filepath.Glob(filepath.Join(dir, "projects", projectDirName(workDir), "*.jsonl"))
+		for _, m := range matches {
+			info, err := os.Stat(m)
+			if err != nil || info.ModTime().Before(startedAt) {
+				continue
+			}
+			if recordedSessionID(m) == sessionID {
+				return m
+			}
+		}
+	}
+	return ""
+}
+
+// recordedSessionID returns the first sessionId recorded in a transcript,
+// scanning at most a few records.
+func recordedSessionID(path string) string {
+	f, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for i := 0; i < 20 && scanner.Scan(); i++ {
+		var rec struct {
+			SessionID string `json:"sessionId"`
+		}
+		if json.Unmarshal(scanner.Bytes(), &rec) == nil && rec.SessionID != "" {
+			return rec.SessionID
+		}
+	}
+	return ""
+}
+
+// EndSession archives every transcript the agent produced since its last
+// session_end and records a session_end event for each. Best-effort:
+// sessions whose transcript cannot be found still get their event so
+// later calls do not retry them.
+func EndSession(townRoot, agent, bead string) []*Entry {
+	// Taken before reading so the cursor can't pass a session_start that
+	// lands while this call runs.
+	cursor := time.Now().Truncate(time.Second)
+	pending, _ := pendingSessions(townRoot, agent)
+	var archived []*Entry
+	for _, s := range pending {
+		payload := events.SessionPayload(s.SessionID, agent, "", s.CWD)
+		if bead != "" {
+			payload["bead"] = bead
+		}
+		if src := Locate(townRoot, s.SessionID, s.CWD, s.Time); src != "" {
+			entry, err := Archive(townRoot, ArchiveOptions{
+				SessionID: s.SessionID,
+				Agent:     agent,
+				Bead:      bead,
+				StartedAt: s.Time,
+				Source:    src,
+			})
+			if err == nil {
+				payload["transcript"] = filepath.Join(Dir, entry.Path)
+				archived = append(archived, entry)
+			}
+		}
+		_ = events.LogFeedIn(townRoot, events.TypeSessionEnd, agent, payload)
+	}
+	if len(pending) > 0 {
+		_ = writeEndCursor(townRoot, strings.TrimSuffix(agent, "/"), cursor)
+	}
+	return archived
+}
@@ -0,0 +1,360 @@
+// Package transcript archives agent session transcripts into the town and
+// reads them back for replay.
+//
+// Runtimes keep transcripts under their own config directory, keyed by
+// working directory, and rotate or lose them as accounts change. At
+// session_end gt copies the transcript into <town>/.transcripts/ as
+// gzip-compressed JSONL (secrets redacted) and appends an index record so
+// archives can be found by session ID, agent, or bead. Retention follows
+// KRC TTLs ("transcript_<role>").
+package transcript
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gofrs/flock"
+	"github.com/steveyegge/gastown/internal/redact"
+)
+
+// Dir is the archive directory, relative to the town root.
+const Dir = ".transcripts"
+
+// IndexFile is the archive index, relative to Dir.
+const IndexFile = "index.jsonl"
+
+// ErrNotFound is returned when no archived transcript matches a lookup.
+var ErrNotFound = errors.New("transcript not found")
+
+// Entry is one archived session in the index.
+type Entry struct {
+	SessionID  string    `json:"session_id"`
+	Agent      string    `json:"agent"`
+	Role       string    `json:"role"`
+	Bead       string    `json:"bead,omitempty"`
+	StartedAt  time.Time `json:"started_at,omitempty"`
+	EndedAt    time.Time `json:"ended_at,omitempty"`
+	ArchivedAt time.Time `json:"archived_at"`
+	Path       string    `json:"path"`   // relative to the archive dir
+	Source     string    `json:"source"` // original transcript path
+	Bytes      int64     `json:"bytes"`
+	Stored     int64     `json:"stored_bytes"`
+	Messages   int       `json:"messages"`
+	Model      string    `json:"model,omitempty"`
+	Usage      Usage     `json:"usage"`
+	Redactions int       `json:"redactions,omitempty"`
+}
+
+// ArchiveDir returns the archive directory for a town.
+func ArchiveDir(townRoot string) string {
+	return filepath.Join(townRoot, Dir)
+}
+
+// RoleOf derives the role from an agent address: "gastown/polecats/toast"
+// → "polecat", "gastown/witness" → "witness", "mayor" → "mayor".
+func RoleOf(agent string) string {
+	parts := strings.Split(strings.Trim(agent, "/"), "/")
+	switch {
+	case len(parts) >= 3 && parts[1] == "polecats":
+		return "polecat"
+	case len(parts) >= 3 && parts[1] == "crew":
+		return "crew"
+	case len(parts) >= 2:
+		return parts[1]
+	default:
+		return parts[0]
+	}
+}
+
+// ArchiveOptions describes a transcript to archive.
+type ArchiveOptions struct {
+	SessionID string
+	Agent     string
+	Bead      string
+	StartedAt time.Time
+	Source    string // path to the runtime's transcript file
+}
+
+// Archive compresses opts.Source into the town archive and indexes it.
+// Archiving the same session twice replaces the earlier copy, so a session
+// that resumed after a handoff ends up with its full transcript.
+func Archive(townRoot string, opts ArchiveOptions) (*Entry, error) {
+	if opts.SessionID == "" || opts.Source == "" {
+		return nil, fmt.Errorf("session ID and source are required")
+	}
+	src, err := os.Open(opts.Source)
+	if err != nil {
+		return nil, fmt.Errorf("opening transcript: %w", err)
+	}
+	defer src.Close()
+
+	now := time.Now().UTC()
+	rel := filepath.Join(now.Format("2006-01"), safeName(opts.SessionID)+".jsonl.gz")
+	dst := filepath.Join(ArchiveDir(townRoot), rel)
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return nil, fmt.Errorf("creating archive dir: %w", err)
+	}
+
+	entry := &Entry{
+		SessionID:  opts.SessionID,
+		Agent:      opts.Agent,
+		Role:       RoleOf(opts.Agent),
+		Bead:       opts.Bead,
+		StartedAt:  opts.StartedAt,
+		EndedAt:    now,
+		ArchivedAt: now,
+		Path:       rel,
+		Source:     opts.Source,
+	}
+
+	tmp := dst + ".tmp"
+	if err := writeArchive(townRoot, src, tmp, entry); err != nil {
+		_ = os.Remove(tmp)
+		return nil, err
+	}
+	if err := os.Rename(tmp, dst); err != nil {
+		_ = os.Remove(tmp)
+		return nil, fmt.Errorf("renaming archive: %w", err)
+	}
+	if info, err := os.Stat(dst); err == nil {
+		entry.Stored = info.Size()
+	}
+
+	err = updateIndex(townRoot, func(entries []Entry) []Entry {
+		kept := entries[:0]
+		for _, e := range entries {
+			if e.SessionID == entry.SessionID {
+				if e.Path != entry.Path {
+					_ = os.Remove(filepath.Join(ArchiveDir(townRoot), e.Path))
+				}
+				if entry.StartedAt.IsZero() {
+					entry.StartedAt = e.StartedAt
+				}
+				if entry.Bead == "" {
+					entry.Bead = e.Bead
+				}
+				continue
+			}
+			kept = append(kept, e)
+		}
+		return append(kept, *entry)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// writeArchive streams src through the redactor into a gzip file at path,
+// filling in entry's size, message, and usage totals.
+func writeArchive(townRoot string, src io.Reader, path string, entry *Entry) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return fmt.Errorf("creating archive: %w", err)
+	}
+	zw := gzip.NewWriter(f)
+
+	red := redact.ForTown(townRoot)
+	counts := redact.Counts{}
+	scanner := bufio.NewScanner(src)
+	scanner.Buffer(make([]byte, 0, 256*1024), 64*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		entry.Bytes += int64(len(line)) + 1
+		if len(line) == 0 {
+			continue
+		}
+		if rec, ok := parseRecord(line); ok {
+			entry.Messages++
+			if rec.Message != nil {
+				if entry.Model == "" && rec.Message.Model != "" {
+					entry.Model = rec.Message.Model
+				}
+				if rec.Message.Usage != nil {
+					entry.Usage.Add(*rec.Message.Usage)
+				}
+			}
+		}
+		out, c := red.String(string(line))
+		counts.Add(c)
+		if _, err := io.WriteString(zw, out+"\n"); err != nil {
+			_ = f.Close()
+			return fmt.Errorf("writing archive: %w", err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("reading transcript: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("compressing archive: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("closing archive: %w", err)
+	}
+	entry.Redactions = counts.Total()
+	_ = redact.Record(townRoot, redact.SinkTranscripts, counts)
+	return nil
+}
+
+// safeName keeps session IDs usable as file names.
+func safeName(id string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '/' || r == os.PathSeparator || r == 0 {
+			return '_'
+		}
+		return r
+	}, id)
+}
+
+// Open returns a reader over an archived transcript's JSONL.
+func Open(townRoot string, entry *Entry) (io.ReadCloser, error) {
+	f, err := os.Open(filepath.Join(ArchiveDir(townRoot), entry.Path))
+	if err != nil {
+		return nil, fmt.Errorf("opening archive: %w", err)
+	}
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("reading archive: %w", err)
+	}
+	return &archiveReader{Reader: zr, f: f}, nil
+}
+
+type archiveReader struct {
+	*gzip.Reader
+	f *os.File
+}
+
+func (r *archiveReader) Close() error {
+	_ = r.Reader.Close()
+	return r.f.Close()
+}
+
+// Query filters index entries. Empty fields match everything.
+type Query struct {
+	SessionID string // exact or unique prefix
+	Agent     string
+	Bead      string
+	Since     time.Time
+}
+
+// List returns index entries matching q, newest first.
+func List(townRoot string, q Query) ([]Entry, error) {
+	entries, err := readIndex(townRoot)
+	if err != nil {
+		return nil, err
+	}
+	var out []Entry
+	for i := len(entries) - 1; i >= 0; i-- {
+		e := entries[i]
+		if q.SessionID != "" && !strings.HasPrefix(e.SessionID, q.SessionID) {
+			continue
+		}
+		if q.Agent != "" && strings.TrimSuffix(e.Agent, "/") != strings.TrimSuffix(q.Agent, "/") {
+			continue
+		}
+		if q.Bead != "" && e.Bead != q.Bead {
+			continue
+		}
+		if !q.Since.IsZero() && e.EndedAt.Before(q.Since) {
+			continue
+		}
+		out = append(out, e)
+	}
+	return out, nil
+}
+
+// Find returns the entry for a session ID or unique prefix of one.
+func Find(townRoot, sessionID string) (*Entry, error) {
+	matches, err := List(townRoot, Query{SessionID: sessionID})
+	if err != nil {
+		return nil, err
+	}
+	for i := range matches {
+		if matches[i].SessionID == sessionID {
+			return &matches[i], nil
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, sessionID)
+	case 1:
+		return &matches[0], nil
+	default:
+		return nil, fmt.Errorf("session ID prefix %q is ambiguous (%d matches)", sessionID, len(matches))
+	}
+}
+
+func indexPath(townRoot string) string {
+	return filepath.Join(ArchiveDir(townRoot), IndexFile)
+}
+
+func readIndex(townRoot string) ([]Entry, error) {
+	f, err := os.Open(indexPath(townRoot))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("opening transcript index: %w", err)
+	}
+	defer f.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			continue
+		}
+		entries = append(entries, e)
+	}
+	return entries, scanner.Err()
+}
+
+// updateIndex rewrites the index under a lock with fn's result.
+func updateIndex(townRoot string, fn func([]Entry) []Entry) error {
+	if err := os.MkdirAll(ArchiveDir(townRoot), 0755); err != nil {
+		return fmt.Errorf("creating archive dir: %w", err)
+	}
+	fl := flock.New(indexPath(townRoot) + ".lock")
+	if err := fl.Lock(); err != nil {
+		return fmt.Errorf("acquiring transcript index lock: %w", err)
+	}
+	defer fl.Unlock() //nolint:errcheck // best-effort unlock
+
+	entries, err := readIndex(townRoot)
+	if err != nil {
+		return err
+	}
+	entries = fn(entries)
+
+	tmp := indexPath(townRoot) + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return fmt.Errorf("writing transcript index: %w", err)
+	}
+	enc := json.NewEncoder(f)
+	for _, e := range entries {
+		if err := enc.Encode(e); err != nil {
+			_ = f.Close()
+			_ = os.Remove(tmp)
+			return fmt.Errorf("writing transcript index: %w", err)
+		}
+	}
+	if err := f.Close(); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("writing transcript index: %w", err)
+	}
+	return os.Rename(tmp, indexPath(townRoot))
+}
@@ -0,0 +1,306 @@
+package transcript
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/events"
+)
+
+const sampleTranscript = `{"type":"summary","summary":"Fix the widget"}
+{"type":"user","timestamp":"2026-01-02T10:00:00Z","sessionId":"sess-1","message":{"role":"user","content":"Please fix gt-abc"}}
+{"type":"assistant","timestamp":"2026-01-02T10:00:05Z","sessionId":"sess-1","message":{"role":"assistant","model":"claude-test","content":[{"type":"thinking","thinking":"look first"},{"type":"text","text":"Looking."},{"type":"tool_use","id":"tu1","name":"Bash","input":{"command":"ls"}}],"usage":{"input_tokens":10,"cache_read_input_tokens":100,"output_tokens":5}}}
+{"type":"user","timestamp":"2026-01-02T10:00:07Z","sessionId":"sess-1","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"tu1","content":[{"type":"text","text":"main.go"}],"is_error":true}]}}
+{"type":"user","timestamp":"2026-01-02T10:00:08Z","isMeta":true,"message":{"role":"user","content":"meta"}}
+not json
+{"type":"assistant","timestamp":"2026-01-02T10:01:00Z","sessionId":"sess-1","message":{"role":"assistant","content":[{"type":"text","text":"Done. key=sk-ant-REDACTED"}],"usage":{"input_tokens":20,"output_tokens":7}}}
+`
+
+func writeSource(t *testing.T, dir, name string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(sampleTranscript), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestRoleOf(t *testing.T) {
+	tests := map[string]string{
+		"gastown/polecats/toast": "polecat",
+		"gastown/crew/max":       "crew",
+		"gastown/witness":        "witness",
+		"gastown/refinery/":      "refinery",
+		"mayor":                  "mayor",
+	}
+	for agent, want := range tests {
+		if got := RoleOf(agent); got != want {
+			t.Errorf("RoleOf(%q) = %q, want %q", agent, got, want)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	conv, err := Parse(strings.NewReader(sampleTranscript))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if conv.SessionID != "sess-1" || conv.Model != "claude-test" {
+		t.Errorf("session/model = %q/%q", conv.SessionID, conv.Model)
+	}
+	if got := conv.End.Sub(conv.Start); got != time.Minute {
+		t.Errorf("duration = %v, want 1m", got)
+	}
+
+	var kinds []string
+	for _, s := range conv.Steps {
+		kinds = append(kinds, s.Kind)
+	}
+	want := []string{KindSummary, KindText, KindThinking, KindText, KindToolUse, KindToolResult, KindText}
+	if strings.Join(kinds, ",") != strings.Join(want, ",") {
+		t.Fatalf("kinds = %v, want %v", kinds, want)
+	}
+
+	thinking := conv.Steps[2]
+	if thinking.Usage == nil || thinking.Usage.OutputTokens != 5 {
+		t.Errorf("usage should attach to first step of assistant message, got %+v", thinking.Usage)
+	}
+	result := conv.Steps[5]
+	if result.Text != "main.go" || !result.IsError || result.ToolID != "tu1" {
+		t.Errorf("tool result = %+v", result)
+	}
+	if conv.ToolCalls["Bash"] != 1 {
+		t.Errorf("tool calls = %v", conv.ToolCalls)
+	}
+	if conv.Usage.Total() != 142 {
+		t.Errorf("usage total = %d, want 142", conv.Usage.Total())
+	}
+}
+
+func TestArchiveFindOpen(t *testing.T) {
+	town := t.TempDir()
+	src := writeSource(t, t.TempDir(), "sess-1.jsonl")
+
+	entry, err := Archive(town, ArchiveOptions{
+		SessionID: "sess-1",
+		Agent:     "gastown/polecats/toast",
+		Bead:      "gt-abc",
+		Source:    src,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry.Role != "polecat" || entry.Messages != 4 || entry.Model != "claude-test" {
+		t.Errorf("entry = %+v", entry)
+	}
+	if entry.Usage.Total() != 142 {
+		t.Errorf("usage total = %d, want 142", entry.Usage.Total())
+	}
+	if entry.Redactions == 0 {
+		t.Error("expected the API key to be redacted")
+	}
+	if !strings.HasSuffix(entry.Path, ".jsonl.gz") {
+		t.Errorf("path = %q", entry.Path)
+	}
+
+	found, err := Find(town, "sess")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := Open(town, found)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := io.ReadAll(r)
+	_ = r.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "sk-ant-api03") {
+		t.Error("archived transcript contains the secret")
+	}
+	if !strings.Contains(string(data), "Please fix gt-abc") {
+		t.Error("archived transcript lost content")
+	}
+
+	if _, err := Find(town, "nope"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Find(nope) err = %v, want ErrNotFound", err)
+	}
+	if got, _ := List(town, Query{Bead: "gt-abc"}); len(got) != 1 {
+		t.Errorf("List by bead = %d entries, want 1", len(got))
+	}
+	if got, _ := List(town, Query{Agent: "gastown/polecats/other"}); len(got) != 0 {
+		t.Errorf("List by other agent = %d entries, want 0", len(got))
+	}
+}
+
+func TestArchiveReplacesSession(t *testing.T) {
+	town := t.TempDir()
+	src := writeSource(t, t.TempDir(), "sess-1.jsonl")
+	started := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+
+	if _, err := Archive(town, ArchiveOptions{SessionID: "sess-1", Agent: "mayor", Bead: "gt-abc", StartedAt: started, Source: src}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Archive(town, ArchiveOptions{SessionID: "sess-1", Agent: "mayor", Source: src}); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := List(town, Query{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("got %d entries, want 1", len(entries))
+	}
+	if entries[0].Bead != "gt-abc" || !entries[0].StartedAt.Equal(started) {
+		t.Errorf("re-archive lost bead/start: %+v", entries[0])
+	}
+}
+
+func TestFindAmbiguousPrefix(t *testing.T) {
+	town := t.TempDir()
+	src := writeSource(t, t.TempDir(), "s.jsonl")
+	for _, id := range []string{"abc-1", "abc-2"} {
+		if _, err := Archive(town, ArchiveOptions{SessionID: id, Agent: "mayor", Source: src}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := Find(town, "abc"); err == nil || errors.Is(err, ErrNotFound) {
+		t.Errorf("Find(abc) err = %v, want ambiguity error", err)
+	}
+	if e, err := Find(town, "abc-2"); err != nil || e.SessionID != "abc-2" {
+		t.Errorf("Find(abc-2) = %v, %v", e, err)
+	}
+}
+
+func TestPrune(t *testing.T) {
+	town := t.TempDir()
+	src := writeSource(t, t.TempDir(), "s.jsonl")
+	polecat, err := Archive(town, ArchiveOptions{SessionID: "p1", Agent: "gastown/polecats/toast", Source: src})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Archive(town, ArchiveOptions{SessionID: "m1", Agent: "mayor", Source: src}); err != nil {
+		t.Fatal(err)
+	}
+
+	ttl := func(role string) time.Duration {
+		if role == "polecat" {
+			return time.Hour
+		}
+		return 0 // keep forever
+	}
+	result, err := Prune(town, ttl, time.Now().Add(2*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Pruned != 1 || result.Retained != 1 || result.ByRole["polecat"] != 1 {
+		t.Errorf("result = %+v", result)
+	}
+	if result.BytesFreed == 0 {
+		t.Error("expected bytes freed")
+	}
+	if _, err := os.Stat(filepath.Join(ArchiveDir(town), polecat.Path)); !os.IsNotExist(err) {
+		t.Error("pruned archive file still exists")
+	}
+	if _, err := Find(town, "m1"); err != nil {
+		t.Errorf("mayor transcript should be retained: %v", err)
+	}
+}
+
+func TestEndSession(t *testing.T) {
+	town := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(town, "mayor"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(town, "mayor", "town.json"), []byte(`{}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(town)
+
+	configDir := t.TempDir()
+	t.Setenv("CLAUDE_CONFIG_DIR", configDir)
+	t.Setenv("HOME", t.TempDir())
+
+	workDir := "/work/gastown/polecats/toast"
+	writeSource(t, filepath.Join(configDir, "projects", projectDirName(workDir)), "sess-1.jsonl")
+
+	agent := "gastown/polecats/toast"
+	if err := events.LogFeed(events.TypeSessionStart, agent, events.SessionPayload("sess-1", agent, "", workDir)); err != nil {
+		t.Fatal(err)
+	}
+
+	archived := EndSession(town, agent, "gt-abc")
+	if len(archived) != 1 || archived[0].Bead != "gt-abc" {
+		t.Fatalf("archived = %+v", archived)
+	}
+
+	// The session_end marker means a second call archives nothing.
+	if again := EndSession(town, agent, "gt-abc"); len(again) != 0 {
+		t.Errorf("second EndSession archived %d sessions", len(again))
+	}
+	data, err := os.ReadFile(filepath.Join(town, events.EventsFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var end events.Event
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		var e events.Event
+		if json.Unmarshal([]byte(line), &e) == nil && e.Type == events.TypeSessionEnd {
+			end = e
+		}
+	}
+	if end.Payload["transcript"] == nil || end.Payload["bead"] != "gt-abc" {
+		t.Errorf("session_end payload = %v", end.Payload)
+	}
+
+	if readEndCursor(town, agent).IsZero() {
+		t.Error("EndSession should record the agent's cursor")
+	}
+
+	// A session that starts after the cursor is still picked up.
+	writeSource(t, filepath.Join(configDir, "projects", projectDirName(workDir)), "sess-2.jsonl")
+	if err := events.LogFeed(events.TypeSessionStart, agent, events.SessionPayload("sess-2", agent, "", workDir)); err != nil {
+		t.Fatal(err)
+	}
+	if next := EndSession(town, agent, ""); len(next) != 1 || next[0].SessionID != "sess-2" {
+		t.Errorf("EndSession after cursor archived %+v, want sess-2", next)
+	}
+}
+
+func TestLocateFallbackMatchesSessionID(t *testing.T) {
+	configDir := t.TempDir()
+	t.Setenv("CLAUDE_CONFIG_DIR", configDir)
+	t.Setenv("HOME", t.TempDir())
+
+	workDir := "/work/gastown/crew/max"
+	projDir := filepath.Join(configDir, "projects", projectDirName(workDir))
+	started := time.Now().Add(-time.Minute)
+
+	// Named after neither session; its records say sess-1.
+	match := writeSource(t, projDir, "resumed.jsonl")
+	// Newer, but another session's transcript.
+	other := filepath.Join(projDir, "other.jsonl")
+	if err := os.WriteFile(other, []byte(strings.ReplaceAll(sampleTranscript, "sess-1", "sess-2")), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(other, time.Now().Add(time.Minute), time.Now().Add(time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := Locate("", "sess-1", workDir, started); got != match {
+		t.Errorf("Locate(sess-1) = %q, want %q", got, match)
+	}
+	if got := Locate("", "sess-3", workDir, started); got != "" {
+		t.Errorf("Locate(sess-3) = %q, want no guess", got)
+	}
+}
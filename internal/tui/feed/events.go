@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/events"
 )
 
 // EventSource represents a source of events
@@ -257,12 +258,9 @@ func (s *GtEventsSource) tail(ctx context.Context) {
 	// Load recent events (last 200 lines) for initial display
 	s.loadRecentEvents()
 
-	// Seek to true EOF so the tail scanner starts cleanly,
-	// regardless of the preload scanner's internal read-ahead buffer.
-	_, _ = s.file.Seek(0, 2)
-
-	// Now tail for new events
-	scanner := bufio.NewScanner(s.file)
+	// Now tail for new events, following the active segment across rotation
+	tail := events.NewTailer(s.file.Name())
+	defer tail.Close()
 	ticker := time.NewTicker(100 * time.Millisecond)
 	defer ticker.Stop()
 
@@ -271,9 +269,12 @@ func (s *GtEventsSource) tail(ctx context.Context) {
 		case <-ctx.Done():
 			return
 		case <-ticker.C:
-			for scanner.Scan() {
-				line := scanner.Text()
-				if event := parseGtEventLine(line); event != nil {
+			for {
+				line, err := tail.Next()
+				if err != nil || line == nil {
+					break
+				}
+				if event := parseGtEventLine(string(line)); event != nil {
 					select {
 					case s.events <- *event:
 					default:
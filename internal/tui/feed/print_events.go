@@ -1,7 +1,6 @@
 package feed
 
 import (
-	"bufio"
 	"context"
 	"fmt"
 	"os"
@@ -10,6 +9,8 @@ import (
 	"sort"
 	"strings"
 	"time"
+
+	"github.com/steveyegge/gastown/internal/events"
 )
 
 // PrintOptions controls filtering and behavior for PrintGtEvents.
@@ -23,16 +24,22 @@ type PrintOptions struct {
 	Ctx    context.Context // optional: controls follow-mode lifecycle; nil uses signal.NotifyContext
 }
 
-// PrintGtEvents reads .events.jsonl and prints events to stdout.
-// When opts.Follow is true, it tails the file for new events after printing
-// the initial batch, polling every 200ms. Canceled via opts.Ctx or SIGINT.
+// PrintGtEvents reads .events.jsonl, including its closed segments, and
+// prints events to stdout. When opts.Follow is true, it tails the file for
+// new events after printing the initial batch, polling every 200ms.
+// Canceled via opts.Ctx or SIGINT.
 func PrintGtEvents(townRoot string, opts PrintOptions) error {
 	eventsPath := filepath.Join(townRoot, ".events.jsonl")
-	file, err := os.Open(eventsPath)
-	if err != nil {
+	if _, err := os.Stat(eventsPath); err != nil {
 		return fmt.Errorf("no events file found at %s: %w", eventsPath, err)
 	}
-	defer file.Close()
+	// Start following before reading history so nothing written in
+	// between is missed.
+	var tail *events.Tailer
+	if opts.Follow {
+		tail = events.NewTailer(eventsPath)
+		defer tail.Close()
+	}
 
 	// Parse --since into a cutoff time
 	var sinceTime time.Time
@@ -44,44 +51,40 @@ func PrintGtEvents(townRoot string, opts PrintOptions) error {
 		sinceTime = time.Now().Add(-dur)
 	}
 
-	var events []Event
-	scanner := bufio.NewScanner(file)
-	scanner.Buffer(make([]byte, 1024*1024), 1024*1024)
-
-	for scanner.Scan() {
-		line := scanner.Text()
-		if event := parseGtEventLine(line); event != nil {
+	var matched []Event
+	err := events.ReadLines(eventsPath, events.Query{Since: sinceTime}, func(line []byte) bool {
+		if event := parseGtEventLine(string(line)); event != nil {
 			if matchesFilters(event, sinceTime, opts.Mol, opts.Type, opts.Rig) {
-				events = append(events, *event)
+				matched = append(matched, *event)
 			}
 		}
-	}
-
-	if err := scanner.Err(); err != nil {
+		return true
+	})
+	if err != nil {
 		return fmt.Errorf("reading events: %w", err)
 	}
 
 	// Sort by time descending (most recent first)
-	sort.Slice(events, func(i, j int) bool {
-		return events[i].Time.After(events[j].Time)
+	sort.Slice(matched, func(i, j int) bool {
+		return matched[i].Time.After(matched[j].Time)
 	})
 
 	// Apply limit
-	if opts.Limit > 0 && len(events) > opts.Limit {
-		events = events[:opts.Limit]
+	if opts.Limit > 0 && len(matched) > opts.Limit {
+		matched = matched[:opts.Limit]
 	}
 
 	// Reverse to show oldest first (chronological)
-	for i, j := 0, len(events)-1; i < j; i, j = i+1, j-1 {
-		events[i], events[j] = events[j], events[i]
+	for i, j := 0, len(matched)-1; i < j; i, j = i+1, j-1 {
+		matched[i], matched[j] = matched[j], matched[i]
 	}
 
-	if len(events) == 0 && !opts.Follow {
+	if len(matched) == 0 && !opts.Follow {
 		fmt.Println("No events found in .events.jsonl")
 		return nil
 	}
 
-	for _, event := range events {
+	for _, event := range matched {
 		printEvent(event)
 	}
 
@@ -89,10 +92,7 @@ func PrintGtEvents(townRoot string, opts PrintOptions) error {
 		return nil
 	}
 
-	// Tail mode: poll for new lines using a fresh scanner each tick.
-	// bufio.Scanner sets an internal 'done' flag after EOF and won't retry,
-	// so we must create a new scanner each poll cycle while preserving the
-	// file offset (os.File tracks position across scanner instances).
+	// Tail mode: poll the tailer for lines appended since history was read.
 	ctx := opts.Ctx
 	if ctx == nil {
 		var stop context.CancelFunc
@@ -108,11 +108,12 @@ func PrintGtEvents(townRoot string, opts PrintOptions) error {
 		case <-ctx.Done():
 			return nil
 		case <-ticker.C:
-			s := bufio.NewScanner(file)
-			s.Buffer(make([]byte, 1024*1024), 1024*1024)
-			for s.Scan() {
-				line := s.Text()
-				if event := parseGtEventLine(line); event != nil {
+			for {
+				line, err := tail.Next()
+				if err != nil || line == nil {
+					break
+				}
+				if event := parseGtEventLine(string(line)); event != nil {
 					if matchesFilters(event, sinceTime, opts.Mol, opts.Type, opts.Rig) {
 						printEvent(*event)
 					}
@@ -102,6 +102,8 @@ func (h *APIHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		h.handleMailInbox(w, r)
 	case path == "/mail/threads" && r.Method == http.MethodGet:
 		h.handleMailThreads(w, r)
+	case path == "/mail/search" && r.Method == http.MethodGet:
+		h.handleMailSearch(w, r)
 	case path == "/mail/read" && r.Method == http.MethodGet:
 		h.handleMailRead(w, r)
 	case path == "/mail/send" && r.Method == http.MethodPost:
@@ -390,6 +392,52 @@ func (h *APIHandler) handleMailThreads(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// MailSearchResponse is the response for /api/mail/search.
+type MailSearchResponse struct {
+	Query    string        `json:"query"`
+	Messages []MailMessage `json:"messages"`
+	Total    int           `json:"total"`
+}
+
+// handleMailSearch searches the mail index. The q parameter uses the
+// `gt mail search` query syntax (from:, to:, thread:, before:, after:,
+// quoted phrases). Results are ranked by relevance.
+func (h *APIHandler) handleMailSearch(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query().Get("q")
+	const maxQueryLen = 500
+	if len(query) > maxQueryLen {
+		h.sendError(w, fmt.Sprintf("Query too long (max %d bytes)", maxQueryLen), http.StatusBadRequest)
+		return
+	}
+	if strings.Contains(query, "\x00") {
+		h.sendError(w, "Query cannot contain null bytes", http.StatusBadRequest)
+		return
+	}
+
+	// -- ends flag parsing so queries like "--help" are searched, not run.
+	output, err := h.runGtCommand(r.Context(), 15*time.Second, []string{"mail", "search", "--json", "--", query})
+	if err != nil {
+		h.sendError(w, "Failed to search mail: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var messages []MailMessage
+	if err := json.Unmarshal([]byte(output), &messages); err != nil {
+		h.sendError(w, "Failed to parse search results: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if messages == nil {
+		messages = []MailMessage{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(MailSearchResponse{
+		Query:    query,
+		Messages: messages,
+		Total:    len(messages),
+	})
+}
+
 // groupIntoThreads groups messages into conversation threads.
 // Messages are grouped by ThreadID when available, otherwise by ReplyTo chain,
 // and finally by subject similarity as a fallback.
@@ -16,6 +16,7 @@ import (
 	"github.com/steveyegge/gastown/internal/activity"
 	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/events"
 	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/workspace"
 )
@@ -1583,49 +1584,38 @@ func (f *LiveConvoyFetcher) FetchIssues() ([]IssueRow, error) {
 	return rows, nil
 }
 
-// FetchActivity returns recent activity from the event log.
-func (f *LiveConvoyFetcher) FetchActivity() ([]ActivityRow, error) {
-	eventsPath := filepath.Join(f.townRoot, ".events.jsonl")
-
-	// Read events file
-	data, err := os.ReadFile(eventsPath)
-	if err != nil {
-		return nil, nil // No events file
-	}
+// activityWindow bounds how far back FetchActivity looks for events.
+const activityWindow = 7 * 24 * time.Hour
 
-	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
-	if len(lines) == 0 {
-		return nil, nil
-	}
+// activityLimit is the number of events FetchActivity returns.
+const activityLimit = 50
 
-	// Take last 50 events for richer timeline
-	start := 0
-	if len(lines) > 50 {
-		start = len(lines) - 50
-	}
-
-	var rows []ActivityRow
-	for i := len(lines) - 1; i >= start; i-- {
-		line := lines[i]
-		if line == "" {
-			continue
-		}
+// FetchActivity returns recent activity from the event log, newest first.
+func (f *LiveConvoyFetcher) FetchActivity() ([]ActivityRow, error) {
+	eventsPath := filepath.Join(f.townRoot, events.EventsFile)
 
-		var event struct {
-			Timestamp  string                 `json:"ts"`
-			Type       string                 `json:"type"`
-			Actor      string                 `json:"actor"`
-			Payload    map[string]interface{} `json:"payload"`
-			Visibility string                 `json:"visibility"`
+	// Keep the last activityLimit events for a richer timeline, reading
+	// closed segments too so a recent rotation doesn't empty it.
+	var recent []events.Event
+	q := events.Query{Since: time.Now().Add(-activityWindow)}
+	err := events.Read(eventsPath, q, func(e events.Event) bool {
+		// Skip audit-only events
+		if e.Visibility == events.VisibilityAudit {
+			return true
 		}
-		if err := json.Unmarshal([]byte(line), &event); err != nil {
-			continue
+		recent = append(recent, e)
+		if len(recent) > activityLimit {
+			recent = recent[1:]
 		}
+		return true
+	})
+	if err != nil {
+		return nil, nil // No readable events log
+	}
 
-		// Skip audit-only events
-		if event.Visibility == "audit" {
-			continue
-		}
+	var rows []ActivityRow
+	for i := len(recent) - 1; i >= 0; i-- {
+		event := recent[i]
 
 		row := ActivityRow{
 			Type:         event.Type,
@@ -0,0 +1,56 @@
+package web
+
+import (
+	"bytes"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/redact"
+)
+
+// redactHandler scrubs secrets from dashboard and API responses. Agent
+// output (mail bodies, command output, pane captures) reaches the browser
+// through these, so the response is buffered and redacted as a whole.
+// The SSE stream and embedded static assets pass through untouched.
+type redactHandler struct {
+	next     http.Handler
+	townRoot string
+}
+
+func newRedactHandler(next http.Handler, townRoot string) http.Handler {
+	return &redactHandler{next: next, townRoot: townRoot}
+}
+
+func (h *redactHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/api/events" || strings.HasPrefix(r.URL.Path, "/static/") {
+		h.next.ServeHTTP(w, r)
+		return
+	}
+
+	buf := &bufferedResponse{header: make(http.Header), status: http.StatusOK}
+	h.next.ServeHTTP(buf, r)
+
+	body, counts := redact.ForTown(h.townRoot).String(buf.body.String())
+	_ = redact.Record(h.townRoot, redact.SinkWeb, counts)
+
+	for k, v := range buf.header {
+		w.Header()[k] = v
+	}
+	if w.Header().Get("Content-Length") != "" {
+		w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	}
+	w.WriteHeader(buf.status)
+	_, _ = w.Write([]byte(body))
+}
+
+// bufferedResponse collects a response so it can be rewritten.
+type bufferedResponse struct {
+	header http.Header
+	body   bytes.Buffer
+	status int
+}
+
+func (b *bufferedResponse) Header() http.Header         { return b.header }
+func (b *bufferedResponse) Write(p []byte) (int, error) { return b.body.Write(p) }
+func (b *bufferedResponse) WriteHeader(status int)      { b.status = status }
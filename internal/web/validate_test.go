@@ -315,6 +315,18 @@ func TestHandler_MailSend_OversizedSubject(t *testing.T) {
 	}
 }
 
+func TestHandler_MailSearch_OversizedQuery(t *testing.T) {
+	handler := NewAPIHandler(30*time.Second, 60*time.Second)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/mail/search?q="+strings.Repeat("x", 501), nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("GET /api/mail/search oversized query status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+}
+
 func TestHandler_IssueShow_InvalidID(t *testing.T) {
 	handler := NewAPIHandler(30*time.Second, 60*time.Second)
 
@@ -10,6 +10,8 @@ import (
 	"time"
 
 	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/deacon"
+	"github.com/steveyegge/gastown/internal/events"
 	"github.com/steveyegge/gastown/internal/git"
 	"github.com/steveyegge/gastown/internal/mail"
 	"github.com/steveyegge/gastown/internal/rig"
@@ -28,6 +30,14 @@ import (
 // tmux output (tool calls, status updates). 30 minutes of silence is abnormal.
 const HungSessionThresholdMinutes = 30
 
+// Done-intent timing: a polecat whose session is still alive this long after
+// starting gt done is stuck in it; one whose session is dead is given this
+// grace before it's treated as a zombie.
+const (
+	stuckDoneIntentTimeout = 60 * time.Second
+	deadDoneIntentGrace    = 30 * time.Second
+)
+
 // initRegistryFromWorkDir initializes the session prefix and agent registries
 // from a work directory. This ensures session.PrefixFor(rigName) returns the
 // correct rig prefix (e.g., "tr" for testrig) instead of the default "gt",
@@ -974,7 +984,24 @@ type NukePolecatResult struct {
 // An orphan is likely from a crash before gt done completed.
 // Returns whether the nuke was performed and any error.
 func AutoNukeIfClean(workDir, rigName, polecatName string) *NukePolecatResult {
+	return autoNuke(workDir, rigName, polecatName, false)
+}
+
+// autoNuke implements AutoNukeIfClean. With dryRun it makes the same
+// read-only checks but only reports the nuke it would perform.
+func autoNuke(workDir, rigName, polecatName string, dryRun bool) *NukePolecatResult {
 	result := &NukePolecatResult{}
+	nuke := func(reason string) {
+		if !dryRun {
+			if err := NukePolecat(workDir, rigName, polecatName); err != nil {
+				result.Error = err
+				result.Reason = fmt.Sprintf("nuke failed: %v", err)
+				return
+			}
+		}
+		result.Nuked = true
+		result.Reason = reason
+	}
 
 	// Check cleanup_status from agent bead
 	cleanupStatus := getCleanupStatus(workDir, rigName, polecatName)
@@ -982,13 +1009,7 @@ func AutoNukeIfClean(workDir, rigName, polecatName string) *NukePolecatResult {
 	switch cleanupStatus {
 	case "clean":
 		// Safe to nuke
-		if err := NukePolecat(workDir, rigName, polecatName); err != nil {
-			result.Error = err
-			result.Reason = fmt.Sprintf("nuke failed: %v", err)
-		} else {
-			result.Nuked = true
-			result.Reason = "auto-nuked (cleanup_status=clean, no MR)"
-		}
+		nuke("auto-nuked (cleanup_status=clean, no MR)")
 
 	case "has_uncommitted", "has_stash", "has_unpushed":
 		// Not safe - has work that could be lost
@@ -1004,13 +1025,7 @@ func AutoNukeIfClean(workDir, rigName, polecatName string) *NukePolecatResult {
 			result.Reason = fmt.Sprintf("skipped: couldn't verify git state: %v", err)
 		} else if onMain {
 			// Commit is on main, likely safe
-			if err := NukePolecat(workDir, rigName, polecatName); err != nil {
-				result.Error = err
-				result.Reason = fmt.Sprintf("nuke failed: %v", err)
-			} else {
-				result.Nuked = true
-				result.Reason = "auto-nuked (commit on main, no cleanup_status)"
-			}
+			nuke("auto-nuked (commit on main, no cleanup_status)")
 		} else {
 			// Not on main - skip, might have unpushed work
 			result.Skipped = true
@@ -1109,6 +1124,10 @@ type DetectZombiePolecatsResult struct {
 	Checked int
 	Zombies []ZombieResult
 	Errors  []error // Transient errors that prevented checking some polecats
+
+	// Shadow is true when patrol shadow mode was on: Zombies describes the
+	// actions that would have been taken, and none were.
+	Shadow bool
 }
 
 // DetectZombiePolecats cross-references polecat agent state with tmux session
@@ -1149,6 +1168,8 @@ func DetectZombiePolecats(workDir, rigName string, router *mail.Router) *DetectZ
 	}
 
 	t := tmux.NewTmux()
+	act := zombieActor{workDir: workDir, rigName: rigName, router: router, shadow: deacon.InShadow(townRoot)}
+	result.Shadow = act.shadow
 
 	for _, entry := range entries {
 		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
@@ -1174,7 +1195,7 @@ func DetectZombiePolecats(workDir, rigName string, router *mail.Router) *DetectZ
 		doneIntent := extractDoneIntent(labels)
 
 		if sessionAlive {
-			if zombie, found := detectZombieLiveSession(workDir, rigName, polecatName, agentBeadID, sessionName, t, doneIntent, router); found {
+			if zombie, found := detectZombieLiveSession(workDir, rigName, polecatName, agentBeadID, sessionName, t, doneIntent, act); found {
 				result.Zombies = append(result.Zombies, zombie)
 			}
 
@@ -1191,12 +1212,12 @@ func DetectZombiePolecats(workDir, rigName string, router *mail.Router) *DetectZ
 					HookBead:    deadAgentHookBead,
 					Action:      "killed-agent-dead-session",
 				}
-				if err := NukePolecat(workDir, rigName, polecatName); err != nil {
+				if err := act.nuke(polecatName); err != nil {
 					zombie.Error = err
 					zombie.Action = fmt.Sprintf("kill-agent-dead-session-failed: %v", err)
 				}
 				// Reset abandoned bead for re-dispatch (gt-c3lgp)
-				zombie.BeadRecovered = resetAbandonedBead(workDir, rigName, deadAgentHookBead, polecatName, router)
+				zombie.BeadRecovered = act.resetBead(deadAgentHookBead, polecatName)
 				result.Zombies = append(result.Zombies, zombie)
 			} else {
 				// Agent is alive. Check if the hooked bead has been closed.
@@ -1210,7 +1231,7 @@ func DetectZombiePolecats(workDir, rigName string, router *mail.Router) *DetectZ
 						HookBead:    hookBead,
 						Action:      "nuke-bead-closed-polecat",
 					}
-					if err := NukePolecat(workDir, rigName, polecatName); err != nil {
+					if err := act.nuke(polecatName); err != nil {
 						zombie.Error = err
 						zombie.Action = fmt.Sprintf("nuke-bead-closed-failed: %v", err)
 					}
@@ -1231,11 +1252,11 @@ func DetectZombiePolecats(workDir, rigName string, router *mail.Router) *DetectZ
 								HookBead:    hungHookBead,
 								Action:      fmt.Sprintf("killed-hung-session (inactive %dm)", inactiveMinutes),
 							}
-							if err := NukePolecat(workDir, rigName, polecatName); err != nil {
+							if err := act.nuke(polecatName); err != nil {
 								zombie.Error = err
 								zombie.Action = fmt.Sprintf("kill-hung-session-failed: %v", err)
 							}
-							zombie.BeadRecovered = resetAbandonedBead(workDir, rigName, hungHookBead, polecatName, router)
+							zombie.BeadRecovered = act.resetBead(hungHookBead, polecatName)
 							result.Zombies = append(result.Zombies, zombie)
 						}
 					}
@@ -1244,19 +1265,24 @@ func DetectZombiePolecats(workDir, rigName string, router *mail.Router) *DetectZ
 			continue // Either handled or not a zombie
 		}
 
-		if zombie, found := detectZombieDeadSession(workDir, rigName, polecatName, agentBeadID, sessionName, t, doneIntent, detectedAt, router); found {
+		if zombie, found := detectZombieDeadSession(workDir, rigName, polecatName, agentBeadID, sessionName, t, doneIntent, detectedAt, act); found {
 			result.Zombies = append(result.Zombies, zombie)
 		}
 	}
 
+	for _, zombie := range result.Zombies {
+		receipt := BuildZombieReceipt(rigName, zombie)
+		receipt.Shadow = act.shadow
+		_ = events.LogReceipt(townRoot, rigName+"/witness", receipt)
+	}
 	return result
 }
 
 // detectZombieLiveSession checks a polecat with a live tmux session for zombie indicators:
 // stuck done-intent, dead agent process, or closed bead while still running.
-func detectZombieLiveSession(workDir, rigName, polecatName, agentBeadID, sessionName string, t *tmux.Tmux, doneIntent *DoneIntent, router *mail.Router) (ZombieResult, bool) {
+func detectZombieLiveSession(workDir, rigName, polecatName, agentBeadID, sessionName string, t *tmux.Tmux, doneIntent *DoneIntent, act zombieActor) (ZombieResult, bool) {
 	// Check for done-intent stuck too long (polecat hung in gt done).
-	if doneIntent != nil && time.Since(doneIntent.Timestamp) > 60*time.Second {
+	if doneIntent != nil && time.Since(doneIntent.Timestamp) > stuckDoneIntentTimeout {
 		_, stuckHookBead := getAgentBeadState(workDir, agentBeadID)
 		zombie := ZombieResult{
 			PolecatName: polecatName,
@@ -1264,11 +1290,11 @@ func detectZombieLiveSession(workDir, rigName, polecatName, agentBeadID, session
 			HookBead:    stuckHookBead,
 			Action:      fmt.Sprintf("killed-stuck-session (done-intent age=%v)", time.Since(doneIntent.Timestamp).Round(time.Second)),
 		}
-		if err := NukePolecat(workDir, rigName, polecatName); err != nil {
+		if err := act.nuke(polecatName); err != nil {
 			zombie.Error = err
 			zombie.Action = fmt.Sprintf("kill-stuck-session-failed: %v", err)
 		}
-		zombie.BeadRecovered = resetAbandonedBead(workDir, rigName, stuckHookBead, polecatName, router)
+		zombie.BeadRecovered = act.resetBead(stuckHookBead, polecatName)
 		return zombie, true
 	}
 
@@ -1281,11 +1307,11 @@ func detectZombieLiveSession(workDir, rigName, polecatName, agentBeadID, session
 			HookBead:    deadAgentHookBead,
 			Action:      "killed-agent-dead-session",
 		}
-		if err := NukePolecat(workDir, rigName, polecatName); err != nil {
+		if err := act.nuke(polecatName); err != nil {
 			zombie.Error = err
 			zombie.Action = fmt.Sprintf("kill-agent-dead-session-failed: %v", err)
 		}
-		zombie.BeadRecovered = resetAbandonedBead(workDir, rigName, deadAgentHookBead, polecatName, router)
+		zombie.BeadRecovered = act.resetBead(deadAgentHookBead, polecatName)
 		return zombie, true
 	}
 
@@ -1298,7 +1324,7 @@ func detectZombieLiveSession(workDir, rigName, polecatName, agentBeadID, session
 			HookBead:    hookBead,
 			Action:      "nuke-bead-closed-polecat",
 		}
-		if err := NukePolecat(workDir, rigName, polecatName); err != nil {
+		if err := act.nuke(polecatName); err != nil {
 			zombie.Error = err
 			zombie.Action = fmt.Sprintf("nuke-bead-closed-failed: %v", err)
 		}
@@ -1310,11 +1336,11 @@ func detectZombieLiveSession(workDir, rigName, polecatName, agentBeadID, session
 
 // detectZombieDeadSession checks a polecat with a dead tmux session for zombie indicators:
 // stale done-intent, or active agent state / hooked bead with no session.
-func detectZombieDeadSession(workDir, rigName, polecatName, agentBeadID, sessionName string, t *tmux.Tmux, doneIntent *DoneIntent, detectedAt time.Time, router *mail.Router) (ZombieResult, bool) {
+func detectZombieDeadSession(workDir, rigName, polecatName, agentBeadID, sessionName string, t *tmux.Tmux, doneIntent *DoneIntent, detectedAt time.Time, act zombieActor) (ZombieResult, bool) {
 	// Done-intent: polecat was trying to exit.
 	if doneIntent != nil {
 		age := time.Since(doneIntent.Timestamp)
-		if age < 30*time.Second {
+		if age < deadDoneIntentGrace {
 			return ZombieResult{}, false // Recent — still working through gt done
 		}
 		_, diHookBead := getAgentBeadState(workDir, agentBeadID)
@@ -1324,11 +1350,11 @@ func detectZombieDeadSession(workDir, rigName, polecatName, agentBeadID, session
 			HookBead:    diHookBead,
 			Action:      fmt.Sprintf("auto-nuked (done-intent age=%v, type=%s)", age.Round(time.Second), doneIntent.ExitType),
 		}
-		if err := NukePolecat(workDir, rigName, polecatName); err != nil {
+		if err := act.nuke(polecatName); err != nil {
 			zombie.Error = err
 			zombie.Action = fmt.Sprintf("nuke-failed (done-intent): %v", err)
 		}
-		zombie.BeadRecovered = resetAbandonedBead(workDir, rigName, diHookBead, polecatName, router)
+		zombie.BeadRecovered = act.resetBead(diHookBead, polecatName)
 		return zombie, true
 	}
 
@@ -1350,8 +1376,8 @@ func detectZombieDeadSession(workDir, rigName, polecatName, agentBeadID, session
 	}
 
 	cleanupStatus := getCleanupStatus(workDir, rigName, polecatName)
-	handleZombieCleanup(workDir, rigName, polecatName, hookBead, cleanupStatus, router, &zombie)
-	zombie.BeadRecovered = resetAbandonedBead(workDir, rigName, hookBead, polecatName, router)
+	act.cleanup(polecatName, hookBead, cleanupStatus, &zombie)
+	zombie.BeadRecovered = act.resetBead(hookBead, polecatName)
 	return zombie, true
 }
 
@@ -1363,21 +1389,54 @@ func isZombieState(agentState, hookBead string) bool {
 	return agentState == "working" || agentState == "running" || agentState == "spawning"
 }
 
+// zombieActor carries out the actions zombie detection decides on. In
+// patrol shadow mode it leaves the polecat alone and only fills in the
+// action that would have been taken.
+type zombieActor struct {
+	workDir string
+	rigName string
+	router  *mail.Router
+	shadow  bool
+}
+
+func (a zombieActor) nuke(polecatName string) error {
+	if a.shadow {
+		return nil
+	}
+	return NukePolecat(a.workDir, a.rigName, polecatName)
+}
+
+func (a zombieActor) resetBead(hookBead, polecatName string) bool {
+	if a.shadow {
+		return false
+	}
+	return resetAbandonedBead(a.workDir, a.rigName, hookBead, polecatName, a.router)
+}
+
+func (a zombieActor) cleanup(polecatName, hookBead, cleanupStatus string, zombie *ZombieResult) {
+	handleZombieCleanup(a.workDir, a.rigName, polecatName, hookBead, cleanupStatus, a.router, zombie, a.shadow)
+}
+
 // handleZombieCleanup determines the cleanup action for a confirmed zombie based on
 // its cleanup_status. Clean or empty status → auto-nuke. Dirty status → escalate.
-func handleZombieCleanup(workDir, rigName, polecatName, hookBead, cleanupStatus string, router *mail.Router, zombie *ZombieResult) {
+// In shadow mode it runs the same checks but nukes, escalates and files nothing.
+func handleZombieCleanup(workDir, rigName, polecatName, hookBead, cleanupStatus string, router *mail.Router, zombie *ZombieResult, shadow bool) {
 	switch cleanupStatus {
 	case "clean", "":
 		// Clean state or no cleanup info — try auto-nuke.
 		// Empty status means polecat crashed before gt done; AutoNukeIfClean
 		// uses verifyCommitOnMain as fallback.
-		nukeResult := AutoNukeIfClean(workDir, rigName, polecatName)
+		nukeResult := autoNuke(workDir, rigName, polecatName, shadow)
 		if nukeResult.Nuked {
 			zombie.Action = "auto-nuked"
 		} else if nukeResult.Skipped {
-			wispID, wispErr := createCleanupWisp(workDir, polecatName, hookBead, "")
-			if wispErr != nil {
-				zombie.Error = wispErr
+			var wispID string
+			if !shadow {
+				var wispErr error
+				wispID, wispErr = createCleanupWisp(workDir, polecatName, hookBead, "")
+				if wispErr != nil {
+					zombie.Error = wispErr
+				}
 			}
 			zombie.Action = fmt.Sprintf("cleanup-wisp-created:%s (skip reason: %s)", wispID, nukeResult.Reason)
 		} else if nukeResult.Error != nil {
@@ -1392,6 +1451,10 @@ func handleZombieCleanup(workDir, rigName, polecatName, hookBead, cleanupStatus
 			zombie.Action = fmt.Sprintf("already-tracked (cleanup_status=%s, existing-wisp=%s)", cleanupStatus, existingWisp)
 			return
 		}
+		if shadow {
+			zombie.Action = fmt.Sprintf("escalated (cleanup_status=%s, wisp=)", cleanupStatus)
+			return
+		}
 		if router != nil {
 			_, escErr := EscalateRecoveryNeeded(router, rigName, &RecoveryPayload{
 				PolecatName:   polecatName,
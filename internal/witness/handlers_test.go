@@ -3,6 +3,7 @@ package witness
 import (
 	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"runtime"
 	"strings"
@@ -1366,3 +1367,68 @@ Tried: Manual rebase`,
 	}
 }
 
+// TestZombieCleanup_ShadowChecksCommitOnMain verifies that in patrol shadow
+// mode zombie cleanup makes the same verifyCommitOnMain check as the real
+// auto-nuke, reporting a nuke only when the commit is on main, and that it
+// removes, files and escalates nothing.
+func TestZombieCleanup_ShadowChecksCommitOnMain(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("test uses a Unix shell script mock for bd")
+	}
+	binDir := t.TempDir()
+	bdLog := filepath.Join(binDir, "bd.log")
+	script := "#!/bin/sh\necho \"$@\" >> \"" + bdLog + "\"\nexit 1\n"
+	if err := os.WriteFile(filepath.Join(binDir, "bd"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	townRoot := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(townRoot, "mayor"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	polecatPath := filepath.Join(townRoot, "testrig", "polecats", "nux", "testrig")
+	if err := os.MkdirAll(polecatPath, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	gitRun := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-c", "user.name=t", "-c", "user.email=t@t"}, args...)...)
+		cmd.Dir = polecatPath
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	gitRun("init", "-q", "-b", "main")
+	gitRun("commit", "-q", "--allow-empty", "-m", "merged")
+
+	act := zombieActor{workDir: townRoot, rigName: "testrig", shadow: true}
+
+	var merged ZombieResult
+	act.cleanup("nux", "gt-work", "", &merged)
+	if merged.Action != "auto-nuked" || merged.Error != nil {
+		t.Errorf("commit on main: Action = %q, Error = %v, want auto-nuked", merged.Action, merged.Error)
+	}
+	if _, err := os.Stat(polecatPath); err != nil {
+		t.Errorf("shadow cleanup removed the polecat: %v", err)
+	}
+
+	gitRun("checkout", "-q", "-b", "polecat/nux")
+	gitRun("commit", "-q", "--allow-empty", "-m", "unmerged")
+	var unmerged ZombieResult
+	act.cleanup("nux", "gt-work", "", &unmerged)
+	if !strings.HasPrefix(unmerged.Action, "cleanup-wisp-created:") || !strings.Contains(unmerged.Action, "commit not on main") {
+		t.Errorf("commit off main: Action = %q, want cleanup wisp for unpushed work", unmerged.Action)
+	}
+
+	var dirty ZombieResult
+	act.cleanup("nux", "gt-work", "has_unpushed", &dirty)
+	if !strings.HasPrefix(dirty.Action, "escalated (cleanup_status=has_unpushed") {
+		t.Errorf("dirty: Action = %q, want escalated", dirty.Action)
+	}
+
+	data, _ := os.ReadFile(bdLog)
+	if strings.Contains(string(data), "create") {
+		t.Errorf("shadow cleanup filed beads: %s", data)
+	}
+}
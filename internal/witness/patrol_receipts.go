@@ -1,6 +1,12 @@
 package witness
 
-import "strings"
+import (
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/session"
+)
 
 // PatrolVerdict classifies witness patrol outcomes for machine consumers.
 type PatrolVerdict string
@@ -81,3 +87,62 @@ func BuildPatrolReceipts(rigName string, result *DetectZombiePolecatsResult) []P
 	}
 	return receipts
 }
+
+// zombieReasons describes, for each zombie class DetectZombiePolecats
+// reports, the rule that classified it.
+var zombieReasons = map[string]string{
+	"stuck-in-done":             "session still running after done-intent timeout",
+	"done-intent-dead":          "session dead after done-intent grace period",
+	"agent-dead-in-session":     "tmux session alive but agent process dead",
+	"bead-closed-still-running": "hooked bead closed but session still running",
+	"agent-hung":                "no session output within hung-session threshold",
+}
+
+// zombieThresholds returns the thresholds the rule for a zombie class applied.
+func zombieThresholds(agentState string) map[string]interface{} {
+	switch agentState {
+	case "stuck-in-done":
+		return map[string]interface{}{"done_intent_timeout": stuckDoneIntentTimeout.String()}
+	case "done-intent-dead":
+		return map[string]interface{}{"done_intent_grace": deadDoneIntentGrace.String()}
+	case "agent-hung":
+		return map[string]interface{}{"hung_session_minutes": HungSessionThresholdMinutes}
+	}
+	return nil
+}
+
+// BuildZombieReceipt explains a zombie patrol action as a decision receipt
+// for the events log.
+func BuildZombieReceipt(rigName string, z ZombieResult) events.Receipt {
+	action := strings.TrimSpace(z.Action)
+	if action == "" {
+		action = "none"
+	}
+	reason, ok := zombieReasons[z.AgentState]
+	if !ok {
+		reason = fmt.Sprintf("session dead with agent_state=%q", z.AgentState)
+		if z.HookBead != "" {
+			reason += " and hooked work"
+		}
+	}
+
+	r := events.Receipt{
+		Agent:   rigName + "/polecats/" + z.PolecatName,
+		Session: session.PolecatSessionName(session.PrefixFor(rigName), z.PolecatName),
+		Bead:    z.HookBead,
+		Rule:    "zombie_polecat",
+		Inputs: map[string]interface{}{
+			"agent_state":    z.AgentState,
+			"verdict":        string(receiptVerdictForZombie(z)),
+			"bead_recovered": z.BeadRecovered,
+		},
+		Thresholds: zombieThresholds(z.AgentState),
+		Decision:   z.AgentState,
+		Action:     action,
+		Reason:     reason,
+	}
+	if z.Error != nil {
+		r.Error = z.Error.Error()
+	}
+	return r
+}
@@ -172,3 +172,44 @@ func TestBuildPatrolReceipts_DeterministicStaleOrphanOrdering(t *testing.T) {
 		t.Fatalf("second receipt = %+v, want polecat=echo verdict=%q", receipts[1], PatrolVerdictOrphan)
 	}
 }
+
+func TestBuildZombieReceipt_HungSession(t *testing.T) {
+	receipt := BuildZombieReceipt("gastown", ZombieResult{
+		PolecatName:   "max",
+		AgentState:    "agent-hung",
+		HookBead:      "gt-abc123",
+		Action:        "killed-hung-session (inactive 45m)",
+		BeadRecovered: true,
+	})
+
+	if receipt.Agent != "gastown/polecats/max" || receipt.Rule != "zombie_polecat" {
+		t.Errorf("subject = %q rule = %q", receipt.Agent, receipt.Rule)
+	}
+	if receipt.Decision != "agent-hung" || receipt.Action != "killed-hung-session (inactive 45m)" {
+		t.Errorf("decision = %q action = %q", receipt.Decision, receipt.Action)
+	}
+	if receipt.Thresholds["hung_session_minutes"] != HungSessionThresholdMinutes {
+		t.Errorf("thresholds = %v", receipt.Thresholds)
+	}
+	if receipt.Inputs["verdict"] != string(PatrolVerdictStale) || receipt.Inputs["bead_recovered"] != true {
+		t.Errorf("inputs = %v", receipt.Inputs)
+	}
+	if !receipt.Concerns("max") || !receipt.Concerns("gt-abc123") {
+		t.Error("receipt should concern the polecat and its hooked bead")
+	}
+}
+
+func TestBuildZombieReceipt_DeadSessionWithError(t *testing.T) {
+	receipt := BuildZombieReceipt("gastown", ZombieResult{
+		PolecatName: "nux",
+		AgentState:  "working",
+		Error:       errors.New("nuke failed"),
+	})
+
+	if receipt.Action != "none" || receipt.Error != "nuke failed" {
+		t.Errorf("action = %q error = %q", receipt.Action, receipt.Error)
+	}
+	if receipt.Reason == "" || receipt.Thresholds != nil {
+		t.Errorf("reason = %q thresholds = %v", receipt.Reason, receipt.Thresholds)
+	}
+}